@@ -0,0 +1,307 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/provider"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+// Environment variables holding the object IDs of the shared prerequisite objects. When these are all set prior to a
+// test run, the specified objects are used instead of provisioning new ones, and they are not deleted afterwards.
+const (
+	SharedUserIdsEnvVar            = "ARM_TEST_SHARED_USER_IDS"
+	SharedApplicationIdEnvVar      = "ARM_TEST_SHARED_APPLICATION_OBJECT_ID"
+	SharedServicePrincipalIdEnvVar = "ARM_TEST_SHARED_SERVICE_PRINCIPAL_OBJECT_ID"
+)
+
+// sharedUsersCount is the number of shared users, which should be sufficient for tests needing several principals
+const sharedUsersCount = 3
+
+type sharedObjects struct {
+	UserIds            []string
+	ApplicationId      string
+	ServicePrincipalId string
+
+	// provisioned indicates that the objects were created during this test run, and should be deleted afterwards
+	provisioned bool
+}
+
+var shared struct {
+	once    sync.Once
+	client  *clients.Client
+	objects *sharedObjects
+}
+
+// RunWithSharedPrerequisites runs the tests for a package, deleting any shared prerequisite objects provisioned during
+// the test run once all tests have completed. It should be called from TestMain in packages using SharedPrerequisites.
+func RunWithSharedPrerequisites(m *testing.M) int {
+	code := m.Run()
+	destroySharedObjects()
+	return code
+}
+
+// SharedPrerequisites returns configuration declaring the following locals, which refer to prerequisite objects for
+// tests which reference, but do not modify, them:
+//
+// - `shared_user_ids`: the object IDs of three users
+// - `shared_application_object_id`: the object ID of an application
+// - `shared_service_principal_object_id`: the object ID of a service principal for the shared application
+//
+// These objects are provisioned once per test run, or are specified using environment variables, in which case they are
+// checked once to ensure they exist. When they are not available, for example when provisioning failed or the specified
+// objects were deleted by an earlier test run, the returned configuration instead creates equivalent objects for this
+// test only.
+func (td TestData) SharedPrerequisites() string {
+	// Test configurations are built even when acceptance tests are skipped, in which case nothing should be provisioned
+	if os.Getenv("TF_ACC") == "" {
+		return td.sharedPrerequisitesFallbackConfig()
+	}
+
+	if objects := ensureSharedObjects(); objects != nil {
+		return sharedPrerequisitesConfig(objects)
+	}
+
+	log.Printf("[DEBUG] Shared prerequisite objects are not available - creating prerequisite objects for this test")
+	return td.sharedPrerequisitesFallbackConfig()
+}
+
+func sharedPrerequisitesConfig(objects *sharedObjects) string {
+	userIds := make([]string, 0, len(objects.UserIds))
+	for _, id := range objects.UserIds {
+		userIds = append(userIds, fmt.Sprintf("%q", id))
+	}
+
+	return fmt.Sprintf(`
+locals {
+  shared_user_ids                    = [%[1]s]
+  shared_application_object_id       = %[2]q
+  shared_service_principal_object_id = %[3]q
+}
+`, strings.Join(userIds, ", "), objects.ApplicationId, objects.ServicePrincipalId)
+}
+
+func (td TestData) sharedPrerequisitesFallbackConfig() string {
+	return fmt.Sprintf(`
+data "azuread_domains" "shared" {
+  only_initial = true
+}
+
+resource "azuread_user" "shared" {
+  count = %[3]d
+
+  user_principal_name = "acctestUser.%[1]d.${count.index}@${data.azuread_domains.shared.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-${count.index}"
+  password            = "%[2]s"
+}
+
+resource "azuread_application" "shared" {
+  display_name = "acctestShared-%[1]d"
+}
+
+resource "azuread_service_principal" "shared" {
+  application_id = azuread_application.shared.application_id
+}
+
+locals {
+  shared_user_ids                    = azuread_user.shared.*.object_id
+  shared_application_object_id       = azuread_application.shared.object_id
+  shared_service_principal_object_id = azuread_service_principal.shared.object_id
+}
+`, td.RandomInteger, td.RandomPassword, sharedUsersCount)
+}
+
+// ensureSharedObjects returns the shared prerequisite objects, provisioning them the first time it's called during a
+// test run. Returns nil when the objects could not be provisioned, or when the objects specified using environment
+// variables do not exist.
+func ensureSharedObjects() *sharedObjects {
+	shared.once.Do(func() {
+		ctx := context.Background()
+
+		p := provider.AzureADProvider()
+		if diags := p.Configure(ctx, terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+			log.Printf("[WARN] Configuring client for shared prerequisite objects: %+v", diags)
+			return
+		}
+		shared.client = p.Meta().(*clients.Client)
+
+		if objects := sharedObjectsFromEnvironment(); objects != nil {
+			if sharedObjectsExist(objects) {
+				shared.objects = objects
+			}
+			return
+		}
+
+		objects, err := provisionSharedObjects(ctx, shared.client)
+		if objects != nil {
+			// Record any objects created, so they are cleaned up even when provisioning did not complete
+			shared.objects = objects
+		}
+		if err != nil {
+			log.Printf("[WARN] Provisioning shared prerequisite objects: %v", err)
+			destroySharedObjects()
+			return
+		}
+
+		os.Setenv(SharedUserIdsEnvVar, strings.Join(objects.UserIds, ","))
+		os.Setenv(SharedApplicationIdEnvVar, objects.ApplicationId)
+		os.Setenv(SharedServicePrincipalIdEnvVar, objects.ServicePrincipalId)
+	})
+
+	return shared.objects
+}
+
+// sharedObjectsFromEnvironment returns the shared prerequisite objects specified with environment variables, or nil
+// when they are not all specified
+func sharedObjectsFromEnvironment() *sharedObjects {
+	userIds, applicationId, servicePrincipalId := os.Getenv(SharedUserIdsEnvVar), os.Getenv(SharedApplicationIdEnvVar), os.Getenv(SharedServicePrincipalIdEnvVar)
+	if userIds == "" || applicationId == "" || servicePrincipalId == "" {
+		return nil
+	}
+
+	objects := &sharedObjects{
+		UserIds:            strings.Split(userIds, ","),
+		ApplicationId:      applicationId,
+		ServicePrincipalId: servicePrincipalId,
+	}
+	if len(objects.UserIds) < sharedUsersCount {
+		log.Printf("[WARN] %s should specify %d user IDs, but only %d were specified", SharedUserIdsEnvVar, sharedUsersCount, len(objects.UserIds))
+		return nil
+	}
+
+	return objects
+}
+
+func provisionSharedObjects(ctx context.Context, client *clients.Client) (*sharedObjects, error) {
+	domains, _, err := client.Domains.DomainsClient.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing domains: %v", err)
+	}
+	var domainName string
+	if domains != nil {
+		for _, d := range *domains {
+			if d.IsInitial != nil && *d.IsInitial && d.ID != nil {
+				domainName = *d.ID
+				break
+			}
+		}
+	}
+	if domainName == "" {
+		return nil, fmt.Errorf("could not determine the initial domain for the tenant")
+	}
+
+	suffix := tf.AccRandTimeInt()
+	objects := &sharedObjects{
+		provisioned: true,
+	}
+
+	for i := 0; i < sharedUsersCount; i++ {
+		user, _, err := client.Users.UsersClient.Create(ctx, msgraph.User{
+			AccountEnabled:    utils.Bool(true),
+			DisplayName:       utils.String(fmt.Sprintf("acctestSharedUser-%d-%d", suffix, i)),
+			MailNickname:      utils.String(fmt.Sprintf("acctestSharedUser-%d-%d", suffix, i)),
+			UserPrincipalName: utils.String(fmt.Sprintf("acctestSharedUser.%d.%d@%s", suffix, i, domainName)),
+			PasswordProfile: &msgraph.UserPasswordProfile{
+				Password: utils.String(fmt.Sprintf("%s%s", "p@$$Wd", acctest.RandString(10))),
+			},
+		})
+		if err != nil {
+			return objects, fmt.Errorf("creating user: %v", err)
+		}
+		if user.ID == nil {
+			return objects, fmt.Errorf("nil ID returned for user")
+		}
+		objects.UserIds = append(objects.UserIds, *user.ID)
+	}
+
+	app, _, err := client.Applications.ApplicationsClient.Create(ctx, msgraph.Application{
+		DisplayName: utils.String(fmt.Sprintf("acctestShared-%d", suffix)),
+	})
+	if err != nil {
+		return objects, fmt.Errorf("creating application: %v", err)
+	}
+	if app.ID == nil || app.AppId == nil {
+		return objects, fmt.Errorf("nil ID returned for application")
+	}
+	objects.ApplicationId = *app.ID
+
+	sp, _, err := client.ServicePrincipals.ServicePrincipalsClient.Create(ctx, msgraph.ServicePrincipal{
+		AppId: app.AppId,
+	})
+	if err != nil {
+		return objects, fmt.Errorf("creating service principal: %v", err)
+	}
+	if sp.ID == nil {
+		return objects, fmt.Errorf("nil ID returned for service principal")
+	}
+	objects.ServicePrincipalId = *sp.ID
+
+	log.Printf("[DEBUG] Provisioned shared prerequisite objects: users %v, application %q, service principal %q", objects.UserIds, objects.ApplicationId, objects.ServicePrincipalId)
+
+	return objects, nil
+}
+
+// sharedObjectsExist checks that all the shared prerequisite objects can be retrieved
+func sharedObjectsExist(objects *sharedObjects) bool {
+	ctx := context.Background()
+	client := shared.client
+
+	for _, id := range objects.UserIds {
+		if _, _, err := client.Users.UsersClient.Get(ctx, id); err != nil {
+			log.Printf("[DEBUG] Retrieving shared user %q: %v", id, err)
+			return false
+		}
+	}
+	if _, _, err := client.Applications.ApplicationsClient.Get(ctx, objects.ApplicationId); err != nil {
+		log.Printf("[DEBUG] Retrieving shared application %q: %v", objects.ApplicationId, err)
+		return false
+	}
+	if _, _, err := client.ServicePrincipals.ServicePrincipalsClient.Get(ctx, objects.ServicePrincipalId); err != nil {
+		log.Printf("[DEBUG] Retrieving shared service principal %q: %v", objects.ServicePrincipalId, err)
+		return false
+	}
+
+	return true
+}
+
+// destroySharedObjects permanently deletes the shared prerequisite objects, when they were provisioned during this
+// test run, so that they do not count towards the directory quota
+func destroySharedObjects() {
+	objects := shared.objects
+	if objects == nil || !objects.provisioned {
+		return
+	}
+	shared.objects = nil
+
+	ctx := context.Background()
+	client := shared.client
+
+	// Deleting the application also deletes its service principal
+	if objects.ApplicationId != "" {
+		if _, err := client.Applications.ApplicationsClient.Delete(ctx, objects.ApplicationId); err != nil {
+			log.Printf("[WARN] Deleting shared application %q: %v", objects.ApplicationId, err)
+		} else if _, err := client.Applications.ApplicationsClient.DeletePermanently(ctx, objects.ApplicationId); err != nil {
+			log.Printf("[WARN] Permanently deleting shared application %q: %v", objects.ApplicationId, err)
+		}
+	}
+
+	for _, id := range objects.UserIds {
+		if _, err := client.Users.UsersClient.Delete(ctx, id); err != nil {
+			log.Printf("[WARN] Deleting shared user %q: %v", id, err)
+		} else if _, err := client.Users.UsersClient.DeletePermanently(ctx, id); err != nil {
+			log.Printf("[WARN] Permanently deleting shared user %q: %v", id, err)
+		}
+	}
+}
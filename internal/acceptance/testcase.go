@@ -35,6 +35,17 @@ func (td TestData) ResourceTest(t *testing.T, testResource types.TestResource, s
 	td.runAcceptanceTest(t, testCase)
 }
 
+// ResourceTestIgnoreCheckDestroyed runs an acceptance test for a resource which remains in the tenant once destroyed,
+// such as an activated directory role, and so skips checking that it no longer exists
+func (td TestData) ResourceTestIgnoreCheckDestroyed(t *testing.T, steps []resource.TestStep) {
+	testCase := resource.TestCase{
+		PreCheck: func() { PreCheck(t) },
+		Steps:    steps,
+	}
+
+	td.runAcceptanceTest(t, testCase)
+}
+
 func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ProviderFactories = map[string]func() (*schema.Provider, error){
 		"azuread": func() (*schema.Provider, error) {
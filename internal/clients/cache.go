@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"sync"
+)
+
+// lookupCache memoizes the results of lookups which cannot change during a single Terraform operation, such as the
+// directory role templates or the service principals of Microsoft-published APIs. A new cache is created each time the
+// provider is configured, so results never outlive the operation in which they were retrieved.
+//
+// Concurrent lookups of the same key share a single request. Errors, and results which the lookup reports as not
+// cacheable, are returned to any waiting callers but are not retained, so the next lookup for that key is retried.
+// Cached values are shared between callers and must not be modified.
+type lookupCache struct {
+	disabled bool
+
+	mutex   sync.Mutex
+	entries map[string]*lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		entries: make(map[string]*lookupCacheEntry),
+	}
+}
+
+// get returns the cached value for key, or calls lookup to retrieve it. The lookup returns the value, whether it may be
+// cached, and any error. When the cache is nil or disabled, lookup is always called.
+func (c *lookupCache) get(key string, lookup func() (interface{}, bool, error)) (interface{}, error) {
+	if c == nil || c.disabled {
+		value, _, err := lookup()
+		return value, err
+	}
+
+	c.mutex.Lock()
+	if entry, ok := c.entries[key]; ok {
+		c.mutex.Unlock()
+		<-entry.done
+		return entry.value, entry.err
+	}
+	entry := &lookupCacheEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mutex.Unlock()
+
+	var cacheable bool
+	entry.value, cacheable, entry.err = lookup()
+	if entry.err != nil || !cacheable {
+		c.mutex.Lock()
+		delete(c.entries, key)
+		c.mutex.Unlock()
+	}
+	close(entry.done)
+
+	return entry.value, entry.err
+}
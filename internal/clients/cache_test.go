@@ -0,0 +1,253 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	directoryroles "github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+	domains "github.com/hashicorp/terraform-provider-azuread/internal/services/domains/client"
+	serviceprincipals "github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
+)
+
+const testTenantId = "00000000-0000-0000-0000-000000000000"
+
+// testCountingClient returns a Client whose API clients send requests to a fake Microsoft Graph server, along with a
+// function returning the number of requests received for each path
+func testCountingClient(t *testing.T) (*Client, func(path string) int) {
+	var mutex sync.Mutex
+	counts := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		counts[r.URL.Path]++
+		mutex.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1.0/" + testTenantId + "/directoryRoleTemplates":
+			fmt.Fprint(w, `{"value":[{"id":"62e90394-69f5-4237-9190-012177145e10","displayName":"Global Administrator"}]}`)
+		case "/v1.0/" + testTenantId + "/domains":
+			fmt.Fprint(w, `{"value":[{"id":"contoso.onmicrosoft.com","isVerified":true},{"id":"contoso.com","isVerified":false}]}`)
+		case "/beta/" + testTenantId + "/servicePrincipals":
+			if r.URL.Query().Get("$filter") == fmt.Sprintf("appId eq '%s'", environments.PublishedApis["MicrosoftGraph"]) {
+				fmt.Fprintf(w, `{"value":[{"id":"11111111-1111-1111-1111-111111111111","appId":%q}]}`, environments.PublishedApis["MicrosoftGraph"])
+				return
+			}
+			fmt.Fprint(w, `{"value":[]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	configure := func(c *msgraph.Client) {
+		c.Endpoint = environments.ApiEndpoint(server.URL)
+		c.DisableRetries = true
+	}
+
+	templatesClient := msgraph.NewDirectoryRoleTemplatesClient(testTenantId)
+	configure(&templatesClient.BaseClient)
+	domainsClient := msgraph.NewDomainsClient(testTenantId)
+	configure(&domainsClient.BaseClient)
+	servicePrincipalsClient := msgraph.NewServicePrincipalsClient(testTenantId)
+	configure(&servicePrincipalsClient.BaseClient)
+
+	client := &Client{
+		DirectoryRoles:    &directoryroles.Client{DirectoryRoleTemplatesClient: templatesClient},
+		Domains:           &domains.Client{DomainsClient: domainsClient},
+		ServicePrincipals: &serviceprincipals.Client{ServicePrincipalsClient: servicePrincipalsClient},
+		cache:             newLookupCache(),
+	}
+
+	count := func(path string) int {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return counts[path]
+	}
+
+	return client, count
+}
+
+func TestClientDirectoryRoleTemplatesCached(t *testing.T) {
+	client, count := testCountingClient(t)
+	path := "/v1.0/" + testTenantId + "/directoryRoleTemplates"
+
+	for i := 0; i < 2; i++ {
+		templates, err := client.DirectoryRoleTemplates(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if templates == nil || len(*templates) != 1 {
+			t.Fatalf("expected 1 template, got %v", templates)
+		}
+	}
+	if n := count(path); n != 1 {
+		t.Fatalf("expected 1 request for directory role templates, got %d", n)
+	}
+
+	client.DisableLookupCache()
+	if _, err := client.DirectoryRoleTemplates(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := count(path); n != 2 {
+		t.Fatalf("expected a further request with the cache disabled, got %d requests", n)
+	}
+}
+
+func TestClientVerifiedDomainsCached(t *testing.T) {
+	client, count := testCountingClient(t)
+
+	for i := 0; i < 2; i++ {
+		verified, err := client.VerifiedDomains(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(verified) != 1 || verified[0] != "contoso.onmicrosoft.com" {
+			t.Fatalf("expected only the verified domain, got %v", verified)
+		}
+	}
+
+	all, err := client.TenantDomains(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*all) != 2 {
+		t.Fatalf("expected 2 domains, got %d", len(*all))
+	}
+
+	if n := count("/v1.0/" + testTenantId + "/domains"); n != 1 {
+		t.Fatalf("expected 1 request for domains, got %d", n)
+	}
+}
+
+func TestClientServicePrincipalByAppIdCached(t *testing.T) {
+	client, count := testCountingClient(t)
+	path := "/beta/" + testTenantId + "/servicePrincipals"
+
+	for i := 0; i < 2; i++ {
+		sp, err := client.ServicePrincipalByAppId(context.Background(), string(environments.PublishedApis["MicrosoftGraph"]))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sp == nil || sp.ID == nil || *sp.ID != "11111111-1111-1111-1111-111111111111" {
+			t.Fatalf("unexpected service principal: %+v", sp)
+		}
+	}
+	if n := count(path); n != 1 {
+		t.Fatalf("expected 1 request for a published API, got %d", n)
+	}
+
+	// A missing service principal for a published API may be created during the apply, so is looked up again
+	for i := 0; i < 2; i++ {
+		sp, err := client.ServicePrincipalByAppId(context.Background(), string(environments.PublishedApis["AzureKeyVault"]))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sp != nil {
+			t.Fatalf("expected no service principal, got %+v", sp)
+		}
+	}
+	if n := count(path); n != 3 {
+		t.Fatalf("expected missing service principals not to be cached, got %d requests", n)
+	}
+
+	// Service principals for other applications can change during the apply, so are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.ServicePrincipalByAppId(context.Background(), "22222222-2222-2222-2222-222222222222"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := count(path); n != 5 {
+		t.Fatalf("expected service principals for other applications not to be cached, got %d requests", n)
+	}
+}
+
+func TestLookupCacheConcurrent(t *testing.T) {
+	cache := newLookupCache()
+
+	var calls int32
+	release := make(chan struct{})
+	lookup := func() (interface{}, bool, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", true, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := cache.get("key", lookup)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("expected concurrent lookups to share 1 call, got %d", n)
+	}
+	for i, v := range results {
+		if v != "value" {
+			t.Fatalf("unexpected result %d: %v", i, v)
+		}
+	}
+}
+
+func TestLookupCacheErrorsNotCached(t *testing.T) {
+	cache := newLookupCache()
+
+	calls := 0
+	failing := func() (interface{}, bool, error) {
+		calls++
+		return nil, false, errors.New("transient failure")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get("key", failing); err == nil {
+			t.Fatalf("expected an error")
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected failed lookups to be retried, got %d calls", calls)
+	}
+
+	v, err := cache.get("key", func() (interface{}, bool, error) {
+		return "value", true, nil
+	})
+	if err != nil || v != "value" {
+		t.Fatalf("expected lookup to succeed after earlier failures, got %v, %v", v, err)
+	}
+}
+
+func TestLookupCacheNil(t *testing.T) {
+	var cache *lookupCache
+
+	calls := 0
+	lookup := func() (interface{}, bool, error) {
+		calls++
+		return "value", true, nil
+	}
+	for i := 0; i < 2; i++ {
+		if v, err := cache.get("key", lookup); err != nil || v != "value" {
+			t.Fatalf("unexpected result: %v, %v", v, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected a nil cache to always perform the lookup, got %d calls", calls)
+	}
+}
@@ -3,14 +3,22 @@ package clients
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/manicminer/hamilton/auth"
 	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	applications "github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
+	conditionalaccess "github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	directoryroles "github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
 	domains "github.com/hashicorp/terraform-provider-azuread/internal/services/domains/client"
 	groups "github.com/hashicorp/terraform-provider-azuread/internal/services/groups/client"
+	organizations "github.com/hashicorp/terraform-provider-azuread/internal/services/organizations/client"
+	policies "github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
 	serviceprincipals "github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
 	users "github.com/hashicorp/terraform-provider-azuread/internal/services/users/client"
 )
@@ -26,19 +34,44 @@ type Client struct {
 
 	StopContext context.Context
 
+	// StrictDelete causes deletion of objects which no longer exist to fail, rather than being treated as deleted
+	StrictDelete bool
+
+	// RollbackOnPartialCreate causes objects to be deleted when a subsequent step of their creation fails
+	RollbackOnPartialCreate bool
+
+	// ReadOnly causes all create, update and delete operations to fail before making any API requests
+	ReadOnly bool
+
+	// DefaultTimeouts holds the timeouts configured for the provider, keyed by operation (e.g. `create`), which are used
+	// for resources that do not specify a timeout for an operation
+	DefaultTimeouts map[string]time.Duration
+
 	Applications      *applications.Client
+	ConditionalAccess *conditionalaccess.Client
+	DirectoryRoles    *directoryroles.Client
 	Domains           *domains.Client
 	Groups            *groups.Client
+	Organizations     *organizations.Client
+	Policies          *policies.Client
 	ServicePrincipals *serviceprincipals.Client
 	Users             *users.Client
+
+	// cache holds the results of lookups which cannot change during an operation, see lookupCache
+	cache *lookupCache
 }
 
 func (client *Client) build(ctx context.Context, o *common.ClientOptions) error {
 	client.StopContext = ctx
+	client.cache = newLookupCache()
 
 	client.Applications = applications.NewClient(o)
+	client.ConditionalAccess = conditionalaccess.NewClient(o)
+	client.DirectoryRoles = directoryroles.NewClient(o)
 	client.Domains = domains.NewClient(o)
 	client.Groups = groups.NewClient(o)
+	client.Organizations = organizations.NewClient(o)
+	client.Policies = policies.NewClient(o)
 	client.ServicePrincipals = serviceprincipals.NewClient(o)
 	client.Users = users.NewClient(o)
 
@@ -57,3 +90,129 @@ func (client *Client) build(ctx context.Context, o *common.ClientOptions) error
 
 	return nil
 }
+
+// DisableLookupCache causes all lookups to be sent to the API, instead of reusing earlier results retrieved by this
+// provider instance
+func (client *Client) DisableLookupCache() {
+	if client.cache != nil {
+		client.cache.disabled = true
+	}
+}
+
+// TenantDomains returns all domains in the tenant. Domains are retrieved once and then cached for the lifetime of the
+// provider, unless the lookup cache is disabled.
+func (client *Client) TenantDomains(ctx context.Context) (*[]msgraph.Domain, error) {
+	v, err := client.cache.get("domains", func() (interface{}, bool, error) {
+		result, _, err := client.Domains.DomainsClient.List(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("listing domains: %v", err)
+		}
+		if result == nil {
+			return nil, false, fmt.Errorf("listing domains: result was nil")
+		}
+		return result, true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*[]msgraph.Domain), nil
+}
+
+// VerifiedDomains returns the names of all verified domains in the tenant, so that they can be consulted cheaply at
+// plan time
+func (client *Client) VerifiedDomains(ctx context.Context) ([]string, error) {
+	domains, err := client.TenantDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	for _, domain := range *domains {
+		if domain.ID != nil && domain.IsVerified != nil && *domain.IsVerified {
+			result = append(result, *domain.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// TenantOrganization returns the organization for the tenant. The organization is retrieved once and then cached for
+// the lifetime of the provider, unless the lookup cache is disabled.
+func (client *Client) TenantOrganization(ctx context.Context) (*organizations.Organization, error) {
+	v, err := client.cache.get("organization", func() (interface{}, bool, error) {
+		org, _, err := client.Organizations.OrganizationClient.Get(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("retrieving organization: %v", err)
+		}
+		if org == nil {
+			return nil, false, fmt.Errorf("retrieving organization: result was nil")
+		}
+		return org, true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*organizations.Organization), nil
+}
+
+// DirectoryRoleTemplates returns all directory role templates. The templates are built in and cannot change, so they
+// are retrieved once and then cached for the lifetime of the provider, unless the lookup cache is disabled.
+func (client *Client) DirectoryRoleTemplates(ctx context.Context) (*[]msgraph.DirectoryRoleTemplate, error) {
+	v, err := client.cache.get("directoryRoleTemplates", func() (interface{}, bool, error) {
+		result, _, err := client.DirectoryRoles.DirectoryRoleTemplatesClient.List(ctx)
+		return result, err == nil && result != nil, err
+	})
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.(*[]msgraph.DirectoryRoleTemplate), nil
+}
+
+// ServicePrincipalByAppId returns the service principal for the application with the specified client ID, or nil if the
+// application has no service principal in the tenant. Service principals for Microsoft-published APIs, such as Microsoft
+// Graph, are cached for the lifetime of the provider unless the lookup cache is disabled, so that configurations
+// referencing the same APIs do not repeat the lookup. A missing service principal is not cached, since it may be
+// created during the same apply.
+func (client *Client) ServicePrincipalByAppId(ctx context.Context, appId string) (*msgraph.ServicePrincipal, error) {
+	lookup := func() (interface{}, bool, error) {
+		result, _, err := client.ServicePrincipals.ServicePrincipalsClient.List(ctx, helpers.ODataEq("appId", appId))
+		if err != nil {
+			return nil, false, fmt.Errorf("listing service principals with client ID %q: %v", appId, err)
+		}
+		if result == nil || len(*result) == 0 {
+			return nil, false, nil
+		}
+		return &(*result)[0], true, nil
+	}
+
+	var v interface{}
+	var err error
+	if isPublishedApi(appId) {
+		v, err = client.cache.get("servicePrincipalByAppId/"+strings.ToLower(appId), lookup)
+	} else {
+		v, _, err = lookup()
+	}
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v.(*msgraph.ServicePrincipal), nil
+}
+
+// isPublishedApi returns whether appId is the client ID of a well-known API published by Microsoft
+func isPublishedApi(appId string) bool {
+	for _, v := range environments.PublishedApis {
+		if strings.EqualFold(string(v), appId) {
+			return true
+		}
+	}
+	return false
+}
+
+// IdConfusion returns a helper for explaining failed requests which may have specified the wrong kind of ID, e.g. the
+// client ID of an application where the object ID of a service principal is required.
+func (client *Client) IdConfusion() helpers.IdConfusion {
+	return helpers.IdConfusion{
+		ApplicationsClient:      client.Applications.ApplicationsClient,
+		ServicePrincipalsClient: client.ServicePrincipals.ServicePrincipalsClient,
+	}
+}
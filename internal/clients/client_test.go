@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	domains "github.com/hashicorp/terraform-provider-azuread/internal/services/domains/client"
+	serviceprincipals "github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
+)
+
+func TestClientVerifiedDomainsRetriedAfterError(t *testing.T) {
+	tenantId := "00000000-0000-0000-0000-000000000000"
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+			return
+		}
+		fmt.Fprint(w, `{"value":[{"id":"contoso.onmicrosoft.com","isVerified":true},{"id":"contoso.com","isVerified":false}]}`)
+	}))
+	defer server.Close()
+
+	domainsClient := msgraph.NewDomainsClient(tenantId)
+	domainsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	domainsClient.BaseClient.DisableRetries = true
+	client := &Client{
+		Domains: &domains.Client{DomainsClient: domainsClient},
+	}
+
+	if _, err := client.VerifiedDomains(context.Background()); err == nil {
+		t.Fatalf("expected an error when domains cannot be listed")
+	}
+
+	verified, err := client.VerifiedDomains(context.Background())
+	if err != nil {
+		t.Fatalf("expected a failed lookup to be retried, got error: %v", err)
+	}
+	if len(verified) != 1 || verified[0] != "contoso.onmicrosoft.com" {
+		t.Fatalf("expected only the verified domain, got %v", verified)
+	}
+}
+
+func TestClientServicePrincipalByAppIdConcurrent(t *testing.T) {
+	tenantId := "00000000-0000-0000-0000-000000000000"
+	firstAppId, secondAppId := "11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222"
+
+	firstReceived, secondReceived := make(chan struct{}), make(chan struct{})
+	var firstOnce, secondOnce sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		appId := secondAppId
+		if r.URL.Query().Get("$filter") == fmt.Sprintf("appId eq '%s'", firstAppId) {
+			appId = firstAppId
+			firstOnce.Do(func() { close(firstReceived) })
+
+			// Only respond to the first lookup once the second lookup has been received, which never happens when
+			// lookups are serialized
+			select {
+			case <-secondReceived:
+			case <-time.After(5 * time.Second):
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Timed out waiting for a concurrent lookup."}}`)
+				return
+			}
+		} else {
+			secondOnce.Do(func() { close(secondReceived) })
+		}
+		fmt.Fprintf(w, `{"value":[{"id":"33333333-3333-3333-3333-333333333333","appId":%q}]}`, appId)
+	}))
+	defer server.Close()
+
+	servicePrincipalsClient := msgraph.NewServicePrincipalsClient(tenantId)
+	servicePrincipalsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	servicePrincipalsClient.BaseClient.DisableRetries = true
+	client := &Client{
+		ServicePrincipals: &serviceprincipals.Client{ServicePrincipalsClient: servicePrincipalsClient},
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.ServicePrincipalByAppId(context.Background(), firstAppId)
+		errs <- err
+	}()
+	<-firstReceived
+
+	if _, err := client.ServicePrincipalByAppId(context.Background(), secondAppId); err != nil {
+		t.Fatalf("unexpected error looking up second service principal: %v", err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("unexpected error looking up first service principal: %v", err)
+	}
+}
@@ -0,0 +1,349 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+// AdvancedQuery configures an advanced query against a directory object collection in Microsoft Graph. Advanced
+// queries are sent with the `ConsistencyLevel: eventual` header, which is required for `$count`, `$search` and
+// certain `$filter` operators such as `endsWith`.
+type AdvancedQuery struct {
+	// Filter is an OData filter expression, e.g. `endsWith(userPrincipalName, '@example.com')`
+	Filter string
+
+	// Search is a search expression, e.g. `"displayName:foo"`
+	Search string
+
+	// Select is a list of properties to return
+	Select []string
+
+	// Top is the page size to request, which is left to the API default when zero
+	Top int
+
+	// Limit is the maximum number of objects to list. No further pages are requested once more than Limit objects have
+	// been retrieved, and one more than Limit objects are returned so that callers can tell when results were
+	// truncated. No limit is applied when zero.
+	Limit int
+
+	// Regular sends the query without the `ConsistencyLevel: eventual` header and the `$count` parameter, for use as a
+	// fallback when an advanced query is not supported. Paging, limits and retries are otherwise unchanged.
+	Regular bool
+}
+
+// AdvancedQueryUnsupportedError is returned when an advanced query is rejected by the API, in which case callers
+// should fall back to a regular (non-advanced) query.
+type AdvancedQueryUnsupportedError struct {
+	Status int
+	Err    error
+}
+
+func (e AdvancedQueryUnsupportedError) Error() string {
+	return fmt.Sprintf("advanced query not supported (status %d): %v", e.Status, e.Err)
+}
+
+// IsAdvancedQueryUnsupported returns whether the provided error indicates that an advanced query was rejected
+func IsAdvancedQueryUnsupported(err error) bool {
+	_, ok := err.(AdvancedQueryUnsupportedError)
+	return ok
+}
+
+// params returns the query parameters for the query. When countSegment is true, the query is targeting a `$count`
+// path segment and paging parameters are omitted.
+func (q AdvancedQuery) params(countSegment bool) url.Values {
+	params := url.Values{}
+	if q.Filter != "" {
+		params.Add("$filter", q.Filter)
+	}
+	if q.Search != "" {
+		params.Add("$search", q.Search)
+	}
+	if len(q.Select) > 0 {
+		params.Add("$select", strings.Join(q.Select, ","))
+	}
+	if !countSegment {
+		if !q.Regular {
+			params.Add("$count", "true")
+		}
+		if q.Top > 0 {
+			params.Add("$top", strconv.Itoa(q.Top))
+		}
+	}
+	return params
+}
+
+// AdvancedQueryCount returns the number of objects in the specified collection (e.g. `/groups`) matching the query
+func AdvancedQueryCount(ctx context.Context, client msgraph.Client, collection string, q AdvancedQuery) (int, int, error) {
+	uri, err := advancedQueryUri(client, fmt.Sprintf("%s/$count", strings.TrimRight(collection, "/")), q.params(true))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	respBody, status, err := advancedQueryRequest(ctx, client, uri, true)
+	if err != nil {
+		return 0, status, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(string(respBody), "\ufeff")))
+	if err != nil {
+		return 0, status, fmt.Errorf("parsing count %q: %v", respBody, err)
+	}
+
+	return count, status, nil
+}
+
+// AdvancedQueryListExisting is a fast path for listing the objects in the specified collection (e.g. `/groups`) matching
+// the query, which is expected to match few objects. A `$count` query is used to determine whether there are any matching
+// objects, which are then retrieved in a single page. Counts are eventually consistent and may not yet include recently
+// created objects, so false is returned when no objects were counted, or when advanced queries are not supported, in
+// which case `out` is not populated and callers should fall back to a regular query.
+func AdvancedQueryListExisting(ctx context.Context, client msgraph.Client, collection string, q AdvancedQuery, out interface{}) (bool, error) {
+	count, _, err := AdvancedQueryCount(ctx, client, collection, q)
+	if err != nil {
+		if IsAdvancedQueryUnsupported(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if count == 0 {
+		return false, nil
+	}
+
+	if q.Top == 0 {
+		q.Top = count
+		if q.Top > advancedQueryMaxPageSize {
+			q.Top = advancedQueryMaxPageSize
+		}
+	}
+	if _, err := AdvancedQueryList(ctx, client, collection, q, out); err != nil {
+		if IsAdvancedQueryUnsupported(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// advancedQueryMaxPageSize is the largest page size supported for directory object collections
+const advancedQueryMaxPageSize = 999
+
+// AdvancedQueryPageFunc is called with the JSON array of objects on each page of results for an advanced query, and
+// returns whether further pages should be retrieved
+type AdvancedQueryPageFunc func(values json.RawMessage) (bool, error)
+
+// AdvancedQueryPages retrieves the objects in the specified collection (e.g. `/groups`) matching the query one page at a
+// time, calling f for each page. Only one page is held in memory at a time, so callers can decode each page directly
+// into their results. The query Limit is not applied; callers should stop paging by returning false from f.
+func AdvancedQueryPages(ctx context.Context, client msgraph.Client, collection string, q AdvancedQuery, f AdvancedQueryPageFunc) (int, error) {
+	uri, err := advancedQueryUri(client, collection, q.params(false))
+	if err != nil {
+		return 0, err
+	}
+
+	var status int
+	for uri != "" {
+		var respBody []byte
+		respBody, status, err = advancedQueryRequest(ctx, client, uri, !q.Regular)
+		if err != nil {
+			return status, err
+		}
+
+		var page struct {
+			NextLink *string         `json:"@odata.nextLink"`
+			Value    json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(respBody, &page); err != nil {
+			return status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+
+		more := true
+		if len(page.Value) > 0 {
+			if more, err = f(page.Value); err != nil {
+				return status, err
+			}
+		}
+
+		uri = ""
+		if more && page.NextLink != nil {
+			uri = *page.NextLink
+		}
+	}
+
+	return status, nil
+}
+
+// AdvancedQueryList populates `out`, which must be a pointer to a slice, with all objects in the specified collection
+// (e.g. `/groups`) matching the query. All pages of results are retrieved, unless the query specifies a Limit.
+func AdvancedQueryList(ctx context.Context, client msgraph.Client, collection string, q AdvancedQuery, out interface{}) (int, error) {
+	result := reflect.ValueOf(out)
+	if result.Kind() != reflect.Ptr || result.Elem().Kind() != reflect.Slice {
+		return 0, fmt.Errorf("out must be a pointer to a slice, got %T", out)
+	}
+	items := result.Elem()
+	items.Set(reflect.MakeSlice(items.Type(), 0, 0))
+
+	status, err := AdvancedQueryPages(ctx, client, collection, q, func(values json.RawMessage) (bool, error) {
+		page := reflect.New(items.Type())
+		if err := json.Unmarshal(values, page.Interface()); err != nil {
+			return false, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+		items.Set(reflect.AppendSlice(items, page.Elem()))
+
+		if q.Limit > 0 && items.Len() > q.Limit {
+			items.Set(items.Slice(0, q.Limit+1))
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
+
+func advancedQueryUri(client msgraph.Client, entity string, params url.Values) (string, error) {
+	u, err := url.Parse(string(client.Endpoint))
+	if err != nil {
+		return "", fmt.Errorf("parsing endpoint %q: %v", client.Endpoint, err)
+	}
+	u.Path = "/" + string(client.ApiVersion)
+	if client.TenantId != "" {
+		u.Path = fmt.Sprintf("%s/%s", u.Path, client.TenantId)
+	}
+	u.Path = fmt.Sprintf("%s/%s", u.Path, strings.TrimLeft(entity, "/"))
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+// advancedQueryUnsupportedCodes are the OData error codes returned when an advanced query is not supported
+var advancedQueryUnsupportedCodes = []string{
+	"Request_UnsupportedQuery",
+}
+
+// advancedQueryRetryStatuses are the response statuses for which a request is retried, which matches the rate limiting
+// behaviour of the Microsoft Graph client
+var advancedQueryRetryStatuses = []int{
+	http.StatusFailedDependency,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+}
+
+const (
+	advancedQueryRequestAttempts = 10
+	advancedQueryRetryDelayCap   = 64 * time.Second
+)
+
+// advancedQueryRetryInitialDelay is the delay before the first retry, which is doubled for each subsequent retry
+var advancedQueryRetryInitialDelay = 1 * time.Second
+
+func advancedQueryRequest(ctx context.Context, client msgraph.Client, uri string, eventual bool) ([]byte, int, error) {
+	delay := advancedQueryRetryInitialDelay
+	for attempt := 1; ; attempt++ {
+		respBody, status, retryAfter, err := advancedQueryRequestAttempt(ctx, client, uri, eventual)
+		if err == nil || attempt >= advancedQueryRequestAttempts || !containsInt(advancedQueryRetryStatuses, status) {
+			return respBody, status, err
+		}
+
+		wait := delay
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if delay *= 2; delay > advancedQueryRetryDelayCap {
+			delay = advancedQueryRetryDelayCap
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status, fmt.Errorf("%v (context finished whilst retrying: %v)", err, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// advancedQueryRequestAttempt performs a single request, returning the response body, the response status, and the
+// delay requested by any Retry-After header. The `ConsistencyLevel: eventual` header is only sent when eventual is true.
+func advancedQueryRequestAttempt(ctx context.Context, client msgraph.Client, uri string, eventual bool) ([]byte, int, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, http.NoBody)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if client.Authorizer != nil {
+		token, err := client.Authorizer.Token()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	req.Header.Add("Accept", "application/json")
+	if eventual {
+		req.Header.Add("ConsistencyLevel", "eventual")
+	}
+	if client.UserAgent != "" {
+		req.Header.Add("User-Agent", client.UserAgent)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, 0, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var retryAfter time.Duration
+		if r, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), 64); err == nil && r > 0 {
+			retryAfter = time.Duration(r * float64(time.Second))
+		}
+
+		err := fmt.Errorf("unexpected status %d with response: %s", resp.StatusCode, respBody)
+		var o odata.OData
+		if jsonErr := json.Unmarshal(respBody, &o); jsonErr == nil && o.Error != nil {
+			err = fmt.Errorf("unexpected status %d with OData error: %s", resp.StatusCode, o.Error)
+			if o.Error.Code != nil && containsString(advancedQueryUnsupportedCodes, *o.Error.Code) {
+				return nil, resp.StatusCode, 0, AdvancedQueryUnsupportedError{Status: resp.StatusCode, Err: err}
+			}
+		}
+		return nil, resp.StatusCode, retryAfter, err
+	}
+
+	return respBody, resp.StatusCode, 0, nil
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
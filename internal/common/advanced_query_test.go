@@ -0,0 +1,416 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func testAdvancedQueryClient(t *testing.T, handler http.HandlerFunc) msgraph.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := msgraph.NewClient(msgraph.Version10, "00000000-0000-0000-0000-000000000000")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+	return client
+}
+
+func TestAdvancedQueryCount(t *testing.T) {
+	ctx := context.Background()
+	query := AdvancedQuery{Filter: "displayName eq 'acctest'"}
+
+	t.Run("advanced query supported", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("ConsistencyLevel"); got != "eventual" {
+				t.Errorf("expected ConsistencyLevel header to be %q, got %q", "eventual", got)
+			}
+			if expected := "/v1.0/00000000-0000-0000-0000-000000000000/groups/$count"; r.URL.Path != expected {
+				t.Errorf("expected path %q, got %q", expected, r.URL.Path)
+			}
+			if got := r.URL.Query().Get("$filter"); got != query.Filter {
+				t.Errorf("expected filter %q, got %q", query.Filter, got)
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "\ufeff3")
+		})
+
+		count, _, err := AdvancedQueryCount(ctx, client, "/groups", query)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 3 {
+			t.Fatalf("expected count of 3, got %d", count)
+		}
+	})
+
+	t.Run("advanced query unsupported", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":"Request_UnsupportedQuery","message":"Unsupported query."}}`)
+		})
+
+		_, status, err := AdvancedQueryCount(ctx, client, "/groups", query)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !IsAdvancedQueryUnsupported(err) {
+			t.Fatalf("expected error to indicate an unsupported query: %v", err)
+		}
+		if status != http.StatusBadRequest {
+			t.Fatalf("expected status %d, got %d", http.StatusBadRequest, status)
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":"Request_BadRequest","message":"Invalid filter clause."}}`)
+		})
+
+		_, _, err := AdvancedQueryCount(ctx, client, "/groups", query)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if IsAdvancedQueryUnsupported(err) {
+			t.Fatalf("expected error not to indicate an unsupported query: %v", err)
+		}
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+
+		_, _, err := AdvancedQueryCount(ctx, client, "/groups", query)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if IsAdvancedQueryUnsupported(err) {
+			t.Fatalf("expected error not to indicate an unsupported query: %v", err)
+		}
+	})
+}
+
+func TestAdvancedQueryRetries(t *testing.T) {
+	ctx := context.Background()
+
+	initialDelay := advancedQueryRetryInitialDelay
+	advancedQueryRetryInitialDelay = time.Millisecond
+	t.Cleanup(func() { advancedQueryRetryInitialDelay = initialDelay })
+
+	t.Run("retried until successful", func(t *testing.T) {
+		requests := 0
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			switch requests {
+			case 1:
+				w.Header().Set("Retry-After", "0.001")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			case 2:
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "3")
+		})
+
+		count, _, err := AdvancedQueryCount(ctx, client, "/groups", AdvancedQuery{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 3 || requests != 3 {
+			t.Fatalf("expected count of 3 after 3 requests, got %d after %d requests", count, requests)
+		}
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		requests := 0
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		_, status, err := AdvancedQueryCount(ctx, client, "/groups", AdvancedQuery{})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if status != http.StatusInternalServerError || requests != advancedQueryRequestAttempts {
+			t.Fatalf("expected status %d after %d requests, got %d after %d requests", http.StatusInternalServerError, advancedQueryRequestAttempts, status, requests)
+		}
+	})
+
+	t.Run("not retried", func(t *testing.T) {
+		requests := 0
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusForbidden)
+		})
+
+		if _, _, err := AdvancedQueryCount(ctx, client, "/groups", AdvancedQuery{}); err == nil {
+			t.Fatal("expected an error")
+		}
+		if requests != 1 {
+			t.Fatalf("expected 1 request, got %d", requests)
+		}
+	})
+}
+
+func TestAdvancedQueryList(t *testing.T) {
+	ctx := context.Background()
+
+	var serverUrl string
+	client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("ConsistencyLevel"); got != "eventual" {
+			t.Errorf("expected ConsistencyLevel header to be %q, got %q", "eventual", got)
+		}
+		if got := r.URL.Query().Get("$count"); got != "true" {
+			t.Errorf("expected $count to be %q, got %q", "true", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"value":[{"id":"22222222-2222-2222-2222-222222222222"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"@odata.nextLink":"%s/v1.0/groups?$count=true&page=2","value":[{"id":"11111111-1111-1111-1111-111111111111"}]}`, serverUrl)
+	})
+	serverUrl = string(client.Endpoint)
+
+	groups := make([]msgraph.Group, 0)
+	if _, err := AdvancedQueryList(ctx, client, "/groups", AdvancedQuery{Filter: "startsWith(displayName, 'acctest')"}, &groups); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[1].ID == nil || *groups[1].ID != "22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("unexpected ID for second group: %v", groups[1].ID)
+	}
+}
+
+func TestAdvancedQueryListExisting(t *testing.T) {
+	ctx := context.Background()
+	query := AdvancedQuery{Filter: "displayName eq 'acctest'"}
+
+	t.Run("counted", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/$count") {
+				fmt.Fprint(w, "1500")
+				return
+			}
+			if got := r.URL.Query().Get("$top"); got != "999" {
+				t.Errorf("expected $top to be capped at 999, got %q", got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"value":[{"id":"1"}]}`)
+		})
+
+		var out []struct {
+			ID string `json:"id"`
+		}
+		found, err := AdvancedQueryListExisting(ctx, client, "/groups", query, &out)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !found || len(out) != 1 {
+			t.Fatalf("expected 1 object to be found, got %t with %#v", found, out)
+		}
+	})
+
+	t.Run("not counted", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasSuffix(r.URL.Path, "/$count") {
+				t.Errorf("unexpected list request when no objects were counted")
+			}
+			fmt.Fprint(w, "0")
+		})
+
+		var out []struct{}
+		found, err := AdvancedQueryListExisting(ctx, client, "/groups", query, &out)
+		if err != nil || found {
+			t.Fatalf("expected no objects to be found, got %t, %v", found, err)
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+		})
+
+		var out []struct{}
+		if _, err := AdvancedQueryListExisting(ctx, client, "/groups", query, &out); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestAdvancedQueryListRegular(t *testing.T) {
+	ctx := context.Background()
+
+	requests := 0
+	client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("ConsistencyLevel"); got != "" {
+			t.Errorf("expected no ConsistencyLevel header, got %q", got)
+		}
+		if v, ok := r.URL.Query()["$count"]; ok {
+			t.Errorf("expected no $count parameter, got %q", v)
+		}
+		if requests == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"value":[{"id":"11111111-1111-1111-1111-111111111111"}]}`)
+	})
+
+	initialDelay := advancedQueryRetryInitialDelay
+	advancedQueryRetryInitialDelay = time.Millisecond
+	t.Cleanup(func() { advancedQueryRetryInitialDelay = initialDelay })
+
+	groups := make([]msgraph.Group, 0)
+	if _, err := AdvancedQueryList(ctx, client, "/groups", AdvancedQuery{Regular: true}, &groups); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(groups))
+	}
+	if requests != 2 {
+		t.Fatalf("expected a throttled request to be retried, got %d requests", requests)
+	}
+}
+
+func TestAdvancedQueryListLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var serverUrl string
+	requests := 0
+	client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"@odata.nextLink":"%s/v1.0/applications?$count=true&page=%d","value":[{"id":"11111111-1111-1111-1111-111111111111"},{"id":"22222222-2222-2222-2222-222222222222"}]}`, serverUrl, requests+1)
+	})
+	serverUrl = string(client.Endpoint)
+
+	apps := make([]msgraph.Application, 0)
+	if _, err := AdvancedQueryList(ctx, client, "/applications", AdvancedQuery{Limit: 3}, &apps); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(apps) != 4 {
+		t.Fatalf("expected one more than the limit of 3 applications, got %d", len(apps))
+	}
+	if requests != 2 {
+		t.Fatalf("expected paging to stop after 2 requests, got %d", requests)
+	}
+}
+
+// testAdvancedQueryPagingClient returns a client for a server which serves the specified number of pages of objects
+func testAdvancedQueryPagingClient(t *testing.T, pages, pageSize int) (msgraph.Client, *int) {
+	var serverUrl string
+	requests := 0
+	client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+
+		values := make([]string, 0, pageSize)
+		for i := 0; i < pageSize; i++ {
+			values = append(values, fmt.Sprintf(`{"id":"%08d-0000-0000-0000-%012d","displayName":"acctest-%d-%d"}`, page, i, page, i))
+		}
+		nextLink := ""
+		if page < pages-1 {
+			nextLink = fmt.Sprintf(`"@odata.nextLink":"%s/v1.0/groups?$count=true&page=%d",`, serverUrl, page+1)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{%s"value":[%s]}`, nextLink, strings.Join(values, ","))
+	})
+	serverUrl = string(client.Endpoint)
+
+	return client, &requests
+}
+
+func TestAdvancedQueryPages(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all pages", func(t *testing.T) {
+		client, requests := testAdvancedQueryPagingClient(t, 50, 10)
+
+		total := 0
+		_, err := AdvancedQueryPages(ctx, client, "/groups", AdvancedQuery{}, func(values json.RawMessage) (bool, error) {
+			var page []msgraph.Group
+			if err := json.Unmarshal(values, &page); err != nil {
+				return false, err
+			}
+			total += len(page)
+			return true, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *requests != 50 || total != 500 {
+			t.Fatalf("expected 500 groups from 50 requests, got %d groups from %d requests", total, *requests)
+		}
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		client, requests := testAdvancedQueryPagingClient(t, 50, 10)
+
+		pages := 0
+		_, err := AdvancedQueryPages(ctx, client, "/groups", AdvancedQuery{}, func(values json.RawMessage) (bool, error) {
+			pages++
+			return pages < 3, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *requests != 3 {
+			t.Fatalf("expected paging to stop after 3 requests, got %d", *requests)
+		}
+	})
+}
+
+// TestAdvancedQueryListMemory checks that memory allocated when listing grows linearly with the number of pages, i.e.
+// that results are not repeatedly copied as pages are accumulated
+func TestAdvancedQueryListMemory(t *testing.T) {
+	ctx := context.Background()
+
+	allocated := func(pages int) uint64 {
+		client, _ := testAdvancedQueryPagingClient(t, pages, 100)
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		groups := make([]msgraph.Group, 0)
+		if _, err := AdvancedQueryList(ctx, client, "/groups", AdvancedQuery{}, &groups); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		runtime.ReadMemStats(&after)
+		if len(groups) != pages*100 {
+			t.Fatalf("expected %d groups, got %d", pages*100, len(groups))
+		}
+		return after.TotalAlloc - before.TotalAlloc
+	}
+
+	small, large := allocated(10), allocated(50)
+
+	// Five times the pages should allocate roughly five times the memory, whereas quadratic growth would be nearer 25
+	if ratio := float64(large) / float64(small); ratio > 10 {
+		t.Fatalf("expected allocations to grow linearly with the number of pages, got %d bytes for 10 pages and %d bytes for 50 pages (ratio %.1f)", small, large, ratio)
+	}
+}
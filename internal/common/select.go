@@ -0,0 +1,43 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// GetSelected populates `out` with the object at the specified entity path (e.g. `/groups/{id}`), requesting only the
+// specified properties with `$select`. This substantially reduces the size of the response for objects with many
+// properties, but note that properties not included in the list will be absent from the result. As with the Get
+// methods of the msgraph clients, a 404 response is retried to allow for replication delays.
+func GetSelected(ctx context.Context, client msgraph.Client, entity string, properties []string, out interface{}) (int, error) {
+	resp, status, _, err := client.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      entity,
+			Params:      url.Values{"$select": []string{strings.Join(properties, ",")}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return status, nil
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func TestGetSelected(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if expected := "/v1.0/00000000-0000-0000-0000-000000000000/groups/11111111-1111-1111-1111-111111111111"; r.URL.Path != expected {
+				t.Errorf("expected path %q, got %q", expected, r.URL.Path)
+			}
+			if expected, got := "id,displayName", r.URL.Query().Get("$select"); got != expected {
+				t.Errorf("expected $select %q, got %q", expected, got)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"id":"11111111-1111-1111-1111-111111111111","displayName":"acctest"}`)
+		})
+
+		var group msgraph.Group
+		status, err := GetSelected(ctx, client, "/groups/11111111-1111-1111-1111-111111111111", []string{"id", "displayName"}, &group)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, status)
+		}
+		if group.DisplayName == nil || *group.DisplayName != "acctest" {
+			t.Fatalf("unexpected display name: %v", group.DisplayName)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		client := testAdvancedQueryClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+		})
+		client.DisableRetries = true
+
+		var group msgraph.Group
+		status, err := GetSelected(ctx, client, "/groups/11111111-1111-1111-1111-111111111111", []string{"id"}, &group)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if status != http.StatusNotFound {
+			t.Fatalf("expected status %d, got %d", http.StatusNotFound, status)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+)
+
+// CredentialParentNotFoundError is returned when the application or service principal that owns a credential does not exist
+type CredentialParentNotFoundError struct {
+	ParentType string
+	ObjectId   string
+}
+
+func (e CredentialParentNotFoundError) Error() string {
+	return fmt.Sprintf("%s with object ID %q was not found", e.ParentType, e.ObjectId)
+}
+
+// CredentialParent describes the parent object of a credential resource, and is used to consistently handle the
+// parent being absent throughout the lifecycle of the credential resource.
+type CredentialParent struct {
+	// ResourceType is the name of the credential resource, e.g. `azuread_application_password`
+	ResourceType string
+
+	// ParentType is a human readable name for the parent object, e.g. `application`
+	ParentType string
+
+	// Attr is the schema attribute holding the parent object ID
+	Attr string
+
+	// ObjectId is the object ID of the parent
+	ObjectId string
+}
+
+func (p CredentialParent) notFoundError() CredentialParentNotFoundError {
+	return CredentialParentNotFoundError{
+		ParentType: p.ParentType,
+		ObjectId:   p.ObjectId,
+	}
+}
+
+// CheckCreate should be called with the result of retrieving the parent prior to creating a credential. It returns an
+// error diagnostic pointing at the parent ID attribute when the parent does not exist or could not be retrieved.
+func (p CredentialParent) CheckCreate(status int, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if status == http.StatusNotFound {
+		return tf.ErrorDiagPathF(p.notFoundError(), p.Attr, "Could not create %s: the %s with object ID %q does not exist", p.ResourceType, p.ParentType, p.ObjectId)
+	}
+	return tf.ErrorDiagPathF(err, p.Attr, "Retrieving %s with object ID %q", p.ParentType, p.ObjectId)
+}
+
+// CheckRead should be called with the result of retrieving the parent when reading a credential. When the parent does
+// not exist, the resource is removed from state and `gone` is true.
+func (p CredentialParent) CheckRead(d *schema.ResourceData, status int, err error) (gone bool, diags diag.Diagnostics) {
+	if err == nil {
+		return false, nil
+	}
+	if status == http.StatusNotFound {
+		log.Printf("[DEBUG] Parent %s with object ID %q for %s %q was not found - removing from state!", p.ParentType, p.ObjectId, p.ResourceType, d.Id())
+		d.SetId("")
+		return true, nil
+	}
+	return false, tf.ErrorDiagPathF(err, p.Attr, "Retrieving %s with object ID %q", p.ParentType, p.ObjectId)
+}
+
+// CheckDelete should be called with the result of retrieving or updating the parent when deleting a credential. When
+// the parent does not exist, the credential is considered to be already deleted and `gone` is true.
+func (p CredentialParent) CheckDelete(status int, err error) (gone bool, diags diag.Diagnostics) {
+	if err == nil {
+		return false, nil
+	}
+	if status == http.StatusNotFound {
+		log.Printf("[DEBUG] Parent %s with object ID %q for %s was not found - assuming credential was already deleted", p.ParentType, p.ObjectId, p.ResourceType)
+		return true, nil
+	}
+	return false, tf.ErrorDiagPathF(err, p.Attr, "Retrieving %s with object ID %q", p.ParentType, p.ObjectId)
+}
@@ -0,0 +1,90 @@
+package helpers
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testCredentialParent() CredentialParent {
+	return CredentialParent{
+		ResourceType: "azuread_application_password",
+		ParentType:   "application",
+		Attr:         "application_object_id",
+		ObjectId:     "00000000-0000-0000-0000-000000000000",
+	}
+}
+
+func TestCredentialParentCheckCreate(t *testing.T) {
+	p := testCredentialParent()
+
+	if diags := p.CheckCreate(http.StatusOK, nil); diags.HasError() {
+		t.Fatalf("expected no error when parent exists, got: %+v", diags)
+	}
+
+	diags := p.CheckCreate(http.StatusNotFound, errors.New("not found"))
+	if !diags.HasError() {
+		t.Fatal("expected an error when parent does not exist")
+	}
+	if got := diags[0].Detail; got != p.notFoundError().Error() {
+		t.Fatalf("expected detail %q, got %q", p.notFoundError().Error(), got)
+	}
+	if len(diags[0].AttributePath) != 1 {
+		t.Fatalf("expected diagnostic to have an attribute path")
+	}
+
+	if diags := p.CheckCreate(http.StatusInternalServerError, errors.New("boom")); !diags.HasError() {
+		t.Fatal("expected an error for an unexpected status")
+	}
+}
+
+func TestCredentialParentCheckRead(t *testing.T) {
+	p := testCredentialParent()
+	newData := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId(p.ObjectId + "/password/11111111-1111-1111-1111-111111111111")
+		return d
+	}
+
+	d := newData()
+	if gone, diags := p.CheckRead(d, http.StatusOK, nil); gone || diags.HasError() {
+		t.Fatalf("expected parent to exist, got gone=%t, diags=%+v", gone, diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected ID to be retained when parent exists")
+	}
+
+	d = newData()
+	if gone, diags := p.CheckRead(d, http.StatusNotFound, errors.New("not found")); !gone || diags.HasError() {
+		t.Fatalf("expected parent to be gone without error, got gone=%t, diags=%+v", gone, diags)
+	}
+	if d.Id() != "" {
+		t.Fatal("expected ID to be cleared when parent is gone")
+	}
+
+	d = newData()
+	if gone, diags := p.CheckRead(d, http.StatusInternalServerError, errors.New("boom")); gone || !diags.HasError() {
+		t.Fatalf("expected an error for an unexpected status, got gone=%t, diags=%+v", gone, diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("expected ID to be retained on error")
+	}
+}
+
+func TestCredentialParentCheckDelete(t *testing.T) {
+	p := testCredentialParent()
+
+	if gone, diags := p.CheckDelete(http.StatusOK, nil); gone || diags.HasError() {
+		t.Fatalf("expected parent to exist, got gone=%t, diags=%+v", gone, diags)
+	}
+
+	if gone, diags := p.CheckDelete(http.StatusNotFound, errors.New("not found")); !gone || diags.HasError() {
+		t.Fatalf("expected parent to be gone without error, got gone=%t, diags=%+v", gone, diags)
+	}
+
+	if gone, diags := p.CheckDelete(http.StatusInternalServerError, errors.New("boom")); gone || !diags.HasError() {
+		t.Fatalf("expected an error for an unexpected status, got gone=%t, diags=%+v", gone, diags)
+	}
+}
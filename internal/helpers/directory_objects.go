@@ -0,0 +1,193 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const (
+	DirectoryObjectTypeApplication      = "application"
+	DirectoryObjectTypeGroup            = "group"
+	DirectoryObjectTypeServicePrincipal = "servicePrincipal"
+	DirectoryObjectTypeUser             = "user"
+)
+
+// SkipImportTypeValidationEnvVar is the name of an environment variable which, when set to a non-empty value, disables
+// verification of the directory object type when importing resources.
+const SkipImportTypeValidationEnvVar = "ARM_SKIP_IMPORT_TYPE_VALIDATION"
+
+// directoryObjectsGetByIdsMaxSize is the maximum number of IDs permitted in a single getByIds request
+const directoryObjectsGetByIdsMaxSize = 1000
+
+// DirectoryObjectSummary describes the type and display name of a directory object
+type DirectoryObjectSummary struct {
+	ID          string
+	Type        string
+	DisplayName string
+}
+
+// DirectoryObjectsGetByIds resolves the type and display name for the specified directory object IDs using the
+// getByIds action, which retrieves up to 1000 objects per request. Objects which could not be found are omitted.
+func DirectoryObjectsGetByIds(ctx context.Context, client msgraph.Client, ids []string) ([]DirectoryObjectSummary, error) {
+	result := make([]DirectoryObjectSummary, 0, len(ids))
+
+	for start := 0; start < len(ids); start += directoryObjectsGetByIdsMaxSize {
+		end := start + directoryObjectsGetByIdsMaxSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		body, err := json.Marshal(struct {
+			Ids []string `json:"ids"`
+		}{ids[start:end]})
+		if err != nil {
+			return nil, fmt.Errorf("json.Marshal(): %v", err)
+		}
+
+		resp, _, _, err := client.Post(ctx, msgraph.PostHttpRequestInput{
+			Body:             body,
+			ValidStatusCodes: []int{http.StatusOK},
+			Uri: msgraph.Uri{
+				Entity:      "/directoryObjects/getByIds",
+				HasTenantId: true,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("DirectoryObjects.BaseClient.Post(): %v", err)
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("io.ReadAll(): %v", err)
+		}
+
+		var data struct {
+			Value []struct {
+				ID          *string `json:"id"`
+				Type        string  `json:"@odata.type"`
+				DisplayName *string `json:"displayName"`
+			} `json:"value"`
+		}
+		if err := json.Unmarshal(respBody, &data); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+
+		for _, v := range data.Value {
+			if v.ID == nil {
+				continue
+			}
+			summary := DirectoryObjectSummary{
+				ID:   *v.ID,
+				Type: strings.TrimPrefix(v.Type, "#microsoft.graph."),
+			}
+			if v.DisplayName != nil {
+				summary.DisplayName = *v.DisplayName
+			}
+			result = append(result, summary)
+		}
+	}
+
+	return result, nil
+}
+
+// ValidateOwnerObjectTypes verifies that none of the specified owners are groups. Azure Active Directory does not
+// support groups as owners of groups or applications, but the API rejects them with a misleading error only once the
+// owners are added. The owners are resolved with a single getByIds request, and any objects which are not found are
+// left for the API to reject.
+func ValidateOwnerObjectTypes(ctx context.Context, client msgraph.Client, ownedObjectType string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	objects, err := DirectoryObjectsGetByIds(ctx, client, ids)
+	if err != nil {
+		return fmt.Errorf("retrieving owner objects: %v", err)
+	}
+
+	groups := make([]string, 0)
+	for _, object := range objects {
+		if strings.EqualFold(object.Type, DirectoryObjectTypeGroup) {
+			groups = append(groups, fmt.Sprintf("%s (%q)", object.ID, object.DisplayName))
+		}
+	}
+	if len(groups) > 0 {
+		return fmt.Errorf("groups cannot be owners of %ss in Azure Active Directory, only users and service principals are supported, but the following owners are groups: %s", ownedObjectType, strings.Join(groups, ", "))
+	}
+
+	return nil
+}
+
+// DirectoryObjectType retrieves the directory object with the specified ID and returns its type, with any OData
+// namespace removed, e.g. `user` or `servicePrincipal`.
+func DirectoryObjectType(ctx context.Context, client msgraph.Client, id string) (string, int, error) {
+	resp, status, _, err := client.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/directoryObjects/%s", id),
+			Params:      url.Values{"$select": []string{"id"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return "", status, fmt.Errorf("DirectoryObjects.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var object struct {
+		Type string `json:"@odata.type"`
+	}
+	if err := json.Unmarshal(respBody, &object); err != nil {
+		return "", status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return strings.TrimPrefix(object.Type, "#microsoft.graph."), status, nil
+}
+
+// ValidateDirectoryObjectType verifies that the directory object with the specified ID is of the expected type, so
+// that resources cannot be imported using the object ID of a different kind of object. Validation is skipped when the
+// environment variable named by SkipImportTypeValidationEnvVar is set.
+func ValidateDirectoryObjectType(ctx context.Context, client msgraph.Client, id, expectedType string) error {
+	if os.Getenv(SkipImportTypeValidationEnvVar) != "" {
+		return nil
+	}
+
+	objectType, status, err := DirectoryObjectType(ctx, client, id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return fmt.Errorf("object %s was not found", id)
+		}
+		return fmt.Errorf("retrieving directory object %s: %v", id, err)
+	}
+
+	if !strings.EqualFold(objectType, expectedType) {
+		return fmt.Errorf("object %s is %s, not %s", id, directoryObjectTypeName(objectType), directoryObjectTypeName(expectedType))
+	}
+
+	return nil
+}
+
+// directoryObjectTypeName returns a readable name for the object type, prefixed with an indefinite article, e.g. `a User`
+func directoryObjectTypeName(objectType string) string {
+	if objectType == "" {
+		return "an unknown object"
+	}
+	name := strings.ToUpper(objectType[:1]) + objectType[1:]
+	if strings.ContainsAny(name[:1], "AEIO") {
+		return "an " + name
+	}
+	return "a " + name
+}
@@ -0,0 +1,138 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func testDirectoryObjectsClient(t *testing.T, objectType string) msgraph.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if expected := "/v1.0/00000000-0000-0000-0000-000000000000/directoryObjects/11111111-1111-1111-1111-111111111111"; r.URL.Path != expected {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"@odata.type":"#microsoft.graph.%s","id":"11111111-1111-1111-1111-111111111111"}`, objectType)
+	}))
+	t.Cleanup(server.Close)
+
+	client := msgraph.NewClient(msgraph.Version10, "00000000-0000-0000-0000-000000000000")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+	client.DisableRetries = true
+	return client
+}
+
+func TestValidateDirectoryObjectType(t *testing.T) {
+	ctx := context.Background()
+	id := "11111111-1111-1111-1111-111111111111"
+
+	cases := []struct {
+		objectType   string
+		expectedType string
+		expectedErr  string
+	}{
+		{objectType: "group", expectedType: DirectoryObjectTypeGroup},
+		{objectType: "user", expectedType: DirectoryObjectTypeGroup, expectedErr: "object " + id + " is a User, not a Group"},
+		{objectType: "group", expectedType: DirectoryObjectTypeUser, expectedErr: "object " + id + " is a Group, not a User"},
+		{objectType: "application", expectedType: DirectoryObjectTypeServicePrincipal, expectedErr: "object " + id + " is an Application, not a ServicePrincipal"},
+		{objectType: "servicePrincipal", expectedType: DirectoryObjectTypeApplication, expectedErr: "object " + id + " is a ServicePrincipal, not an Application"},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s as %s", c.objectType, c.expectedType), func(t *testing.T) {
+			err := ValidateDirectoryObjectType(ctx, testDirectoryObjectsClient(t, c.objectType), id, c.expectedType)
+			if c.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.expectedErr {
+				t.Fatalf("expected error %q, got: %v", c.expectedErr, err)
+			}
+		})
+	}
+
+	t.Run("not found", func(t *testing.T) {
+		err := ValidateDirectoryObjectType(ctx, testDirectoryObjectsClient(t, "group"), "22222222-2222-2222-2222-222222222222", DirectoryObjectTypeGroup)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		os.Setenv(SkipImportTypeValidationEnvVar, "1")
+		defer os.Unsetenv(SkipImportTypeValidationEnvVar)
+
+		if err := ValidateDirectoryObjectType(ctx, testDirectoryObjectsClient(t, "user"), id, DirectoryObjectTypeGroup); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestValidateOwnerObjectTypes(t *testing.T) {
+	ctx := context.Background()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if expected := "/v1.0/00000000-0000-0000-0000-000000000000/directoryObjects/getByIds"; r.URL.Path != expected {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+			return
+		}
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"value":[
+			{"@odata.type":"#microsoft.graph.user","id":"11111111-1111-1111-1111-111111111111","displayName":"Test User"},
+			{"@odata.type":"#microsoft.graph.servicePrincipal","id":"22222222-2222-2222-2222-222222222222","displayName":"Test Service Principal"},
+			{"@odata.type":"#microsoft.graph.group","id":"33333333-3333-3333-3333-333333333333","displayName":"Test Group"}
+		]}`)
+	}))
+	defer server.Close()
+
+	client := msgraph.NewClient(msgraph.Version10, "00000000-0000-0000-0000-000000000000")
+	client.Endpoint = environments.ApiEndpoint(server.URL)
+	client.DisableRetries = true
+
+	ids := []string{
+		"11111111-1111-1111-1111-111111111111",
+		"22222222-2222-2222-2222-222222222222",
+		"33333333-3333-3333-3333-333333333333",
+		"44444444-4444-4444-4444-444444444444",
+	}
+
+	err := ValidateOwnerObjectTypes(ctx, client, DirectoryObjectTypeGroup, ids)
+	if err == nil {
+		t.Fatal("expected an error for a group owner")
+	}
+	for _, expected := range []string{"groups cannot be owners of groups", `33333333-3333-3333-3333-333333333333 ("Test Group")`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected error to contain %q, got: %v", expected, err)
+		}
+	}
+	for _, unexpected := range []string{"11111111", "22222222", "44444444"} {
+		if strings.Contains(err.Error(), unexpected) {
+			t.Errorf("expected error not to contain %q, got: %v", unexpected, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+
+	if err := ValidateOwnerObjectTypes(ctx, client, DirectoryObjectTypeApplication, nil); err != nil {
+		t.Fatalf("unexpected error with no owners: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no request with no owners, got %d requests", requests)
+	}
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// DirectoryRoleFind returns the activated directory role matching either the display name (case-insensitively) or the
+// role template ID. A nil role is returned when no activated role matches.
+func DirectoryRoleFind(ctx context.Context, client *msgraph.DirectoryRolesClient, displayName, templateId string) (*msgraph.DirectoryRole, error) {
+	roles, _, err := client.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing directory roles: %v", err)
+	}
+	if roles == nil {
+		return nil, fmt.Errorf("listing directory roles: API returned nil result")
+	}
+
+	for _, role := range *roles {
+		if templateId != "" && role.RoleTemplateId != nil && strings.EqualFold(*role.RoleTemplateId, templateId) {
+			return &role, nil
+		}
+		if displayName != "" && role.DisplayName != nil && strings.EqualFold(*role.DisplayName, displayName) {
+			return &role, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DirectoryRoleTemplatesList retrieves all directory role templates. Implementations may cache the result, since role
+// templates are built in and cannot change.
+type DirectoryRoleTemplatesList func(ctx context.Context) (*[]msgraph.DirectoryRoleTemplate, error)
+
+// DirectoryRoleTemplateFind returns the directory role template matching either the display name (case-insensitively)
+// or the template ID. A nil template is returned when no template matches.
+func DirectoryRoleTemplateFind(ctx context.Context, listTemplates DirectoryRoleTemplatesList, displayName, templateId string) (*msgraph.DirectoryRoleTemplate, error) {
+	templates, err := listTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing directory role templates: %v", err)
+	}
+	if templates == nil {
+		return nil, fmt.Errorf("listing directory role templates: API returned nil result")
+	}
+
+	for _, template := range *templates {
+		if templateId != "" && template.ID != nil && strings.EqualFold(*template.ID, templateId) {
+			return &template, nil
+		}
+		if displayName != "" && template.DisplayName != nil && strings.EqualFold(*template.DisplayName, displayName) {
+			return &template, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// DirectoryRoleResolve returns the activated directory role matching either the display name or the role template ID,
+// along with the template for the role. Built-in roles must be activated from their template before they can be
+// assigned, so the template is returned even when the role has not yet been activated, in which case the role is nil.
+// Both are nil when neither an activated role nor a template matches.
+func DirectoryRoleResolve(ctx context.Context, rolesClient *msgraph.DirectoryRolesClient, listTemplates DirectoryRoleTemplatesList, displayName, templateId string) (*msgraph.DirectoryRole, *msgraph.DirectoryRoleTemplate, error) {
+	role, err := DirectoryRoleFind(ctx, rolesClient, displayName, templateId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if role != nil && role.RoleTemplateId != nil {
+		templateId = *role.RoleTemplateId
+	}
+
+	template, err := DirectoryRoleTemplateFind(ctx, listTemplates, displayName, templateId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return role, template, nil
+}
@@ -0,0 +1,102 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+func testDirectoryRolesClients(t *testing.T) (*msgraph.DirectoryRolesClient, DirectoryRoleTemplatesList) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1.0/00000000-0000-0000-0000-000000000000/directoryRoles":
+			fmt.Fprint(w, `{"value":[{"id":"11111111-1111-1111-1111-111111111111","displayName":"Global Administrator","roleTemplateId":"62e90394-69f5-4237-9190-012177145e10"}]}`)
+		case "/v1.0/00000000-0000-0000-0000-000000000000/directoryRoleTemplates":
+			fmt.Fprint(w, `{"value":[{"id":"62e90394-69f5-4237-9190-012177145e10","displayName":"Global Administrator"},{"id":"fe930be7-5e62-47db-91af-98c3a49a38b1","displayName":"User Administrator"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	rolesClient := msgraph.NewDirectoryRolesClient("00000000-0000-0000-0000-000000000000")
+	rolesClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	rolesClient.BaseClient.DisableRetries = true
+
+	templatesClient := msgraph.NewDirectoryRoleTemplatesClient("00000000-0000-0000-0000-000000000000")
+	templatesClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	templatesClient.BaseClient.DisableRetries = true
+
+	listTemplates := func(ctx context.Context) (*[]msgraph.DirectoryRoleTemplate, error) {
+		templates, _, err := templatesClient.List(ctx)
+		return templates, err
+	}
+
+	return rolesClient, listTemplates
+}
+
+func TestDirectoryRoleResolve(t *testing.T) {
+	rolesClient, listTemplates := testDirectoryRolesClients(t)
+
+	cases := []struct {
+		name             string
+		displayName      string
+		templateId       string
+		expectRoleId     string
+		expectTemplateId string
+	}{
+		{
+			name:             "activated role by display name",
+			displayName:      "global administrator",
+			expectRoleId:     "11111111-1111-1111-1111-111111111111",
+			expectTemplateId: "62e90394-69f5-4237-9190-012177145e10",
+		},
+		{
+			name:             "activated role by template ID",
+			templateId:       "62E90394-69F5-4237-9190-012177145E10",
+			expectRoleId:     "11111111-1111-1111-1111-111111111111",
+			expectTemplateId: "62e90394-69f5-4237-9190-012177145e10",
+		},
+		{
+			name:             "role not activated",
+			displayName:      "User Administrator",
+			expectTemplateId: "fe930be7-5e62-47db-91af-98c3a49a38b1",
+		},
+		{
+			name:        "no matching role or template",
+			displayName: "Nonexistent Administrator",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			role, template, err := DirectoryRoleResolve(context.Background(), rolesClient, listTemplates, c.displayName, c.templateId)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			roleId := ""
+			if role != nil && role.ID != nil {
+				roleId = *role.ID
+			}
+			if roleId != c.expectRoleId {
+				t.Errorf("expected role ID %q, got %q", c.expectRoleId, roleId)
+			}
+
+			templateId := ""
+			if template != nil && template.ID != nil {
+				templateId = *template.ID
+			}
+			if templateId != c.expectTemplateId {
+				t.Errorf("expected template ID %q, got %q", c.expectTemplateId, templateId)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DuplicateNameObject is an existing object considered when checking for duplicate names
+type DuplicateNameObject struct {
+	ID   *string
+	Name *string
+}
+
+// DuplicateNameListFunc returns the objects matching an OData filter
+type DuplicateNameListFunc func(ctx context.Context, filter string) ([]DuplicateNameObject, error)
+
+// DuplicateNameFind returns the ID of an existing object having exactly the specified name for property, e.g.
+// `displayName`, ignoring the object with currentId so that an object is never reported as a duplicate of itself. The
+// currentId should be empty when the object is yet to be created. An empty ID is returned when there is no duplicate.
+func DuplicateNameFind(ctx context.Context, list DuplicateNameListFunc, property, name, currentId string) (string, error) {
+	filter := ODataEq(property, name)
+	objects, err := list(ctx, filter)
+	if err != nil {
+		return "", fmt.Errorf("listing objects with filter %q: %v", filter, err)
+	}
+
+	for _, o := range objects {
+		// The API matches case-insensitively, but only an exact match is considered a duplicate
+		if o.Name == nil || *o.Name != name {
+			continue
+		}
+		if o.ID == nil {
+			return "", errors.New("API returned object with nil object ID during duplicate name check")
+		}
+		if !strings.EqualFold(*o.ID, currentId) {
+			return *o.ID, nil
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,106 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestDuplicateNameFind(t *testing.T) {
+	objects := []DuplicateNameObject{
+		{ID: utils.String("11111111-1111-1111-1111-111111111111"), Name: utils.String("acctest-one")},
+		{ID: utils.String("22222222-2222-2222-2222-222222222222"), Name: utils.String("ACCTEST-ONE")},
+		{ID: utils.String("cccccccc-3333-3333-3333-333333333333"), Name: utils.String("O'Brien")},
+	}
+
+	var filters []string
+	list := func(_ context.Context, filter string) ([]DuplicateNameObject, error) {
+		filters = append(filters, filter)
+		return objects, nil
+	}
+
+	cases := []struct {
+		name           string
+		value          string
+		currentId      string
+		expectedId     string
+		expectedFilter string
+	}{
+		{
+			name:           "create with duplicate",
+			value:          "acctest-one",
+			expectedId:     "11111111-1111-1111-1111-111111111111",
+			expectedFilter: "displayName eq 'acctest-one'",
+		},
+		{
+			name:       "create with duplicate differing only by case",
+			value:      "acctest-One",
+			expectedId: "",
+		},
+		{
+			name:       "existing object is not its own duplicate",
+			value:      "acctest-one",
+			currentId:  "11111111-1111-1111-1111-111111111111",
+			expectedId: "",
+		},
+		{
+			name:       "existing object ID differing by case",
+			value:      "O'Brien",
+			currentId:  "CCCCCCCC-3333-3333-3333-333333333333",
+			expectedId: "",
+		},
+		{
+			name:       "rename to name of another object",
+			value:      "ACCTEST-ONE",
+			currentId:  "11111111-1111-1111-1111-111111111111",
+			expectedId: "22222222-2222-2222-2222-222222222222",
+		},
+		{
+			name:           "quotes are escaped",
+			value:          "O'Brien",
+			expectedId:     "cccccccc-3333-3333-3333-333333333333",
+			expectedFilter: "displayName eq 'O''Brien'",
+		},
+		{
+			name:       "no duplicate",
+			value:      "acctest-two",
+			expectedId: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filters = nil
+			id, err := DuplicateNameFind(context.Background(), list, "displayName", c.value, c.currentId)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != c.expectedId {
+				t.Fatalf("expected ID %q, got %q", c.expectedId, id)
+			}
+			if c.expectedFilter != "" && (len(filters) != 1 || filters[0] != c.expectedFilter) {
+				t.Fatalf("expected filter %q, got %v", c.expectedFilter, filters)
+			}
+		})
+	}
+}
+
+func TestDuplicateNameFindErrors(t *testing.T) {
+	listErr := errors.New("unexpected status 403")
+	failing := func(_ context.Context, _ string) ([]DuplicateNameObject, error) {
+		return nil, listErr
+	}
+	if _, err := DuplicateNameFind(context.Background(), failing, "displayName", "acctest", ""); err == nil || !strings.Contains(err.Error(), listErr.Error()) {
+		t.Fatalf("expected list error, got: %v", err)
+	}
+
+	nilId := func(_ context.Context, _ string) ([]DuplicateNameObject, error) {
+		return []DuplicateNameObject{{Name: utils.String("acctest")}}, nil
+	}
+	if _, err := DuplicateNameFind(context.Background(), nilId, "displayName", "acctest", ""); err == nil {
+		t.Fatal("expected an error for an object with nil ID")
+	}
+}
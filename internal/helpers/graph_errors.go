@@ -0,0 +1,165 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// graphErrorPattern matches the errors returned by the msgraph clients for unexpected response statuses, which are
+// prefixed with the name of the client method and include either the parsed OData error or the raw response body
+var graphErrorPattern = regexp.MustCompile(`(?s)^(.*?)unexpected status (\d+) with (OData error|response): (.*)$`)
+
+// GraphErrorDetail describes a single error found in a Microsoft Graph response
+type GraphErrorDetail struct {
+	// Status is the status of the individual response when the error was returned within a batch response
+	Status    int
+	Code      string
+	Message   string
+	RequestId string
+}
+
+func (e GraphErrorDetail) String() string {
+	parts := make([]string, 0, 2)
+	if e.Code != "" {
+		parts = append(parts, e.Code)
+	}
+	if e.Message != "" {
+		parts = append(parts, e.Message)
+	}
+	s := strings.Join(parts, ": ")
+	if e.Status != 0 {
+		s = fmt.Sprintf("status %d: %s", e.Status, s)
+	}
+	if e.RequestId != "" {
+		s = fmt.Sprintf("%s (request ID: %s)", s, e.RequestId)
+	}
+	return s
+}
+
+// ParseGraphErrors extracts the errors from a Microsoft Graph response body, which may be nested at any depth, such as
+// within the individual responses of a batch response. Both the `error` and the legacy `odata.error` forms are
+// recognised. No errors are returned when the body is not JSON.
+func ParseGraphErrors(body []byte) []GraphErrorDetail {
+	var raw interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+	return findGraphErrors(raw, 0)
+}
+
+func findGraphErrors(v interface{}, status int) []GraphErrorDetail {
+	switch value := v.(type) {
+	case []interface{}:
+		result := make([]GraphErrorDetail, 0)
+		for _, item := range value {
+			result = append(result, findGraphErrors(item, status)...)
+		}
+		return result
+
+	case map[string]interface{}:
+		for _, key := range []string{"error", "odata.error"} {
+			if e, ok := value[key].(map[string]interface{}); ok {
+				if detail, ok := parseGraphError(e, status); ok {
+					return []GraphErrorDetail{detail}
+				}
+			}
+		}
+
+		// An individual response within a batch response has a numeric status alongside its body
+		if s, ok := value["status"].(float64); ok {
+			if _, ok := value["body"]; ok {
+				status = int(s)
+			}
+		}
+
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		result := make([]GraphErrorDetail, 0)
+		for _, k := range keys {
+			result = append(result, findGraphErrors(value[k], status)...)
+		}
+		return result
+	}
+
+	return nil
+}
+
+func parseGraphError(e map[string]interface{}, status int) (GraphErrorDetail, bool) {
+	detail := GraphErrorDetail{Status: status}
+
+	if v, ok := e["code"].(string); ok {
+		detail.Code = v
+	}
+
+	// The message is sometimes a string, and sometimes an object with the message in its value
+	switch v := e["message"].(type) {
+	case string:
+		detail.Message = v
+	case map[string]interface{}:
+		if s, ok := v["value"].(string); ok {
+			detail.Message = s
+		}
+	}
+
+	if v, ok := e["requestId"].(string); ok {
+		detail.RequestId = v
+	}
+	for _, key := range []string{"innerError", "innererror"} {
+		if inner, ok := e[key].(map[string]interface{}); ok {
+			if v, ok := inner["request-id"].(string); ok && v != "" {
+				detail.RequestId = v
+			}
+		}
+	}
+
+	return detail, detail.Code != "" || detail.Message != ""
+}
+
+// GraphError simplifies an error returned by an msgraph client for an unexpected response status, so that it includes
+// only the code, message and request ID of each error in the response, rather than the entire response body. The raw
+// response body is logged at DEBUG level. Any other error, or one whose response does not contain a recognisable
+// error, is returned unchanged.
+func GraphError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	m := graphErrorPattern.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+	prefix, status, kind, text := m[1], m[2], m[3], m[4]
+
+	if kind == "OData error" {
+		return fmt.Errorf("%sunexpected status %s: %s", prefix, status, text)
+	}
+
+	details := ParseGraphErrors([]byte(text))
+	if len(details) == 0 {
+		return err
+	}
+
+	log.Printf("[DEBUG] Microsoft Graph returned status %s with response: %s", status, text)
+
+	if len(details) == 1 {
+		if s, _ := strconv.Atoi(status); details[0].Status == s {
+			details[0].Status = 0
+		}
+		return fmt.Errorf("%sunexpected status %s: %s", prefix, status, details[0])
+	}
+
+	lines := make([]string, 0, len(details))
+	for _, d := range details {
+		lines = append(lines, fmt.Sprintf("- %s", d))
+	}
+	return fmt.Errorf("%sunexpected status %s with %d errors:\n%s", prefix, status, len(details), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGraphError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "member already exists",
+			err:      errors.New(`GroupsClient.BaseClient.Patch(): unexpected status 400 with response: {"error":{"code":"Request_BadRequest","message":"One or more added object references already exist for the following modified properties: 'members'.","innerError":{"date":"2021-11-03T14:26:09","request-id":"5d2b7d1b-35f1-4d5c-a8ba-2b4bd6fbb5e4","client-request-id":"5d2b7d1b-35f1-4d5c-a8ba-2b4bd6fbb5e4"}}}`),
+			expected: `GroupsClient.BaseClient.Patch(): unexpected status 400: Request_BadRequest: One or more added object references already exist for the following modified properties: 'members'. (request ID: 5d2b7d1b-35f1-4d5c-a8ba-2b4bd6fbb5e4)`,
+		},
+		{
+			name:     "owner limit exceeded",
+			err:      errors.New(`ApplicationsClient.BaseClient.Post(): unexpected status 400 with response: {"odata.error":{"code":"Request_BadRequest","message":{"lang":"en","value":"The maximum number of owners for this object has been exceeded."},"requestId":"0b6f4d3e-8f2c-4a47-9e3a-6a1c2f0d9b11","date":"2021-11-03T14:31:40"}}`),
+			expected: `ApplicationsClient.BaseClient.Post(): unexpected status 400: Request_BadRequest: The maximum number of owners for this object has been exceeded. (request ID: 0b6f4d3e-8f2c-4a47-9e3a-6a1c2f0d9b11)`,
+		},
+		{
+			name:     "throttled batch item",
+			err:      errors.New(`unexpected status 429 with response: {"responses":[{"id":"1","status":429,"headers":{"Retry-After":"8"},"body":{"error":{"code":"TooManyRequests","message":"Too many requests.","innerError":{"code":"429","date":"2021-11-03T14:40:02","request-id":"9c3a0bb5-7bb1-4e48-8d8c-3bfa7d2b6d84","client-request-id":"9c3a0bb5-7bb1-4e48-8d8c-3bfa7d2b6d84"}}}}]}`),
+			expected: `unexpected status 429: TooManyRequests: Too many requests. (request ID: 9c3a0bb5-7bb1-4e48-8d8c-3bfa7d2b6d84)`,
+		},
+		{
+			name: "several failed batch items",
+			err:  errors.New(`BatchClient.BaseClient.Post(): unexpected status 200 with response: {"responses":[{"id":"0","status":204,"body":null},{"id":"1","status":429,"headers":{"Retry-After":"8"},"body":{"error":{"code":"TooManyRequests","message":"Too many requests.","innerError":{"request-id":"9c3a0bb5-7bb1-4e48-8d8c-3bfa7d2b6d84"}}}},{"id":"2","status":404,"body":{"error":{"code":"Request_ResourceNotFound","message":"Resource '11111111-1111-1111-1111-111111111111' does not exist or one of its queried reference-property objects are not present.","innerError":{"request-id":"2f0e0b7a-48a4-4f7c-9f53-0a8c5c1c7f3e"}}}}]}`),
+			expected: `BatchClient.BaseClient.Post(): unexpected status 200 with 2 errors:
+- status 429: TooManyRequests: Too many requests. (request ID: 9c3a0bb5-7bb1-4e48-8d8c-3bfa7d2b6d84)
+- status 404: Request_ResourceNotFound: Resource '11111111-1111-1111-1111-111111111111' does not exist or one of its queried reference-property objects are not present. (request ID: 2f0e0b7a-48a4-4f7c-9f53-0a8c5c1c7f3e)`,
+		},
+		{
+			name:     "parsed odata error",
+			err:      errors.New(`GroupsClient.BaseClient.Post(): unexpected status 403 with OData error: Authorization_RequestDenied: Insufficient privileges to complete the operation.`),
+			expected: `GroupsClient.BaseClient.Post(): unexpected status 403: Authorization_RequestDenied: Insufficient privileges to complete the operation.`,
+		},
+		{
+			name:     "non-JSON response",
+			err:      errors.New(`GroupsClient.BaseClient.Post(): unexpected status 502 with response: <html>Bad Gateway</html>`),
+			expected: `GroupsClient.BaseClient.Post(): unexpected status 502 with response: <html>Bad Gateway</html>`,
+		},
+		{
+			name:     "JSON response without an error",
+			err:      errors.New(`GroupsClient.BaseClient.Post(): unexpected status 400 with response: {"value":[]}`),
+			expected: `GroupsClient.BaseClient.Post(): unexpected status 400 with response: {"value":[]}`,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("json.Marshal(): unsupported type"),
+			expected: "json.Marshal(): unsupported type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := GraphError(tc.err); actual == nil || actual.Error() != tc.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%v", tc.expected, actual)
+			}
+		})
+	}
+
+	if err := GraphError(nil); err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+func TestParseGraphErrors(t *testing.T) {
+	details := ParseGraphErrors([]byte(`{"error":{"code":"Request_BadRequest","message":"Invalid object identifier 'foo'.","innererror":{"request-id":"3b0a4f1c-0f55-4b8e-9a4b-6f7a2d9c1e21"}}}`))
+	expected := []GraphErrorDetail{{
+		Code:      "Request_BadRequest",
+		Message:   "Invalid object identifier 'foo'.",
+		RequestId: "3b0a4f1c-0f55-4b8e-9a4b-6f7a2d9c1e21",
+	}}
+	if !reflect.DeepEqual(details, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, details)
+	}
+
+	if details := ParseGraphErrors([]byte("not json")); len(details) != 0 {
+		t.Fatalf("expected no errors for a non-JSON body, got %+v", details)
+	}
+}
@@ -0,0 +1,174 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// idConfusionMaxLookups is the maximum number of IDs to investigate when explaining a failed request, since this
+// happens on the error path and should not issue an unbounded number of requests
+const idConfusionMaxLookups = 20
+
+// IdConfusion explains failed requests caused by specifying the wrong kind of ID, for example the client ID of an
+// application where the object ID of its service principal is required. These IDs are all UUIDs, so this is a common
+// mistake which otherwise results in an unhelpful "not found" error at apply time. Lookups are best-effort and are only
+// performed after a request has failed, so that success-path behaviour is unchanged.
+type IdConfusion struct {
+	ApplicationsClient      *msgraph.ApplicationsClient
+	ServicePrincipalsClient *msgraph.ServicePrincipalsClient
+}
+
+// ClientIdError annotates an error resulting from a request which specified the client ID (application ID) of an
+// application, when that ID looks like the object ID of an application.
+func (c IdConfusion) ClientIdError(ctx context.Context, err error, status int, id string) error {
+	if !idConfusionPossible(err, status) {
+		return err
+	}
+
+	if hint := c.clientIdHint(ctx, id); hint != "" {
+		return fmt.Errorf("%v\n\n%s", err, hint)
+	}
+	return err
+}
+
+// PrincipalObjectIdsError annotates an error resulting from a request which specified the object IDs of principals,
+// such as group members or owners, for any IDs that look like the client ID or object ID of an application.
+func (c IdConfusion) PrincipalObjectIdsError(ctx context.Context, err error, status int, ids []string) error {
+	if !idConfusionPossible(err, status) {
+		return err
+	}
+
+	candidates := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.ParseUUID(id); err == nil {
+			candidates = append(candidates, id)
+		}
+		if len(candidates) == idConfusionMaxLookups {
+			break
+		}
+	}
+	if len(candidates) == 0 {
+		return err
+	}
+
+	objects, lookupErr := DirectoryObjectsGetByIds(ctx, c.ServicePrincipalsClient.BaseClient, candidates)
+	if lookupErr != nil {
+		log.Printf("[DEBUG] Unable to look up directory objects when explaining a failed request: %v", lookupErr)
+		return err
+	}
+	objectTypes := make(map[string]string)
+	for _, o := range objects {
+		objectTypes[strings.ToLower(o.ID)] = o.Type
+	}
+
+	hints := make([]string, 0)
+	for _, id := range candidates {
+		objectType, exists := objectTypes[strings.ToLower(id)]
+		switch {
+		case !exists:
+			if hint := c.principalClientIdHint(ctx, id); hint != "" {
+				hints = append(hints, hint)
+			}
+		case objectType == DirectoryObjectTypeApplication:
+			if hint := c.principalApplicationObjectIdHint(ctx, id); hint != "" {
+				hints = append(hints, hint)
+			}
+		}
+	}
+
+	if len(hints) == 0 {
+		return err
+	}
+	return fmt.Errorf("%v\n\n%s", err, strings.Join(hints, "\n"))
+}
+
+// idConfusionPossible returns whether a failed request could have been caused by specifying the wrong kind of ID
+func idConfusionPossible(err error, status int) bool {
+	return err != nil && (status == http.StatusBadRequest || status == http.StatusNotFound)
+}
+
+// clientIdHint explains a client ID which is actually the object ID of an application
+func (c IdConfusion) clientIdHint(ctx context.Context, id string) string {
+	if _, err := uuid.ParseUUID(id); err != nil {
+		return ""
+	}
+
+	app := c.findApplication(ctx, "id", id)
+	if app == nil || app.AppId == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%q looks like the object ID of the application %q, which has the client ID %q. You probably want to specify the client ID instead.", id, idConfusionDisplayName(app.DisplayName), *app.AppId)
+}
+
+// principalClientIdHint explains a principal object ID which is actually the client ID of an application
+func (c IdConfusion) principalClientIdHint(ctx context.Context, id string) string {
+	if sp := c.findServicePrincipal(ctx, id); sp != nil && sp.ID != nil {
+		return fmt.Sprintf("%q looks like the client ID of the application %q. You probably want to specify the object ID of its service principal, %q, instead.", id, idConfusionDisplayName(sp.DisplayName), *sp.ID)
+	}
+
+	if app := c.findApplication(ctx, "appId", id); app != nil {
+		return fmt.Sprintf("%q looks like the client ID of the application %q, which does not have a service principal. You probably want to create a service principal for the application and specify its object ID instead.", id, idConfusionDisplayName(app.DisplayName))
+	}
+
+	return ""
+}
+
+// principalApplicationObjectIdHint explains a principal object ID which is actually the object ID of an application
+func (c IdConfusion) principalApplicationObjectIdHint(ctx context.Context, id string) string {
+	app := c.findApplication(ctx, "id", id)
+	if app == nil {
+		return ""
+	}
+
+	if app.AppId != nil {
+		if sp := c.findServicePrincipal(ctx, *app.AppId); sp != nil && sp.ID != nil {
+			return fmt.Sprintf("%q looks like the object ID of the application %q. You probably want to specify the object ID of its service principal, %q, instead.", id, idConfusionDisplayName(app.DisplayName), *sp.ID)
+		}
+	}
+
+	return fmt.Sprintf("%q looks like the object ID of the application %q, which does not have a service principal. You probably want to create a service principal for the application and specify its object ID instead.", id, idConfusionDisplayName(app.DisplayName))
+}
+
+func (c IdConfusion) findApplication(ctx context.Context, property, value string) *msgraph.Application {
+	if c.ApplicationsClient == nil {
+		return nil
+	}
+	result, _, err := c.ApplicationsClient.List(ctx, ODataEq(property, value))
+	if err != nil {
+		log.Printf("[DEBUG] Unable to look up application with %s %q when explaining a failed request: %v", property, value, err)
+		return nil
+	}
+	if result == nil || len(*result) == 0 {
+		return nil
+	}
+	return &(*result)[0]
+}
+
+func (c IdConfusion) findServicePrincipal(ctx context.Context, appId string) *msgraph.ServicePrincipal {
+	if c.ServicePrincipalsClient == nil {
+		return nil
+	}
+	result, _, err := c.ServicePrincipalsClient.List(ctx, ODataEq("appId", appId))
+	if err != nil {
+		log.Printf("[DEBUG] Unable to look up service principal with client ID %q when explaining a failed request: %v", appId, err)
+		return nil
+	}
+	if result == nil || len(*result) == 0 {
+		return nil
+	}
+	return &(*result)[0]
+}
+
+func idConfusionDisplayName(displayName *string) string {
+	if displayName == nil {
+		return ""
+	}
+	return *displayName
+}
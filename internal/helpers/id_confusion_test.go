@@ -0,0 +1,284 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const (
+	testAppObjectId       = "11111111-1111-1111-1111-111111111111"
+	testAppClientId       = "22222222-2222-2222-2222-222222222222"
+	testSpObjectId        = "33333333-3333-3333-3333-333333333333"
+	testLonelyAppObjectId = "44444444-4444-4444-4444-444444444444"
+	testLonelyAppClientId = "55555555-5555-5555-5555-555555555555"
+	testUserObjectId      = "66666666-6666-6666-6666-666666666666"
+	testUnknownId         = "77777777-7777-7777-7777-777777777777"
+)
+
+type testIdConfusionObject struct {
+	id, appId, objectType, displayName string
+}
+
+// testIdConfusion returns an IdConfusion backed by a fake directory, along with a counter of requests received
+func testIdConfusion(t *testing.T) (IdConfusion, *int) {
+	objects := []testIdConfusionObject{
+		{id: testAppObjectId, appId: testAppClientId, objectType: "application", displayName: "acctest-app"},
+		{id: testSpObjectId, appId: testAppClientId, objectType: "servicePrincipal", displayName: "acctest-app"},
+		{id: testLonelyAppObjectId, appId: testLonelyAppClientId, objectType: "application", displayName: "acctest-lonely"},
+		{id: testUserObjectId, objectType: "user", displayName: "acctest-user"},
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+
+		value := make([]map[string]string, 0)
+		switch r.URL.Path {
+		case "/beta/00000000-0000-0000-0000-000000000000/directoryObjects/getByIds":
+			var body struct {
+				Ids []string `json:"ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			for _, id := range body.Ids {
+				for _, o := range objects {
+					if o.id == id {
+						value = append(value, map[string]string{"@odata.type": "#microsoft.graph." + o.objectType, "id": o.id, "displayName": o.displayName})
+					}
+				}
+			}
+
+		case "/beta/00000000-0000-0000-0000-000000000000/applications", "/beta/00000000-0000-0000-0000-000000000000/servicePrincipals":
+			objectType := "application"
+			if strings.HasSuffix(r.URL.Path, "servicePrincipals") {
+				objectType = "servicePrincipal"
+			}
+			var property, filterValue string
+			if _, err := fmt.Sscanf(r.URL.Query().Get("$filter"), "%s eq %s", &property, &filterValue); err != nil {
+				t.Fatalf("parsing filter %q: %v", r.URL.Query().Get("$filter"), err)
+			}
+			filterValue = strings.Trim(filterValue, "'")
+			for _, o := range objects {
+				if o.objectType != objectType {
+					continue
+				}
+				if (property == "id" && o.id == filterValue) || (property == "appId" && o.appId == filterValue) {
+					value = append(value, map[string]string{"id": o.id, "appId": o.appId, "displayName": o.displayName})
+				}
+			}
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"value": value}); err != nil {
+			t.Fatalf("encoding response: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	applicationsClient := msgraph.NewApplicationsClient("00000000-0000-0000-0000-000000000000")
+	applicationsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	applicationsClient.BaseClient.DisableRetries = true
+
+	servicePrincipalsClient := msgraph.NewServicePrincipalsClient("00000000-0000-0000-0000-000000000000")
+	servicePrincipalsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	servicePrincipalsClient.BaseClient.DisableRetries = true
+
+	return IdConfusion{
+		ApplicationsClient:      applicationsClient,
+		ServicePrincipalsClient: servicePrincipalsClient,
+	}, &requests
+}
+
+func TestIdConfusionClientIdError(t *testing.T) {
+	ctx := context.Background()
+	apiErr := errors.New("ServicePrincipalsClient.BaseClient.Post(): unexpected status 400")
+
+	cases := []struct {
+		name     string
+		id       string
+		status   int
+		err      error
+		expected string
+		lookups  bool
+	}{
+		{
+			name:     "application object ID",
+			id:       testAppObjectId,
+			status:   http.StatusBadRequest,
+			err:      apiErr,
+			expected: fmt.Sprintf("%q looks like the object ID of the application %q, which has the client ID %q", testAppObjectId, "acctest-app", testAppClientId),
+			lookups:  true,
+		},
+		{
+			name:    "unknown ID",
+			id:      testUnknownId,
+			status:  http.StatusBadRequest,
+			err:     apiErr,
+			lookups: true,
+		},
+		{
+			name:   "not a UUID",
+			id:     "foo",
+			status: http.StatusBadRequest,
+			err:    apiErr,
+		},
+		{
+			name:   "other status",
+			id:     testAppObjectId,
+			status: http.StatusForbidden,
+			err:    apiErr,
+		},
+		{
+			name:   "success",
+			id:     testAppObjectId,
+			status: http.StatusCreated,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			idc, requests := testIdConfusion(t)
+			err := idc.ClientIdError(ctx, c.err, c.status, c.id)
+
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if c.expected == "" {
+				if err != c.err {
+					t.Fatalf("expected original error, got: %v", err)
+				}
+			} else if !strings.HasPrefix(err.Error(), c.err.Error()) || !strings.Contains(err.Error(), c.expected) {
+				t.Fatalf("expected error containing %q, got: %v", c.expected, err)
+			}
+
+			if !c.lookups && *requests > 0 {
+				t.Fatalf("expected no lookups, got %d requests", *requests)
+			}
+		})
+	}
+}
+
+func TestIdConfusionPrincipalObjectIdsError(t *testing.T) {
+	ctx := context.Background()
+	apiErr := errors.New("GroupsClient.BaseClient.Post(): unexpected status 404")
+
+	cases := []struct {
+		name     string
+		ids      []string
+		status   int
+		err      error
+		expected []string
+		lookups  bool
+	}{
+		{
+			name:     "application client ID",
+			ids:      []string{testAppClientId},
+			status:   http.StatusNotFound,
+			err:      apiErr,
+			expected: []string{fmt.Sprintf("%q looks like the client ID of the application %q. You probably want to specify the object ID of its service principal, %q", testAppClientId, "acctest-app", testSpObjectId)},
+			lookups:  true,
+		},
+		{
+			name:     "application client ID without service principal",
+			ids:      []string{testLonelyAppClientId},
+			status:   http.StatusNotFound,
+			err:      apiErr,
+			expected: []string{fmt.Sprintf("%q looks like the client ID of the application %q, which does not have a service principal", testLonelyAppClientId, "acctest-lonely")},
+			lookups:  true,
+		},
+		{
+			name:     "application object ID",
+			ids:      []string{testAppObjectId},
+			status:   http.StatusBadRequest,
+			err:      apiErr,
+			expected: []string{fmt.Sprintf("%q looks like the object ID of the application %q. You probably want to specify the object ID of its service principal, %q", testAppObjectId, "acctest-app", testSpObjectId)},
+			lookups:  true,
+		},
+		{
+			name:     "application object ID without service principal",
+			ids:      []string{testLonelyAppObjectId},
+			status:   http.StatusBadRequest,
+			err:      apiErr,
+			expected: []string{fmt.Sprintf("%q looks like the object ID of the application %q, which does not have a service principal", testLonelyAppObjectId, "acctest-lonely")},
+			lookups:  true,
+		},
+		{
+			name:   "multiple IDs",
+			ids:    []string{testUserObjectId, testSpObjectId, testAppClientId, testUnknownId, testLonelyAppObjectId},
+			status: http.StatusNotFound,
+			err:    apiErr,
+			expected: []string{
+				fmt.Sprintf("%q looks like the client ID of the application %q", testAppClientId, "acctest-app"),
+				fmt.Sprintf("%q looks like the object ID of the application %q", testLonelyAppObjectId, "acctest-lonely"),
+			},
+			lookups: true,
+		},
+		{
+			name:    "valid principals",
+			ids:     []string{testUserObjectId, testSpObjectId},
+			status:  http.StatusNotFound,
+			err:     apiErr,
+			lookups: true,
+		},
+		{
+			name:   "other status",
+			ids:    []string{testAppClientId},
+			status: http.StatusForbidden,
+			err:    apiErr,
+		},
+		{
+			name:   "success",
+			ids:    []string{testAppClientId},
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			idc, requests := testIdConfusion(t)
+			err := idc.PrincipalObjectIdsError(ctx, c.err, c.status, c.ids)
+
+			if c.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			} else if len(c.expected) == 0 {
+				if err != c.err {
+					t.Fatalf("expected original error, got: %v", err)
+				}
+			} else {
+				if !strings.HasPrefix(err.Error(), c.err.Error()) {
+					t.Fatalf("expected error to begin with %q, got: %v", c.err.Error(), err)
+				}
+				for _, expected := range c.expected {
+					if !strings.Contains(err.Error(), expected) {
+						t.Fatalf("expected error containing %q, got: %v", expected, err)
+					}
+				}
+				if hints := strings.Count(err.Error(), " looks like "); hints != len(c.expected) {
+					t.Fatalf("expected %d hints, got %d: %v", len(c.expected), hints, err)
+				}
+			}
+
+			if !c.lookups && *requests > 0 {
+				t.Fatalf("expected no lookups, got %d requests", *requests)
+			}
+		})
+	}
+}
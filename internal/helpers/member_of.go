@@ -0,0 +1,122 @@
+package helpers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+)
+
+// TransitiveMemberOfGroupIds returns the object IDs of all groups that a directory object is a member of, either
+// directly or transitively, sorted for stable ordering in state. The objectPath is the collection path and ID of the object, e.g. `/users/{id}`.
+func TransitiveMemberOfGroupIds(ctx context.Context, client msgraph.Client, objectPath string) ([]string, int, error) {
+	resp, status, _, err := client.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("%s/transitiveMemberOf/microsoft.graph.group", strings.TrimRight(objectPath, "/")),
+			Params:      url.Values{"$select": []string{"id"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Groups []struct {
+			ID *string `json:"id"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	result := make([]string, 0, len(data.Groups))
+	for _, g := range data.Groups {
+		if g.ID != nil {
+			result = append(result, *g.ID)
+		}
+	}
+	sort.Strings(result)
+
+	return result, status, nil
+}
+
+// MemberOfGroups returns the groups that a directory object is a direct member of. The objectPath is the collection
+// path and ID of the object, e.g. `/groups/{id}`.
+func MemberOfGroups(ctx context.Context, client msgraph.Client, objectPath string) ([]DirectoryObjectSummary, int, error) {
+	resp, status, _, err := client.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("%s/memberOf/microsoft.graph.group", strings.TrimRight(objectPath, "/")),
+			Params:      url.Values{"$select": []string{"id,displayName"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Groups []struct {
+			ID          *string `json:"id"`
+			DisplayName *string `json:"displayName"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	result := make([]DirectoryObjectSummary, 0, len(data.Groups))
+	for _, g := range data.Groups {
+		if g.ID == nil {
+			continue
+		}
+		summary := DirectoryObjectSummary{
+			ID:   *g.ID,
+			Type: DirectoryObjectTypeGroup,
+		}
+		if g.DisplayName != nil {
+			summary.DisplayName = *g.DisplayName
+		}
+		result = append(result, summary)
+	}
+
+	return result, status, nil
+}
+
+// FlattenTransitiveMemberOf retrieves the group memberships of a directory object for use in a `member_of` attribute.
+// When the caller lacks permission to read group memberships, an empty list is returned with a warning diagnostic.
+func FlattenTransitiveMemberOf(ctx context.Context, client msgraph.Client, objectPath string) ([]string, diag.Diagnostics) {
+	groupIds, status, err := TransitiveMemberOfGroupIds(ctx, client, objectPath)
+	if err != nil {
+		if status == http.StatusForbidden {
+			return []string{}, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "Insufficient permissions to retrieve group memberships",
+				Detail:   fmt.Sprintf("The `member_of` attribute will be empty. Group memberships for %q could not be retrieved: %v", objectPath, err),
+			}}
+		}
+		return nil, tf.ErrorDiagPathF(err, "member_of", "Retrieving group memberships for %q", objectPath)
+	}
+	return groupIds, nil
+}
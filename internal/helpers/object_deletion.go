@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+)
+
+// objectDeletionVerifyTimeout is the maximum amount of time to spend verifying that a deleted object is gone
+var objectDeletionVerifyTimeout = 2 * time.Minute
+
+// objectDeletionVerifyInterval is the minimum interval between checks when verifying that a deleted object is gone
+var objectDeletionVerifyInterval = 1 * time.Second
+
+// ObjectDeletion describes a directory object being deleted, and is used to consistently handle objects which have
+// already been deleted, e.g. out-of-band or by a previous partial destroy.
+type ObjectDeletion struct {
+	// ObjectType is a human readable name for the object, e.g. `group`
+	ObjectType string
+
+	// ObjectId is the object ID of the object being deleted
+	ObjectId string
+
+	// Strict causes an error to be returned when the object does not exist, rather than treating it as deleted
+	Strict bool
+
+	// Get retrieves the object, returning the HTTP status of the request. Deletion is verified by polling until the
+	// object consistently cannot be found, so Get should not itself retry requests which return 404, e.g. by disabling
+	// retries for the client used.
+	Get func(ctx context.Context) (int, error)
+}
+
+// CheckExists should be called with the result of retrieving the object prior to deleting it. When the object does not
+// exist, it is considered to be already deleted and `gone` is true, unless Strict is set.
+func (o ObjectDeletion) CheckExists(status int, err error) (gone bool, diags diag.Diagnostics) {
+	if err == nil {
+		return false, nil
+	}
+	if status == http.StatusNotFound {
+		if o.Strict {
+			return false, tf.ErrorDiagPathF(fmt.Errorf("%s was not found", o.ObjectType), "id", "Retrieving %s with object ID %q", o.ObjectType, o.ObjectId)
+		}
+		log.Printf("[DEBUG] The %s with object ID %q was not found - assuming it was already deleted", o.ObjectType, o.ObjectId)
+		return true, nil
+	}
+	return false, tf.ErrorDiagPathF(err, "id", "Retrieving %s with object ID %q", o.ObjectType, o.ObjectId)
+}
+
+// CheckDeleted should be called with the result of the delete request. A 404 response may indicate that the object was
+// removed concurrently, or may be caused by replication lag, so in either case the object is confirmed to be gone by
+// polling until it consistently cannot be found.
+func (o ObjectDeletion) CheckDeleted(ctx context.Context, status int, err error) diag.Diagnostics {
+	if err != nil {
+		if status != http.StatusNotFound {
+			return tf.ErrorDiagPathF(err, "id", "Deleting %s with object ID %q, got status %d", o.ObjectType, o.ObjectId, status)
+		}
+		if o.Strict {
+			return tf.ErrorDiagPathF(fmt.Errorf("%s was not found", o.ObjectType), "id", "Deleting %s with object ID %q", o.ObjectType, o.ObjectId)
+		}
+		log.Printf("[DEBUG] Deleting %s with object ID %q returned status %d - verifying that it is gone", o.ObjectType, o.ObjectId, status)
+	}
+
+	if err := o.waitForDeletion(ctx); err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Waiting for deletion of %s with object ID %q", o.ObjectType, o.ObjectId)
+	}
+
+	return nil
+}
+
+func (o ObjectDeletion) waitForDeletion(ctx context.Context) error {
+	if o.Get == nil {
+		return nil
+	}
+
+	timeout := objectDeletionVerifyTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+
+	_, err := (&resource.StateChangeConf{
+		Pending:                   []string{"Exists"},
+		Target:                    []string{"Deleted"},
+		Timeout:                   timeout,
+		MinTimeout:                objectDeletionVerifyInterval,
+		PollInterval:              objectDeletionVerifyInterval,
+		ContinuousTargetOccurence: 3,
+		Refresh: func() (interface{}, string, error) {
+			status, err := o.Get(ctx)
+			if err != nil {
+				if status == http.StatusNotFound {
+					return status, "Deleted", nil
+				}
+				return nil, "Error", fmt.Errorf("retrieving %s with object ID %q: %+v", o.ObjectType, o.ObjectId, err)
+			}
+			log.Printf("[DEBUG] The %s with object ID %q still exists", o.ObjectType, o.ObjectId)
+			return status, "Exists", nil
+		},
+	}).WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("the %s with object ID %q still exists: %+v", o.ObjectType, o.ObjectId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// fakeObject simulates a directory object which disappears after a number of requests
+type fakeObject struct {
+	requests       int
+	existsRequests int
+}
+
+func (o *fakeObject) get(_ context.Context) (int, error) {
+	o.requests++
+	if o.requests <= o.existsRequests {
+		return http.StatusOK, nil
+	}
+	return http.StatusNotFound, errors.New("not found")
+}
+
+func testObjectDeletion(t *testing.T, object *fakeObject, strict bool) ObjectDeletion {
+	interval, timeout := objectDeletionVerifyInterval, objectDeletionVerifyTimeout
+	objectDeletionVerifyInterval, objectDeletionVerifyTimeout = 10*time.Millisecond, 2*time.Second
+	t.Cleanup(func() {
+		objectDeletionVerifyInterval, objectDeletionVerifyTimeout = interval, timeout
+	})
+
+	return ObjectDeletion{
+		ObjectType: "group",
+		ObjectId:   "00000000-0000-0000-0000-000000000000",
+		Strict:     strict,
+		Get:        object.get,
+	}
+}
+
+func TestObjectDeletionCheckExists(t *testing.T) {
+	o := testObjectDeletion(t, &fakeObject{}, false)
+
+	if gone, diags := o.CheckExists(http.StatusOK, nil); gone || diags.HasError() {
+		t.Fatalf("expected object to exist, got gone=%t diags=%+v", gone, diags)
+	}
+	if gone, diags := o.CheckExists(http.StatusNotFound, errors.New("not found")); !gone || diags.HasError() {
+		t.Fatalf("expected missing object to be treated as deleted, got gone=%t diags=%+v", gone, diags)
+	}
+	if gone, diags := o.CheckExists(http.StatusForbidden, errors.New("forbidden")); gone || !diags.HasError() {
+		t.Fatalf("expected an error for other failures, got gone=%t diags=%+v", gone, diags)
+	}
+
+	o.Strict = true
+	if gone, diags := o.CheckExists(http.StatusNotFound, errors.New("not found")); gone || !diags.HasError() {
+		t.Fatalf("expected an error for missing object in strict mode, got gone=%t diags=%+v", gone, diags)
+	}
+}
+
+func TestObjectDeletionCheckDeleted(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deleted", func(t *testing.T) {
+		object := &fakeObject{}
+		if diags := testObjectDeletion(t, object, false).CheckDeleted(ctx, http.StatusNoContent, nil); diags.HasError() {
+			t.Fatalf("unexpected error: %+v", diags)
+		}
+		if object.requests < 3 {
+			t.Fatalf("expected deletion to be verified with at least 3 requests, got %d", object.requests)
+		}
+	})
+
+	t.Run("replication lag after delete", func(t *testing.T) {
+		object := &fakeObject{existsRequests: 2}
+		if diags := testObjectDeletion(t, object, false).CheckDeleted(ctx, http.StatusNoContent, nil); diags.HasError() {
+			t.Fatalf("unexpected error: %+v", diags)
+		}
+		if object.requests < 5 {
+			t.Fatalf("expected polling to continue until the object was gone, got %d requests", object.requests)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		object := &fakeObject{}
+		if diags := testObjectDeletion(t, object, false).CheckDeleted(ctx, http.StatusNotFound, errors.New("not found")); diags.HasError() {
+			t.Fatalf("unexpected error: %+v", diags)
+		}
+		if object.requests == 0 {
+			t.Fatal("expected deletion to be verified")
+		}
+	})
+
+	t.Run("not found but still exists", func(t *testing.T) {
+		object := &fakeObject{existsRequests: 1000}
+		o := testObjectDeletion(t, object, false)
+		objectDeletionVerifyTimeout = 200 * time.Millisecond
+		if diags := o.CheckDeleted(ctx, http.StatusNotFound, errors.New("not found")); !diags.HasError() {
+			t.Fatal("expected an error when the object still exists")
+		}
+	})
+
+	t.Run("not found in strict mode", func(t *testing.T) {
+		object := &fakeObject{}
+		if diags := testObjectDeletion(t, object, true).CheckDeleted(ctx, http.StatusNotFound, errors.New("not found")); !diags.HasError() {
+			t.Fatal("expected an error in strict mode")
+		}
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		object := &fakeObject{}
+		if diags := testObjectDeletion(t, object, false).CheckDeleted(ctx, http.StatusForbidden, errors.New("forbidden")); !diags.HasError() {
+			t.Fatal("expected an error")
+		}
+		if object.requests != 0 {
+			t.Fatalf("expected no verification requests, got %d", object.requests)
+		}
+	})
+}
+
+func TestObjectDeletionCheckDeletedWithoutRetries(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+	}))
+	defer server.Close()
+
+	client := msgraph.NewUsersClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+
+	o := testObjectDeletion(t, &fakeObject{}, false)
+	o.Get = func(ctx context.Context) (int, error) {
+		client := *client
+		client.BaseClient.DisableRetries = true
+		_, status, err := client.Get(ctx, o.ObjectId)
+		return status, err
+	}
+
+	if diags := o.CheckDeleted(context.Background(), http.StatusNoContent, nil); diags.HasError() {
+		t.Fatalf("unexpected error: %+v", diags)
+	}
+
+	// Each check should be a single request, rather than retrying the 404 response with backoff
+	if requests != 3 {
+		t.Fatalf("expected deletion to be verified with 3 requests, got %d", requests)
+	}
+	if client.BaseClient.DisableRetries {
+		t.Fatal("expected retries to remain enabled for the original client")
+	}
+}
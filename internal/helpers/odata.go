@@ -0,0 +1,59 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ODataString formats a string as an OData string literal. Single quotes are escaped by doubling them, as required by
+// the OData ABNF. Other reserved characters such as `&`, `#` and `+`, and any non-ASCII characters, must not be escaped
+// here since they are percent-encoded when the filter is added to the query string of the request.
+func ODataString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ODataLiteral formats a value as an OData literal. Strings are quoted and escaped, whilst booleans and integers are
+// formatted as-is. Any other value is formatted as a string literal.
+func ODataLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return ODataString(v)
+	case bool:
+		return fmt.Sprintf("%t", v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	default:
+		return ODataString(fmt.Sprintf("%v", v))
+	}
+}
+
+// ODataEq returns a filter expression matching objects where the specified property is equal to the value
+func ODataEq(property string, value interface{}) string {
+	return fmt.Sprintf("%s eq %s", property, ODataLiteral(value))
+}
+
+// ODataStartsWith returns a filter expression matching objects where the specified property starts with the prefix
+func ODataStartsWith(property, prefix string) string {
+	return fmt.Sprintf("startswith(%s, %s)", property, ODataString(prefix))
+}
+
+// ODataIn returns a filter expression matching objects where the specified property is equal to any of the values
+func ODataIn(property string, values []string) string {
+	literals := make([]string, 0, len(values))
+	for _, v := range values {
+		literals = append(literals, ODataString(v))
+	}
+	return fmt.Sprintf("%s in (%s)", property, strings.Join(literals, ", "))
+}
+
+// ODataAnd combines filter expressions so that all must match. Empty expressions are omitted, so that optional
+// expressions can be passed unconditionally.
+func ODataAnd(filters ...string) string {
+	nonEmpty := make([]string, 0, len(filters))
+	for _, f := range filters {
+		if f != "" {
+			nonEmpty = append(nonEmpty, f)
+		}
+	}
+	return strings.Join(nonEmpty, " and ")
+}
@@ -0,0 +1,132 @@
+package helpers
+
+import (
+	"testing"
+)
+
+func TestODataString(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+	}{
+		{value: "", expected: "''"},
+		{value: "acctest-group", expected: "'acctest-group'"},
+		{value: "O'Brien", expected: "'O''Brien'"},
+		{value: "'", expected: "''''"},
+		{value: "''", expected: "''''''"},
+		{value: "'leading", expected: "'''leading'"},
+		{value: "trailing'", expected: "'trailing'''"},
+		{value: "it's Bob's", expected: "'it''s Bob''s'"},
+		{value: "R&D", expected: "'R&D'"},
+		{value: "a+b#c?d/e", expected: "'a+b#c?d/e'"},
+		{value: "100%", expected: "'100%'"},
+		{value: `double "quotes"`, expected: `'double "quotes"'`},
+		{value: `back\slash`, expected: `'back\slash'`},
+		{value: "Zoë Ångström", expected: "'Zoë Ångström'"},
+		{value: "ＯＫ’s", expected: "'ＯＫ’s'"},
+		{value: "eq 'x' or displayName eq 'y", expected: "'eq ''x'' or displayName eq ''y'"},
+	}
+
+	for _, c := range cases {
+		if actual := ODataString(c.value); actual != c.expected {
+			t.Errorf("ODataString(%q): expected %s, got %s", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestODataLiteral(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{value: "O'Brien", expected: "'O''Brien'"},
+		{value: true, expected: "true"},
+		{value: false, expected: "false"},
+		{value: 42, expected: "42"},
+		{value: int64(-7), expected: "-7"},
+		{value: uint8(3), expected: "3"},
+		{value: 1.5, expected: "'1.5'"},
+	}
+
+	for _, c := range cases {
+		if actual := ODataLiteral(c.value); actual != c.expected {
+			t.Errorf("ODataLiteral(%#v): expected %s, got %s", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestODataEq(t *testing.T) {
+	cases := []struct {
+		property string
+		value    interface{}
+		expected string
+	}{
+		{property: "displayName", value: "acctest", expected: "displayName eq 'acctest'"},
+		{property: "displayName", value: "O'Brien's Team", expected: "displayName eq 'O''Brien''s Team'"},
+		{property: "displayName", value: "", expected: "displayName eq ''"},
+		{property: "mailEnabled", value: true, expected: "mailEnabled eq true"},
+		{property: "securityEnabled", value: false, expected: "securityEnabled eq false"},
+	}
+
+	for _, c := range cases {
+		if actual := ODataEq(c.property, c.value); actual != c.expected {
+			t.Errorf("ODataEq(%q, %#v): expected %q, got %q", c.property, c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestODataStartsWith(t *testing.T) {
+	cases := []struct {
+		property string
+		prefix   string
+		expected string
+	}{
+		{property: "displayName", prefix: "acctest-", expected: "startswith(displayName, 'acctest-')"},
+		{property: "displayName", prefix: "O'", expected: "startswith(displayName, 'O''')"},
+		{property: "mail", prefix: "", expected: "startswith(mail, '')"},
+	}
+
+	for _, c := range cases {
+		if actual := ODataStartsWith(c.property, c.prefix); actual != c.expected {
+			t.Errorf("ODataStartsWith(%q, %q): expected %q, got %q", c.property, c.prefix, c.expected, actual)
+		}
+	}
+}
+
+func TestODataIn(t *testing.T) {
+	cases := []struct {
+		property string
+		values   []string
+		expected string
+	}{
+		{property: "appId", values: []string{"11111111-1111-1111-1111-111111111111"}, expected: "appId in ('11111111-1111-1111-1111-111111111111')"},
+		{property: "displayName", values: []string{"one", "O'Two", "R&D"}, expected: "displayName in ('one', 'O''Two', 'R&D')"},
+		{property: "displayName", values: []string{}, expected: "displayName in ()"},
+	}
+
+	for _, c := range cases {
+		if actual := ODataIn(c.property, c.values); actual != c.expected {
+			t.Errorf("ODataIn(%q, %q): expected %q, got %q", c.property, c.values, c.expected, actual)
+		}
+	}
+}
+
+func TestODataAnd(t *testing.T) {
+	cases := []struct {
+		name     string
+		filters  []string
+		expected string
+	}{
+		{name: "none", filters: nil, expected: ""},
+		{name: "single", filters: []string{"displayName eq 'a'"}, expected: "displayName eq 'a'"},
+		{name: "multiple", filters: []string{"displayName eq 'a'", "mailEnabled eq true"}, expected: "displayName eq 'a' and mailEnabled eq true"},
+		{name: "empty omitted", filters: []string{"", "principalId eq 'b'", ""}, expected: "principalId eq 'b'"},
+		{name: "all empty", filters: []string{"", ""}, expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := ODataAnd(c.filters...); actual != c.expected {
+			t.Errorf("%s: expected %q, got %q", c.name, c.expected, actual)
+		}
+	}
+}
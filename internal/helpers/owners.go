@@ -0,0 +1,67 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CurrentPrincipalOwner can be specified in the owners of a resource in place of the object ID of the principal that
+// is authenticated to the provider.
+const CurrentPrincipalOwner = "current"
+
+// ExpandOwners resolves the CurrentPrincipalOwner placeholder in the specified owners to the object ID of the
+// authenticated principal. Any resulting duplicate is omitted, since owners may not be validated until apply time.
+func ExpandOwners(owners []string, callerId string) []string {
+	result := make([]string, 0, len(owners))
+	seen := make(map[string]bool)
+	for _, o := range owners {
+		if strings.EqualFold(o, CurrentPrincipalOwner) {
+			o = callerId
+		}
+		if seen[strings.ToLower(o)] {
+			continue
+		}
+		seen[strings.ToLower(o)] = true
+		result = append(result, o)
+	}
+	return result
+}
+
+// FlattenOwners substitutes the CurrentPrincipalOwner placeholder for the object ID of the authenticated principal, when
+// the placeholder was specified in the configured owners. This keeps plans stable when the same principal runs them, and
+// surfaces a diff when a different principal does, since the original principal will not be substituted.
+func FlattenOwners(owners, configuredOwners []string, callerId string) []string {
+	current := false
+	for _, o := range configuredOwners {
+		if strings.EqualFold(o, CurrentPrincipalOwner) {
+			current = true
+			break
+		}
+	}
+
+	result := make([]string, 0, len(owners))
+	for _, o := range owners {
+		if current && strings.EqualFold(o, callerId) {
+			o = CurrentPrincipalOwner
+		}
+		result = append(result, o)
+	}
+	return result
+}
+
+// ValidateCurrentPrincipalOwner checks that the configured owners do not include both the CurrentPrincipalOwner
+// placeholder and the object ID of the authenticated principal, which would otherwise resolve to the same owner.
+func ValidateCurrentPrincipalOwner(owners []string, callerId string) error {
+	current, explicit := false, false
+	for _, o := range owners {
+		if strings.EqualFold(o, CurrentPrincipalOwner) {
+			current = true
+		} else if strings.EqualFold(o, callerId) {
+			explicit = true
+		}
+	}
+	if current && explicit {
+		return fmt.Errorf("%q and the object ID of the authenticated principal (%s) cannot both be specified, since they refer to the same owner", CurrentPrincipalOwner, callerId)
+	}
+	return nil
+}
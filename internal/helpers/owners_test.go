@@ -0,0 +1,75 @@
+package helpers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExpandOwners(t *testing.T) {
+	callerId := "11111111-1111-1111-1111-111111111111"
+	owners := ExpandOwners([]string{"current", "22222222-2222-2222-2222-222222222222"}, callerId)
+	if expected := callerId + ",22222222-2222-2222-2222-222222222222"; strings.Join(owners, ",") != expected {
+		t.Fatalf("expected owners %q, got: %v", expected, owners)
+	}
+
+	owners = ExpandOwners([]string{"current", callerId}, callerId)
+	if len(owners) != 1 || owners[0] != callerId {
+		t.Fatalf("expected the authenticated principal to be included once, got: %v", owners)
+	}
+}
+
+func TestFlattenOwners(t *testing.T) {
+	firstCaller := "11111111-1111-1111-1111-111111111111"
+	secondCaller := "33333333-3333-3333-3333-333333333333"
+	owners := []string{firstCaller, "22222222-2222-2222-2222-222222222222"}
+
+	cases := []struct {
+		name       string
+		configured []string
+		callerId   string
+		expected   []string
+	}{
+		{
+			name:       "placeholder configured",
+			configured: []string{"current", "22222222-2222-2222-2222-222222222222"},
+			callerId:   firstCaller,
+			expected:   []string{"current", "22222222-2222-2222-2222-222222222222"},
+		},
+		{
+			name:       "placeholder not configured",
+			configured: []string{firstCaller, "22222222-2222-2222-2222-222222222222"},
+			callerId:   firstCaller,
+			expected:   owners,
+		},
+		{
+			// The original principal is retained, so the plan shows it being replaced with the new principal
+			name:       "different principal",
+			configured: []string{"current", "22222222-2222-2222-2222-222222222222"},
+			callerId:   secondCaller,
+			expected:   owners,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := FlattenOwners(owners, tc.configured, tc.callerId)
+			if strings.Join(result, ",") != strings.Join(tc.expected, ",") {
+				t.Fatalf("expected owners %v, got: %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestValidateCurrentPrincipalOwner(t *testing.T) {
+	callerId := "11111111-1111-1111-1111-111111111111"
+
+	if err := ValidateCurrentPrincipalOwner([]string{"current", "22222222-2222-2222-2222-222222222222"}, callerId); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateCurrentPrincipalOwner([]string{callerId}, callerId); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ValidateCurrentPrincipalOwner([]string{"current", callerId}, callerId); err == nil {
+		t.Fatal("expected an error when specifying both the placeholder and the authenticated principal")
+	}
+}
@@ -0,0 +1,146 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ParentWait waits for the parent object of a child resource, e.g. the application owning a password credential, to be
+// consistently visible before the child is created. Immediately after the parent has been created, reads can fail
+// with a 404 response until the object has replicated, which is indistinguishable from the parent not existing. The
+// parent is therefore only considered to exist after it has been found a number of times consecutively, and only
+// considered to be absent after a number of consecutive not found responses, with an increasing interval between
+// attempts. Waiting is bounded by the deadline of the context, which is typically the resource timeout, so objects should
+// be retrieved using a client with retries disabled. Otherwise the client retries not found responses itself, without
+// regard for the deadline.
+type ParentWait struct {
+	// ParentType is a human readable name for the parent object, e.g. `application`
+	ParentType string
+
+	// ObjectId is the object ID of the parent
+	ObjectId string
+
+	// MinInterval is the interval before the first retry, which doubles with each attempt. Defaults to 1 second.
+	MinInterval time.Duration
+
+	// MaxInterval is the maximum interval between attempts. Defaults to 10 seconds.
+	MaxInterval time.Duration
+
+	// Successes is the number of consecutive times the parent must be found. Defaults to 2.
+	Successes int
+
+	// NotFoundChecks is the number of consecutive not found responses after which the parent is considered to not
+	// exist. Defaults to 6.
+	NotFoundChecks int
+}
+
+var (
+	parentWaitDefaultMinInterval    = 1 * time.Second
+	parentWaitDefaultMaxInterval    = 10 * time.Second
+	parentWaitDefaultSuccesses      = 2
+	parentWaitDefaultNotFoundChecks = 6
+)
+
+// Wait retrieves the parent object using the provided func until it is consistently found, and returns the last
+// retrieved object along with the HTTP status of the last request. When the parent is consistently not found, the
+// returned status is 404 so that callers can handle this in the same way as a single failed request.
+func (w ParentWait) Wait(ctx context.Context, get func(context.Context) (interface{}, int, error)) (interface{}, int, error) {
+	minInterval, maxInterval, successes, notFoundChecks := w.MinInterval, w.MaxInterval, w.Successes, w.NotFoundChecks
+	if minInterval <= 0 {
+		minInterval = parentWaitDefaultMinInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = parentWaitDefaultMaxInterval
+	}
+	if successes <= 0 {
+		successes = parentWaitDefaultSuccesses
+	}
+	if notFoundChecks <= 0 {
+		notFoundChecks = parentWaitDefaultNotFoundChecks
+	}
+
+	var found, notFound int
+	interval := minInterval
+
+	for {
+		obj, status, err := get(ctx)
+		switch {
+		case err == nil:
+			found++
+			notFound = 0
+			if found >= successes {
+				return obj, status, nil
+			}
+			log.Printf("[DEBUG] The %s with object ID %q was found (%d/%d)", w.ParentType, w.ObjectId, found, successes)
+
+		case status == http.StatusNotFound:
+			found = 0
+			notFound++
+			if notFound >= notFoundChecks {
+				return nil, status, err
+			}
+			log.Printf("[DEBUG] The %s with object ID %q was not found (%d/%d) - it may not have replicated yet", w.ParentType, w.ObjectId, notFound, notFoundChecks)
+
+		default:
+			return nil, status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, 0, fmt.Errorf("waiting for %s with object ID %q to become available: %v", w.ParentType, w.ObjectId, ctx.Err())
+		case <-time.After(interval):
+		}
+
+		// Back off while the parent is not found, but not between successes since it is likely to already be available
+		if found == 0 {
+			interval *= 2
+			if interval > maxInterval {
+				interval = maxInterval
+			}
+		}
+	}
+}
+
+// WaitForParentApplication waits for an application to be consistently visible, and returns it
+func WaitForParentApplication(ctx context.Context, client *msgraph.ApplicationsClient, id string) (*msgraph.Application, int, error) {
+	c := *client
+	c.BaseClient.DisableRetries = true
+	obj, status, err := ParentWait{ParentType: "application", ObjectId: id}.Wait(ctx, func(ctx context.Context) (interface{}, int, error) {
+		return c.Get(ctx, id)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return obj.(*msgraph.Application), status, nil
+}
+
+// WaitForParentGroup waits for a group to be consistently visible, and returns it
+func WaitForParentGroup(ctx context.Context, client *msgraph.GroupsClient, id string) (*msgraph.Group, int, error) {
+	c := *client
+	c.BaseClient.DisableRetries = true
+	obj, status, err := ParentWait{ParentType: "group", ObjectId: id}.Wait(ctx, func(ctx context.Context) (interface{}, int, error) {
+		return c.Get(ctx, id)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return obj.(*msgraph.Group), status, nil
+}
+
+// WaitForParentServicePrincipal waits for a service principal to be consistently visible, and returns it
+func WaitForParentServicePrincipal(ctx context.Context, client *msgraph.ServicePrincipalsClient, id string) (*msgraph.ServicePrincipal, int, error) {
+	c := *client
+	c.BaseClient.DisableRetries = true
+	obj, status, err := ParentWait{ParentType: "service principal", ObjectId: id}.Wait(ctx, func(ctx context.Context) (interface{}, int, error) {
+		return c.Get(ctx, id)
+	})
+	if err != nil {
+		return nil, status, err
+	}
+	return obj.(*msgraph.ServicePrincipal), status, nil
+}
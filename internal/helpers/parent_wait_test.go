@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// fakeParent returns a scripted sequence of HTTP statuses, repeating the last status once the script is exhausted
+type fakeParent struct {
+	script   []int
+	requests int
+}
+
+func (p *fakeParent) get(_ context.Context) (interface{}, int, error) {
+	status := p.script[len(p.script)-1]
+	if p.requests < len(p.script) {
+		status = p.script[p.requests]
+	}
+	p.requests++
+
+	if status != http.StatusOK {
+		return nil, status, errors.New(http.StatusText(status))
+	}
+	return p, status, nil
+}
+
+func testParentWait() ParentWait {
+	return ParentWait{
+		ParentType:     "application",
+		ObjectId:       "00000000-0000-0000-0000-000000000000",
+		MinInterval:    time.Millisecond,
+		MaxInterval:    5 * time.Millisecond,
+		Successes:      2,
+		NotFoundChecks: 4,
+	}
+}
+
+func TestParentWait(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name             string
+		script           []int
+		expectedStatus   int
+		expectedRequests int
+		expectError      bool
+	}{
+		{
+			name:             "available",
+			script:           []int{http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 2,
+		},
+		{
+			name:             "replication lag",
+			script:           []int{http.StatusNotFound, http.StatusNotFound, http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 5,
+		},
+		{
+			name:             "intermittent",
+			script:           []int{http.StatusOK, http.StatusNotFound, http.StatusOK},
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 4,
+		},
+		{
+			name:             "not found",
+			script:           []int{http.StatusNotFound},
+			expectedStatus:   http.StatusNotFound,
+			expectedRequests: 4,
+			expectError:      true,
+		},
+		{
+			name:             "not found after intermittent success",
+			script:           []int{http.StatusNotFound, http.StatusNotFound, http.StatusOK, http.StatusNotFound},
+			expectedStatus:   http.StatusNotFound,
+			expectedRequests: 7,
+			expectError:      true,
+		},
+		{
+			name:             "other error",
+			script:           []int{http.StatusNotFound, http.StatusForbidden},
+			expectedStatus:   http.StatusForbidden,
+			expectedRequests: 2,
+			expectError:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			parent := &fakeParent{script: tc.script}
+			obj, status, err := testParentWait().Wait(ctx, parent.get)
+
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !tc.expectError {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if obj != parent {
+					t.Fatalf("expected the retrieved parent to be returned, got %v", obj)
+				}
+			}
+			if status != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, status)
+			}
+			if parent.requests != tc.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tc.expectedRequests, parent.requests)
+			}
+		})
+	}
+}
+
+func TestParentWaitContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	w := testParentWait()
+	w.NotFoundChecks = 1000
+
+	_, status, err := w.Wait(ctx, (&fakeParent{script: []int{http.StatusNotFound}}).get)
+	if err == nil {
+		t.Fatalf("expected an error when the context deadline is exceeded")
+	}
+	if status == http.StatusNotFound {
+		t.Fatalf("expected the parent to not be reported as missing when the deadline is exceeded")
+	}
+}
+
+func TestWaitForParentGroup(t *testing.T) {
+	minInterval, maxInterval := parentWaitDefaultMinInterval, parentWaitDefaultMaxInterval
+	parentWaitDefaultMinInterval, parentWaitDefaultMaxInterval = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() {
+		parentWaitDefaultMinInterval, parentWaitDefaultMaxInterval = minInterval, maxInterval
+	})
+
+	for _, tc := range []struct {
+		name             string
+		found            bool
+		expectedStatus   int
+		expectedRequests int
+	}{
+		{
+			name:             "replication lag",
+			found:            true,
+			expectedStatus:   http.StatusOK,
+			expectedRequests: 3,
+		},
+		{
+			name:             "not found",
+			expectedStatus:   http.StatusNotFound,
+			expectedRequests: parentWaitDefaultNotFoundChecks,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				if requests == 1 || !tc.found {
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+					return
+				}
+				fmt.Fprint(w, `{"id":"00000000-0000-0000-0000-000000000000","displayName":"acctest"}`)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+
+			// Each check should be a single request, rather than the client retrying not found responses itself
+			_, status, _ := WaitForParentGroup(context.Background(), client, "00000000-0000-0000-0000-000000000000")
+			if status != tc.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tc.expectedStatus, status)
+			}
+			if requests != tc.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// partialCreateRollbackTimeout is the maximum amount of time to spend deleting a partially created object, when the
+// context for the create operation has already expired
+var partialCreateRollbackTimeout = 2 * time.Minute
+
+// PartialCreateRollback describes the primary object of a resource which has just been created, and is used to delete it
+// again when a subsequent step of the creation fails, e.g. adding owners or members.
+type PartialCreateRollback struct {
+	// Enabled causes the object to be deleted when creation fails, otherwise it is retained in state and will be tainted
+	Enabled bool
+
+	// ObjectType is a human readable name for the object, e.g. `group`
+	ObjectType string
+
+	// ObjectId is the object ID of the object which was created
+	ObjectId string
+
+	// Delete deletes the object, returning the HTTP status of the request
+	Delete func(ctx context.Context) (int, error)
+}
+
+// Check should be deferred immediately after the primary object is created and the resource ID is set, and passed the
+// diagnostics returned by the create function. When creation has failed and rollback is enabled, the object is deleted
+// and the resource ID is cleared, so that the failed apply does not leave anything behind.
+func (r PartialCreateRollback) Check(ctx context.Context, d *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	if !r.Enabled || !diags.HasError() {
+		return diags
+	}
+
+	// The create operation may have failed because its context expired, which should not prevent the rollback
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.Background(), partialCreateRollbackTimeout)
+		defer cancel()
+	}
+
+	if status, err := r.Delete(ctx); err != nil && status != http.StatusNotFound {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Could not roll back partially created %s with object ID %q", r.ObjectType, r.ObjectId),
+			Detail:   fmt.Sprintf("The %s remains in state and will be replaced on the next apply: %v", r.ObjectType, err),
+		})
+	}
+
+	log.Printf("[WARN] Deleted partially created %s with object ID %q, since a subsequent step of its creation failed", r.ObjectType, r.ObjectId)
+	d.SetId("")
+
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Partially created %s with object ID %q was deleted", r.ObjectType, r.ObjectId),
+		Detail:   fmt.Sprintf("The %s was deleted because `rollback_on_partial_create` is enabled and a subsequent step of its creation failed.", r.ObjectType),
+	})
+}
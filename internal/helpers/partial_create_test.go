@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPartialCreateRollbackCheck(t *testing.T) {
+	ctx := context.Background()
+	failed := diag.Errorf("could not add owners")
+
+	newData := func() *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+		d.SetId("00000000-0000-0000-0000-000000000000")
+		return d
+	}
+	newRollback := func(enabled bool, status int, err error, deleted *bool) PartialCreateRollback {
+		return PartialCreateRollback{
+			Enabled:    enabled,
+			ObjectType: "group",
+			ObjectId:   "00000000-0000-0000-0000-000000000000",
+			Delete: func(_ context.Context) (int, error) {
+				*deleted = true
+				return status, err
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		d, deleted := newData(), false
+		diags := newRollback(false, http.StatusNoContent, nil, &deleted).Check(ctx, d, failed)
+		if deleted || d.Id() == "" || len(diags) != 1 {
+			t.Fatalf("expected no rollback, got deleted=%t, id=%q, diags=%+v", deleted, d.Id(), diags)
+		}
+	})
+
+	t.Run("succeeded", func(t *testing.T) {
+		d, deleted := newData(), false
+		diags := newRollback(true, http.StatusNoContent, nil, &deleted).Check(ctx, d, nil)
+		if deleted || d.Id() == "" || diags.HasError() {
+			t.Fatalf("expected no rollback, got deleted=%t, id=%q, diags=%+v", deleted, d.Id(), diags)
+		}
+	})
+
+	t.Run("rolled back", func(t *testing.T) {
+		d, deleted := newData(), false
+		diags := newRollback(true, http.StatusNoContent, nil, &deleted).Check(ctx, d, failed)
+		if !deleted || d.Id() != "" {
+			t.Fatalf("expected rollback, got deleted=%t, id=%q", deleted, d.Id())
+		}
+		if !diags.HasError() || len(diags) != 2 || diags[1].Severity != diag.Warning {
+			t.Fatalf("expected the original error and a warning, got %+v", diags)
+		}
+	})
+
+	t.Run("already gone", func(t *testing.T) {
+		d, deleted := newData(), false
+		newRollback(true, http.StatusNotFound, errors.New("not found"), &deleted).Check(ctx, d, failed)
+		if d.Id() != "" {
+			t.Fatalf("expected ID to be cleared, got %q", d.Id())
+		}
+	})
+
+	t.Run("rollback failed", func(t *testing.T) {
+		d, deleted := newData(), false
+		diags := newRollback(true, http.StatusForbidden, errors.New("insufficient privileges"), &deleted).Check(ctx, d, failed)
+		if d.Id() == "" {
+			t.Fatal("expected ID to be retained when rollback fails")
+		}
+		if len(diags) != 2 || diags[1].Severity != diag.Error {
+			t.Fatalf("expected the original error and a rollback error, got %+v", diags)
+		}
+	})
+
+	t.Run("context expired", func(t *testing.T) {
+		expired, cancel := context.WithCancel(ctx)
+		cancel()
+
+		d := newData()
+		rollback := PartialCreateRollback{
+			Enabled:    true,
+			ObjectType: "group",
+			ObjectId:   "00000000-0000-0000-0000-000000000000",
+			Delete: func(ctx context.Context) (int, error) {
+				return http.StatusNoContent, ctx.Err()
+			},
+		}
+		rollback.Check(expired, d, failed)
+		if d.Id() != "" {
+			t.Fatal("expected rollback to use a fresh context when the create context has expired")
+		}
+	})
+}
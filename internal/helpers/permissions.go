@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/manicminer/hamilton/auth"
+)
+
+// PermissionsOperation identifies an operation for which the required Microsoft Graph permissions are known, so that
+// authorization failures can be explained
+type PermissionsOperation string
+
+const (
+	PermissionsOperationApplicationCreate       PermissionsOperation = "create applications"
+	PermissionsOperationApplicationOwnerAdd     PermissionsOperation = "add owners to applications"
+	PermissionsOperationCrossTenantAccessWrite  PermissionsOperation = "manage cross-tenant access policies"
+	PermissionsOperationDirectoryRoleActivate   PermissionsOperation = "activate directory roles"
+	PermissionsOperationDirectoryRoleAssignment PermissionsOperation = "assign directory roles"
+	PermissionsOperationGroupCreate             PermissionsOperation = "create groups"
+	PermissionsOperationGroupMemberAdd          PermissionsOperation = "add members to groups"
+	PermissionsOperationGroupOwnerAdd           PermissionsOperation = "add owners to groups"
+	PermissionsOperationServicePrincipalCreate  PermissionsOperation = "create service principals"
+	PermissionsOperationUserCreate              PermissionsOperation = "create users"
+	PermissionsOperationUserInvite              PermissionsOperation = "invite guest users"
+)
+
+// PermissionsRequirement describes the Microsoft Graph permissions which permit an operation. Any one of the
+// listed permissions is typically sufficient.
+type PermissionsRequirement struct {
+	// ApplicationRoles are the application permissions which permit the operation for an app-only token. These always
+	// require admin consent.
+	ApplicationRoles []string
+
+	// DelegatedScopes are the delegated permissions which permit the operation for a token issued to a user
+	DelegatedScopes []string
+
+	// DelegatedAdminConsent indicates whether the delegated permissions require admin consent
+	DelegatedAdminConsent bool
+}
+
+// permissionsRequirements maps each operation to the least privileged permissions which permit it, in order of
+// increasing privilege
+var permissionsRequirements = map[PermissionsOperation]PermissionsRequirement{
+	PermissionsOperationApplicationCreate: {
+		ApplicationRoles:      []string{"Application.ReadWrite.OwnedBy", "Application.ReadWrite.All"},
+		DelegatedScopes:       []string{"Application.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationApplicationOwnerAdd: {
+		ApplicationRoles:      []string{"Application.ReadWrite.OwnedBy", "Application.ReadWrite.All"},
+		DelegatedScopes:       []string{"Application.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationCrossTenantAccessWrite: {
+		ApplicationRoles:      []string{"Policy.ReadWrite.CrossTenantAccess"},
+		DelegatedScopes:       []string{"Policy.ReadWrite.CrossTenantAccess"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationDirectoryRoleActivate: {
+		ApplicationRoles:      []string{"RoleManagement.ReadWrite.Directory"},
+		DelegatedScopes:       []string{"RoleManagement.ReadWrite.Directory"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationDirectoryRoleAssignment: {
+		ApplicationRoles:      []string{"RoleManagement.ReadWrite.Directory"},
+		DelegatedScopes:       []string{"RoleManagement.ReadWrite.Directory"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationGroupCreate: {
+		ApplicationRoles:      []string{"Group.Create", "Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationGroupMemberAdd: {
+		ApplicationRoles:      []string{"GroupMember.ReadWrite.All", "Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"GroupMember.ReadWrite.All", "Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationGroupOwnerAdd: {
+		ApplicationRoles:      []string{"Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"Group.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationServicePrincipalCreate: {
+		ApplicationRoles:      []string{"Application.ReadWrite.OwnedBy", "Application.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"Application.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationUserCreate: {
+		ApplicationRoles:      []string{"User.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"User.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+	PermissionsOperationUserInvite: {
+		ApplicationRoles:      []string{"User.Invite.All", "User.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedScopes:       []string{"User.Invite.All", "User.ReadWrite.All", "Directory.ReadWrite.All"},
+		DelegatedAdminConsent: true,
+	},
+}
+
+// PermissionsError annotates an authorization failure for the specified operation with the Microsoft Graph permissions
+// which typically permit it, and whether any of these are present in the access token described by claims. Other errors
+// are returned unchanged.
+func PermissionsError(err error, status int, operation PermissionsOperation, claims auth.Claims) error {
+	if err == nil || status != http.StatusForbidden {
+		return err
+	}
+
+	requirement, ok := permissionsRequirements[operation]
+	if !ok {
+		return err
+	}
+
+	return fmt.Errorf("%v\n\n%s", err, permissionsHint(operation, requirement, claims))
+}
+
+// permissionsHint explains which permissions are needed for an operation, depending on the type of access token
+func permissionsHint(operation PermissionsOperation, requirement PermissionsRequirement, claims auth.Claims) string {
+	if permissionsAppOnly(claims) {
+		hint := fmt.Sprintf("To %s, the authenticated application typically requires one of the following Microsoft Graph application permissions, which require admin consent: %s.", operation, strings.Join(requirement.ApplicationRoles, ", "))
+		if granted := permissionsGranted(requirement.ApplicationRoles, claims.Roles); len(granted) > 0 {
+			return fmt.Sprintf("%s The access token includes %s, so the request may instead have been denied because the application lacks a required directory role or does not own the object.", hint, strings.Join(granted, ", "))
+		}
+		return fmt.Sprintf("%s None of these are present in the roles claim of the access token. Check that the permission has been granted and admin consent given, then obtain a new token.", hint)
+	}
+
+	consent := ""
+	if requirement.DelegatedAdminConsent {
+		consent = ", which typically require admin consent"
+	}
+	hint := fmt.Sprintf("To %s, the signed-in user typically requires one of the following Microsoft Graph delegated permissions%s: %s.", operation, consent, strings.Join(requirement.DelegatedScopes, ", "))
+	if granted := permissionsGranted(requirement.DelegatedScopes, strings.Fields(claims.Scopes)); len(granted) > 0 {
+		return fmt.Sprintf("%s The access token includes %s, so the request may instead have been denied because the user lacks a required directory role or does not own the object.", hint, strings.Join(granted, ", "))
+	}
+	return fmt.Sprintf("%s None of these are present in the scp claim of the access token.", hint)
+}
+
+// permissionsAppOnly returns whether the access token was issued to an application acting as itself, rather than on
+// behalf of a user. App-only tokens carry application permissions in the roles claim and have no scp claim.
+func permissionsAppOnly(claims auth.Claims) bool {
+	return strings.EqualFold(claims.IdType, "app") || claims.Scopes == ""
+}
+
+// permissionsGranted returns the required permissions which are present in the access token
+func permissionsGranted(required, present []string) []string {
+	result := make([]string, 0)
+	for _, r := range required {
+		for _, p := range present {
+			if strings.EqualFold(r, p) {
+				result = append(result, r)
+				break
+			}
+		}
+	}
+	return result
+}
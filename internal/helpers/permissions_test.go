@@ -0,0 +1,166 @@
+package helpers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/auth"
+)
+
+func TestPermissionsRequirements(t *testing.T) {
+	operations := []PermissionsOperation{
+		PermissionsOperationApplicationCreate,
+		PermissionsOperationApplicationOwnerAdd,
+		PermissionsOperationCrossTenantAccessWrite,
+		PermissionsOperationDirectoryRoleActivate,
+		PermissionsOperationDirectoryRoleAssignment,
+		PermissionsOperationGroupCreate,
+		PermissionsOperationGroupMemberAdd,
+		PermissionsOperationGroupOwnerAdd,
+		PermissionsOperationServicePrincipalCreate,
+		PermissionsOperationUserCreate,
+		PermissionsOperationUserInvite,
+	}
+	if len(operations) != len(permissionsRequirements) {
+		t.Fatalf("expected %d operations in mapping, got %d", len(operations), len(permissionsRequirements))
+	}
+
+	permissionName := regexp.MustCompile(`^[A-Z][A-Za-z]+(\.[A-Z][A-Za-z]+)+$`)
+	for _, operation := range operations {
+		requirement, ok := permissionsRequirements[operation]
+		if !ok {
+			t.Errorf("no permissions mapped for operation %q", operation)
+			continue
+		}
+		if len(requirement.ApplicationRoles) == 0 || len(requirement.DelegatedScopes) == 0 {
+			t.Errorf("expected both application and delegated permissions for operation %q", operation)
+		}
+		for _, p := range append(requirement.ApplicationRoles, requirement.DelegatedScopes...) {
+			if !permissionName.MatchString(p) {
+				t.Errorf("invalid permission name %q for operation %q", p, operation)
+			}
+		}
+	}
+}
+
+// testPermissionsClaims decodes the claims from the payload of an unsigned JWT, in the same way as the provider does
+// for the access token it acquires
+func testPermissionsClaims(t *testing.T, payload map[string]interface{}) auth.Claims {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshalling payload: %v", err)
+	}
+	token := strings.Join([]string{
+		base64.RawStdEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)),
+		base64.RawStdEncoding.EncodeToString(body),
+		"",
+	}, ".")
+
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.Split(token, ".")[1])
+	if err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	var claims auth.Claims
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		t.Fatalf("unmarshalling claims: %v", err)
+	}
+	return claims
+}
+
+func TestPermissionsError(t *testing.T) {
+	apiErr := errors.New("GroupsClient.BaseClient.Post(): unexpected status 403 with OData error: Authorization_RequestDenied: Insufficient privileges to complete the operation.")
+
+	appOnlyWithoutRole := testPermissionsClaims(t, map[string]interface{}{"idtyp": "app", "roles": []string{"User.Read.All"}})
+	appOnlyWithRole := testPermissionsClaims(t, map[string]interface{}{"idtyp": "app", "roles": []string{"User.Read.All", "group.create"}})
+	delegatedWithoutScope := testPermissionsClaims(t, map[string]interface{}{"scp": "openid profile User.Read"})
+	delegatedWithScope := testPermissionsClaims(t, map[string]interface{}{"scp": "openid Group.ReadWrite.All User.Read"})
+
+	cases := []struct {
+		name      string
+		err       error
+		status    int
+		operation PermissionsOperation
+		claims    auth.Claims
+		expected  []string
+	}{
+		{
+			name:      "app-only token without permission",
+			err:       apiErr,
+			status:    http.StatusForbidden,
+			operation: PermissionsOperationGroupCreate,
+			claims:    appOnlyWithoutRole,
+			expected:  []string{"application permissions, which require admin consent: Group.Create, Group.ReadWrite.All, Directory.ReadWrite.All.", "None of these are present in the roles claim"},
+		},
+		{
+			name:      "app-only token with permission",
+			err:       apiErr,
+			status:    http.StatusForbidden,
+			operation: PermissionsOperationGroupCreate,
+			claims:    appOnlyWithRole,
+			expected:  []string{"The access token includes Group.Create, so the request may instead"},
+		},
+		{
+			name:      "delegated token without permission",
+			err:       apiErr,
+			status:    http.StatusForbidden,
+			operation: PermissionsOperationGroupCreate,
+			claims:    delegatedWithoutScope,
+			expected:  []string{"delegated permissions, which typically require admin consent: Group.ReadWrite.All, Directory.ReadWrite.All.", "None of these are present in the scp claim"},
+		},
+		{
+			name:      "delegated token with permission",
+			err:       apiErr,
+			status:    http.StatusForbidden,
+			operation: PermissionsOperationGroupCreate,
+			claims:    delegatedWithScope,
+			expected:  []string{"The access token includes Group.ReadWrite.All, so the request may instead"},
+		},
+		{
+			name:      "other status",
+			err:       apiErr,
+			status:    http.StatusBadRequest,
+			operation: PermissionsOperationGroupCreate,
+			claims:    appOnlyWithoutRole,
+		},
+		{
+			name:      "unknown operation",
+			err:       apiErr,
+			status:    http.StatusForbidden,
+			operation: PermissionsOperation("do something else"),
+			claims:    appOnlyWithoutRole,
+		},
+		{
+			name:      "success",
+			status:    http.StatusCreated,
+			operation: PermissionsOperationGroupCreate,
+			claims:    appOnlyWithoutRole,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := PermissionsError(c.err, c.status, c.operation, c.claims)
+
+			if len(c.expected) == 0 {
+				if err != c.err {
+					t.Fatalf("expected original error, got: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.HasPrefix(err.Error(), c.err.Error()) {
+				t.Fatalf("expected error to begin with %q, got: %v", c.err.Error(), err)
+			}
+			for _, expected := range c.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Fatalf("expected error containing %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RemoveReferences removes the specified directory object references, such as members or owners, one at a time using
+// the provided remove func. The relationship should be singular, e.g. `owner`. Should a removal fail, the returned
+// error reports which references were removed and which were not, so that the resulting state of the relationship is
+// clear. When reconciling a relationship, new references should be added before calling this, so that the
+// relationship is never transiently empty.
+func RemoveReferences(ctx context.Context, relationship string, ids []string, remove func(context.Context, []string) (int, error)) error {
+	removed := make([]string, 0, len(ids))
+	for i, id := range ids {
+		if _, err := remove(ctx, []string{id}); err != nil {
+			return fmt.Errorf("removing %[1]s %[2]q: %[3]v\n\nRemoved %[1]ss: [%[4]s]\nNot removed %[1]ss: [%[5]s]", relationship, id, err, strings.Join(removed, ", "), strings.Join(ids[i:], ", "))
+		}
+		removed = append(removed, id)
+	}
+	return nil
+}
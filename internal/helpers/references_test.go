@@ -0,0 +1,48 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRemoveReferences(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{"11111111-1111-1111-1111-111111111111", "22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"}
+
+	t.Run("all removed", func(t *testing.T) {
+		calls := make([]string, 0)
+		err := RemoveReferences(ctx, "owner", ids, func(_ context.Context, toRemove []string) (int, error) {
+			calls = append(calls, toRemove...)
+			return 204, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Join(calls, ",") != strings.Join(ids, ",") {
+			t.Fatalf("expected each ID to be removed in order, got: %v", calls)
+		}
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		err := RemoveReferences(ctx, "owner", ids, func(_ context.Context, toRemove []string) (int, error) {
+			if toRemove[0] == ids[1] {
+				return 403, errors.New("insufficient privileges")
+			}
+			return 204, nil
+		})
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, expected := range []string{
+			`removing owner "22222222-2222-2222-2222-222222222222": insufficient privileges`,
+			"Removed owners: [11111111-1111-1111-1111-111111111111]",
+			"Not removed owners: [22222222-2222-2222-2222-222222222222, 33333333-3333-3333-3333-333333333333]",
+		} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Fatalf("expected error containing %q, got: %v", expected, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package helpers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+// RelationshipResource describes a resource which manages a single reference between a parent directory object and a
+// related object, such as a member or an owner of a group. Resources built from a RelationshipResource share the same
+// semantics for import IDs and existing relationships:
+//
+// - the resource ID is composed of the parent object ID and the related object ID, as returned by FormatId
+// - creating a relationship which already exists results in an import error
+// - the relationship is removed from state when it is no longer listed for the parent object
+//
+// Handling of a parent object or relationship which no longer exists is determined by MissingIsRemoved.
+type RelationshipResource struct {
+	// Name is the name of the Terraform resource, e.g. `azuread_group_member`
+	Name string
+
+	// ParentType is a human readable name for the parent object, e.g. `group`
+	ParentType string
+
+	// ParentAttribute is the name of the schema attribute holding the parent object ID, e.g. `group_object_id`
+	ParentAttribute string
+
+	// ParentDescription is the description of the parent attribute in the resource schema
+	ParentDescription string
+
+	// RelatedType is a human readable name for the related object, e.g. `member`
+	RelatedType string
+
+	// RelatedAttribute is the name of the schema attribute holding the related object ID, e.g. `member_object_id`
+	RelatedAttribute string
+
+	// RelatedDescription is the description of the related attribute in the resource schema
+	RelatedDescription string
+
+	// LockName is the name used to lock the parent object whilst relationships are added or removed, which should
+	// match the name used by the resource managing the parent object
+	LockName string
+
+	// MissingIsRemoved causes the relationship to be removed from state when the parent object no longer exists, and
+	// deleting a relationship for which the parent object or the relationship no longer exists to succeed, unless
+	// StrictDelete returns true. Otherwise, these cases result in an error.
+	MissingIsRemoved bool
+
+	// StrictDelete is optional, and returns whether the provider is configured to fail when deleting objects which no
+	// longer exist
+	StrictDelete func(meta interface{}) bool
+
+	// FormatId returns the resource ID for a relationship
+	FormatId func(parentId, relatedId string) string
+
+	// ParseId parses a resource ID, returning the parent object ID and the related object ID
+	ParseId func(id string) (parentId, relatedId string, err error)
+
+	// GetParent retrieves the parent object, waiting for it to become available when it was recently created, and
+	// returns the HTTP status of the request
+	GetParent func(ctx context.Context, meta interface{}, parentId string) (int, error)
+
+	// List returns the IDs of the objects related to the parent object, along with the HTTP status of the request
+	List func(ctx context.Context, meta interface{}, parentId string) (*[]string, int, error)
+
+	// Add adds a relationship, and is expected to return an error suitable for presenting to the user
+	Add func(ctx context.Context, meta interface{}, parentId, relatedId string) error
+
+	// Remove removes a relationship, returning the HTTP status of the request
+	Remove func(ctx context.Context, meta interface{}, parentId, relatedId string) (int, error)
+
+	// ValidateAdd is optional, and returns an error when the related object cannot be added to the parent object
+	ValidateAdd func(ctx context.Context, meta interface{}, parentId, relatedId string) error
+
+	// ValidateRemove is optional, and returns an error when the related object cannot be removed from the parent
+	// object, given the IDs of all objects currently related to it
+	ValidateRemove func(related *[]string, relatedId string) error
+}
+
+// Resource builds a Terraform resource which manages the relationship
+func (r RelationshipResource) Resource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: r.create,
+		ReadContext:   r.read,
+		DeleteContext: r.delete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, _, err := r.ParseId(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			r.ParentAttribute: {
+				Description:      r.ParentDescription,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			r.RelatedAttribute: {
+				Description:      r.RelatedDescription,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+		},
+	}
+}
+
+// find returns the ID of the related object as listed for the parent object, or an empty string when it's not found
+func (r RelationshipResource) find(related *[]string, relatedId string) string {
+	if related != nil {
+		for _, v := range *related {
+			if strings.EqualFold(v, relatedId) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+func (r RelationshipResource) create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parentId := d.Get(r.ParentAttribute).(string)
+	relatedId := d.Get(r.RelatedAttribute).(string)
+	id := r.FormatId(parentId, relatedId)
+
+	tf.LockByName(r.LockName, parentId)
+	defer tf.UnlockByName(r.LockName, parentId)
+
+	if status, err := r.GetParent(ctx, meta, parentId); err != nil {
+		if status == http.StatusNotFound {
+			return tf.ErrorDiagPathF(nil, r.ParentAttribute, "The %s with object ID %q was not found", r.ParentType, parentId)
+		}
+		return tf.ErrorDiagPathF(err, r.ParentAttribute, "Retrieving %s with object ID %q", r.ParentType, parentId)
+	}
+
+	existing, _, err := r.List(ctx, meta, parentId)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing existing %ss for %s with object ID %q", r.RelatedType, r.ParentType, parentId)
+	}
+	if r.find(existing, relatedId) != "" {
+		return tf.ImportAsExistsDiag(r.Name, id)
+	}
+
+	if r.ValidateAdd != nil {
+		if err := r.ValidateAdd(ctx, meta, parentId, relatedId); err != nil {
+			return tf.ErrorDiagPathF(err, r.RelatedAttribute, "Invalid %s for %s with object ID %q", r.RelatedType, r.ParentType, parentId)
+		}
+	}
+
+	if err := r.Add(ctx, meta, parentId, relatedId); err != nil {
+		return tf.ErrorDiagF(err, "Adding %s %q to %s with object ID %q", r.RelatedType, relatedId, r.ParentType, parentId)
+	}
+
+	d.SetId(id)
+
+	return r.read(ctx, d, meta)
+}
+
+func (r RelationshipResource) read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parentId, relatedId, err := r.ParseId(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing %s ID %q", r.Name, d.Id())
+	}
+
+	related, status, err := r.List(ctx, meta, parentId)
+	if err != nil {
+		if status == http.StatusNotFound && r.MissingIsRemoved {
+			log.Printf("[DEBUG] The %s with object ID %q was not found - removing %s %q from state", r.ParentType, parentId, r.RelatedType, relatedId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Listing %ss for %s with object ID %q", r.RelatedType, r.ParentType, parentId)
+	}
+
+	relatedObjectId := r.find(related, relatedId)
+	if relatedObjectId == "" {
+		log.Printf("[DEBUG] The %s %q was not found for %s %q - removing from state", r.RelatedType, relatedId, r.ParentType, parentId)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, r.ParentAttribute, parentId)
+	tf.Set(d, r.RelatedAttribute, relatedObjectId)
+
+	return nil
+}
+
+func (r RelationshipResource) delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parentId, relatedId, err := r.ParseId(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing %s ID %q", r.Name, d.Id())
+	}
+
+	tf.LockByName(r.LockName, parentId)
+	defer tf.UnlockByName(r.LockName, parentId)
+
+	missingIsRemoved := r.MissingIsRemoved && (r.StrictDelete == nil || !r.StrictDelete(meta))
+
+	if r.ValidateRemove != nil {
+		related, status, err := r.List(ctx, meta, parentId)
+		if err != nil {
+			if status == http.StatusNotFound && missingIsRemoved {
+				log.Printf("[DEBUG] The %s with object ID %q was not found - assuming %s %q was already removed", r.ParentType, parentId, r.RelatedType, relatedId)
+				return nil
+			}
+			return tf.ErrorDiagF(err, "Listing existing %ss for %s with object ID %q", r.RelatedType, r.ParentType, parentId)
+		}
+
+		if err := r.ValidateRemove(related, relatedId); err != nil {
+			return tf.ErrorDiagF(err, "Removing %s %q from %s with object ID %q", r.RelatedType, relatedId, r.ParentType, parentId)
+		}
+	}
+
+	if status, err := r.Remove(ctx, meta, parentId, relatedId); err != nil {
+		if status == http.StatusNotFound && missingIsRemoved {
+			log.Printf("[DEBUG] The %s %q was not found for %s %q - assuming it was already removed", r.RelatedType, relatedId, r.ParentType, parentId)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Removing %s %q from %s with object ID %q", r.RelatedType, relatedId, r.ParentType, parentId)
+	}
+
+	return nil
+}
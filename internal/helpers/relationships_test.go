@@ -0,0 +1,282 @@
+package helpers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	testRelationshipParentId  = "00000000-0000-0000-0000-000000000000"
+	testRelationshipRelatedId = "11111111-1111-1111-1111-111111111111"
+)
+
+// fakeRelationships simulates a parent object and the objects related to it
+type fakeRelationships struct {
+	parentExists     bool
+	related          []string
+	removeStatus     int
+	removed          []string
+	missingIsRemoved bool
+	strict           bool
+}
+
+func (f *fakeRelationships) relationship() RelationshipResource {
+	return RelationshipResource{
+		Name:             "azuread_test_relationship",
+		ParentType:       "parent",
+		ParentAttribute:  "parent_object_id",
+		RelatedType:      "related",
+		RelatedAttribute: "related_object_id",
+		LockName:         "azuread_test_parent",
+		MissingIsRemoved: f.missingIsRemoved,
+		StrictDelete:     func(_ interface{}) bool { return f.strict },
+		FormatId:         func(parentId, relatedId string) string { return fmt.Sprintf("%s/related/%s", parentId, relatedId) },
+		ParseId: func(id string) (string, string, error) {
+			parts := strings.Split(id, "/")
+			if len(parts) != 3 || parts[1] != "related" {
+				return "", "", fmt.Errorf("invalid ID %q", id)
+			}
+			return parts[0], parts[2], nil
+		},
+		GetParent: func(_ context.Context, _ interface{}, _ string) (int, error) {
+			if !f.parentExists {
+				return http.StatusNotFound, errors.New("not found")
+			}
+			return http.StatusOK, nil
+		},
+		List: func(_ context.Context, _ interface{}, _ string) (*[]string, int, error) {
+			if !f.parentExists {
+				return nil, http.StatusNotFound, errors.New("not found")
+			}
+			related := append([]string{}, f.related...)
+			return &related, http.StatusOK, nil
+		},
+		Add: func(_ context.Context, _ interface{}, _, relatedId string) error {
+			f.related = append(f.related, relatedId)
+			return nil
+		},
+		Remove: func(_ context.Context, _ interface{}, _, relatedId string) (int, error) {
+			if f.removeStatus != 0 {
+				return f.removeStatus, errors.New("remove failed")
+			}
+			f.removed = append(f.removed, relatedId)
+			return http.StatusNoContent, nil
+		},
+	}
+}
+
+func testRelationshipResourceData(t *testing.T, resource *schema.Resource, id string) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"parent_object_id":  testRelationshipParentId,
+		"related_object_id": testRelationshipRelatedId,
+	})
+	d.SetId(id)
+	return d
+}
+
+func TestRelationshipResourceCreate(t *testing.T) {
+	ctx := context.Background()
+	expectedId := testRelationshipParentId + "/related/" + testRelationshipRelatedId
+
+	t.Run("added", func(t *testing.T) {
+		f := &fakeRelationships{parentExists: true}
+		resource := f.relationship().Resource()
+		d := testRelationshipResourceData(t, resource, "")
+
+		if diags := resource.CreateContext(ctx, d, nil); diags.HasError() {
+			t.Fatalf("unexpected error: %+v", diags)
+		}
+		if d.Id() != expectedId {
+			t.Fatalf("expected ID %q, got %q", expectedId, d.Id())
+		}
+		if len(f.related) != 1 || f.related[0] != testRelationshipRelatedId {
+			t.Fatalf("expected related object to be added, got %v", f.related)
+		}
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		f := &fakeRelationships{parentExists: true, related: []string{strings.ToUpper(testRelationshipRelatedId)}}
+		resource := f.relationship().Resource()
+		d := testRelationshipResourceData(t, resource, "")
+
+		diags := resource.CreateContext(ctx, d, nil)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "already exists") {
+			t.Fatalf("expected an import error, got %+v", diags)
+		}
+		if len(f.related) != 1 {
+			t.Fatalf("expected no related objects to be added, got %v", f.related)
+		}
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		f := &fakeRelationships{}
+		resource := f.relationship().Resource()
+		d := testRelationshipResourceData(t, resource, "")
+
+		if diags := resource.CreateContext(ctx, d, nil); !diags.HasError() {
+			t.Fatal("expected an error when the parent object does not exist")
+		}
+		if d.Id() != "" {
+			t.Fatalf("expected no ID to be set, got %q", d.Id())
+		}
+	})
+
+	t.Run("invalid related object", func(t *testing.T) {
+		f := &fakeRelationships{parentExists: true}
+		relationship := f.relationship()
+		relationship.ValidateAdd = func(_ context.Context, _ interface{}, _, _ string) error {
+			return errors.New("unsupported object type")
+		}
+		resource := relationship.Resource()
+		d := testRelationshipResourceData(t, resource, "")
+
+		if diags := resource.CreateContext(ctx, d, nil); !diags.HasError() {
+			t.Fatal("expected a validation error")
+		}
+		if len(f.related) != 0 {
+			t.Fatalf("expected no related objects to be added, got %v", f.related)
+		}
+	})
+}
+
+func TestRelationshipResourceRead(t *testing.T) {
+	ctx := context.Background()
+	id := testRelationshipParentId + "/related/" + testRelationshipRelatedId
+
+	testCases := []struct {
+		name        string
+		fake        *fakeRelationships
+		expectError bool
+		expectGone  bool
+	}{
+		{
+			name: "exists",
+			fake: &fakeRelationships{parentExists: true, related: []string{testRelationshipRelatedId}},
+		},
+		{
+			name:       "relationship not found",
+			fake:       &fakeRelationships{parentExists: true, related: []string{"22222222-2222-2222-2222-222222222222"}},
+			expectGone: true,
+		},
+		{
+			name:       "parent not found",
+			fake:       &fakeRelationships{missingIsRemoved: true},
+			expectGone: true,
+		},
+		{
+			name:        "parent not found without removal",
+			fake:        &fakeRelationships{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource := tc.fake.relationship().Resource()
+			d := testRelationshipResourceData(t, resource, id)
+
+			if diags := resource.ReadContext(ctx, d, nil); diags.HasError() != tc.expectError {
+				t.Fatalf("expected error to be %t, got %+v", tc.expectError, diags)
+			}
+			if gone := d.Id() == ""; gone != tc.expectGone {
+				t.Fatalf("expected removal from state to be %t, got ID %q", tc.expectGone, d.Id())
+			}
+		})
+	}
+}
+
+func TestRelationshipResourceDelete(t *testing.T) {
+	ctx := context.Background()
+	id := testRelationshipParentId + "/related/" + testRelationshipRelatedId
+
+	testCases := []struct {
+		name           string
+		fake           *fakeRelationships
+		validateRemove bool
+		expectError    bool
+		expectRemoved  bool
+	}{
+		{
+			name:          "removed",
+			fake:          &fakeRelationships{parentExists: true, related: []string{testRelationshipRelatedId}},
+			expectRemoved: true,
+		},
+		{
+			name: "relationship not found",
+			fake: &fakeRelationships{parentExists: true, removeStatus: http.StatusNotFound, missingIsRemoved: true},
+		},
+		{
+			name:        "relationship not found with strict delete",
+			fake:        &fakeRelationships{parentExists: true, removeStatus: http.StatusNotFound, missingIsRemoved: true, strict: true},
+			expectError: true,
+		},
+		{
+			name:        "relationship not found without removal",
+			fake:        &fakeRelationships{parentExists: true, removeStatus: http.StatusNotFound},
+			expectError: true,
+		},
+		{
+			name:        "remove failed",
+			fake:        &fakeRelationships{parentExists: true, removeStatus: http.StatusForbidden},
+			expectError: true,
+		},
+		{
+			name:           "parent not found when validating",
+			fake:           &fakeRelationships{missingIsRemoved: true},
+			validateRemove: true,
+		},
+		{
+			name:           "parent not found when validating with strict delete",
+			fake:           &fakeRelationships{missingIsRemoved: true, strict: true},
+			validateRemove: true,
+			expectError:    true,
+		},
+		{
+			name:           "removal not permitted",
+			fake:           &fakeRelationships{parentExists: true, related: []string{testRelationshipRelatedId}},
+			validateRemove: true,
+			expectError:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			relationship := tc.fake.relationship()
+			if tc.validateRemove {
+				relationship.ValidateRemove = func(_ *[]string, _ string) error {
+					return errors.New("cannot remove the last related object")
+				}
+			}
+			resource := relationship.Resource()
+			d := testRelationshipResourceData(t, resource, id)
+
+			if diags := resource.DeleteContext(ctx, d, nil); diags.HasError() != tc.expectError {
+				t.Fatalf("expected error to be %t, got %+v", tc.expectError, diags)
+			}
+			if removed := len(tc.fake.removed) == 1; removed != tc.expectRemoved {
+				t.Fatalf("expected removal to be %t, got %v", tc.expectRemoved, tc.fake.removed)
+			}
+		})
+	}
+}
+
+func TestRelationshipResourceImport(t *testing.T) {
+	resource := (&fakeRelationships{}).relationship().Resource()
+
+	for _, id := range []string{
+		testRelationshipParentId + "/related/" + testRelationshipRelatedId,
+		testRelationshipParentId + "/other/" + testRelationshipRelatedId,
+	} {
+		d := testRelationshipResourceData(t, resource, id)
+		_, err := resource.Importer.StateContext(context.Background(), d, nil)
+		if valid := err == nil; valid != strings.Contains(id, "/related/") {
+			t.Fatalf("unexpected result importing %q: %v", id, err)
+		}
+	}
+}
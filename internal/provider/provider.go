@@ -69,6 +69,10 @@ func AzureADProvider() *schema.Provider {
 		}
 	}
 
+	applyReadOnlyGuard(resources)
+	applyDefaultTimeouts(resources)
+	applyDefaultTimeouts(dataSources)
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
@@ -152,6 +156,67 @@ func AzureADProvider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("ARM_DISABLE_TERRAFORM_PARTNER_ID", false),
 				Description: "Disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
+
+			// Feature flags
+			"batch_user_creation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_BATCH_USER_CREATION", false),
+				Description: "Create users using batch requests, which can significantly speed up the creation of many users in a single apply.",
+			},
+
+			"disable_lookup_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_DISABLE_LOOKUP_CACHE", false),
+				Description: "Disable caching of lookups which cannot change during an operation, such as directory role templates, tenant domains and the service principals of Microsoft-published APIs.",
+			},
+
+			"strict_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_STRICT_DELETE", false),
+				Description: "Return an error when deleting a resource whose object no longer exists, instead of treating it as already deleted.",
+			},
+
+			"rollback_on_partial_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_ROLLBACK_ON_PARTIAL_CREATE", false),
+				Description: "Delete the object created for a group or application when a subsequent step of its creation fails, such as adding owners or members, instead of leaving it in state to be replaced.",
+			},
+
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_PROVIDER_READ_ONLY", false),
+				Description: "Forbid all write operations, so that creating, updating or deleting any resource fails without making changes. Reading resources and data sources is unaffected.",
+			},
+
+			// Default timeouts
+			"default_create_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default timeout for creating resources, e.g. `10m`. Overrides the default for all resources, unless a `timeouts` block is specified for a resource.",
+			},
+
+			"default_read_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default timeout for reading resources and data sources, e.g. `10m`. Overrides the default for all resources and data sources, unless a `timeouts` block is specified.",
+			},
+
+			"default_update_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default timeout for updating resources, e.g. `10m`. Overrides the default for all resources, unless a `timeouts` block is specified for a resource.",
+			},
+
+			"default_delete_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The default timeout for deleting resources, e.g. `10m`. Overrides the default for all resources, unless a `timeouts` block is specified for a resource.",
+			},
 		},
 
 		ResourcesMap:   resources,
@@ -187,7 +252,30 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			partnerId = terraformPartnerId
 		}
 
-		return buildClient(ctx, p, authConfig, partnerId)
+		timeouts, diags := expandDefaultTimeouts(d)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		client, diags := buildClient(ctx, p, authConfig, partnerId)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if d.Get("batch_user_creation").(bool) {
+			client.Users.EnableCreateBatching()
+		}
+
+		if d.Get("disable_lookup_cache").(bool) {
+			client.DisableLookupCache()
+		}
+
+		client.StrictDelete = d.Get("strict_delete").(bool)
+		client.RollbackOnPartialCreate = d.Get("rollback_on_partial_create").(bool)
+		client.ReadOnly = d.Get("read_only").(bool)
+		client.DefaultTimeouts = timeouts
+
+		return client, diags
 	}
 }
 
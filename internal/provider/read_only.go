@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+// applyReadOnlyGuard wraps the create, update and delete functions of the provided resources, so that they fail without
+// making any API requests when the provider is configured to be read-only. This is applied as resources are registered,
+// so that every resource is covered without needing to check the configuration itself.
+func applyReadOnlyGuard(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		if resource.CreateContext != nil {
+			resource.CreateContext = readOnlyGuard(name, "created", resource.CreateContext)
+		}
+		if resource.CreateWithoutTimeout != nil {
+			resource.CreateWithoutTimeout = readOnlyGuard(name, "created", resource.CreateWithoutTimeout)
+		}
+		if resource.UpdateContext != nil {
+			resource.UpdateContext = readOnlyGuard(name, "updated", resource.UpdateContext)
+		}
+		if resource.UpdateWithoutTimeout != nil {
+			resource.UpdateWithoutTimeout = readOnlyGuard(name, "updated", resource.UpdateWithoutTimeout)
+		}
+		if resource.DeleteContext != nil {
+			resource.DeleteContext = readOnlyGuard(name, "deleted", resource.DeleteContext)
+		}
+		if resource.DeleteWithoutTimeout != nil {
+			resource.DeleteWithoutTimeout = readOnlyGuard(name, "deleted", resource.DeleteWithoutTimeout)
+		}
+	}
+}
+
+func readOnlyGuard(name, operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		if client, ok := meta.(*clients.Client); ok && client.ReadOnly {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s cannot be %s because the provider is read-only", name, operation),
+				Detail:   "The provider is configured with `read_only` (or the ARM_PROVIDER_READ_ONLY environment variable), which forbids all write operations. No changes were made.",
+			}}
+		}
+		return f(ctx, d, meta)
+	}
+}
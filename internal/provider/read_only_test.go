@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/environments"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	applications "github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
+	conditionalaccess "github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	groups "github.com/hashicorp/terraform-provider-azuread/internal/services/groups/client"
+	policies "github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	users "github.com/hashicorp/terraform-provider-azuread/internal/services/users/client"
+)
+
+// recordingTransport records every request sent through it, and fails them without contacting the API
+type recordingTransport struct {
+	mu       sync.Mutex
+	requests []string
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests = append(t.requests, req.Method+" "+req.URL.Path)
+	return nil, errors.New("request recorded")
+}
+
+func (t *recordingTransport) writes() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]string, 0)
+	for _, r := range t.requests {
+		if !strings.HasPrefix(r, http.MethodGet+" ") {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+func TestProvider_readOnly(t *testing.T) {
+	transport := &recordingTransport{}
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() {
+		http.DefaultTransport = defaultTransport
+	}()
+
+	o := &common.ClientOptions{
+		Environment: environments.Global,
+		TenantID:    "00000000-0000-0000-0000-000000000000",
+	}
+	client := &clients.Client{
+		ReadOnly:          true,
+		Applications:      applications.NewClient(o),
+		ConditionalAccess: conditionalaccess.NewClient(o),
+		Groups:            groups.NewClient(o),
+		Policies:          policies.NewClient(o),
+		Users:             users.NewClient(o),
+	}
+
+	provider := AzureADProvider()
+	ctx := context.Background()
+
+	testCases := map[string]map[string]interface{}{
+		"azuread_application": {
+			"display_name": "acctest",
+		},
+		"azuread_claims_mapping_policy": {
+			"display_name": "acctest",
+			"definition":   []interface{}{"{}"},
+		},
+		"azuread_conditional_access_policy": {
+			"display_name": "acctest",
+			"state":        "disabled",
+			"conditions": []interface{}{map[string]interface{}{
+				"applications": []interface{}{map[string]interface{}{
+					"included_applications": []interface{}{"All"},
+				}},
+				"users": []interface{}{map[string]interface{}{
+					"included_users": []interface{}{"All"},
+				}},
+				"client_app_types": []interface{}{"all"},
+			}},
+			"grant_controls": []interface{}{map[string]interface{}{
+				"operator":          "OR",
+				"built_in_controls": []interface{}{"mfa"},
+			}},
+		},
+		"azuread_group": {
+			"display_name":     "acctest",
+			"security_enabled": true,
+		},
+		"azuread_named_location": {
+			"display_name": "acctest",
+			"ip": []interface{}{map[string]interface{}{
+				"ip_ranges": []interface{}{"1.1.1.1/32"},
+			}},
+		},
+		"azuread_user": {
+			"display_name":        "acctest",
+			"user_principal_name": "acctest@example.com",
+			"password":            "Pa55w0rd!",
+		},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource := provider.ResourcesMap[name]
+			d := schema.TestResourceDataRaw(t, resource.Schema, config)
+
+			diags := resource.CreateWithoutTimeout(ctx, d, client)
+			if !diags.HasError() || !strings.Contains(diags[0].Summary, "cannot be created because the provider is read-only") {
+				t.Fatalf("expected create to fail in read-only mode, got: %+v", diags)
+			}
+			if d.Id() != "" {
+				t.Fatalf("expected no ID to be set, got %q", d.Id())
+			}
+
+			d.SetId("11111111-1111-1111-1111-111111111111")
+			if resource.UpdateWithoutTimeout != nil {
+				if diags := resource.UpdateWithoutTimeout(ctx, d, client); !diags.HasError() || !strings.Contains(diags[0].Summary, "cannot be updated") {
+					t.Fatalf("expected update to fail in read-only mode, got: %+v", diags)
+				}
+			}
+			if diags := resource.DeleteWithoutTimeout(ctx, d, client); !diags.HasError() || !strings.Contains(diags[0].Summary, "cannot be deleted") {
+				t.Fatalf("expected delete to fail in read-only mode, got: %+v", diags)
+			}
+
+			if writes := transport.writes(); len(writes) > 0 {
+				t.Fatalf("expected no write requests, got: %v", writes)
+			}
+		})
+	}
+
+	// Reads are unaffected, so the read is attempted and fails only because the transport rejects it
+	group := provider.ResourcesMap["azuread_group"]
+	d := schema.TestResourceDataRaw(t, group.Schema, map[string]interface{}{})
+	d.SetId("11111111-1111-1111-1111-111111111111")
+	if diags := group.ReadWithoutTimeout(ctx, d, client); !diags.HasError() || strings.Contains(diags[0].Summary, "read-only") {
+		t.Fatalf("expected read to be attempted, got: %+v", diags)
+	}
+	if len(transport.requests) == 0 {
+		t.Fatalf("expected the read to send a request")
+	}
+	if writes := transport.writes(); len(writes) > 0 {
+		t.Fatalf("expected no write requests, got: %v", writes)
+	}
+}
+
+func TestProvider_readOnlyDisabled(t *testing.T) {
+	called := false
+	resources := map[string]*schema.Resource{
+		"azuread_test": {
+			CreateContext: func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+				called = true
+				return nil
+			},
+		},
+	}
+	applyReadOnlyGuard(resources)
+
+	if diags := resources["azuread_test"].CreateContext(context.Background(), nil, &clients.Client{}); diags.HasError() {
+		t.Fatalf("unexpected error: %+v", diags)
+	}
+	if !called {
+		t.Fatalf("expected create to be called when the provider is not read-only")
+	}
+}
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+// expandDefaultTimeouts parses the `default_*_timeout` provider arguments, returning the configured timeouts keyed by
+// operation
+func expandDefaultTimeouts(d *schema.ResourceData) (map[string]time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	result := make(map[string]time.Duration)
+
+	for _, t := range []struct {
+		key       string
+		operation string
+	}{
+		{"default_create_timeout", schema.TimeoutCreate},
+		{"default_read_timeout", schema.TimeoutRead},
+		{"default_update_timeout", schema.TimeoutUpdate},
+		{"default_delete_timeout", schema.TimeoutDelete},
+	} {
+		v := d.Get(t.key).(string)
+		if v == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(v)
+		if err == nil && duration <= 0 {
+			err = fmt.Errorf("duration must be greater than zero")
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Invalid value for `%s`: %q", t.key, v),
+				Detail:        fmt.Sprintf("Expected a duration such as `10m` or `1h30m`: %v", err),
+				AttributePath: cty.GetAttrPath(t.key),
+			})
+			continue
+		}
+
+		result[t.operation] = duration
+	}
+
+	return result, diags
+}
+
+// timeoutUnset is added to the default timeouts of a resource to mark them as unset. Timeouts parsed from a resource's
+// `timeouts` block replace these values, so a timeout specified in configuration can be distinguished from the resource
+// default even when it has the same value. The SDK does not otherwise expose whether a timeout was configured.
+const timeoutUnset = time.Nanosecond
+
+// applyDefaultTimeouts wraps the functions of the provided resources, so that the default timeouts configured for the
+// provider are used for operations without a timeout specified in the resource's `timeouts` block. This is applied as
+// resources are registered and the timeout is resolved for each operation, so that resources are not changed when the
+// provider is configured. Only timeouts already supported by a resource are overridden, since the set of supported
+// timeouts forms part of the resource schema.
+func applyDefaultTimeouts(resources map[string]*schema.Resource) {
+	for _, resource := range resources {
+		if resource.Timeouts == nil {
+			continue
+		}
+
+		timeouts := *resource.Timeouts
+		if resource.CreateContext != nil && timeouts.Create != nil {
+			timeouts.Create, resource.CreateWithoutTimeout = defaultTimeout(schema.TimeoutCreate, *timeouts.Create, resource.CreateContext)
+			resource.CreateContext = nil
+		}
+		if resource.ReadContext != nil && timeouts.Read != nil {
+			timeouts.Read, resource.ReadWithoutTimeout = defaultTimeout(schema.TimeoutRead, *timeouts.Read, resource.ReadContext)
+			resource.ReadContext = nil
+		}
+		if resource.UpdateContext != nil && timeouts.Update != nil {
+			timeouts.Update, resource.UpdateWithoutTimeout = defaultTimeout(schema.TimeoutUpdate, *timeouts.Update, resource.UpdateContext)
+			resource.UpdateContext = nil
+		}
+		if resource.DeleteContext != nil && timeouts.Delete != nil {
+			timeouts.Delete, resource.DeleteWithoutTimeout = defaultTimeout(schema.TimeoutDelete, *timeouts.Delete, resource.DeleteContext)
+			resource.DeleteContext = nil
+		}
+		resource.Timeouts = &timeouts
+	}
+}
+
+// defaultTimeout returns the marked resource default for the operation, along with a function which calls f with the
+// timeout for the operation applied to its context. When the marked resource default has not been replaced by a value
+// from the resource's `timeouts` block, any default timeout configured for the provider is used, falling back to the
+// resource default.
+func defaultTimeout(operation string, resourceDefault time.Duration, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) (*time.Duration, func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) {
+	unset := resourceDefault + timeoutUnset
+
+	return &unset, func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		timeout := d.Timeout(operation)
+		if timeout == unset {
+			timeout = resourceDefault
+			if client, ok := meta.(*clients.Client); ok {
+				if v, ok := client.DefaultTimeouts[operation]; ok {
+					timeout = v
+				}
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return f(ctx, d, meta)
+	}
+}
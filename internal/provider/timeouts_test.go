@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+)
+
+// testTimeoutResource returns a resource whose functions record the time remaining before their context deadline
+func testTimeoutResource(remaining map[string]time.Duration) *schema.Resource {
+	record := func(operation string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+			if deadline, ok := ctx.Deadline(); ok {
+				remaining[operation] = time.Until(deadline).Round(time.Minute)
+			}
+			return nil
+		}
+	}
+
+	return &schema.Resource{
+		CreateContext: record(schema.TimeoutCreate),
+		ReadContext:   record(schema.TimeoutRead),
+		UpdateContext: record(schema.TimeoutUpdate),
+		DeleteContext: record(schema.TimeoutDelete),
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func TestProvider_defaultTimeouts(t *testing.T) {
+	provider := AzureADProvider()
+
+	d := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+		"default_create_timeout": "45m",
+		"default_read_timeout":   "10m",
+		"default_delete_timeout": "30m",
+	})
+	timeouts, diags := expandDefaultTimeouts(d)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %+v", diags)
+	}
+	client := &clients.Client{DefaultTimeouts: timeouts}
+
+	remaining := make(map[string]time.Duration)
+	resources := map[string]*schema.Resource{"test": testTimeoutResource(remaining)}
+	applyDefaultTimeouts(resources)
+	r := resources["test"]
+
+	if err := r.InternalValidate(nil, true); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+	if *r.Timeouts.Create != 5*time.Minute+timeoutUnset {
+		t.Fatalf("expected the resource default create timeout to be marked as unset, got %s", *r.Timeouts.Create)
+	}
+
+	ctx := context.Background()
+	rd := r.Data(nil)
+	r.CreateWithoutTimeout(ctx, rd, client)
+	r.ReadWithoutTimeout(ctx, rd, client)
+	r.UpdateWithoutTimeout(ctx, rd, client)
+	if r.DeleteContext == nil || r.DeleteWithoutTimeout != nil {
+		t.Fatal("expected delete, which has no timeout, not to be wrapped")
+	}
+
+	if v := remaining[schema.TimeoutCreate]; v != 45*time.Minute {
+		t.Fatalf("expected create timeout of 45m, got %s", v)
+	}
+	if v := remaining[schema.TimeoutRead]; v != 10*time.Minute {
+		t.Fatalf("expected read timeout of 10m, got %s", v)
+	}
+	if v := remaining[schema.TimeoutUpdate]; v != 5*time.Minute {
+		t.Fatalf("expected update timeout to remain at 5m, got %s", v)
+	}
+
+	// Timeouts specified for a resource take precedence, including those equal to the resource default
+	var configured schema.ResourceTimeout
+	if err := configured.ConfigDecode(r, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"timeouts": []interface{}{
+			map[string]interface{}{
+				"create": "2m",
+				"update": "5m",
+			},
+		},
+	})); err != nil {
+		t.Fatalf("unexpected error decoding timeouts: %v", err)
+	}
+	rd = (&schema.Resource{Schema: r.Schema, Timeouts: &configured}).Data(nil)
+	r.CreateWithoutTimeout(ctx, rd, client)
+	r.ReadWithoutTimeout(ctx, rd, client)
+	r.UpdateWithoutTimeout(ctx, rd, &clients.Client{DefaultTimeouts: map[string]time.Duration{schema.TimeoutUpdate: time.Hour}})
+
+	if v := remaining[schema.TimeoutCreate]; v != 2*time.Minute {
+		t.Fatalf("expected configured create timeout of 2m, got %s", v)
+	}
+	if v := remaining[schema.TimeoutRead]; v != 10*time.Minute {
+		t.Fatalf("expected default read timeout of 10m, got %s", v)
+	}
+	if v := remaining[schema.TimeoutUpdate]; v != 5*time.Minute {
+		t.Fatalf("expected configured update timeout of 5m, equal to the resource default, got %s", v)
+	}
+
+	// Without provider defaults, resource timeouts are unchanged
+	rd = r.Data(nil)
+	r.CreateWithoutTimeout(ctx, rd, &clients.Client{})
+	if v := remaining[schema.TimeoutCreate]; v != 5*time.Minute {
+		t.Fatalf("expected create timeout of 5m, got %s", v)
+	}
+}
+
+func TestProvider_defaultTimeoutsRegistered(t *testing.T) {
+	provider := AzureADProvider()
+
+	if err := provider.InternalValidate(); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	group := provider.ResourcesMap["azuread_group"]
+	if group.CreateWithoutTimeout == nil || group.CreateContext != nil {
+		t.Fatal("expected default timeouts to be applied to resources")
+	}
+	if dataSource := provider.DataSourcesMap["azuread_group"]; dataSource.ReadWithoutTimeout == nil || dataSource.ReadContext != nil {
+		t.Fatal("expected default timeouts to be applied to data sources")
+	}
+}
+
+func TestProvider_defaultTimeoutsInvalid(t *testing.T) {
+	provider := AzureADProvider()
+
+	for _, v := range []string{"10", "ten minutes", "-5m", "0s"} {
+		d := schema.TestResourceDataRaw(t, provider.Schema, map[string]interface{}{
+			"default_delete_timeout": v,
+		})
+		if _, diags := expandDefaultTimeouts(d); !diags.HasError() {
+			t.Fatalf("expected an error for default_delete_timeout %q", v)
+		}
+	}
+}
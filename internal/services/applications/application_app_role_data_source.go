@@ -0,0 +1,175 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func applicationAppRoleDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: applicationAppRoleDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_object_id": {
+				Description:      "The object ID of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_id", "client_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"application_id": {
+				Description:      "The Application ID (also called Client ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"client_id": {
+				Description:      "The Client ID (also called Application ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"value": {
+				Description:      "The value of the app role, as emitted in the `roles` claim of issued tokens. This is case-sensitive",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "value"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"display_name": {
+				Description:      "The display name of the app role",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "value"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"allowed_member_types": {
+				Description: "Specifies whether this app role definition can be assigned to users and groups, or to other applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"description": {
+				Description: "Description of the app role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"enabled": {
+				Description: "Whether the app role is enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"role_id": {
+				Description: "The unique identifier of the app role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func applicationAppRoleDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+
+	app, diags := applicationDataSourceGet(ctx, client, d)
+	if diags.HasError() {
+		return diags
+	}
+
+	value := d.Get("value").(string)
+	displayName := d.Get("display_name").(string)
+
+	attr, identifier := "display_name", displayName
+	if value != "" {
+		attr, identifier = "value", value
+	}
+
+	roles, err := ApplicationFindAppRolesByValue(app, value, displayName)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, attr, "Finding app role %q for application with object ID %q", identifier, *app.ID)
+	}
+
+	switch {
+	case len(roles) == 0:
+		all := applicationFindAppRoles(app, func(msgraph.AppRole) bool { return true })
+		return tf.ErrorDiagPathF(fmt.Errorf("available app roles: %s", applicationAppRoleCandidates(all)),
+			attr, "No app role found with %s %q for application with object ID %q", strings.ReplaceAll(attr, "_", " "), identifier, *app.ID)
+	case len(roles) > 1:
+		return tf.ErrorDiagPathF(fmt.Errorf("matching app roles: %s", applicationAppRoleCandidates(roles)),
+			attr, "Found %d app roles with %s %q for application with object ID %q", len(roles), strings.ReplaceAll(attr, "_", " "), identifier, *app.ID)
+	}
+
+	role := roles[0]
+	if role.ID == nil {
+		return tf.ErrorDiagF(errors.New("ID returned for app role is nil"), "Bad API Response")
+	}
+
+	d.SetId(parse.NewAppRoleID(*app.ID, *role.ID).String())
+
+	allowedMemberTypes := make([]string, 0)
+	if role.AllowedMemberTypes != nil {
+		for _, v := range *role.AllowedMemberTypes {
+			allowedMemberTypes = append(allowedMemberTypes, string(v))
+		}
+	}
+
+	tf.Set(d, "allowed_member_types", allowedMemberTypes)
+	tf.Set(d, "application_id", app.AppId)
+	tf.Set(d, "application_object_id", app.ID)
+	tf.Set(d, "client_id", app.AppId)
+	tf.Set(d, "description", role.Description)
+	tf.Set(d, "display_name", role.DisplayName)
+	tf.Set(d, "enabled", boolValue(role.IsEnabled))
+	tf.Set(d, "role_id", role.ID)
+	tf.Set(d, "value", role.Value)
+
+	return nil
+}
+
+// applicationAppRoleCandidates describes app roles for inclusion in an error message
+func applicationAppRoleCandidates(roles []msgraph.AppRole) string {
+	if len(roles) == 0 {
+		return "(none)"
+	}
+	candidates := make([]string, 0, len(roles))
+	for _, r := range roles {
+		candidates = append(candidates, fmt.Sprintf("%s (value: %q, display name: %q, enabled: %t)", stringValue(r.ID), stringValue(r.Value), stringValue(r.DisplayName), boolValue(r.IsEnabled)))
+	}
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,126 @@
+package applications_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationAppRoleDataSource struct{}
+
+func TestAccApplicationAppRoleDataSource_byValue(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_app_role", "test")
+	r := ApplicationAppRoleDataSource{}
+	roleId := data.UUID()
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byValue(data, roleId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("role_id").HasValue(roleId),
+				check.That(data.ResourceName).Key("allowed_member_types.#").HasValue("2"),
+				check.That(data.ResourceName).Key("client_id").IsUuid(),
+				check.That(data.ResourceName).Key("description").HasValue("Admins can manage roles and perform all task actions"),
+				check.That(data.ResourceName).Key("display_name").HasValue("Admin"),
+				check.That(data.ResourceName).Key("enabled").HasValue("true"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationAppRoleDataSource_byDisplayNameWithClientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_app_role", "test")
+	r := ApplicationAppRoleDataSource{}
+	roleId := data.UUID()
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byDisplayName(data, roleId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("role_id").HasValue(roleId),
+				check.That(data.ResourceName).Key("application_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("enabled").HasValue("false"),
+				check.That(data.ResourceName).Key("value").HasValue("Legacy"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationAppRoleDataSource_notFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_app_role", "test")
+	r := ApplicationAppRoleDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			// Values are compared case-sensitively
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile("No app role found with value \"admin\""),
+		},
+	})
+}
+
+func (ApplicationAppRoleDataSource) template(data acceptance.TestData, adminRoleId, legacyRoleId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User", "Application"]
+    description          = "Admins can manage roles and perform all task actions"
+    display_name         = "Admin"
+    enabled              = true
+    id                   = "%[2]s"
+    value                = "Admin"
+  }
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Legacy role which is no longer assigned"
+    display_name         = "Legacy"
+    enabled              = false
+    id                   = "%[3]s"
+    value                = "Legacy"
+  }
+}
+`, data.RandomInteger, adminRoleId, legacyRoleId)
+}
+
+func (r ApplicationAppRoleDataSource) byValue(data acceptance.TestData, roleId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_app_role" "test" {
+  application_object_id = azuread_application.test.object_id
+  value                 = "Admin"
+}
+`, r.template(data, roleId, data.UUID()))
+}
+
+func (r ApplicationAppRoleDataSource) byDisplayName(data acceptance.TestData, roleId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_app_role" "test" {
+  client_id    = azuread_application.test.client_id
+  display_name = "legacy"
+}
+`, r.template(data, data.UUID(), roleId))
+}
+
+func (r ApplicationAppRoleDataSource) notFound(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_app_role" "test" {
+  application_object_id = azuread_application.test.object_id
+  value                 = "admin"
+}
+`, r.template(data, data.UUID(), data.UUID()))
+}
@@ -3,9 +3,7 @@ package applications
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -94,7 +92,7 @@ func applicationCertificateResource() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				ConflictsWith:    []string{"end_date"},
-				ValidateDiagFunc: validate.NoEmptyStrings,
+				ValidateDiagFunc: validate.Duration,
 			},
 
 			"type": {
@@ -140,12 +138,9 @@ func applicationCertificateResourceCreate(ctx context.Context, d *schema.Resourc
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
-	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(nil, "application_object_id", "Application with object ID %q was not found", id.ObjectId)
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving application with object ID %q", id.ObjectId)
+	app, status, err := helpers.WaitForParentApplication(ctx, client, id.ObjectId)
+	if diags := applicationCredentialParent("azuread_application_certificate", id.ObjectId).CheckCreate(status, err); diags.HasError() {
+		return diags
 	}
 
 	newCredentials := make([]msgraph.KeyCredential, 0)
@@ -182,13 +177,8 @@ func applicationCertificateResourceRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			log.Printf("[DEBUG] Application with ID %q for %s credential %q was not found - removing from state!", id.ObjectId, id.KeyType, id.KeyId)
-			d.SetId("")
-			return nil
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving Application with object ID %q", id.ObjectId)
+	if gone, diags := applicationCredentialParent("azuread_application_certificate", id.ObjectId).CheckRead(d, status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	var credential *msgraph.KeyCredential
@@ -238,11 +228,8 @@ func applicationCertificateResourceDelete(ctx context.Context, d *schema.Resourc
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("Application was not found"), "application_object_id", "Retrieving Application with ID %q", id.ObjectId)
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving application with object ID %q", id.ObjectId)
+	if gone, diags := applicationCredentialParent("azuread_application_certificate", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	newCredentials := make([]msgraph.KeyCredential, 0)
@@ -11,6 +11,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
 )
@@ -29,7 +30,8 @@ func applicationDataSource() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"application_id", "client_id", "display_name"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
@@ -38,7 +40,18 @@ func applicationDataSource() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"display_name", "object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"client_id": {
+				Description:      "The Client ID (also called Application ID)",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"display_name", "object_id"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
@@ -47,15 +60,29 @@ func applicationDataSource() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"application_id", "client_id", "object_id"},
 				ValidateDiagFunc: validate.NoEmptyStrings,
 			},
 
+			"fail_if_not_found": tf.DataSourceFailIfNotFoundSchema("application"),
+
+			"found": tf.DataSourceFoundSchema("application"),
+
 			"api": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"known_client_applications": {
+							Description: "Used for bundling consent if you have a solution that contains two parts: a client app and a custom web API app",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
 						// TODO: v2.0 also consider another computed typemap attribute `oauth2_permission_scope_ids` for easier consumption
 						"oauth2_permission_scopes": {
 							Description: "List of OAuth2 permission scopes published by the application",
@@ -113,6 +140,12 @@ func applicationDataSource() *schema.Resource {
 								},
 							},
 						},
+
+						"requested_access_token_version": {
+							Description: "The access token version expected by this resource",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -252,6 +285,12 @@ func applicationDataSource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"unique_name": {
+				Description: "A unique, immutable identifier for the application which can be used as an alternate key",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"web": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -315,24 +354,31 @@ func applicationDataSourceRead(ctx context.Context, d *schema.ResourceData, meta
 		app, status, err = client.Get(ctx, objectId)
 		if err != nil {
 			if status == http.StatusNotFound {
-				return tf.ErrorDiagPathF(nil, "object_id", "Application with object ID %q was not found", objectId)
+				return tf.DataSourceNotFound(d, "object_id", objectId, tf.ErrorDiagPathF(nil, "object_id", "Application with object ID %q was not found", objectId))
 			}
 
 			return tf.ErrorDiagPathF(err, "object_id", "Retrieving Application with object ID %q", objectId)
 		}
 	} else {
-		var fieldName, fieldValue string
-		if applicationId, ok := d.Get("application_id").(string); ok && applicationId != "" {
+		applicationId, applicationIdKey, err := tf.GetAliasedString(d, "application_id", "client_id")
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "client_id", "Conflicting arguments")
+		}
+
+		var fieldKey, fieldName, fieldValue string
+		if applicationId != "" {
+			fieldKey = applicationIdKey
 			fieldName = "appId"
 			fieldValue = applicationId
 		} else if displayName, ok := d.Get("display_name").(string); ok && displayName != "" {
+			fieldKey = "display_name"
 			fieldName = "displayName"
 			fieldValue = displayName
 		} else {
-			return tf.ErrorDiagF(nil, "One of `object_id`, `application_id` or `displayName` must be specified")
+			return tf.ErrorDiagF(nil, "One of `object_id`, `application_id`, `client_id` or `displayName` must be specified")
 		}
 
-		filter := fmt.Sprintf("%s eq '%s'", fieldName, fieldValue)
+		filter := helpers.ODataEq(fieldName, fieldValue)
 
 		result, _, err := client.List(ctx, filter)
 		if err != nil {
@@ -341,7 +387,7 @@ func applicationDataSourceRead(ctx context.Context, d *schema.ResourceData, meta
 
 		switch {
 		case result == nil || len(*result) == 0:
-			return tf.ErrorDiagF(fmt.Errorf("No applications found matching filter: %q", filter), "Application not found")
+			return tf.DataSourceNotFound(d, fieldKey, fieldValue, tf.ErrorDiagF(fmt.Errorf("No applications found matching filter: %q", filter), "Application not found"))
 		case len(*result) > 1:
 			return tf.ErrorDiagF(fmt.Errorf("Found multiple applications matching filter: %q", filter), "Multiple applications found")
 		}
@@ -375,24 +421,27 @@ func applicationDataSourceRead(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(*app.ID)
 
-	tf.Set(d, "api", flattenApplicationApi(app.Api, true))
+	tf.Set(d, "api", flattenApplicationApi(app.Api, true, false))
 	tf.Set(d, "app_roles", flattenApplicationAppRoles(app.AppRoles))
 	tf.Set(d, "application_id", app.AppId)
+	tf.Set(d, "client_id", app.AppId)
 	tf.Set(d, "display_name", app.DisplayName)
 	tf.Set(d, "fallback_public_client_enabled", app.IsFallbackPublicClient)
+	tf.Set(d, "found", true)
 	tf.Set(d, "group_membership_claims", flattenApplicationGroupMembershipClaims(app.GroupMembershipClaims))
 	tf.Set(d, "identifier_uris", tf.FlattenStringSlicePtr(app.IdentifierUris))
 	tf.Set(d, "object_id", app.ID)
 	tf.Set(d, "optional_claims", flattenApplicationOptionalClaims(app.OptionalClaims))
 	tf.Set(d, "required_resource_access", flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess))
 	tf.Set(d, "sign_in_audience", string(app.SignInAudience))
+	tf.Set(d, "unique_name", app.UniqueName)
 	tf.Set(d, "web", flattenApplicationWeb(app.Web, true, true))
 
 	owners, _, err := client.ListOwners(ctx, *app.ID)
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not retrieve owners for application with object ID %q", *app.ID)
 	}
-	tf.Set(d, "owners", owners)
+	tf.Set(d, "owners", tf.SortedStringSlice(owners))
 
 	return nil
 }
@@ -2,6 +2,7 @@ package applications_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -36,6 +37,29 @@ func TestAccApplicationDataSource_byApplicationId(t *testing.T) {
 	})
 }
 
+func TestAccApplicationDataSource_byClientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application", "test")
+	r := ApplicationDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.clientId(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func TestAccApplicationDataSource_byConflictingIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      ApplicationDataSource{}.conflictingIds(data),
+			ExpectError: regexp.MustCompile("must have the same value"),
+		},
+	})
+}
+
 func TestAccApplicationDataSource_byDisplayName(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_application", "test")
 	r := ApplicationDataSource{}
@@ -48,13 +72,43 @@ func TestAccApplicationDataSource_byDisplayName(t *testing.T) {
 	})
 }
 
+func TestAccApplicationDataSource_byDisplayNameNonexistent(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      ApplicationDataSource{}.displayNameNonexistent(data, true),
+			ExpectError: regexp.MustCompile("Application not found"),
+		},
+	})
+}
+
+func TestAccApplicationDataSource_byDisplayNameNonexistentOptional(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: ApplicationDataSource{}.displayNameNonexistent(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("found").HasValue("false"),
+				check.That(data.ResourceName).Key("application_id").IsEmpty(),
+				check.That(data.ResourceName).Key("object_id").IsEmpty(),
+				check.That("azuread_application.fallback.0").Key("object_id").IsUuid(),
+			),
+		},
+	})
+}
+
 func (ApplicationDataSource) testCheck(data acceptance.TestData) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		check.That(data.ResourceName).Key("application_id").IsUuid(),
+		resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", data.ResourceName, "application_id"),
+		resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", "azuread_application.test", "client_id"),
 		check.That(data.ResourceName).Key("object_id").IsUuid(),
 		check.That(data.ResourceName).Key("api.0.oauth2_permission_scopes.#").HasValue("2"),
 		check.That(data.ResourceName).Key("app_roles.#").HasValue("2"),
 		check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-APP-complete-%d", data.RandomInteger)),
+		check.That(data.ResourceName).Key("found").HasValue("true"),
 		check.That(data.ResourceName).Key("group_membership_claims.#").HasValue("1"),
 		check.That(data.ResourceName).Key("group_membership_claims.0").HasValue("All"),
 		check.That(data.ResourceName).Key("identifier_uris.#").HasValue("1"),
@@ -91,6 +145,27 @@ data "azuread_application" "test" {
 `, ApplicationResource{}.complete(data))
 }
 
+func (ApplicationDataSource) clientId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application" "test" {
+  client_id = azuread_application.test.client_id
+}
+`, ApplicationResource{}.complete(data))
+}
+
+func (ApplicationDataSource) conflictingIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application" "test" {
+  application_id = azuread_application.test.application_id
+  client_id      = "%[2]s"
+}
+`, ApplicationResource{}.complete(data), data.UUID())
+}
+
 func (ApplicationDataSource) displayName(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -100,3 +175,17 @@ data "azuread_application" "test" {
 }
 `, ApplicationResource{}.complete(data))
 }
+
+func (ApplicationDataSource) displayNameNonexistent(data acceptance.TestData, failIfNotFound bool) string {
+	return fmt.Sprintf(`
+data "azuread_application" "test" {
+  display_name      = "acctest-APP-nonexistent-%[1]d"
+  fail_if_not_found = %[2]t
+}
+
+resource "azuread_application" "fallback" {
+  count        = data.azuread_application.test.found ? 0 : 1
+  display_name = "acctest-APP-fallback-%[1]d"
+}
+`, data.RandomInteger, failIfNotFound)
+}
@@ -0,0 +1,231 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func applicationFederatedIdentityCredentialResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: applicationFederatedIdentityCredentialResourceCreate,
+		ReadContext:   applicationFederatedIdentityCredentialResourceRead,
+		UpdateContext: applicationFederatedIdentityCredentialResourceUpdate,
+		DeleteContext: applicationFederatedIdentityCredentialResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.FederatedIdentityCredentialID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"application_object_id": {
+				Description:      "The object ID of the application for which this federated identity credential should be created",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"audiences": {
+				Description: "List of audiences that can appear in the external token. This specifies what should be accepted in the `aud` claim of incoming tokens.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"display_name": {
+				Description:      "A unique display name for the federated identity credential",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"issuer": {
+				Description:      "The URL of the external identity provider, which must match the issuer claim of the external token being exchanged. The combination of the values of issuer and subject must be unique on the app.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.IsHTTPSURL,
+			},
+
+			"subject": {
+				Description:      "The identifier of the external software workload within the external identity provider. The combination of issuer and subject must be unique on the app.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"description": {
+				Description: "A description for the federated identity credential",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"credential_id": {
+				Description: "A UUID used to uniquely identify this federated identity credential",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func applicationFederatedIdentityCredentialResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+	credentialsClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialsClient
+	objectId := d.Get("application_object_id").(string)
+	displayName := d.Get("display_name").(string)
+
+	tf.LockByName(applicationResourceName, objectId)
+	defer tf.UnlockByName(applicationResourceName, objectId)
+
+	_, status, err := helpers.WaitForParentApplication(ctx, client, objectId)
+	if diags := applicationCredentialParent("azuread_application_federated_identity_credential", objectId).CheckCreate(status, err); diags.HasError() {
+		return diags
+	}
+
+	// Credential names must be unique for an application, so surface an existing credential as an import error
+	existing, _, err := credentialsClient.List(ctx, objectId, "")
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing federated identity credentials for application with object ID %q", objectId)
+	}
+	for _, c := range *existing {
+		if c.ID != nil && c.Name != nil && strings.EqualFold(*c.Name, displayName) {
+			return tf.ImportAsExistsDiag("azuread_application_federated_identity_credential", parse.NewFederatedIdentityCredentialID(objectId, *c.ID).String())
+		}
+	}
+
+	properties := expandApplicationFederatedIdentityCredential(d)
+	properties.Name = utils.String(displayName)
+
+	credential, _, err := credentialsClient.Create(ctx, objectId, properties)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Adding federated identity credential for application with object ID %q", objectId)
+	}
+	if credential.ID == nil || *credential.ID == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for federated identity credential is nil/empty"), "Bad API response")
+	}
+
+	id := parse.NewFederatedIdentityCredentialID(objectId, *credential.ID)
+	d.SetId(id.String())
+
+	return applicationFederatedIdentityCredentialResourceRead(ctx, d, meta)
+}
+
+func applicationFederatedIdentityCredentialResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	credentialsClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialsClient
+
+	id, err := parse.FederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential with ID %q", d.Id())
+	}
+
+	tf.LockByName(applicationResourceName, id.ObjectId)
+	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
+
+	// The name of a credential cannot be changed, so it is omitted from the update
+	properties := expandApplicationFederatedIdentityCredential(d)
+	properties.ID = utils.String(id.CredentialId)
+
+	if _, err := credentialsClient.Update(ctx, id.ObjectId, properties); err != nil {
+		return tf.ErrorDiagF(err, "Updating federated identity credential %q for application with object ID %q", id.CredentialId, id.ObjectId)
+	}
+
+	return applicationFederatedIdentityCredentialResourceRead(ctx, d, meta)
+}
+
+func applicationFederatedIdentityCredentialResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	credentialsClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialsClient
+
+	id, err := parse.FederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential with ID %q", d.Id())
+	}
+
+	credential, status, err := credentialsClient.Get(ctx, id.ObjectId, id.CredentialId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Federated identity credential %q for application with object ID %q was not found - removing from state!", id.CredentialId, id.ObjectId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagPathF(err, "id", "Retrieving federated identity credential %q for application with object ID %q", id.CredentialId, id.ObjectId)
+	}
+
+	tf.Set(d, "application_object_id", id.ObjectId)
+	tf.Set(d, "audiences", tf.FlattenStringSlicePtr(credential.Audiences))
+	tf.Set(d, "credential_id", id.CredentialId)
+	tf.Set(d, "description", credential.Description)
+	tf.Set(d, "display_name", credential.Name)
+	tf.Set(d, "issuer", credential.Issuer)
+	tf.Set(d, "subject", credential.Subject)
+
+	return nil
+}
+
+func applicationFederatedIdentityCredentialResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	credentialsClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialsClient
+
+	id, err := parse.FederatedIdentityCredentialID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing federated identity credential with ID %q", d.Id())
+	}
+
+	tf.LockByName(applicationResourceName, id.ObjectId)
+	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "federated identity credential",
+		ObjectId:   id.CredentialId,
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			credentialsClient := *credentialsClient
+			credentialsClient.BaseClient.DisableRetries = true
+			_, status, err := credentialsClient.Get(ctx, id.ObjectId, id.CredentialId)
+			return status, err
+		},
+	}
+
+	_, status, err := credentialsClient.Get(ctx, id.ObjectId, id.CredentialId)
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = credentialsClient.Delete(ctx, id.ObjectId, id.CredentialId)
+	return deletion.CheckDeleted(ctx, status, err)
+}
+
+func expandApplicationFederatedIdentityCredential(d *schema.ResourceData) client.FederatedIdentityCredential {
+	return client.FederatedIdentityCredential{
+		Audiences:   tf.ExpandStringSlicePtr(d.Get("audiences").([]interface{})),
+		Description: utils.String(d.Get("description").(string)),
+		Issuer:      utils.String(d.Get("issuer").(string)),
+		Subject:     utils.String(d.Get("subject").(string)),
+	}
+}
@@ -0,0 +1,170 @@
+package applications_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type ApplicationFederatedIdentityCredentialResource struct{}
+
+func TestAccApplicationFederatedIdentityCredential_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("credential_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.complete(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("credential_id").IsUuid(),
+				check.That(data.ResourceName).Key("description").HasValue("Deployments for repository cloud-infra"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("credential_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("subject").HasValue("repo:contoso/cloud-infra:environment:Production"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("subject").HasValue("repo:contoso/cloud-infra:ref:refs/heads/main"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredential_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_federated_identity_credential", "test")
+	r := ApplicationFederatedIdentityCredentialResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationFederatedIdentityCredentialsClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.FederatedIdentityCredentialID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Federated Identity Credential ID: %v", err)
+	}
+
+	credential, status, err := client.Get(ctx, id.ObjectId, id.CredentialId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Federated Identity Credential %q for Application with object ID %q does not exist", id.CredentialId, id.ObjectId)
+		}
+		return nil, fmt.Errorf("failed to retrieve Federated Identity Credential %q for Application with object ID %q: %+v", id.CredentialId, id.ObjectId, err)
+	}
+
+	return utils.Bool(credential.ID != nil && *credential.ID == id.CredentialId), nil
+}
+
+func (ApplicationFederatedIdentityCredentialResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestApp-%[1]d"
+}
+`, data.RandomInteger)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_object_id = azuread_application.test.object_id
+  display_name          = "acctest-FIC-%[2]d"
+  audiences             = ["api://AzureADTokenExchange"]
+  issuer                = "https://token.actions.githubusercontent.com"
+  subject               = "repo:contoso/cloud-infra:ref:refs/heads/main"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "test" {
+  application_object_id = azuread_application.test.object_id
+  display_name          = "acctest-FIC-%[2]d"
+  description           = "Deployments for repository cloud-infra"
+  audiences             = ["api://AzureADTokenExchange"]
+  issuer                = "https://token.actions.githubusercontent.com"
+  subject               = "repo:contoso/cloud-infra:environment:Production"
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationFederatedIdentityCredentialResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_federated_identity_credential" "import" {
+  application_object_id = azuread_application_federated_identity_credential.test.application_object_id
+  display_name          = azuread_application_federated_identity_credential.test.display_name
+  audiences             = azuread_application_federated_identity_credential.test.audiences
+  issuer                = azuread_application_federated_identity_credential.test.issuer
+  subject               = azuread_application_federated_identity_credential.test.subject
+}
+`, r.basic(data))
+}
@@ -0,0 +1,151 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func applicationFederatedIdentityCredentialsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: applicationFederatedIdentityCredentialsDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_object_id": {
+				Description:      "The object ID of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_id", "client_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"application_id": {
+				Description:      "The Application ID (also called Client ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"client_id": {
+				Description:      "The Client ID (also called Application ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"display_name": {
+				Description:      "Only return federated identity credentials with this display name",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"credentials": {
+				Description: "A list of federated identity credentials for the application",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"audiences": {
+							Description: "List of audiences that can appear in the external token",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"credential_id": {
+							Description: "The unique ID of the federated identity credential",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"description": {
+							Description: "A description for the federated identity credential",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the federated identity credential",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"issuer": {
+							Description: "The URL of the external identity provider",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"subject": {
+							Description: "The identifier of the external software workload within the external identity provider",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func applicationFederatedIdentityCredentialsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+	credentialsClient := meta.(*clients.Client).Applications.ApplicationFederatedIdentityCredentialsClient
+
+	app, diags := applicationDataSourceGet(ctx, client, d)
+	if diags.HasError() {
+		return diags
+	}
+	objectId := *app.ID
+
+	displayName := d.Get("display_name").(string)
+
+	var filter string
+	if displayName != "" {
+		filter = helpers.ODataEq("name", displayName)
+	}
+
+	credentials, status, err := credentialsClient.List(ctx, objectId, filter)
+	if err != nil && filter != "" && status == http.StatusBadRequest {
+		// Filtering is not supported in all clouds, so retrieve all the credentials and filter them below instead
+		log.Printf("[DEBUG] Filtering federated identity credentials for application with object ID %q was rejected, retrying without a filter", objectId)
+		credentials, _, err = credentialsClient.List(ctx, objectId, "")
+	}
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing federated identity credentials for application with object ID %q", objectId)
+	}
+
+	d.SetId(fmt.Sprintf("federatedIdentityCredentials#%s", objectId))
+
+	tf.Set(d, "application_id", app.AppId)
+	tf.Set(d, "application_object_id", objectId)
+	tf.Set(d, "client_id", app.AppId)
+	tf.Set(d, "credentials", flattenApplicationFederatedIdentityCredentials(credentials, displayName))
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package applications_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationFederatedIdentityCredentialsDataSource struct{}
+
+func TestAccApplicationFederatedIdentityCredentialsDataSource_byObjectId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credentials", "test")
+	r := ApplicationFederatedIdentityCredentialsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.objectId(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialsDataSource_byApplicationId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credentials", "test")
+	r := ApplicationFederatedIdentityCredentialsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.applicationId(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func TestAccApplicationFederatedIdentityCredentialsDataSource_displayName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_federated_identity_credentials", "test")
+	r := ApplicationFederatedIdentityCredentialsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.displayName(data),
+			Check:  r.testCheck(data),
+		},
+	})
+}
+
+func (ApplicationFederatedIdentityCredentialsDataSource) testCheck(data acceptance.TestData) resource.TestCheckFunc {
+	return resource.ComposeTestCheckFunc(
+		check.That(data.ResourceName).Key("application_id").IsUuid(),
+		check.That(data.ResourceName).Key("application_object_id").IsUuid(),
+		check.That(data.ResourceName).Key("credentials.#").HasValue("0"),
+	)
+}
+
+func (ApplicationFederatedIdentityCredentialsDataSource) objectId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_federated_identity_credentials" "test" {
+  application_object_id = azuread_application.test.object_id
+}
+`, ApplicationResource{}.basic(data))
+}
+
+func (ApplicationFederatedIdentityCredentialsDataSource) applicationId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_federated_identity_credentials" "test" {
+  application_id = azuread_application.test.application_id
+}
+`, ApplicationResource{}.basic(data))
+}
+
+func (ApplicationFederatedIdentityCredentialsDataSource) displayName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_federated_identity_credentials" "test" {
+  application_object_id = azuread_application.test.object_id
+  display_name          = "acctest-FIC-%[2]d"
+}
+`, ApplicationResource{}.basic(data), data.RandomInteger)
+}
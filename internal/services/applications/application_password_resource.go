@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -81,7 +80,17 @@ func applicationPasswordResource() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				ConflictsWith:    []string{"end_date"},
-				ValidateDiagFunc: validate.NoEmptyStrings,
+				ValidateDiagFunc: validate.Duration,
+			},
+
+			"rotate_when_changed": {
+				Description: "Arbitrary map of values that, when changed, will trigger rotation of the password",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
 			},
 
 			"value": {
@@ -121,12 +130,9 @@ func applicationPasswordResourceCreate(ctx context.Context, d *schema.ResourceDa
 	tf.LockByName(applicationResourceName, objectId)
 	defer tf.UnlockByName(applicationResourceName, objectId)
 
-	app, status, err := client.Get(ctx, objectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(nil, "application_object_id", "Application with object ID %q was not found", objectId)
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving application with object ID %q", objectId)
+	app, status, err := helpers.WaitForParentApplication(ctx, client, objectId)
+	if diags := applicationCredentialParent("azuread_application_password", objectId).CheckCreate(status, err); diags.HasError() {
+		return diags
 	}
 	if app == nil || app.ID == nil {
 		return tf.ErrorDiagF(errors.New("nil application or application with nil ID was returned"), "API error retrieving application with object ID %q", objectId)
@@ -162,13 +168,8 @@ func applicationPasswordResourceRead(ctx context.Context, d *schema.ResourceData
 	}
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			log.Printf("[DEBUG] Application with ID %q for %s credential %q was not found - removing from state!", id.ObjectId, id.KeyType, id.KeyId)
-			d.SetId("")
-			return nil
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving application with object ID %q", id.ObjectId)
+	if gone, diags := applicationCredentialParent("azuread_application_password", id.ObjectId).CheckRead(d, status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	var credential *msgraph.PasswordCredential
@@ -217,6 +218,11 @@ func applicationPasswordResourceDelete(ctx context.Context, d *schema.ResourceDa
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
+	_, status, err := client.Get(ctx, id.ObjectId)
+	if gone, diags := applicationCredentialParent("azuread_application_password", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
+	}
+
 	if _, err := client.RemovePassword(ctx, id.ObjectId, id.KeyId); err != nil {
 		return tf.ErrorDiagF(err, "Removing password credential %q from application with object ID %q", id.KeyId, id.ObjectId)
 	}
@@ -76,6 +76,40 @@ func TestAccApplicationPassword_relativeEndDate(t *testing.T) {
 	})
 }
 
+func TestAccApplicationPassword_rotateWhenChanged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_password", "test")
+	r := ApplicationPasswordResource{}
+	keyIds := &applicationPasswordKeyIds{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.rotateWhenChanged(data, "1"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("rotate_when_changed.%").HasValue("1"),
+				check.That(data.ResourceName).Key("value").Exists(),
+				keyIds.capture(data.ResourceName),
+			),
+		},
+		{
+			// Unchanged values should not rotate the password, and the value should remain stable across refreshes
+			Config: r.rotateWhenChanged(data, "1"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				keyIds.checkRotated(data.ResourceName, false),
+			),
+		},
+		{
+			Config: r.rotateWhenChanged(data, "2"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("value").Exists(),
+				keyIds.checkRotated(data.ResourceName, true),
+			),
+		},
+	})
+}
+
 func (r ApplicationPasswordResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationsClient
 	client.BaseClient.DisableRetries = true
@@ -146,3 +180,46 @@ resource "azuread_application_password" "test" {
 }
 `, r.template(data), data.RandomString)
 }
+
+func (r ApplicationPasswordResource) rotateWhenChanged(data acceptance.TestData, rotation string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application_password" "test" {
+  application_object_id = azuread_application.test.object_id
+
+  rotate_when_changed = {
+    rotation = "%[2]s"
+  }
+}
+`, r.template(data), rotation)
+}
+
+// applicationPasswordKeyIds records the key ID of a password, to check whether it was rotated by a subsequent step
+type applicationPasswordKeyIds struct {
+	keyId string
+}
+
+func (k *applicationPasswordKeyIds) capture(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		k.keyId = rs.Primary.Attributes["key_id"]
+		return nil
+	}
+}
+
+func (k *applicationPasswordKeyIds) checkRotated(resourceName string, expected bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		if rotated := rs.Primary.Attributes["key_id"] != k.keyId; rotated != expected {
+			return fmt.Errorf("expected password to be rotated: %t, but key ID changed from %q to %q", expected, k.keyId, rs.Primary.Attributes["key_id"])
+		}
+		return nil
+	}
+}
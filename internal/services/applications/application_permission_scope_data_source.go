@@ -0,0 +1,179 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func applicationPermissionScopeDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: applicationPermissionScopeDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_object_id": {
+				Description:      "The object ID of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_id", "client_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"application_id": {
+				Description:      "The Application ID (also called Client ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"client_id": {
+				Description:      "The Client ID (also called Application ID) of the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "application_object_id", "client_id"},
+				ConflictsWith:    []string{"application_object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"value": {
+				Description:      "The value of the permission scope, as emitted in the `scp` claim of issued tokens. This is case-sensitive",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "value"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"display_name": {
+				Description:      "The admin consent display name of the permission scope",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "value"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"admin_consent_description": {
+				Description: "Delegated permission description that appears in all tenant-wide admin consent experiences",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"enabled": {
+				Description: "Whether the permission scope is enabled",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"scope_id": {
+				Description: "The unique identifier of the permission scope",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"type": {
+				Description: "Whether this delegated permission can be consented to by non-admin users, or requires admin consent. Possible values are `User` or `Admin`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"user_consent_description": {
+				Description: "Delegated permission description that appears in the end user consent experience",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"user_consent_display_name": {
+				Description: "Display name for the delegated permission that appears in the end user consent experience",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func applicationPermissionScopeDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+
+	app, diags := applicationDataSourceGet(ctx, client, d)
+	if diags.HasError() {
+		return diags
+	}
+
+	value := d.Get("value").(string)
+	displayName := d.Get("display_name").(string)
+
+	attr, identifier := "display_name", displayName
+	if value != "" {
+		attr, identifier = "value", value
+	}
+
+	scopes, err := ApplicationFindOAuth2PermissionScopesByValue(app, value, displayName)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, attr, "Finding permission scope %q for application with object ID %q", identifier, *app.ID)
+	}
+
+	switch {
+	case len(scopes) == 0:
+		all := applicationFindOAuth2PermissionScopes(app, func(msgraph.PermissionScope) bool { return true })
+		return tf.ErrorDiagPathF(fmt.Errorf("available permission scopes: %s", applicationPermissionScopeCandidates(all)),
+			attr, "No permission scope found with %s %q for application with object ID %q", strings.ReplaceAll(attr, "_", " "), identifier, *app.ID)
+	case len(scopes) > 1:
+		return tf.ErrorDiagPathF(fmt.Errorf("matching permission scopes: %s", applicationPermissionScopeCandidates(scopes)),
+			attr, "Found %d permission scopes with %s %q for application with object ID %q", len(scopes), strings.ReplaceAll(attr, "_", " "), identifier, *app.ID)
+	}
+
+	scope := scopes[0]
+	if scope.ID == nil {
+		return tf.ErrorDiagF(errors.New("ID returned for permission scope is nil"), "Bad API Response")
+	}
+
+	d.SetId(parse.NewOAuth2PermissionScopeID(*app.ID, *scope.ID).String())
+
+	tf.Set(d, "admin_consent_description", scope.AdminConsentDescription)
+	tf.Set(d, "application_id", app.AppId)
+	tf.Set(d, "application_object_id", app.ID)
+	tf.Set(d, "client_id", app.AppId)
+	tf.Set(d, "display_name", scope.AdminConsentDisplayName)
+	tf.Set(d, "enabled", boolValue(scope.IsEnabled))
+	tf.Set(d, "scope_id", scope.ID)
+	tf.Set(d, "type", string(scope.Type))
+	tf.Set(d, "user_consent_description", scope.UserConsentDescription)
+	tf.Set(d, "user_consent_display_name", scope.UserConsentDisplayName)
+	tf.Set(d, "value", scope.Value)
+
+	return nil
+}
+
+// applicationPermissionScopeCandidates describes permission scopes for inclusion in an error message
+func applicationPermissionScopeCandidates(scopes []msgraph.PermissionScope) string {
+	if len(scopes) == 0 {
+		return "(none)"
+	}
+	candidates := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		candidates = append(candidates, fmt.Sprintf("%s (value: %q, display name: %q, enabled: %t)", stringValue(s.ID), stringValue(s.Value), stringValue(s.AdminConsentDisplayName), boolValue(s.IsEnabled)))
+	}
+	return strings.Join(candidates, ", ")
+}
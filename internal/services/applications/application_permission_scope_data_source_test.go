@@ -0,0 +1,130 @@
+package applications_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationPermissionScopeDataSource struct{}
+
+func TestAccApplicationPermissionScopeDataSource_byValue(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_permission_scope", "test")
+	r := ApplicationPermissionScopeDataSource{}
+	scopeId := data.UUID()
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byValue(data, scopeId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("scope_id").HasValue(scopeId),
+				check.That(data.ResourceName).Key("admin_consent_description").HasValue("Administer the application"),
+				check.That(data.ResourceName).Key("display_name").HasValue("Administer"),
+				check.That(data.ResourceName).Key("enabled").HasValue("true"),
+				check.That(data.ResourceName).Key("type").HasValue("Admin"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationPermissionScopeDataSource_byDisplayNameWithClientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_permission_scope", "test")
+	r := ApplicationPermissionScopeDataSource{}
+	scopeId := data.UUID()
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byDisplayName(data, scopeId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("scope_id").HasValue(scopeId),
+				check.That(data.ResourceName).Key("application_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("enabled").HasValue("false"),
+				check.That(data.ResourceName).Key("user_consent_display_name").HasValue("Read your files"),
+				check.That(data.ResourceName).Key("value").HasValue("files.read"),
+			),
+		},
+	})
+}
+
+func TestAccApplicationPermissionScopeDataSource_notFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_application_permission_scope", "test")
+	r := ApplicationPermissionScopeDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			// Values are compared case-sensitively
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile("No permission scope found with value \"Administer\""),
+		},
+	})
+}
+
+func (ApplicationPermissionScopeDataSource) template(data acceptance.TestData, adminScopeId, readScopeId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  api {
+    oauth2_permission_scope {
+      admin_consent_description  = "Administer the application"
+      admin_consent_display_name = "Administer"
+      enabled                    = true
+      id                         = "%[2]s"
+      type                       = "Admin"
+      value                      = "administer"
+    }
+
+    oauth2_permission_scope {
+      admin_consent_description  = "Allow the application to read files on behalf of the signed-in user"
+      admin_consent_display_name = "Read files"
+      enabled                    = false
+      id                         = "%[3]s"
+      type                       = "User"
+      user_consent_description   = "Allow the application to read your files"
+      user_consent_display_name  = "Read your files"
+      value                      = "files.read"
+    }
+  }
+}
+`, data.RandomInteger, adminScopeId, readScopeId)
+}
+
+func (r ApplicationPermissionScopeDataSource) byValue(data acceptance.TestData, scopeId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_permission_scope" "test" {
+  application_object_id = azuread_application.test.object_id
+  value                 = "administer"
+}
+`, r.template(data, scopeId, data.UUID()))
+}
+
+func (r ApplicationPermissionScopeDataSource) byDisplayName(data acceptance.TestData, scopeId string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_permission_scope" "test" {
+  client_id    = azuread_application.test.client_id
+  display_name = "read files"
+}
+`, r.template(data, data.UUID(), scopeId))
+}
+
+func (r ApplicationPermissionScopeDataSource) notFound(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_application_permission_scope" "test" {
+  application_object_id = azuread_application.test.object_id
+  value                 = "Administer"
+}
+`, r.template(data, data.UUID(), data.UUID()))
+}
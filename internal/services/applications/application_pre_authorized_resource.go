@@ -14,6 +14,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/parse"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
@@ -76,7 +77,7 @@ func applicationPreAuthorizedResourceCreate(ctx context.Context, d *schema.Resou
 	tf.LockByName(applicationResourceName, id.ObjectId)
 	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
 
-	app, status, err := client.Get(ctx, id.ObjectId)
+	app, status, err := helpers.WaitForParentApplication(ctx, client, id.ObjectId)
 	if err != nil {
 		if status == http.StatusNotFound {
 			return tf.ErrorDiagPathF(nil, "application_object_id", "Application with object ID %q was not found", id.ObjectId)
@@ -219,28 +220,26 @@ func applicationPreAuthorizedResourceDelete(ctx context.Context, d *schema.Resou
 		return tf.ErrorDiagPathF(err, "id", "Parsing pre-authorized application ID %q", d.Id())
 	}
 
+	tf.LockByName(applicationResourceName, id.ObjectId)
+	defer tf.UnlockByName(applicationResourceName, id.ObjectId)
+
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			log.Printf("[DEBUG] Application with ID %q for pre-authorized application %q was not found - removing from state!", id.ObjectId, id.AppId)
-			d.SetId("")
-			return nil
-		}
-		return tf.ErrorDiagPathF(err, "application_object_id", "Retrieving Application with object ID %q", id.ObjectId)
+	if gone, diags := applicationCredentialParent("azuread_application_pre_authorized", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
 	}
 	if app == nil || app.ID == nil {
 		return tf.ErrorDiagF(errors.New("nil application or application with nil ID was returned"), "API error retrieving application with object ID %q", id.ObjectId)
 	}
 	if app.Api == nil || app.Api.PreAuthorizedApplications == nil {
-		return tf.ErrorDiagF(errors.New("application with nil preAuthorizedApplications was returned"), "API error retrieving application with object ID %q", id.ObjectId)
+		log.Printf("[DEBUG] Application with object ID %q has no pre-authorized applications - assuming %q was already removed", id.ObjectId, id.AppId)
+		return nil
 	}
 
-	newPreAuthorizedApps := make([]msgraph.ApiPreAuthorizedApplication, 0)
-	for _, a := range *app.Api.PreAuthorizedApplications {
-		if a.AppId != nil && !strings.EqualFold(*a.AppId, id.AppId) {
-			newPreAuthorizedApps = append(newPreAuthorizedApps, a)
-			break
-		}
+	// Retain the entries for all other client applications, which may be managed by other resources
+	newPreAuthorizedApps, found := applicationPreAuthorizedAppsWithout(*app.Api.PreAuthorizedApplications, id.AppId)
+	if !found {
+		log.Printf("[DEBUG] No matching preAuthorizedApplication for ID %q - assuming it was already removed", id)
+		return nil
 	}
 
 	properties := msgraph.Application{
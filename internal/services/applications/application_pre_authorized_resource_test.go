@@ -51,6 +51,31 @@ func TestAccApplicationPreAuthorized_requiresImport(t *testing.T) {
 	})
 }
 
+func TestAccApplicationPreAuthorized_multiple(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application_pre_authorized", "test")
+	r := ApplicationPreAuthorizedResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.multiple(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_application_pre_authorized.second").ExistsInAzure(r),
+				check.That("azuread_application_pre_authorized.second").Key("permission_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permission_ids.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func (ApplicationPreAuthorizedResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Applications.ApplicationsClient
 	client.BaseClient.DisableRetries = true
@@ -130,3 +155,19 @@ resource "azuread_application_pre_authorized" "import" {
 }
 `, r.basic(data), data.UUID())
 }
+
+func (r ApplicationPreAuthorizedResource) multiple(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "second" {
+  display_name = "acctestApp-second-%[2]d"
+}
+
+resource "azuread_application_pre_authorized" "second" {
+  application_object_id = azuread_application.authorizer.object_id
+  authorized_app_id     = azuread_application.second.application_id
+  permission_ids        = [tolist(azuread_application_pre_authorized.test.permission_ids)[0]]
+}
+`, r.basic(data), data.RandomInteger)
+}
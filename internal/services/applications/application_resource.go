@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,13 +17,17 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	applicationsValidate "github.com/hashicorp/terraform-provider-azuread/internal/services/applications/validate"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
 )
 
-const applicationResourceName = "azuread_application"
+const (
+	applicationResourceName           = "azuread_application"
+	applicationUniqueNameImportPrefix = "uniqueName/"
+)
 
 func applicationResource() *schema.Resource {
 	return &schema.Resource{
@@ -39,12 +45,18 @@ func applicationResource() *schema.Resource {
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
-		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+		Importer: tf.ValidateResourceIDPriorToImportThen(func(id string) error {
+			if strings.HasPrefix(id, applicationUniqueNameImportPrefix) {
+				if strings.TrimPrefix(id, applicationUniqueNameImportPrefix) == "" {
+					return fmt.Errorf("specified ID (%q) does not contain a unique name", id)
+				}
+				return nil
+			}
 			if _, err := uuid.ParseUUID(id); err != nil {
 				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
 			}
 			return nil
-		}),
+		}, applicationResourceImport),
 
 		Schema: map[string]*schema.Schema{
 			"display_name": {
@@ -60,17 +72,30 @@ func applicationResource() *schema.Resource {
 				MaxItems: 1,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
+						"known_client_applications": {
+							Description: "Used for bundling consent if you have a solution that contains two parts: a client app and a custom web API app",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.UUID,
+							},
+						},
+
 						// TODO: v2.0 also consider another computed typemap attribute `oauth2_permission_scope_ids` for easier consumption
 						"oauth2_permission_scope": {
 							Description: "One or more `oauth2_permission_scope` blocks to describe delegated permissions exposed by the web API represented by this application",
 							Type:        schema.TypeSet,
 							Optional:    true,
+							Set:         applicationOAuth2PermissionScopeHash,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"id": {
-										Description: "The unique identifier of the delegated permission",
-										Type:        schema.TypeString,
-										Required:    true,
+										Description:      "The unique identifier of the delegated permission. When not specified, an existing permission with the same value is reused, or a new ID is generated",
+										Type:             schema.TypeString,
+										Optional:         true,
+										Computed:         true,
+										ValidateDiagFunc: validate.UUID,
 									},
 
 									"admin_consent_description": {
@@ -128,6 +153,14 @@ func applicationResource() *schema.Resource {
 								},
 							},
 						},
+
+						"requested_access_token_version": {
+							Description:  "The access token version expected by this resource",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 2),
+						},
 					},
 				},
 			},
@@ -136,12 +169,14 @@ func applicationResource() *schema.Resource {
 			"app_role": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Set:      applicationAppRoleHash,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
-							Description:  "The unique identifier of the app role",
+							Description:  "The unique identifier of the app role. When not specified, an existing role with the same value is reused, or a new ID is generated",
 							Type:         schema.TypeString,
-							Required:     true,
+							Optional:     true,
+							Computed:     true,
 							ValidateFunc: validation.IsUUID,
 						},
 
@@ -225,6 +260,63 @@ func applicationResource() *schema.Resource {
 				},
 			},
 
+			"on_premises_publishing": {
+				Description: "Configures publishing of an on-premises application with Application Proxy",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"external_url": {
+							Description:      "The published external URL for the application",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.IsHTTPSURL,
+						},
+
+						"internal_url": {
+							Description:      "The internal URL of the application",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.IsHTTPOrHTTPSURL,
+						},
+
+						"external_authentication_type": {
+							Description: "How Application Proxy verifies users before giving them access to the application",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "aadPreAuthentication",
+							ValidateFunc: validation.StringInSlice([]string{
+								"aadPreAuthentication",
+								"passthru",
+							}, false),
+						},
+
+						"http_only_cookie_enabled": {
+							Description: "Whether the HTTPOnly cookie flag is set in the HTTP response headers",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"persistent_cookie_enabled": {
+							Description: "Whether the access cookie is persisted after the browser is closed",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+
+						"translate_host_header_enabled": {
+							Description: "Whether Application Proxy translates the host header in requests to the internal URL",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+					},
+				},
+			},
+
 			"optional_claims": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -239,12 +331,31 @@ func applicationResource() *schema.Resource {
 			},
 
 			"owners": {
-				Description: "A list of object IDs of principals that will be granted ownership of the application. It's recommended to specify the object ID of the authenticated principal running Terraform, to ensure sufficient permissions that the application can be subsequently updated",
+				Description: "A list of object IDs of principals that will be granted ownership of the application, or `current` for the authenticated principal. It's recommended to include the authenticated principal running Terraform, to ensure sufficient permissions that the application can be subsequently updated",
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
-					ValidateDiagFunc: validate.NoEmptyStrings,
+					ValidateDiagFunc: validate.UUIDOrCurrent,
+				},
+			},
+
+			"public_client": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"redirect_uris": {
+							Description: "The URLs where user tokens are sent for sign-in, or the redirect URIs where OAuth 2.0 authorization codes and access tokens are sent",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.IsPublicClientRedirectURI,
+							},
+						},
+					},
 				},
 			},
 
@@ -304,6 +415,33 @@ func applicationResource() *schema.Resource {
 				}, false),
 			},
 
+			"single_page_application": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"redirect_uris": {
+							Description: "The URLs where user tokens are sent for sign-in, or the redirect URIs where OAuth 2.0 authorization codes and access tokens are sent",
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.IsHTTPSOrLocalhostURL,
+							},
+						},
+					},
+				},
+			},
+
+			"unique_name": {
+				Description:      "A unique, immutable identifier for the application which can be used as an alternate key. Can only be set once, and cannot be changed after it has been set",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
 			"web": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -321,7 +459,7 @@ func applicationResource() *schema.Resource {
 							Description:      "The URL that will be used by Microsoft's authorization service to sign out a user using front-channel, back-channel or SAML logout protocols",
 							Type:             schema.TypeString,
 							Optional:         true,
-							ValidateDiagFunc: validate.IsHTTPOrHTTPSURL,
+							ValidateDiagFunc: validate.IsLogoutURL,
 						},
 
 						"redirect_uris": {
@@ -330,7 +468,7 @@ func applicationResource() *schema.Resource {
 							Optional:    true,
 							Elem: &schema.Schema{
 								Type:             schema.TypeString,
-								ValidateDiagFunc: validate.NoEmptyStrings,
+								ValidateDiagFunc: validate.IsHTTPOrHTTPSURL,
 							},
 						},
 
@@ -364,6 +502,12 @@ func applicationResource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"client_id": {
+				Description: "The Client ID (also called Application ID)",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"object_id": {
 				Description: "The application's object ID",
 				Type:        schema.TypeString,
@@ -376,6 +520,81 @@ func applicationResource() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+
+			"remove_expired_credentials": {
+				Description: "If `true`, password and certificate credentials which expired longer ago than `expired_credentials_grace_period` will be removed from the application",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"expired_credentials_grace_period": {
+				Description:      "How long after expiry a credential should be retained before it is removed, when `remove_expired_credentials` is `true`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0s",
+				ValidateDiagFunc: validate.Duration,
+			},
+
+			"retained_credential_key_ids": {
+				Description: "The key IDs of password and certificate credentials which should never be removed when `remove_expired_credentials` is `true`, such as those managed by `azuread_application_password` or `azuread_application_certificate` resources",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.UUID,
+				},
+			},
+
+			"expired_credential_key_ids": {
+				Description: "The key IDs of password and certificate credentials which expired longer ago than the grace period, and which will be removed when `remove_expired_credentials` is `true`",
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"verified_publisher_mpn_id": {
+				Description:      "The Microsoft Partner Network (MPN) ID of the verified publisher to set for the application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"verified_publisher": {
+				Description: "The verified publisher of the application",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"added_date_time": {
+							Description: "The timestamp when the verified publisher was first added or most recently updated",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The verified publisher name from the publisher's Microsoft Partner Network account",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"verified_publisher_id": {
+							Description: "The Microsoft Partner Network (MPN) ID of the verified publisher",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"validate_resource_access": {
+				Description: "If `true`, will return an error if any permission in `required_resource_access` is not published or is disabled by the resource API",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 		},
 	}
 }
@@ -384,20 +603,50 @@ func applicationResourceCustomizeDiff(ctx context.Context, diff *schema.Resource
 	client := meta.(*clients.Client).Applications.ApplicationsClient
 	oldDisplayName, newDisplayName := diff.GetChange("display_name")
 
-	if diff.Get("prevent_duplicate_names").(bool) &&
+	if diff.Get("prevent_duplicate_names").(bool) && diff.NewValueKnown("display_name") &&
 		(oldDisplayName.(string) == "" || oldDisplayName.(string) != newDisplayName.(string)) {
-		result, err := applicationFindByName(ctx, client, newDisplayName.(string))
+		existingId, err := helpers.DuplicateNameFind(ctx, applicationDuplicateNameList(client), "displayName", newDisplayName.(string), diff.Id())
 		if err != nil {
 			return fmt.Errorf("could not check for existing application(s): %+v", err)
 		}
-		if result != nil && len(*result) > 0 {
-			for _, existingApp := range *result {
-				if existingApp.ID == nil {
-					return fmt.Errorf("API error: application returned with nil object ID during duplicate name check")
-				}
-				if diff.Id() == "" || diff.Id() == *existingApp.ID {
-					return tf.ImportAsDuplicateError("azuread_application", *existingApp.ID, newDisplayName.(string))
-				}
+		if existingId != "" {
+			return tf.ImportAsDuplicateError("azuread_application", existingId, newDisplayName.(string))
+		}
+	}
+
+	// Graph only permits the unique name to be set once, so catch any attempt to change it at plan time
+	if oldUniqueName, newUniqueName := diff.GetChange("unique_name"); oldUniqueName.(string) != "" && oldUniqueName.(string) != newUniqueName.(string) {
+		return fmt.Errorf("`unique_name` cannot be changed once it has been set (existing value: %q, new value: %q), the application must be replaced in order to change it", oldUniqueName.(string), newUniqueName.(string))
+	}
+
+	if diff.HasChange("owners") && diff.NewValueKnown("owners") {
+		callerId := meta.(*clients.Client).Claims.ObjectId
+		owners := *tf.ExpandStringSlicePtr(diff.Get("owners").(*schema.Set).List())
+		if err := helpers.ValidateCurrentPrincipalOwner(owners, callerId); err != nil {
+			return fmt.Errorf("invalid `owners`: %v", err)
+		}
+		if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeApplication, helpers.ExpandOwners(owners, callerId)); err != nil {
+			return fmt.Errorf("invalid `owners`: %v", err)
+		}
+	}
+
+	if err := applicationValidateRedirectUriCount(diff.Get("public_client").([]interface{}), diff.Get("single_page_application").([]interface{}), diff.Get("web").([]interface{})); err != nil {
+		return fmt.Errorf("validating redirect URIs: %v", err)
+	}
+
+	// Personal Microsoft accounts are only supported with v2 access tokens, which the API would otherwise reject at apply time
+	if signInAudience := diff.Get("sign_in_audience").(string); len(diff.Get("api").([]interface{})) > 0 &&
+		(signInAudience == string(msgraph.SignInAudienceAzureADandPersonalMicrosoftAccount) || signInAudience == string(msgraph.SignInAudiencePersonalMicrosoftAccount)) {
+		if version := diff.Get("api.0.requested_access_token_version").(int); version != 2 {
+			return fmt.Errorf("`requested_access_token_version` must be 2 when `sign_in_audience` is %q, but is %d", signInAudience, version)
+		}
+	}
+
+	// Surface any expired credentials in the plan, so that it is clear which credentials will be removed
+	if diff.Get("remove_expired_credentials").(bool) {
+		if old, _ := diff.GetChange("expired_credential_key_ids"); old.(*schema.Set).Len() > 0 {
+			if err := diff.SetNew("expired_credential_key_ids", []string{}); err != nil {
+				return fmt.Errorf("could not plan removal of expired credentials: %v", err)
 			}
 		}
 	}
@@ -409,22 +658,21 @@ func applicationResourceCustomizeDiff(ctx context.Context, diff *schema.Resource
 	return nil
 }
 
-func applicationResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func applicationResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client := meta.(*clients.Client).Applications.ApplicationsClient
+	publishingClient := meta.(*clients.Client).Applications.ApplicationOnPremisesPublishingClient
+	spaClient := meta.(*clients.Client).Applications.ApplicationSpaClient
+	verifiedPublisherClient := meta.(*clients.Client).Applications.ApplicationVerifiedPublisherClient
 	displayName := d.Get("display_name").(string)
 
 	// Perform this check at apply time to catch any duplicate names created during the same apply
 	if d.Get("prevent_duplicate_names").(bool) {
-		result, err := applicationFindByName(ctx, client, displayName)
+		existingId, err := helpers.DuplicateNameFind(ctx, applicationDuplicateNameList(client), "displayName", displayName, "")
 		if err != nil {
-			return tf.ErrorDiagPathF(err, "name", "Could not check for existing application(s)")
+			return tf.ErrorDiagPathF(err, "display_name", "Could not check for existing application(s)")
 		}
-		if result != nil && len(*result) > 0 {
-			existingApp := (*result)[0]
-			if existingApp.ID == nil {
-				return tf.ErrorDiagF(errors.New("API returned application with nil object ID during duplicate name check"), "Bad API response")
-			}
-			return tf.ImportAsDuplicateDiag("azuread_application", *existingApp.ID, displayName)
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_application", existingId, displayName)
 		}
 	}
 
@@ -436,13 +684,39 @@ func applicationResourceCreate(ctx context.Context, d *schema.ResourceData, meta
 		GroupMembershipClaims:  expandApplicationGroupMembershipClaims(d.Get("group_membership_claims").(*schema.Set).List()),
 		IdentifierUris:         tf.ExpandStringSlicePtr(d.Get("identifier_uris").([]interface{})),
 		OptionalClaims:         expandApplicationOptionalClaims(d.Get("optional_claims").([]interface{})),
+		PublicClient:           expandApplicationPublicClient(d.Get("public_client").([]interface{})),
 		RequiredResourceAccess: expandApplicationRequiredResourceAccess(d.Get("required_resource_access").(*schema.Set).List()),
 		SignInAudience:         msgraph.SignInAudience(d.Get("sign_in_audience").(string)),
 		Web:                    expandApplicationWeb(d.Get("web").([]interface{})),
 	}
 
-	app, _, err := client.Create(ctx, properties)
+	if v, ok := d.GetOk("unique_name"); ok {
+		properties.UniqueName = utils.String(v.(string))
+	}
+
+	// Validate at apply time, since resource app IDs and permission IDs are often unknown at plan time
+	if d.Get("validate_resource_access").(bool) {
+		if err := applicationValidateResourceAccess(ctx, meta.(*clients.Client).ServicePrincipalByAppId, properties.RequiredResourceAccess); err != nil {
+			return tf.ErrorDiagPathF(err, "required_resource_access", "Validating required resource access")
+		}
+	}
+
+	// Groups cannot be owners, which the API would only report with a misleading error after the application is created
+	owners := helpers.ExpandOwners(*tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List()), meta.(*clients.Client).Claims.ObjectId)
+	if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeApplication, owners); err != nil {
+		return tf.ErrorDiagPathF(err, "owners", "Invalid owners for application %q", displayName)
+	}
+
+	if err := applicationAssignAppRoleIds(properties.AppRoles, nil); err != nil {
+		return tf.ErrorDiagPathF(err, "app_role", "Could not assign IDs for app roles")
+	}
+	if err := applicationAssignOAuth2PermissionScopeIds(properties.Api.OAuth2PermissionScopes, nil); err != nil {
+		return tf.ErrorDiagPathF(err, "api.0.oauth2_permission_scope", "Could not assign IDs for OAuth2 permission scopes")
+	}
+
+	app, status, err := client.Create(ctx, properties)
 	if err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationApplicationCreate, meta.(*clients.Client).Claims)
 		return tf.ErrorDiagF(err, "Could not create application")
 	}
 
@@ -452,35 +726,68 @@ func applicationResourceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(*app.ID)
 
-	owners := *tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List())
-	if err := applicationSetOwners(ctx, client, app, owners); err != nil {
+	// Optionally delete the application if any subsequent step fails, so that a failed apply does not leave it behind
+	rollback := helpers.PartialCreateRollback{
+		Enabled:    meta.(*clients.Client).RollbackOnPartialCreate,
+		ObjectType: "application",
+		ObjectId:   *app.ID,
+		Delete: func(ctx context.Context) (int, error) {
+			return client.Delete(ctx, *app.ID)
+		},
+	}
+	defer func() {
+		diags = rollback.Check(ctx, d, diags)
+	}()
+
+	if onPremisesPublishing := expandApplicationOnPremisesPublishing(d.Get("on_premises_publishing").([]interface{})); onPremisesPublishing != nil {
+		if _, err := publishingClient.Update(ctx, *app.ID, *onPremisesPublishing); err != nil {
+			return tf.ErrorDiagPathF(err, "on_premises_publishing", "Could not configure on-premises publishing for application with object ID: %q", *app.ID)
+		}
+	}
+
+	// Single-page application settings are not part of the application model, so must be configured separately
+	if v := d.Get("single_page_application").([]interface{}); len(v) > 0 {
+		if _, err := spaClient.Update(ctx, *app.ID, expandApplicationSpa(v)); err != nil {
+			return tf.ErrorDiagPathF(err, "single_page_application", "Could not configure single-page application for application with object ID: %q", *app.ID)
+		}
+	}
+
+	if err := applicationSetOwners(ctx, client, app, owners, meta.(*clients.Client).Claims); err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not set owners for application with object ID: %q", *app.ID)
 	}
 
+	if v, ok := d.GetOk("verified_publisher_mpn_id"); ok {
+		if _, err := verifiedPublisherClient.Set(ctx, *app.ID, v.(string)); err != nil {
+			return tf.ErrorDiagPathF(applicationVerifiedPublisherError(err), "verified_publisher_mpn_id", "Could not set verified publisher for application with object ID: %q", *app.ID)
+		}
+	}
+
 	return applicationResourceRead(ctx, d, meta)
 }
 
-func applicationResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func applicationResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client := meta.(*clients.Client).Applications.ApplicationsClient
+	publishingClient := meta.(*clients.Client).Applications.ApplicationOnPremisesPublishingClient
+	spaClient := meta.(*clients.Client).Applications.ApplicationSpaClient
+	verifiedPublisherClient := meta.(*clients.Client).Applications.ApplicationVerifiedPublisherClient
 	applicationId := d.Id()
 	displayName := d.Get("display_name").(string)
 
 	// Perform this check at apply time to catch any duplicate names created during the same apply
 	if d.Get("prevent_duplicate_names").(bool) {
-		result, err := applicationFindByName(ctx, client, displayName)
+		existingId, err := helpers.DuplicateNameFind(ctx, applicationDuplicateNameList(client), "displayName", displayName, applicationId)
 		if err != nil {
 			return tf.ErrorDiagPathF(err, "display_name", "Could not check for existing application(s)")
 		}
-		if result != nil && len(*result) > 0 {
-			for _, existingApp := range *result {
-				if existingApp.ID == nil {
-					return tf.ErrorDiagF(errors.New("API returned application with nil object ID during duplicate name check"), "Bad API response")
-				}
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_application", existingId, displayName)
+		}
+	}
 
-				if *existingApp.ID != applicationId {
-					return tf.ImportAsDuplicateDiag("azuread_application", *existingApp.ID, displayName)
-				}
-			}
+	owners := helpers.ExpandOwners(*tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List()), meta.(*clients.Client).Claims.ObjectId)
+	if d.HasChange("owners") {
+		if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeApplication, owners); err != nil {
+			return tf.ErrorDiagPathF(err, "owners", "Invalid owners for application with object ID: %q", applicationId)
 		}
 	}
 
@@ -493,33 +800,111 @@ func applicationResourceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		GroupMembershipClaims:  expandApplicationGroupMembershipClaims(d.Get("group_membership_claims").(*schema.Set).List()),
 		IdentifierUris:         tf.ExpandStringSlicePtr(d.Get("identifier_uris").([]interface{})),
 		OptionalClaims:         expandApplicationOptionalClaims(d.Get("optional_claims").([]interface{})),
+		PublicClient:           expandApplicationPublicClient(d.Get("public_client").([]interface{})),
 		RequiredResourceAccess: expandApplicationRequiredResourceAccess(d.Get("required_resource_access").(*schema.Set).List()),
 		SignInAudience:         msgraph.SignInAudience(d.Get("sign_in_audience").(string)),
 		Web:                    expandApplicationWeb(d.Get("web").([]interface{})),
 	}
 
-	if err := applicationDisableAppRoles(ctx, client, &properties, expandApplicationAppRoles(d.Get("app_role").(*schema.Set).List())); err != nil {
+	if d.HasChange("unique_name") {
+		properties.UniqueName = utils.String(d.Get("unique_name").(string))
+	}
+
+	// Validate at apply time, since resource app IDs and permission IDs are often unknown at plan time
+	if d.Get("validate_resource_access").(bool) && d.HasChanges("required_resource_access", "validate_resource_access") {
+		if err := applicationValidateResourceAccess(ctx, meta.(*clients.Client).ServicePrincipalByAppId, properties.RequiredResourceAccess); err != nil {
+			return tf.ErrorDiagPathF(err, "required_resource_access", "Validating required resource access")
+		}
+	}
+
+	// Reuse the IDs of existing roles and scopes where these are not configured, so they are updated in place
+	oldAppRoles, _ := d.GetChange("app_role")
+	if err := applicationAssignAppRoleIds(properties.AppRoles, expandApplicationAppRoles(oldAppRoles.(*schema.Set).List())); err != nil {
+		return tf.ErrorDiagPathF(err, "app_role", "Could not assign IDs for app roles")
+	}
+	oldApi, _ := d.GetChange("api")
+	if err := applicationAssignOAuth2PermissionScopeIds(properties.Api.OAuth2PermissionScopes, expandApplicationApi(oldApi.([]interface{})).OAuth2PermissionScopes); err != nil {
+		return tf.ErrorDiagPathF(err, "api.0.oauth2_permission_scope", "Could not assign IDs for OAuth2 permission scopes")
+	}
+
+	// Only changed or removed roles and scopes are disabled beforehand, and they are omitted from the update when unchanged
+	appRoles, err := applicationDisableAppRoles(ctx, client, &properties, properties.AppRoles)
+	if err != nil {
 		return tf.ErrorDiagPathF(err, "app_role", "Could not disable App Roles for application with object ID %q", d.Id())
 	}
+	properties.AppRoles = appRoles
 
-	if err := applicationDisableOauth2PermissionScopes(ctx, client, &properties, expandApplicationOAuth2PermissionScope(d.Get("api.0.oauth2_permission_scope").(*schema.Set).List())); err != nil {
+	oauth2PermissionScopes, err := applicationDisableOauth2PermissionScopes(ctx, client, &properties, properties.Api.OAuth2PermissionScopes)
+	if err != nil {
 		return tf.ErrorDiagPathF(err, "api.0.oauth2_permission_scope", "Could not disable OAuth2 Permission Scopes for application with object ID %q", d.Id())
 	}
+	properties.Api.OAuth2PermissionScopes = oauth2PermissionScopes
 
 	if _, err := client.Update(ctx, properties); err != nil {
 		return tf.ErrorDiagF(err, "Could not update application with ID: %q", d.Id())
 	}
 
-	owners := *tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List())
-	if err := applicationSetOwners(ctx, client, &properties, owners); err != nil {
+	if d.HasChange("on_premises_publishing") {
+		if onPremisesPublishing := expandApplicationOnPremisesPublishing(d.Get("on_premises_publishing").([]interface{})); onPremisesPublishing != nil {
+			if _, err := publishingClient.Update(ctx, d.Id(), *onPremisesPublishing); err != nil {
+				return tf.ErrorDiagPathF(err, "on_premises_publishing", "Could not update on-premises publishing for application with object ID: %q", d.Id())
+			}
+		}
+	}
+
+	if d.HasChange("single_page_application") {
+		if _, err := spaClient.Update(ctx, d.Id(), expandApplicationSpa(d.Get("single_page_application").([]interface{}))); err != nil {
+			return tf.ErrorDiagPathF(err, "single_page_application", "Could not update single-page application for application with object ID: %q", d.Id())
+		}
+	}
+
+	if err := applicationSetOwners(ctx, client, &properties, owners, meta.(*clients.Client).Claims); err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not set owners for application with object ID: %q", d.Id())
 	}
 
-	return applicationResourceRead(ctx, d, meta)
+	// The verified publisher is set and unset using separate actions, rather than by updating the application
+	if d.HasChange("verified_publisher_mpn_id") {
+		if mpnId := d.Get("verified_publisher_mpn_id").(string); mpnId != "" {
+			if _, err := verifiedPublisherClient.Set(ctx, d.Id(), mpnId); err != nil {
+				return tf.ErrorDiagPathF(applicationVerifiedPublisherError(err), "verified_publisher_mpn_id", "Could not set verified publisher for application with object ID: %q", d.Id())
+			}
+		} else {
+			if _, err := verifiedPublisherClient.Unset(ctx, d.Id()); err != nil {
+				return tf.ErrorDiagPathF(applicationVerifiedPublisherError(err), "verified_publisher_mpn_id", "Could not unset verified publisher for application with object ID: %q", d.Id())
+			}
+		}
+	}
+
+	if d.Get("remove_expired_credentials").(bool) {
+		gracePeriod, err := time.ParseDuration(d.Get("expired_credentials_grace_period").(string))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "expired_credentials_grace_period", "Parsing grace period for expired credentials")
+		}
+
+		tf.LockByName(applicationResourceName, d.Id())
+		retainedKeyIds := tf.ExpandStringSlice(d.Get("retained_credential_key_ids").(*schema.Set).List())
+		removed, err := applicationRemoveExpiredCredentials(ctx, client, d.Id(), gracePeriod, retainedKeyIds)
+		tf.UnlockByName(applicationResourceName, d.Id())
+		if len(removed) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       fmt.Sprintf("Removed %d expired credential(s) from application with object ID %q", len(removed), d.Id()),
+				Detail:        fmt.Sprintf("The following password and certificate credentials expired longer ago than `expired_credentials_grace_period` and have been removed: %s", strings.Join(removed, ", ")),
+				AttributePath: cty.Path{cty.GetAttrStep{Name: "remove_expired_credentials"}},
+			})
+		}
+		if err != nil {
+			return append(diags, tf.ErrorDiagF(err, "Could not remove expired credentials from application with object ID: %q", d.Id())...)
+		}
+	}
+
+	return append(diags, applicationResourceRead(ctx, d, meta)...)
 }
 
 func applicationResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).Applications.ApplicationsClient
+	publishingClient := meta.(*clients.Client).Applications.ApplicationOnPremisesPublishingClient
+	spaClient := meta.(*clients.Client).Applications.ApplicationSpaClient
 
 	app, status, err := client.Get(ctx, d.Id())
 	if err != nil {
@@ -532,50 +917,134 @@ func applicationResourceRead(ctx context.Context, d *schema.ResourceData, meta i
 		return tf.ErrorDiagPathF(err, "id", "Retrieving Application with object ID %q", d.Id())
 	}
 
-	tf.Set(d, "api", flattenApplicationApi(app.Api, false))
+	tf.Set(d, "api", flattenApplicationApi(app.Api, false, len(d.Get("api").([]interface{})) > 0))
 	tf.Set(d, "app_role", flattenApplicationAppRoles(app.AppRoles))
 	tf.Set(d, "application_id", app.AppId)
+	tf.Set(d, "client_id", app.AppId)
 	tf.Set(d, "display_name", app.DisplayName)
 	tf.Set(d, "fallback_public_client_enabled", app.IsFallbackPublicClient)
 	tf.Set(d, "group_membership_claims", flattenApplicationGroupMembershipClaims(app.GroupMembershipClaims))
 	tf.Set(d, "identifier_uris", tf.FlattenStringSlicePtr(app.IdentifierUris))
 	tf.Set(d, "object_id", app.ID)
 	tf.Set(d, "optional_claims", flattenApplicationOptionalClaims(app.OptionalClaims))
+	tf.Set(d, "public_client", flattenApplicationPublicClient(app.PublicClient, len(d.Get("public_client").([]interface{})) > 0))
 	tf.Set(d, "required_resource_access", flattenApplicationRequiredResourceAccess(app.RequiredResourceAccess))
 	tf.Set(d, "sign_in_audience", string(app.SignInAudience))
+	tf.Set(d, "unique_name", app.UniqueName)
+
+	spa, _, err := spaClient.Get(ctx, *app.ID)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "single_page_application", "Could not retrieve single-page application for application with object ID %q", *app.ID)
+	}
+	tf.Set(d, "single_page_application", flattenApplicationSpa(spa, len(d.Get("single_page_application").([]interface{})) > 0))
 	tf.Set(d, "web", flattenApplicationWeb(app.Web, d.Get("web.#").(int) > 0, d.Get("web.0.implicit_grant.#").(int) > 0))
 
+	// Application Proxy configuration is only available from the beta API, so it is only retrieved when it is being
+	// managed for this application
+	if len(d.Get("on_premises_publishing").([]interface{})) > 0 {
+		onPremisesPublishing, _, err := publishingClient.Get(ctx, *app.ID)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "on_premises_publishing", "Could not retrieve on-premises publishing for application with object ID %q", *app.ID)
+		}
+		tf.Set(d, "on_premises_publishing", flattenApplicationOnPremisesPublishing(onPremisesPublishing))
+	}
+
 	preventDuplicates := false
 	if v := d.Get("prevent_duplicate_names").(bool); v {
 		preventDuplicates = v
 	}
 	tf.Set(d, "prevent_duplicate_names", preventDuplicates)
+	tf.Set(d, "validate_resource_access", d.Get("validate_resource_access").(bool))
+
+	removeExpiredCredentials := d.Get("remove_expired_credentials").(bool)
+	tf.Set(d, "remove_expired_credentials", removeExpiredCredentials)
+
+	gracePeriod := "0s"
+	if v := d.Get("expired_credentials_grace_period").(string); v != "" {
+		gracePeriod = v
+	}
+	tf.Set(d, "expired_credentials_grace_period", gracePeriod)
+
+	expiredCredentialKeyIds := make([]string, 0)
+	if removeExpiredCredentials {
+		duration, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "expired_credentials_grace_period", "Parsing grace period for expired credentials")
+		}
+		retainedKeyIds := tf.ExpandStringSlice(d.Get("retained_credential_key_ids").(*schema.Set).List())
+		passwords, keys := applicationExpiredCredentialKeyIds(app, duration, time.Now(), retainedKeyIds)
+		expiredCredentialKeyIds = append(append(expiredCredentialKeyIds, passwords...), keys...)
+	}
+	tf.Set(d, "expired_credential_key_ids", expiredCredentialKeyIds)
+	tf.Set(d, "verified_publisher", flattenApplicationVerifiedPublisher(app.VerifiedPublisher))
+
+	// The verified publisher is only tracked when it is managed for this application, so that publishers verified
+	// outside of Terraform are not removed
+	if d.Get("verified_publisher_mpn_id").(string) != "" {
+		verifiedPublisherId := ""
+		if app.VerifiedPublisher != nil && app.VerifiedPublisher.VerifiedPublisherId != nil {
+			verifiedPublisherId = *app.VerifiedPublisher.VerifiedPublisherId
+		}
+		tf.Set(d, "verified_publisher_mpn_id", verifiedPublisherId)
+	}
 
 	owners, _, err := client.ListOwners(ctx, *app.ID)
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not retrieve owners for application with object ID %q", *app.ID)
 	}
-	tf.Set(d, "owners", owners)
+	configuredOwners := *tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List())
+	tf.Set(d, "owners", helpers.FlattenOwners(*owners, configuredOwners, meta.(*clients.Client).Claims.ObjectId))
 
 	return nil
 }
 
-func applicationResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func applicationResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*clients.Client).Applications.ApplicationsClient
 
-	_, status, err := client.Get(ctx, d.Id())
+	if !strings.HasPrefix(d.Id(), applicationUniqueNameImportPrefix) {
+		if err := helpers.ValidateDirectoryObjectType(ctx, client.BaseClient, d.Id(), helpers.DirectoryObjectTypeApplication); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+
+	uniqueName := strings.TrimPrefix(d.Id(), applicationUniqueNameImportPrefix)
+	app, status, err := applicationGetByUniqueName(ctx, client, uniqueName)
 	if err != nil {
 		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("Application was not found"), "id", "Retrieving Application with object ID %q", d.Id())
+			return nil, fmt.Errorf("application with unique name %q was not found", uniqueName)
 		}
+		return nil, fmt.Errorf("retrieving application with unique name %q: %+v", uniqueName, err)
+	}
+	if app.ID == nil || *app.ID == "" {
+		return nil, fmt.Errorf("object ID returned for application with unique name %q is nil/empty", uniqueName)
+	}
+
+	d.SetId(*app.ID)
 
-		return tf.ErrorDiagPathF(err, "id", "Retrieving application with object ID %q", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func applicationResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "application",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
 	}
 
-	status, err = client.Delete(ctx, d.Id())
-	if err != nil {
-		return tf.ErrorDiagPathF(err, "id", "Deleting application with object ID %q, got status %d", d.Id(), status)
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
 	}
 
-	return nil
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
 }
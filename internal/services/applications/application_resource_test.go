@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
@@ -18,6 +23,10 @@ import (
 
 type ApplicationResource struct{}
 
+// verifiedPublisherMpnIdEnvVar specifies the Microsoft Partner Network ID to use when testing verified publishers. Most
+// test tenants cannot meet the prerequisites for publisher verification, so these tests are skipped unless it is set.
+const verifiedPublisherMpnIdEnvVar = "ARM_TEST_VERIFIED_PUBLISHER_MPN_ID"
+
 func TestAccApplication_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application", "test")
 	r := ApplicationResource{}
@@ -28,6 +37,7 @@ func TestAccApplication_basic(t *testing.T) {
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("application_id").Exists(),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", data.ResourceName, "application_id"),
 				check.That(data.ResourceName).Key("object_id").Exists(),
 				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-APP-%d", data.RandomInteger)),
 			),
@@ -136,6 +146,89 @@ func TestAccApplication_appRoles(t *testing.T) {
 	})
 }
 
+func TestAccApplication_appRolesWithoutIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	appRoleIds := make(map[string]string)
+	scopeIds := make(map[string]string)
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.appRolesWithoutIds(data, "user"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role.#").HasValue("2"),
+				check.That(data.ResourceName).Key("api.0.oauth2_permission_scope.#").HasValue("1"),
+				r.recordRoleScopeIds(data.ResourceName, "app_role", appRoleIds),
+				r.recordRoleScopeIds(data.ResourceName, "api.0.oauth2_permission_scope", scopeIds),
+			),
+		},
+		data.ImportStep(),
+		{
+			// Re-applying the same configuration must not result in any roles or scopes being recreated or disabled
+			Config: r.appRolesWithoutIds(data, "user"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				r.checkRoleScopeIdsUnchanged(data.ResourceName, "app_role", appRoleIds),
+				r.checkRoleScopeIdsUnchanged(data.ResourceName, "api.0.oauth2_permission_scope", scopeIds),
+			),
+		},
+		{
+			// Changing the value of a role should update it in place, retaining its ID
+			Config: r.appRolesWithoutIds(data, "reader"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role.#").HasValue("2"),
+				r.checkRoleScopeIdsUnchanged(data.ResourceName, "app_role", appRoleIds),
+				r.checkRoleScopeIdsUnchanged(data.ResourceName, "api.0.oauth2_permission_scope", scopeIds),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_appRolesUnchangedStayEnabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+	adminRoleId := data.UUID()
+	userRoleId := data.UUID()
+	poller := &applicationAppRolePoller{roleId: adminRoleId}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.appRolesDelta(data, adminRoleId, userRoleId, "User", ""),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role.#").HasValue("2"),
+				poller.captureObjectId(data.ResourceName),
+			),
+		},
+		{
+			// Change one role and add another, whilst polling to check that the unchanged role is never disabled
+			PreConfig: poller.start,
+			Config:    r.appRolesDelta(data, adminRoleId, userRoleId, "Member", data.UUID()),
+			Check: resource.ComposeTestCheckFunc(
+				poller.stop,
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role.#").HasValue("3"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// Remove the changed and added roles, again checking that the unchanged role is never disabled
+			PreConfig: poller.start,
+			Config:    r.appRolesDelta(data, adminRoleId, "", "", ""),
+			Check: resource.ComposeTestCheckFunc(
+				poller.stop,
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccApplication_duplicateAppRolesOauth2PermissionsValues(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application", "test")
 	r := ApplicationResource{}
@@ -269,6 +362,34 @@ func TestAccApplication_owners(t *testing.T) {
 	})
 }
 
+func TestAccApplication_ownersReplaced(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+	poller := &applicationOwnersPoller{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.singleOwner(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("1"),
+				poller.captureObjectId(data.ResourceName),
+			),
+		},
+		{
+			// Replace the entire owner set in one apply, whilst polling to check that the application never has zero owners
+			PreConfig: poller.start,
+			Config:    r.replacedOwners(data),
+			Check: resource.ComposeTestCheckFunc(
+				poller.stop,
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccApplication_preventDuplicateNamesPass(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_application", "test")
 	r := ApplicationResource{}
@@ -284,26 +405,741 @@ func TestAccApplication_preventDuplicateNamesPass(t *testing.T) {
 	})
 }
 
-func TestAccApplication_preventDuplicateNamesFail(t *testing.T) {
-	data := acceptance.BuildTestData(t, "azuread_application", "test")
-	r := ApplicationResource{}
+func TestAccApplication_apiTokenVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.apiTokenVersion(data, 1),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("`requested_access_token_version` must be 2"),
+		},
+		{
+			Config: r.apiTokenVersion(data, 2),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("api.0.known_client_applications.#").HasValue("1"),
+				check.That(data.ResourceName).Key("api.0.requested_access_token_version").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_removeExpiredCredentials(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+	seeder := &applicationCredentialSeeder{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.removeExpiredCredentials(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expired_credential_key_ids.#").HasValue("0"),
+				seeder.captureObjectId(data.ResourceName),
+			),
+		},
+		{
+			// Add a short-lived password and a long-lived password, then wait for the short-lived password to expire
+			PreConfig: seeder.seed(t),
+			Config:    r.removeExpiredCredentials(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expired_credential_key_ids.#").HasValue("0"),
+				seeder.checkRemoved,
+			),
+		},
+		data.ImportStep("expired_credentials_grace_period", "remove_expired_credentials"),
+	})
+}
+
+func TestAccApplication_removeExpiredCredentialsRetained(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	// The certificate expires shortly after being created, and must outlive the seeded password by no more than the wait
+	certificateEndDate := time.Now().Add(3 * time.Minute)
+	seeder := &applicationCredentialSeeder{waitUntil: certificateEndDate}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.removeExpiredCredentialsRetained(data, certificateEndDate.UTC().Format(time.RFC3339)),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("retained_credential_key_ids.#").HasValue("1"),
+				seeder.captureObjectId(data.ResourceName),
+			),
+		},
+		{
+			// Once both the seeded password and the certificate have expired, only the password should be removed
+			PreConfig: seeder.seed(t),
+			Config:    r.removeExpiredCredentialsRetained(data, certificateEndDate.UTC().Format(time.RFC3339)),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("expired_credential_key_ids.#").HasValue("0"),
+				check.That("azuread_application_certificate.test").ExistsInAzure(ApplicationCertificateResource{}),
+				seeder.checkRemoved,
+			),
+		},
+	})
+}
+
+func TestAccApplication_publicClient(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.publicClient(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_client.0.redirect_uris.#").HasValue("3"),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("public_client.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_optionalClaims(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.optionalClaims(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("optional_claims.0.access_token.#").HasValue("0"),
+				check.That(data.ResourceName).Key("optional_claims.0.id_token.#").HasValue("0"),
+				check.That(data.ResourceName).Key("optional_claims.0.saml2_token.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("optional_claims.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_singlePageApplication(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.singlePageApplication(data, `"https://app.hashitown-%[1]d.com/", "http://localhost:3000/"`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("single_page_application.0.redirect_uris.#").HasValue("2"),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.singlePageApplication(data, `"https://app.hashitown-%[1]d.com/callback"`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("single_page_application.0.redirect_uris.#").HasValue("1"),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("single_page_application.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_currentOwner(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.currentOwner(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("1"),
+				check.That(data.ResourceName).Key("owners.0").HasValue("current"),
+			),
+		},
+		// The placeholder is only retained when it is configured, so cannot be imported
+		data.ImportStep("owners"),
+		{
+			Config:      r.currentOwnerDuplicate(data),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("cannot both be specified"),
+		},
+	})
+}
+
+func TestAccApplication_groupOwner(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			// The group is created in the same apply, so its object ID is unknown at plan time
+			Config:      r.groupOwner(data),
+			ExpectError: regexp.MustCompile("groups cannot be owners of applications"),
+		},
+		{
+			Config:      r.groupOwner(data),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("groups cannot be owners of applications"),
+		},
+	})
+}
+
+func TestAccApplication_preventDuplicateNamesFail(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		data.RequiresImportErrorStep(r.preventDuplicateNamesFail(data)),
+	})
+}
+
+func TestAccApplication_uniqueName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.uniqueName(data, "acctest-app-unique-"+data.RandomString),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("unique_name").HasValue("acctest-app-unique-"+data.RandomString),
+			),
+		},
+		data.ImportStep(),
+		{
+			ResourceName:      data.ResourceName,
+			ImportState:       true,
+			ImportStateId:     "uniqueName/acctest-app-unique-" + data.RandomString,
+			ImportStateVerify: true,
+		},
+		{
+			Config:      r.uniqueName(data, "acctest-app-renamed-"+data.RandomString),
+			ExpectError: regexp.MustCompile("`unique_name` cannot be changed once it has been set"),
+		},
+	})
+}
+
+func TestAccApplication_importWrongObjectType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.importWrongObjectType(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			ResourceName: data.ResourceName,
+			ImportState:  true,
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				other, ok := s.RootModule().Resources["azuread_service_principal.other"]
+				if !ok {
+					return "", fmt.Errorf("azuread_service_principal.other not found in state")
+				}
+				return other.Primary.ID, nil
+			},
+			ExpectError: regexp.MustCompile("is a ServicePrincipal, not an Application"),
+		},
+	})
+}
+
+func TestAccApplication_onPremisesPublishing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.onPremisesPublishing(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("on_premises_publishing.#").HasValue("1"),
+				check.That(data.ResourceName).Key("on_premises_publishing.0.http_only_cookie_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep("on_premises_publishing"),
+		{
+			Config: r.onPremisesPublishing(data, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("on_premises_publishing.#").HasValue("1"),
+				check.That(data.ResourceName).Key("on_premises_publishing.0.http_only_cookie_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep("on_premises_publishing"),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("on_premises_publishing.#").HasValue("1"),
+			),
+		},
+	})
+}
+
+func TestAccApplication_webClearUrls(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.webUrls(data, true, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("web.0.homepage_url").HasValue(fmt.Sprintf("https://app-%d.example.com", data.RandomInteger)),
+				check.That(data.ResourceName).Key("web.0.logout_url").HasValue(fmt.Sprintf("https://app-%d.example.com/logout", data.RandomInteger)),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.webUrls(data, true, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("web.0.homepage_url").HasValue(fmt.Sprintf("https://app-%d.example.com", data.RandomInteger)),
+				check.That(data.ResourceName).Key("web.0.logout_url").HasValue(""),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.webUrls(data, false, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("web.0.homepage_url").HasValue(""),
+				check.That(data.ResourceName).Key("web.0.logout_url").HasValue(""),
+				check.That(data.ResourceName).Key("web.0.redirect_uris.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccApplication_tooManyRedirectUris(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.redirectUris(data, 257),
+			ExpectError: regexp.MustCompile("a maximum of 256 redirect URIs are supported for an application, but 257 were specified"),
+		},
+	})
+}
+
+func TestAccApplication_validateResourceAccess(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.validateResourceAccess(data, "e1fe6dd8-ba31-4d61-89e7-88639da4683d"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("required_resource_access.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("validate_resource_access"),
+		{
+			Config:      r.validateResourceAccess(data, "e1fe6dd8-ba31-4d61-89e7-88639da4683e"),
+			ExpectError: regexp.MustCompile(`no OAuth2 permission scope with ID "e1fe6dd8-ba31-4d61-89e7-88639da4683e" is published by "Microsoft Graph" \(resource_app_id "00000003-0000-0000-c000-000000000000"\), the closest match is "e1fe6dd8-ba31-4d61-89e7-88639da4683d" \(User.Read\)`),
+		},
+	})
+}
+
+func TestAccApplication_verifiedPublisher(t *testing.T) {
+	mpnId := os.Getenv(verifiedPublisherMpnIdEnvVar)
+	if mpnId == "" {
+		t.Skipf("skipping since %s is not set", verifiedPublisherMpnIdEnvVar)
+	}
+
+	data := acceptance.BuildTestData(t, "azuread_application", "test")
+	r := ApplicationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("verified_publisher.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.verifiedPublisher(data, mpnId),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("verified_publisher_mpn_id").HasValue(mpnId),
+				check.That(data.ResourceName).Key("verified_publisher.#").HasValue("1"),
+				check.That(data.ResourceName).Key("verified_publisher.0.verified_publisher_id").HasValue(mpnId),
+				check.That(data.ResourceName).Key("verified_publisher.0.display_name").Exists(),
+			),
+		},
+		data.ImportStep("verified_publisher_mpn_id"),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("verified_publisher.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ApplicationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Applications.ApplicationsClient
+	client.BaseClient.DisableRetries = true
+	app, status, err := client.Get(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Application with object ID %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve Application with object ID %q: %+v", state.ID, err)
+	}
+	return utils.Bool(app.ID != nil && *app.ID == state.ID), nil
+}
+
+// applicationCredentialSeeder adds password credentials directly to an application, to check that only expired
+// credentials are removed when remove_expired_credentials is enabled. When waitUntil is set, seeding waits until at
+// least this time, so that other credentials can expire too.
+type applicationCredentialSeeder struct {
+	objectId       string
+	expiredKeyId   string
+	unexpiredKeyId string
+	waitUntil      time.Time
+}
+
+func (c *applicationCredentialSeeder) captureObjectId(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		c.objectId = rs.Primary.ID
+		return nil
+	}
+}
+
+func (c *applicationCredentialSeeder) seed(t *testing.T) func() {
+	return func() {
+		client := acceptance.AzureADProvider.Meta().(*clients.Client).Applications.ApplicationsClient
+		ctx := context.Background()
+
+		expiry := time.Now().Add(time.Minute)
+		expired, _, err := client.AddPassword(ctx, c.objectId, msgraph.PasswordCredential{
+			DisplayName: utils.String("acctest-expiring"),
+			EndDateTime: &expiry,
+		})
+		if err != nil || expired.KeyId == nil {
+			t.Fatalf("adding expiring password to application with object ID %q: %v", c.objectId, err)
+		}
+		c.expiredKeyId = *expired.KeyId
+
+		unexpiredExpiry := time.Now().AddDate(1, 0, 0)
+		unexpired, _, err := client.AddPassword(ctx, c.objectId, msgraph.PasswordCredential{
+			DisplayName: utils.String("acctest-unexpired"),
+			EndDateTime: &unexpiredExpiry,
+		})
+		if err != nil || unexpired.KeyId == nil {
+			t.Fatalf("adding password to application with object ID %q: %v", c.objectId, err)
+		}
+		c.unexpiredKeyId = *unexpired.KeyId
+
+		if c.waitUntil.After(expiry) {
+			expiry = c.waitUntil
+		}
+		time.Sleep(time.Until(expiry) + 30*time.Second)
+	}
+}
+
+func (c *applicationCredentialSeeder) checkRemoved(_ *terraform.State) error {
+	client := acceptance.AzureADProvider.Meta().(*clients.Client).Applications.ApplicationsClient
+	app, _, err := client.Get(context.Background(), c.objectId)
+	if err != nil {
+		return fmt.Errorf("retrieving application with object ID %q: %v", c.objectId, err)
+	}
+
+	found := make(map[string]bool)
+	if app.PasswordCredentials != nil {
+		for _, cred := range *app.PasswordCredentials {
+			if cred.KeyId != nil {
+				found[*cred.KeyId] = true
+			}
+		}
+	}
+	if found[c.expiredKeyId] {
+		return fmt.Errorf("expired password credential %q was not removed", c.expiredKeyId)
+	}
+	if !found[c.unexpiredKeyId] {
+		return fmt.Errorf("unexpired password credential %q was removed", c.unexpiredKeyId)
+	}
+	return nil
+}
+
+// applicationOwnersPoller repeatedly lists the owners of an application in the background, recording the smallest
+// number of owners observed
+type applicationOwnersPoller struct {
+	objectId string
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu           sync.Mutex
+	observations int
+	minOwners    int
+}
+
+func (p *applicationOwnersPoller) captureObjectId(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		p.objectId = rs.Primary.ID
+		return nil
+	}
+}
+
+func (p *applicationOwnersPoller) start() {
+	client := *acceptance.AzureADProvider.Meta().(*clients.Client).Applications.ApplicationsClient
+	client.BaseClient.DisableRetries = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	p.minOwners = -1
+
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			if owners, _, err := client.ListOwners(ctx, p.objectId); err == nil && owners != nil {
+				p.mu.Lock()
+				p.observations++
+				if p.minOwners < 0 || len(*owners) < p.minOwners {
+					p.minOwners = len(*owners)
+				}
+				p.mu.Unlock()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *applicationOwnersPoller) stop(_ *terraform.State) error {
+	p.cancel()
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.observations == 0 {
+		return fmt.Errorf("owners of application with object ID %q were never observed", p.objectId)
+	}
+	if p.minOwners == 0 {
+		return fmt.Errorf("application with object ID %q was observed with zero owners during %d polls", p.objectId, p.observations)
+	}
+	return nil
+}
+
+// applicationAppRolePoller repeatedly retrieves an application in the background, recording whether the app role with
+// the given ID was ever observed to be missing or disabled
+type applicationAppRolePoller struct {
+	objectId string
+	roleId   string
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu           sync.Mutex
+	observations int
+	disabled     int
+}
+
+func (p *applicationAppRolePoller) captureObjectId(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		p.objectId = rs.Primary.ID
+		return nil
+	}
+}
+
+func (p *applicationAppRolePoller) start() {
+	client := *acceptance.AzureADProvider.Meta().(*clients.Client).Applications.ApplicationsClient
+	client.BaseClient.DisableRetries = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	p.observations = 0
+	p.disabled = 0
+
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(250 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			if app, _, err := client.Get(ctx, p.objectId); err == nil && app != nil {
+				enabled := false
+				if app.AppRoles != nil {
+					for _, role := range *app.AppRoles {
+						if role.ID != nil && strings.EqualFold(*role.ID, p.roleId) {
+							enabled = role.IsEnabled != nil && *role.IsEnabled
+							break
+						}
+					}
+				}
+				p.mu.Lock()
+				p.observations++
+				if !enabled {
+					p.disabled++
+				}
+				p.mu.Unlock()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (p *applicationAppRolePoller) stop(_ *terraform.State) error {
+	p.cancel()
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.observations == 0 {
+		return fmt.Errorf("application with object ID %q was never observed", p.objectId)
+	}
+	if p.disabled > 0 {
+		return fmt.Errorf("app role %q was observed missing or disabled in %d of %d polls", p.roleId, p.disabled, p.observations)
+	}
+	return nil
+}
+
+// roleScopeAttributes returns the attributes for each role or scope in the set at the given path, keyed by display name
+func (ApplicationResource) roleScopeAttributes(s *terraform.State, resourceName, path string) (map[string]map[string]string, error) {
+	rs, ok := s.RootModule().Resources[resourceName]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in state", resourceName)
+	}
+
+	byIndex := make(map[string]map[string]string)
+	prefix := path + "."
+	for k, v := range rs.Primary.Attributes {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(k, prefix), ".", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if _, ok := byIndex[parts[0]]; !ok {
+			byIndex[parts[0]] = make(map[string]string)
+		}
+		byIndex[parts[0]][parts[1]] = v
+	}
+
+	result := make(map[string]map[string]string)
+	for _, attrs := range byIndex {
+		name := attrs["display_name"]
+		if name == "" {
+			name = attrs["admin_consent_display_name"]
+		}
+		result[name] = attrs
+	}
+	return result, nil
+}
 
-	data.ResourceTest(t, r, []resource.TestStep{
-		data.RequiresImportErrorStep(r.preventDuplicateNamesFail(data)),
-	})
+func (r ApplicationResource) recordRoleScopeIds(resourceName, path string, ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		items, err := r.roleScopeAttributes(s, resourceName, path)
+		if err != nil {
+			return err
+		}
+		for name, attrs := range items {
+			if attrs["id"] == "" {
+				return fmt.Errorf("no ID was assigned for %s %q", path, name)
+			}
+			ids[name] = attrs["id"]
+		}
+		return nil
+	}
 }
 
-func (r ApplicationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
-	client := clients.Applications.ApplicationsClient
-	client.BaseClient.DisableRetries = true
-	app, status, err := client.Get(ctx, state.ID)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return nil, fmt.Errorf("Application with object ID %q does not exist", state.ID)
+func (r ApplicationResource) checkRoleScopeIdsUnchanged(resourceName, path string, ids map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		items, err := r.roleScopeAttributes(s, resourceName, path)
+		if err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to retrieve Application with object ID %q: %+v", state.ID, err)
+		if len(items) != len(ids) {
+			return fmt.Errorf("expected %d items for %s, got %d", len(ids), path, len(items))
+		}
+		for name, attrs := range items {
+			if attrs["id"] != ids[name] {
+				return fmt.Errorf("ID for %s %q changed from %q to %q", path, name, ids[name], attrs["id"])
+			}
+			if attrs["enabled"] != "true" {
+				return fmt.Errorf("%s %q was unexpectedly disabled", path, name)
+			}
+		}
+		return nil
 	}
-	return utils.Bool(app.ID != nil && *app.ID == state.ID), nil
 }
 
 func (ApplicationResource) basic(data acceptance.TestData) string {
@@ -316,6 +1152,17 @@ resource "azuread_application" "test" {
 `, data.RandomInteger)
 }
 
+func (ApplicationResource) uniqueName(data acceptance.TestData, uniqueName string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+  unique_name  = "%[2]s"
+}
+`, data.RandomInteger, uniqueName)
+}
+
 func (ApplicationResource) withGroupMembershipClaims(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -516,6 +1363,53 @@ resource "azuread_application" "test" {
 `, data.RandomInteger, data.UUID(), data.UUID())
 }
 
+func (ApplicationResource) appRolesDelta(data acceptance.TestData, adminRoleId, userRoleId, userRoleName, readerRoleId string) string {
+	userRole := ""
+	if userRoleId != "" {
+		userRole = fmt.Sprintf(`
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "%[2]ss can perform limited actions"
+    display_name         = "%[2]s"
+    enabled              = true
+    id                   = "%[1]s"
+    value                = "%[2]s"
+  }
+`, userRoleId, userRoleName)
+	}
+
+	readerRole := ""
+	if readerRoleId != "" {
+		readerRole = fmt.Sprintf(`
+  app_role {
+    allowed_member_types = ["Application"]
+    description          = "Readers have read-only access"
+    display_name         = "Reader"
+    enabled              = true
+    id                   = "%[1]s"
+    value                = "Reader"
+  }
+`, readerRoleId)
+	}
+
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User", "Application"]
+    description          = "Admins can manage roles and perform all task actions"
+    display_name         = "Admin"
+    enabled              = true
+    id                   = "%[2]s"
+    value                = "Admin"
+  }
+%[3]s%[4]s}
+`, data.RandomInteger, adminRoleId, userRole, readerRole)
+}
+
 func (ApplicationResource) oauth2PermissionScopes(data acceptance.TestData, scopeIDs []string) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -683,6 +1577,143 @@ resource "azuread_application" "test" {
 `, r.templateThreeUsers(data), data.RandomInteger)
 }
 
+func (ApplicationResource) apiTokenVersion(data acceptance.TestData, version int) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "known" {
+  display_name = "acctest-APP-known-%[1]d"
+}
+
+resource "azuread_application" "test" {
+  display_name     = "acctest-APP-%[1]d"
+  sign_in_audience = "AzureADandPersonalMicrosoftAccount"
+
+  api {
+    known_client_applications      = [azuread_application.known.application_id]
+    requested_access_token_version = %[2]d
+  }
+}
+`, data.RandomInteger, version)
+}
+
+func (ApplicationResource) removeExpiredCredentials(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name               = "acctest-APP-%[1]d"
+  remove_expired_credentials = true
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) removeExpiredCredentialsRetained(data acceptance.TestData, certificateEndDate string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name                = "acctest-APP-%[1]d"
+  remove_expired_credentials  = true
+  retained_credential_key_ids = ["%[2]s"]
+}
+
+resource "azuread_application_certificate" "test" {
+  application_object_id = azuread_application.test.id
+  key_id                = "%[2]s"
+  type                  = "AsymmetricX509Cert"
+  end_date              = "%[3]s"
+  value                 = <<EOT
+%[4]s
+EOT
+}
+`, data.RandomInteger, data.RandomID, certificateEndDate, applicationCertificatePem)
+}
+
+func (ApplicationResource) publicClient(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  public_client {
+    redirect_uris = [
+      "https://login.microsoftonline.com/common/oauth2/nativeclient",
+      "http://localhost:8080/",
+      "ms-appx-web://microsoft.aad.brokerplugin/acctest-%[1]d",
+    ]
+  }
+
+  web {
+    redirect_uris = ["https://web.hashitown-%[1]d.com/"]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) optionalClaims(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  optional_claims {
+    saml2_token {
+      name = "groups"
+    }
+
+    saml2_token {
+      name                  = "upn"
+      essential             = true
+      additional_properties = ["include_externally_authenticated_upn"]
+    }
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) singlePageApplication(data acceptance.TestData, redirectUris string) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  single_page_application {
+    redirect_uris = [`+redirectUris+`]
+  }
+
+  web {
+    redirect_uris = ["https://web.hashitown-%[1]d.com/"]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) currentOwner(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+  owners       = ["current"]
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) currentOwnerDuplicate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_client_config" "current" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+  owners       = ["current", data.azuread_client_config.current.object_id]
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationResource) groupOwner(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "owner" {
+  display_name     = "acctest-APP-owner-%[1]d"
+  security_enabled = true
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+  owners       = [azuread_group.owner.object_id]
+}
+`, data.RandomInteger)
+}
+
 func (r ApplicationResource) threeOwners(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -697,3 +1728,153 @@ resource "azuread_application" "test" {
 }
 `, r.templateThreeUsers(data), data.RandomInteger)
 }
+
+func (r ApplicationResource) replacedOwners(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[2]d"
+  owners = [
+    azuread_user.testB.object_id,
+    azuread_user.testC.object_id,
+  ]
+}
+`, r.templateThreeUsers(data), data.RandomInteger)
+}
+
+func (r ApplicationResource) importWrongObjectType(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal" "other" {
+  application_id = azuread_application.test.application_id
+}
+`, r.basic(data))
+}
+
+func (ApplicationResource) appRolesWithoutIds(data acceptance.TestData, userRoleValue string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  api {
+    oauth2_permission_scope {
+      admin_consent_description  = "Administer the application"
+      admin_consent_display_name = "Administer"
+      enabled                    = true
+      type                       = "Admin"
+      value                      = "administer"
+    }
+  }
+
+  app_role {
+    allowed_member_types = ["User"]
+    description          = "Admins can manage roles and perform all task actions"
+    display_name         = "Admin"
+    enabled              = true
+    value                = "admin"
+  }
+
+  app_role {
+    allowed_member_types = ["User", "Application"]
+    description          = "Users can perform limited actions"
+    display_name         = "User"
+    enabled              = true
+    value                = "%[2]s"
+  }
+}
+`, data.RandomInteger, userRoleValue)
+}
+
+func (ApplicationResource) onPremisesPublishing(data acceptance.TestData, httpOnlyCookie bool) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  on_premises_publishing {
+    external_url             = "https://acctest-app-%[1]d-${replace(data.azuread_domains.test.domains.0.domain_name, ".onmicrosoft.com", "")}.msappproxy.net/"
+    internal_url             = "http://acctest-app-%[1]d.internal.local/"
+    http_only_cookie_enabled = %[2]t
+  }
+}
+`, data.RandomInteger, httpOnlyCookie)
+}
+
+func (ApplicationResource) webUrls(data acceptance.TestData, homepage, logout bool) string {
+	var homepageUrl, logoutUrl string
+	if homepage {
+		homepageUrl = fmt.Sprintf(`homepage_url = "https://app-%d.example.com"`, data.RandomInteger)
+	}
+	if logout {
+		logoutUrl = fmt.Sprintf(`logout_url = "https://app-%d.example.com/logout"`, data.RandomInteger)
+	}
+
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  web {
+    %[2]s
+    %[3]s
+    redirect_uris = ["https://app-%[1]d.example.com/callback"]
+  }
+}
+`, data.RandomInteger, homepageUrl, logoutUrl)
+}
+
+func (ApplicationResource) validateResourceAccess(data acceptance.TestData, scopeId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name             = "acctest-APP-%[1]d"
+  validate_resource_access = true
+
+  required_resource_access {
+    resource_app_id = "00000003-0000-0000-c000-000000000000"
+
+    resource_access {
+      id   = "%[2]s"
+      type = "Scope"
+    }
+  }
+}
+`, data.RandomInteger, scopeId)
+}
+
+func (ApplicationResource) verifiedPublisher(data acceptance.TestData, mpnId string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name              = "acctest-APP-%[1]d"
+  sign_in_audience          = "AzureADMultipleOrgs"
+  verified_publisher_mpn_id = "%[2]s"
+}
+`, data.RandomInteger, mpnId)
+}
+
+func (ApplicationResource) redirectUris(data acceptance.TestData, count int) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[1]d"
+
+  web {
+    redirect_uris = [for i in range(%[2]d) : "https://app-%[1]d.example.com/callback/${i}"]
+  }
+}
+`, data.RandomInteger, count)
+}
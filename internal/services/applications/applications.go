@@ -2,94 +2,477 @@ package applications
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/auth"
 	"github.com/manicminer/hamilton/msgraph"
 
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
 	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
 )
 
-func applicationDisableAppRoles(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, newRoles *[]msgraph.AppRole) error {
-	if application.ID == nil {
-		return fmt.Errorf("cannot use Application model with nil ID")
+// applicationAppRoleChanged returns whether any configurable property of an app role differs. Properties which cannot be
+// configured, such as the origin, are ignored, as is the ordering of the allowed member types.
+func applicationAppRoleChanged(existing, new msgraph.AppRole) bool {
+	memberTypes := func(in *[]msgraph.AppRoleAllowedMemberType) []string {
+		result := make([]string, 0)
+		if in != nil {
+			for _, v := range *in {
+				result = append(result, string(v))
+			}
+		}
+		sort.Strings(result)
+		return result
 	}
 
-	if newRoles == nil {
-		newRoles = &[]msgraph.AppRole{}
+	return !reflect.DeepEqual(memberTypes(existing.AllowedMemberTypes), memberTypes(new.AllowedMemberTypes)) ||
+		stringValue(existing.Description) != stringValue(new.Description) ||
+		stringValue(existing.DisplayName) != stringValue(new.DisplayName) ||
+		boolValue(existing.IsEnabled) != boolValue(new.IsEnabled) ||
+		stringValue(existing.Value) != stringValue(new.Value)
+}
+
+// applicationOAuth2PermissionScopeChanged returns whether any configurable property of a permission scope differs
+func applicationOAuth2PermissionScopeChanged(existing, new msgraph.PermissionScope) bool {
+	return stringValue(existing.AdminConsentDescription) != stringValue(new.AdminConsentDescription) ||
+		stringValue(existing.AdminConsentDisplayName) != stringValue(new.AdminConsentDisplayName) ||
+		boolValue(existing.IsEnabled) != boolValue(new.IsEnabled) ||
+		existing.Type != new.Type ||
+		stringValue(existing.UserConsentDescription) != stringValue(new.UserConsentDescription) ||
+		stringValue(existing.UserConsentDisplayName) != stringValue(new.UserConsentDisplayName) ||
+		stringValue(existing.Value) != stringValue(new.Value)
+}
+
+// applicationAssignAppRoleIds populates the ID for any app roles which were configured without one. Where an existing
+// role matches by value (or failing that, by display name), its ID is reused so that the role is updated in place and
+// any assignments are preserved. Otherwise a new ID is generated, which is then persisted in state.
+func applicationAssignAppRoleIds(roles *[]msgraph.AppRole, existingRoles *[]msgraph.AppRole) error {
+	if roles == nil {
+		return nil
 	}
 
-	app, status, err := client.Get(ctx, *application.ID)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return fmt.Errorf("application with ID %q was not found", *application.ID)
+	candidates := make([]applicationRoleScopeIdentity, 0)
+	if existingRoles != nil {
+		for _, r := range *existingRoles {
+			candidates = append(candidates, applicationRoleScopeIdentity{id: stringValue(r.ID), value: stringValue(r.Value), displayName: stringValue(r.DisplayName)})
 		}
+	}
 
-		return fmt.Errorf("retrieving Application with object ID %q: %+v", *application.ID, err)
+	wanted := make([]applicationRoleScopeIdentity, 0, len(*roles))
+	for _, r := range *roles {
+		wanted = append(wanted, applicationRoleScopeIdentity{id: stringValue(r.ID), value: stringValue(r.Value), displayName: stringValue(r.DisplayName)})
 	}
 
-	var existingRoles []msgraph.AppRole
-	if app.AppRoles != nil {
-		existingRoles = *app.AppRoles
+	ids, err := applicationAssignRoleScopeIds(wanted, candidates)
+	if err != nil {
+		return fmt.Errorf("assigning IDs for app roles: %v", err)
+	}
+	for i := range *roles {
+		(*roles)[i].ID = utils.String(ids[i])
 	}
 
-	// Don't update if no changes to be made
-	if reflect.DeepEqual(existingRoles, *newRoles) {
+	return nil
+}
+
+// applicationAssignOAuth2PermissionScopeIds populates the ID for any permission scopes which were configured without
+// one, in the same way as applicationAssignAppRoleIds.
+func applicationAssignOAuth2PermissionScopeIds(scopes *[]msgraph.PermissionScope, existingScopes *[]msgraph.PermissionScope) error {
+	if scopes == nil {
 		return nil
 	}
 
-	// Identify any roles to be changed
-	var disable bool
-	for _, new := range *newRoles {
-		if new.ID == nil || *new.ID == "" {
-			return fmt.Errorf("new role provided with nil or empty ID")
+	candidates := make([]applicationRoleScopeIdentity, 0)
+	if existingScopes != nil {
+		for _, s := range *existingScopes {
+			candidates = append(candidates, applicationRoleScopeIdentity{id: stringValue(s.ID), value: stringValue(s.Value), displayName: stringValue(s.AdminConsentDisplayName)})
+		}
+	}
+
+	wanted := make([]applicationRoleScopeIdentity, 0, len(*scopes))
+	for _, s := range *scopes {
+		wanted = append(wanted, applicationRoleScopeIdentity{id: stringValue(s.ID), value: stringValue(s.Value), displayName: stringValue(s.AdminConsentDisplayName)})
+	}
+
+	ids, err := applicationAssignRoleScopeIds(wanted, candidates)
+	if err != nil {
+		return fmt.Errorf("assigning IDs for OAuth2 permission scopes: %v", err)
+	}
+	for i := range *scopes {
+		(*scopes)[i].ID = utils.String(ids[i])
+	}
+
+	return nil
+}
+
+type applicationRoleScopeIdentity struct {
+	id          string
+	value       string
+	displayName string
+}
+
+// applicationAssignRoleScopeIds returns an ID for each of the wanted roles or scopes. Explicitly configured IDs are
+// always used. Remaining roles or scopes are matched against unclaimed existing ones, first by value and then by display
+// name, and a new UUID is generated for any that remain unmatched.
+func applicationAssignRoleScopeIds(wanted, existing []applicationRoleScopeIdentity) ([]string, error) {
+	ids := make([]string, len(wanted))
+	claimed := make(map[string]bool)
+
+	for i, w := range wanted {
+		if w.id != "" {
+			ids[i] = w.id
+			claimed[strings.ToLower(w.id)] = true
 		}
-		for i, existing := range existingRoles {
-			if existing.ID != nil && *existing.ID == *new.ID {
-				if existing.IsEnabled != nil && *existing.IsEnabled && !reflect.DeepEqual(existing, new) {
-					*existingRoles[i].IsEnabled = false
-					disable = true
+	}
+
+	match := func(matches func(w, e applicationRoleScopeIdentity) bool) {
+		for i, w := range wanted {
+			if ids[i] != "" {
+				continue
+			}
+			for _, e := range existing {
+				if e.id == "" || claimed[strings.ToLower(e.id)] {
+					continue
+				}
+				if matches(w, e) {
+					ids[i] = e.id
+					claimed[strings.ToLower(e.id)] = true
+					break
 				}
-				break
 			}
 		}
 	}
+	match(func(w, e applicationRoleScopeIdentity) bool { return w.value != "" && w.value == e.value })
+	match(func(w, e applicationRoleScopeIdentity) bool {
+		return w.displayName != "" && w.displayName == e.displayName
+	})
+
+	for i := range wanted {
+		if ids[i] != "" {
+			continue
+		}
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			return nil, fmt.Errorf("generating UUID: %v", err)
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// applicationAppRoleHash computes the set hash for an app_role block. The ID is excluded, so that a role configured
+// without an ID continues to match the role in state once an ID has been assigned.
+func applicationAppRoleHash(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	memberTypes := make([]string, 0)
+	if raw, ok := m["allowed_member_types"].(*schema.Set); ok {
+		for _, t := range raw.List() {
+			memberTypes = append(memberTypes, t.(string))
+		}
+	}
+	sort.Strings(memberTypes)
+
+	return schema.HashString(fmt.Sprintf("%s|%s|%s|%s|%s", strings.Join(memberTypes, ","),
+		applicationHashValue(m, "description"), applicationHashValue(m, "display_name"), applicationHashValue(m, "enabled"),
+		applicationHashValue(m, "value")))
+}
+
+// applicationOAuth2PermissionScopeHash computes the set hash for an oauth2_permission_scope block, excluding the ID
+func applicationOAuth2PermissionScopeHash(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	return schema.HashString(fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s",
+		applicationHashValue(m, "admin_consent_description"), applicationHashValue(m, "admin_consent_display_name"),
+		applicationHashValue(m, "enabled"), applicationHashValue(m, "type"), applicationHashValue(m, "user_consent_description"),
+		applicationHashValue(m, "user_consent_display_name"), applicationHashValue(m, "value")))
+}
+
+// applicationHashValue returns the string representation of a field for use in a set hash, treating missing fields as empty
+func applicationHashValue(m map[string]interface{}, key string) string {
+	if v, ok := m[key]; ok && v != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return ""
+}
+
+func stringValue(in *string) string {
+	if in == nil {
+		return ""
+	}
+	return *in
+}
+
+func boolValue(in *bool) bool {
+	return in != nil && *in
+}
+
+// applicationDeltaEntries provides access to the app roles or permission scopes of an application, so that the delta
+// between existing and desired entries can be computed by applicationDeltaFor
+type applicationDeltaEntries interface {
+	Kind() string
+	Len() int
+	ID(i int) *string
+	IsEnabled(i int) bool
+
+	// Changed returns whether the entry at index i differs from the entry at index j of other, which holds the same
+	// type of entries
+	Changed(i int, other applicationDeltaEntries, j int) bool
+}
+
+type applicationAppRoles []msgraph.AppRole
+
+func (r applicationAppRoles) Kind() string         { return "role" }
+func (r applicationAppRoles) Len() int             { return len(r) }
+func (r applicationAppRoles) ID(i int) *string     { return r[i].ID }
+func (r applicationAppRoles) IsEnabled(i int) bool { return boolValue(r[i].IsEnabled) }
+
+func (r applicationAppRoles) Changed(i int, other applicationDeltaEntries, j int) bool {
+	return applicationAppRoleChanged(r[i], other.(applicationAppRoles)[j])
+}
+
+// disabled returns a copy of the app roles in which the roles at the specified indices are disabled
+func (r applicationAppRoles) disabled(indices []int) []msgraph.AppRole {
+	result := append(make([]msgraph.AppRole, 0, len(r)), r...)
+	for _, i := range indices {
+		result[i].IsEnabled = utils.Bool(false)
+	}
+	return result
+}
+
+// merged returns the app roles picked from the existing roles (r) and the desired roles, see applicationDelta.merge
+func (r applicationAppRoles) merged(desired applicationAppRoles, picks []applicationDeltaPick) []msgraph.AppRole {
+	result := make([]msgraph.AppRole, 0, len(picks))
+	for _, pick := range picks {
+		if pick.desired {
+			result = append(result, desired[pick.index])
+		} else {
+			result = append(result, r[pick.index])
+		}
+	}
+	return result
+}
+
+type applicationPermissionScopes []msgraph.PermissionScope
+
+func (s applicationPermissionScopes) Kind() string         { return "scope" }
+func (s applicationPermissionScopes) Len() int             { return len(s) }
+func (s applicationPermissionScopes) ID(i int) *string     { return s[i].ID }
+func (s applicationPermissionScopes) IsEnabled(i int) bool { return boolValue(s[i].IsEnabled) }
+
+func (s applicationPermissionScopes) Changed(i int, other applicationDeltaEntries, j int) bool {
+	return applicationOAuth2PermissionScopeChanged(s[i], other.(applicationPermissionScopes)[j])
+}
+
+// disabled returns a copy of the permission scopes in which the scopes at the specified indices are disabled
+func (s applicationPermissionScopes) disabled(indices []int) []msgraph.PermissionScope {
+	result := append(make([]msgraph.PermissionScope, 0, len(s)), s...)
+	for _, i := range indices {
+		result[i].IsEnabled = utils.Bool(false)
+	}
+	return result
+}
+
+// merged returns the permission scopes picked from the existing scopes (s) and the desired scopes, see
+// applicationDelta.merge
+func (s applicationPermissionScopes) merged(desired applicationPermissionScopes, picks []applicationDeltaPick) []msgraph.PermissionScope {
+	result := make([]msgraph.PermissionScope, 0, len(picks))
+	for _, pick := range picks {
+		if pick.desired {
+			result = append(result, desired[pick.index])
+		} else {
+			result = append(result, s[pick.index])
+		}
+	}
+	return result
+}
+
+// applicationDelta describes how the desired app roles or permission scopes for an application differ from the existing
+// ones, with entries being matched by ID. Added entries are indices of desired entries, whilst changed, removed and
+// unchanged entries are indices of existing entries.
+type applicationDelta struct {
+	added     []int
+	changed   []int
+	removed   []int
+	unchanged []int
+
+	// matches maps the index of each changed or unchanged existing entry to the index of its desired entry
+	matches map[int]int
+
+	// rewrite is set when none of the desired entries could be matched to an existing entry, in which case all existing
+	// entries are disabled and the list is rewritten in full
+	rewrite bool
+}
+
+// applicationDeltaPick refers to an entry to be written following an applicationDelta, which is either a desired or an
+// existing entry
+type applicationDeltaPick struct {
+	desired bool
+	index   int
+}
+
+// applicationDeltaFor computes the delta between the existing and desired app roles or permission scopes for an
+// application, which must hold the same type of entries
+func applicationDeltaFor(existing, desired applicationDeltaEntries) (*applicationDelta, error) {
+	delta := applicationDelta{
+		matches: make(map[int]int),
+	}
+
+	existingById := make(map[string]int)
+	for i := 0; i < existing.Len(); i++ {
+		if id := existing.ID(i); id != nil {
+			existingById[strings.ToLower(*id)] = i
+		}
+	}
+
+	for j := 0; j < desired.Len(); j++ {
+		id := desired.ID(j)
+		if id == nil || *id == "" {
+			return nil, fmt.Errorf("new %s provided with nil or empty ID", desired.Kind())
+		}
+
+		i, ok := existingById[strings.ToLower(*id)]
+		if !ok {
+			delta.added = append(delta.added, j)
+			continue
+		}
+
+		delta.matches[i] = j
+		if existing.Changed(i, desired, j) {
+			delta.changed = append(delta.changed, i)
+		} else {
+			delta.unchanged = append(delta.unchanged, i)
+		}
+	}
+
+	for i := 0; i < existing.Len(); i++ {
+		if _, ok := delta.matches[i]; !ok {
+			delta.removed = append(delta.removed, i)
+		}
+	}
+
+	delta.rewrite = existing.Len() > 0 && desired.Len() > 0 && len(delta.added) == desired.Len()
+
+	return &delta, nil
+}
+
+func (d applicationDelta) hasChanges() bool {
+	return len(d.added) > 0 || len(d.changed) > 0 || len(d.removed) > 0
+}
+
+// disable returns the indices of existing entries which are enabled and are to be changed or removed, and so must be
+// disabled before the entries are updated. When rewriting, every enabled entry is disabled.
+func (d applicationDelta) disable(existing applicationDeltaEntries) []int {
+	pending := make(map[int]bool)
+	for _, i := range append(append([]int{}, d.changed...), d.removed...) {
+		pending[i] = true
+	}
+
+	result := make([]int, 0)
+	for i := 0; i < existing.Len(); i++ {
+		if existing.IsEnabled(i) && (d.rewrite || pending[i]) {
+			result = append(result, i)
+		}
+	}
 
-	// Identify any roles to be removed
-	for i, existing := range existingRoles {
-		found := false
-		for _, new := range *newRoles {
-			if existing.ID != nil && *new.ID == *existing.ID {
-				found = true
-				break
+	return result
+}
+
+// merge returns the entries to be written once any changed or removed entries have been disabled. Unchanged entries keep
+// their existing definition and position, changed entries take their desired definition, and new entries are appended.
+// When rewriting, the desired entries are written as they are.
+func (d applicationDelta) merge(existing applicationDeltaEntries) []applicationDeltaPick {
+	result := make([]applicationDeltaPick, 0)
+
+	if !d.rewrite {
+		changed := make(map[int]bool)
+		for _, i := range d.changed {
+			changed[i] = true
+		}
+
+		for i := 0; i < existing.Len(); i++ {
+			if j, ok := d.matches[i]; ok && changed[i] {
+				result = append(result, applicationDeltaPick{desired: true, index: j})
+			} else if ok {
+				result = append(result, applicationDeltaPick{index: i})
 			}
 		}
-		if !found {
-			*existingRoles[i].IsEnabled = false
-			disable = true
+	}
+
+	for _, j := range d.added {
+		result = append(result, applicationDeltaPick{desired: true, index: j})
+	}
+
+	return result
+}
+
+// applicationDisableAppRoles prepares the app roles for an application to be updated. Existing roles which are to be
+// removed, or whose definition is changing, must first be disabled. Unchanged roles are left untouched so that they
+// remain enabled throughout. The app roles to be written by the subsequent update are returned, or nil when there are
+// no changes and the app roles should be omitted from the update.
+func applicationDisableAppRoles(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, newRoles *[]msgraph.AppRole) (*[]msgraph.AppRole, error) {
+	if application.ID == nil {
+		return nil, fmt.Errorf("cannot use Application model with nil ID")
+	}
+
+	if newRoles == nil {
+		newRoles = &[]msgraph.AppRole{}
+	}
+
+	app, status, err := client.Get(ctx, *application.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("application with ID %q was not found", *application.ID)
 		}
+
+		return nil, fmt.Errorf("retrieving Application with object ID %q: %+v", *application.ID, err)
+	}
+
+	var existingRoles applicationAppRoles
+	if app.AppRoles != nil {
+		existingRoles = *app.AppRoles
+	}
+
+	delta, err := applicationDeltaFor(existingRoles, applicationAppRoles(*newRoles))
+	if err != nil {
+		return nil, err
 	}
 
-	if disable {
+	// Don't update if no changes to be made
+	if !delta.hasChanges() {
+		return nil, nil
+	}
+
+	if disable := delta.disable(existingRoles); len(disable) > 0 {
 		// Disable any changed or removed roles
+		disabledRoles := existingRoles.disabled(disable)
 		properties := msgraph.Application{
 			ID:       application.ID,
-			AppRoles: &existingRoles,
+			AppRoles: &disabledRoles,
 		}
 		if _, err := client.Update(ctx, properties); err != nil {
-			return fmt.Errorf("disabling App Roles for Application with object ID %q: %+v", *application.ID, err)
+			return nil, fmt.Errorf("disabling App Roles for Application with object ID %q: %+v", *application.ID, err)
 		}
 
 		// Wait for application manifest to reflect the disabled roles
 		deadline, ok := ctx.Deadline()
 		if !ok {
-			return fmt.Errorf("context has no deadline")
+			return nil, fmt.Errorf("context has no deadline")
 		}
 		timeout := time.Until(deadline)
 		_, err = (&resource.StateChangeConf{
@@ -106,11 +489,11 @@ func applicationDisableAppRoles(ctx context.Context, client *msgraph.Application
 					return nil, "Error", fmt.Errorf("reading roles for Application with object ID %q: %+v", *application.ID, err)
 				}
 				actualRoles := *app.AppRoles
-				for _, expectedRole := range existingRoles {
-					if expectedRole.IsEnabled != nil && !*expectedRole.IsEnabled {
+				for _, expectedRole := range disabledRoles {
+					if !boolValue(expectedRole.IsEnabled) {
 						for _, actualRole := range actualRoles {
 							if expectedRole.ID != nil && actualRole.ID != nil && *expectedRole.ID == *actualRole.ID {
-								if actualRole.IsEnabled != nil && *actualRole.IsEnabled {
+								if boolValue(actualRole.IsEnabled) {
 									return actualRoles, "Waiting", nil
 								}
 								break
@@ -122,16 +505,20 @@ func applicationDisableAppRoles(ctx context.Context, client *msgraph.Application
 			},
 		}).WaitForStateContext(ctx)
 		if err != nil {
-			return fmt.Errorf("waiting for App Roles to be disabled for Application with object ID %q: %+v", *application.ID, err)
+			return nil, fmt.Errorf("waiting for App Roles to be disabled for Application with object ID %q: %+v", *application.ID, err)
 		}
 	}
 
-	return nil
+	roles := existingRoles.merged(*newRoles, delta.merge(existingRoles))
+	return &roles, nil
 }
 
-func applicationDisableOauth2PermissionScopes(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, newScopes *[]msgraph.PermissionScope) error {
+// applicationDisableOauth2PermissionScopes prepares the permission scopes for an application to be updated, in the same
+// way as applicationDisableAppRoles. The scopes to be written by the subsequent update are returned, or nil when there
+// are no changes and the scopes should be omitted from the update.
+func applicationDisableOauth2PermissionScopes(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, newScopes *[]msgraph.PermissionScope) (*[]msgraph.PermissionScope, error) {
 	if application.ID == nil {
-		return fmt.Errorf("Cannot use Application model with nil ID")
+		return nil, fmt.Errorf("Cannot use Application model with nil ID")
 	}
 
 	if newScopes == nil {
@@ -141,70 +528,44 @@ func applicationDisableOauth2PermissionScopes(ctx context.Context, client *msgra
 	app, status, err := client.Get(ctx, *application.ID)
 	if err != nil {
 		if status == http.StatusNotFound {
-			return fmt.Errorf("application with ID %q was not found", *application.ID)
+			return nil, fmt.Errorf("application with ID %q was not found", *application.ID)
 		}
 
-		return fmt.Errorf("retrieving Application with object ID %q: %+v", *application.ID, err)
+		return nil, fmt.Errorf("retrieving Application with object ID %q: %+v", *application.ID, err)
 	}
 
-	var existingScopes []msgraph.PermissionScope
+	var existingScopes applicationPermissionScopes
 	if app.Api != nil && app.Api.OAuth2PermissionScopes != nil {
 		existingScopes = *app.Api.OAuth2PermissionScopes
 	}
 
-	// Don't update if no changes to be made
-	if reflect.DeepEqual(existingScopes, *newScopes) {
-		return nil
-	}
-
-	// Identify any scopes to be changed
-	var disable bool
-	for _, new := range *newScopes {
-		if new.ID == nil || *new.ID == "" {
-			return fmt.Errorf("new scope provided with nil or empty ID")
-		}
-		for i, existing := range existingScopes {
-			if existing.ID != nil && *existing.ID == *new.ID {
-				if existing.IsEnabled != nil && *existing.IsEnabled && !reflect.DeepEqual(existing, new) {
-					*existingScopes[i].IsEnabled = false
-					disable = true
-				}
-				break
-			}
-		}
+	delta, err := applicationDeltaFor(existingScopes, applicationPermissionScopes(*newScopes))
+	if err != nil {
+		return nil, err
 	}
 
-	// Identify any scopes to be removed
-	for i, existing := range existingScopes {
-		found := false
-		for _, new := range *newScopes {
-			if existing.ID != nil && *new.ID == *existing.ID {
-				found = true
-				break
-			}
-		}
-		if !found {
-			*existingScopes[i].IsEnabled = false
-			disable = true
-		}
+	// Don't update if no changes to be made
+	if !delta.hasChanges() {
+		return nil, nil
 	}
 
-	if disable {
+	if disable := delta.disable(existingScopes); len(disable) > 0 {
 		// Disable any changed or removed scopes
+		disabledScopes := existingScopes.disabled(disable)
 		properties := msgraph.Application{
 			ID: application.ID,
 			Api: &msgraph.ApplicationApi{
-				OAuth2PermissionScopes: &existingScopes,
+				OAuth2PermissionScopes: &disabledScopes,
 			},
 		}
 		if _, err := client.Update(ctx, properties); err != nil {
-			return fmt.Errorf("disabling OAuth2 Permission Scopes for Application with object ID %q: %+v", *application.ID, err)
+			return nil, fmt.Errorf("disabling OAuth2 Permission Scopes for Application with object ID %q: %+v", *application.ID, err)
 		}
 
 		// Wait for application manifest to reflect the disabled scopes
 		deadline, ok := ctx.Deadline()
 		if !ok {
-			return fmt.Errorf("context has no deadline")
+			return nil, fmt.Errorf("context has no deadline")
 		}
 		timeout := time.Until(deadline)
 		_, err = (&resource.StateChangeConf{
@@ -221,11 +582,11 @@ func applicationDisableOauth2PermissionScopes(ctx context.Context, client *msgra
 					return nil, "Error", fmt.Errorf("reading scopes for Application with object ID %q: %+v", *application.ID, err)
 				}
 				actualScopes := *app.Api.OAuth2PermissionScopes
-				for _, expectedScope := range existingScopes {
-					if expectedScope.IsEnabled != nil && !*expectedScope.IsEnabled {
+				for _, expectedScope := range disabledScopes {
+					if !boolValue(expectedScope.IsEnabled) {
 						for _, actualScope := range actualScopes {
 							if expectedScope.ID != nil && actualScope.ID != nil && *expectedScope.ID == *actualScope.ID {
-								if actualScope.IsEnabled != nil && *actualScope.IsEnabled {
+								if boolValue(actualScope.IsEnabled) {
 									return actualScopes, "Waiting", nil
 								}
 								break
@@ -237,69 +598,262 @@ func applicationDisableOauth2PermissionScopes(ctx context.Context, client *msgra
 			},
 		}).WaitForStateContext(ctx)
 		if err != nil {
-			return fmt.Errorf("waiting for OAuth2 Permission Scopes to be disabled for Application with object ID %q: %+v", *application.ID, err)
+			return nil, fmt.Errorf("waiting for OAuth2 Permission Scopes to be disabled for Application with object ID %q: %+v", *application.ID, err)
 		}
 	}
 
-	return nil
+	scopes := existingScopes.merged(*newScopes, delta.merge(existingScopes))
+	return &scopes, nil
 }
 
 func ApplicationFindAppRole(app *msgraph.Application, roleId string) (*msgraph.AppRole, error) {
-	if app == nil || app.AppRoles == nil {
-		return nil, nil
-	}
 	if roleId == "" {
 		return nil, fmt.Errorf("specified role ID is empty")
 	}
-	for _, r := range *app.AppRoles {
-		if r.ID == nil {
-			continue
+	roles := applicationFindAppRoles(app, func(r msgraph.AppRole) bool {
+		return r.ID != nil && *r.ID == roleId
+	})
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	return &roles[0], nil
+}
+
+// ApplicationFindAppRolesByValue returns the app roles for an application matching either the value or the display
+// name. Values are compared case-sensitively, since they are emitted as-is in the roles claim of issued tokens, whereas
+// display names are compared case-insensitively. Disabled roles are included.
+func ApplicationFindAppRolesByValue(app *msgraph.Application, value, displayName string) ([]msgraph.AppRole, error) {
+	if value == "" && displayName == "" {
+		return nil, fmt.Errorf("one of value or display name must be specified")
+	}
+	return applicationFindAppRoles(app, func(r msgraph.AppRole) bool {
+		if value != "" {
+			return r.Value != nil && *r.Value == value
 		}
-		if *r.ID == roleId {
-			return &r, nil
+		return r.DisplayName != nil && strings.EqualFold(*r.DisplayName, displayName)
+	}), nil
+}
+
+// applicationFindAppRoles returns the app roles for an application which satisfy match
+func applicationFindAppRoles(app *msgraph.Application, match func(msgraph.AppRole) bool) []msgraph.AppRole {
+	result := make([]msgraph.AppRole, 0)
+	if app == nil || app.AppRoles == nil {
+		return result
+	}
+	for _, r := range *app.AppRoles {
+		if match(r) {
+			result = append(result, r)
 		}
 	}
-	return nil, nil
+	return result
 }
 
 func ApplicationFindOAuth2PermissionScope(app *msgraph.Application, scopeId string) (*msgraph.PermissionScope, error) {
-	if app == nil || app.Api == nil || app.Api.OAuth2PermissionScopes == nil {
-		return nil, nil
-	}
 	if scopeId == "" {
 		return nil, fmt.Errorf("specified scope ID is empty")
 	}
+	scopes := applicationFindOAuth2PermissionScopes(app, func(s msgraph.PermissionScope) bool {
+		return s.ID != nil && *s.ID == scopeId
+	})
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+	return &scopes[0], nil
+}
+
+// ApplicationFindOAuth2PermissionScopesByValue returns the permission scopes for an application matching either the
+// value or the admin consent display name, in the same way as ApplicationFindAppRolesByValue
+func ApplicationFindOAuth2PermissionScopesByValue(app *msgraph.Application, value, displayName string) ([]msgraph.PermissionScope, error) {
+	if value == "" && displayName == "" {
+		return nil, fmt.Errorf("one of value or display name must be specified")
+	}
+	return applicationFindOAuth2PermissionScopes(app, func(s msgraph.PermissionScope) bool {
+		if value != "" {
+			return s.Value != nil && *s.Value == value
+		}
+		return s.AdminConsentDisplayName != nil && strings.EqualFold(*s.AdminConsentDisplayName, displayName)
+	}), nil
+}
+
+// applicationFindOAuth2PermissionScopes returns the permission scopes for an application which satisfy match
+func applicationFindOAuth2PermissionScopes(app *msgraph.Application, match func(msgraph.PermissionScope) bool) []msgraph.PermissionScope {
+	result := make([]msgraph.PermissionScope, 0)
+	if app == nil || app.Api == nil || app.Api.OAuth2PermissionScopes == nil {
+		return result
+	}
 	for _, s := range *app.Api.OAuth2PermissionScopes {
-		if s.ID == nil {
-			continue
+		if match(s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// applicationDataSourceGet retrieves the application specified by either the `application_object_id` argument, or the
+// aliased `application_id` and `client_id` arguments, of a data source
+func applicationDataSourceGet(ctx context.Context, client *msgraph.ApplicationsClient, d *schema.ResourceData) (*msgraph.Application, diag.Diagnostics) {
+	objectId := d.Get("application_object_id").(string)
+
+	applicationId, applicationIdKey, err := tf.GetAliasedString(d, "application_id", "client_id")
+	if err != nil {
+		return nil, tf.ErrorDiagPathF(err, "client_id", "Conflicting arguments")
+	}
+
+	if objectId == "" && applicationId != "" {
+		filter := helpers.ODataEq("appId", applicationId)
+		result, _, err := client.List(ctx, filter)
+		if err != nil {
+			return nil, tf.ErrorDiagPathF(err, applicationIdKey, "Listing applications for filter %q", filter)
 		}
-		if *s.ID == scopeId {
-			return &s, nil
+
+		switch {
+		case result == nil || len(*result) == 0:
+			return nil, tf.ErrorDiagPathF(fmt.Errorf("No applications found matching filter: %q", filter), applicationIdKey, "Application not found")
+		case len(*result) > 1:
+			return nil, tf.ErrorDiagPathF(fmt.Errorf("Found multiple applications matching filter: %q", filter), applicationIdKey, "Multiple applications found")
+		}
+
+		app := (*result)[0]
+		if app.ID == nil {
+			return nil, tf.ErrorDiagF(errors.New("Object ID returned for application is nil"), "Bad API Response")
+		}
+		objectId = *app.ID
+	}
+
+	app, status, err := client.Get(ctx, objectId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, tf.ErrorDiagPathF(nil, "application_object_id", "Application with object ID %q was not found", objectId)
 		}
+		return nil, tf.ErrorDiagPathF(err, "application_object_id", "Retrieving Application with object ID %q", objectId)
 	}
-	return nil, nil
+	if app.ID == nil {
+		return nil, tf.ErrorDiagF(errors.New("Object ID returned for application is nil"), "Bad API Response")
+	}
+	if app.AppId == nil {
+		return nil, tf.ErrorDiagF(errors.New("Application ID returned for application is nil"), "Bad API Response")
+	}
+
+	return app, nil
 }
 
-func applicationFindByName(ctx context.Context, client *msgraph.ApplicationsClient, displayName string) (*[]msgraph.Application, error) {
-	filter := fmt.Sprintf("displayName eq '%s'", displayName)
+// applicationListByFilter returns the applications matching filter. When a `$count` query finds matching applications,
+// they are retrieved with an advanced query, otherwise a regular list is used so that recently created applications are
+// not missed.
+func applicationListByFilter(ctx context.Context, client *msgraph.ApplicationsClient, filter string) ([]msgraph.Application, error) {
+	result := make([]msgraph.Application, 0)
+
+	found, err := common.AdvancedQueryListExisting(ctx, client.BaseClient, "/applications", common.AdvancedQuery{Filter: filter}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to count Applications with filter %q: %+v", filter, err)
+	}
+	if found {
+		return result, nil
+	}
+
 	apps, _, err := client.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list Applications with filter %q: %+v", filter, err)
 	}
-
-	result := make([]msgraph.Application, 0)
 	if apps != nil {
-		for _, app := range *apps {
-			if app.DisplayName != nil && *app.DisplayName == displayName {
-				result = append(result, app)
+		result = append(result, *apps...)
+	}
+
+	return result, nil
+}
+
+// applicationListPageSize is the largest page size supported when listing applications
+const applicationListPageSize = 999
+
+// applicationServicePrincipalLookupBatchSize is the maximum number of values permitted in an `in` filter expression
+const applicationServicePrincipalLookupBatchSize = 15
+
+// applicationListWithLimit returns up to limit applications matching filter, retrieving no more pages than necessary,
+// and whether there were more matching applications than the limit
+func applicationListWithLimit(ctx context.Context, client *msgraph.ApplicationsClient, filter string, limit int) ([]msgraph.Application, bool, error) {
+	query := common.AdvancedQuery{
+		Filter: filter,
+		Top:    minInt(limit+1, applicationListPageSize),
+		Limit:  limit,
+	}
+
+	apps := make([]msgraph.Application, 0)
+	if _, err := common.AdvancedQueryList(ctx, client.BaseClient, "/applications", query, &apps); err != nil {
+		return nil, false, fmt.Errorf("unable to list Applications with filter %q: %+v", filter, err)
+	}
+
+	if len(apps) > limit {
+		return apps[:limit], true, nil
+	}
+	return apps, false, nil
+}
+
+// applicationServicePrincipalIds returns the object IDs of the service principals in the home tenant for the specified
+// application IDs, keyed by the lowercased application ID. Applications without a service principal are omitted.
+func applicationServicePrincipalIds(ctx context.Context, client *msgraph.ServicePrincipalsClient, appIds []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for start := 0; start < len(appIds); start += applicationServicePrincipalLookupBatchSize {
+		end := minInt(start+applicationServicePrincipalLookupBatchSize, len(appIds))
+
+		servicePrincipals, _, err := client.List(ctx, helpers.ODataIn("appId", appIds[start:end]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list Service Principals: %+v", err)
+		}
+		if servicePrincipals == nil {
+			continue
+		}
+		for _, sp := range *servicePrincipals {
+			if sp.ID != nil && sp.AppId != nil {
+				result[strings.ToLower(*sp.AppId)] = *sp.ID
 			}
 		}
 	}
+	return result, nil
+}
+
+// applicationDuplicateNameList returns a function which lists applications for a duplicate name check
+func applicationDuplicateNameList(client *msgraph.ApplicationsClient) helpers.DuplicateNameListFunc {
+	return func(ctx context.Context, filter string) ([]helpers.DuplicateNameObject, error) {
+		apps, err := applicationListByFilter(ctx, client, filter)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]helpers.DuplicateNameObject, 0, len(apps))
+		for _, app := range apps {
+			result = append(result, helpers.DuplicateNameObject{ID: app.ID, Name: app.DisplayName})
+		}
+		return result, nil
+	}
+}
+
+// applicationGetByUniqueName retrieves an application using its uniqueName alternate key
+func applicationGetByUniqueName(ctx context.Context, client *msgraph.ApplicationsClient, uniqueName string) (*msgraph.Application, int, error) {
+	resp, status, _, err := client.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications(uniqueName=%s)", helpers.ODataString(uniqueName)),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var app msgraph.Application
+	if err := json.Unmarshal(respBody, &app); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
 
-	return &result, nil
+	return &app, status, nil
 }
 
-func applicationSetOwners(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, desiredOwners []string) error {
+func applicationSetOwners(ctx context.Context, client *msgraph.ApplicationsClient, application *msgraph.Application, desiredOwners []string, claims auth.Claims) error {
 	if application.ID == nil {
 		return fmt.Errorf("Cannot use Application model with nil ID")
 	}
@@ -313,22 +867,131 @@ func applicationSetOwners(ctx context.Context, client *msgraph.ApplicationsClien
 	ownersForRemoval := utils.Difference(existingOwners, desiredOwners)
 	ownersToAdd := utils.Difference(desiredOwners, existingOwners)
 
+	// Add new owners before removing old ones, so the application never transiently has no owners
 	if ownersToAdd != nil {
 		for _, m := range ownersToAdd {
 			application.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, m)
 		}
 
-		if _, err := client.AddOwners(ctx, application); err != nil {
+		if status, err := client.AddOwners(ctx, application); err != nil {
+			err = helpers.GraphError(err)
+			err = helpers.PermissionsError(err, status, helpers.PermissionsOperationApplicationOwnerAdd, claims)
 			return fmt.Errorf("adding owners to Application with object ID %q: %+v", *application.ID, err)
 		}
 	}
 
 	if ownersForRemoval != nil {
-		if _, err = client.RemoveOwners(ctx, *application.ID, &ownersForRemoval); err != nil {
-			return fmt.Errorf("removing owner from Application with object ID %q: %+v", *application.ID, err)
+		if err := helpers.RemoveReferences(ctx, "owner", ownersForRemoval, func(ctx context.Context, ids []string) (int, error) {
+			return client.RemoveOwners(ctx, *application.ID, &ids)
+		}); err != nil {
+			return fmt.Errorf("removing owners from Application with object ID %q: %+v", *application.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// applicationExpiredCredentialKeyIds returns the key IDs of the password and certificate credentials for an application
+// which expired longer ago than the specified grace period. Credentials without an end date are never considered expired,
+// and credentials with a retained key ID, such as those managed by credential resources, are never returned.
+func applicationExpiredCredentialKeyIds(app *msgraph.Application, gracePeriod time.Duration, now time.Time, retainedKeyIds []string) (passwords []string, keys []string) {
+	cutoff := now.Add(-gracePeriod)
+
+	retained := make(map[string]bool, len(retainedKeyIds))
+	for _, keyId := range retainedKeyIds {
+		retained[strings.ToLower(keyId)] = true
+	}
+	expired := func(keyId *string, endDateTime *time.Time) bool {
+		return keyId != nil && !retained[strings.ToLower(*keyId)] && endDateTime != nil && endDateTime.Before(cutoff)
+	}
+
+	if app.PasswordCredentials != nil {
+		for _, cred := range *app.PasswordCredentials {
+			if expired(cred.KeyId, cred.EndDateTime) {
+				passwords = append(passwords, *cred.KeyId)
+			}
+		}
+	}
+
+	if app.KeyCredentials != nil {
+		for _, cred := range *app.KeyCredentials {
+			if expired(cred.KeyId, cred.EndDateTime) {
+				keys = append(keys, *cred.KeyId)
+			}
+		}
+	}
+
+	return
+}
+
+// applicationRemoveExpiredCredentials removes any password and certificate credentials from an application which expired
+// longer ago than the specified grace period, other than those with a retained key ID, returning the key IDs of the
+// removed credentials. The application is retrieved afresh so that credentials which were renewed since the last refresh
+// are not removed.
+func applicationRemoveExpiredCredentials(ctx context.Context, client *msgraph.ApplicationsClient, applicationId string, gracePeriod time.Duration, retainedKeyIds []string) ([]string, error) {
+	app, _, err := client.Get(ctx, applicationId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Application with object ID %q: %+v", applicationId, err)
+	}
+
+	removed := make([]string, 0)
+	passwords, keys := applicationExpiredCredentialKeyIds(app, gracePeriod, time.Now(), retainedKeyIds)
+
+	for _, keyId := range passwords {
+		if _, err := client.RemovePassword(ctx, applicationId, keyId); err != nil {
+			return removed, fmt.Errorf("removing expired password credential %q: %+v", keyId, err)
+		}
+		removed = append(removed, keyId)
+	}
+
+	if len(keys) > 0 {
+		expired := make(map[string]bool, len(keys))
+		for _, keyId := range keys {
+			expired[keyId] = true
+		}
+
+		newCredentials := make([]msgraph.KeyCredential, 0)
+		for _, cred := range *app.KeyCredentials {
+			if cred.KeyId != nil && !expired[*cred.KeyId] {
+				newCredentials = append(newCredentials, cred)
+			}
+		}
+
+		properties := msgraph.Application{
+			ID:             utils.String(applicationId),
+			KeyCredentials: &newCredentials,
+		}
+		if _, err := client.Update(ctx, properties); err != nil {
+			return removed, fmt.Errorf("removing expired certificate credentials %s: %+v", strings.Join(keys, ", "), err)
+		}
+		removed = append(removed, keys...)
+	}
+
+	return removed, nil
+}
+
+// applicationRedirectUriMaxCount is the maximum number of redirect URIs permitted across all platforms for an application
+const applicationRedirectUriMaxCount = 256
+
+// applicationValidateRedirectUriCount checks that the total number of redirect URIs does not exceed the limit enforced
+// by the API, which would otherwise only be reported partway through an apply
+func applicationValidateRedirectUriCount(platforms ...[]interface{}) error {
+	count := 0
+	for _, platform := range platforms {
+		for _, p := range platform {
+			if p == nil {
+				continue
+			}
+			if v, ok := p.(map[string]interface{})["redirect_uris"].(*schema.Set); ok && v != nil {
+				count += v.Len()
+			}
 		}
 	}
 
+	if count > applicationRedirectUriMaxCount {
+		return fmt.Errorf("a maximum of %d redirect URIs are supported for an application, but %d were specified", applicationRedirectUriMaxCount, count)
+	}
+
 	return nil
 }
 
@@ -362,20 +1025,197 @@ func applicationValidateRolesScopes(appRoles, oauth2Permissions []interface{}) e
 	return nil
 }
 
+// applicationVerifiedPublisherHints explains the unmet prerequisite for each error code returned when setting the
+// verified publisher of an application
+var applicationVerifiedPublisherHints = map[string]string{
+	"B2CTenantNotAllowed":                   "Publisher verification is not supported in Azure AD B2C tenants.",
+	"EmailVerifiedTenantNotAllowed":         "Publisher verification is not supported in unmanaged (email verified) tenants.",
+	"InteractionRequired":                   "Multi-factor authentication is required to set the verified publisher. Authenticate as a user who has completed multi-factor authentication.",
+	"MPNAccountInvalid":                     "The specified MPN ID is not a valid Microsoft Partner Network account.",
+	"MPNAccountNotFoundOrNoAccess":          "The specified MPN ID does not exist, or the authenticated principal does not have access to it. Check the MPN ID, and that the principal has a role in the Microsoft Partner Network account.",
+	"MPNAccountNotVetted":                   "The Microsoft Partner Network account has not completed the vetting process. Complete vetting in Partner Center before setting the verified publisher.",
+	"MPNGlobalAccountNotFound":              "The specified MPN ID is not a Partner Global Account (PGA). Specify the MPN ID of the Partner Global Account rather than a Partner Location Account.",
+	"MPNIdDoesNotMatchAssociatedMPNAccount": "The specified MPN ID does not match the Microsoft Partner Network account associated with the publisher domain.",
+	"MSANotSupported":                       "Personal Microsoft accounts cannot be used to set the verified publisher. Authenticate using an organizational account.",
+	"NoPublisherDomainOnApplication":        "The application does not have a publisher domain. Configure a verified publisher domain for the application before setting the verified publisher.",
+	"NoPublisherIdOnAssociatedMPNAccount":   "The specified MPN ID is not a Partner Global Account (PGA). Specify the MPN ID of the Partner Global Account.",
+	"NotAuthorizedToVerifyPublisher":        "The authenticated principal is not authorized to set the verified publisher. A role such as Application Administrator is required in Azure AD, along with a role such as MPN Admin or Accounts Admin in the Microsoft Partner Network account.",
+	"PublisherDomainMismatch":               "The publisher domain of the application does not match a domain verified for the Microsoft Partner Network account. The domain of the email address used for the MPN account must be verified in the tenant and set as the publisher domain.",
+}
+
+// applicationVerifiedPublisherError annotates a failure to set or unset the verified publisher of an application with
+// an explanation of the unmet prerequisite, where the error code is recognised
+func applicationVerifiedPublisherError(err error) error {
+	if e, ok := err.(client.VerifiedPublisherError); ok {
+		if hint, ok := applicationVerifiedPublisherHints[e.Code]; ok {
+			return fmt.Errorf("%v\n\n%s", err, hint)
+		}
+	}
+	return err
+}
+
+// applicationServicePrincipalLookup retrieves the service principal for an application by its client ID, returning nil
+// when the application has no service principal in the tenant
+type applicationServicePrincipalLookup func(ctx context.Context, appId string) (*msgraph.ServicePrincipal, error)
+
+// applicationPermission is an app role or OAuth2 permission scope published by a resource API
+type applicationPermission struct {
+	id      string
+	value   string
+	enabled bool
+}
+
+// applicationValidateResourceAccess checks that every app role and permission scope requested in requiredResourceAccess
+// is published and enabled by the service principal of the resource API. All invalid permissions are reported together,
+// along with the closest matching valid permission, to help spot a mistyped ID.
+func applicationValidateResourceAccess(ctx context.Context, lookup applicationServicePrincipalLookup, requiredResourceAccess *[]msgraph.RequiredResourceAccess) error {
+	if requiredResourceAccess == nil {
+		return nil
+	}
+
+	problems := make([]string, 0)
+	for _, rra := range *requiredResourceAccess {
+		if rra.ResourceAppId == nil || rra.ResourceAccess == nil {
+			continue
+		}
+		resourceAppId := *rra.ResourceAppId
+
+		sp, err := lookup(ctx, resourceAppId)
+		if err != nil {
+			return fmt.Errorf("retrieving service principal for resource_app_id %q: %v", resourceAppId, err)
+		}
+		if sp == nil {
+			problems = append(problems, fmt.Sprintf("no service principal was found for resource_app_id %q, so its permissions cannot be validated", resourceAppId))
+			continue
+		}
+
+		roles := make([]applicationPermission, 0)
+		if sp.AppRoles != nil {
+			for _, role := range *sp.AppRoles {
+				roles = append(roles, applicationPermission{id: stringValue(role.ID), value: stringValue(role.Value), enabled: boolValue(role.IsEnabled)})
+			}
+		}
+		scopes := make([]applicationPermission, 0)
+		if sp.PublishedPermissionScopes != nil {
+			for _, scope := range *sp.PublishedPermissionScopes {
+				scopes = append(scopes, applicationPermission{id: stringValue(scope.ID), value: stringValue(scope.Value), enabled: boolValue(scope.IsEnabled)})
+			}
+		}
+
+		apiName := fmt.Sprintf("%q (resource_app_id %q)", stringValue(sp.DisplayName), resourceAppId)
+
+		for _, access := range *rra.ResourceAccess {
+			id := stringValue(access.ID)
+
+			wanted, other := roles, scopes
+			wantedKind, otherKind := "app role", "OAuth2 permission scope"
+			if access.Type == msgraph.ResourceAccessTypeScope {
+				wanted, other = scopes, roles
+				wantedKind, otherKind = otherKind, wantedKind
+			}
+
+			if p := applicationFindPermission(wanted, id); p != nil {
+				if !p.enabled {
+					problems = append(problems, fmt.Sprintf("the %s %q (%s) published by %s is disabled", wantedKind, id, p.value, apiName))
+				}
+				continue
+			}
+
+			if p := applicationFindPermission(other, id); p != nil {
+				problems = append(problems, fmt.Sprintf("%q is an %s (%s) published by %s, but was requested with type %q", id, otherKind, p.value, apiName, access.Type))
+				continue
+			}
+
+			problem := fmt.Sprintf("no %s with ID %q is published by %s", wantedKind, id, apiName)
+			if closest := applicationClosestPermission(wanted, id); closest != nil {
+				problem = fmt.Sprintf("%s, the closest match is %q (%s)", problem, closest.id, closest.value)
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid permissions in required_resource_access:\n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func applicationFindPermission(permissions []applicationPermission, id string) *applicationPermission {
+	for i := range permissions {
+		if strings.EqualFold(permissions[i].id, id) {
+			return &permissions[i]
+		}
+	}
+	return nil
+}
+
+// applicationClosestPermission returns the enabled permission with the ID most similar to the specified ID
+func applicationClosestPermission(permissions []applicationPermission, id string) *applicationPermission {
+	var closest *applicationPermission
+	closestDistance := 0
+	for i := range permissions {
+		if !permissions[i].enabled {
+			continue
+		}
+		distance := levenshteinDistance(strings.ToLower(permissions[i].id), strings.ToLower(id))
+		if closest == nil || distance < closestDistance {
+			closest = &permissions[i]
+			closestDistance = distance
+		}
+	}
+	return closest
+}
+
+// levenshteinDistance returns the number of single character edits required to change a into b
+func levenshteinDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
+
 func expandApplicationApi(input []interface{}) *msgraph.ApplicationApi {
+	knownClientApplications := &[]string{}
 	oauth2PermissionScopes := &[]msgraph.PermissionScope{}
+	var requestedAccessTokenVersion *int32
 
-	if len(input) > 0 {
+	if len(input) > 0 && input[0] != nil {
 		in := input[0].(map[string]interface{})
+		knownClientApplications = tf.ExpandStringSlicePtr(in["known_client_applications"].(*schema.Set).List())
 		oauth2PermissionScopes = expandApplicationOAuth2PermissionScope(in["oauth2_permission_scope"].(*schema.Set).List())
+		requestedAccessTokenVersion = utils.Int32(int32(in["requested_access_token_version"].(int)))
 	}
 
 	return &msgraph.ApplicationApi{
 		AcceptMappedClaims:          nil,
-		KnownClientApplications:     nil,
+		KnownClientApplications:     knownClientApplications,
 		OAuth2PermissionScopes:      oauth2PermissionScopes,
 		PreAuthorizedApplications:   nil,
-		RequestedAccessTokenVersion: nil,
+		RequestedAccessTokenVersion: requestedAccessTokenVersion,
 	}
 }
 
@@ -426,7 +1266,7 @@ func expandApplicationGroupMembershipClaims(in []interface{}) *[]msgraph.GroupMe
 func expandApplicationImplicitGrantSettings(input []interface{}) *msgraph.ImplicitGrantSettings {
 	var enableAccessTokenIssuance, enableIdTokenIssuance bool
 
-	if input != nil || len(input) > 0 {
+	if len(input) > 0 && input[0] != nil {
 		in := input[0].(map[string]interface{})
 		enableAccessTokenIssuance = in["access_token_issuance_enabled"].(bool)
 		enableIdTokenIssuance = in["id_token_issuance_enabled"].(bool)
@@ -462,7 +1302,13 @@ func expandApplicationOAuth2PermissionScope(in []interface{}) *[]msgraph.Permiss
 }
 
 func expandApplicationOptionalClaims(in []interface{}) *msgraph.OptionalClaims {
-	result := msgraph.OptionalClaims{}
+	// Empty lists must be sent for each token type in order to remove existing claims, since omitted token types are
+	// left unchanged by the API
+	result := msgraph.OptionalClaims{
+		AccessToken: &[]msgraph.OptionalClaim{},
+		IdToken:     &[]msgraph.OptionalClaim{},
+		Saml2Token:  &[]msgraph.OptionalClaim{},
+	}
 
 	if len(in) == 0 || in[0] == nil {
 		return &result
@@ -506,6 +1352,19 @@ func expandApplicationOptionalClaim(in []interface{}) *[]msgraph.OptionalClaim {
 	return &result
 }
 
+func expandApplicationPublicClient(input []interface{}) *msgraph.PublicClient {
+	redirectUris := &[]string{}
+
+	if len(input) > 0 && input[0] != nil {
+		in := input[0].(map[string]interface{})
+		redirectUris = tf.ExpandStringSlicePtr(in["redirect_uris"].(*schema.Set).List())
+	}
+
+	return &msgraph.PublicClient{
+		RedirectUris: redirectUris,
+	}
+}
+
 func expandApplicationRequiredResourceAccess(in []interface{}) *[]msgraph.RequiredResourceAccess {
 	result := make([]msgraph.RequiredResourceAccess, 0)
 
@@ -538,6 +1397,36 @@ func expandApplicationResourceAccess(in []interface{}) *[]msgraph.ResourceAccess
 	return &result
 }
 
+func expandApplicationOnPremisesPublishing(input []interface{}) *client.OnPremisesPublishing {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	in := input[0].(map[string]interface{})
+
+	return &client.OnPremisesPublishing{
+		ExternalAuthenticationType:   utils.String(in["external_authentication_type"].(string)),
+		ExternalUrl:                  utils.String(in["external_url"].(string)),
+		InternalUrl:                  utils.String(in["internal_url"].(string)),
+		IsHttpOnlyCookieEnabled:      utils.Bool(in["http_only_cookie_enabled"].(bool)),
+		IsPersistentCookieEnabled:    utils.Bool(in["persistent_cookie_enabled"].(bool)),
+		IsTranslateHostHeaderEnabled: utils.Bool(in["translate_host_header_enabled"].(bool)),
+	}
+}
+
+func expandApplicationSpa(input []interface{}) client.ApplicationSpa {
+	redirectUris := &[]string{}
+
+	if len(input) > 0 && input[0] != nil {
+		in := input[0].(map[string]interface{})
+		redirectUris = tf.ExpandStringSlicePtr(in["redirect_uris"].(*schema.Set).List())
+	}
+
+	return client.ApplicationSpa{
+		RedirectUris: redirectUris,
+	}
+}
+
 func expandApplicationWeb(input []interface{}) *msgraph.ApplicationWeb {
 	var homepageUrl msgraph.StringNullWhenEmpty
 	var logoutUrl msgraph.StringNullWhenEmpty
@@ -561,7 +1450,7 @@ func expandApplicationWeb(input []interface{}) *msgraph.ApplicationWeb {
 	}
 }
 
-func flattenApplicationApi(in *msgraph.ApplicationApi, dataSource bool) (result []map[string]interface{}) {
+func flattenApplicationApi(in *msgraph.ApplicationApi, dataSource bool, apiConfigured bool) (result []map[string]interface{}) {
 	if in == nil {
 		return
 	}
@@ -573,10 +1462,19 @@ func flattenApplicationApi(in *msgraph.ApplicationApi, dataSource bool) (result
 		scopesKey = "oauth2_permission_scope"
 	}
 	oauth2PermissionScopes := flattenApplicationOAuth2PermissionScopes(in.OAuth2PermissionScopes)
+	knownClientApplications := tf.FlattenStringSlicePtr(in.KnownClientApplications)
+
+	// The API does not return a token version until one has been set, in which case v1 tokens are issued
+	requestedAccessTokenVersion := 1
+	if in.RequestedAccessTokenVersion != nil {
+		requestedAccessTokenVersion = int(*in.RequestedAccessTokenVersion)
+	}
 
-	if oauth2PermissionScopes != nil {
+	if apiConfigured || oauth2PermissionScopes != nil || len(knownClientApplications) > 0 {
 		result = append(result, map[string]interface{}{
-			scopesKey: oauth2PermissionScopes,
+			"known_client_applications":      knownClientApplications,
+			scopesKey:                        oauth2PermissionScopes,
+			"requested_access_token_version": requestedAccessTokenVersion,
 		})
 	}
 
@@ -587,6 +1485,42 @@ func flattenApplicationAppRoles(in *[]msgraph.AppRole) []map[string]interface{}
 	return helpers.ApplicationFlattenAppRoles(in)
 }
 
+// flattenApplicationFederatedIdentityCredentials flattens federated identity credentials, optionally only including
+// those with the specified display name. This is also applied when the API has already filtered the results, in case
+// the filter was not supported.
+func flattenApplicationFederatedIdentityCredentials(in *[]client.FederatedIdentityCredential, displayName string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, credential := range *in {
+		name := ""
+		if credential.Name != nil {
+			name = *credential.Name
+		}
+		if displayName != "" && name != displayName {
+			continue
+		}
+
+		audiences := make([]string, 0)
+		if credential.Audiences != nil {
+			audiences = *credential.Audiences
+		}
+
+		result = append(result, map[string]interface{}{
+			"audiences":     audiences,
+			"credential_id": credential.ID,
+			"description":   credential.Description,
+			"display_name":  name,
+			"issuer":        credential.Issuer,
+			"subject":       credential.Subject,
+		})
+	}
+
+	return result
+}
+
 func flattenApplicationGroupMembershipClaims(in *[]msgraph.GroupMembershipClaim) []string {
 	if in == nil {
 		return nil
@@ -636,7 +1570,7 @@ func flattenApplicationOptionalClaims(in *msgraph.OptionalClaims) interface{} {
 	idTokenClaims := flattenApplicationOptionalClaim(in.IdToken)
 	saml2TokenClaims := flattenApplicationOptionalClaim(in.Saml2Token)
 
-	if len(accessTokenClaims) == 0 && len(idTokenClaims) == 0 {
+	if len(accessTokenClaims) == 0 && len(idTokenClaims) == 0 && len(saml2TokenClaims) == 0 {
 		return result
 	}
 
@@ -676,6 +1610,20 @@ func flattenApplicationOptionalClaim(in *[]msgraph.OptionalClaim) []interface{}
 	return optionalClaims
 }
 
+func flattenApplicationPublicClient(in *msgraph.PublicClient, publicClientConfigured bool) (result []map[string]interface{}) {
+	if in == nil {
+		return
+	}
+
+	if v := tf.FlattenStringSlice(tf.SortedStringSlice(in.RedirectUris)); publicClientConfigured || len(v) > 0 {
+		result = append(result, map[string]interface{}{
+			"redirect_uris": v,
+		})
+	}
+
+	return
+}
+
 func flattenApplicationRequiredResourceAccess(in *[]msgraph.RequiredResourceAccess) []map[string]interface{} {
 	if in == nil {
 		return []map[string]interface{}{}
@@ -694,6 +1642,11 @@ func flattenApplicationRequiredResourceAccess(in *[]msgraph.RequiredResourceAcce
 		})
 	}
 
+	// Resources are returned in no particular order, the ordering of resource_access within each is left as-is
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i]["resource_app_id"].(string) < result[j]["resource_app_id"].(string)
+	})
+
 	return result
 }
 
@@ -715,6 +1668,59 @@ func flattenApplicationResourceAccess(in *[]msgraph.ResourceAccess) []interface{
 	return accesses
 }
 
+// flattenApplicationOnPremisesPublishing returns an empty result for applications which are not published with
+// Application Proxy, which is the case for the majority of applications
+func flattenApplicationOnPremisesPublishing(in *client.OnPremisesPublishing) []map[string]interface{} {
+	if in == nil || in.ExternalUrl == nil || in.InternalUrl == nil || *in.ExternalUrl == "" || *in.InternalUrl == "" {
+		return []map[string]interface{}{}
+	}
+
+	externalAuthenticationType := ""
+	if in.ExternalAuthenticationType != nil {
+		externalAuthenticationType = *in.ExternalAuthenticationType
+	}
+
+	return []map[string]interface{}{{
+		"external_authentication_type":  externalAuthenticationType,
+		"external_url":                  *in.ExternalUrl,
+		"http_only_cookie_enabled":      in.IsHttpOnlyCookieEnabled != nil && *in.IsHttpOnlyCookieEnabled,
+		"internal_url":                  *in.InternalUrl,
+		"persistent_cookie_enabled":     in.IsPersistentCookieEnabled != nil && *in.IsPersistentCookieEnabled,
+		"translate_host_header_enabled": in.IsTranslateHostHeaderEnabled != nil && *in.IsTranslateHostHeaderEnabled,
+	}}
+}
+
+func flattenApplicationVerifiedPublisher(in *msgraph.VerifiedPublisher) []map[string]interface{} {
+	if in == nil || in.VerifiedPublisherId == nil || *in.VerifiedPublisherId == "" {
+		return []map[string]interface{}{}
+	}
+
+	addedDateTime := ""
+	if in.AddedDateTime != nil {
+		addedDateTime = in.AddedDateTime.Format(time.RFC3339)
+	}
+
+	return []map[string]interface{}{{
+		"added_date_time":       addedDateTime,
+		"display_name":          stringValue(in.DisplayName),
+		"verified_publisher_id": *in.VerifiedPublisherId,
+	}}
+}
+
+func flattenApplicationSpa(in *client.ApplicationSpa, spaConfigured bool) (result []map[string]interface{}) {
+	if in == nil {
+		return
+	}
+
+	if v := tf.FlattenStringSlice(tf.SortedStringSlice(in.RedirectUris)); spaConfigured || len(v) > 0 {
+		result = append(result, map[string]interface{}{
+			"redirect_uris": v,
+		})
+	}
+
+	return
+}
+
 func flattenApplicationWeb(in *msgraph.ApplicationWeb, webConfigured bool, implicitGrantConfigured bool) (result []map[string]interface{}) {
 	if in == nil {
 		return
@@ -728,7 +1734,7 @@ func flattenApplicationWeb(in *msgraph.ApplicationWeb, webConfigured bool, impli
 	if webConfigured || in.LogoutUrl != nil {
 		web["logout_url"] = in.LogoutUrl
 	}
-	if v := tf.FlattenStringSlicePtr(in.RedirectUris); webConfigured || len(v) > 0 {
+	if v := tf.FlattenStringSlice(tf.SortedStringSlice(in.RedirectUris)); webConfigured || len(v) > 0 {
 		web["redirect_uris"] = v
 	}
 	if implicitGrant := flattenApplicationImplicitGrant(in.ImplicitGrantSettings, implicitGrantConfigured); len(implicitGrant) > 0 {
@@ -741,3 +1747,27 @@ func flattenApplicationWeb(in *msgraph.ApplicationWeb, webConfigured bool, impli
 
 	return
 }
+
+// applicationPreAuthorizedAppsWithout returns the pre-authorized applications with the entry for the specified client
+// application removed, and whether such an entry was found.
+func applicationPreAuthorizedAppsWithout(apps []msgraph.ApiPreAuthorizedApplication, appId string) ([]msgraph.ApiPreAuthorizedApplication, bool) {
+	result := make([]msgraph.ApiPreAuthorizedApplication, 0, len(apps))
+	found := false
+	for _, a := range apps {
+		if a.AppId != nil && strings.EqualFold(*a.AppId, appId) {
+			found = true
+			continue
+		}
+		result = append(result, a)
+	}
+	return result, found
+}
+
+func applicationCredentialParent(resourceType, objectId string) helpers.CredentialParent {
+	return helpers.CredentialParent{
+		ResourceType: resourceType,
+		ParentType:   "application",
+		Attr:         "application_object_id",
+		ObjectId:     objectId,
+	}
+}
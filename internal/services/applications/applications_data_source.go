@@ -0,0 +1,240 @@
+package applications
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+// applicationsDataSourceDefaultMaxResults is the default limit for the number of applications returned by the
+// azuread_applications data source, so that large tenants are not listed in full by accident
+const applicationsDataSourceDefaultMaxResults = 1000
+
+func applicationsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: applicationsDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name_prefix": {
+				Description:      "Only return applications whose display name starts with this prefix",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"publisher_domain": {
+				Description:      "Only return applications with this publisher domain",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"sign_in_audience": {
+				Description: "Only return applications with this sign-in audience",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					string(msgraph.SignInAudienceAzureADMyOrg),
+					string(msgraph.SignInAudienceAzureADMultipleOrgs),
+					string(msgraph.SignInAudienceAzureADandPersonalMicrosoftAccount),
+					string(msgraph.SignInAudiencePersonalMicrosoftAccount),
+				}, false),
+			},
+
+			"max_results": {
+				Description:  "The maximum number of applications to return. When more applications are found, the results are truncated and a warning is emitted",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      applicationsDataSourceDefaultMaxResults,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"application_ids": {
+				Description: "The application IDs (client IDs) of the applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"display_names": {
+				Description: "The display names of the applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"object_ids": {
+				Description: "The object IDs of the applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"service_principal_ids": {
+				Description: "The object IDs of the service principals for the applications, in the home tenant. Applications without a service principal are omitted",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"applications": {
+				Description: "A list of applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_id": {
+							Description: "The application ID (client ID) of the application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"publisher_domain": {
+							Description: "The verified publisher domain for the application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"service_principal_object_id": {
+							Description: "The object ID of the service principal for the application in the home tenant, if one exists",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"sign_in_audience": {
+							Description: "The Microsoft account types that are supported for the application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func applicationsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Applications.ApplicationsClient
+	servicePrincipalsClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	filters := make([]string, 0)
+	if v := d.Get("display_name_prefix").(string); v != "" {
+		filters = append(filters, helpers.ODataStartsWith("displayName", v))
+	}
+	if v := d.Get("publisher_domain").(string); v != "" {
+		filters = append(filters, helpers.ODataEq("publisherDomain", v))
+	}
+	if v := d.Get("sign_in_audience").(string); v != "" {
+		filters = append(filters, helpers.ODataEq("signInAudience", v))
+	}
+	filter := helpers.ODataAnd(filters...)
+	maxResults := d.Get("max_results").(int)
+
+	apps, truncated, err := applicationListWithLimit(ctx, client, filter, maxResults)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing applications")
+	}
+
+	appIds := make([]string, 0, len(apps))
+	for _, app := range apps {
+		if app.ID == nil || app.AppId == nil {
+			return tf.ErrorDiagF(errors.New("API returned application with nil object ID or application ID"), "Bad API Response")
+		}
+		appIds = append(appIds, *app.AppId)
+	}
+
+	servicePrincipalIds, err := applicationServicePrincipalIds(ctx, servicePrincipalsClient, appIds)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving service principals for applications")
+	}
+
+	applicationIds := make([]string, 0)
+	displayNames := make([]string, 0)
+	objectIds := make([]string, 0)
+	spIds := make([]string, 0)
+	applicationList := make([]map[string]interface{}, 0)
+	for _, app := range apps {
+		objectIds = append(objectIds, *app.ID)
+		applicationIds = append(applicationIds, *app.AppId)
+		if app.DisplayName != nil {
+			displayNames = append(displayNames, *app.DisplayName)
+		}
+
+		spId := servicePrincipalIds[strings.ToLower(*app.AppId)]
+		if spId != "" {
+			spIds = append(spIds, spId)
+		}
+
+		applicationList = append(applicationList, map[string]interface{}{
+			"application_id":              app.AppId,
+			"display_name":                app.DisplayName,
+			"object_id":                   app.ID,
+			"publisher_domain":            app.PublisherDomain,
+			"service_principal_object_id": spId,
+			"sign_in_audience":            string(app.SignInAudience),
+		})
+	}
+
+	// Generate a unique ID based on the filters and the result
+	h := sha1.New()
+	if _, err := h.Write([]byte(filter + "#" + strings.Join(objectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for object IDs")
+	}
+
+	d.SetId("applications#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "application_ids", applicationIds)
+	tf.Set(d, "applications", applicationList)
+	tf.Set(d, "display_names", displayNames)
+	tf.Set(d, "object_ids", objectIds)
+	tf.Set(d, "service_principal_ids", spIds)
+
+	if truncated {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Applications truncated",
+			Detail:        fmt.Sprintf("More than %d applications were found, so only the first %d have been returned. Specify more filters, or increase `max_results`, to return all the matching applications", maxResults, maxResults),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "max_results"}},
+		}}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package applications_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ApplicationsDataSource struct{}
+
+func TestAccApplicationsDataSource_byDisplayNamePrefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_applications", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ApplicationsDataSource{}.byDisplayNamePrefix(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("applications.#").HasValue("2"),
+			check.That(data.ResourceName).Key("application_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("display_names.#").HasValue("2"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principal_ids.#").HasValue("1"),
+		),
+	}})
+}
+
+func TestAccApplicationsDataSource_maxResults(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_applications", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ApplicationsDataSource{}.maxResults(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("applications.#").HasValue("1"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("1"),
+		),
+	}})
+}
+
+func TestAccApplicationsDataSource_noResults(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_applications", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ApplicationsDataSource{}.noResults(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("applications.#").HasValue("0"),
+			check.That(data.ResourceName).Key("service_principal_ids.#").HasValue("0"),
+		),
+	}})
+}
+
+func TestAccApplicationsDataSource_invalidSignInAudience(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_applications", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config:      ApplicationsDataSource{}.invalidSignInAudience(),
+		ExpectError: regexp.MustCompile("expected sign_in_audience to be one of"),
+	}})
+}
+
+func (ApplicationsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "testA" {
+  display_name = "acctestApplications-%[1]d-A"
+}
+
+resource "azuread_application" "testB" {
+  display_name = "acctestApplications-%[1]d-B"
+}
+
+resource "azuread_service_principal" "testA" {
+  application_id = azuread_application.testA.application_id
+}
+`, data.RandomInteger)
+}
+
+func (r ApplicationsDataSource) byDisplayNamePrefix(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_applications" "test" {
+  display_name_prefix = "acctestApplications-%[2]d-"
+  sign_in_audience    = "AzureADMyOrg"
+
+  depends_on = [azuread_application.testA, azuread_application.testB, azuread_service_principal.testA]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ApplicationsDataSource) maxResults(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_applications" "test" {
+  display_name_prefix = "acctestApplications-%[2]d-"
+  max_results         = 1
+
+  depends_on = [azuread_application.testA, azuread_application.testB]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (ApplicationsDataSource) noResults(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_applications" "test" {
+  display_name_prefix = "acctestApplicationsNonExistent-%[1]d"
+}
+`, data.RandomInteger)
+}
+
+func (ApplicationsDataSource) invalidSignInAudience() string {
+	return `
+data "azuread_applications" "test" {
+  sign_in_audience = "Everyone"
+}
+`
+}
@@ -0,0 +1,1263 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
+	serviceprincipalsClient "github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestApplicationAssignRoleScopeIds(t *testing.T) {
+	existing := []applicationRoleScopeIdentity{
+		{id: "00000000-0000-0000-0000-000000000001", value: "admin", displayName: "Admin"},
+		{id: "00000000-0000-0000-0000-000000000002", value: "user", displayName: "User"},
+		{id: "00000000-0000-0000-0000-000000000003", value: "explicit", displayName: "Explicit"},
+	}
+
+	wanted := []applicationRoleScopeIdentity{
+		// matches by value
+		{value: "admin", displayName: "Administrator"},
+		// value changed, matches by display name
+		{value: "reader", displayName: "User"},
+		// explicitly configured ID
+		{id: "00000000-0000-0000-0000-000000000003", value: "explicit", displayName: "Explicit"},
+		// no match
+		{value: "new", displayName: "New"},
+	}
+
+	ids, err := applicationAssignRoleScopeIds(wanted, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, expected := range []string{
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000002",
+		"00000000-0000-0000-0000-000000000003",
+	} {
+		if ids[i] != expected {
+			t.Errorf("expected ID %q for item %d, got %q", expected, i, ids[i])
+		}
+	}
+
+	if _, err := uuid.ParseUUID(ids[3]); err != nil {
+		t.Errorf("expected a generated UUID for unmatched item, got %q", ids[3])
+	}
+	for i := 0; i < 3; i++ {
+		if ids[3] == ids[i] {
+			t.Errorf("generated ID %q collides with item %d", ids[3], i)
+		}
+	}
+}
+
+func TestApplicationAssignRoleScopeIdsExistingClaimedOnce(t *testing.T) {
+	existing := []applicationRoleScopeIdentity{
+		{id: "00000000-0000-0000-0000-000000000001", value: "admin", displayName: "Admin"},
+	}
+	wanted := []applicationRoleScopeIdentity{
+		{id: "00000000-0000-0000-0000-000000000001", value: "other", displayName: "Other"},
+		{value: "admin", displayName: "Admin"},
+	}
+
+	ids, err := applicationAssignRoleScopeIds(wanted, existing)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ids[1] == ids[0] {
+		t.Fatalf("expected a new ID for the second item since the existing ID is already claimed, got %q", ids[1])
+	}
+}
+
+func TestApplicationAppRoleChanged(t *testing.T) {
+	existing := msgraph.AppRole{
+		ID:                 utils.String("00000000-0000-0000-0000-000000000001"),
+		AllowedMemberTypes: &[]msgraph.AppRoleAllowedMemberType{msgraph.AppRoleAllowedMemberTypeUser, msgraph.AppRoleAllowedMemberTypeApplication},
+		Description:        utils.String("Admins"),
+		DisplayName:        utils.String("Admin"),
+		IsEnabled:          utils.Bool(true),
+		Origin:             utils.String("Application"),
+		Value:              utils.String("admin"),
+	}
+
+	unchanged := existing
+	unchanged.Origin = nil
+	unchanged.AllowedMemberTypes = &[]msgraph.AppRoleAllowedMemberType{msgraph.AppRoleAllowedMemberTypeApplication, msgraph.AppRoleAllowedMemberTypeUser}
+	if applicationAppRoleChanged(existing, unchanged) {
+		t.Error("expected role to be unchanged when only origin and member type ordering differ")
+	}
+
+	changed := unchanged
+	changed.Value = utils.String("administrator")
+	if !applicationAppRoleChanged(existing, changed) {
+		t.Error("expected role to be changed when value differs")
+	}
+}
+
+func TestApplicationAppRolesDelta(t *testing.T) {
+	role := func(id, value string, enabled bool) msgraph.AppRole {
+		return msgraph.AppRole{
+			ID:                 utils.String(id),
+			AllowedMemberTypes: &[]msgraph.AppRoleAllowedMemberType{msgraph.AppRoleAllowedMemberTypeUser},
+			Description:        utils.String(value),
+			DisplayName:        utils.String(value),
+			IsEnabled:          utils.Bool(enabled),
+			Value:              utils.String(value),
+		}
+	}
+	ids := func(roles []msgraph.AppRole) []string {
+		result := make([]string, 0)
+		for _, r := range roles {
+			result = append(result, *r.ID)
+		}
+		return result
+	}
+	idsAt := func(roles []msgraph.AppRole, indices []int) []string {
+		result := make([]string, 0)
+		for _, i := range indices {
+			result = append(result, *roles[i].ID)
+		}
+		return result
+	}
+	disabledIds := func(roles []msgraph.AppRole) []string {
+		result := make([]string, 0)
+		for _, r := range roles {
+			if !boolValue(r.IsEnabled) {
+				result = append(result, *r.ID)
+			}
+		}
+		return result
+	}
+
+	existing := []msgraph.AppRole{role("1", "admin", true), role("2", "user", true), role("3", "reader", true)}
+
+	cases := []struct {
+		name            string
+		desired         []msgraph.AppRole
+		expectAdded     []string
+		expectChanged   []string
+		expectRemoved   []string
+		expectUnchanged []string
+		expectRewrite   bool
+		expectDisabled  []string
+		expectMerged    []string
+	}{
+		{
+			name:            "unchanged",
+			desired:         []msgraph.AppRole{role("3", "reader", true), role("1", "admin", true), role("2", "user", true)},
+			expectUnchanged: []string{"3", "1", "2"},
+			expectDisabled:  []string{},
+			expectMerged:    []string{"1", "2", "3"},
+		},
+		{
+			name:            "add only",
+			desired:         []msgraph.AppRole{role("1", "admin", true), role("2", "user", true), role("3", "reader", true), role("4", "writer", true)},
+			expectAdded:     []string{"4"},
+			expectUnchanged: []string{"1", "2", "3"},
+			expectDisabled:  []string{},
+			expectMerged:    []string{"1", "2", "3", "4"},
+		},
+		{
+			name:            "remove only",
+			desired:         []msgraph.AppRole{role("1", "admin", true), role("3", "reader", true)},
+			expectRemoved:   []string{"2"},
+			expectUnchanged: []string{"1", "3"},
+			expectDisabled:  []string{"2"},
+			expectMerged:    []string{"1", "3"},
+		},
+		{
+			name:            "edit in place",
+			desired:         []msgraph.AppRole{role("1", "admin", true), role("2", "member", true), role("3", "reader", true)},
+			expectChanged:   []string{"2"},
+			expectUnchanged: []string{"1", "3"},
+			expectDisabled:  []string{"2"},
+			expectMerged:    []string{"1", "2", "3"},
+		},
+		{
+			name:            "mixed",
+			desired:         []msgraph.AppRole{role("5", "owner", true), role("3", "reader", false), role("1", "admin", true)},
+			expectAdded:     []string{"5"},
+			expectChanged:   []string{"3"},
+			expectRemoved:   []string{"2"},
+			expectUnchanged: []string{"1"},
+			expectDisabled:  []string{"2", "3"},
+			expectMerged:    []string{"1", "3", "5"},
+		},
+		{
+			name:           "ids changed",
+			desired:        []msgraph.AppRole{role("6", "admin", true), role("7", "user", true)},
+			expectAdded:    []string{"6", "7"},
+			expectRemoved:  []string{"1", "2", "3"},
+			expectRewrite:  true,
+			expectDisabled: []string{"1", "2", "3"},
+			expectMerged:   []string{"6", "7"},
+		},
+		{
+			name:           "all removed",
+			desired:        []msgraph.AppRole{},
+			expectRemoved:  []string{"1", "2", "3"},
+			expectDisabled: []string{"1", "2", "3"},
+			expectMerged:   []string{},
+		},
+	}
+
+	normalize := func(in []string) []string {
+		if in == nil {
+			return []string{}
+		}
+		return in
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delta, err := applicationDeltaFor(applicationAppRoles(existing), applicationAppRoles(tc.desired))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, c := range []struct {
+				field    string
+				actual   []string
+				expected []string
+			}{
+				{"added", idsAt(tc.desired, delta.added), tc.expectAdded},
+				{"changed", idsAt(existing, delta.changed), tc.expectChanged},
+				{"removed", idsAt(existing, delta.removed), tc.expectRemoved},
+				{"unchanged", idsAt(existing, delta.unchanged), tc.expectUnchanged},
+			} {
+				if !reflect.DeepEqual(c.actual, normalize(c.expected)) {
+					t.Errorf("expected %s roles %v, got %v", c.field, normalize(c.expected), c.actual)
+				}
+			}
+
+			if delta.rewrite != tc.expectRewrite {
+				t.Errorf("expected rewrite to be %t", tc.expectRewrite)
+			}
+
+			disable := delta.disable(applicationAppRoles(existing))
+			if actual := idsAt(existing, disable); !reflect.DeepEqual(actual, tc.expectDisabled) {
+				t.Errorf("expected roles to disable %v, got %v", tc.expectDisabled, actual)
+			}
+			disabled := applicationAppRoles(existing).disabled(disable)
+			if actual := disabledIds(disabled); !reflect.DeepEqual(actual, tc.expectDisabled) {
+				t.Errorf("expected disabled roles %v, got %v", tc.expectDisabled, actual)
+			}
+
+			merged := applicationAppRoles(existing).merged(tc.desired, delta.merge(applicationAppRoles(existing)))
+			if actual := ids(merged); !reflect.DeepEqual(actual, tc.expectMerged) {
+				t.Errorf("expected merged roles %v, got %v", tc.expectMerged, actual)
+			}
+		})
+	}
+
+	for _, r := range existing {
+		if !boolValue(r.IsEnabled) {
+			t.Errorf("existing role %q was modified", *r.ID)
+		}
+	}
+}
+
+func TestApplicationAppRolesDeltaNilIsEnabled(t *testing.T) {
+	existing := []msgraph.AppRole{{ID: utils.String("1"), Value: utils.String("admin")}}
+
+	delta, err := applicationDeltaFor(applicationAppRoles(existing), applicationAppRoles{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if disable := delta.disable(applicationAppRoles(existing)); len(disable) > 0 {
+		t.Error("expected a role without an enabled status not to be disabled")
+	}
+
+	if _, err := applicationDeltaFor(applicationAppRoles(existing), applicationAppRoles{{Value: utils.String("admin")}}); err == nil {
+		t.Error("expected an error for a role without an ID")
+	}
+}
+
+func TestApplicationOAuth2PermissionScopesDelta(t *testing.T) {
+	scope := func(id, value string) msgraph.PermissionScope {
+		return msgraph.PermissionScope{
+			ID:                      utils.String(id),
+			AdminConsentDescription: utils.String(value),
+			AdminConsentDisplayName: utils.String(value),
+			IsEnabled:               utils.Bool(true),
+			Type:                    msgraph.PermissionScopeTypeUser,
+			Value:                   utils.String(value),
+		}
+	}
+	ids := func(scopes []msgraph.PermissionScope) []string {
+		result := make([]string, 0)
+		for _, s := range scopes {
+			result = append(result, *s.ID)
+		}
+		return result
+	}
+
+	existing := applicationPermissionScopes{scope("1", "read"), scope("2", "write"), scope("3", "delete")}
+
+	changed := scope("2", "write")
+	changed.AdminConsentDescription = utils.String("Write everything")
+	desired := applicationPermissionScopes{scope("1", "read"), changed, scope("4", "admin")}
+	delta, err := applicationDeltaFor(existing, desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if delta.rewrite {
+		t.Error("expected scopes not to be rewritten")
+	}
+	if !reflect.DeepEqual(delta.unchanged, []int{0}) {
+		t.Errorf("expected unchanged scopes [0], got %v", delta.unchanged)
+	}
+
+	disable := delta.disable(existing)
+	if len(disable) == 0 {
+		t.Fatal("expected scopes to be disabled")
+	}
+	for _, s := range existing.disabled(disable) {
+		if expected := *s.ID == "1"; boolValue(s.IsEnabled) != expected {
+			t.Errorf("unexpected enabled status for scope %q", *s.ID)
+		}
+	}
+
+	merged := existing.merged(desired, delta.merge(existing))
+	if actual := ids(merged); !reflect.DeepEqual(actual, []string{"1", "2", "4"}) {
+		t.Errorf("expected merged scopes [1 2 4], got %v", actual)
+	}
+	if stringValue(merged[1].AdminConsentDescription) != "Write everything" {
+		t.Error("expected changed scope to take its desired definition")
+	}
+
+	delta, err = applicationDeltaFor(existing, applicationPermissionScopes{scope("5", "read")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !delta.rewrite {
+		t.Error("expected scopes to be rewritten when no IDs match")
+	}
+	if disable := delta.disable(existing); len(disable) != len(existing) {
+		t.Error("expected all scopes to be disabled when rewriting")
+	}
+}
+
+func TestApplicationDisableAppRolesLeavesUnchangedRolesEnabled(t *testing.T) {
+	const applicationId = "11111111-1111-1111-1111-111111111111"
+
+	roles := []msgraph.AppRole{
+		{ID: utils.String("00000000-0000-0000-0000-000000000001"), DisplayName: utils.String("Admin"), IsEnabled: utils.Bool(true), Value: utils.String("admin")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000002"), DisplayName: utils.String("User"), IsEnabled: utils.Bool(true), Value: utils.String("user")},
+	}
+
+	patches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/applications/"+applicationId) {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(msgraph.Application{ID: utils.String(applicationId), AppRoles: &roles})
+		case http.MethodPatch:
+			patches++
+			var app msgraph.Application
+			if err := json.NewDecoder(r.Body).Decode(&app); err != nil {
+				t.Errorf("decoding request body: %v", err)
+			}
+			if app.AppRoles != nil {
+				for _, role := range *app.AppRoles {
+					if *role.ID == "00000000-0000-0000-0000-000000000001" && !boolValue(role.IsEnabled) {
+						t.Error("unchanged role was disabled")
+					}
+				}
+				roles = *app.AppRoles
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewApplicationsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	desired := []msgraph.AppRole{
+		roles[0],
+		{ID: utils.String("00000000-0000-0000-0000-000000000002"), DisplayName: utils.String("Member"), IsEnabled: utils.Bool(true), Value: utils.String("member")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000003"), DisplayName: utils.String("Reader"), IsEnabled: utils.Bool(true), Value: utils.String("reader")},
+	}
+
+	result, err := applicationDisableAppRoles(ctx, client, &msgraph.Application{ID: utils.String(applicationId)}, &desired)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patches != 1 {
+		t.Errorf("expected 1 request to disable roles, got %d", patches)
+	}
+	if result == nil || len(*result) != 3 {
+		t.Fatalf("expected 3 roles to be written, got %v", result)
+	}
+
+	result, err = applicationDisableAppRoles(ctx, client, &msgraph.Application{ID: utils.String(applicationId)}, &[]msgraph.AppRole{roles[0], roles[1]})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != nil {
+		t.Error("expected unchanged roles to be omitted from the update")
+	}
+	if patches != 1 {
+		t.Errorf("expected no further requests to disable roles, got %d", patches-1)
+	}
+}
+
+func TestApplicationFindAppRolesByValue(t *testing.T) {
+	app := &msgraph.Application{
+		AppRoles: &[]msgraph.AppRole{
+			{ID: utils.String("00000000-0000-0000-0000-000000000001"), DisplayName: utils.String("Admin"), IsEnabled: utils.Bool(true), Value: utils.String("Admin")},
+			{ID: utils.String("00000000-0000-0000-0000-000000000002"), DisplayName: utils.String("Admin"), IsEnabled: utils.Bool(true), Value: utils.String("admin")},
+			{ID: utils.String("00000000-0000-0000-0000-000000000003"), DisplayName: utils.String("Legacy"), IsEnabled: utils.Bool(false), Value: utils.String("legacy")},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		value       string
+		displayName string
+		expectIds   []string
+	}{
+		{name: "value is case-sensitive", value: "admin", expectIds: []string{"00000000-0000-0000-0000-000000000002"}},
+		{name: "value differing only by case", value: "ADMIN", expectIds: []string{}},
+		{name: "duplicate display names", displayName: "admin", expectIds: []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}},
+		{name: "disabled role", value: "legacy", expectIds: []string{"00000000-0000-0000-0000-000000000003"}},
+		{name: "no match", value: "reader", expectIds: []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			roles, err := ApplicationFindAppRolesByValue(app, c.value, c.displayName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids := make([]string, 0)
+			for _, r := range roles {
+				ids = append(ids, *r.ID)
+			}
+			if !reflect.DeepEqual(ids, c.expectIds) {
+				t.Errorf("expected roles %v, got %v", c.expectIds, ids)
+			}
+		})
+	}
+
+	if _, err := ApplicationFindAppRolesByValue(app, "", ""); err == nil {
+		t.Error("expected an error when neither value nor display name is specified")
+	}
+
+	if roles, _ := ApplicationFindAppRolesByValue(&msgraph.Application{}, "admin", ""); len(roles) != 0 {
+		t.Error("expected no roles for an application without app roles")
+	}
+
+	if role, _ := ApplicationFindAppRole(app, "00000000-0000-0000-0000-000000000003"); role == nil || stringValue(role.Value) != "legacy" {
+		t.Error("expected to find disabled role by ID")
+	}
+}
+
+func TestApplicationFindOAuth2PermissionScopesByValue(t *testing.T) {
+	app := &msgraph.Application{
+		Api: &msgraph.ApplicationApi{
+			OAuth2PermissionScopes: &[]msgraph.PermissionScope{
+				{ID: utils.String("00000000-0000-0000-0000-000000000001"), AdminConsentDisplayName: utils.String("Read"), IsEnabled: utils.Bool(true), Value: utils.String("Read")},
+				{ID: utils.String("00000000-0000-0000-0000-000000000002"), AdminConsentDisplayName: utils.String("Read"), IsEnabled: utils.Bool(true), Value: utils.String("read")},
+				{ID: utils.String("00000000-0000-0000-0000-000000000003"), AdminConsentDisplayName: utils.String("Write"), IsEnabled: utils.Bool(false), Value: utils.String("write")},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		value       string
+		displayName string
+		expectIds   []string
+	}{
+		{name: "value is case-sensitive", value: "Read", expectIds: []string{"00000000-0000-0000-0000-000000000001"}},
+		{name: "value differing only by case", value: "WRITE", expectIds: []string{}},
+		{name: "duplicate display names", displayName: "READ", expectIds: []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"}},
+		{name: "disabled scope", displayName: "Write", expectIds: []string{"00000000-0000-0000-0000-000000000003"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			scopes, err := ApplicationFindOAuth2PermissionScopesByValue(app, c.value, c.displayName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids := make([]string, 0)
+			for _, s := range scopes {
+				ids = append(ids, *s.ID)
+			}
+			if !reflect.DeepEqual(ids, c.expectIds) {
+				t.Errorf("expected scopes %v, got %v", c.expectIds, ids)
+			}
+		})
+	}
+
+	if scopes, _ := ApplicationFindOAuth2PermissionScopesByValue(&msgraph.Application{}, "read", ""); len(scopes) != 0 {
+		t.Error("expected no scopes for an application without an API")
+	}
+}
+
+func TestApplicationAppRoleCandidates(t *testing.T) {
+	if actual := applicationAppRoleCandidates(nil); actual != "(none)" {
+		t.Errorf("unexpected candidates for no roles: %q", actual)
+	}
+
+	roles := []msgraph.AppRole{
+		{ID: utils.String("00000000-0000-0000-0000-000000000001"), DisplayName: utils.String("Admin"), IsEnabled: utils.Bool(true), Value: utils.String("admin")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000002"), DisplayName: utils.String("Admin"), Value: utils.String("administrator")},
+	}
+	expected := `00000000-0000-0000-0000-000000000001 (value: "admin", display name: "Admin", enabled: true), 00000000-0000-0000-0000-000000000002 (value: "administrator", display name: "Admin", enabled: false)`
+	if actual := applicationAppRoleCandidates(roles); actual != expected {
+		t.Errorf("expected candidates %q, got %q", expected, actual)
+	}
+}
+
+func TestApplicationAppRoleHashIgnoresId(t *testing.T) {
+	role := func(id string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":                   id,
+			"allowed_member_types": schema.NewSet(schema.HashString, []interface{}{"User", "Application"}),
+			"description":          "Admins",
+			"display_name":         "Admin",
+			"enabled":              true,
+			"value":                "admin",
+		}
+	}
+
+	if applicationAppRoleHash(role("")) != applicationAppRoleHash(role("00000000-0000-0000-0000-000000000001")) {
+		t.Fatal("expected app role hash to be independent of the role ID")
+	}
+}
+
+func TestFlattenApplicationApi(t *testing.T) {
+	if result := flattenApplicationApi(&msgraph.ApplicationApi{}, false, false); len(result) != 0 {
+		t.Fatalf("expected no api block when nothing is configured, got %v", result)
+	}
+
+	result := flattenApplicationApi(&msgraph.ApplicationApi{}, false, true)
+	if len(result) != 1 {
+		t.Fatalf("expected an api block when configured, got %v", result)
+	}
+	if v := result[0]["requested_access_token_version"]; v != 1 {
+		t.Errorf("expected requested_access_token_version to default to 1, got %v", v)
+	}
+
+	result = flattenApplicationApi(&msgraph.ApplicationApi{
+		KnownClientApplications:     &[]string{"11111111-1111-1111-1111-111111111111"},
+		RequestedAccessTokenVersion: utils.Int32(2),
+	}, false, false)
+	if len(result) != 1 {
+		t.Fatalf("expected an api block for known client applications, got %v", result)
+	}
+	if v := result[0]["requested_access_token_version"]; v != 2 {
+		t.Errorf("expected requested_access_token_version to be 2, got %v", v)
+	}
+	if v := result[0]["known_client_applications"].([]interface{}); len(v) != 1 {
+		t.Errorf("expected one known client application, got %v", v)
+	}
+}
+
+func TestFlattenApplicationOnPremisesPublishing(t *testing.T) {
+	if result := flattenApplicationOnPremisesPublishing(nil); len(result) != 0 {
+		t.Fatalf("expected no result for nil input, got %d", len(result))
+	}
+
+	// Applications which are not published with Application Proxy return an object with null URLs
+	if result := flattenApplicationOnPremisesPublishing(&client.OnPremisesPublishing{
+		IsOnPremPublishingEnabled: utils.Bool(false),
+	}); len(result) != 0 {
+		t.Fatalf("expected no result for unpublished application, got %d", len(result))
+	}
+
+	in := expandApplicationOnPremisesPublishing([]interface{}{map[string]interface{}{
+		"external_authentication_type":  "passthru",
+		"external_url":                  "https://app-contoso.msappproxy.net/",
+		"http_only_cookie_enabled":      true,
+		"internal_url":                  "http://app.contoso.local/",
+		"persistent_cookie_enabled":     false,
+		"translate_host_header_enabled": true,
+	}})
+	result := flattenApplicationOnPremisesPublishing(in)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result))
+	}
+	for k, expected := range map[string]interface{}{
+		"external_authentication_type":  "passthru",
+		"external_url":                  "https://app-contoso.msappproxy.net/",
+		"http_only_cookie_enabled":      true,
+		"internal_url":                  "http://app.contoso.local/",
+		"persistent_cookie_enabled":     false,
+		"translate_host_header_enabled": true,
+	} {
+		if result[0][k] != expected {
+			t.Errorf("expected %q to be %v, got %v", k, expected, result[0][k])
+		}
+	}
+}
+
+func TestFlattenApplicationFederatedIdentityCredentials(t *testing.T) {
+	if result := flattenApplicationFederatedIdentityCredentials(nil, ""); len(result) != 0 {
+		t.Fatalf("expected no result for nil input, got %d", len(result))
+	}
+
+	in := &[]client.FederatedIdentityCredential{
+		{
+			ID:        utils.String("00000000-0000-0000-0000-000000000001"),
+			Audiences: &[]string{"api://AzureADTokenExchange"},
+			Issuer:    utils.String("https://token.actions.githubusercontent.com"),
+			Name:      utils.String("github-main"),
+			Subject:   utils.String("repo:contoso/app:ref:refs/heads/main"),
+		},
+		{
+			ID:      utils.String("00000000-0000-0000-0000-000000000002"),
+			Issuer:  utils.String("https://token.actions.githubusercontent.com"),
+			Name:    utils.String("github-release"),
+			Subject: utils.String("repo:contoso/app:environment:release"),
+		},
+	}
+
+	if result := flattenApplicationFederatedIdentityCredentials(in, ""); len(result) != 2 {
+		t.Fatalf("expected 2 results without a display name, got %d", len(result))
+	}
+
+	result := flattenApplicationFederatedIdentityCredentials(in, "github-release")
+	if len(result) != 1 {
+		t.Fatalf("expected 1 result for display name, got %d", len(result))
+	}
+	if result[0]["display_name"] != "github-release" {
+		t.Errorf("expected display_name to be %q, got %v", "github-release", result[0]["display_name"])
+	}
+	if audiences, ok := result[0]["audiences"].([]string); !ok || len(audiences) != 0 {
+		t.Errorf("expected audiences to be an empty list, got %v", result[0]["audiences"])
+	}
+}
+
+func TestApplicationExpiredCredentialKeyIds(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+
+	app := &msgraph.Application{
+		PasswordCredentials: &[]msgraph.PasswordCredential{
+			{KeyId: utils.String("password-expired-long-ago"), EndDateTime: at(-30 * 24 * time.Hour)},
+			{KeyId: utils.String("password-expired-recently"), EndDateTime: at(-time.Hour)},
+			{KeyId: utils.String("password-valid"), EndDateTime: at(time.Hour)},
+			{KeyId: utils.String("password-no-end-date")},
+		},
+		KeyCredentials: &[]msgraph.KeyCredential{
+			{KeyId: utils.String("key-expired-long-ago"), EndDateTime: at(-30 * 24 * time.Hour)},
+			{KeyId: utils.String("key-valid"), EndDateTime: at(365 * 24 * time.Hour)},
+		},
+	}
+
+	passwords, keys := applicationExpiredCredentialKeyIds(app, 0, now, nil)
+	if expected := "password-expired-long-ago,password-expired-recently"; strings.Join(passwords, ",") != expected {
+		t.Errorf("expected expired passwords %q with no grace period, got: %v", expected, passwords)
+	}
+	if expected := "key-expired-long-ago"; strings.Join(keys, ",") != expected {
+		t.Errorf("expected expired keys %q with no grace period, got: %v", expected, keys)
+	}
+
+	passwords, keys = applicationExpiredCredentialKeyIds(app, 7*24*time.Hour, now, nil)
+	if expected := "password-expired-long-ago"; strings.Join(passwords, ",") != expected {
+		t.Errorf("expected expired passwords %q with a grace period, got: %v", expected, passwords)
+	}
+	if expected := "key-expired-long-ago"; strings.Join(keys, ",") != expected {
+		t.Errorf("expected expired keys %q with a grace period, got: %v", expected, keys)
+	}
+
+	// Credentials managed by credential resources are retained, regardless of the case of their key IDs
+	passwords, keys = applicationExpiredCredentialKeyIds(app, 0, now, []string{"PASSWORD-EXPIRED-RECENTLY", "key-expired-long-ago"})
+	if expected := "password-expired-long-ago"; strings.Join(passwords, ",") != expected {
+		t.Errorf("expected expired passwords %q with retained key IDs, got: %v", expected, passwords)
+	}
+	if len(keys) != 0 {
+		t.Errorf("expected no expired keys with retained key IDs, got: %v", keys)
+	}
+}
+
+func TestApplicationPreAuthorizedAppsWithout(t *testing.T) {
+	apps := []msgraph.ApiPreAuthorizedApplication{
+		{AppId: utils.String("11111111-1111-1111-1111-111111111111"), PermissionIds: &[]string{"aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"}},
+		{AppId: utils.String("22222222-2222-2222-2222-222222222222"), PermissionIds: &[]string{"bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"}},
+		{AppId: utils.String("33333333-3333-3333-3333-333333333333"), PermissionIds: &[]string{"cccccccc-cccc-cccc-cccc-cccccccccccc"}},
+	}
+
+	result, found := applicationPreAuthorizedAppsWithout(apps, "22222222-2222-2222-2222-222222222222")
+	if !found {
+		t.Fatalf("expected the pre-authorized application to be found")
+	}
+	if len(result) != 2 || *result[0].AppId != "11111111-1111-1111-1111-111111111111" || *result[1].AppId != "33333333-3333-3333-3333-333333333333" {
+		t.Fatalf("expected all other pre-authorized applications to be retained, got: %+v", result)
+	}
+
+	result, found = applicationPreAuthorizedAppsWithout(apps, "44444444-4444-4444-4444-444444444444")
+	if found {
+		t.Fatalf("expected the pre-authorized application not to be found")
+	}
+	if len(result) != 3 {
+		t.Fatalf("expected all pre-authorized applications to be retained, got: %+v", result)
+	}
+}
+
+func TestApplicationValidateRedirectUriCount(t *testing.T) {
+	web := func(count int) []interface{} {
+		uris := schema.NewSet(schema.HashString, nil)
+		for i := 0; i < count; i++ {
+			uris.Add(fmt.Sprintf("https://app.example.net/callback/%d", i))
+		}
+		return []interface{}{map[string]interface{}{"redirect_uris": uris}}
+	}
+
+	if err := applicationValidateRedirectUriCount(nil); err != nil {
+		t.Fatalf("unexpected error for no web block: %v", err)
+	}
+	if err := applicationValidateRedirectUriCount(web(256)); err != nil {
+		t.Fatalf("unexpected error for 256 redirect URIs: %v", err)
+	}
+	if err := applicationValidateRedirectUriCount(web(257)); err == nil {
+		t.Fatal("expected an error for 257 redirect URIs")
+	}
+	if err := applicationValidateRedirectUriCount(web(200), web(57)); err == nil {
+		t.Fatal("expected an error for 257 redirect URIs across web and single-page application")
+	}
+}
+
+func TestExpandApplicationPublicClientClearsRedirectUris(t *testing.T) {
+	body, err := json.Marshal(expandApplicationPublicClient(nil))
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	if expected := `{"redirectUris":[]}`; string(body) != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestExpandApplicationSpaClearsRedirectUris(t *testing.T) {
+	body, err := json.Marshal(expandApplicationSpa(nil))
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	if expected := `{"redirectUris":[]}`; string(body) != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestExpandApplicationWebClearsUrls(t *testing.T) {
+	web := expandApplicationWeb([]interface{}{map[string]interface{}{
+		"homepage_url":   "",
+		"logout_url":     "",
+		"implicit_grant": []interface{}{},
+		"redirect_uris":  schema.NewSet(schema.HashString, []interface{}{"https://app.example.net/callback"}),
+	}})
+
+	body, err := json.Marshal(web)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	for _, expected := range []string{`"homePageUrl":null`, `"logoutUrl":null`} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected %s in %s", expected, body)
+		}
+	}
+}
+
+func TestExpandApplicationOptionalClaimsClearsClaims(t *testing.T) {
+	body, err := json.Marshal(expandApplicationOptionalClaims(nil))
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+	if expected := `{"accessToken":[],"idToken":[],"saml2Token":[]}`; string(body) != expected {
+		t.Fatalf("expected %s, got %s", expected, body)
+	}
+}
+
+func TestFlattenApplicationOptionalClaims(t *testing.T) {
+	in := &msgraph.OptionalClaims{
+		AccessToken: &[]msgraph.OptionalClaim{},
+		IdToken:     &[]msgraph.OptionalClaim{},
+		Saml2Token: &[]msgraph.OptionalClaim{
+			{
+				Name:                 utils.String("upn"),
+				Essential:            utils.Bool(true),
+				AdditionalProperties: &[]string{"include_externally_authenticated_upn"},
+			},
+		},
+	}
+
+	result, ok := flattenApplicationOptionalClaims(in).([]map[string]interface{})
+	if !ok || len(result) != 1 {
+		t.Fatalf("expected optional claims with only saml2_token claims to be flattened, got %#v", result)
+	}
+
+	saml2Token := result[0]["saml2_token"].([]interface{})
+	if len(saml2Token) != 1 {
+		t.Fatalf("expected 1 saml2_token claim, got %d", len(saml2Token))
+	}
+	claim := saml2Token[0].(map[string]interface{})
+	if name := claim["name"].(*string); name == nil || *name != "upn" {
+		t.Fatalf("unexpected claim name: %v", claim["name"])
+	}
+	if claim["source"] != "" {
+		t.Fatalf("expected empty source, got %q", claim["source"])
+	}
+	if props := claim["additional_properties"].([]string); !reflect.DeepEqual(props, []string{"include_externally_authenticated_upn"}) {
+		t.Fatalf("unexpected additional properties: %v", props)
+	}
+
+	if result := flattenApplicationOptionalClaims(&msgraph.OptionalClaims{}).([]map[string]interface{}); len(result) != 0 {
+		t.Fatalf("expected no optional claims to be flattened, got %#v", result)
+	}
+}
+
+func TestApplicationValidateResourceAccess(t *testing.T) {
+	const (
+		apiAppId        = "00000003-0000-0000-c000-000000000000"
+		missingAppId    = "00000009-0000-0000-c000-000000000000"
+		readRoleId      = "df021288-bdef-4463-88db-98f22de89214"
+		writeRoleId     = "741f803b-c850-494e-b5df-cde7c675a1ca"
+		disabledRoleId  = "19dbc75e-c2e2-444c-a770-ec69d8559fc7"
+		userReadScopeId = "e1fe6dd8-ba31-4d61-89e7-88639da4683d"
+	)
+
+	lookups := make(map[string]int)
+	lookup := func(_ context.Context, appId string) (*msgraph.ServicePrincipal, error) {
+		lookups[appId]++
+		if appId != apiAppId {
+			return nil, nil
+		}
+		return &msgraph.ServicePrincipal{
+			AppId:       utils.String(apiAppId),
+			DisplayName: utils.String("Microsoft Graph"),
+			AppRoles: &[]msgraph.AppRole{
+				{ID: utils.String(readRoleId), Value: utils.String("User.Read.All"), IsEnabled: utils.Bool(true)},
+				{ID: utils.String(writeRoleId), Value: utils.String("User.ReadWrite.All"), IsEnabled: utils.Bool(true)},
+				{ID: utils.String(disabledRoleId), Value: utils.String("User.Invite.All"), IsEnabled: utils.Bool(false)},
+			},
+			PublishedPermissionScopes: &[]msgraph.PermissionScope{
+				{ID: utils.String(userReadScopeId), Value: utils.String("User.Read"), IsEnabled: utils.Bool(true)},
+			},
+		}, nil
+	}
+
+	access := func(appId string, accesses ...msgraph.ResourceAccess) *[]msgraph.RequiredResourceAccess {
+		return &[]msgraph.RequiredResourceAccess{{ResourceAppId: utils.String(appId), ResourceAccess: &accesses}}
+	}
+	role := func(id string) msgraph.ResourceAccess {
+		return msgraph.ResourceAccess{ID: utils.String(id), Type: msgraph.ResourceAccessTypeRole}
+	}
+	scope := func(id string) msgraph.ResourceAccess {
+		return msgraph.ResourceAccess{ID: utils.String(id), Type: msgraph.ResourceAccessTypeScope}
+	}
+
+	cases := []struct {
+		name     string
+		input    *[]msgraph.RequiredResourceAccess
+		expected []string
+	}{
+		{
+			name:  "valid",
+			input: access(apiAppId, role(readRoleId), role(strings.ToUpper(writeRoleId)), scope(userReadScopeId)),
+		},
+		{
+			name:     "invalid role",
+			input:    access(apiAppId, role("df021288-bdef-4463-88db-98f22de89215")),
+			expected: []string{`no app role with ID "df021288-bdef-4463-88db-98f22de89215" is published by "Microsoft Graph" (resource_app_id "00000003-0000-0000-c000-000000000000"), the closest match is "df021288-bdef-4463-88db-98f22de89214" (User.Read.All)`},
+		},
+		{
+			name:     "disabled role",
+			input:    access(apiAppId, role(disabledRoleId)),
+			expected: []string{`the app role "19dbc75e-c2e2-444c-a770-ec69d8559fc7" (User.Invite.All) published by "Microsoft Graph" (resource_app_id "00000003-0000-0000-c000-000000000000") is disabled`},
+		},
+		{
+			name:     "wrong type",
+			input:    access(apiAppId, role(userReadScopeId)),
+			expected: []string{`"e1fe6dd8-ba31-4d61-89e7-88639da4683d" is an OAuth2 permission scope (User.Read) published by "Microsoft Graph" (resource_app_id "00000003-0000-0000-c000-000000000000"), but was requested with type "Role"`},
+		},
+		{
+			name:     "missing service principal",
+			input:    access(missingAppId, scope(userReadScopeId)),
+			expected: []string{`no service principal was found for resource_app_id "00000009-0000-0000-c000-000000000000"`},
+		},
+		{
+			name:  "multiple problems",
+			input: access(apiAppId, role(readRoleId), role(disabledRoleId), scope("e1fe6dd8-ba31-4d61-89e7-88639da4683e")),
+			expected: []string{
+				`the app role "19dbc75e-c2e2-444c-a770-ec69d8559fc7" (User.Invite.All)`,
+				`no OAuth2 permission scope with ID "e1fe6dd8-ba31-4d61-89e7-88639da4683e" is published by "Microsoft Graph" (resource_app_id "00000003-0000-0000-c000-000000000000"), the closest match is "e1fe6dd8-ba31-4d61-89e7-88639da4683d" (User.Read)`,
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := applicationValidateResourceAccess(context.Background(), lookup, c.input)
+			if len(c.expected) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, expected := range c.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error containing %q, got: %v", expected, err)
+				}
+			}
+			if problems := strings.Count(err.Error(), "\n"); problems != len(c.expected) {
+				t.Errorf("expected %d problems, got %d: %v", len(c.expected), problems, err)
+			}
+		})
+	}
+
+	if lookups[apiAppId] != len(cases)-1 {
+		t.Errorf("expected one lookup per validation of the API, got %d", lookups[apiAppId])
+	}
+
+	lookupErr := errors.New("unexpected status 403")
+	failingLookup := func(_ context.Context, _ string) (*msgraph.ServicePrincipal, error) {
+		return nil, lookupErr
+	}
+	if err := applicationValidateResourceAccess(context.Background(), failingLookup, access(apiAppId, role(readRoleId))); err == nil || !strings.Contains(err.Error(), lookupErr.Error()) {
+		t.Fatalf("expected lookup error, got: %v", err)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	for _, c := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"df021288-bdef-4463-88db-98f22de89214", "df021288-bdef-4463-88db-98f22de89215", 1},
+	} {
+		if actual := levenshteinDistance(c.a, c.b); actual != c.expected {
+			t.Errorf("levenshteinDistance(%q, %q): expected %d, got %d", c.a, c.b, c.expected, actual)
+		}
+	}
+}
+
+func TestApplicationVerifiedPublisherError(t *testing.T) {
+	const objectId = "11111111-1111-1111-1111-111111111111"
+
+	var requestBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1.0/00000000-0000-0000-0000-000000000000/applications/%s/setVerifiedPublisher", objectId):
+			if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":"PublisherDomainMismatch","message":"The publisher domain does not match."}}`)
+		case fmt.Sprintf("/v1.0/00000000-0000-0000-0000-000000000000/applications/%s/unsetVerifiedPublisher", objectId):
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":"SomethingElse","message":"Something else went wrong."}}`)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewApplicationVerifiedPublisherClient("00000000-0000-0000-0000-000000000000")
+	c.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	c.BaseClient.DisableRetries = true
+
+	_, err := c.Set(context.Background(), objectId, "1234567")
+	if err == nil {
+		t.Fatal("expected an error setting verified publisher")
+	}
+	if requestBody["verifiedPublisherId"] != "1234567" {
+		t.Fatalf("expected verifiedPublisherId %q in request body, got %v", "1234567", requestBody)
+	}
+	if e, ok := err.(client.VerifiedPublisherError); !ok || e.Code != "PublisherDomainMismatch" {
+		t.Fatalf("expected a VerifiedPublisherError with code %q, got: %#v", "PublisherDomainMismatch", err)
+	}
+	if annotated := applicationVerifiedPublisherError(err); !strings.HasPrefix(annotated.Error(), err.Error()) || !strings.Contains(annotated.Error(), applicationVerifiedPublisherHints["PublisherDomainMismatch"]) {
+		t.Fatalf("expected error to be annotated with a hint, got: %v", annotated)
+	}
+
+	if _, err := c.Unset(context.Background(), objectId); err != nil {
+		t.Fatalf("unexpected error unsetting verified publisher: %v", err)
+	}
+
+	_, err = c.Set(context.Background(), "22222222-2222-2222-2222-222222222222", "1234567")
+	if err == nil {
+		t.Fatal("expected an error setting verified publisher")
+	}
+	if annotated := applicationVerifiedPublisherError(err); annotated.Error() != err.Error() {
+		t.Fatalf("expected unrecognised error code to be returned unchanged, got: %v", annotated)
+	}
+}
+
+func TestApplicationResourceCreateRollback(t *testing.T) {
+	const (
+		callerId      = "00000000-0000-0000-0000-000000000001"
+		applicationId = "11111111-1111-1111-1111-111111111111"
+	)
+
+	cases := []struct {
+		name           string
+		failStep       string
+		rollback       bool
+		expectDeleted  bool
+		expectRetained bool
+	}{
+		{name: "single-page application fails without rollback", failStep: "spa", expectRetained: true},
+		{name: "single-page application fails with rollback", failStep: "spa", rollback: true, expectDeleted: true},
+		{name: "owners fail without rollback", failStep: "owners", expectRetained: true},
+		{name: "owners fail with rollback", failStep: "owners", rollback: true, expectDeleted: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				step := ""
+				switch {
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/directoryObjects/getByIds"):
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"value":[{"@odata.type":"#microsoft.graph.user","id":%q}]}`, callerId)
+					return
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/applications"):
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `{"id":%q,"appId":"22222222-2222-2222-2222-222222222222","displayName":"acctest"}`, applicationId)
+					return
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/applications/"+applicationId):
+					step = "spa"
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/applications/"+applicationId+"/owners"):
+					step = "owners"
+				case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/applications/"+applicationId):
+					deleted = true
+					w.WriteHeader(http.StatusNoContent)
+					return
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if step == tc.failStep {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+					return
+				}
+				if r.Method == http.MethodGet {
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"value":[{"id":%q}]}`, callerId)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			applicationsClient := msgraph.NewApplicationsClient("00000000-0000-0000-0000-000000000000")
+			applicationsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			applicationsClient.BaseClient.DisableRetries = true
+
+			spaClient := client.NewApplicationSpaClient("00000000-0000-0000-0000-000000000000")
+			spaClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			spaClient.BaseClient.DisableRetries = true
+
+			meta := &clients.Client{
+				Claims:                  auth.Claims{ObjectId: callerId},
+				RollbackOnPartialCreate: tc.rollback,
+				Applications: &client.Client{
+					ApplicationsClient:   applicationsClient,
+					ApplicationSpaClient: spaClient,
+				},
+				ServicePrincipals: &serviceprincipalsClient.Client{},
+			}
+
+			d := schema.TestResourceDataRaw(t, applicationResource().Schema, map[string]interface{}{
+				"display_name": "acctest",
+				"owners":       []interface{}{"current"},
+				"single_page_application": []interface{}{
+					map[string]interface{}{
+						"redirect_uris": []interface{}{"https://example.com/"},
+					},
+				},
+			})
+
+			diags := applicationResourceCreate(context.Background(), d, meta)
+			if !diags.HasError() {
+				t.Fatalf("expected an error when the %s step fails", tc.failStep)
+			}
+			if deleted != tc.expectDeleted {
+				t.Fatalf("expected deleted to be %t, got %t", tc.expectDeleted, deleted)
+			}
+			if retained := d.Id() == applicationId; retained != tc.expectRetained {
+				t.Fatalf("expected the application ID to be retained in state: %t, got ID %q", tc.expectRetained, d.Id())
+			}
+		})
+	}
+}
+
+func TestApplicationListWithLimit(t *testing.T) {
+	const filter = "startswith(displayName, 'acctest')"
+
+	var serverUrl, expectedTop string
+	requests, pages := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			if got := r.URL.Query().Get("$filter"); got != filter {
+				t.Errorf("unexpected filter: %q", got)
+			}
+			if got := r.URL.Query().Get("$top"); got != expectedTop {
+				t.Errorf("expected $top to be %q, got %q", expectedTop, got)
+			}
+		}
+
+		result := map[string]interface{}{
+			"value": []msgraph.Application{
+				{ID: utils.String(fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", requests*2-1)), AppId: utils.String("11111111-1111-1111-1111-111111111111")},
+				{ID: utils.String(fmt.Sprintf("00000000-0000-0000-0000-00000000000%d", requests*2)), AppId: utils.String("22222222-2222-2222-2222-222222222222")},
+			},
+		}
+		if requests < pages {
+			result["@odata.nextLink"] = fmt.Sprintf("%s/beta/applications?page=%d", serverUrl, requests+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+	serverUrl = server.URL
+
+	client := msgraph.NewApplicationsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	testCases := []struct {
+		name              string
+		limit             int
+		pages             int
+		expectedCount     int
+		expectedRequests  int
+		expectedTruncated bool
+	}{
+		{
+			name:              "limit exceeded",
+			limit:             2,
+			pages:             100,
+			expectedCount:     2,
+			expectedRequests:  2,
+			expectedTruncated: true,
+		},
+		{
+			name:             "limit not exceeded",
+			limit:            4,
+			pages:            2,
+			expectedCount:    4,
+			expectedRequests: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests, pages, expectedTop = 0, tc.pages, strconv.Itoa(tc.limit+1)
+
+			apps, truncated, err := applicationListWithLimit(context.Background(), client, filter, tc.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if truncated != tc.expectedTruncated {
+				t.Fatalf("expected truncated to be %t, got %t", tc.expectedTruncated, truncated)
+			}
+			if len(apps) != tc.expectedCount {
+				t.Fatalf("expected %d applications, got %d", tc.expectedCount, len(apps))
+			}
+			if requests != tc.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+		})
+	}
+}
+
+func TestApplicationServicePrincipalIds(t *testing.T) {
+	appIds := make([]string, 0)
+	for i := 0; i < 20; i++ {
+		appIds = append(appIds, fmt.Sprintf("00000000-0000-0000-0000-0000000000%02d", i))
+	}
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		filter := r.URL.Query().Get("$filter")
+		if !strings.HasPrefix(filter, "appId in (") {
+			t.Errorf("unexpected filter: %q", filter)
+		}
+
+		// Only the first application in each batch has a service principal
+		servicePrincipals := make([]msgraph.ServicePrincipal, 0)
+		for _, id := range appIds {
+			if strings.Contains(filter, fmt.Sprintf("'%s'", id)) {
+				servicePrincipals = append(servicePrincipals, msgraph.ServicePrincipal{
+					ID:    utils.String("sp-" + id),
+					AppId: utils.String(strings.ToUpper(id)),
+				})
+				break
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": servicePrincipals})
+	}))
+	defer server.Close()
+
+	client := msgraph.NewServicePrincipalsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	result, err := applicationServicePrincipalIds(context.Background(), client, appIds)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected application IDs to be looked up in 2 batches, got %d requests", requests)
+	}
+	expected := map[string]string{
+		appIds[0]:  "sp-" + appIds[0],
+		appIds[15]: "sp-" + appIds[15],
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
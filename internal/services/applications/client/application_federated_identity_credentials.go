@@ -0,0 +1,166 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// FederatedIdentityCredential describes a trust relationship between an application and an external identity provider.
+// These are only exposed by the beta API and are not modelled by msgraph.Application.
+type FederatedIdentityCredential struct {
+	ID          *string   `json:"id,omitempty"`
+	Audiences   *[]string `json:"audiences,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Issuer      *string   `json:"issuer,omitempty"`
+	Name        *string   `json:"name,omitempty"`
+	Subject     *string   `json:"subject,omitempty"`
+}
+
+// ApplicationFederatedIdentityCredentialsClient performs operations on the Federated Identity Credentials of Applications.
+type ApplicationFederatedIdentityCredentialsClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewApplicationFederatedIdentityCredentialsClient returns a new ApplicationFederatedIdentityCredentialsClient.
+func NewApplicationFederatedIdentityCredentialsClient(tenantId string) *ApplicationFederatedIdentityCredentialsClient {
+	return &ApplicationFederatedIdentityCredentialsClient{
+		BaseClient: msgraph.NewClient(msgraph.VersionBeta, tenantId),
+	}
+}
+
+// List returns the Federated Identity Credentials for an Application, following any paged results. An optional
+// OData filter can be specified.
+func (c *ApplicationFederatedIdentityCredentialsClient) List(ctx context.Context, id string, filter string) (*[]FederatedIdentityCredential, int, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Add("$filter", filter)
+	}
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/federatedIdentityCredentials", id),
+			Params:      params,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationFederatedIdentityCredentialsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		FederatedIdentityCredentials []FederatedIdentityCredential `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.FederatedIdentityCredentials, status, nil
+}
+
+// Get retrieves a Federated Identity Credential for an Application.
+func (c *ApplicationFederatedIdentityCredentialsClient) Get(ctx context.Context, id string, credentialId string) (*FederatedIdentityCredential, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/federatedIdentityCredentials/%s", id, credentialId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationFederatedIdentityCredentialsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var credential FederatedIdentityCredential
+	if err := json.Unmarshal(respBody, &credential); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &credential, status, nil
+}
+
+// Create adds a new Federated Identity Credential to an Application.
+func (c *ApplicationFederatedIdentityCredentialsClient) Create(ctx context.Context, id string, credential FederatedIdentityCredential) (*FederatedIdentityCredential, int, error) {
+	body, err := json.Marshal(credential)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/federatedIdentityCredentials", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationFederatedIdentityCredentialsClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newCredential FederatedIdentityCredential
+	if err := json.Unmarshal(respBody, &newCredential); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newCredential, status, nil
+}
+
+// Update amends an existing Federated Identity Credential for an Application. The name of a credential cannot be
+// changed, so should be omitted.
+func (c *ApplicationFederatedIdentityCredentialsClient) Update(ctx context.Context, id string, credential FederatedIdentityCredential) (int, error) {
+	if credential.ID == nil {
+		return 0, fmt.Errorf("cannot update federated identity credential with nil ID")
+	}
+	credentialId := *credential.ID
+	credential.ID = nil
+	body, err := json.Marshal(credential)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/federatedIdentityCredentials/%s", id, credentialId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationFederatedIdentityCredentialsClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
+
+// Delete removes a Federated Identity Credential from an Application.
+func (c *ApplicationFederatedIdentityCredentialsClient) Delete(ctx context.Context, id string, credentialId string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/federatedIdentityCredentials/%s", id, credentialId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationFederatedIdentityCredentialsClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
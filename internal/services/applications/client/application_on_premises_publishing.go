@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// OnPremisesPublishing describes the Application Proxy configuration for an application. This is only exposed by the
+// beta API, and the property name is misspelled in msgraph.Application, so it is modelled separately here.
+type OnPremisesPublishing struct {
+	ExternalAuthenticationType   *string `json:"externalAuthenticationType,omitempty"`
+	ExternalUrl                  *string `json:"externalUrl,omitempty"`
+	InternalUrl                  *string `json:"internalUrl,omitempty"`
+	IsHttpOnlyCookieEnabled      *bool   `json:"isHttpOnlyCookieEnabled,omitempty"`
+	IsOnPremPublishingEnabled    *bool   `json:"isOnPremPublishingEnabled,omitempty"`
+	IsPersistentCookieEnabled    *bool   `json:"isPersistentCookieEnabled,omitempty"`
+	IsTranslateHostHeaderEnabled *bool   `json:"isTranslateHostHeaderEnabled,omitempty"`
+}
+
+// ApplicationOnPremisesPublishingClient performs operations on the Application Proxy configuration of Applications.
+type ApplicationOnPremisesPublishingClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewApplicationOnPremisesPublishingClient returns a new ApplicationOnPremisesPublishingClient.
+func NewApplicationOnPremisesPublishingClient(tenantId string) *ApplicationOnPremisesPublishingClient {
+	return &ApplicationOnPremisesPublishingClient{
+		BaseClient: msgraph.NewClient(msgraph.VersionBeta, tenantId),
+	}
+}
+
+// Get retrieves the Application Proxy configuration for an Application.
+func (c *ApplicationOnPremisesPublishingClient) Get(ctx context.Context, id string) (*OnPremisesPublishing, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s", id),
+			Params:      url.Values{"$select": []string{"onPremisesPublishing"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationOnPremisesPublishingClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		OnPremisesPublishing *OnPremisesPublishing `json:"onPremisesPublishing"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return data.OnPremisesPublishing, status, nil
+}
+
+// Update amends the Application Proxy configuration for an Application.
+func (c *ApplicationOnPremisesPublishingClient) Update(ctx context.Context, id string, onPremisesPublishing OnPremisesPublishing) (int, error) {
+	body, err := json.Marshal(struct {
+		OnPremisesPublishing OnPremisesPublishing `json:"onPremisesPublishing"`
+	}{onPremisesPublishing})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationOnPremisesPublishingClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
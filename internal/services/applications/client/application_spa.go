@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ApplicationSpa describes the single-page application settings for an application. This is not modelled by
+// msgraph.Application, so it is modelled separately here.
+type ApplicationSpa struct {
+	RedirectUris *[]string `json:"redirectUris,omitempty"`
+}
+
+// ApplicationSpaClient performs operations on the single-page application settings of Applications.
+type ApplicationSpaClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewApplicationSpaClient returns a new ApplicationSpaClient.
+func NewApplicationSpaClient(tenantId string) *ApplicationSpaClient {
+	return &ApplicationSpaClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Get retrieves the single-page application settings for an Application.
+func (c *ApplicationSpaClient) Get(ctx context.Context, id string) (*ApplicationSpa, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s", id),
+			Params:      url.Values{"$select": []string{"spa"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ApplicationSpaClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Spa *ApplicationSpa `json:"spa"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return data.Spa, status, nil
+}
+
+// Update amends the single-page application settings for an Application.
+func (c *ApplicationSpaClient) Update(ctx context.Context, id string, spa ApplicationSpa) (int, error) {
+	body, err := json.Marshal(struct {
+		Spa ApplicationSpa `json:"spa"`
+	}{spa})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ApplicationSpaClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+// VerifiedPublisherError is returned when the verified publisher of an application could not be set or unset. The Code
+// is the OData error code returned by the API, which identifies the unmet prerequisite.
+type VerifiedPublisherError struct {
+	Code string
+	Err  error
+}
+
+func (e VerifiedPublisherError) Error() string {
+	return e.Err.Error()
+}
+
+// ApplicationVerifiedPublisherClient manages the verified publisher of Applications, which is set using separate actions
+// rather than by updating the application.
+type ApplicationVerifiedPublisherClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewApplicationVerifiedPublisherClient returns a new ApplicationVerifiedPublisherClient.
+func NewApplicationVerifiedPublisherClient(tenantId string) *ApplicationVerifiedPublisherClient {
+	return &ApplicationVerifiedPublisherClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Set sets the verified publisher of an Application, using the Microsoft Partner Network (MPN) ID of the publisher.
+func (c *ApplicationVerifiedPublisherClient) Set(ctx context.Context, id string, verifiedPublisherId string) (int, error) {
+	body, err := json.Marshal(struct {
+		VerifiedPublisherId string `json:"verifiedPublisherId"`
+	}{verifiedPublisherId})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, o, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/setVerifiedPublisher", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, verifiedPublisherError(o, fmt.Errorf("ApplicationVerifiedPublisherClient.BaseClient.Post(): %v", err))
+	}
+	return status, nil
+}
+
+// Unset removes the verified publisher of an Application.
+func (c *ApplicationVerifiedPublisherClient) Unset(ctx context.Context, id string) (int, error) {
+	_, status, o, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/applications/%s/unsetVerifiedPublisher", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, verifiedPublisherError(o, fmt.Errorf("ApplicationVerifiedPublisherClient.BaseClient.Post(): %v", err))
+	}
+	return status, nil
+}
+
+func verifiedPublisherError(o *odata.OData, err error) error {
+	if o == nil || o.Error == nil || o.Error.Code == nil {
+		return err
+	}
+	return VerifiedPublisherError{Code: *o.Error.Code, Err: err}
+}
@@ -7,14 +7,34 @@ import (
 )
 
 type Client struct {
-	ApplicationsClient *msgraph.ApplicationsClient
+	ApplicationsClient                            *msgraph.ApplicationsClient
+	ApplicationFederatedIdentityCredentialsClient *ApplicationFederatedIdentityCredentialsClient
+	ApplicationOnPremisesPublishingClient         *ApplicationOnPremisesPublishingClient
+	ApplicationSpaClient                          *ApplicationSpaClient
+	ApplicationVerifiedPublisherClient            *ApplicationVerifiedPublisherClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
 	msClient := msgraph.NewApplicationsClient(o.TenantID)
 	o.ConfigureClient(&msClient.BaseClient)
 
+	federatedIdentityCredentialsClient := NewApplicationFederatedIdentityCredentialsClient(o.TenantID)
+	o.ConfigureClient(&federatedIdentityCredentialsClient.BaseClient)
+
+	onPremisesPublishingClient := NewApplicationOnPremisesPublishingClient(o.TenantID)
+	o.ConfigureClient(&onPremisesPublishingClient.BaseClient)
+
+	spaClient := NewApplicationSpaClient(o.TenantID)
+	o.ConfigureClient(&spaClient.BaseClient)
+
+	verifiedPublisherClient := NewApplicationVerifiedPublisherClient(o.TenantID)
+	o.ConfigureClient(&verifiedPublisherClient.BaseClient)
+
 	return &Client{
 		ApplicationsClient: msClient,
+		ApplicationFederatedIdentityCredentialsClient: federatedIdentityCredentialsClient,
+		ApplicationOnPremisesPublishingClient:         onPremisesPublishingClient,
+		ApplicationSpaClient:                          spaClient,
+		ApplicationVerifiedPublisherClient:            verifiedPublisherClient,
 	}
 }
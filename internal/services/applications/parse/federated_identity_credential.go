@@ -0,0 +1,31 @@
+package parse
+
+import "fmt"
+
+type FederatedIdentityCredentialId struct {
+	ObjectId     string
+	CredentialId string
+}
+
+func NewFederatedIdentityCredentialID(objectId, credentialId string) FederatedIdentityCredentialId {
+	return FederatedIdentityCredentialId{
+		ObjectId:     objectId,
+		CredentialId: credentialId,
+	}
+}
+
+func (id FederatedIdentityCredentialId) String() string {
+	return id.ObjectId + "/federatedIdentityCredential/" + id.CredentialId
+}
+
+func FederatedIdentityCredentialID(idString string) (*FederatedIdentityCredentialId, error) {
+	id, err := ObjectSubResourceID(idString, "federatedIdentityCredential")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Federated Identity Credential ID: %v", err)
+	}
+
+	return &FederatedIdentityCredentialId{
+		ObjectId:     id.objectId,
+		CredentialId: id.subId,
+	}, nil
+}
@@ -21,16 +21,21 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"azuread_application": applicationDataSource(),
+		"azuread_application":                                applicationDataSource(),
+		"azuread_application_app_role":                       applicationAppRoleDataSource(),
+		"azuread_application_federated_identity_credentials": applicationFederatedIdentityCredentialsDataSource(),
+		"azuread_application_permission_scope":               applicationPermissionScopeDataSource(),
+		"azuread_applications":                               applicationsDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"azuread_application":                applicationResource(),
-		"azuread_application_certificate":    applicationCertificateResource(),
-		"azuread_application_password":       applicationPasswordResource(),
-		"azuread_application_pre_authorized": applicationPreAuthorizedResource(),
+		"azuread_application":                               applicationResource(),
+		"azuread_application_certificate":                   applicationCertificateResource(),
+		"azuread_application_federated_identity_credential": applicationFederatedIdentityCredentialResource(),
+		"azuread_application_password":                      applicationPasswordResource(),
+		"azuread_application_pre_authorized":                applicationPreAuthorizedResource(),
 	}
 }
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+type Client struct {
+	ConditionalAccessPolicyClient *ConditionalAccessPolicyClient
+	CountryNamedLocationsClient   *CountryNamedLocationsClient
+	NamedLocationsClient          *msgraph.NamedLocationsClient
+	PoliciesClient                *msgraph.ConditionalAccessPolicyClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	conditionalAccessPolicyClient := NewConditionalAccessPolicyClient(o.TenantID)
+	o.ConfigureClient(&conditionalAccessPolicyClient.BaseClient)
+
+	countryNamedLocationsClient := NewCountryNamedLocationsClient(o.TenantID)
+	o.ConfigureClient(&countryNamedLocationsClient.BaseClient)
+
+	namedLocationsClient := msgraph.NewNamedLocationsClient(o.TenantID)
+	o.ConfigureClient(&namedLocationsClient.BaseClient)
+
+	policiesClient := msgraph.NewConditionalAccessPolicyClient(o.TenantID)
+	o.ConfigureClient(&policiesClient.BaseClient)
+
+	return &Client{
+		ConditionalAccessPolicyClient: conditionalAccessPolicyClient,
+		CountryNamedLocationsClient:   countryNamedLocationsClient,
+		NamedLocationsClient:          namedLocationsClient,
+		PoliciesClient:                policiesClient,
+	}
+}
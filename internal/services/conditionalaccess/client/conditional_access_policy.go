@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const (
+	ExternalTenantsMembershipKindAll        = "all"
+	ExternalTenantsMembershipKindEnumerated = "enumerated"
+)
+
+const (
+	DeviceFilterModeExclude = "exclude"
+	DeviceFilterModeInclude = "include"
+)
+
+// ConditionalAccessPolicy is a conditional access policy whose conditions include properties which are not modelled by
+// msgraph.ConditionalAccessPolicy
+type ConditionalAccessPolicy struct {
+	Conditions       *ConditionalAccessConditionSet            `json:"conditions,omitempty"`
+	CreatedDateTime  *time.Time                                `json:"createdDateTime,omitempty"`
+	DisplayName      *string                                   `json:"displayName,omitempty"`
+	GrantControls    *msgraph.ConditionalAccessGrantControls   `json:"grantControls,omitempty"`
+	ID               *string                                   `json:"id,omitempty"`
+	ModifiedDateTime *time.Time                                `json:"modifiedDateTime,omitempty"`
+	SessionControls  *msgraph.ConditionalAccessSessionControls `json:"sessionControls,omitempty"`
+	State            *string                                   `json:"state,omitempty"`
+}
+
+// ConditionalAccessConditionSet is a msgraph.ConditionalAccessConditionSet whose users condition additionally includes
+// guests and external users, and which additionally includes the devices condition
+type ConditionalAccessConditionSet struct {
+	Applications     *msgraph.ConditionalAccessApplications `json:"applications,omitempty"`
+	Users            *ConditionalAccessUsers                `json:"users,omitempty"`
+	ClientAppTypes   *[]string                              `json:"clientAppTypes,omitempty"`
+	Devices          *ConditionalAccessDevices              `json:"devices,omitempty"`
+	Locations        *msgraph.ConditionalAccessLocations    `json:"locations,omitempty"`
+	Platforms        *msgraph.ConditionalAccessPlatforms    `json:"platforms,omitempty"`
+	SignInRiskLevels *[]string                              `json:"signInRiskLevels,omitempty"`
+	UserRiskLevels   *[]string                              `json:"userRiskLevels,omitempty"`
+}
+
+// ConditionalAccessDevices describes the devices targeted by a policy. DeviceFilter is always marshalled, so that an
+// empty ConditionalAccessDevices removes an existing device filter from a policy.
+type ConditionalAccessDevices struct {
+	DeviceFilter *ConditionalAccessFilter `json:"deviceFilter"`
+}
+
+// ConditionalAccessFilter is a rule expression which includes or excludes the matching devices
+type ConditionalAccessFilter struct {
+	Mode *string `json:"mode,omitempty"`
+	Rule *string `json:"rule,omitempty"`
+}
+
+// ConditionalAccessUsers is a msgraph.ConditionalAccessUsers which additionally includes the guests and external users
+// included in and excluded from a policy
+type ConditionalAccessUsers struct {
+	msgraph.ConditionalAccessUsers
+	IncludeGuestsOrExternalUsers *ConditionalAccessGuestsOrExternalUsers `json:"includeGuestsOrExternalUsers,omitempty"`
+	ExcludeGuestsOrExternalUsers *ConditionalAccessGuestsOrExternalUsers `json:"excludeGuestsOrExternalUsers,omitempty"`
+}
+
+// ConditionalAccessGuestsOrExternalUsers describes the types of guests and external users, and the tenants they belong
+// to, which are targeted by a policy. GuestOrExternalUserTypes is a comma-separated list of types.
+type ConditionalAccessGuestsOrExternalUsers struct {
+	GuestOrExternalUserTypes *string                           `json:"guestOrExternalUserTypes,omitempty"`
+	ExternalTenants          *ConditionalAccessExternalTenants `json:"externalTenants,omitempty"`
+}
+
+// ConditionalAccessExternalTenants describes the external tenants targeted by a policy, which are either all external
+// tenants or the tenants listed in Members
+type ConditionalAccessExternalTenants struct {
+	ODataType      *string   `json:"@odata.type,omitempty"`
+	MembershipKind *string   `json:"membershipKind,omitempty"`
+	Members        *[]string `json:"members,omitempty"`
+}
+
+// ConditionalAccessPolicyClient performs operations on conditional access policies using ConditionalAccessPolicy, so
+// that conditions which are not modelled by msgraph.ConditionalAccessPolicy are sent and returned.
+type ConditionalAccessPolicyClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewConditionalAccessPolicyClient returns a new ConditionalAccessPolicyClient.
+func NewConditionalAccessPolicyClient(tenantId string) *ConditionalAccessPolicyClient {
+	return &ConditionalAccessPolicyClient{
+		BaseClient: msgraph.NewClient(msgraph.VersionBeta, tenantId),
+	}
+}
+
+// Create creates a new conditional access policy.
+func (c *ConditionalAccessPolicyClient) Create(ctx context.Context, policy ConditionalAccessPolicy) (*ConditionalAccessPolicy, int, error) {
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/identity/conditionalAccess/policies",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newPolicy ConditionalAccessPolicy
+	if err := json.Unmarshal(respBody, &newPolicy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newPolicy, status, nil
+}
+
+// Get retrieves a conditional access policy.
+func (c *ConditionalAccessPolicyClient) Get(ctx context.Context, id string) (*ConditionalAccessPolicy, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/identity/conditionalAccess/policies/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var policy ConditionalAccessPolicy
+	if err := json.Unmarshal(respBody, &policy); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &policy, status, nil
+}
+
+// Update amends an existing conditional access policy.
+func (c *ConditionalAccessPolicyClient) Update(ctx context.Context, policy ConditionalAccessPolicy) (int, error) {
+	if policy.ID == nil {
+		return 0, errors.New("cannot update conditional access policy with nil ID")
+	}
+	body, err := json.Marshal(policy)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/identity/conditionalAccess/policies/%s", *policy.ID),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ConditionalAccessPolicyClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
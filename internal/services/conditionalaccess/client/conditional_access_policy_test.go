@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestConditionalAccessPolicyClient(t *testing.T) {
+	const id = "11111111-1111-1111-1111-111111111111"
+
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decoding request: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id":%q,"displayName":"guests","conditions":{"users":{"includeUsers":[],"includeGuestsOrExternalUsers":{"guestOrExternalUserTypes":"internalGuest,serviceProvider","externalTenants":{"@odata.type":"#microsoft.graph.conditionalAccessEnumeratedExternalTenants","membershipKind":"enumerated","members":["22222222-2222-2222-2222-222222222222"]}}}}}`, id)
+		default:
+			t.Errorf("unexpected method %q", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewConditionalAccessPolicyClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	if _, err := client.Update(context.Background(), ConditionalAccessPolicy{
+		ID: utils.String(id),
+		Conditions: &ConditionalAccessConditionSet{
+			Users: &ConditionalAccessUsers{
+				ConditionalAccessUsers: msgraph.ConditionalAccessUsers{IncludeUsers: &[]string{}},
+				ExcludeGuestsOrExternalUsers: &ConditionalAccessGuestsOrExternalUsers{
+					GuestOrExternalUserTypes: utils.String("internalGuest"),
+					ExternalTenants: &ConditionalAccessExternalTenants{
+						ODataType:      utils.String("#microsoft.graph.conditionalAccessAllExternalTenants"),
+						MembershipKind: utils.String(ExternalTenantsMembershipKindAll),
+					},
+				},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+	users := sent["conditions"].(map[string]interface{})["users"].(map[string]interface{})
+	excluded, ok := users["excludeGuestsOrExternalUsers"].(map[string]interface{})
+	if !ok || excluded["guestOrExternalUserTypes"] != "internalGuest" {
+		t.Fatalf("expected excluded guests or external users to be sent, got %v", users)
+	}
+
+	policy, _, err := client.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error retrieving: %v", err)
+	}
+	included := policy.Conditions.Users.IncludeGuestsOrExternalUsers
+	if included == nil || included.GuestOrExternalUserTypes == nil || *included.GuestOrExternalUserTypes != "internalGuest,serviceProvider" {
+		t.Fatalf("expected included guests or external users to be returned, got %#v", included)
+	}
+	if included.ExternalTenants == nil || included.ExternalTenants.Members == nil || len(*included.ExternalTenants.Members) != 1 {
+		t.Fatalf("expected enumerated external tenants to be returned, got %#v", included.ExternalTenants)
+	}
+}
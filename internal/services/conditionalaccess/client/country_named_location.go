@@ -0,0 +1,142 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+const (
+	CountryLookupMethodAuthenticatorAppGps = "authenticatorAppGps"
+	CountryLookupMethodClientIpAddress     = "clientIpAddress"
+)
+
+// CountryNamedLocation is a CountryNamedLocation which additionally includes the method used to determine the country
+// of a sign-in, which is not modelled by msgraph.CountryNamedLocation
+type CountryNamedLocation struct {
+	msgraph.CountryNamedLocation
+	CountryLookupMethod *string `json:"countryLookupMethod,omitempty"`
+}
+
+// CountryNamedLocationsClient performs operations on Country Named Locations which involve their country lookup method.
+type CountryNamedLocationsClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewCountryNamedLocationsClient returns a new CountryNamedLocationsClient.
+func NewCountryNamedLocationsClient(tenantId string) *CountryNamedLocationsClient {
+	return &CountryNamedLocationsClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Create creates a new Country Named Location.
+func (c *CountryNamedLocationsClient) Create(ctx context.Context, location CountryNamedLocation) (*CountryNamedLocation, int, error) {
+	if location.BaseNamedLocation == nil {
+		location.BaseNamedLocation = &msgraph.BaseNamedLocation{}
+	}
+	location.ODataType = utils.String("#microsoft.graph.countryNamedLocation")
+	body, err := json.Marshal(location)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/identity/conditionalAccess/namedLocations",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("CountryNamedLocationsClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newLocation CountryNamedLocation
+	if err := json.Unmarshal(respBody, &newLocation); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newLocation, status, nil
+}
+
+// Get retrieves a Named Location of any type. Country Named Locations are returned as a CountryNamedLocation from this
+// package, so that the country lookup method is included, and IP Named Locations as a msgraph.IPNamedLocation.
+func (c *CountryNamedLocationsClient) Get(ctx context.Context, id string) (msgraph.NamedLocation, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("CountryNamedLocationsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var o odata.OData
+	if err := json.Unmarshal(respBody, &o); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if o.Type == nil {
+		return nil, status, nil
+	}
+
+	switch *o.Type {
+	case "#microsoft.graph.countryNamedLocation":
+		var location CountryNamedLocation
+		if err := json.Unmarshal(respBody, &location); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+		return location, status, nil
+	case "#microsoft.graph.ipNamedLocation":
+		var location msgraph.IPNamedLocation
+		if err := json.Unmarshal(respBody, &location); err != nil {
+			return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+		}
+		return location, status, nil
+	}
+
+	return nil, status, fmt.Errorf("unsupported named location type %q", *o.Type)
+}
+
+// Update amends an existing Country Named Location.
+func (c *CountryNamedLocationsClient) Update(ctx context.Context, location CountryNamedLocation) (int, error) {
+	if location.BaseNamedLocation == nil || location.ID == nil {
+		return 0, fmt.Errorf("cannot update named location with a nil ID")
+	}
+	location.ODataType = utils.String("#microsoft.graph.countryNamedLocation")
+	body, err := json.Marshal(location)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/identity/conditionalAccess/namedLocations/%s", *location.ID),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("CountryNamedLocationsClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
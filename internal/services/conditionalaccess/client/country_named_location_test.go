@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestCountryNamedLocationsClient(t *testing.T) {
+	const id = "11111111-1111-1111-1111-111111111111"
+
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPatch:
+			if err := json.NewDecoder(r.Body).Decode(&sent); err != nil {
+				t.Errorf("decoding request: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"@odata.type":"#microsoft.graph.countryNamedLocation","id":%q,"displayName":"office","countriesAndRegions":["GB"],"countryLookupMethod":"authenticatorAppGps"}`, id)
+		default:
+			t.Errorf("unexpected method %q", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewCountryNamedLocationsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	if _, err := client.Update(context.Background(), CountryNamedLocation{
+		CountryNamedLocation: msgraph.CountryNamedLocation{
+			BaseNamedLocation:   &msgraph.BaseNamedLocation{ID: utils.String(id), DisplayName: utils.String("office")},
+			CountriesAndRegions: &[]string{"GB"},
+		},
+		CountryLookupMethod: utils.String(CountryLookupMethodAuthenticatorAppGps),
+	}); err != nil {
+		t.Fatalf("unexpected error updating: %v", err)
+	}
+	if sent["@odata.type"] != "#microsoft.graph.countryNamedLocation" || sent["countryLookupMethod"] != CountryLookupMethodAuthenticatorAppGps {
+		t.Fatalf("expected the type and lookup method to be sent, got %v", sent)
+	}
+
+	location, _, err := client.Get(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error retrieving: %v", err)
+	}
+	country, ok := location.(CountryNamedLocation)
+	if !ok {
+		t.Fatalf("expected a CountryNamedLocation, got %T", location)
+	}
+	if country.CountryLookupMethod == nil || *country.CountryLookupMethod != CountryLookupMethodAuthenticatorAppGps {
+		t.Fatalf("expected lookup method %q, got %v", CountryLookupMethodAuthenticatorAppGps, country.CountryLookupMethod)
+	}
+}
@@ -0,0 +1,194 @@
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func conditionalAccessPolicyDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: conditionalAccessPolicyDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The display name of the conditional access policy",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "object_id"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"object_id": {
+				Description:      "The ID of the conditional access policy",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"resolve_display_names": {
+				Description: "Whether to resolve the display names of the users and groups referenced by the policy",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"conditions_summary": {
+				Description: "A summary of the users, groups, roles and applications targeted by the policy",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"all_users": {
+							Description: "Whether the policy includes all users",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"guests_or_external_users_included": {
+							Description: "Whether the policy includes guests or external users",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"guests_or_external_users_excluded": {
+							Description: "Whether the policy excludes guests or external users",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"included_users": {
+							Description: "The number of users included in the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"excluded_users": {
+							Description: "The number of users excluded from the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"included_groups": {
+							Description: "The number of groups included in the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"excluded_groups": {
+							Description: "The number of groups excluded from the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"included_roles": {
+							Description: "The number of roles included in the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"excluded_roles": {
+							Description: "The number of roles excluded from the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"all_applications": {
+							Description: "Whether the policy includes all applications",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"included_applications": {
+							Description: "The number of applications included in the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+
+						"excluded_applications": {
+							Description: "The number of applications excluded from the policy",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"resolved_display_names": {
+				Description: "A mapping of the object IDs of users and groups referenced by the policy to their display names. Only populated when `resolve_display_names` is `true`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"state": {
+				Description: "The state of the policy",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func conditionalAccessPolicyDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policiesClient := meta.(*clients.Client).ConditionalAccess.PoliciesClient
+	policyClient := meta.(*clients.Client).ConditionalAccess.ConditionalAccessPolicyClient
+
+	id := d.Get("object_id").(string)
+	if displayName := d.Get("display_name").(string); displayName != "" {
+		var err error
+		if id, err = conditionalAccessPolicyFindByDisplayName(ctx, policiesClient, displayName); err != nil {
+			return tf.ErrorDiagPathF(err, "display_name", "Retrieving conditional access policy")
+		}
+	}
+
+	policy, status, err := policyClient.Get(ctx, id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return tf.ErrorDiagPathF(nil, "object_id", "No conditional access policy was found with ID %q", id)
+		}
+		return tf.ErrorDiagF(err, "Retrieving conditional access policy with ID %q", id)
+	}
+	if policy == nil || policy.ID == nil {
+		return tf.ErrorDiagF(errors.New("API returned conditional access policy with nil ID"), "Bad API response")
+	}
+
+	resolvedDisplayNames := make(map[string]interface{})
+	if d.Get("resolve_display_names").(bool) {
+		if ids := conditionalAccessPolicyReferencedObjectIds(policy.Conditions); len(ids) > 0 {
+			objects, err := helpers.DirectoryObjectsGetByIds(ctx, policyClient.BaseClient, ids)
+			if err != nil {
+				return tf.ErrorDiagPathF(err, "resolved_display_names", "Could not resolve objects referenced by conditional access policy with ID %q", *policy.ID)
+			}
+			resolvedDisplayNames = conditionalAccessPolicyResolvedDisplayNames(ids, objects)
+		}
+	}
+
+	d.SetId(*policy.ID)
+
+	tf.Set(d, "conditions_summary", flattenConditionalAccessPolicyConditionsSummary(policy.Conditions))
+	tf.Set(d, "display_name", policy.DisplayName)
+	tf.Set(d, "object_id", policy.ID)
+	tf.Set(d, "resolved_display_names", resolvedDisplayNames)
+	tf.Set(d, "state", policy.State)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package conditionalaccess_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ConditionalAccessPolicyDataSource struct{}
+
+func TestAccConditionalAccessPolicyDataSource_byObjectId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byObjectId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-CONPOLICY-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("state").HasValue("disabled"),
+				check.That(data.ResourceName).Key("conditions_summary.0.all_users").HasValue("false"),
+				check.That(data.ResourceName).Key("conditions_summary.0.included_groups").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions_summary.0.included_roles").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions_summary.0.excluded_roles").HasValue("1"),
+				check.That(data.ResourceName).Key("conditions_summary.0.all_applications").HasValue("true"),
+				check.That(data.ResourceName).Key("resolved_display_names.%").HasValue("0"),
+			),
+		},
+	})
+}
+
+func TestAccConditionalAccessPolicyDataSource_byDisplayNameWithDisplayNames(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_conditional_access_policy", "test")
+	r := ConditionalAccessPolicyDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byDisplayNameWithDisplayNames(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+				check.That(data.ResourceName).Key("resolved_display_names.%").HasValue("1"),
+				check.That(data.ResourceName).Key("resolved_display_names.%").MatchesOtherKey(check.That(data.ResourceName).Key("conditions_summary.0.included_groups")),
+			),
+		},
+	})
+}
+
+func (ConditionalAccessPolicyDataSource) byObjectId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_conditional_access_policy" "test" {
+  object_id = azuread_conditional_access_policy.test.id
+}
+`, ConditionalAccessPolicyResource{}.groupsAndRoles(data))
+}
+
+func (ConditionalAccessPolicyDataSource) byDisplayNameWithDisplayNames(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_conditional_access_policy" "test" {
+  display_name          = azuread_conditional_access_policy.test.display_name
+  resolve_display_names = true
+}
+`, ConditionalAccessPolicyResource{}.groupsAndRoles(data))
+}
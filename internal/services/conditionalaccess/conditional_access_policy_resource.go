@@ -0,0 +1,721 @@
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+const conditionalAccessPolicyDisplayNameImportPrefix = "displayName/"
+
+func conditionalAccessPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: conditionalAccessPolicyResourceCreate,
+		ReadContext:   conditionalAccessPolicyResourceRead,
+		UpdateContext: conditionalAccessPolicyResourceUpdate,
+		DeleteContext: conditionalAccessPolicyResourceDelete,
+
+		CustomizeDiff: conditionalAccessPolicyResourceCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImportThen(func(id string) error {
+			if strings.HasPrefix(id, conditionalAccessPolicyDisplayNameImportPrefix) {
+				if strings.TrimPrefix(id, conditionalAccessPolicyDisplayNameImportPrefix) == "" {
+					return fmt.Errorf("specified ID (%q) does not contain a display name", id)
+				}
+				return nil
+			}
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
+			}
+			return nil
+		}, conditionalAccessPolicyResourceImport),
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The friendly name for this conditional access policy",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"state": {
+				Description: "Specifies the state of the policy object",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"disabled",
+					"enabled",
+					"enabledForReportingButNotEnforced",
+				}, false),
+			},
+
+			"conditions": {
+				Description: "Specifies the rules that must be met for the policy to apply",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"applications": {
+							Description: "Applications and user actions included in and excluded from the policy",
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"included_applications": {
+										Description: "A list of application IDs the policy applies to, unless explicitly excluded, or one of `All`, `None` or `Office365`",
+										Type:        schema.TypeList,
+										Optional:    true,
+										AtLeastOneOf: []string{
+											"conditions.0.applications.0.included_applications",
+											"conditions.0.applications.0.included_user_actions",
+										},
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"excluded_applications": {
+										Description: "A list of application IDs explicitly excluded from the policy, or `Office365`",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"included_user_actions": {
+										Description: "A list of user actions to include",
+										Type:        schema.TypeList,
+										Optional:    true,
+										AtLeastOneOf: []string{
+											"conditions.0.applications.0.included_applications",
+											"conditions.0.applications.0.included_user_actions",
+										},
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{
+												"urn:user:registerdevice",
+												"urn:user:registersecurityinfo",
+											}, false),
+										},
+									},
+								},
+							},
+						},
+
+						"users": {
+							Description: "Users, groups and roles included in and excluded from the policy",
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									// At least one of the included users, groups or roles must be non-empty (see
+									// conditionalAccessPolicyResourceCustomizeDiff)
+									"included_users": {
+										Description: "A list of user IDs the policy applies to, unless explicitly excluded, or one of `All`, `None` or `GuestsOrExternalUsers`. `GuestsOrExternalUsers` cannot be specified with `included_guests_or_external_users`",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"excluded_users": {
+										Description: "A list of user IDs explicitly excluded from the policy, or `GuestsOrExternalUsers`. `GuestsOrExternalUsers` cannot be specified with `excluded_guests_or_external_users`",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"included_groups": {
+										Description: "A list of group IDs the policy applies to, unless explicitly excluded",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.UUID,
+										},
+									},
+
+									"excluded_groups": {
+										Description: "A list of group IDs explicitly excluded from the policy",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.UUID,
+										},
+									},
+
+									"included_roles": {
+										Description: "A list of directory role template IDs the policy applies to, unless explicitly excluded",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.UUID,
+										},
+									},
+
+									"excluded_roles": {
+										Description: "A list of directory role template IDs explicitly excluded from the policy",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.UUID,
+										},
+									},
+
+									"included_guests_or_external_users": conditionalAccessGuestsOrExternalUsersSchema("Guests or external users the policy applies to, unless explicitly excluded"),
+
+									"excluded_guests_or_external_users": conditionalAccessGuestsOrExternalUsersSchema("Guests or external users explicitly excluded from the policy"),
+								},
+							},
+						},
+
+						"client_app_types": {
+							Description: "A list of client application types included in the policy",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"all",
+									"browser",
+									"mobileAppsAndDesktopClients",
+									"exchangeActiveSync",
+									"easSupported",
+									"other",
+								}, false),
+							},
+						},
+
+						"devices": {
+							Description: "Devices included in and excluded from the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"filter": {
+										Description: "A filter which includes or excludes the devices matching a rule",
+										Type:        schema.TypeList,
+										Required:    true,
+										MaxItems:    1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"mode": {
+													Description: "Whether devices matching the rule are included in or excluded from the policy",
+													Type:        schema.TypeString,
+													Required:    true,
+													ValidateFunc: validation.StringInSlice([]string{
+														client.DeviceFilterModeExclude,
+														client.DeviceFilterModeInclude,
+													}, false),
+												},
+
+												"rule": {
+													Description:      "The rule expression used to match devices",
+													Type:             schema.TypeString,
+													Required:         true,
+													ValidateDiagFunc: validate.NoEmptyStrings,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+
+						// The API does not support removing locations or platforms from a policy, so removing either of
+						// these blocks forces replacement (see conditionalAccessPolicyResourceCustomizeDiff)
+						"locations": {
+							Description: "Locations included in and excluded from the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"included_locations": {
+										Description: "A list of named location IDs the policy applies to, unless explicitly excluded, or one of `All` or `AllTrusted`",
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+
+									"excluded_locations": {
+										Description: "A list of named location IDs explicitly excluded from the policy, or `AllTrusted`",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:             schema.TypeString,
+											ValidateDiagFunc: validate.NoEmptyStrings,
+										},
+									},
+								},
+							},
+						},
+
+						"platforms": {
+							Description: "Device platforms included in and excluded from the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"included_platforms": {
+										Description: "A list of platforms the policy applies to, unless explicitly excluded",
+										Type:        schema.TypeList,
+										Required:    true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice(conditionalAccessPolicyPlatforms, false),
+										},
+									},
+
+									"excluded_platforms": {
+										Description: "A list of platforms explicitly excluded from the policy",
+										Type:        schema.TypeList,
+										Optional:    true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice(conditionalAccessPolicyPlatforms, false),
+										},
+									},
+								},
+							},
+						},
+
+						"sign_in_risk_levels": {
+							Description: "A list of sign-in risk levels included in the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(conditionalAccessPolicyRiskLevels, false),
+							},
+						},
+
+						"user_risk_levels": {
+							Description: "A list of user risk levels included in the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(conditionalAccessPolicyRiskLevels, false),
+							},
+						},
+					},
+				},
+			},
+
+			"grant_controls": {
+				Description: "Specifies the controls which must be satisfied to grant access",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operator": {
+							Description:  "Defines the relationship of the grant controls",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"AND", "OR"}, false),
+						},
+
+						"built_in_controls": {
+							Description: "A list of built-in controls required by the policy",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"approvedApplication",
+									"block",
+									"compliantApplication",
+									"compliantDevice",
+									"domainJoinedDevice",
+									"mfa",
+									"passwordChange",
+								}, false),
+							},
+						},
+
+						"custom_authentication_factors": {
+							Description: "A list of custom controls IDs required by the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.NoEmptyStrings,
+							},
+						},
+
+						"terms_of_use": {
+							Description: "A list of terms of use IDs required by the policy",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.NoEmptyStrings,
+							},
+						},
+					},
+				},
+			},
+
+			"session_controls": {
+				Description: "Specifies the session controls that are enforced after sign-in",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"application_enforced_restrictions_enabled": {
+							Description: "Whether or not application enforced restrictions are enabled",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+
+						"cloud_app_security_policy": {
+							Description: "Enables cloud app security and specifies the cloud app security policy to use",
+							Type:        schema.TypeString,
+							Optional:    true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"blockDownloads",
+								"mcasConfigured",
+								"monitorOnly",
+							}, false),
+						},
+
+						"persistent_browser_mode": {
+							Description:  "Session control to define whether to persist cookies or not",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"always", "never"}, false),
+						},
+
+						"sign_in_frequency": {
+							Description:  "Number of days or hours to enforce sign-in frequency",
+							Type:         schema.TypeInt,
+							Optional:     true,
+							RequiredWith: []string{"session_controls.0.sign_in_frequency_period"},
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"sign_in_frequency_period": {
+							Description:  "The time period to enforce sign-in frequency",
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"session_controls.0.sign_in_frequency"},
+							ValidateFunc: validation.StringInSlice([]string{"days", "hours"}, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func conditionalAccessGuestsOrExternalUsersSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"guest_or_external_user_types": {
+					Description: "The types of guests and external users",
+					Type:        schema.TypeSet,
+					Required:    true,
+					MinItems:    1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(conditionalAccessPolicyGuestOrExternalUserTypes, false),
+					},
+				},
+
+				"external_tenants": {
+					Description:      "The external tenants of the guests and external users. All external tenants are included when omitted",
+					Type:             schema.TypeList,
+					Optional:         true,
+					MaxItems:         1,
+					DiffSuppressFunc: conditionalAccessExternalTenantsDiffSuppress,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"membership_kind": {
+								Description: "Whether all external tenants, or only those listed in `members`, are included",
+								Type:        schema.TypeString,
+								Required:    true,
+								ValidateFunc: validation.StringInSlice([]string{
+									client.ExternalTenantsMembershipKindAll,
+									client.ExternalTenantsMembershipKindEnumerated,
+								}, false),
+							},
+
+							"members": {
+								Description: "A list of tenant IDs, which must be specified when `membership_kind` is `enumerated`",
+								Type:        schema.TypeList,
+								Optional:    true,
+								Elem: &schema.Schema{
+									Type:             schema.TypeString,
+									ValidateDiagFunc: validate.UUID,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// conditionalAccessExternalTenantsDiffSuppress suppresses the difference between an omitted `external_tenants` block
+// and one which includes all external tenants, since the API returns the latter when external tenants are not specified
+func conditionalAccessExternalTenantsDiffSuppress(k, _, _ string, d *schema.ResourceData) bool {
+	key := k[:strings.LastIndex(k, "external_tenants")+len("external_tenants")]
+	oldValue, newValue := d.GetChange(key)
+	if len(newValue.([]interface{})) > 0 {
+		return false
+	}
+	old := oldValue.([]interface{})
+	return len(old) == 1 && old[0] != nil && old[0].(map[string]interface{})["membership_kind"] == client.ExternalTenantsMembershipKindAll
+}
+
+// conditionalAccessPolicyGuestsOrExternalUsers is the legacy value of `included_users` or `excluded_users` which targets
+// all guests and external users, superseded by the `included_guests_or_external_users` and
+// `excluded_guests_or_external_users` blocks
+const conditionalAccessPolicyGuestsOrExternalUsers = "GuestsOrExternalUsers"
+
+var (
+	conditionalAccessPolicyGuestOrExternalUserTypes = []string{"b2bCollaborationGuest", "b2bCollaborationMember", "b2bDirectConnectUser", "internalGuest", "otherExternalUser", "serviceProvider"}
+	conditionalAccessPolicyPlatforms                = []string{"all", "android", "iOS", "macOS", "windows", "windowsPhone", "unknownFutureValue"}
+	conditionalAccessPolicyRiskLevels               = []string{"hidden", "high", "low", "medium", "none", "unknownFutureValue"}
+)
+
+func conditionalAccessPolicyResourceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// The API rejects policies which do not include any users, groups or roles
+	included := false
+	for _, attr := range []string{"included_users", "included_groups", "included_roles", "included_guests_or_external_users"} {
+		key := "conditions.0.users.0." + attr
+		if !diff.NewValueKnown(key) || len(diff.Get(key).([]interface{})) > 0 {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return fmt.Errorf("at least one of `included_users`, `included_groups`, `included_roles` or `included_guests_or_external_users` must be specified in the `users` block")
+	}
+
+	for _, direction := range []string{"included", "excluded"} {
+		if err := conditionalAccessPolicyValidateGuestsOrExternalUsers(diff, direction); err != nil {
+			return err
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	// Locations and platforms cannot be removed from an existing policy, since the API ignores them when omitted
+	for _, attr := range []string{"conditions.0.locations", "conditions.0.platforms"} {
+		if oldValue, newValue := diff.GetChange(attr); len(oldValue.([]interface{})) > 0 && len(newValue.([]interface{})) == 0 {
+			if err := diff.ForceNew(attr); err != nil {
+				return fmt.Errorf("could not mark `%s` as requiring replacement: %v", attr, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// conditionalAccessPolicyValidateGuestsOrExternalUsers ensures that the legacy `GuestsOrExternalUsers` value of
+// `included_users` or `excluded_users` is not combined with the corresponding guests or external users block, and that
+// enumerated external tenants are listed
+func conditionalAccessPolicyValidateGuestsOrExternalUsers(diff *schema.ResourceDiff, direction string) error {
+	usersKey := fmt.Sprintf("conditions.0.users.0.%s_users", direction)
+	blockKey := fmt.Sprintf("conditions.0.users.0.%s_guests_or_external_users", direction)
+
+	block := diff.Get(blockKey).([]interface{})
+	if len(block) == 0 {
+		return nil
+	}
+
+	if diff.NewValueKnown(usersKey) {
+		for _, v := range diff.Get(usersKey).([]interface{}) {
+			if v == conditionalAccessPolicyGuestsOrExternalUsers {
+				return fmt.Errorf("`%s_users` cannot contain %q when `%s_guests_or_external_users` is specified", direction, conditionalAccessPolicyGuestsOrExternalUsers, direction)
+			}
+		}
+	}
+
+	if block[0] == nil {
+		return nil
+	}
+	tenants := block[0].(map[string]interface{})["external_tenants"].([]interface{})
+	if len(tenants) == 0 || tenants[0] == nil {
+		return nil
+	}
+	membersKey := blockKey + ".0.external_tenants.0.members"
+	if !diff.NewValueKnown(membersKey) {
+		return nil
+	}
+	kind := tenants[0].(map[string]interface{})["membership_kind"].(string)
+	members := tenants[0].(map[string]interface{})["members"].([]interface{})
+	if kind == client.ExternalTenantsMembershipKindEnumerated && len(members) == 0 {
+		return fmt.Errorf("`members` must be specified in `%s_guests_or_external_users.external_tenants` when `membership_kind` is %q", direction, client.ExternalTenantsMembershipKindEnumerated)
+	}
+	if kind == client.ExternalTenantsMembershipKindAll && len(members) > 0 {
+		return fmt.Errorf("`members` cannot be specified in `%s_guests_or_external_users.external_tenants` when `membership_kind` is %q", direction, client.ExternalTenantsMembershipKindAll)
+	}
+
+	return nil
+}
+
+func conditionalAccessPolicyResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.ConditionalAccessPolicyClient
+	displayName := d.Get("display_name").(string)
+
+	properties := expandConditionalAccessPolicy(d)
+
+	if v, ok := d.GetOk("session_controls"); ok {
+		properties.SessionControls = expandConditionalAccessSessionControls(v.([]interface{}))
+	}
+
+	policy, _, err := client.Create(ctx, properties)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Creating conditional access policy %q", displayName)
+	}
+
+	if policy.ID == nil || *policy.ID == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for conditional access policy is nil/empty"), "Bad API response")
+	}
+
+	d.SetId(*policy.ID)
+
+	return conditionalAccessPolicyResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessPolicyResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	policyClient := meta.(*clients.Client).ConditionalAccess.ConditionalAccessPolicyClient
+
+	// Session controls are always sent, so that any which have been removed are disabled
+	properties := expandConditionalAccessPolicy(d)
+	properties.ID = utils.String(d.Id())
+	properties.SessionControls = expandConditionalAccessSessionControls(d.Get("session_controls").([]interface{}))
+
+	// An empty devices condition is sent when the device filter has been removed, so that it is cleared
+	if d.HasChange("conditions.0.devices") && properties.Conditions != nil && properties.Conditions.Devices == nil {
+		properties.Conditions.Devices = &client.ConditionalAccessDevices{}
+	}
+
+	if _, err := policyClient.Update(ctx, properties); err != nil {
+		return tf.ErrorDiagF(err, "Updating conditional access policy with ID %q", d.Id())
+	}
+
+	return conditionalAccessPolicyResourceRead(ctx, d, meta)
+}
+
+func conditionalAccessPolicyResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.ConditionalAccessPolicyClient
+
+	policy, status, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Conditional access policy with ID %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving conditional access policy with ID %q", d.Id())
+	}
+	if policy == nil {
+		return tf.ErrorDiagF(errors.New("conditional access policy was nil"), "Bad API response")
+	}
+
+	tf.Set(d, "conditions", flattenConditionalAccessConditionSet(policy.Conditions))
+	tf.Set(d, "display_name", policy.DisplayName)
+	tf.Set(d, "grant_controls", flattenConditionalAccessGrantControls(policy.GrantControls))
+	tf.Set(d, "session_controls", flattenConditionalAccessSessionControls(policy.SessionControls))
+	tf.Set(d, "state", policy.State)
+
+	return nil
+}
+
+func conditionalAccessPolicyResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.HasPrefix(d.Id(), conditionalAccessPolicyDisplayNameImportPrefix) {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client := meta.(*clients.Client).ConditionalAccess.PoliciesClient
+	displayName := strings.TrimPrefix(d.Id(), conditionalAccessPolicyDisplayNameImportPrefix)
+
+	id, err := conditionalAccessPolicyFindByDisplayName(ctx, client, displayName)
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func conditionalAccessPolicyResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.PoliciesClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "conditional access policy",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
+}
@@ -0,0 +1,642 @@
+package conditionalaccess
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func expandIPNamedLocation(displayName string, in []interface{}) msgraph.IPNamedLocation {
+	result := msgraph.IPNamedLocation{
+		BaseNamedLocation: &msgraph.BaseNamedLocation{
+			DisplayName: utils.String(displayName),
+		},
+	}
+	if len(in) == 0 || in[0] == nil {
+		return result
+	}
+	ip := in[0].(map[string]interface{})
+
+	ipRanges := make([]msgraph.IPNamedLocationIPRange, 0)
+	for _, v := range ip["ip_ranges"].([]interface{}) {
+		ipRanges = append(ipRanges, msgraph.IPNamedLocationIPRange{
+			CIDRAddress: utils.String(v.(string)),
+		})
+	}
+	result.IPRanges = &ipRanges
+	result.IsTrusted = utils.Bool(ip["trusted"].(bool))
+
+	return result
+}
+
+func expandCountryNamedLocation(displayName string, in []interface{}) client.CountryNamedLocation {
+	result := client.CountryNamedLocation{
+		CountryNamedLocation: msgraph.CountryNamedLocation{
+			BaseNamedLocation: &msgraph.BaseNamedLocation{
+				DisplayName: utils.String(displayName),
+			},
+		},
+	}
+	if len(in) == 0 || in[0] == nil {
+		return result
+	}
+	country := in[0].(map[string]interface{})
+
+	result.CountriesAndRegions = tf.ExpandStringSlicePtr(country["countries_and_regions"].([]interface{}))
+	result.IncludeUnknownCountriesAndRegions = utils.Bool(country["include_unknown_countries_and_regions"].(bool))
+	result.CountryLookupMethod = utils.String(country["country_lookup_method"].(string))
+
+	return result
+}
+
+// flattenNamedLocation returns the display name of a named location, along with the value of the `ip` or `country`
+// block according to its type. The block for the other type is always empty.
+func flattenNamedLocation(location msgraph.NamedLocation) (displayName *string, ip []interface{}, country []interface{}, err error) {
+	ip, country = make([]interface{}, 0), make([]interface{}, 0)
+
+	switch v := location.(type) {
+	case msgraph.IPNamedLocation:
+		if v.BaseNamedLocation != nil {
+			displayName = v.DisplayName
+		}
+
+		ipRanges := make([]interface{}, 0)
+		if v.IPRanges != nil {
+			for _, r := range *v.IPRanges {
+				if r.CIDRAddress != nil {
+					ipRanges = append(ipRanges, *r.CIDRAddress)
+				}
+			}
+		}
+		trusted := false
+		if v.IsTrusted != nil {
+			trusted = *v.IsTrusted
+		}
+		ip = append(ip, map[string]interface{}{
+			"ip_ranges": ipRanges,
+			"trusted":   trusted,
+		})
+
+	case msgraph.CountryNamedLocation:
+		return flattenNamedLocation(client.CountryNamedLocation{CountryNamedLocation: v})
+
+	case client.CountryNamedLocation:
+		if v.BaseNamedLocation != nil {
+			displayName = v.DisplayName
+		}
+
+		includeUnknown := false
+		if v.IncludeUnknownCountriesAndRegions != nil {
+			includeUnknown = *v.IncludeUnknownCountriesAndRegions
+		}
+		// The lookup method is omitted by the API for locations which have never had it set
+		lookupMethod := client.CountryLookupMethodClientIpAddress
+		if v.CountryLookupMethod != nil && *v.CountryLookupMethod != "" {
+			lookupMethod = *v.CountryLookupMethod
+		}
+		country = append(country, map[string]interface{}{
+			"countries_and_regions":                 tf.FlattenStringSlicePtr(v.CountriesAndRegions),
+			"country_lookup_method":                 lookupMethod,
+			"include_unknown_countries_and_regions": includeUnknown,
+		})
+
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported named location type %T", location)
+	}
+
+	return displayName, ip, country, nil
+}
+
+// conditionalAccessPolicyFindByDisplayName returns the ID of the only conditional access policy with the specified
+// display name. Display names are not unique, so an error is returned when more than one policy matches.
+func conditionalAccessPolicyFindByDisplayName(ctx context.Context, client *msgraph.ConditionalAccessPolicyClient, displayName string) (string, error) {
+	policies, _, err := client.List(ctx, helpers.ODataEq("displayName", displayName))
+	if err != nil {
+		return "", fmt.Errorf("listing conditional access policies with display name %q: %+v", displayName, err)
+	}
+
+	ids := make([]string, 0)
+	if policies != nil {
+		for _, policy := range *policies {
+			// The API matches display names case-insensitively
+			if policy.DisplayName != nil && *policy.DisplayName == displayName && policy.ID != nil {
+				ids = append(ids, *policy.ID)
+			}
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no conditional access policy was found with display name %q", displayName)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("found %d conditional access policies with display name %q, specify one of them by ID instead: %s", len(ids), displayName, strings.Join(ids, ", "))
+	}
+}
+
+// expandConditionalAccessPolicy returns the properties of a conditional access policy, excluding its ID and session
+// controls
+func expandConditionalAccessPolicy(d *schema.ResourceData) client.ConditionalAccessPolicy {
+	return client.ConditionalAccessPolicy{
+		DisplayName:   utils.String(d.Get("display_name").(string)),
+		State:         utils.String(d.Get("state").(string)),
+		Conditions:    expandConditionalAccessConditionSet(d.Get("conditions").([]interface{})),
+		GrantControls: expandConditionalAccessGrantControls(d.Get("grant_controls").([]interface{})),
+	}
+}
+
+func expandConditionalAccessConditionSet(in []interface{}) *client.ConditionalAccessConditionSet {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	result := client.ConditionalAccessConditionSet{
+		ClientAppTypes:   tf.ExpandStringSlicePtr(config["client_app_types"].([]interface{})),
+		SignInRiskLevels: tf.ExpandStringSlicePtr(config["sign_in_risk_levels"].([]interface{})),
+		UserRiskLevels:   tf.ExpandStringSlicePtr(config["user_risk_levels"].([]interface{})),
+	}
+
+	if v := config["applications"].([]interface{}); len(v) > 0 && v[0] != nil {
+		applications := v[0].(map[string]interface{})
+		result.Applications = &msgraph.ConditionalAccessApplications{
+			IncludeApplications: tf.ExpandStringSlicePtr(applications["included_applications"].([]interface{})),
+			ExcludeApplications: tf.ExpandStringSlicePtr(applications["excluded_applications"].([]interface{})),
+			IncludeUserActions:  tf.ExpandStringSlicePtr(applications["included_user_actions"].([]interface{})),
+		}
+	}
+
+	if v := config["users"].([]interface{}); len(v) > 0 && v[0] != nil {
+		users := v[0].(map[string]interface{})
+		result.Users = &client.ConditionalAccessUsers{
+			ConditionalAccessUsers: msgraph.ConditionalAccessUsers{
+				IncludeUsers:  tf.ExpandStringSlicePtr(users["included_users"].([]interface{})),
+				ExcludeUsers:  tf.ExpandStringSlicePtr(users["excluded_users"].([]interface{})),
+				IncludeGroups: tf.ExpandStringSlicePtr(users["included_groups"].([]interface{})),
+				ExcludeGroups: tf.ExpandStringSlicePtr(users["excluded_groups"].([]interface{})),
+				IncludeRoles:  tf.ExpandStringSlicePtr(users["included_roles"].([]interface{})),
+				ExcludeRoles:  tf.ExpandStringSlicePtr(users["excluded_roles"].([]interface{})),
+			},
+			IncludeGuestsOrExternalUsers: expandConditionalAccessGuestsOrExternalUsers(users["included_guests_or_external_users"].([]interface{})),
+			ExcludeGuestsOrExternalUsers: expandConditionalAccessGuestsOrExternalUsers(users["excluded_guests_or_external_users"].([]interface{})),
+		}
+	}
+
+	result.Devices = expandConditionalAccessDevices(config["devices"].([]interface{}))
+
+	if v := config["locations"].([]interface{}); len(v) > 0 && v[0] != nil {
+		locations := v[0].(map[string]interface{})
+		result.Locations = &msgraph.ConditionalAccessLocations{
+			IncludeLocations: tf.ExpandStringSlicePtr(locations["included_locations"].([]interface{})),
+			ExcludeLocations: tf.ExpandStringSlicePtr(locations["excluded_locations"].([]interface{})),
+		}
+	}
+
+	if v := config["platforms"].([]interface{}); len(v) > 0 && v[0] != nil {
+		platforms := v[0].(map[string]interface{})
+		result.Platforms = &msgraph.ConditionalAccessPlatforms{
+			IncludePlatforms: tf.ExpandStringSlicePtr(platforms["included_platforms"].([]interface{})),
+			ExcludePlatforms: tf.ExpandStringSlicePtr(platforms["excluded_platforms"].([]interface{})),
+		}
+	}
+
+	return &result
+}
+
+func expandConditionalAccessDevices(in []interface{}) *client.ConditionalAccessDevices {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	devices := in[0].(map[string]interface{})
+
+	filters := devices["filter"].([]interface{})
+	if len(filters) == 0 || filters[0] == nil {
+		return nil
+	}
+	filter := filters[0].(map[string]interface{})
+
+	return &client.ConditionalAccessDevices{
+		DeviceFilter: &client.ConditionalAccessFilter{
+			Mode: utils.String(filter["mode"].(string)),
+			Rule: utils.String(filter["rule"].(string)),
+		},
+	}
+}
+
+func expandConditionalAccessGuestsOrExternalUsers(in []interface{}) *client.ConditionalAccessGuestsOrExternalUsers {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	userTypes := tf.ExpandStringSlice(config["guest_or_external_user_types"].(*schema.Set).List())
+	sort.Strings(userTypes)
+	result := client.ConditionalAccessGuestsOrExternalUsers{
+		GuestOrExternalUserTypes: utils.String(strings.Join(userTypes, ",")),
+	}
+
+	if v := config["external_tenants"].([]interface{}); len(v) > 0 && v[0] != nil {
+		tenants := v[0].(map[string]interface{})
+		membershipKind := tenants["membership_kind"].(string)
+		result.ExternalTenants = &client.ConditionalAccessExternalTenants{
+			MembershipKind: utils.String(membershipKind),
+		}
+		if membershipKind == client.ExternalTenantsMembershipKindEnumerated {
+			result.ExternalTenants.ODataType = utils.String("#microsoft.graph.conditionalAccessEnumeratedExternalTenants")
+			result.ExternalTenants.Members = tf.ExpandStringSlicePtr(tenants["members"].([]interface{}))
+		} else {
+			result.ExternalTenants.ODataType = utils.String("#microsoft.graph.conditionalAccessAllExternalTenants")
+		}
+	}
+
+	return &result
+}
+
+func expandConditionalAccessGrantControls(in []interface{}) *msgraph.ConditionalAccessGrantControls {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	return &msgraph.ConditionalAccessGrantControls{
+		Operator:                    utils.String(config["operator"].(string)),
+		BuiltInControls:             tf.ExpandStringSlicePtr(config["built_in_controls"].([]interface{})),
+		CustomAuthenticationFactors: tf.ExpandStringSlicePtr(config["custom_authentication_factors"].([]interface{})),
+		TermsOfUse:                  tf.ExpandStringSlicePtr(config["terms_of_use"].([]interface{})),
+	}
+}
+
+// expandConditionalAccessSessionControls always returns every session control, so that any which are not configured
+// are explicitly disabled when updating a policy.
+func expandConditionalAccessSessionControls(in []interface{}) *msgraph.ConditionalAccessSessionControls {
+	result := msgraph.ConditionalAccessSessionControls{
+		ApplicationEnforcedRestrictions: &msgraph.ApplicationEnforcedRestrictionsSessionControl{
+			IsEnabled: utils.Bool(false),
+		},
+		CloudAppSecurity: &msgraph.CloudAppSecurityControl{
+			IsEnabled: utils.Bool(false),
+		},
+		PersistentBrowser: &msgraph.PersistentBrowserSessionControl{
+			IsEnabled: utils.Bool(false),
+		},
+		SignInFrequency: &msgraph.SignInFrequencySessionControl{
+			IsEnabled: utils.Bool(false),
+		},
+	}
+	if len(in) == 0 || in[0] == nil {
+		return &result
+	}
+	config := in[0].(map[string]interface{})
+
+	if config["application_enforced_restrictions_enabled"].(bool) {
+		result.ApplicationEnforcedRestrictions.IsEnabled = utils.Bool(true)
+	}
+
+	if v := config["cloud_app_security_policy"].(string); v != "" {
+		result.CloudAppSecurity.IsEnabled = utils.Bool(true)
+		result.CloudAppSecurity.CloudAppSecurityType = utils.String(v)
+	}
+
+	if v := config["persistent_browser_mode"].(string); v != "" {
+		result.PersistentBrowser.IsEnabled = utils.Bool(true)
+		result.PersistentBrowser.Mode = utils.String(v)
+	}
+
+	if v := config["sign_in_frequency"].(int); v > 0 {
+		value := int32(v)
+		result.SignInFrequency.IsEnabled = utils.Bool(true)
+		result.SignInFrequency.Type = utils.String(config["sign_in_frequency_period"].(string))
+		result.SignInFrequency.Value = &value
+	}
+
+	return &result
+}
+
+func flattenConditionalAccessConditionSet(in *client.ConditionalAccessConditionSet) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	applications := make([]interface{}, 0)
+	if in.Applications != nil {
+		applications = append(applications, map[string]interface{}{
+			"included_applications": tf.FlattenStringSlicePtr(in.Applications.IncludeApplications),
+			"excluded_applications": tf.FlattenStringSlicePtr(in.Applications.ExcludeApplications),
+			"included_user_actions": tf.FlattenStringSlicePtr(in.Applications.IncludeUserActions),
+		})
+	}
+
+	users := make([]interface{}, 0)
+	if in.Users != nil {
+		includedGuests := flattenConditionalAccessGuestsOrExternalUsers(in.Users.IncludeGuestsOrExternalUsers)
+		excludedGuests := flattenConditionalAccessGuestsOrExternalUsers(in.Users.ExcludeGuestsOrExternalUsers)
+
+		users = append(users, map[string]interface{}{
+			"included_users":                    flattenConditionalAccessUsers(in.Users.IncludeUsers, len(includedGuests) > 0),
+			"excluded_users":                    flattenConditionalAccessUsers(in.Users.ExcludeUsers, len(excludedGuests) > 0),
+			"included_groups":                   tf.FlattenStringSlicePtr(in.Users.IncludeGroups),
+			"excluded_groups":                   tf.FlattenStringSlicePtr(in.Users.ExcludeGroups),
+			"included_roles":                    tf.FlattenStringSlicePtr(in.Users.IncludeRoles),
+			"excluded_roles":                    tf.FlattenStringSlicePtr(in.Users.ExcludeRoles),
+			"included_guests_or_external_users": includedGuests,
+			"excluded_guests_or_external_users": excludedGuests,
+		})
+	}
+
+	locations := make([]interface{}, 0)
+	if in.Locations != nil {
+		locations = append(locations, map[string]interface{}{
+			"included_locations": tf.FlattenStringSlicePtr(in.Locations.IncludeLocations),
+			"excluded_locations": tf.FlattenStringSlicePtr(in.Locations.ExcludeLocations),
+		})
+	}
+
+	platforms := make([]interface{}, 0)
+	if in.Platforms != nil {
+		platforms = append(platforms, map[string]interface{}{
+			"included_platforms": tf.FlattenStringSlicePtr(in.Platforms.IncludePlatforms),
+			"excluded_platforms": tf.FlattenStringSlicePtr(in.Platforms.ExcludePlatforms),
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"applications":        applications,
+			"users":               users,
+			"client_app_types":    tf.FlattenStringSlicePtr(in.ClientAppTypes),
+			"devices":             flattenConditionalAccessDevices(in.Devices),
+			"locations":           locations,
+			"platforms":           platforms,
+			"sign_in_risk_levels": tf.FlattenStringSlicePtr(in.SignInRiskLevels),
+			"user_risk_levels":    tf.FlattenStringSlicePtr(in.UserRiskLevels),
+		},
+	}
+}
+
+// flattenConditionalAccessDevices returns an empty list for policies without a device filter, which may be returned
+// with an empty devices condition
+func flattenConditionalAccessDevices(in *client.ConditionalAccessDevices) []interface{} {
+	if in == nil || in.DeviceFilter == nil || (in.DeviceFilter.Mode == nil && in.DeviceFilter.Rule == nil) {
+		return []interface{}{}
+	}
+
+	mode := ""
+	if in.DeviceFilter.Mode != nil {
+		mode = *in.DeviceFilter.Mode
+	}
+	rule := ""
+	if in.DeviceFilter.Rule != nil {
+		rule = *in.DeviceFilter.Rule
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"filter": []interface{}{
+				map[string]interface{}{
+					"mode": mode,
+					"rule": rule,
+				},
+			},
+		},
+	}
+}
+
+// flattenConditionalAccessUsers returns the included or excluded users of a policy. When the corresponding guests or
+// external users are also present, the structured form is preferred and the legacy `GuestsOrExternalUsers` value is
+// omitted from the users.
+func flattenConditionalAccessUsers(in *[]string, structuredGuests bool) []interface{} {
+	users := tf.FlattenStringSlicePtr(in)
+	if !structuredGuests {
+		return users
+	}
+
+	result := make([]interface{}, 0, len(users))
+	for _, v := range users {
+		if v != conditionalAccessPolicyGuestsOrExternalUsers {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func flattenConditionalAccessGuestsOrExternalUsers(in *client.ConditionalAccessGuestsOrExternalUsers) []interface{} {
+	if in == nil || in.GuestOrExternalUserTypes == nil || *in.GuestOrExternalUserTypes == "" {
+		return []interface{}{}
+	}
+
+	userTypes := make([]interface{}, 0)
+	for _, v := range strings.Split(*in.GuestOrExternalUserTypes, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			userTypes = append(userTypes, v)
+		}
+	}
+
+	externalTenants := make([]interface{}, 0)
+	if t := in.ExternalTenants; t != nil && t.MembershipKind != nil {
+		members := make([]interface{}, 0)
+		if *t.MembershipKind == client.ExternalTenantsMembershipKindEnumerated {
+			members = tf.FlattenStringSlicePtr(t.Members)
+		}
+		externalTenants = append(externalTenants, map[string]interface{}{
+			"membership_kind": *t.MembershipKind,
+			"members":         members,
+		})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"guest_or_external_user_types": userTypes,
+			"external_tenants":             externalTenants,
+		},
+	}
+}
+
+func flattenConditionalAccessGrantControls(in *msgraph.ConditionalAccessGrantControls) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	operator := ""
+	if in.Operator != nil {
+		operator = *in.Operator
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"operator":                      operator,
+			"built_in_controls":             tf.FlattenStringSlicePtr(in.BuiltInControls),
+			"custom_authentication_factors": tf.FlattenStringSlicePtr(in.CustomAuthenticationFactors),
+			"terms_of_use":                  tf.FlattenStringSlicePtr(in.TermsOfUse),
+		},
+	}
+}
+
+// flattenConditionalAccessSessionControls treats disabled session controls as absent, and returns an empty list when
+// no session controls are enabled.
+func flattenConditionalAccessSessionControls(in *msgraph.ConditionalAccessSessionControls) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	enabled := false
+
+	applicationEnforcedRestrictions := false
+	if c := in.ApplicationEnforcedRestrictions; c != nil && c.IsEnabled != nil && *c.IsEnabled {
+		applicationEnforcedRestrictions = true
+		enabled = true
+	}
+
+	cloudAppSecurity := ""
+	if c := in.CloudAppSecurity; c != nil && c.IsEnabled != nil && *c.IsEnabled && c.CloudAppSecurityType != nil {
+		cloudAppSecurity = *c.CloudAppSecurityType
+		enabled = true
+	}
+
+	persistentBrowserMode := ""
+	if c := in.PersistentBrowser; c != nil && c.IsEnabled != nil && *c.IsEnabled && c.Mode != nil {
+		persistentBrowserMode = *c.Mode
+		enabled = true
+	}
+
+	signInFrequency, signInFrequencyPeriod := 0, ""
+	if c := in.SignInFrequency; c != nil && c.IsEnabled != nil && *c.IsEnabled && c.Value != nil && c.Type != nil {
+		signInFrequency, signInFrequencyPeriod = int(*c.Value), *c.Type
+		enabled = true
+	}
+
+	if !enabled {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"application_enforced_restrictions_enabled": applicationEnforcedRestrictions,
+			"cloud_app_security_policy":                 cloudAppSecurity,
+			"persistent_browser_mode":                   persistentBrowserMode,
+			"sign_in_frequency":                         signInFrequency,
+			"sign_in_frequency_period":                  signInFrequencyPeriod,
+		},
+	}
+}
+
+// conditionalAccessPolicyObjectIds returns the object IDs in a list of policy targets, omitting literals such as `All`,
+// `None` or `GuestsOrExternalUsers`
+func conditionalAccessPolicyObjectIds(in *[]string) []string {
+	result := make([]string, 0)
+	if in == nil {
+		return result
+	}
+	for _, v := range *in {
+		if _, err := uuid.ParseUUID(v); err == nil {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+func conditionalAccessPolicyTargetsContain(in *[]string, literal string) bool {
+	if in != nil {
+		for _, v := range *in {
+			if strings.EqualFold(v, literal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// flattenConditionalAccessPolicyConditionsSummary returns the number of users, groups, roles and applications targeted by
+// a policy. Literals are not counted, and are instead reflected by the `all_users`, `all_applications` and guests or
+// external users attributes.
+func flattenConditionalAccessPolicyConditionsSummary(in *client.ConditionalAccessConditionSet) []interface{} {
+	summary := map[string]interface{}{
+		"all_users":                         false,
+		"guests_or_external_users_included": false,
+		"guests_or_external_users_excluded": false,
+		"included_users":                    0,
+		"excluded_users":                    0,
+		"included_groups":                   0,
+		"excluded_groups":                   0,
+		"included_roles":                    0,
+		"excluded_roles":                    0,
+		"all_applications":                  false,
+		"included_applications":             0,
+		"excluded_applications":             0,
+	}
+
+	if in != nil && in.Users != nil {
+		summary["all_users"] = conditionalAccessPolicyTargetsContain(in.Users.IncludeUsers, "All")
+		summary["guests_or_external_users_included"] = in.Users.IncludeGuestsOrExternalUsers != nil ||
+			conditionalAccessPolicyTargetsContain(in.Users.IncludeUsers, conditionalAccessPolicyGuestsOrExternalUsers)
+		summary["guests_or_external_users_excluded"] = in.Users.ExcludeGuestsOrExternalUsers != nil ||
+			conditionalAccessPolicyTargetsContain(in.Users.ExcludeUsers, conditionalAccessPolicyGuestsOrExternalUsers)
+		summary["included_users"] = len(conditionalAccessPolicyObjectIds(in.Users.IncludeUsers))
+		summary["excluded_users"] = len(conditionalAccessPolicyObjectIds(in.Users.ExcludeUsers))
+		summary["included_groups"] = len(conditionalAccessPolicyObjectIds(in.Users.IncludeGroups))
+		summary["excluded_groups"] = len(conditionalAccessPolicyObjectIds(in.Users.ExcludeGroups))
+		summary["included_roles"] = len(conditionalAccessPolicyObjectIds(in.Users.IncludeRoles))
+		summary["excluded_roles"] = len(conditionalAccessPolicyObjectIds(in.Users.ExcludeRoles))
+	}
+
+	if in != nil && in.Applications != nil {
+		summary["all_applications"] = conditionalAccessPolicyTargetsContain(in.Applications.IncludeApplications, "All")
+		summary["included_applications"] = len(conditionalAccessPolicyObjectIds(in.Applications.IncludeApplications))
+		summary["excluded_applications"] = len(conditionalAccessPolicyObjectIds(in.Applications.ExcludeApplications))
+	}
+
+	return []interface{}{summary}
+}
+
+// conditionalAccessPolicyReferencedObjectIds returns the sorted, unique object IDs of the users and groups referenced by
+// a policy. Roles and applications are referenced by template ID and application ID respectively, which cannot be
+// resolved as directory objects.
+func conditionalAccessPolicyReferencedObjectIds(in *client.ConditionalAccessConditionSet) []string {
+	if in == nil || in.Users == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	for _, targets := range []*[]string{in.Users.IncludeUsers, in.Users.ExcludeUsers, in.Users.IncludeGroups, in.Users.ExcludeGroups} {
+		for _, id := range conditionalAccessPolicyObjectIds(targets) {
+			if !seen[id] {
+				seen[id] = true
+				result = append(result, id)
+			}
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// conditionalAccessPolicyResolvedDisplayNames maps each of the specified object IDs to the display name of the
+// corresponding directory object. Objects which no longer exist are mapped to `(deleted)`.
+func conditionalAccessPolicyResolvedDisplayNames(ids []string, objects []helpers.DirectoryObjectSummary) map[string]interface{} {
+	displayNames := make(map[string]string)
+	for _, o := range objects {
+		displayNames[strings.ToLower(o.ID)] = o.DisplayName
+	}
+
+	result := make(map[string]interface{})
+	for _, id := range ids {
+		if displayName, ok := displayNames[strings.ToLower(id)]; ok {
+			result[id] = displayName
+		} else {
+			result[id] = "(deleted)"
+		}
+	}
+
+	return result
+}
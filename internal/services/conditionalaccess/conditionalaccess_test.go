@@ -0,0 +1,750 @@
+package conditionalaccess
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestNamedLocationRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name            string
+		location        msgraph.NamedLocation
+		expectedIP      []interface{}
+		expectedCountry []interface{}
+	}{
+		{
+			name: "ip",
+			location: expandIPNamedLocation("office", []interface{}{map[string]interface{}{
+				"ip_ranges": []interface{}{"1.1.1.1/32", "2.2.2.0/24"},
+				"trusted":   true,
+			}}),
+			expectedIP: []interface{}{map[string]interface{}{
+				"ip_ranges": []interface{}{"1.1.1.1/32", "2.2.2.0/24"},
+				"trusted":   true,
+			}},
+			expectedCountry: []interface{}{},
+		},
+		{
+			name: "country",
+			location: expandCountryNamedLocation("office", []interface{}{map[string]interface{}{
+				"countries_and_regions":                 []interface{}{"GB", "US"},
+				"country_lookup_method":                 "clientIpAddress",
+				"include_unknown_countries_and_regions": false,
+			}}),
+			expectedIP: []interface{}{},
+			expectedCountry: []interface{}{map[string]interface{}{
+				"countries_and_regions":                 []interface{}{"GB", "US"},
+				"country_lookup_method":                 "clientIpAddress",
+				"include_unknown_countries_and_regions": false,
+			}},
+		},
+		{
+			name: "country by authenticator app gps",
+			location: expandCountryNamedLocation("office", []interface{}{map[string]interface{}{
+				"countries_and_regions":                 []interface{}{"FR"},
+				"country_lookup_method":                 "authenticatorAppGps",
+				"include_unknown_countries_and_regions": false,
+			}}),
+			expectedIP: []interface{}{},
+			expectedCountry: []interface{}{map[string]interface{}{
+				"countries_and_regions":                 []interface{}{"FR"},
+				"country_lookup_method":                 "authenticatorAppGps",
+				"include_unknown_countries_and_regions": false,
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			displayName, ip, country, err := flattenNamedLocation(tc.location)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if displayName == nil || *displayName != "office" {
+				t.Fatalf("expected display name %q, got %v", "office", displayName)
+			}
+			if !reflect.DeepEqual(ip, tc.expectedIP) {
+				t.Fatalf("expected ip %#v, got %#v", tc.expectedIP, ip)
+			}
+			if !reflect.DeepEqual(country, tc.expectedCountry) {
+				t.Fatalf("expected country %#v, got %#v", tc.expectedCountry, country)
+			}
+		})
+	}
+}
+
+func TestFlattenNamedLocationDefaults(t *testing.T) {
+	_, ip, _, err := flattenNamedLocation(msgraph.IPNamedLocation{
+		BaseNamedLocation: &msgraph.BaseNamedLocation{DisplayName: utils.String("office")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []interface{}{map[string]interface{}{
+		"ip_ranges": []interface{}{},
+		"trusted":   false,
+	}}
+	if !reflect.DeepEqual(ip, expected) {
+		t.Fatalf("expected ip %#v, got %#v", expected, ip)
+	}
+
+	// Locations which have never had a lookup method set, or which were retrieved without one, use the API default
+	_, _, country, err := flattenNamedLocation(msgraph.CountryNamedLocation{
+		BaseNamedLocation:   &msgraph.BaseNamedLocation{DisplayName: utils.String("office")},
+		CountriesAndRegions: &[]string{"GB"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []interface{}{map[string]interface{}{
+		"countries_and_regions":                 []interface{}{"GB"},
+		"country_lookup_method":                 "clientIpAddress",
+		"include_unknown_countries_and_regions": false,
+	}}
+	if !reflect.DeepEqual(country, expected) {
+		t.Fatalf("expected country %#v, got %#v", expected, country)
+	}
+
+	if _, _, _, err := flattenNamedLocation(nil); err == nil {
+		t.Fatalf("expected an error for an unrecognised named location type")
+	}
+}
+
+func TestConditionalAccessPolicyRoundTrip(t *testing.T) {
+	conditions := []interface{}{map[string]interface{}{
+		"applications": []interface{}{map[string]interface{}{
+			"included_applications": []interface{}{"All"},
+			"excluded_applications": []interface{}{"00000000-0000-0000-0000-000000000001"},
+			"included_user_actions": []interface{}{},
+		}},
+		"users": []interface{}{map[string]interface{}{
+			"included_users":  []interface{}{"All"},
+			"excluded_users":  []interface{}{"GuestsOrExternalUsers"},
+			"included_groups": []interface{}{},
+			"excluded_groups": []interface{}{"00000000-0000-0000-0000-000000000002"},
+			"included_roles":  []interface{}{"62e90394-69f5-4237-9190-012177145e10"},
+			"excluded_roles":  []interface{}{},
+
+			"included_guests_or_external_users": []interface{}{},
+			"excluded_guests_or_external_users": []interface{}{},
+		}},
+		"client_app_types": []interface{}{"browser"},
+		"devices":          []interface{}{},
+		"locations": []interface{}{map[string]interface{}{
+			"included_locations": []interface{}{"All"},
+			"excluded_locations": []interface{}{"AllTrusted"},
+		}},
+		"platforms":           []interface{}{},
+		"sign_in_risk_levels": []interface{}{"medium"},
+		"user_risk_levels":    []interface{}{},
+	}}
+	grantControls := []interface{}{map[string]interface{}{
+		"operator":                      "OR",
+		"built_in_controls":             []interface{}{"mfa"},
+		"custom_authentication_factors": []interface{}{},
+		"terms_of_use":                  []interface{}{},
+	}}
+	sessionControls := []interface{}{map[string]interface{}{
+		"application_enforced_restrictions_enabled": false,
+		"cloud_app_security_policy":                 "monitorOnly",
+		"persistent_browser_mode":                   "",
+		"sign_in_frequency":                         10,
+		"sign_in_frequency_period":                  "hours",
+	}}
+
+	if got := flattenConditionalAccessConditionSet(expandConditionalAccessConditionSet(conditions)); !reflect.DeepEqual(got, conditions) {
+		t.Fatalf("expected conditions %#v, got %#v", conditions, got)
+	}
+	if got := flattenConditionalAccessGrantControls(expandConditionalAccessGrantControls(grantControls)); !reflect.DeepEqual(got, grantControls) {
+		t.Fatalf("expected grant controls %#v, got %#v", grantControls, got)
+	}
+	if got := flattenConditionalAccessSessionControls(expandConditionalAccessSessionControls(sessionControls)); !reflect.DeepEqual(got, sessionControls) {
+		t.Fatalf("expected session controls %#v, got %#v", sessionControls, got)
+	}
+}
+
+func TestConditionalAccessPolicyDevices(t *testing.T) {
+	devices := []interface{}{map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{
+			"mode": "exclude",
+			"rule": "device.isCompliant -eq True",
+		}},
+	}}
+
+	result := expandConditionalAccessDevices(devices)
+	if result == nil || result.DeviceFilter == nil || *result.DeviceFilter.Mode != "exclude" || *result.DeviceFilter.Rule != "device.isCompliant -eq True" {
+		t.Fatalf("expected an exclude device filter, got %#v", result)
+	}
+	if got := flattenConditionalAccessDevices(result); !reflect.DeepEqual(got, devices) {
+		t.Fatalf("expected devices %#v, got %#v", devices, got)
+	}
+
+	for name, in := range map[string]*client.ConditionalAccessDevices{
+		"nil":                 nil,
+		"empty":               {},
+		"empty device filter": {DeviceFilter: &client.ConditionalAccessFilter{}},
+	} {
+		if got := flattenConditionalAccessDevices(in); len(got) != 0 {
+			t.Errorf("%s: expected devices to flatten to an empty list, got %#v", name, got)
+		}
+	}
+
+	if got := expandConditionalAccessDevices([]interface{}{}); got != nil {
+		t.Fatalf("expected no devices condition when unconfigured, got %#v", got)
+	}
+
+	// Removing the device filter sends an explicit null filter
+	body, err := json.Marshal(client.ConditionalAccessConditionSet{Devices: &client.ConditionalAccessDevices{}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"devices":{"deviceFilter":null}}` {
+		t.Fatalf("expected the device filter to be cleared, got %s", body)
+	}
+}
+
+func TestConditionalAccessPolicyGuestsOrExternalUsers(t *testing.T) {
+	guests := func(membershipKind string, members ...interface{}) []interface{} {
+		externalTenants := make([]interface{}, 0)
+		if membershipKind != "" {
+			externalTenants = append(externalTenants, map[string]interface{}{
+				"membership_kind": membershipKind,
+				"members":         append([]interface{}{}, members...),
+			})
+		}
+		return []interface{}{map[string]interface{}{
+			"guest_or_external_user_types": schema.NewSet(schema.HashString, []interface{}{"internalGuest", "b2bCollaborationGuest"}),
+			"external_tenants":             externalTenants,
+		}}
+	}
+
+	testCases := []struct {
+		name                 string
+		in                   []interface{}
+		expectedTypes        string
+		expectedODataType    string
+		expectedMembers      []string
+		expectedFlattenTypes []interface{}
+	}{
+		{
+			name:                 "all external tenants by default",
+			in:                   guests(""),
+			expectedTypes:        "b2bCollaborationGuest,internalGuest",
+			expectedFlattenTypes: []interface{}{"b2bCollaborationGuest", "internalGuest"},
+		},
+		{
+			name:                 "all external tenants",
+			in:                   guests("all"),
+			expectedTypes:        "b2bCollaborationGuest,internalGuest",
+			expectedODataType:    "#microsoft.graph.conditionalAccessAllExternalTenants",
+			expectedFlattenTypes: []interface{}{"b2bCollaborationGuest", "internalGuest"},
+		},
+		{
+			name:                 "enumerated external tenants",
+			in:                   guests("enumerated", "00000000-0000-0000-0000-000000000001"),
+			expectedTypes:        "b2bCollaborationGuest,internalGuest",
+			expectedODataType:    "#microsoft.graph.conditionalAccessEnumeratedExternalTenants",
+			expectedMembers:      []string{"00000000-0000-0000-0000-000000000001"},
+			expectedFlattenTypes: []interface{}{"b2bCollaborationGuest", "internalGuest"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := expandConditionalAccessGuestsOrExternalUsers(tc.in)
+			if result == nil || result.GuestOrExternalUserTypes == nil || *result.GuestOrExternalUserTypes != tc.expectedTypes {
+				t.Fatalf("expected guest or external user types %q, got %#v", tc.expectedTypes, result)
+			}
+			if tc.expectedODataType == "" {
+				if result.ExternalTenants != nil {
+					t.Fatalf("expected no external tenants, got %#v", result.ExternalTenants)
+				}
+			} else {
+				if result.ExternalTenants == nil || result.ExternalTenants.ODataType == nil || *result.ExternalTenants.ODataType != tc.expectedODataType {
+					t.Fatalf("expected external tenants of type %q, got %#v", tc.expectedODataType, result.ExternalTenants)
+				}
+				if (result.ExternalTenants.Members == nil) != (tc.expectedMembers == nil) || (tc.expectedMembers != nil && !reflect.DeepEqual(*result.ExternalTenants.Members, tc.expectedMembers)) {
+					t.Fatalf("expected members %v, got %v", tc.expectedMembers, result.ExternalTenants.Members)
+				}
+			}
+
+			flattened := flattenConditionalAccessGuestsOrExternalUsers(result)
+			if len(flattened) != 1 {
+				t.Fatalf("expected a single block, got %#v", flattened)
+			}
+			block := flattened[0].(map[string]interface{})
+			if !reflect.DeepEqual(block["guest_or_external_user_types"], tc.expectedFlattenTypes) {
+				t.Fatalf("expected types %#v, got %#v", tc.expectedFlattenTypes, block["guest_or_external_user_types"])
+			}
+		})
+	}
+}
+
+func TestConditionalAccessPolicyGuestsOrExternalUsersFlatten(t *testing.T) {
+	structured := &client.ConditionalAccessGuestsOrExternalUsers{
+		GuestOrExternalUserTypes: utils.String("internalGuest,serviceProvider"),
+		ExternalTenants: &client.ConditionalAccessExternalTenants{
+			ODataType:      utils.String("#microsoft.graph.conditionalAccessEnumeratedExternalTenants"),
+			MembershipKind: utils.String("enumerated"),
+			Members:        &[]string{"00000000-0000-0000-0000-000000000001"},
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		users          *client.ConditionalAccessUsers
+		expectedUsers  []interface{}
+		expectedGuests []interface{}
+	}{
+		{
+			name: "legacy literal",
+			users: &client.ConditionalAccessUsers{
+				ConditionalAccessUsers: msgraph.ConditionalAccessUsers{
+					IncludeUsers: &[]string{"GuestsOrExternalUsers"},
+				},
+			},
+			expectedUsers:  []interface{}{"GuestsOrExternalUsers"},
+			expectedGuests: []interface{}{},
+		},
+		{
+			name: "structured form is preferred",
+			users: &client.ConditionalAccessUsers{
+				ConditionalAccessUsers: msgraph.ConditionalAccessUsers{
+					IncludeUsers: &[]string{"GuestsOrExternalUsers", "00000000-0000-0000-0000-000000000002"},
+				},
+				IncludeGuestsOrExternalUsers: structured,
+			},
+			expectedUsers: []interface{}{"00000000-0000-0000-0000-000000000002"},
+			expectedGuests: []interface{}{map[string]interface{}{
+				"guest_or_external_user_types": []interface{}{"internalGuest", "serviceProvider"},
+				"external_tenants": []interface{}{map[string]interface{}{
+					"membership_kind": "enumerated",
+					"members":         []interface{}{"00000000-0000-0000-0000-000000000001"},
+				}},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := flattenConditionalAccessConditionSet(&client.ConditionalAccessConditionSet{Users: tc.users})
+			users := conditions[0].(map[string]interface{})["users"].([]interface{})[0].(map[string]interface{})
+			if !reflect.DeepEqual(users["included_users"], tc.expectedUsers) {
+				t.Fatalf("expected included users %#v, got %#v", tc.expectedUsers, users["included_users"])
+			}
+			if !reflect.DeepEqual(users["included_guests_or_external_users"], tc.expectedGuests) {
+				t.Fatalf("expected included guests or external users %#v, got %#v", tc.expectedGuests, users["included_guests_or_external_users"])
+			}
+		})
+	}
+}
+
+func TestConditionalAccessPolicySessionControlsRemoved(t *testing.T) {
+	controls := expandConditionalAccessSessionControls([]interface{}{})
+	if controls == nil {
+		t.Fatal("expected session controls to be returned when none are configured")
+	}
+	for name, enabled := range map[string]*bool{
+		"applicationEnforcedRestrictions": controls.ApplicationEnforcedRestrictions.IsEnabled,
+		"cloudAppSecurity":                controls.CloudAppSecurity.IsEnabled,
+		"persistentBrowser":               controls.PersistentBrowser.IsEnabled,
+		"signInFrequency":                 controls.SignInFrequency.IsEnabled,
+	} {
+		if enabled == nil || *enabled {
+			t.Errorf("expected %s to be explicitly disabled", name)
+		}
+	}
+
+	if got := flattenConditionalAccessSessionControls(controls); len(got) != 0 {
+		t.Fatalf("expected disabled session controls to flatten to an empty list, got %#v", got)
+	}
+}
+
+func TestConditionalAccessPolicyResourceUsersIncluded(t *testing.T) {
+	r := conditionalAccessPolicyResource()
+
+	config := func(users map[string]interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"display_name": "test",
+			"state":        "disabled",
+			"conditions": []interface{}{map[string]interface{}{
+				"applications":     []interface{}{map[string]interface{}{"included_applications": []interface{}{"All"}}},
+				"users":            []interface{}{users},
+				"client_app_types": []interface{}{"all"},
+			}},
+			"grant_controls": []interface{}{map[string]interface{}{
+				"operator":          "OR",
+				"built_in_controls": []interface{}{"mfa"},
+			}},
+		})
+	}
+
+	guests := func(externalTenants ...interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"guest_or_external_user_types": []interface{}{"internalGuest"},
+			"external_tenants":             externalTenants,
+		}}
+	}
+
+	cases := []struct {
+		name          string
+		users         map[string]interface{}
+		expectedError string
+	}{
+		{name: "users", users: map[string]interface{}{"included_users": []interface{}{"All"}}},
+		{name: "groups", users: map[string]interface{}{"included_groups": []interface{}{"00000000-0000-0000-0000-000000000001"}}},
+		{name: "roles", users: map[string]interface{}{"included_roles": []interface{}{"62e90394-69f5-4237-9190-012177145e10"}}},
+		{name: "guests or external users", users: map[string]interface{}{"included_guests_or_external_users": guests()}},
+		{
+			name: "enumerated external tenants",
+			users: map[string]interface{}{"included_guests_or_external_users": guests(map[string]interface{}{
+				"membership_kind": "enumerated",
+				"members":         []interface{}{"00000000-0000-0000-0000-000000000001"},
+			})},
+		},
+		{
+			name:          "exclusions only",
+			users:         map[string]interface{}{"excluded_groups": []interface{}{"00000000-0000-0000-0000-000000000001"}},
+			expectedError: "at least one of `included_users`, `included_groups`, `included_roles` or `included_guests_or_external_users`",
+		},
+		{
+			name:          "empty inclusions",
+			users:         map[string]interface{}{"included_users": []interface{}{}, "included_roles": []interface{}{}},
+			expectedError: "at least one of `included_users`, `included_groups`, `included_roles` or `included_guests_or_external_users`",
+		},
+		{
+			name: "legacy literal with structured guests",
+			users: map[string]interface{}{
+				"included_users":                    []interface{}{"GuestsOrExternalUsers"},
+				"included_guests_or_external_users": guests(),
+			},
+			expectedError: "GuestsOrExternalUsers",
+		},
+		{
+			name: "enumerated external tenants without members",
+			users: map[string]interface{}{"included_guests_or_external_users": guests(map[string]interface{}{
+				"membership_kind": "enumerated",
+			})},
+			expectedError: "members",
+		},
+		{
+			name: "all external tenants with members",
+			users: map[string]interface{}{"included_guests_or_external_users": guests(map[string]interface{}{
+				"membership_kind": "all",
+				"members":         []interface{}{"00000000-0000-0000-0000-000000000001"},
+			})},
+			expectedError: "members",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), nil, config(tc.users), nil)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected an error containing %q, got: %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestConditionalAccessPolicyResourceImport(t *testing.T) {
+	policies := []msgraph.ConditionalAccessPolicy{
+		{ID: utils.String("00000000-0000-0000-0000-000000000001"), DisplayName: utils.String("O'Brien")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000002"), DisplayName: utils.String("Duplicate")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000003"), DisplayName: utils.String("Duplicate")},
+		{ID: utils.String("00000000-0000-0000-0000-000000000004"), DisplayName: utils.String("DUPLICATE")},
+	}
+
+	testCases := []struct {
+		name           string
+		importId       string
+		expectedFilter string
+		expectedId     string
+		expectedError  string
+	}{
+		{
+			name:       "by ID",
+			importId:   "00000000-0000-0000-0000-000000000009",
+			expectedId: "00000000-0000-0000-0000-000000000009",
+		},
+		{
+			name:           "by display name",
+			importId:       "displayName/O'Brien",
+			expectedFilter: "displayName eq 'O''Brien'",
+			expectedId:     "00000000-0000-0000-0000-000000000001",
+		},
+		{
+			name:           "not found",
+			importId:       "displayName/Missing",
+			expectedFilter: "displayName eq 'Missing'",
+			expectedError:  `no conditional access policy was found with display name "Missing"`,
+		},
+		{
+			name:           "ambiguous",
+			importId:       "displayName/Duplicate",
+			expectedFilter: "displayName eq 'Duplicate'",
+			expectedError:  "found 2 conditional access policies with display name \"Duplicate\", specify one of them by ID instead: 00000000-0000-0000-0000-000000000002, 00000000-0000-0000-0000-000000000003",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				filter := r.URL.Query().Get("$filter")
+				if filter != tc.expectedFilter {
+					t.Errorf("expected filter %q, got %q", tc.expectedFilter, filter)
+				}
+
+				// Emulate the API, which compares display names case-insensitively
+				value := make([]msgraph.ConditionalAccessPolicy, 0)
+				for _, policy := range policies {
+					if strings.EqualFold(filter, "displayName eq '"+strings.ReplaceAll(*policy.DisplayName, "'", "''")+"'") {
+						value = append(value, policy)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if err := json.NewEncoder(w).Encode(map[string]interface{}{"value": value}); err != nil {
+					t.Errorf("encoding response: %v", err)
+				}
+			}))
+			defer server.Close()
+
+			policiesClient := msgraph.NewConditionalAccessPolicyClient("00000000-0000-0000-0000-000000000000")
+			policiesClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			policiesClient.BaseClient.DisableRetries = true
+			meta := &clients.Client{
+				ConditionalAccess: &client.Client{PoliciesClient: policiesClient},
+			}
+
+			resource := conditionalAccessPolicyResource()
+			d := resource.Data(nil)
+			d.SetId(tc.importId)
+
+			result, err := resource.Importer.StateContext(context.Background(), d, meta)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got: %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result) != 1 || result[0].Id() != tc.expectedId {
+				t.Fatalf("expected ID %q, got %q", tc.expectedId, d.Id())
+			}
+			if tc.expectedFilter == "" && requests > 0 {
+				t.Fatalf("expected no requests when importing by ID, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestConditionalAccessPolicyDataSource(t *testing.T) {
+	const (
+		user1       = "10000000-0000-0000-0000-000000000001"
+		userDeleted = "10000000-0000-0000-0000-000000000002"
+		group1      = "20000000-0000-0000-0000-000000000001"
+		role1       = "62e90394-69f5-4237-9190-012177145e10"
+		app1        = "30000000-0000-0000-0000-000000000001"
+	)
+
+	policies := map[string]string{
+		"00000000-0000-0000-0000-000000000001": `{
+			"id": "00000000-0000-0000-0000-000000000001",
+			"displayName": "mixed",
+			"state": "enabled",
+			"conditions": {
+				"users": {
+					"includeUsers": ["` + user1 + `", "` + userDeleted + `", "GuestsOrExternalUsers"],
+					"excludeUsers": ["` + user1 + `"],
+					"includeGroups": ["` + group1 + `"],
+					"excludeGroups": [],
+					"includeRoles": ["` + role1 + `"],
+					"excludeRoles": []
+				},
+				"applications": {
+					"includeApplications": ["All"],
+					"excludeApplications": ["` + app1 + `", "Office365"]
+				}
+			}
+		}`,
+		"00000000-0000-0000-0000-000000000002": `{
+			"id": "00000000-0000-0000-0000-000000000002",
+			"displayName": "literals",
+			"state": "disabled",
+			"conditions": {
+				"users": {
+					"includeUsers": ["All"],
+					"excludeUsers": ["None"]
+				},
+				"applications": {
+					"includeApplications": ["None"]
+				}
+			}
+		}`,
+	}
+	objects := map[string]string{
+		user1:  `{"@odata.type": "#microsoft.graph.user", "id": "` + user1 + `", "displayName": "Alice"}`,
+		group1: `{"@odata.type": "#microsoft.graph.group", "id": "` + group1 + `", "displayName": "Admins"}`,
+	}
+
+	summary := func(overrides map[string]interface{}) []interface{} {
+		result := map[string]interface{}{
+			"all_users":                         false,
+			"guests_or_external_users_included": false,
+			"guests_or_external_users_excluded": false,
+			"included_users":                    0,
+			"excluded_users":                    0,
+			"included_groups":                   0,
+			"excluded_groups":                   0,
+			"included_roles":                    0,
+			"excluded_roles":                    0,
+			"all_applications":                  false,
+			"included_applications":             0,
+			"excluded_applications":             0,
+		}
+		for k, v := range overrides {
+			result[k] = v
+		}
+		return []interface{}{result}
+	}
+
+	testCases := []struct {
+		name                     string
+		id                       string
+		resolveDisplayNames      bool
+		expectedSummary          []interface{}
+		expectedDisplayNames     map[string]interface{}
+		expectedGetByIdsRequests int
+	}{
+		{
+			name: "mixed",
+			id:   "00000000-0000-0000-0000-000000000001",
+			expectedSummary: summary(map[string]interface{}{
+				"guests_or_external_users_included": true,
+				"included_users":                    2,
+				"excluded_users":                    1,
+				"included_groups":                   1,
+				"included_roles":                    1,
+				"all_applications":                  true,
+				"excluded_applications":             1,
+			}),
+			expectedDisplayNames: map[string]interface{}{},
+		},
+		{
+			name:                "mixed with display names",
+			id:                  "00000000-0000-0000-0000-000000000001",
+			resolveDisplayNames: true,
+			expectedSummary: summary(map[string]interface{}{
+				"guests_or_external_users_included": true,
+				"included_users":                    2,
+				"excluded_users":                    1,
+				"included_groups":                   1,
+				"included_roles":                    1,
+				"all_applications":                  true,
+				"excluded_applications":             1,
+			}),
+			expectedDisplayNames: map[string]interface{}{
+				user1:       "Alice",
+				userDeleted: "(deleted)",
+				group1:      "Admins",
+			},
+			expectedGetByIdsRequests: 1,
+		},
+		{
+			name:                 "literals",
+			id:                   "00000000-0000-0000-0000-000000000002",
+			resolveDisplayNames:  true,
+			expectedSummary:      summary(map[string]interface{}{"all_users": true}),
+			expectedDisplayNames: map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			getByIdsRequests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/identity/conditionalAccess/policies/"):
+					policy, ok := policies[r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]]
+					if !ok {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					fmt.Fprint(w, policy)
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/directoryObjects/getByIds"):
+					getByIdsRequests++
+					var body struct {
+						Ids []string `json:"ids"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decoding request: %v", err)
+					}
+					value := make([]string, 0)
+					for _, id := range body.Ids {
+						if object, ok := objects[id]; ok {
+							value = append(value, object)
+						}
+					}
+					fmt.Fprintf(w, `{"value": [%s]}`, strings.Join(value, ","))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			policyClient := client.NewConditionalAccessPolicyClient("00000000-0000-0000-0000-000000000000")
+			policyClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			policyClient.BaseClient.DisableRetries = true
+			meta := &clients.Client{
+				ConditionalAccess: &client.Client{ConditionalAccessPolicyClient: policyClient},
+			}
+
+			d := schema.TestResourceDataRaw(t, conditionalAccessPolicyDataSource().Schema, map[string]interface{}{
+				"object_id":             tc.id,
+				"resolve_display_names": tc.resolveDisplayNames,
+			})
+			if diags := conditionalAccessPolicyDataSourceRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if d.Id() != tc.id {
+				t.Fatalf("expected ID %q, got %q", tc.id, d.Id())
+			}
+			if got := d.Get("conditions_summary"); !reflect.DeepEqual(got, tc.expectedSummary) {
+				t.Fatalf("expected conditions summary %#v, got %#v", tc.expectedSummary, got)
+			}
+			if got := d.Get("resolved_display_names"); !reflect.DeepEqual(got, tc.expectedDisplayNames) {
+				t.Fatalf("expected resolved display names %#v, got %#v", tc.expectedDisplayNames, got)
+			}
+			if getByIdsRequests != tc.expectedGetByIdsRequests {
+				t.Fatalf("expected %d getByIds requests, got %d", tc.expectedGetByIdsRequests, getByIdsRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package conditionalaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/conditionalaccess/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func namedLocationResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: namedLocationResourceCreate,
+		ReadContext:   namedLocationResourceRead,
+		UpdateContext: namedLocationResourceUpdate,
+		DeleteContext: namedLocationResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The friendly name for this named location",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			// The type of a named location cannot be changed, so adding or removing either block forces replacement
+			"ip": {
+				Description:  "An IP-based named location",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"ip", "country"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_ranges": {
+							Description: "List of IP address ranges in IPv4 CIDR format (e.g. `1.2.3.4/32`) or any allowable IPv6 format from IETF RFC596",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.IsCIDR,
+							},
+						},
+
+						"trusted": {
+							Description: "Whether the named location is trusted",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+
+			"country": {
+				Description:  "A country-based named location",
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"ip", "country"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"countries_and_regions": {
+							Description: "List of countries and/or regions in two-letter format specified by ISO 3166-2",
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Z]{2}$`), "must be a two-letter uppercase country or region code"),
+							},
+						},
+
+						"country_lookup_method": {
+							Description: "Method of detecting the country the user is located in",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     client.CountryLookupMethodClientIpAddress,
+							ValidateFunc: validation.StringInSlice([]string{
+								client.CountryLookupMethodAuthenticatorAppGps,
+								client.CountryLookupMethodClientIpAddress,
+							}, false),
+						},
+
+						"include_unknown_countries_and_regions": {
+							Description: "Whether IP addresses that don't map to a country or region should be included in the named location",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func namedLocationResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.NamedLocationsClient
+	countryClient := meta.(*clients.Client).ConditionalAccess.CountryNamedLocationsClient
+	displayName := d.Get("display_name").(string)
+
+	var id *string
+	if v, ok := d.GetOk("ip"); ok {
+		location, _, err := client.CreateIP(ctx, expandIPNamedLocation(displayName, v.([]interface{})))
+		if err != nil {
+			return tf.ErrorDiagF(err, "Creating IP named location %q", displayName)
+		}
+		if location.BaseNamedLocation != nil {
+			id = location.ID
+		}
+	} else if v, ok := d.GetOk("country"); ok {
+		location, _, err := countryClient.Create(ctx, expandCountryNamedLocation(displayName, v.([]interface{})))
+		if err != nil {
+			return tf.ErrorDiagF(err, "Creating country named location %q", displayName)
+		}
+		if location.BaseNamedLocation != nil {
+			id = location.ID
+		}
+	}
+
+	if id == nil || *id == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for named location is nil/empty"), "Bad API response")
+	}
+
+	d.SetId(*id)
+
+	return namedLocationResourceRead(ctx, d, meta)
+}
+
+func namedLocationResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.NamedLocationsClient
+	countryClient := meta.(*clients.Client).ConditionalAccess.CountryNamedLocationsClient
+	displayName := d.Get("display_name").(string)
+
+	if v, ok := d.GetOk("ip"); ok {
+		location := expandIPNamedLocation(displayName, v.([]interface{}))
+		location.ID = utils.String(d.Id())
+		if _, err := client.UpdateIP(ctx, location); err != nil {
+			return tf.ErrorDiagF(err, "Updating IP named location with ID %q", d.Id())
+		}
+	} else if v, ok := d.GetOk("country"); ok {
+		location := expandCountryNamedLocation(displayName, v.([]interface{}))
+		location.ID = utils.String(d.Id())
+		if _, err := countryClient.Update(ctx, location); err != nil {
+			return tf.ErrorDiagF(err, "Updating country named location with ID %q", d.Id())
+		}
+	}
+
+	return namedLocationResourceRead(ctx, d, meta)
+}
+
+func namedLocationResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.CountryNamedLocationsClient
+
+	location, status, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Named location with ID %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving named location with ID %q", d.Id())
+	}
+	if location == nil {
+		return tf.ErrorDiagF(errors.New("named location was nil"), "Bad API response")
+	}
+
+	displayName, ip, country, err := flattenNamedLocation(location)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Reading named location with ID %q", d.Id())
+	}
+
+	tf.Set(d, "country", country)
+	tf.Set(d, "display_name", displayName)
+	tf.Set(d, "ip", ip)
+
+	return nil
+}
+
+func namedLocationResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ConditionalAccess.NamedLocationsClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "named location",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
+}
@@ -0,0 +1,259 @@
+package conditionalaccess_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type NamedLocationResource struct{}
+
+func TestAccNamedLocation_basicIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip.0.ip_ranges.#").HasValue("1"),
+				check.That(data.ResourceName).Key("ip.0.trusted").HasValue("false"),
+				check.That(data.ResourceName).Key("country.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_completeIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.completeIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip.0.ip_ranges.#").HasValue("3"),
+				check.That(data.ResourceName).Key("ip.0.trusted").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_updateIP(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.completeIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basicIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_basicCountry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.countries_and_regions.#").HasValue("2"),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("clientIpAddress"),
+				check.That(data.ResourceName).Key("country.0.include_unknown_countries_and_regions").HasValue("false"),
+				check.That(data.ResourceName).Key("ip.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_updateCountry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.completeCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.countries_and_regions.#").HasValue("3"),
+				check.That(data.ResourceName).Key("country.0.include_unknown_countries_and_regions").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_updateCountryLookupMethod(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("clientIpAddress"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.countryByGps(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("authenticatorAppGps"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basicCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("country.0.country_lookup_method").HasValue("clientIpAddress"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccNamedLocation_changeType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_named_location", "test")
+	r := NamedLocationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basicIP(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basicCountry(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("ip.#").HasValue("0"),
+				check.That(data.ResourceName).Key("country.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r NamedLocationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ConditionalAccess.NamedLocationsClient
+	client.BaseClient.DisableRetries = true
+
+	_, status, err := client.Get(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Named location with ID %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve named location with ID %q: %+v", state.ID, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (NamedLocationResource) basicIP(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLIP-%[1]d"
+
+  ip {
+    ip_ranges = ["1.1.1.1/32"]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) completeIP(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLIP-updated-%[1]d"
+
+  ip {
+    ip_ranges = ["1.1.1.1/32", "2.2.2.0/24", "2001:db8::/32"]
+    trusted   = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) basicCountry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLCountry-%[1]d"
+
+  country {
+    countries_and_regions = ["GB", "US"]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) completeCountry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLCountry-updated-%[1]d"
+
+  country {
+    countries_and_regions                 = ["GB", "US", "FR"]
+    include_unknown_countries_and_regions = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (NamedLocationResource) countryByGps(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_named_location" "test" {
+  display_name = "acctestNLCountry-%[1]d"
+
+  country {
+    countries_and_regions = ["GB", "US"]
+    country_lookup_method = "authenticatorAppGps"
+  }
+}
+`, data.RandomInteger)
+}
@@ -0,0 +1,184 @@
+package directoryroles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func administrativeUnitRoleMemberResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: administrativeUnitRoleMemberResourceCreate,
+		ReadContext:   administrativeUnitRoleMemberResourceRead,
+		DeleteContext: administrativeUnitRoleMemberResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.AdministrativeUnitRoleMemberID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"administrative_unit_object_id": {
+				Description:      "The object ID of the administrative unit to which the role assignment is scoped",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"role_object_id": {
+				Description:      "The object ID of the activated directory role",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"member_object_id": {
+				Description:      "The object ID of the user or service principal to be assigned the role",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+		},
+	}
+}
+
+func administrativeUnitRoleMemberResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	directoryRolesClient := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+	scopedRoleMembersClient := meta.(*clients.Client).DirectoryRoles.ScopedRoleMembersClient
+
+	id := parse.NewAdministrativeUnitRoleMemberID(d.Get("administrative_unit_object_id").(string), d.Get("role_object_id").(string), d.Get("member_object_id").(string))
+
+	existing, status, err := administrativeUnitRoleMemberFind(ctx, scopedRoleMembersClient, id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return tf.ErrorDiagPathF(nil, "administrative_unit_object_id", "Administrative unit with object ID %q was not found", id.AdministrativeUnitId)
+		}
+		return tf.ErrorDiagF(err, "Checking for existing scoped role member")
+	}
+	if existing != nil {
+		return tf.ImportAsExistsDiag("azuread_administrative_unit_role_member", id.String())
+	}
+
+	// The API returns a generic error for an unsuitable role or member, so check these first to give a useful error
+	if err := administrativeUnitRoleMemberCheckRole(ctx, directoryRolesClient, meta.(*clients.Client).DirectoryRoleTemplates, id.RoleId); err != nil {
+		return tf.ErrorDiagPathF(err, "role_object_id", "Invalid directory role for scoped role member")
+	}
+	if err := administrativeUnitRoleMemberCheckMember(ctx, directoryRolesClient.BaseClient, id.MemberId); err != nil {
+		return tf.ErrorDiagPathF(err, "member_object_id", "Invalid principal for scoped role member")
+	}
+
+	properties := client.ScopedRoleMembership{
+		RoleId: utils.String(id.RoleId),
+		RoleMemberInfo: &client.Identity{
+			ID: utils.String(id.MemberId),
+		},
+	}
+
+	membership, status, err := scopedRoleMembersClient.Create(ctx, id.AdministrativeUnitId, properties)
+	if err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationDirectoryRoleAssignment, meta.(*clients.Client).Claims)
+		return tf.ErrorDiagF(err, "Assigning directory role %q to principal %q scoped to administrative unit %q", id.RoleId, id.MemberId, id.AdministrativeUnitId)
+	}
+
+	if membership.ID == nil || *membership.ID == "" {
+		return tf.ErrorDiagF(errors.New("API returned scoped role member with nil ID"), "Bad API Response")
+	}
+
+	d.SetId(id.String())
+
+	return administrativeUnitRoleMemberResourceRead(ctx, d, meta)
+}
+
+func administrativeUnitRoleMemberResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.ScopedRoleMembersClient
+
+	id, err := parse.AdministrativeUnitRoleMemberID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing scoped role member with ID %q", d.Id())
+	}
+
+	membership, status, err := administrativeUnitRoleMemberFind(ctx, client, *id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Administrative unit with object ID %q was not found - removing scoped role member from state", id.AdministrativeUnitId)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving scoped role member with ID %q", d.Id())
+	}
+	if membership == nil {
+		log.Printf("[DEBUG] Scoped role member with ID %q was not found - removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "administrative_unit_object_id", id.AdministrativeUnitId)
+	tf.Set(d, "role_object_id", id.RoleId)
+	tf.Set(d, "member_object_id", id.MemberId)
+
+	return nil
+}
+
+func administrativeUnitRoleMemberResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roleMembersClient := meta.(*clients.Client).DirectoryRoles.ScopedRoleMembersClient
+
+	id, err := parse.AdministrativeUnitRoleMemberID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing scoped role member with ID %q", d.Id())
+	}
+
+	// Scoped role members can only be retrieved by listing them, so a missing member is reported with a 404 status
+	getMembership := func(ctx context.Context, c *client.ScopedRoleMembersClient) (*string, int, error) {
+		membership, status, err := administrativeUnitRoleMemberFind(ctx, c, *id)
+		if err != nil {
+			return nil, status, err
+		}
+		if membership == nil {
+			return nil, http.StatusNotFound, fmt.Errorf("scoped role member with ID %q was not found", d.Id())
+		}
+		return membership.ID, status, nil
+	}
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "scoped role member",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			pollClient := *roleMembersClient
+			pollClient.BaseClient.DisableRetries = true
+			_, status, err := getMembership(ctx, &pollClient)
+			return status, err
+		},
+	}
+
+	membershipId, status, err := getMembership(ctx, roleMembersClient)
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = roleMembersClient.Delete(ctx, id.AdministrativeUnitId, *membershipId)
+	return deletion.CheckDeleted(ctx, status, err)
+}
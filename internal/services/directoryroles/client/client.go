@@ -0,0 +1,40 @@
+package client
+
+import (
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+type Client struct {
+	DirectoryRolesClient         *msgraph.DirectoryRolesClient
+	DirectoryRoleTemplatesClient *msgraph.DirectoryRoleTemplatesClient
+	RoleAssignmentsClient        *RoleAssignmentsClient
+	RoleDefinitionsClient        *RoleDefinitionsClient
+	ScopedRoleMembersClient      *ScopedRoleMembersClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	directoryRolesClient := msgraph.NewDirectoryRolesClient(o.TenantID)
+	o.ConfigureClient(&directoryRolesClient.BaseClient)
+
+	directoryRoleTemplatesClient := msgraph.NewDirectoryRoleTemplatesClient(o.TenantID)
+	o.ConfigureClient(&directoryRoleTemplatesClient.BaseClient)
+
+	roleAssignmentsClient := NewRoleAssignmentsClient(o.TenantID)
+	o.ConfigureClient(&roleAssignmentsClient.BaseClient)
+
+	roleDefinitionsClient := NewRoleDefinitionsClient(o.TenantID)
+	o.ConfigureClient(&roleDefinitionsClient.BaseClient)
+
+	scopedRoleMembersClient := NewScopedRoleMembersClient(o.TenantID)
+	o.ConfigureClient(&scopedRoleMembersClient.BaseClient)
+
+	return &Client{
+		DirectoryRolesClient:         directoryRolesClient,
+		DirectoryRoleTemplatesClient: directoryRoleTemplatesClient,
+		RoleAssignmentsClient:        roleAssignmentsClient,
+		RoleDefinitionsClient:        roleDefinitionsClient,
+		ScopedRoleMembersClient:      scopedRoleMembersClient,
+	}
+}
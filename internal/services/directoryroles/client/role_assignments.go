@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+// UnifiedRoleAssignment describes the assignment of a directory role to a principal, at either tenant scope or a
+// narrower scope such as an administrative unit or application.
+type UnifiedRoleAssignment struct {
+	ID               *string `json:"id,omitempty"`
+	DirectoryScopeId *string `json:"directoryScopeId,omitempty"`
+	PrincipalId      *string `json:"principalId,omitempty"`
+	RoleDefinitionId *string `json:"roleDefinitionId,omitempty"`
+}
+
+// RoleAssignmentsClient performs operations on directory role assignments.
+type RoleAssignmentsClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewRoleAssignmentsClient returns a new RoleAssignmentsClient.
+func NewRoleAssignmentsClient(tenantId string) *RoleAssignmentsClient {
+	return &RoleAssignmentsClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// List returns a list of role assignments, optionally filtered using OData.
+func (c *RoleAssignmentsClient) List(ctx context.Context, filter string) (*[]UnifiedRoleAssignment, int, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Add("$filter", filter)
+	}
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      "/roleManagement/directory/roleAssignments",
+			Params:      params,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("RoleAssignmentsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		RoleAssignments []UnifiedRoleAssignment `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.RoleAssignments, status, nil
+}
+
+// ListTransitive returns a list of role assignments filtered using OData, including those held by principals through
+// membership of a role-assignable group. This is only available in the beta API, and requires an Azure AD Premium
+// license in the tenant.
+func (c *RoleAssignmentsClient) ListTransitive(ctx context.Context, filter string) (*[]UnifiedRoleAssignment, int, error) {
+	baseClient := c.BaseClient
+	baseClient.ApiVersion = msgraph.VersionBeta
+
+	var roleAssignments []UnifiedRoleAssignment
+	status, err := common.AdvancedQueryList(ctx, baseClient, "/roleManagement/directory/transitiveRoleAssignments", common.AdvancedQuery{Filter: filter}, &roleAssignments)
+	if err != nil {
+		return nil, status, fmt.Errorf("RoleAssignmentsClient.ListTransitive(): %v", err)
+	}
+	return &roleAssignments, status, nil
+}
+
+// Create creates a new role assignment.
+func (c *RoleAssignmentsClient) Create(ctx context.Context, roleAssignment UnifiedRoleAssignment) (*UnifiedRoleAssignment, int, error) {
+	body, err := json.Marshal(roleAssignment)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/roleManagement/directory/roleAssignments",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("RoleAssignmentsClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newRoleAssignment UnifiedRoleAssignment
+	if err := json.Unmarshal(respBody, &newRoleAssignment); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newRoleAssignment, status, nil
+}
+
+// Get retrieves a role assignment.
+func (c *RoleAssignmentsClient) Get(ctx context.Context, id string) (*UnifiedRoleAssignment, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/roleManagement/directory/roleAssignments/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("RoleAssignmentsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var roleAssignment UnifiedRoleAssignment
+	if err := json.Unmarshal(respBody, &roleAssignment); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &roleAssignment, status, nil
+}
+
+// Delete removes a role assignment.
+func (c *RoleAssignmentsClient) Delete(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/roleManagement/directory/roleAssignments/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("RoleAssignmentsClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
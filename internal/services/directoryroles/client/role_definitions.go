@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// UnifiedRoleDefinition describes a built-in or custom directory role definition. The ID of a built-in role definition
+// is the same as the ID of its role template.
+type UnifiedRoleDefinition struct {
+	ID          *string `json:"id,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	IsBuiltIn   *bool   `json:"isBuiltIn,omitempty"`
+	IsEnabled   *bool   `json:"isEnabled,omitempty"`
+	TemplateId  *string `json:"templateId,omitempty"`
+}
+
+// RoleDefinitionsClient performs operations on directory role definitions.
+type RoleDefinitionsClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewRoleDefinitionsClient returns a new RoleDefinitionsClient.
+func NewRoleDefinitionsClient(tenantId string) *RoleDefinitionsClient {
+	return &RoleDefinitionsClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// List returns a list of role definitions, optionally filtered using OData.
+func (c *RoleDefinitionsClient) List(ctx context.Context, filter string) (*[]UnifiedRoleDefinition, int, error) {
+	params := url.Values{}
+	if filter != "" {
+		params.Add("$filter", filter)
+	}
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      "/roleManagement/directory/roleDefinitions",
+			Params:      params,
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("RoleDefinitionsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		RoleDefinitions []UnifiedRoleDefinition `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.RoleDefinitions, status, nil
+}
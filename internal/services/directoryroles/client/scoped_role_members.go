@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ScopedRoleMembership describes the assignment of an activated directory role to a principal, scoped to an
+// administrative unit.
+type ScopedRoleMembership struct {
+	ID                   *string   `json:"id,omitempty"`
+	AdministrativeUnitId *string   `json:"administrativeUnitId,omitempty"`
+	RoleId               *string   `json:"roleId,omitempty"`
+	RoleMemberInfo       *Identity `json:"roleMemberInfo,omitempty"`
+}
+
+// Identity identifies the principal which is a member of a scoped role.
+type Identity struct {
+	ID          *string `json:"id,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+}
+
+// ScopedRoleMembersClient performs operations on the scoped role members of administrative units.
+type ScopedRoleMembersClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewScopedRoleMembersClient returns a new ScopedRoleMembersClient.
+func NewScopedRoleMembersClient(tenantId string) *ScopedRoleMembersClient {
+	return &ScopedRoleMembersClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// List returns the scoped role members of an administrative unit.
+func (c *ScopedRoleMembersClient) List(ctx context.Context, administrativeUnitId string) (*[]ScopedRoleMembership, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/directory/administrativeUnits/%s/scopedRoleMembers", administrativeUnitId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ScopedRoleMembersClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		ScopedRoleMembers []ScopedRoleMembership `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.ScopedRoleMembers, status, nil
+}
+
+// Create adds a scoped role member to an administrative unit.
+func (c *ScopedRoleMembersClient) Create(ctx context.Context, administrativeUnitId string, membership ScopedRoleMembership) (*ScopedRoleMembership, int, error) {
+	body, err := json.Marshal(membership)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/directory/administrativeUnits/%s/scopedRoleMembers", administrativeUnitId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ScopedRoleMembersClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newMembership ScopedRoleMembership
+	if err := json.Unmarshal(respBody, &newMembership); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newMembership, status, nil
+}
+
+// Delete removes a scoped role member from an administrative unit.
+func (c *ScopedRoleMembersClient) Delete(ctx context.Context, administrativeUnitId, membershipId string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/directory/administrativeUnits/%s/scopedRoleMembers/%s", administrativeUnitId, membershipId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ScopedRoleMembersClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
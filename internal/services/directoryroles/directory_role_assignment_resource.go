@@ -0,0 +1,143 @@
+package directoryroles
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+	directoryRolesValidate "github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/validate"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+// directoryScopeTenant is the directory scope for a tenant-wide role assignment
+const directoryScopeTenant = "/"
+
+func directoryRoleAssignmentResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: directoryRoleAssignmentResourceCreate,
+		ReadContext:   directoryRoleAssignmentResourceRead,
+		DeleteContext: directoryRoleAssignmentResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if id == "" {
+				return errors.New("specified ID is empty")
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Description:      "The object ID of the directory role definition, which for built-in roles is the role template ID",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"principal_object_id": {
+				Description:      "The object ID of the member principal",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"directory_scope_id": {
+				Description:      "The scope of the role assignment, either `/` for the tenant, `/administrativeUnits/{objectId}` for an administrative unit, or `/{applicationObjectId}` for an application",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          directoryScopeTenant,
+				ValidateDiagFunc: directoryRolesValidate.DirectoryScopeId,
+			},
+		},
+	}
+}
+
+func directoryRoleAssignmentResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roleId := d.Get("role_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+	directoryScopeId := d.Get("directory_scope_id").(string)
+
+	properties := client.UnifiedRoleAssignment{
+		DirectoryScopeId: utils.String(directoryScopeId),
+		PrincipalId:      utils.String(principalId),
+		RoleDefinitionId: utils.String(roleId),
+	}
+
+	client := meta.(*clients.Client).DirectoryRoles.RoleAssignmentsClient
+
+	assignment, status, err := client.Create(ctx, properties)
+	if err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationDirectoryRoleAssignment, meta.(*clients.Client).Claims)
+		return tf.ErrorDiagF(err, "Assigning directory role %q to principal %q with scope %q", roleId, principalId, directoryScopeId)
+	}
+
+	if assignment.ID == nil || *assignment.ID == "" {
+		return tf.ErrorDiagF(errors.New("API returned role assignment with nil ID"), "Bad API Response")
+	}
+
+	d.SetId(*assignment.ID)
+
+	return directoryRoleAssignmentResourceRead(ctx, d, meta)
+}
+
+func directoryRoleAssignmentResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.RoleAssignmentsClient
+
+	assignment, status, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Directory role assignment with ID %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving directory role assignment with ID %q", d.Id())
+	}
+
+	tf.Set(d, "directory_scope_id", directoryRoleAssignmentScope(assignment, d.Get("directory_scope_id").(string)))
+	tf.Set(d, "principal_object_id", assignment.PrincipalId)
+	tf.Set(d, "role_id", assignment.RoleDefinitionId)
+
+	return nil
+}
+
+func directoryRoleAssignmentResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.RoleAssignmentsClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "directory role assignment",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
+}
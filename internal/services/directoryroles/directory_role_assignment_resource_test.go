@@ -0,0 +1,116 @@
+package directoryroles_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type DirectoryRoleAssignmentResource struct{}
+
+// userAdministratorTemplateId is the template ID of the User Administrator role
+const userAdministratorTemplateId = "fe930be7-5e62-47db-91af-98c3a49a38b1"
+
+// applicationAdministratorTemplateId is the template ID of the Application Administrator role
+const applicationAdministratorTemplateId = "9b895d92-2cd3-44c7-9d02-a6ac2d5ea5c3"
+
+func TestAccDirectoryRoleAssignment_tenant(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_assignment", "test")
+	r := DirectoryRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.tenant(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_id").HasValue(userAdministratorTemplateId),
+				check.That(data.ResourceName).Key("principal_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("directory_scope_id").HasValue("/"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRoleAssignment_application(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_assignment", "test")
+	r := DirectoryRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.application(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_id").HasValue(applicationAdministratorTemplateId),
+				check.That(data.ResourceName).Key("directory_scope_id").MatchesRegex(regexp.MustCompile("^/[0-9a-fA-F-]{36}$")),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r DirectoryRoleAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.DirectoryRoles.RoleAssignmentsClient
+	client.BaseClient.DisableRetries = true
+
+	_, status, err := client.Get(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Directory role assignment with ID %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve directory role assignment with ID %q: %+v", state.ID, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (DirectoryRoleAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r DirectoryRoleAssignmentResource) tenant(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_directory_role_assignment" "test" {
+  role_id             = "%[2]s"
+  principal_object_id = azuread_user.test.object_id
+}
+`, r.template(data), userAdministratorTemplateId)
+}
+
+func (r DirectoryRoleAssignmentResource) application(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctest-APP-%[2]d"
+}
+
+resource "azuread_directory_role_assignment" "test" {
+  role_id             = "%[3]s"
+  principal_object_id = azuread_user.test.object_id
+  directory_scope_id  = "/${azuread_application.test.object_id}"
+}
+`, r.template(data), data.RandomInteger, applicationAdministratorTemplateId)
+}
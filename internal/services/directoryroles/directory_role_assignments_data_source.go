@@ -0,0 +1,197 @@
+package directoryroles
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func directoryRoleAssignmentsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: directoryRoleAssignmentsDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"principal_object_id": {
+				Description:      "The object ID of a user, group or service principal for which to list role assignments",
+				Type:             schema.TypeString,
+				Optional:         true,
+				AtLeastOneOf:     []string{"principal_object_id", "role_definition_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"role_definition_id": {
+				Description:      "The ID of a role definition for which to list role assignments. For built-in roles, this is the role template ID",
+				Type:             schema.TypeString,
+				Optional:         true,
+				AtLeastOneOf:     []string{"principal_object_id", "role_definition_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"include_transitive": {
+				Description: "Whether to include role assignments held through membership of a role-assignable group",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"role_assignments": {
+				Description: "A list of directory role assignments",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the role assignment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"role_definition_id": {
+							Description: "The ID of the assigned role definition",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"role_display_name": {
+							Description: "The display name of the assigned role",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"principal_object_id": {
+							Description: "The object ID of the assigned principal. For a transitive assignment, this is the object ID of the group through which the role is held",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"directory_scope_id": {
+							Description: "The scope of the role assignment, `/` for a tenant-wide assignment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func directoryRoleAssignmentsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roleAssignmentsClient := meta.(*clients.Client).DirectoryRoles.RoleAssignmentsClient
+	roleDefinitionsClient := meta.(*clients.Client).DirectoryRoles.RoleDefinitionsClient
+
+	principalId := d.Get("principal_object_id").(string)
+	roleDefinitionId := d.Get("role_definition_id").(string)
+	includeTransitive := d.Get("include_transitive").(bool)
+
+	var principalFilter, roleDefinitionFilter string
+	if principalId != "" {
+		principalFilter = helpers.ODataEq("principalId", principalId)
+	}
+	if roleDefinitionId != "" {
+		roleDefinitionFilter = helpers.ODataEq("roleDefinitionId", roleDefinitionId)
+	}
+	filter := helpers.ODataAnd(principalFilter, roleDefinitionFilter)
+
+	assignments, diags := directoryRoleAssignmentsListForFilter(ctx, roleAssignmentsClient, filter, includeTransitive)
+	if diags.HasError() {
+		return diags
+	}
+
+	roleNames, err := directoryRoleDefinitionNames(ctx, roleDefinitionsClient)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "role_assignments", "Could not resolve role definitions")
+	}
+
+	for _, a := range assignments {
+		if a.ID == nil {
+			return tf.ErrorDiagF(errors.New("API returned role assignment with nil ID"), "Bad API response")
+		}
+	}
+	sort.Slice(assignments, func(i, j int) bool {
+		return *assignments[i].ID < *assignments[j].ID
+	})
+
+	result := make([]interface{}, 0)
+	for _, a := range assignments {
+		roleDisplayName := ""
+		if a.RoleDefinitionId != nil {
+			roleDisplayName = roleNames[strings.ToLower(*a.RoleDefinitionId)]
+		}
+		result = append(result, map[string]interface{}{
+			"id":                  a.ID,
+			"role_definition_id":  a.RoleDefinitionId,
+			"role_display_name":   roleDisplayName,
+			"principal_object_id": a.PrincipalId,
+			"directory_scope_id":  directoryRoleAssignmentScope(&a, ""),
+		})
+	}
+
+	h := sha1.New()
+	if _, err := h.Write([]byte(fmt.Sprintf("%s-%t", filter, includeTransitive))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for filter")
+	}
+	d.SetId("directoryRoleAssignments#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+
+	tf.Set(d, "role_assignments", result)
+
+	return diags
+}
+
+// directoryRoleAssignmentsListForFilter returns the role assignments matching filter. When transitive is true, role
+// assignments held through group membership are included where the tenant supports this. Otherwise, a warning is
+// returned along with only the direct role assignments.
+func directoryRoleAssignmentsListForFilter(ctx context.Context, c *client.RoleAssignmentsClient, filter string, transitive bool) ([]client.UnifiedRoleAssignment, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if transitive {
+		assignments, status, err := c.ListTransitive(ctx, filter)
+		if err == nil {
+			if assignments == nil {
+				return nil, tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API response")
+			}
+			return *assignments, nil
+		}
+
+		// Transitive role assignments require an Azure AD Premium license, and the API rejects the request otherwise
+		if status != http.StatusBadRequest && status != http.StatusForbidden {
+			return nil, tf.ErrorDiagPathF(err, "include_transitive", "Could not retrieve transitive role assignments for filter %q", filter)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Transitive role assignments could not be retrieved",
+			Detail:        fmt.Sprintf("Only direct role assignments will be returned. Transitive role assignments require an Azure AD Premium P1 or P2 license in the tenant, along with permission to read role assignments: %v", err),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "include_transitive"}},
+		})
+	}
+
+	assignments, _, err := c.List(ctx, filter)
+	if err != nil {
+		return nil, tf.ErrorDiagF(err, "Could not retrieve role assignments for filter %q", filter)
+	}
+	if assignments == nil {
+		return nil, tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API response")
+	}
+
+	return *assignments, diags
+}
@@ -0,0 +1,82 @@
+package directoryroles_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type DirectoryRoleAssignmentsDataSource struct{}
+
+func TestAccDirectoryRoleAssignmentsDataSource_byPrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role_assignments", "test")
+	r := DirectoryRoleAssignmentsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byPrincipal(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("role_assignments.#").HasValue("1"),
+				check.That(data.ResourceName).Key("role_assignments.0.id").Exists(),
+				check.That(data.ResourceName).Key("role_assignments.0.role_definition_id").HasValue(userAdministratorTemplateId),
+				check.That(data.ResourceName).Key("role_assignments.0.role_display_name").HasValue("User Administrator"),
+				check.That(data.ResourceName).Key("role_assignments.0.directory_scope_id").HasValue("/"),
+			),
+		},
+	})
+}
+
+func TestAccDirectoryRoleAssignmentsDataSource_byPrincipalTransitive(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role_assignments", "test")
+	r := DirectoryRoleAssignmentsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byPrincipal(data, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("role_assignments.#").Exists(),
+				check.That(data.ResourceName).Key("role_assignments.0.role_definition_id").HasValue(userAdministratorTemplateId),
+			),
+		},
+	})
+}
+
+func TestAccDirectoryRoleAssignmentsDataSource_byRoleDefinition(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role_assignments", "test")
+	r := DirectoryRoleAssignmentsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byRoleDefinition(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("role_assignments.#").Exists(),
+				check.That(data.ResourceName).Key("role_assignments.0.role_display_name").HasValue("User Administrator"),
+			),
+		},
+	})
+}
+
+func (DirectoryRoleAssignmentsDataSource) byPrincipal(data acceptance.TestData, transitive bool) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_directory_role_assignments" "test" {
+  principal_object_id = azuread_directory_role_assignment.test.principal_object_id
+  include_transitive  = %[2]t
+}
+`, DirectoryRoleAssignmentResource{}.tenant(data), transitive)
+}
+
+func (DirectoryRoleAssignmentsDataSource) byRoleDefinition(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_directory_role_assignments" "test" {
+  role_definition_id = azuread_directory_role_assignment.test.role_id
+}
+`, DirectoryRoleAssignmentResource{}.tenant(data))
+}
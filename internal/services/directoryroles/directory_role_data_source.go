@@ -0,0 +1,230 @@
+package directoryroles
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func directoryRoleDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: directoryRoleDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The display name of the directory role",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "template_id"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"template_id": {
+				Description:      "The object ID of the template for the directory role",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "template_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"include_members_details": {
+				Description: "Whether to resolve the type and display name of each member of the directory role",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"assignments": {
+				Description: "A list of assignments of the directory role, including those scoped to an administrative unit or application",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"assignment_id": {
+							Description: "The ID of the role assignment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"principal_object_id": {
+							Description: "The object ID of the assigned principal",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"directory_scope_id": {
+							Description: "The scope of the role assignment, `/` for a tenant-wide assignment",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"description": {
+				Description: "The description of the directory role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"members": {
+				Description: "A list of object IDs of the members of the directory role",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"members_details": {
+				Description: "A list of members of the directory role, including their type and display name. Only populated when `include_members_details` is `true`",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_id": {
+							Description: "The object ID of the member",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The object type of the member, e.g. `user`, `group` or `servicePrincipal`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the member",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"object_id": {
+				Description: "The object ID of the directory role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func directoryRoleDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+	roleAssignmentsClient := meta.(*clients.Client).DirectoryRoles.RoleAssignmentsClient
+
+	displayName := d.Get("display_name").(string)
+	templateId := d.Get("template_id").(string)
+
+	attr, identifier := "display_name", displayName
+	if templateId != "" {
+		attr, identifier = "template_id", templateId
+	}
+
+	role, err := helpers.DirectoryRoleFind(ctx, client, displayName, templateId)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, attr, "Retrieving directory role %q", identifier)
+	}
+
+	if role == nil {
+		// Built-in roles must be activated in a tenant before they are returned, so consult the role templates to
+		// provide a more helpful error when the role exists but has not been activated
+		template, err := helpers.DirectoryRoleTemplateFind(ctx, meta.(*clients.Client).DirectoryRoleTemplates, displayName, templateId)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, attr, "Retrieving directory role template %q", identifier)
+		}
+		if template != nil && template.ID != nil {
+			return tf.ErrorDiagPathF(nil, attr, "Directory role %q (template ID %q) has not been activated in this tenant. Roles must be activated before they can be used, for example using the `azuread_directory_role` resource", identifier, *template.ID)
+		}
+		return tf.ErrorDiagPathF(nil, attr, "No directory role or role template was found matching %q", identifier)
+	}
+
+	if role.ID == nil {
+		return tf.ErrorDiagF(errors.New("API returned directory role with nil object ID"), "Bad API response")
+	}
+
+	members, _, err := client.ListMembers(ctx, *role.ID)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "members", "Could not retrieve members for directory role with object ID %q", *role.ID)
+	}
+	memberIds := make([]string, 0)
+	if members != nil {
+		memberIds = append(memberIds, *members...)
+	}
+	sort.Strings(memberIds)
+
+	membersDetails := make([]interface{}, 0)
+	if d.Get("include_members_details").(bool) && len(memberIds) > 0 {
+		objects, err := helpers.DirectoryObjectsGetByIds(ctx, client.BaseClient, memberIds)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "members_details", "Could not resolve members for directory role with object ID %q", *role.ID)
+		}
+		sort.Slice(objects, func(i, j int) bool {
+			return objects[i].ID < objects[j].ID
+		})
+		for _, o := range objects {
+			membersDetails = append(membersDetails, map[string]interface{}{
+				"object_id":    o.ID,
+				"type":         o.Type,
+				"display_name": o.DisplayName,
+			})
+		}
+	}
+
+	// Members of an activated role only reflect tenant-wide assignments, so role assignments are listed separately in
+	// order to include those scoped to an administrative unit or application
+	assignments := make([]interface{}, 0)
+	if role.RoleTemplateId != nil {
+		result, err := directoryRoleAssignmentsList(ctx, roleAssignmentsClient, *role.RoleTemplateId)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "assignments", "Could not retrieve assignments for directory role with object ID %q", *role.ID)
+		}
+		if result != nil {
+			for _, a := range *result {
+				if a.ID == nil {
+					return tf.ErrorDiagF(errors.New("API returned role assignment with nil ID"), "Bad API response")
+				}
+			}
+			sort.Slice(*result, func(i, j int) bool {
+				return *(*result)[i].ID < *(*result)[j].ID
+			})
+			for _, a := range *result {
+				assignments = append(assignments, map[string]interface{}{
+					"assignment_id":       a.ID,
+					"principal_object_id": a.PrincipalId,
+					"directory_scope_id":  directoryRoleAssignmentScope(&a, ""),
+				})
+			}
+		}
+	}
+
+	d.SetId(*role.ID)
+
+	tf.Set(d, "assignments", assignments)
+	tf.Set(d, "description", role.Description)
+	tf.Set(d, "display_name", role.DisplayName)
+	tf.Set(d, "members", memberIds)
+	tf.Set(d, "members_details", membersDetails)
+	tf.Set(d, "object_id", role.ID)
+	tf.Set(d, "template_id", role.RoleTemplateId)
+
+	return nil
+}
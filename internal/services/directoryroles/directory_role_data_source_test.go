@@ -0,0 +1,90 @@
+package directoryroles_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type DirectoryRoleDataSource struct{}
+
+// globalAdministratorTemplateId is the template ID of the Global Administrator role, which is always activated
+const globalAdministratorTemplateId = "62e90394-69f5-4237-9190-012177145e10"
+
+func TestAccDirectoryRoleDataSource_byDisplayName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role", "test")
+	r := DirectoryRoleDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byDisplayName(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+				check.That(data.ResourceName).Key("template_id").HasValue(globalAdministratorTemplateId),
+				check.That(data.ResourceName).Key("description").Exists(),
+				check.That(data.ResourceName).Key("members.#").Exists(),
+				check.That(data.ResourceName).Key("members_details.#").HasValue("0"),
+				check.That(data.ResourceName).Key("assignments.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDirectoryRoleDataSource_byTemplateIdWithMembersDetails(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role", "test")
+	r := DirectoryRoleDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byTemplateIdWithMembersDetails(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+				check.That(data.ResourceName).Key("display_name").HasValue("Global Administrator"),
+				check.That(data.ResourceName).Key("members_details.#").MatchesOtherKey(check.That(data.ResourceName).Key("members.#")),
+				check.That(data.ResourceName).Key("members_details.0.object_id").IsUuid(),
+				check.That(data.ResourceName).Key("members_details.0.type").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDirectoryRoleDataSource_notFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_directory_role", "test")
+	r := DirectoryRoleDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      r.notFound(data),
+			ExpectError: regexp.MustCompile("No directory role or role template was found matching"),
+		},
+	})
+}
+
+func (DirectoryRoleDataSource) byDisplayName() string {
+	return `
+data "azuread_directory_role" "test" {
+  display_name = "Global Administrator"
+}
+`
+}
+
+func (DirectoryRoleDataSource) byTemplateIdWithMembersDetails() string {
+	return `
+data "azuread_directory_role" "test" {
+  template_id             = "` + globalAdministratorTemplateId + `"
+  include_members_details = true
+}
+`
+}
+
+func (DirectoryRoleDataSource) notFound(data acceptance.TestData) string {
+	return `
+data "azuread_directory_role" "test" {
+  display_name = "acctest-not-a-role-` + data.RandomString + `"
+}
+`
+}
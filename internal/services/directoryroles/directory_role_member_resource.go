@@ -0,0 +1,85 @@
+package directoryroles
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/parse"
+)
+
+func directoryRoleMemberResource() *schema.Resource {
+	return directoryRoleMemberRelationship().Resource()
+}
+
+func directoryRoleMemberRelationship() helpers.RelationshipResource {
+	return helpers.RelationshipResource{
+		Name:               "azuread_directory_role_member",
+		ParentType:         "directory role",
+		ParentAttribute:    "role_object_id",
+		ParentDescription:  "The object ID of the activated directory role",
+		RelatedType:        "member",
+		RelatedAttribute:   "member_object_id",
+		RelatedDescription: "The object ID of the member principal. Supported object types are Users, Groups or Service Principals",
+
+		// Adding many members to the same role concurrently results in Graph concurrency errors
+		LockName:         directoryRoleResourceName,
+		MissingIsRemoved: true,
+
+		StrictDelete: func(meta interface{}) bool {
+			return meta.(*clients.Client).StrictDelete
+		},
+
+		FormatId: func(roleId, memberId string) string {
+			return parse.NewDirectoryRoleMemberID(roleId, memberId).String()
+		},
+
+		ParseId: func(idString string) (string, string, error) {
+			id, err := parse.DirectoryRoleMemberID(idString)
+			if err != nil {
+				return "", "", err
+			}
+			return id.RoleId, id.MemberId, nil
+		},
+
+		GetParent: func(ctx context.Context, meta interface{}, roleId string) (int, error) {
+			client := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+
+			_, status, err := client.Get(ctx, roleId)
+			if status == http.StatusNotFound {
+				// A role template ID is often mistakenly specified in place of the object ID of the activated role
+				if err := administrativeUnitRoleMemberCheckRole(ctx, client, meta.(*clients.Client).DirectoryRoleTemplates, roleId); err != nil {
+					return 0, err
+				}
+			}
+			return status, err
+		},
+
+		List: func(ctx context.Context, meta interface{}, roleId string) (*[]string, int, error) {
+			return meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient.ListMembers(ctx, roleId)
+		},
+
+		Add: func(ctx context.Context, meta interface{}, roleId, memberId string) error {
+			client := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+
+			role := msgraph.DirectoryRole{ID: &roleId}
+			role.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, memberId)
+
+			status, err := client.AddMembers(ctx, &role)
+			if err != nil {
+				err = helpers.GraphError(err)
+				err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, []string{memberId})
+				err = helpers.PermissionsError(err, status, helpers.PermissionsOperationDirectoryRoleAssignment, meta.(*clients.Client).Claims)
+			}
+			return err
+		},
+
+		Remove: func(ctx context.Context, meta interface{}, roleId, memberId string) (int, error) {
+			return meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient.RemoveMembers(ctx, roleId, &[]string{memberId})
+		},
+	}
+}
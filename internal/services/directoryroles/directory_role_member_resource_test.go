@@ -0,0 +1,218 @@
+package directoryroles_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type DirectoryRoleMemberResource struct{}
+
+func TestAccDirectoryRoleMember_user(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_member", "test")
+	r := DirectoryRoleMemberResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.user(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("role_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("member_object_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRoleMember_group(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_member", "test")
+	r := DirectoryRoleMemberResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.group(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRoleMember_servicePrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_member", "test")
+	r := DirectoryRoleMemberResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.servicePrincipal(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRoleMember_manyMembers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_member", "test")
+	r := DirectoryRoleMemberResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			// Writes are serialized per role, so adding many members in one apply should not be throttled
+			Config: r.manyMembers(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName+".0").ExistsInAzure(r),
+				check.That(data.ResourceName+".1").ExistsInAzure(r),
+				check.That(data.ResourceName+".2").ExistsInAzure(r),
+				check.That(data.ResourceName+".3").ExistsInAzure(r),
+				check.That(data.ResourceName+".4").ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccDirectoryRoleMember_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role_member", "test")
+	r := DirectoryRoleMemberResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.user(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r DirectoryRoleMemberResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.DirectoryRoles.DirectoryRolesClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.DirectoryRoleMemberID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Directory Role Member ID: %v", err)
+	}
+
+	if _, status, err := client.GetMember(ctx, id.RoleId, id.MemberId); err != nil {
+		if status == http.StatusNotFound {
+			return utils.Bool(false), nil
+		}
+		return nil, fmt.Errorf("failed to retrieve directory role member %q (role ID: %q): %+v", id.MemberId, id.RoleId, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (DirectoryRoleMemberResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_directory_role" "test" {
+  template_id = "%[1]s"
+}
+`, applicationDeveloperTemplateId)
+}
+
+func (r DirectoryRoleMemberResource) user(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[2]d"
+  password            = "%[3]s"
+}
+
+resource "azuread_directory_role_member" "test" {
+  role_object_id   = azuread_directory_role.test.object_id
+  member_object_id = azuread_user.test.object_id
+}
+`, r.template(data), data.RandomInteger, data.RandomPassword)
+}
+
+func (r DirectoryRoleMemberResource) group(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  display_name       = "acctestGroup-%[2]d"
+  assignable_to_role = true
+  security_enabled   = true
+}
+
+resource "azuread_directory_role_member" "test" {
+  role_object_id   = azuread_directory_role.test.object_id
+  member_object_id = azuread_group.test.object_id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DirectoryRoleMemberResource) servicePrincipal(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[2]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+}
+
+resource "azuread_directory_role_member" "test" {
+  role_object_id   = azuread_directory_role.test.object_id
+  member_object_id = azuread_service_principal.test.object_id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r DirectoryRoleMemberResource) manyMembers(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "test" {
+  count = 5
+
+  user_principal_name = "acctestUser.%[2]d.${count.index}@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[2]d-${count.index}"
+  password            = "%[3]s"
+}
+
+resource "azuread_directory_role_member" "test" {
+  count = 5
+
+  role_object_id   = azuread_directory_role.test.object_id
+  member_object_id = azuread_user.test[count.index].object_id
+}
+`, r.template(data), data.RandomInteger, data.RandomPassword)
+}
+
+func (r DirectoryRoleMemberResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_directory_role_member" "import" {
+  role_object_id   = azuread_directory_role_member.test.role_object_id
+  member_object_id = azuread_directory_role_member.test.member_object_id
+}
+`, r.user(data))
+}
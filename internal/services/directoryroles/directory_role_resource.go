@@ -0,0 +1,153 @@
+package directoryroles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+const directoryRoleResourceName = "azuread_directory_role"
+
+func directoryRoleResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: directoryRoleResourceCreate,
+		ReadContext:   directoryRoleResourceRead,
+		DeleteContext: directoryRoleResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The display name of the directory role",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"display_name", "template_id"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"template_id": {
+				Description:      "The object ID of the template for the directory role",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"display_name", "template_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"description": {
+				Description: "The description of the directory role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"object_id": {
+				Description: "The object ID of the directory role",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func directoryRoleResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+
+	displayName := d.Get("display_name").(string)
+	templateId := d.Get("template_id").(string)
+
+	attr, identifier := "display_name", displayName
+	if templateId != "" {
+		attr, identifier = "template_id", templateId
+	}
+
+	role, template, err := helpers.DirectoryRoleResolve(ctx, client, meta.(*clients.Client).DirectoryRoleTemplates, displayName, templateId)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, attr, "Retrieving directory role %q", identifier)
+	}
+
+	// Built-in roles are activated from their template, unless they have already been activated in the tenant
+	if role == nil {
+		if template == nil {
+			return tf.ErrorDiagPathF(nil, attr, "No directory role or role template was found matching %q", identifier)
+		}
+		if template.ID == nil {
+			return tf.ErrorDiagF(errors.New("API returned directory role template with nil object ID"), "Bad API response")
+		}
+
+		var status int
+		role, status, err = client.Activate(ctx, *template.ID)
+		if err != nil {
+			err = helpers.PermissionsError(err, status, helpers.PermissionsOperationDirectoryRoleActivate, meta.(*clients.Client).Claims)
+			return tf.ErrorDiagPathF(err, attr, "Activating directory role %q (template ID %q)", identifier, *template.ID)
+		}
+	}
+
+	if role == nil || role.ID == nil || *role.ID == "" {
+		return tf.ErrorDiagF(errors.New("API returned directory role with nil object ID"), "Bad API response")
+	}
+
+	d.SetId(*role.ID)
+
+	return directoryRoleResourceRead(ctx, d, meta)
+}
+
+func directoryRoleResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).DirectoryRoles.DirectoryRolesClient
+
+	role, status, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Directory role with object ID %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving directory role with object ID %q", d.Id())
+	}
+	if role == nil {
+		return tf.ErrorDiagF(errors.New("API returned nil directory role"), "Bad API response")
+	}
+
+	tf.Set(d, "description", role.Description)
+	tf.Set(d, "display_name", role.DisplayName)
+	tf.Set(d, "object_id", role.ID)
+	tf.Set(d, "template_id", role.RoleTemplateId)
+
+	return nil
+}
+
+func directoryRoleResourceDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Directory roles cannot be deactivated once activated, so the role is only removed from state
+	log.Printf("[DEBUG] Directory role with object ID %q cannot be deactivated - removing from state", d.Id())
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Directory role with object ID %q was not deactivated", d.Id()),
+		Detail:   "Activated directory roles cannot be deactivated. The role has been removed from state but remains activated in the tenant.",
+	}}
+}
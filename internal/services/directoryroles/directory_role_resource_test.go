@@ -0,0 +1,118 @@
+package directoryroles_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type DirectoryRoleResource struct{}
+
+// applicationDeveloperTemplateId is the template ID of the Application Developer role
+const applicationDeveloperTemplateId = "cf1c38e5-3621-4004-a7cb-879624dced7c"
+
+func TestAccDirectoryRole_byDisplayName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role", "test")
+	r := DirectoryRoleResource{}
+
+	data.ResourceTestIgnoreCheckDestroyed(t, []resource.TestStep{
+		{
+			Config: r.byDisplayName(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").Exists(),
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+				check.That(data.ResourceName).Key("template_id").HasValue(applicationDeveloperTemplateId),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRole_byTemplateId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role", "test")
+	r := DirectoryRoleResource{}
+
+	data.ResourceTestIgnoreCheckDestroyed(t, []resource.TestStep{
+		{
+			Config: r.byTemplateId(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("description").Exists(),
+				check.That(data.ResourceName).Key("display_name").HasValue("Application Developer"),
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccDirectoryRole_alreadyActivated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_directory_role", "test")
+	r := DirectoryRoleResource{}
+
+	data.ResourceTestIgnoreCheckDestroyed(t, []resource.TestStep{
+		{
+			Config: r.globalAdministrator(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("display_name").HasValue("Global Administrator"),
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+			),
+		},
+	})
+}
+
+func (r DirectoryRoleResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.DirectoryRoles.DirectoryRolesClient
+	client.BaseClient.DisableRetries = true
+
+	role, status, err := client.Get(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Directory role with object ID %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve directory role with object ID %q: %+v", state.ID, err)
+	}
+
+	return utils.Bool(role.ID != nil && *role.ID == state.ID), nil
+}
+
+func (DirectoryRoleResource) byDisplayName() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_directory_role" "test" {
+  display_name = "Application Developer"
+}
+`
+}
+
+func (DirectoryRoleResource) byTemplateId() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_directory_role" "test" {
+  template_id = "` + applicationDeveloperTemplateId + `"
+}
+`
+}
+
+func (DirectoryRoleResource) globalAdministrator() string {
+	return `
+provider "azuread" {}
+
+resource "azuread_directory_role" "test" {
+  template_id = "` + globalAdministratorTemplateId + `"
+}
+`
+}
@@ -0,0 +1,130 @@
+package directoryroles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/parse"
+)
+
+// directoryRoleAssignmentScope returns the directory scope of a role assignment. An unset scope denotes a tenant-wide
+// assignment, and the existing value is retained when it differs from the API response only by case, so that object
+// IDs specified in upper case do not cause a diff.
+func directoryRoleAssignmentScope(assignment *client.UnifiedRoleAssignment, existing string) string {
+	if assignment == nil || assignment.DirectoryScopeId == nil || *assignment.DirectoryScopeId == "" {
+		return directoryScopeTenant
+	}
+	if strings.EqualFold(*assignment.DirectoryScopeId, existing) {
+		return existing
+	}
+	return *assignment.DirectoryScopeId
+}
+
+// directoryRoleAssignmentsList returns the assignments for a directory role definition
+func directoryRoleAssignmentsList(ctx context.Context, c *client.RoleAssignmentsClient, roleId string) (*[]client.UnifiedRoleAssignment, error) {
+	filter := helpers.ODataEq("roleDefinitionId", roleId)
+	assignments, _, err := c.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing role assignments for filter %q: %v", filter, err)
+	}
+	return assignments, nil
+}
+
+// directoryRoleDefinitionNames returns the display names of all directory role definitions, keyed by lower-cased ID
+func directoryRoleDefinitionNames(ctx context.Context, c *client.RoleDefinitionsClient) (map[string]string, error) {
+	definitions, _, err := c.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing role definitions: %v", err)
+	}
+	if definitions == nil {
+		return nil, fmt.Errorf("listing role definitions: API returned nil result")
+	}
+
+	result := make(map[string]string)
+	for _, definition := range *definitions {
+		if definition.ID != nil && definition.DisplayName != nil {
+			result[strings.ToLower(*definition.ID)] = *definition.DisplayName
+		}
+	}
+	return result, nil
+}
+
+// administrativeUnitRoleMemberFind returns the scoped role membership of an administrative unit matching the role and
+// member in the specified ID. A nil membership is returned when no scoped role member matches.
+func administrativeUnitRoleMemberFind(ctx context.Context, c *client.ScopedRoleMembersClient, id parse.AdministrativeUnitRoleMemberId) (*client.ScopedRoleMembership, int, error) {
+	memberships, status, err := c.List(ctx, id.AdministrativeUnitId)
+	if err != nil {
+		return nil, status, fmt.Errorf("listing scoped role members for administrative unit %q: %v", id.AdministrativeUnitId, err)
+	}
+	if memberships == nil {
+		return nil, status, fmt.Errorf("listing scoped role members for administrative unit %q: API returned nil result", id.AdministrativeUnitId)
+	}
+
+	for _, membership := range *memberships {
+		if membership.RoleId == nil || membership.RoleMemberInfo == nil || membership.RoleMemberInfo.ID == nil {
+			continue
+		}
+		if strings.EqualFold(*membership.RoleId, id.RoleId) && strings.EqualFold(*membership.RoleMemberInfo.ID, id.MemberId) {
+			return &membership, status, nil
+		}
+	}
+
+	return nil, status, nil
+}
+
+// administrativeUnitRoleMemberCheckRole returns an error explaining why the specified role cannot be scoped to an
+// administrative unit, or nil if the role is an activated directory role. The API returns a generic error in this case,
+// and a role template ID is often mistakenly specified in place of the object ID of the activated role.
+func administrativeUnitRoleMemberCheckRole(ctx context.Context, rolesClient *msgraph.DirectoryRolesClient, listTemplates helpers.DirectoryRoleTemplatesList, roleId string) error {
+	roles, _, err := rolesClient.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing directory roles: %v", err)
+	}
+	if roles != nil {
+		for _, role := range *roles {
+			if role.ID != nil && strings.EqualFold(*role.ID, roleId) {
+				return nil
+			}
+		}
+	}
+
+	template, err := helpers.DirectoryRoleTemplateFind(ctx, listTemplates, "", roleId)
+	if err != nil {
+		return err
+	}
+	if template != nil {
+		displayName := ""
+		if template.DisplayName != nil {
+			displayName = *template.DisplayName
+		}
+		if role, err := helpers.DirectoryRoleFind(ctx, rolesClient, "", roleId); err == nil && role != nil && role.ID != nil {
+			return fmt.Errorf("%q is the template ID of the directory role %q, specify the object ID of the activated role (%q) instead", roleId, displayName, *role.ID)
+		}
+		return fmt.Errorf("%q is the template ID of the directory role %q, which is not activated in this tenant. The role must be activated, and the object ID of the activated role specified instead", roleId, displayName)
+	}
+
+	return fmt.Errorf("no activated directory role with object ID %q was found", roleId)
+}
+
+// administrativeUnitRoleMemberCheckMember returns an error explaining why the specified principal cannot be a scoped
+// role member, or nil if the principal is a user or a service principal.
+func administrativeUnitRoleMemberCheckMember(ctx context.Context, c msgraph.Client, memberId string) error {
+	objects, err := helpers.DirectoryObjectsGetByIds(ctx, c, []string{memberId})
+	if err != nil {
+		return fmt.Errorf("retrieving directory object %q: %v", memberId, err)
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("no user or service principal with object ID %q was found", memberId)
+	}
+
+	switch objects[0].Type {
+	case helpers.DirectoryObjectTypeUser, helpers.DirectoryObjectTypeServicePrincipal:
+		return nil
+	}
+	return fmt.Errorf("object %q has type %q, but scoped role members must be users or service principals", memberId, objects[0].Type)
+}
@@ -0,0 +1,216 @@
+package directoryroles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/directoryroles/client"
+)
+
+func TestAdministrativeUnitRoleMemberChecks(t *testing.T) {
+	const (
+		activatedRoleId     = "11111111-1111-1111-1111-111111111111"
+		activatedTemplateId = "22222222-2222-2222-2222-222222222222"
+		inactiveTemplateId  = "33333333-3333-3333-3333-333333333333"
+		userId              = "44444444-4444-4444-4444-444444444444"
+		servicePrincipalId  = "55555555-5555-5555-5555-555555555555"
+		groupId             = "66666666-6666-6666-6666-666666666666"
+		unknownId           = "77777777-7777-7777-7777-777777777777"
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch strings.TrimPrefix(r.URL.Path, "/v1.0/00000000-0000-0000-0000-000000000000") {
+		case "/directoryRoles":
+			fmt.Fprintf(w, `{"value":[{"id":%q,"roleTemplateId":%q,"displayName":"User Administrator"}]}`, activatedRoleId, activatedTemplateId)
+		case "/directoryRoleTemplates":
+			fmt.Fprintf(w, `{"value":[{"id":%q,"displayName":"User Administrator"},{"id":%q,"displayName":"Helpdesk Administrator"}]}`, activatedTemplateId, inactiveTemplateId)
+		case "/directoryObjects/getByIds":
+			var body struct {
+				Ids []string `json:"ids"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			types := map[string]string{userId: "user", servicePrincipalId: "servicePrincipal", groupId: "group"}
+			value := make([]map[string]string, 0)
+			for _, id := range body.Ids {
+				if objectType, ok := types[id]; ok {
+					value = append(value, map[string]string{"@odata.type": "#microsoft.graph." + objectType, "id": id})
+				}
+			}
+			if err := json.NewEncoder(w).Encode(map[string]interface{}{"value": value}); err != nil {
+				t.Fatalf("encoding response: %v", err)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+		}
+	}))
+	defer server.Close()
+
+	rolesClient := msgraph.NewDirectoryRolesClient("00000000-0000-0000-0000-000000000000")
+	rolesClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	rolesClient.BaseClient.DisableRetries = true
+
+	templatesClient := msgraph.NewDirectoryRoleTemplatesClient("00000000-0000-0000-0000-000000000000")
+	templatesClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	templatesClient.BaseClient.DisableRetries = true
+	listTemplates := func(ctx context.Context) (*[]msgraph.DirectoryRoleTemplate, error) {
+		templates, _, err := templatesClient.List(ctx)
+		return templates, err
+	}
+
+	roleCases := []struct {
+		roleId   string
+		expected string
+	}{
+		{roleId: activatedRoleId},
+		{roleId: activatedTemplateId, expected: fmt.Sprintf("specify the object ID of the activated role (%q) instead", activatedRoleId)},
+		{roleId: inactiveTemplateId, expected: "which is not activated in this tenant"},
+		{roleId: unknownId, expected: "no activated directory role"},
+	}
+	for _, c := range roleCases {
+		err := administrativeUnitRoleMemberCheckRole(context.Background(), rolesClient, listTemplates, c.roleId)
+		if c.expected == "" {
+			if err != nil {
+				t.Errorf("unexpected error for role %q: %v", c.roleId, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("expected error containing %q for role %q, got: %v", c.expected, c.roleId, err)
+		}
+	}
+
+	memberCases := []struct {
+		memberId string
+		expected string
+	}{
+		{memberId: userId},
+		{memberId: servicePrincipalId},
+		{memberId: groupId, expected: `has type "group"`},
+		{memberId: unknownId, expected: "no user or service principal"},
+	}
+	for _, c := range memberCases {
+		err := administrativeUnitRoleMemberCheckMember(context.Background(), rolesClient.BaseClient, c.memberId)
+		if c.expected == "" {
+			if err != nil {
+				t.Errorf("unexpected error for member %q: %v", c.memberId, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("expected error containing %q for member %q, got: %v", c.expected, c.memberId, err)
+		}
+	}
+}
+
+func TestDirectoryRoleAssignmentsListForFilter(t *testing.T) {
+	const (
+		principalId = "11111111-1111-1111-1111-111111111111"
+		groupId     = "22222222-2222-2222-2222-222222222222"
+		roleId      = "62e90394-69f5-4237-9190-012177145e10"
+	)
+	filter := fmt.Sprintf("principalId eq '%s'", principalId)
+
+	cases := []struct {
+		name             string
+		transitive       bool
+		transitiveStatus int
+		expectedIds      []string
+		expectWarning    bool
+		expectError      bool
+	}{
+		{name: "direct", expectedIds: []string{"direct"}},
+		{name: "transitive", transitive: true, transitiveStatus: http.StatusOK, expectedIds: []string{"direct", "transitive"}},
+		{name: "transitive unlicensed", transitive: true, transitiveStatus: http.StatusForbidden, expectedIds: []string{"direct"}, expectWarning: true},
+		{name: "transitive rejected", transitive: true, transitiveStatus: http.StatusBadRequest, expectedIds: []string{"direct"}, expectWarning: true},
+		{name: "transitive not found", transitive: true, transitiveStatus: http.StatusNotFound, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if got := r.URL.Query().Get("$filter"); got != filter {
+					t.Errorf("expected filter %q, got %q", filter, got)
+				}
+				switch r.URL.Path {
+				case "/v1.0/00000000-0000-0000-0000-000000000000/roleManagement/directory/roleAssignments":
+					fmt.Fprintf(w, `{"value":[{"id":"direct","principalId":%q,"roleDefinitionId":%q,"directoryScopeId":"/"}]}`, principalId, roleId)
+				case "/beta/00000000-0000-0000-0000-000000000000/roleManagement/directory/transitiveRoleAssignments":
+					if got := r.Header.Get("ConsistencyLevel"); got != "eventual" {
+						t.Errorf("expected ConsistencyLevel header to be %q, got %q", "eventual", got)
+					}
+					if c.transitiveStatus != http.StatusOK {
+						w.WriteHeader(c.transitiveStatus)
+						fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+						return
+					}
+					fmt.Fprintf(w, `{"value":[{"id":"direct","principalId":%q,"roleDefinitionId":%q,"directoryScopeId":"/"},{"id":"transitive","principalId":%q,"roleDefinitionId":%q,"directoryScopeId":"/"}]}`, principalId, roleId, groupId, roleId)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+				}
+			}))
+			defer server.Close()
+
+			roleAssignmentsClient := client.NewRoleAssignmentsClient("00000000-0000-0000-0000-000000000000")
+			roleAssignmentsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			roleAssignmentsClient.BaseClient.DisableRetries = true
+
+			assignments, diags := directoryRoleAssignmentsListForFilter(context.Background(), roleAssignmentsClient, filter, c.transitive)
+			if c.expectError {
+				if !diags.HasError() {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %+v", diags)
+			}
+			if c.expectWarning != (len(diags) == 1 && diags[0].Severity == diag.Warning) {
+				t.Fatalf("expected warning: %t, got diagnostics: %+v", c.expectWarning, diags)
+			}
+
+			ids := make([]string, 0)
+			for _, a := range assignments {
+				ids = append(ids, *a.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(c.expectedIds, ",") {
+				t.Fatalf("expected assignments %v, got %v", c.expectedIds, ids)
+			}
+		})
+	}
+}
+
+func TestDirectoryRoleMemberRelationshipIds(t *testing.T) {
+	roleId, memberId := "00000000-0000-0000-0000-000000000000", "11111111-1111-1111-1111-111111111111"
+	relationship := directoryRoleMemberRelationship()
+
+	expected := fmt.Sprintf("%s/member/%s", roleId, memberId)
+	if id := relationship.FormatId(roleId, memberId); id != expected {
+		t.Fatalf("expected ID %q, got %q", expected, id)
+	}
+
+	parentId, relatedId, err := relationship.ParseId(expected)
+	if err != nil {
+		t.Fatalf("unexpected error parsing ID: %v", err)
+	}
+	if parentId != roleId || relatedId != memberId {
+		t.Fatalf("expected IDs %q and %q, got %q and %q", roleId, memberId, parentId, relatedId)
+	}
+
+	if _, _, err := relationship.ParseId(fmt.Sprintf("%s/owner/%s", roleId, memberId)); err == nil {
+		t.Fatal("expected an error parsing an ID of another type")
+	}
+	if !relationship.MissingIsRemoved {
+		t.Fatal("expected a missing role or membership to be removed from state")
+	}
+}
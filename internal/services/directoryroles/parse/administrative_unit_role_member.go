@@ -0,0 +1,49 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// AdministrativeUnitRoleMemberId identifies a principal which is a member of an activated directory role, scoped to an
+// administrative unit.
+type AdministrativeUnitRoleMemberId struct {
+	AdministrativeUnitId string
+	RoleId               string
+	MemberId             string
+}
+
+func NewAdministrativeUnitRoleMemberID(administrativeUnitId, roleId, memberId string) AdministrativeUnitRoleMemberId {
+	return AdministrativeUnitRoleMemberId{
+		AdministrativeUnitId: administrativeUnitId,
+		RoleId:               roleId,
+		MemberId:             memberId,
+	}
+}
+
+func (id AdministrativeUnitRoleMemberId) String() string {
+	return fmt.Sprintf("%s/role/%s/member/%s", id.AdministrativeUnitId, id.RoleId, id.MemberId)
+}
+
+func AdministrativeUnitRoleMemberID(idString string) (*AdministrativeUnitRoleMemberId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 5 || parts[1] != "role" || parts[3] != "member" {
+		return nil, fmt.Errorf("Administrative Unit Role Member ID should be in the format {administrativeUnitId}/role/{roleObjectId}/member/{memberObjectId} - but got %q", idString)
+	}
+
+	id := NewAdministrativeUnitRoleMemberID(parts[0], parts[2], parts[4])
+
+	if _, err := uuid.ParseUUID(id.AdministrativeUnitId); err != nil {
+		return nil, fmt.Errorf("Administrative Unit ID isn't a valid UUID (%q): %+v", id.AdministrativeUnitId, err)
+	}
+	if _, err := uuid.ParseUUID(id.RoleId); err != nil {
+		return nil, fmt.Errorf("Role Object ID isn't a valid UUID (%q): %+v", id.RoleId, err)
+	}
+	if _, err := uuid.ParseUUID(id.MemberId); err != nil {
+		return nil, fmt.Errorf("Member Object ID isn't a valid UUID (%q): %+v", id.MemberId, err)
+	}
+
+	return &id, nil
+}
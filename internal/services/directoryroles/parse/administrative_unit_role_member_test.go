@@ -0,0 +1,45 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestAdministrativeUnitRoleMemberID(t *testing.T) {
+	const (
+		auId     = "11111111-1111-1111-1111-111111111111"
+		roleId   = "22222222-2222-2222-2222-222222222222"
+		memberId = "33333333-3333-3333-3333-333333333333"
+	)
+
+	cases := []struct {
+		input string
+		valid bool
+	}{
+		{input: auId + "/role/" + roleId + "/member/" + memberId, valid: true},
+		{input: auId + "/member/" + roleId + "/role/" + memberId},
+		{input: auId + "/role/" + roleId + "/member/foo"},
+		{input: auId + "/role/foo/member/" + memberId},
+		{input: "foo/role/" + roleId + "/member/" + memberId},
+		{input: auId + "/role/" + roleId},
+		{input: ""},
+	}
+
+	for _, c := range cases {
+		id, err := AdministrativeUnitRoleMemberID(c.input)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("expected error parsing %q", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", c.input, err)
+		}
+		if id.AdministrativeUnitId != auId || id.RoleId != roleId || id.MemberId != memberId {
+			t.Fatalf("unexpected result parsing %q: %+v", c.input, id)
+		}
+		if id.String() != c.input {
+			t.Fatalf("expected %q, got %q", c.input, id.String())
+		}
+	}
+}
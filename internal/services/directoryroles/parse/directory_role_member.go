@@ -0,0 +1,43 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// DirectoryRoleMemberId identifies a principal which is a member of an activated directory role
+type DirectoryRoleMemberId struct {
+	RoleId   string
+	MemberId string
+}
+
+func NewDirectoryRoleMemberID(roleId, memberId string) DirectoryRoleMemberId {
+	return DirectoryRoleMemberId{
+		RoleId:   roleId,
+		MemberId: memberId,
+	}
+}
+
+func (id DirectoryRoleMemberId) String() string {
+	return fmt.Sprintf("%s/member/%s", id.RoleId, id.MemberId)
+}
+
+func DirectoryRoleMemberID(idString string) (*DirectoryRoleMemberId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 3 || parts[1] != "member" {
+		return nil, fmt.Errorf("Directory Role Member ID should be in the format {roleObjectId}/member/{memberObjectId} - but got %q", idString)
+	}
+
+	id := NewDirectoryRoleMemberID(parts[0], parts[2])
+
+	if _, err := uuid.ParseUUID(id.RoleId); err != nil {
+		return nil, fmt.Errorf("Role Object ID isn't a valid UUID (%q): %+v", id.RoleId, err)
+	}
+	if _, err := uuid.ParseUUID(id.MemberId); err != nil {
+		return nil, fmt.Errorf("Member Object ID isn't a valid UUID (%q): %+v", id.MemberId, err)
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,44 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestDirectoryRoleMemberID(t *testing.T) {
+	const (
+		roleId   = "22222222-2222-2222-2222-222222222222"
+		memberId = "33333333-3333-3333-3333-333333333333"
+	)
+
+	cases := []struct {
+		input string
+		valid bool
+	}{
+		{input: roleId + "/member/" + memberId, valid: true},
+		{input: roleId + "/owner/" + memberId},
+		{input: roleId + "/member/foo"},
+		{input: "foo/member/" + memberId},
+		{input: roleId + "/member/" + memberId + "/member/" + memberId},
+		{input: roleId},
+		{input: ""},
+	}
+
+	for _, c := range cases {
+		id, err := DirectoryRoleMemberID(c.input)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("expected error parsing %q", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", c.input, err)
+		}
+		if id.RoleId != roleId || id.MemberId != memberId {
+			t.Fatalf("unexpected result parsing %q: %+v", c.input, id)
+		}
+		if id.String() != c.input {
+			t.Fatalf("expected %q, got %q", c.input, id.String())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package directoryroles
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type Registration struct{}
+
+// Name is the name of this Service
+func (r Registration) Name() string {
+	return "Directory Roles"
+}
+
+// WebsiteCategories returns a list of categories which can be used for the sidebar
+func (r Registration) WebsiteCategories() []string {
+	return []string{
+		"Directory Roles",
+	}
+}
+
+// SupportedDataSources returns the supported Data Sources supported by this Service
+func (r Registration) SupportedDataSources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"azuread_directory_role":             directoryRoleDataSource(),
+		"azuread_directory_role_assignments": directoryRoleAssignmentsDataSource(),
+	}
+}
+
+// SupportedResources returns the supported Resources supported by this Service
+func (r Registration) SupportedResources() map[string]*schema.Resource {
+	return map[string]*schema.Resource{
+		"azuread_administrative_unit_role_member": administrativeUnitRoleMemberResource(),
+		"azuread_directory_role":                  directoryRoleResource(),
+		"azuread_directory_role_assignment":       directoryRoleAssignmentResource(),
+		"azuread_directory_role_member":           directoryRoleMemberResource(),
+	}
+}
@@ -0,0 +1,53 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// DirectoryScopeId checks whether a value is a valid directory scope for a role assignment. This is either `/` for a
+// tenant-wide assignment, `/administrativeUnits/{id}` for an assignment scoped to an administrative unit, or
+// `/{id}` for an assignment scoped to an application.
+func DirectoryScopeId(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if v == "/" {
+		return
+	}
+
+	id := strings.TrimPrefix(v, "/")
+	if strings.HasPrefix(id, "administrativeUnits/") {
+		id = strings.TrimPrefix(id, "administrativeUnits/")
+	}
+
+	if !strings.HasPrefix(v, "/") || id == "" {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Value must be `/`, `/administrativeUnits/{objectId}` or `/{objectId}`, got %q", v),
+			AttributePath: path,
+		})
+		return
+	}
+
+	if _, err := uuid.ParseUUID(id); err != nil {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Value must contain a valid object ID, got %q", v),
+			AttributePath: path,
+		})
+	}
+
+	return
+}
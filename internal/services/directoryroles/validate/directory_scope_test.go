@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDirectoryScopeId(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    "/",
+			TestName: "Tenant",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/administrativeUnits/00000000-0000-0000-0000-000000000000",
+			TestName: "AdministrativeUnit",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/00000000-0000-0000-0000-000000000000",
+			TestName: "Application",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    "00000000-0000-0000-0000-000000000000",
+			TestName: "MissingSlash",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/administrativeUnits/",
+			TestName: "MissingAdministrativeUnitId",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/administrativeUnits/foo",
+			TestName: "InvalidAdministrativeUnitId",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/groups/00000000-0000-0000-0000-000000000000",
+			TestName: "UnsupportedScope",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := DirectoryScopeId(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected DirectoryScopeId to have %d not %d errors for %q", tc.ErrCount, len(diags), tc.TestName)
+			}
+		})
+	}
+}
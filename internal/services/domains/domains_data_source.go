@@ -131,9 +131,7 @@ func domainsDataSource() *schema.Resource {
 }
 
 func domainsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*clients.Client).Domains.DomainsClient
-
-	result, _, err := client.List(ctx)
+	result, err := meta.(*clients.Client).TenantDomains(ctx)
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not list domains")
 	}
@@ -206,7 +204,7 @@ func domainsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta int
 		return tf.ErrorDiagF(err, "Unable to compute hash for domain names")
 	}
 
-	d.SetId(fmt.Sprintf("domains#%s#%s", client.BaseClient.TenantId, base64.URLEncoding.EncodeToString(h.Sum(nil))))
+	d.SetId(fmt.Sprintf("domains#%s#%s", meta.(*clients.Client).TenantID, base64.URLEncoding.EncodeToString(h.Sum(nil))))
 	tf.Set(d, "domains", domains)
 
 	return nil
@@ -12,6 +12,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
@@ -35,19 +36,46 @@ func groupDataSource() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"display_name", "object_id"},
+				ExactlyOneOf:     []string{"display_name", "mail_nickname", "object_id", "onpremises_sam_account_name", "onpremises_security_identifier"},
 				ValidateDiagFunc: validate.NoEmptyStrings,
 			},
 
+			"mail_nickname": {
+				Description:      "The mail alias for the group, unique in the organisation",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "mail_nickname", "object_id", "onpremises_sam_account_name", "onpremises_security_identifier"},
+				ValidateDiagFunc: validate.MailNickname,
+			},
+
 			"object_id": {
 				Description:      "The object ID of the group",
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"display_name", "object_id"},
+				ExactlyOneOf:     []string{"display_name", "mail_nickname", "object_id", "onpremises_sam_account_name", "onpremises_security_identifier"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
+			"onpremises_sam_account_name": {
+				Description:      "The on-premises SAM account name of the group, only populated for groups synchronized from on-premises directories",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "mail_nickname", "object_id", "onpremises_sam_account_name", "onpremises_security_identifier"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"onpremises_security_identifier": {
+				Description:      "The on-premises security identifier (SID) of the group, only populated for groups synchronized from on-premises directories",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"display_name", "mail_nickname", "object_id", "onpremises_sam_account_name", "onpremises_security_identifier"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
 			"mail_enabled": {
 				Description: "Whether the group is mail-enabled",
 				Type:        schema.TypeBool,
@@ -62,12 +90,34 @@ func groupDataSource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"fail_if_not_found": tf.DataSourceFailIfNotFoundSchema("group"),
+
+			"found": tf.DataSourceFoundSchema("group"),
+
+			"classification": {
+				Description: "A classification for the group, such as a data sensitivity label",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"created_by_app_id": {
+				Description: "The application ID of the application used to create the group, if any",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"description": {
 				Description: "The optional description of the group",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 
+			"expiration_date_time": {
+				Description: "The date and time at which the group is set to expire, formatted as an RFC3339 date string, when a group lifecycle policy applies",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"members": {
 				Description: "The object IDs of the group members",
 				Type:        schema.TypeList,
@@ -77,6 +127,33 @@ func groupDataSource() *schema.Resource {
 				},
 			},
 
+			"members_with_types": {
+				Description: "The direct members of the group, including their object types",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_id": {
+							Description: "The object ID of the member",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The object type of the member, e.g. `user`, `group`, `servicePrincipal` or `device`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
+			"onpremises_last_sync_date_time": {
+				Description: "The date and time at which the group was last synchronized from the on-premises directory, formatted as an RFC3339 date string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"owners": {
 				Description: "The object IDs of the group owners",
 				Type:        schema.TypeList,
@@ -86,6 +163,27 @@ func groupDataSource() *schema.Resource {
 				},
 			},
 
+			"renewed_date_time": {
+				Description: "The date and time at which the group was last renewed, formatted as an RFC3339 date string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"security_identifier": {
+				Description: "The security identifier (SID) of the group, which can be used to grant access to resources such as Azure SQL databases",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"transitive_members": {
+				Description: "The object IDs of the group members, including members inherited from nested groups",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"types": {
 				Description: "A list of group types configured for the group. The only supported type is `Unified`, which specifies a Microsoft 365 group",
 				Type:        schema.TypeList,
@@ -102,11 +200,6 @@ func groupDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 	client := meta.(*clients.Client).Groups.GroupsClient
 
 	var group msgraph.Group
-	var displayName string
-
-	if v, ok := d.GetOk("display_name"); ok {
-		displayName = v.(string)
-	}
 
 	var mailEnabled, securityEnabled *bool
 	if v, exists := d.GetOk("mail_enabled"); exists {
@@ -116,25 +209,19 @@ func groupDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		securityEnabled = utils.Bool(v.(bool))
 	}
 
-	if displayName != "" {
-		filter := fmt.Sprintf("displayName eq '%s'", displayName)
-		if mailEnabled != nil {
-			filter = fmt.Sprintf("%s and mailEnabled eq %t", filter, *mailEnabled)
-		}
-		if securityEnabled != nil {
-			filter = fmt.Sprintf("%s and securityEnabled eq %t", filter, *securityEnabled)
-		}
+	if lookupKey, lookupProperty, lookupValue := groupDataSourceLookup(d); lookupKey != "" {
+		filter := groupDataSourceFilter(lookupProperty, lookupValue, mailEnabled, securityEnabled)
 
 		groups, _, err := client.List(ctx, filter)
 		if err != nil {
-			return tf.ErrorDiagPathF(err, "display_name", "No group found matching specified filter (%s)", filter)
+			return tf.ErrorDiagPathF(err, lookupKey, "No group found matching specified filter (%s)", filter)
 		}
 
 		count := len(*groups)
 		if count > 1 {
-			return tf.ErrorDiagPathF(err, "display_name", "More than one group found matching specified filter (%s)", filter)
+			return tf.ErrorDiagPathF(nil, lookupKey, "More than one group found matching specified filter (%s)", filter)
 		} else if count == 0 {
-			return tf.ErrorDiagPathF(err, "display_name", "No group found matching specified filter (%s)", filter)
+			return tf.DataSourceNotFound(d, lookupKey, lookupValue, tf.ErrorDiagPathF(nil, lookupKey, "No group found matching specified filter (%s)", filter))
 		}
 
 		group = (*groups)[0]
@@ -142,7 +229,7 @@ func groupDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		g, status, err := client.Get(ctx, objectId)
 		if err != nil {
 			if status == http.StatusNotFound {
-				return tf.ErrorDiagPathF(nil, "object_id", "No group found with object ID: %q", objectId)
+				return tf.DataSourceNotFound(d, "object_id", objectId, tf.ErrorDiagPathF(nil, "object_id", "No group found with object ID: %q", objectId))
 			}
 			return tf.ErrorDiagF(err, "Retrieving group with object ID: %q", objectId)
 		}
@@ -171,6 +258,35 @@ func groupDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 
 		group = *g
+	} else {
+		var lookupKey, lookupProperty, lookupValue, lookupName string
+		if v, ok := d.GetOk("onpremises_sam_account_name"); ok {
+			lookupKey, lookupProperty, lookupValue, lookupName = "onpremises_sam_account_name", "onPremisesSamAccountName", v.(string), "on-premises SAM account name"
+		} else if v, ok := d.GetOk("onpremises_security_identifier"); ok {
+			lookupKey, lookupProperty, lookupValue, lookupName = "onpremises_security_identifier", "onPremisesSecurityIdentifier", v.(string), "on-premises security identifier"
+		}
+
+		extraFilters := make([]string, 0)
+		if mailEnabled != nil {
+			extraFilters = append(extraFilters, helpers.ODataEq("mailEnabled", *mailEnabled))
+		}
+		if securityEnabled != nil {
+			extraFilters = append(extraFilters, helpers.ODataEq("securityEnabled", *securityEnabled))
+		}
+
+		groups, filter, err := groupFindByOnPremisesProperty(ctx, client, lookupProperty, lookupValue, extraFilters...)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, lookupKey, "Could not retrieve groups with %s %q", lookupName, lookupValue)
+		}
+
+		count := len(*groups)
+		if count > 1 {
+			return tf.ErrorDiagPathF(nil, lookupKey, "More than one group found matching specified filter (%s)", filter)
+		} else if count == 0 {
+			return tf.DataSourceNotFound(d, lookupKey, lookupValue, tf.ErrorDiagPathF(nil, lookupKey, "No group found with %s %q (filter: %s). Only groups synchronized from an on-premises directory have this property, groups created in Azure Active Directory will not match", lookupName, lookupValue, filter))
+		}
+
+		group = (*groups)[0]
 	}
 
 	if group.ID == nil {
@@ -179,24 +295,71 @@ func groupDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 	d.SetId(*group.ID)
 
+	readOnlyProperties, _, err := groupGetReadOnlyProperties(ctx, client, d.Id())
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve read-only properties for group with object ID: %q", d.Id())
+	}
+
+	tf.Set(d, "classification", group.Classification)
+	tf.Set(d, "created_by_app_id", readOnlyProperties.CreatedByAppId)
 	tf.Set(d, "description", group.Description)
 	tf.Set(d, "display_name", group.DisplayName)
+	tf.Set(d, "expiration_date_time", groupFlattenDateTime(group.ExpirationDateTime))
+	tf.Set(d, "found", true)
 	tf.Set(d, "mail_enabled", group.MailEnabled)
+	tf.Set(d, "mail_nickname", group.MailNickname)
 	tf.Set(d, "object_id", group.ID)
+	tf.Set(d, "onpremises_last_sync_date_time", groupFlattenDateTime(group.OnPremisesLastSyncDateTime))
+	tf.Set(d, "onpremises_sam_account_name", group.OnPremisesSamAccountName)
+	tf.Set(d, "onpremises_security_identifier", group.OnPremisesSecurityIdentifier)
+	tf.Set(d, "renewed_date_time", groupFlattenDateTime(group.RenewedDateTime))
 	tf.Set(d, "security_enabled", group.SecurityEnabled)
+	tf.Set(d, "security_identifier", group.SecurityIdentifier)
 	tf.Set(d, "types", group.GroupTypes)
 
-	members, _, err := client.ListMembers(ctx, d.Id())
+	members, _, err := groupListMembersWithTypes(ctx, client, d.Id(), false)
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not retrieve group members for group with object ID: %q", d.Id())
 	}
-	tf.Set(d, "members", members)
+	tf.Set(d, "members", groupFlattenMemberIds(members))
+	tf.Set(d, "members_with_types", groupFlattenMembersWithTypes(members))
+
+	transitiveMembers, _, err := groupListMembersWithTypes(ctx, client, d.Id(), true)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve transitive group members for group with object ID: %q", d.Id())
+	}
+	tf.Set(d, "transitive_members", groupFlattenMemberIds(transitiveMembers))
 
 	owners, _, err := client.ListOwners(ctx, d.Id())
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not retrieve group owners for group with object ID: %q", d.Id())
 	}
-	tf.Set(d, "owners", owners)
+	tf.Set(d, "owners", tf.SortedStringSlice(owners))
 
 	return nil
 }
+
+// groupDataSourceLookup returns the attribute, Graph property and value to look up a group by, when the group is to be
+// found by display name or by mail nickname. An empty attribute name is returned otherwise.
+func groupDataSourceLookup(d *schema.ResourceData) (string, string, string) {
+	if v, ok := d.GetOk("display_name"); ok && v.(string) != "" {
+		return "display_name", "displayName", v.(string)
+	}
+	if v, ok := d.GetOk("mail_nickname"); ok && v.(string) != "" {
+		return "mail_nickname", "mailNickname", v.(string)
+	}
+	return "", "", ""
+}
+
+// groupDataSourceFilter returns a filter matching groups with the specified value for property, and optionally
+// matching the specified mail enabled and security enabled settings
+func groupDataSourceFilter(property, value string, mailEnabled, securityEnabled *bool) string {
+	filters := []string{helpers.ODataEq(property, value)}
+	if mailEnabled != nil {
+		filters = append(filters, helpers.ODataEq("mailEnabled", *mailEnabled))
+	}
+	if securityEnabled != nil {
+		filters = append(filters, helpers.ODataEq("securityEnabled", *securityEnabled))
+	}
+	return helpers.ODataAnd(filters...)
+}
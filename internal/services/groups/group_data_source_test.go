@@ -2,6 +2,8 @@ package groups_test
 
 import (
 	"fmt"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -20,6 +22,7 @@ func TestAccGroupDataSource_byDisplayName(t *testing.T) {
 			Config: GroupDataSource{}.displayName(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("security_identifier").MatchesRegex(regexp.MustCompile(`^S-1-12-1-`)),
 			),
 		},
 	})
@@ -50,6 +53,21 @@ func TestAccGroupDataSource_byCaseInsensitiveDisplayName(t *testing.T) {
 	})
 }
 
+func TestAccGroupDataSource_byMailNickname(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: GroupDataSource{}.mailNickname(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("mail_nickname").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("object_id").MatchesOtherKey(check.That("azuread_group.test").Key("object_id")),
+			),
+		},
+	})
+}
+
 func TestAccGroupDataSource_byObjectId(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
 
@@ -58,6 +76,29 @@ func TestAccGroupDataSource_byObjectId(t *testing.T) {
 			Config: GroupDataSource{}.objectId(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("onpremises_sam_account_name").HasValue(""),
+				check.That(data.ResourceName).Key("onpremises_security_identifier").HasValue(""),
+				check.That(data.ResourceName).Key("onpremises_last_sync_date_time").HasValue(""),
+				check.That(data.ResourceName).Key("classification").HasValue(""),
+			),
+		},
+	})
+}
+
+func TestAccGroupDataSource_classification(t *testing.T) {
+	classification := os.Getenv(groupClassificationEnvVar)
+	if classification == "" {
+		t.Skipf("skipping since %s is not set", groupClassificationEnvVar)
+	}
+
+	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: GroupDataSource{}.classification(data, classification),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("classification").HasValue(classification),
+				check.That(data.ResourceName).Key("renewed_date_time").Exists(),
 			),
 		},
 	})
@@ -90,6 +131,21 @@ func TestAccGroupDataSource_members(t *testing.T) {
 	})
 }
 
+func TestAccGroupDataSource_transitiveMembers(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: GroupDataSource{}.transitiveMembers(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("members.#").HasValue("3"),
+				check.That(data.ResourceName).Key("members_with_types.#").HasValue("3"),
+				check.That(data.ResourceName).Key("transitive_members.#").HasValue("4"),
+			),
+		},
+	})
+}
+
 func TestAccGroupDataSource_owners(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
 
@@ -104,6 +160,17 @@ func TestAccGroupDataSource_owners(t *testing.T) {
 	})
 }
 
+func TestAccGroupDataSource_byOnPremisesSamAccountNameNotFound(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_group", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      GroupDataSource{}.onPremisesSamAccountName(data),
+			ExpectError: regexp.MustCompile("Only groups synchronized from an on-premises directory have this property"),
+		},
+	})
+}
+
 func (GroupDataSource) displayName(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -135,6 +202,16 @@ data "azuread_group" "test" {
 `, GroupResource{}.basic(data))
 }
 
+func (GroupDataSource) mailNickname(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_group" "test" {
+  mail_nickname = azuread_group.test.mail_nickname
+}
+`, GroupResource{}.mailNickname(data))
+}
+
 func (GroupDataSource) objectId(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -157,6 +234,16 @@ data "azuread_group" "test" {
 `, GroupResource{}.basic(data))
 }
 
+func (GroupDataSource) classification(data acceptance.TestData, classification string) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_group" "test" {
+  object_id = azuread_group.test.object_id
+}
+`, GroupResource{}.classification(data, classification))
+}
+
 func (GroupDataSource) members(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -167,6 +254,29 @@ data "azuread_group" "test" {
 `, GroupResource{}.withThreeMembers(data))
 }
 
+func (GroupDataSource) transitiveMembers(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "nested" {
+  user_principal_name = "acctestGroup.%[2]d.nested@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestGroup-%[2]d-Nested"
+  password            = "%[3]s"
+}
+
+resource "azuread_group_member" "nested" {
+  group_object_id  = azuread_group.member.object_id
+  member_object_id = azuread_user.nested.object_id
+}
+
+data "azuread_group" "test" {
+  object_id = azuread_group.test.object_id
+
+  depends_on = [azuread_group_member.nested]
+}
+`, GroupResource{}.withDiverseMembers(data), data.RandomInteger, data.RandomPassword)
+}
+
 func (GroupDataSource) owners(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -176,3 +286,13 @@ data "azuread_group" "test" {
 }
 `, GroupResource{}.withThreeOwners(data))
 }
+
+func (GroupDataSource) onPremisesSamAccountName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_group" "test" {
+  onpremises_sam_account_name = "acctestGroup-%[1]d"
+}
+`, data.RandomInteger)
+}
@@ -170,33 +170,6 @@ resource "azuread_group" "test" {
 `, data.RandomInteger)
 }
 
-func (GroupMemberResource) templateThreeUsers(data acceptance.TestData) string {
-	return fmt.Sprintf(`
-data "azuread_domains" "test" {
-  only_initial = true
-}
-
-resource "azuread_user" "testA" {
-  user_principal_name = "acctestUser.%[1]d.A@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestUser-%[1]d-A"
-  password            = "%[2]s"
-}
-
-resource "azuread_user" "testB" {
-  user_principal_name = "acctestUser.%[1]d.B@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestUser-%[1]d-B"
-  mail_nickname       = "acctestUser-%[1]d-B"
-  password            = "%[2]s"
-}
-
-resource "azuread_user" "testC" {
-  user_principal_name = "acctestUser.%[1]d.C@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestUser-%[1]d-C"
-  password            = "%[2]s"
-}
-`, data.RandomInteger, data.RandomPassword)
-}
-
 func (r GroupMemberResource) group(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -216,20 +189,13 @@ resource "azuread_group_member" "test" {
 func (r GroupMemberResource) servicePrincipal(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
-
-resource "azuread_application" "test" {
-  display_name = "acctestServicePrincipal-%[2]d"
-}
-
-resource "azuread_service_principal" "test" {
-  application_id = azuread_application.test.application_id
-}
+%[2]s
 
 resource "azuread_group_member" "test" {
   group_object_id  = azuread_group.test.object_id
-  member_object_id = azuread_service_principal.test.object_id
+  member_object_id = local.shared_service_principal_object_id
 }
-`, r.template(data), data.RandomInteger)
+`, r.template(data), data.SharedPrerequisites())
 }
 
 func (r GroupMemberResource) oneUser(data acceptance.TestData) string {
@@ -239,9 +205,9 @@ func (r GroupMemberResource) oneUser(data acceptance.TestData) string {
 
 resource "azuread_group_member" "testA" {
   group_object_id  = azuread_group.test.object_id
-  member_object_id = azuread_user.testA.object_id
+  member_object_id = local.shared_user_ids[0]
 }
-`, r.template(data), r.templateThreeUsers(data))
+`, r.template(data), data.SharedPrerequisites())
 }
 
 func (r GroupMemberResource) twoUsers(data acceptance.TestData) string {
@@ -251,14 +217,14 @@ func (r GroupMemberResource) twoUsers(data acceptance.TestData) string {
 
 resource "azuread_group_member" "testA" {
   group_object_id  = azuread_group.test.object_id
-  member_object_id = azuread_user.testA.object_id
+  member_object_id = local.shared_user_ids[0]
 }
 
 resource "azuread_group_member" "testB" {
   group_object_id  = azuread_group.test.object_id
-  member_object_id = azuread_user.testB.object_id
+  member_object_id = local.shared_user_ids[1]
 }
-`, r.template(data), r.templateThreeUsers(data))
+`, r.template(data), data.SharedPrerequisites())
 }
 
 func (r GroupMemberResource) requiresImport(data acceptance.TestData) string {
@@ -0,0 +1,92 @@
+package groups
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+)
+
+func groupOwnerResource() *schema.Resource {
+	return groupOwnerRelationship().Resource()
+}
+
+func groupOwnerRelationship() helpers.RelationshipResource {
+	return helpers.RelationshipResource{
+		Name:               "azuread_group_owner",
+		ParentType:         "group",
+		ParentAttribute:    "group_object_id",
+		ParentDescription:  "The object ID of the group you want to add the owner to",
+		RelatedType:        "owner",
+		RelatedAttribute:   "owner_object_id",
+		RelatedDescription: "The object ID of the principal you want to add as an owner of the group. Supported object types are Users or Service Principals",
+		LockName:           groupResourceName,
+		MissingIsRemoved:   true,
+
+		StrictDelete: func(meta interface{}) bool {
+			return meta.(*clients.Client).StrictDelete
+		},
+
+		FormatId: func(groupId, ownerId string) string {
+			return parse.NewGroupOwnerID(groupId, ownerId).String()
+		},
+
+		ParseId: func(idString string) (string, string, error) {
+			id, err := parse.GroupOwnerID(idString)
+			if err != nil {
+				return "", "", err
+			}
+			return id.GroupId, id.OwnerId, nil
+		},
+
+		GetParent: func(ctx context.Context, meta interface{}, groupId string) (int, error) {
+			_, status, err := helpers.WaitForParentGroup(ctx, meta.(*clients.Client).Groups.GroupsClient, groupId)
+			return status, err
+		},
+
+		List: func(ctx context.Context, meta interface{}, groupId string) (*[]string, int, error) {
+			return meta.(*clients.Client).Groups.GroupsClient.ListOwners(ctx, groupId)
+		},
+
+		ValidateAdd: func(ctx context.Context, meta interface{}, _, ownerId string) error {
+			client := meta.(*clients.Client).Groups.GroupsClient
+			return helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeGroup, []string{ownerId})
+		},
+
+		Add: func(ctx context.Context, meta interface{}, groupId, ownerId string) error {
+			client := meta.(*clients.Client).Groups.GroupsClient
+
+			group := msgraph.Group{ID: &groupId}
+			group.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, ownerId)
+
+			status, err := client.AddOwners(ctx, &group)
+			if err != nil {
+				err = helpers.GraphError(err)
+				err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, []string{ownerId})
+				err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupOwnerAdd, meta.(*clients.Client).Claims)
+			}
+			return err
+		},
+
+		ValidateRemove: groupOwnerCheckRemoval,
+
+		Remove: func(ctx context.Context, meta interface{}, groupId, ownerId string) (int, error) {
+			return meta.(*clients.Client).Groups.GroupsClient.RemoveOwners(ctx, groupId, &[]string{ownerId})
+		},
+	}
+}
+
+// groupOwnerCheckRemoval returns an error when the specified owner is the only remaining owner of a group, since the
+// API does not permit the last owner of a group to be removed
+func groupOwnerCheckRemoval(owners *[]string, ownerId string) error {
+	if owners == nil || len(*owners) != 1 || !strings.EqualFold((*owners)[0], ownerId) {
+		return nil
+	}
+	return fmt.Errorf("%q is the last owner of the group, and Azure Active Directory does not permit the last owner of a group to be removed. Add another owner to the group before removing this one, or delete the group instead", ownerId)
+}
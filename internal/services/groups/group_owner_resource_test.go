@@ -0,0 +1,207 @@
+package groups_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/groups/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type GroupOwnerResource struct{}
+
+func TestAccGroupOwner_servicePrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_owner", "test")
+	r := GroupOwnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.servicePrincipal(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("group_object_id").IsUuid(),
+				check.That(data.ResourceName).Key("owner_object_id").IsUuid(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroupOwner_multipleUser(t *testing.T) {
+	dataA := acceptance.BuildTestData(t, "azuread_group_owner", "testA")
+	dataB := acceptance.BuildTestData(t, "azuread_group_owner", "testB")
+	r := GroupOwnerResource{}
+
+	dataA.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.oneUser(dataA),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(dataA.ResourceName).ExistsInAzure(r),
+				check.That(dataA.ResourceName).Key("group_object_id").IsUuid(),
+				check.That(dataA.ResourceName).Key("owner_object_id").IsUuid(),
+			),
+		},
+		dataA.ImportStep(),
+		{
+			Config: r.twoUsers(dataA),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(dataA.ResourceName).ExistsInAzure(r),
+				check.That(dataB.ResourceName).ExistsInAzure(r),
+				check.That(dataB.ResourceName).Key("group_object_id").IsUuid(),
+				check.That(dataB.ResourceName).Key("owner_object_id").IsUuid(),
+			),
+		},
+		dataA.ImportStep(),
+		{
+			Config: r.oneUser(dataA),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(dataA.ResourceName).ExistsInAzure(r),
+			),
+		},
+	})
+}
+
+func TestAccGroupOwner_group(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_owner", "test")
+	r := GroupOwnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.group(data),
+			ExpectError: regexp.MustCompile("groups cannot be owners of groups"),
+		},
+	})
+}
+
+func TestAccGroupOwner_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group_owner", "test")
+	r := GroupOwnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.servicePrincipal(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r GroupOwnerResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Groups.GroupsClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.GroupOwnerID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Group Owner ID: %v", err)
+	}
+
+	owners, _, err := client.ListOwners(ctx, id.GroupId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve Group owners (groupId: %q): %+v", id.GroupId, err)
+	}
+
+	if owners != nil {
+		for _, objectId := range *owners {
+			if strings.EqualFold(objectId, id.OwnerId) {
+				return utils.Bool(true), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Owner %q was not found in Group %q", id.OwnerId, id.GroupId)
+}
+
+func (GroupOwnerResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_client_config" "current" {}
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+  owners           = [data.azuread_client_config.current.object_id]
+
+  lifecycle {
+    ignore_changes = [owners]
+  }
+}
+`, data.RandomInteger)
+}
+
+func (r GroupOwnerResource) servicePrincipal(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "azuread_group_owner" "test" {
+  group_object_id = azuread_group.test.object_id
+  owner_object_id = local.shared_service_principal_object_id
+}
+`, r.template(data), data.SharedPrerequisites())
+}
+
+func (r GroupOwnerResource) group(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "owner" {
+  display_name     = "acctestGroup-%[2]d-Owner"
+  security_enabled = true
+}
+
+resource "azuread_group_owner" "test" {
+  group_object_id = azuread_group.test.object_id
+  owner_object_id = azuread_group.owner.object_id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r GroupOwnerResource) oneUser(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "azuread_group_owner" "testA" {
+  group_object_id = azuread_group.test.object_id
+  owner_object_id = local.shared_user_ids[0]
+}
+`, r.template(data), data.SharedPrerequisites())
+}
+
+func (r GroupOwnerResource) twoUsers(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "azuread_group_owner" "testA" {
+  group_object_id = azuread_group.test.object_id
+  owner_object_id = local.shared_user_ids[0]
+}
+
+resource "azuread_group_owner" "testB" {
+  group_object_id = azuread_group.test.object_id
+  owner_object_id = local.shared_user_ids[1]
+}
+`, r.template(data), data.SharedPrerequisites())
+}
+
+func (r GroupOwnerResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group_owner" "import" {
+  group_object_id = azuread_group_owner.test.group_object_id
+  owner_object_id = azuread_group_owner.test.owner_object_id
+}
+`, r.servicePrincipal(data))
+}
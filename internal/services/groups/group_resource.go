@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"regexp"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
@@ -39,14 +41,108 @@ func groupResource() *schema.Resource {
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
-		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+		Importer: tf.ValidateResourceIDPriorToImportThen(func(id string) error {
 			if _, err := uuid.ParseUUID(id); err != nil {
 				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
 			}
 			return nil
-		}),
+		}, groupResourceImport),
 
 		Schema: map[string]*schema.Schema{
+			"adopt_existing": {
+				Description:   "If `true`, an existing group with the same `display_name`, `assignable_to_role`, `mail_enabled`, `security_enabled` and `types` will be adopted instead of creating a new group",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"prevent_duplicate_names"},
+			},
+
+			"adopted_destroy_behaviour": {
+				Description: "What to do with an adopted group when this resource is destroyed. Possible values are `delete` or `abandon`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "delete",
+				ValidateFunc: validation.StringInSlice([]string{
+					"abandon",
+					"delete",
+				}, false),
+			},
+
+			"allow_external_senders": {
+				Description: "Whether people external to the organization can send messages to the group. Only supported for Microsoft 365 (unified) groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			"assignable_to_role": {
+				Description: "Indicates whether this group can be assigned to an Azure Active Directory role. This property can only be `true` for security-enabled groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"auto_subscribe_new_members": {
+				Description: "Whether new members added to the group will be auto-subscribed to receive email notifications. Only supported for Microsoft 365 (unified) groups",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+			},
+
+			// The following settings for mail-enabled groups are only supported by Exchange Online, and are rejected with
+			// an explanation, since they cannot be managed by this resource
+
+			"accept_messages_only_from": {
+				Description: "Not supported via Microsoft Graph. Configure using the `AcceptMessagesOnlySendersOrMembers` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: groupValidateExchangeOnly("AcceptMessagesOnlySendersOrMembers", ""),
+				},
+			},
+
+			"moderated_by": {
+				Description: "Not supported via Microsoft Graph. Configure using the `ModeratedBy` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: groupValidateExchangeOnly("ModeratedBy", ""),
+				},
+			},
+
+			"moderation_enabled": {
+				Description:      "Not supported via Microsoft Graph. Configure using the `ModerationEnabled` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				ValidateDiagFunc: groupValidateExchangeOnly("ModerationEnabled", ""),
+			},
+
+			"reject_messages_from": {
+				Description: "Not supported via Microsoft Graph. Configure using the `RejectMessagesFromSendersOrMembers` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: groupValidateExchangeOnly("RejectMessagesFromSendersOrMembers", ""),
+				},
+			},
+
+			"require_sender_authentication": {
+				Description:      "Not supported via Microsoft Graph. Use `allow_external_senders` instead, or configure using the `RequireSenderAuthenticationEnabled` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:             schema.TypeBool,
+				Optional:         true,
+				ValidateDiagFunc: groupValidateExchangeOnly("RequireSenderAuthenticationEnabled", "allow_external_senders"),
+			},
+
+			"send_moderation_notifications": {
+				Description:      "Not supported via Microsoft Graph. Configure using the `SendModerationNotifications` parameter of the `Set-UnifiedGroup` Exchange Online cmdlet",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: groupValidateExchangeOnly("SendModerationNotifications", ""),
+			},
+
 			"display_name": {
 				Description:      "The display name for the group",
 				Type:             schema.TypeString,
@@ -54,6 +150,26 @@ func groupResource() *schema.Resource {
 				ValidateDiagFunc: validate.NoEmptyStrings,
 			},
 
+			"behaviors": {
+				Description: "The group behaviours for a Microsoft 365 group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(groupResourceBehaviorOptions, false),
+				},
+			},
+
+			"classification": {
+				Description:      "A classification for the group, such as a data sensitivity label. Must be one of the values defined in the tenant's ClassificationList directory setting",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
 			"description": {
 				Description: "The description for the group",
 				Type:        schema.TypeString,
@@ -61,12 +177,21 @@ func groupResource() *schema.Resource {
 			},
 
 			"mail_enabled": {
-				Description:  "Whether the group is a mail enabled, with a shared group mailbox. At least one of `mail_enabled` or `security_enabled` must be specified. A group can be mail enabled _and_ security enabled",
+				Description:  "Whether the group is a mail enabled, with a shared group mailbox. At least one of `mail_enabled` or `security_enabled` must be specified and `true`. A group can be mail enabled _and_ security enabled",
 				Type:         schema.TypeBool,
 				Optional:     true,
 				AtLeastOneOf: []string{"mail_enabled", "security_enabled"},
 			},
 
+			"mail_nickname": {
+				Description:      "The mail alias for the group, unique in the organisation. A random UUID is generated when not specified",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.MailNickname,
+			},
+
 			"members": {
 				Description: "A set of members who should be present in this group. Supported object types are Users, Groups or Service Principals",
 				Type:        schema.TypeSet,
@@ -80,17 +205,32 @@ func groupResource() *schema.Resource {
 			},
 
 			"owners": {
-				Description: "A set of owners who own this group. Supported object types are Users or Service Principals",
+				Description: "A set of owners who own this group, or `current` for the authenticated principal. Supported object types are Users or Service Principals",
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Computed:    true,
 				Set:         schema.HashString,
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
-					ValidateDiagFunc: validate.UUID,
+					ValidateDiagFunc: validate.UUIDOrCurrent,
 				},
 			},
 
+			"force_destroy_nested_references": {
+				Description: "Whether to remove the group from any groups of which it is a direct member before destroying it",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"preferred_language": {
+				Description:  "The preferred language for a Microsoft 365 group, as an ISO 639-1 code, e.g. `en` or `en-US`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`), "must be an ISO 639-1 language code, optionally followed by a region, e.g. `en` or `en-US`"),
+			},
+
 			"prevent_duplicate_names": {
 				Description: "If `true`, will return an error if an existing group is found with the same name",
 				Type:        schema.TypeBool,
@@ -98,13 +238,36 @@ func groupResource() *schema.Resource {
 				Default:     false,
 			},
 
+			"wait_for_permanent_deletion": {
+				Description: "Whether to permanently delete a Microsoft 365 group when destroying it, and wait until its `mail_nickname` can be reused, so that a group with the same `mail_nickname` can be created immediately afterwards",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"provisioning_wait": {
+				Description:      "After creating the group, wait up to this duration (e.g. `2m`) for the group to be available to other resources which reference it. Defaults to `0s`, which does not wait",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0s",
+				ValidateDiagFunc: validate.Duration,
+			},
+
 			"security_enabled": {
-				Description:  "Whether the group is a security group for controlling access to in-app resources. At least one of `security_enabled` or `mail_enabled` must be specified. A group can be security enabled _and_ mail enabled",
+				Description:  "Whether the group is a security group for controlling access to in-app resources. At least one of `security_enabled` or `mail_enabled` must be specified and `true`. A group can be security enabled _and_ mail enabled",
 				Type:         schema.TypeBool,
 				Optional:     true,
 				AtLeastOneOf: []string{"mail_enabled", "security_enabled"},
 			},
 
+			"theme": {
+				Description:  "The color theme for a Microsoft 365 group. Possible values are `Blue`, `Green`, `Orange`, `Pink`, `Purple`, `Red` or `Teal`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(groupThemes, false),
+			},
+
 			"types": {
 				Description: "A set of group types to configure for the group. The only supported type is `Unified`, which specifies a Microsoft 365 group. Required when `mail_enabled` is true",
 				Type:        schema.TypeSet,
@@ -118,11 +281,128 @@ func groupResource() *schema.Resource {
 				},
 			},
 
+			"visibility": {
+				Description: "Specifies the group join policy and group content visibility. Possible values are `Private`, `Public` or `HiddenMembership`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					groupVisibilityHiddenMembership,
+					groupVisibilityPrivate,
+					groupVisibilityPublic,
+				}, false),
+			},
+
+			"adopted": {
+				Description: "Whether the group was adopted by this resource rather than being created by it",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"created_by_app_id": {
+				Description: "The application ID of the application used to create the group, if any",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"expiration_date_time": {
+				Description: "The date and time at which the group is set to expire, formatted as an RFC3339 date string, when a group lifecycle policy applies",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"is_subscribed_by_mail": {
+				Description: "Whether the signed-in user is subscribed to receive email conversations. Only populated for Microsoft 365 (unified) groups",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"mail": {
+				Description: "The SMTP address for the group",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"members_hash": {
+				Description: "A hash of the object IDs of the group's members, which changes whenever the membership of the group changes",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"object_id": {
 				Description: "The object ID of the group",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+
+			"onpremises_domain_name": {
+				Description: "The on-premises FQDN, also called dnsDomainName, synchronized from the on-premises directory when Azure AD Connect is used",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"onpremises_last_sync_date_time": {
+				Description: "The date and time at which the group was last synchronized from the on-premises directory, formatted as an RFC3339 date string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"onpremises_netbios_name": {
+				Description: "The on-premises NetBIOS name, synchronized from the on-premises directory when Azure AD Connect is used",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"onpremises_sam_account_name": {
+				Description: "The on-premises SAM account name, synchronized from the on-premises directory when Azure AD Connect is used",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"onpremises_security_identifier": {
+				Description: "The on-premises security identifier (SID), synchronized from the on-premises directory when Azure AD Connect is used",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"onpremises_sync_enabled": {
+				Description: "Whether this group is synchronized from an on-premises directory (true), no longer synchronized (false), or has never been synchronized (null)",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+
+			"provisioning_options": {
+				Description: "The group provisioning options for a Microsoft 365 group",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(groupResourceProvisioningOptions, false),
+				},
+			},
+
+			"proxy_addresses": {
+				Description: "Email addresses for the group that direct to the same group mailbox",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"renewed_date_time": {
+				Description: "The date and time at which the group was last renewed, formatted as an RFC3339 date string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"security_identifier": {
+				Description: "The security identifier (SID) of the group, which can be used to grant access to resources such as Azure SQL databases",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -144,6 +424,16 @@ func groupResourceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff,
 		return false
 	}
 
+	// AtLeastOneOf only ensures that one of these is present in configuration, so also check that one is actually true
+	if diff.NewValueKnown("mail_enabled") && diff.NewValueKnown("security_enabled") &&
+		!mailEnabled && !diff.Get("security_enabled").(bool) {
+		return fmt.Errorf("at least one of `mail_enabled` or `security_enabled` must be true")
+	}
+
+	if diff.Get("assignable_to_role").(bool) && diff.NewValueKnown("security_enabled") && !diff.Get("security_enabled").(bool) {
+		return fmt.Errorf("`security_enabled` must be true when `assignable_to_role` is true")
+	}
+
 	if mailEnabled && !hasGroupType(msgraph.GroupTypeUnified) {
 		return fmt.Errorf("`types` must contain %q for mail-enabled groups", msgraph.GroupTypeUnified)
 	}
@@ -152,55 +442,202 @@ func groupResourceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff,
 		return fmt.Errorf("`mail_enabled` must be true for unified groups")
 	}
 
-	if diff.Get("prevent_duplicate_names").(bool) &&
-		(oldDisplayName.(string) == "" || oldDisplayName.(string) != newDisplayName.(string)) {
-		result, err := groupFindByName(ctx, client, newDisplayName.(string))
+	if !hasGroupType(msgraph.GroupTypeUnified) {
+		for _, attr := range groupUnifiedOnlyAttributes {
+			v, ok := diff.GetOk(attr)
+
+			// GetOk disregards false, so boolean attributes are instead checked for null, which is the case in state for
+			// groups that are not unified since these attributes are only read for unified groups. When a unified group
+			// is being replaced, its state is disregarded by only checking for a change.
+			if _, isBool := v.(bool); isBool {
+				if diff.Id() != "" && diff.HasChange("types") {
+					ok = diff.HasChange(attr)
+				} else {
+					_, ok = diff.GetOkExists(attr)
+				}
+			}
+
+			if ok {
+				return fmt.Errorf("`%s` is only supported for unified groups", attr)
+			}
+		}
+	}
+
+	// Security cannot be disabled for a role-assignable group, which could only be determined by the API part-way
+	// through an update
+	if oldSecurityEnabled, newSecurityEnabled := diff.GetChange("security_enabled"); diff.Id() != "" &&
+		diff.NewValueKnown("security_enabled") && oldSecurityEnabled.(bool) && !newSecurityEnabled.(bool) {
+		group, _, err := client.Get(ctx, diff.Id())
 		if err != nil {
-			return fmt.Errorf("could not check for existing application(s): %+v", err)
+			return fmt.Errorf("could not retrieve group with object ID %q: %+v", diff.Id(), err)
 		}
-		if result != nil && len(*result) > 0 {
-			for _, existingGroup := range *result {
-				if existingGroup.ID == nil {
-					return fmt.Errorf("API error: group returned with nil object ID during duplicate name check")
-				}
-				if diff.Id() == "" || diff.Id() == *existingGroup.ID {
-					return tf.ImportAsDuplicateError("azuread_group", *existingGroup.ID, newDisplayName.(string))
-				}
+		if group.IsAssignableToRole != nil && *group.IsAssignableToRole {
+			return fmt.Errorf("`security_enabled` cannot be false for a group which is assignable to directory roles")
+		}
+	}
+
+	// Attributes of a group synchronized from an on-premises directory cannot be changed, which the API does not explain
+	if diff.Id() != "" && diff.Get("onpremises_sync_enabled").(bool) {
+		if changed := groupOnPremisesSyncChanges(diff.HasChange); len(changed) > 0 {
+			return fmt.Errorf("group with object ID %q is %s", diff.Id(), groupOnPremisesSyncMessage(changed))
+		}
+	}
+
+	if diff.Get("visibility").(string) == groupVisibilityHiddenMembership && !hasGroupType(msgraph.GroupTypeUnified) {
+		return fmt.Errorf("`visibility` can only be %q for unified groups", groupVisibilityHiddenMembership)
+	}
+
+	// Hidden membership can only be set when creating a group, so the group must be replaced to change to or from it
+	if oldVisibility, newVisibility := diff.GetChange("visibility"); diff.Id() != "" && diff.NewValueKnown("visibility") &&
+		newVisibility.(string) != "" && oldVisibility.(string) != newVisibility.(string) &&
+		(oldVisibility.(string) == groupVisibilityHiddenMembership || newVisibility.(string) == groupVisibilityHiddenMembership) {
+		if err := diff.ForceNew("visibility"); err != nil {
+			return fmt.Errorf("could not mark `visibility` as requiring replacement: %v", err)
+		}
+	}
+
+	if diff.HasChange("owners") && diff.NewValueKnown("owners") {
+		callerId := meta.(*clients.Client).Claims.ObjectId
+		owners := *tf.ExpandStringSlicePtr(diff.Get("owners").(*schema.Set).List())
+		if err := helpers.ValidateCurrentPrincipalOwner(owners, callerId); err != nil {
+			return fmt.Errorf("invalid `owners`: %v", err)
+		}
+		if err := groupValidateOwnersCount(helpers.ExpandOwners(owners, callerId)); err != nil {
+			return fmt.Errorf("invalid `owners`: %v", err)
+		}
+		if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeGroup, helpers.ExpandOwners(owners, callerId)); err != nil {
+			return fmt.Errorf("invalid `owners`: %v", err)
+		}
+	}
+
+	// Role-assignable groups cannot have nested groups as members, which the API would only reject once the members
+	// are added, after the group has been created
+	if diff.Get("assignable_to_role").(bool) && (diff.HasChange("assignable_to_role") || diff.HasChange("members")) && diff.NewValueKnown("members") {
+		members := *tf.ExpandStringSlicePtr(diff.Get("members").(*schema.Set).List())
+		if len(members) > 0 {
+			objects, err := helpers.DirectoryObjectsGetByIds(ctx, client.BaseClient, members)
+			if err != nil {
+				return fmt.Errorf("could not retrieve members: %v", err)
+			}
+			if err := groupValidateRoleAssignableMembers(objects); err != nil {
+				return fmt.Errorf("invalid `members`: %v", err)
+			}
+		}
+	}
+
+	// When any member is not known until apply, the entire set of members is planned as unknown, which renders as all
+	// existing members being replaced. The diff for `members` is cleared in this case, and `members_hash` is planned as
+	// unknown instead, so that the plan shows that membership may change without suggesting that existing members will
+	// be removed. Members are reconciled once they are all known at apply time, when only the differences are applied.
+	// The count is checked, since a set is not reported as unknown when it contains unknown values.
+	if diff.Id() != "" {
+		if !diff.NewValueKnown("members.#") {
+			if err := diff.Clear("members"); err != nil {
+				return fmt.Errorf("could not clear the diff for `members`: %v", err)
+			}
+			if err := diff.SetNewComputed("members_hash"); err != nil {
+				return fmt.Errorf("could not mark `members_hash` as computed: %v", err)
+			}
+		} else if diff.HasChange("members") {
+			if err := diff.SetNewComputed("members_hash"); err != nil {
+				return fmt.Errorf("could not mark `members_hash` as computed: %v", err)
 			}
 		}
 	}
 
+	if diff.Get("prevent_duplicate_names").(bool) && diff.NewValueKnown("display_name") &&
+		(oldDisplayName.(string) == "" || oldDisplayName.(string) != newDisplayName.(string)) {
+		existingId, err := helpers.DuplicateNameFind(ctx, groupDuplicateNameList(client), "displayName", newDisplayName.(string), diff.Id())
+		if err != nil {
+			return fmt.Errorf("could not check for existing group(s): %+v", err)
+		}
+		if existingId != "" {
+			return tf.ImportAsDuplicateError("azuread_group", existingId, newDisplayName.(string))
+		}
+	}
+
 	return nil
 }
 
-func groupResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func groupResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client := meta.(*clients.Client).Groups.GroupsClient
 	callerId := meta.(*clients.Client).Claims.ObjectId
 	displayName := d.Get("display_name").(string)
 
 	// Perform this check at apply time to catch any duplicate names created during the same apply
 	if d.Get("prevent_duplicate_names").(bool) {
+		existingId, err := helpers.DuplicateNameFind(ctx, groupDuplicateNameList(client), "displayName", displayName, "")
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "display_name", "Could not check for existing group(s)")
+		}
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_group", existingId, displayName)
+		}
+	}
+
+	groupTypes := make([]msgraph.GroupType, 0)
+	for _, v := range d.Get("types").(*schema.Set).List() {
+		groupTypes = append(groupTypes, msgraph.GroupType(v.(string)))
+	}
+
+	if d.Get("adopt_existing").(bool) {
 		result, err := groupFindByName(ctx, client, displayName)
 		if err != nil {
-			return tf.ErrorDiagPathF(err, "name", "Could not check for existing groups(s)")
+			return tf.ErrorDiagPathF(err, "display_name", "Could not check for existing group(s) to adopt")
 		}
-		if result != nil && len(*result) > 0 {
-			existingGroup := (*result)[0]
-			if existingGroup.ID == nil {
-				return tf.ErrorDiagF(errors.New("API returned group with nil object ID during duplicate name check"), "Bad API response")
+
+		candidates := groupsMatchingForAdoption(*result, d.Get("mail_enabled").(bool), d.Get("security_enabled").(bool), d.Get("assignable_to_role").(bool), groupTypes)
+
+		// The mail nickname cannot be changed, so only adopt a group with the configured nickname
+		if v, ok := d.GetOk("mail_nickname"); ok {
+			matching := make([]msgraph.Group, 0, len(candidates))
+			for _, g := range candidates {
+				if g.MailNickname != nil && strings.EqualFold(*g.MailNickname, v.(string)) {
+					matching = append(matching, g)
+				}
 			}
-			return tf.ImportAsDuplicateDiag("azuread_group", *existingGroup.ID, displayName)
+			candidates = matching
+		}
+
+		switch len(candidates) {
+		case 0:
+			log.Printf("[DEBUG] No existing group found to adopt with display name %q, creating a new group", displayName)
+		case 1:
+			if candidates[0].ID == nil {
+				return tf.ErrorDiagF(errors.New("API returned group with nil object ID during adoption check"), "Bad API response")
+			}
+			log.Printf("[DEBUG] Adopting existing group with object ID %q and display name %q", *candidates[0].ID, displayName)
+			d.SetId(*candidates[0].ID)
+			tf.Set(d, "adopted", true)
+
+			// Reconcile the properties, members and owners of the adopted group with the configuration
+			return groupResourceUpdate(ctx, d, meta)
+		default:
+			ids := make([]string, 0, len(candidates))
+			for _, g := range candidates {
+				if g.ID != nil {
+					ids = append(ids, *g.ID)
+				}
+			}
+			return tf.ErrorDiagPathF(fmt.Errorf("found %d matching groups with object IDs: %s", len(candidates), strings.Join(ids, ", ")), "adopt_existing", "Could not adopt existing group with display name %q, as more than one matching group was found", displayName)
 		}
 	}
 
-	mailNickname, err := uuid.GenerateUUID()
-	if err != nil {
-		return tf.ErrorDiagF(err, "Failed to generate mailNickname")
+	// Groups cannot be owners, which the API would only report with a misleading error after the group is created
+	owners := helpers.ExpandOwners(*tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List()), callerId)
+	if len(owners) > 0 {
+		if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeGroup, owners); err != nil {
+			return tf.ErrorDiagPathF(err, "owners", "Invalid owners for group %q", displayName)
+		}
 	}
 
-	groupTypes := make([]msgraph.GroupType, 0)
-	for _, v := range d.Get("types").(*schema.Set).List() {
-		groupTypes = append(groupTypes, msgraph.GroupType(v.(string)))
+	mailNickname := d.Get("mail_nickname").(string)
+	if mailNickname == "" {
+		var err error
+		mailNickname, err = uuid.GenerateUUID()
+		if err != nil {
+			return tf.ErrorDiagF(err, "Failed to generate mailNickname")
+		}
 	}
 
 	properties := msgraph.Group{
@@ -212,12 +649,39 @@ func groupResourceCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		SecurityEnabled: utils.Bool(d.Get("security_enabled").(bool)),
 	}
 
+	if d.Get("assignable_to_role").(bool) {
+		properties.IsAssignableToRole = utils.Bool(true)
+	}
+
+	if v, ok := d.GetOk("visibility"); ok {
+		properties.Visibility = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("preferred_language"); ok {
+		properties.PreferredLanguage = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("theme"); ok {
+		properties.Theme = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("classification"); ok {
+		properties.Classification = utils.String(v.(string))
+	}
+
 	// Add the caller as the group owner to prevent lock-out after creation
 	properties.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, callerId)
 	removeInitialOwner := true
 
-	group, _, err := client.Create(ctx, properties)
+	options := groupResourceOptions{
+		ResourceBehaviorOptions:     *tf.ExpandStringSlicePtr(d.Get("behaviors").(*schema.Set).List()),
+		ResourceProvisioningOptions: *tf.ExpandStringSlicePtr(d.Get("provisioning_options").(*schema.Set).List()),
+	}
+
+	group, status, err := groupCreate(ctx, client, properties, options)
 	if err != nil {
+		err = groupClassificationError(err, status, d.Get("classification").(string))
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupCreate, meta.(*clients.Client).Claims)
 		return tf.ErrorDiagF(err, "Creating group %q", displayName)
 	}
 
@@ -226,34 +690,57 @@ func groupResourceCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	d.SetId(*group.ID)
+	tf.Set(d, "adopted", false)
+
+	// Optionally delete the group if any subsequent step fails, so that a failed apply does not leave it behind
+	rollback := helpers.PartialCreateRollback{
+		Enabled:    meta.(*clients.Client).RollbackOnPartialCreate,
+		ObjectType: "group",
+		ObjectId:   *group.ID,
+		Delete: func(ctx context.Context) (int, error) {
+			return client.Delete(ctx, *group.ID)
+		},
+	}
+	defer func() {
+		diags = rollback.Check(ctx, d, diags)
+	}()
 
 	// Configure owners after the group is created, so they can be set one-by-one
-	if v, ok := d.GetOk("owners"); ok {
-		owners := v.(*schema.Set).List()
+	if len(owners) > 0 {
 		for _, o := range owners {
-			group.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, o.(string))
+			group.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, o)
 
 			// If the authenticated principal is included in the owners list, make sure to not remove them after the fact
-			if strings.EqualFold(callerId, o.(string)) {
+			if strings.EqualFold(callerId, o) {
 				removeInitialOwner = false
 			}
 		}
-		if _, err := client.AddOwners(ctx, group); err != nil {
+		if status, err := client.AddOwners(ctx, group); err != nil {
+			err = helpers.GraphError(err)
+			err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, owners)
+			err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupOwnerAdd, meta.(*clients.Client).Claims)
 			return tf.ErrorDiagF(err, "Could not add owners to group with ID: %q", d.Id())
 		}
 	}
 
 	// Configure members after the group is created, so they can be reliably batched
 	if v, ok := d.GetOk("members"); ok {
-		members := v.(*schema.Set).List()
-		for _, o := range members {
-			group.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, o.(string))
-		}
-		if _, err := client.AddMembers(ctx, group); err != nil {
+		members := *tf.ExpandStringSlicePtr(v.(*schema.Set).List())
+		if status, err := groupAddMembers(ctx, client, *group.ID, members); err != nil {
+			err = helpers.GraphError(err)
+			err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, members)
+			err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupMemberAdd, meta.(*clients.Client).Claims)
 			return tf.ErrorDiagF(err, "Could not add members to group with ID: %q", d.Id())
 		}
 	}
 
+	// Mail settings for unified groups cannot be specified at creation time, and must be updated on their own
+	if settings := groupMailSettingsFromConfig(d); settings != nil {
+		if _, err := groupUpdateMailSettings(ctx, client, d.Id(), *settings); err != nil {
+			return tf.ErrorDiagF(err, "Could not update mail settings for group with ID: %q", d.Id())
+		}
+	}
+
 	// Remove the initial owner
 	if removeInitialOwner {
 		ownersToRemove := []string{callerId}
@@ -262,6 +749,13 @@ func groupResourceCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
+	// Optionally wait for the group to be available to downstream resources
+	if wait, _ := time.ParseDuration(d.Get("provisioning_wait").(string)); wait > 0 {
+		if err := groupWaitForProvisioning(ctx, client, d.Id(), wait); err != nil {
+			return tf.ErrorDiagPathF(err, "provisioning_wait", "Waiting for group with ID %q to be provisioned", d.Id())
+		}
+	}
+
 	return groupResourceRead(ctx, d, meta)
 }
 
@@ -275,20 +769,12 @@ func groupResourceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 
 	// Perform this check at apply time to catch any duplicate names created during the same apply
 	if d.Get("prevent_duplicate_names").(bool) {
-		result, err := groupFindByName(ctx, client, displayName)
+		existingId, err := helpers.DuplicateNameFind(ctx, groupDuplicateNameList(client), "displayName", displayName, groupId)
 		if err != nil {
 			return tf.ErrorDiagPathF(err, "display_name", "Could not check for existing group(s)")
 		}
-		if result != nil && len(*result) > 0 {
-			for _, existingGroup := range *result {
-				if existingGroup.ID == nil {
-					return tf.ErrorDiagF(errors.New("API returned group with nil object ID during duplicate name check"), "Bad API response")
-				}
-
-				if *existingGroup.ID != groupId {
-					return tf.ImportAsDuplicateDiag("azuread_group", *existingGroup.ID, displayName)
-				}
-			}
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_group", existingId, displayName)
 		}
 	}
 
@@ -300,10 +786,47 @@ func groupResourceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		SecurityEnabled: utils.Bool(d.Get("security_enabled").(bool)),
 	}
 
-	if _, err := client.Update(ctx, group); err != nil {
+	if v, ok := d.GetOk("visibility"); ok && d.HasChange("visibility") {
+		group.Visibility = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("classification"); ok && d.HasChange("classification") {
+		group.Classification = utils.String(v.(string))
+	}
+
+	// Used to explain failed writes when the group turns out not to be a unified group
+	unifiedOnlyChanges := make([]string, 0)
+
+	if v, ok := d.GetOk("preferred_language"); ok && d.HasChange("preferred_language") {
+		group.PreferredLanguage = utils.String(v.(string))
+		unifiedOnlyChanges = append(unifiedOnlyChanges, "preferred_language")
+	}
+
+	if v, ok := d.GetOk("theme"); ok && d.HasChange("theme") {
+		group.Theme = utils.String(v.(string))
+		unifiedOnlyChanges = append(unifiedOnlyChanges, "theme")
+	}
+
+	// Used to explain failed writes when the group turns out to be synchronized from an on-premises directory
+	syncChanges := groupOnPremisesSyncChanges(d.HasChange)
+
+	if status, err := client.Update(ctx, group); err != nil {
+		if group.Classification != nil {
+			err = groupClassificationError(err, status, *group.Classification)
+		}
+		err = groupUnifiedOnlyWriteError(ctx, client, groupId, status, unifiedOnlyChanges, err)
+		err = groupOnPremisesSyncWriteError(ctx, client, groupId, status, syncChanges, err)
 		return tf.ErrorDiagF(err, "Updating group with ID: %q", d.Id())
 	}
 
+	if d.HasChanges("allow_external_senders", "auto_subscribe_new_members") {
+		if settings := groupMailSettingsFromConfig(d); settings != nil {
+			if _, err := groupUpdateMailSettings(ctx, client, d.Id(), *settings); err != nil {
+				return tf.ErrorDiagF(err, "Could not update mail settings for group with ID: %q", d.Id())
+			}
+		}
+	}
+
 	if v, ok := d.GetOk("members"); ok && d.HasChange("members") {
 		members, _, err := client.ListMembers(ctx, *group.ID)
 		if err != nil {
@@ -315,19 +838,26 @@ func groupResourceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		membersForRemoval := utils.Difference(existingMembers, desiredMembers)
 		membersToAdd := utils.Difference(desiredMembers, existingMembers)
 
-		if membersForRemoval != nil {
-			if _, err = client.RemoveMembers(ctx, d.Id(), &membersForRemoval); err != nil {
-				return tf.ErrorDiagF(err, "Could not remove members from group with ID: %q", d.Id())
-			}
-		}
-
+		// Add new members before removing old ones, so the group is never transiently empty
 		if membersToAdd != nil {
-			for _, m := range membersToAdd {
-				group.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, m)
+			if status, err := groupAddMembers(ctx, client, *group.ID, membersToAdd); err != nil {
+				err = helpers.GraphError(err)
+				err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, membersToAdd)
+				err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupMemberAdd, meta.(*clients.Client).Claims)
+				err = groupOnPremisesSyncWriteError(ctx, client, groupId, status, syncChanges, err)
+				return tf.ErrorDiagF(err, "Could not add members to group with ID: %q", d.Id())
 			}
+		}
 
-			if _, err := client.AddMembers(ctx, &group); err != nil {
-				return tf.ErrorDiagF(err, "Could not add members to group with ID: %q", d.Id())
+		if membersForRemoval != nil {
+			var status int
+			if err := helpers.RemoveReferences(ctx, "member", membersForRemoval, func(ctx context.Context, ids []string) (int, error) {
+				var err error
+				status, err = client.RemoveMembers(ctx, d.Id(), &ids)
+				return status, err
+			}); err != nil {
+				err = groupOnPremisesSyncWriteError(ctx, client, groupId, status, syncChanges, err)
+				return tf.ErrorDiagF(err, "Could not remove members from group with ID: %q", d.Id())
 			}
 		}
 	}
@@ -339,22 +869,32 @@ func groupResourceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 
 		existingOwners := *owners
-		desiredOwners := *tf.ExpandStringSlicePtr(v.(*schema.Set).List())
+		desiredOwners := helpers.ExpandOwners(*tf.ExpandStringSlicePtr(v.(*schema.Set).List()), meta.(*clients.Client).Claims.ObjectId)
 		ownersForRemoval := utils.Difference(existingOwners, desiredOwners)
 		ownersToAdd := utils.Difference(desiredOwners, existingOwners)
 
+		// Add new owners before removing old ones, so the group never transiently has no owners
 		if ownersToAdd != nil {
+			if err := helpers.ValidateOwnerObjectTypes(ctx, client.BaseClient, helpers.DirectoryObjectTypeGroup, ownersToAdd); err != nil {
+				return tf.ErrorDiagPathF(err, "owners", "Invalid owners for group with ID: %q", d.Id())
+			}
+
 			for _, m := range ownersToAdd {
 				group.AppendOwner(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, m)
 			}
 
-			if _, err := client.AddOwners(ctx, &group); err != nil {
+			if status, err := client.AddOwners(ctx, &group); err != nil {
+				err = helpers.GraphError(err)
+				err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, ownersToAdd)
+				err = helpers.PermissionsError(err, status, helpers.PermissionsOperationGroupOwnerAdd, meta.(*clients.Client).Claims)
 				return tf.ErrorDiagF(err, "Could not add owners to group with ID: %q", d.Id())
 			}
 		}
 
 		if ownersForRemoval != nil {
-			if _, err = client.RemoveOwners(ctx, d.Id(), &ownersForRemoval); err != nil {
+			if err := helpers.RemoveReferences(ctx, "owner", ownersForRemoval, func(ctx context.Context, ids []string) (int, error) {
+				return client.RemoveOwners(ctx, d.Id(), &ids)
+			}); err != nil {
 				return tf.ErrorDiagF(err, "Could not remove owners from group with ID: %q", d.Id())
 			}
 		}
@@ -366,7 +906,7 @@ func groupResourceUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 func groupResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).Groups.GroupsClient
 
-	group, status, err := client.Get(ctx, d.Id())
+	group, status, err := groupGetForResource(ctx, client, d.Id())
 	if err != nil {
 		if status == http.StatusNotFound {
 			log.Printf("[DEBUG] Group with ID %q was not found - removing from state", d.Id())
@@ -376,24 +916,59 @@ func groupResourceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return tf.ErrorDiagF(err, "Retrieving group with object ID: %q", d.Id())
 	}
 
+	tf.Set(d, "assignable_to_role", group.IsAssignableToRole)
+	tf.Set(d, "behaviors", group.ResourceBehaviorOptions)
+	tf.Set(d, "classification", group.Classification)
+	tf.Set(d, "created_by_app_id", group.CreatedByAppId)
 	tf.Set(d, "description", group.Description)
 	tf.Set(d, "display_name", group.DisplayName)
+	tf.Set(d, "expiration_date_time", groupFlattenDateTime(group.ExpirationDateTime))
+	tf.Set(d, "mail", group.Mail)
 	tf.Set(d, "mail_enabled", group.MailEnabled)
+	tf.Set(d, "mail_nickname", group.MailNickname)
 	tf.Set(d, "object_id", group.ID)
+	tf.Set(d, "onpremises_domain_name", group.OnPremisesDomainName)
+	tf.Set(d, "onpremises_last_sync_date_time", groupFlattenDateTime(group.OnPremisesLastSyncDateTime))
+	tf.Set(d, "onpremises_netbios_name", group.OnPremisesNetBiosName)
+	tf.Set(d, "onpremises_sam_account_name", group.OnPremisesSamAccountName)
+	tf.Set(d, "onpremises_security_identifier", group.OnPremisesSecurityIdentifier)
+	tf.Set(d, "onpremises_sync_enabled", group.OnPremisesSyncEnabled)
+	tf.Set(d, "preferred_language", group.PreferredLanguage)
+	tf.Set(d, "provisioning_options", group.ResourceProvisioningOptions)
+	tf.Set(d, "proxy_addresses", tf.FlattenStringSlicePtr(group.ProxyAddresses))
+	tf.Set(d, "renewed_date_time", groupFlattenDateTime(group.RenewedDateTime))
 	tf.Set(d, "security_enabled", group.SecurityEnabled)
+	tf.Set(d, "security_identifier", group.SecurityIdentifier)
+	tf.Set(d, "theme", group.Theme)
 	tf.Set(d, "types", group.GroupTypes)
+	tf.Set(d, "visibility", group.Visibility)
+
+	for _, t := range group.GroupTypes {
+		if t != msgraph.GroupTypeUnified {
+			continue
+		}
+		settings, _, err := groupGetMailSettings(ctx, client, d.Id())
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not retrieve mail settings for group with object ID %q", d.Id())
+		}
+		tf.Set(d, "allow_external_senders", settings.AllowExternalSenders)
+		tf.Set(d, "auto_subscribe_new_members", settings.AutoSubscribeNewMembers)
+		tf.Set(d, "is_subscribed_by_mail", settings.IsSubscribedByMail)
+	}
 
 	owners, _, err := client.ListOwners(ctx, *group.ID)
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not retrieve owners for group with object ID %q", d.Id())
 	}
-	tf.Set(d, "owners", owners)
+	configuredOwners := *tf.ExpandStringSlicePtr(d.Get("owners").(*schema.Set).List())
+	tf.Set(d, "owners", helpers.FlattenOwners(*owners, configuredOwners, meta.(*clients.Client).Claims.ObjectId))
 
 	members, _, err := client.ListMembers(ctx, *group.ID)
 	if err != nil {
 		return tf.ErrorDiagPathF(err, "owners", "Could not retrieve members for group with object ID %q", d.Id())
 	}
 	tf.Set(d, "members", members)
+	tf.Set(d, "members_hash", groupMembersHash(members))
 
 	preventDuplicates := false
 	if v := d.Get("prevent_duplicate_names").(bool); v {
@@ -401,22 +976,124 @@ func groupResourceRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 	tf.Set(d, "prevent_duplicate_names", preventDuplicates)
 
+	forceDestroyNestedReferences := false
+	if v := d.Get("force_destroy_nested_references").(bool); v {
+		forceDestroyNestedReferences = v
+	}
+	tf.Set(d, "force_destroy_nested_references", forceDestroyNestedReferences)
+
+	waitForPermanentDeletion := false
+	if v := d.Get("wait_for_permanent_deletion").(bool); v {
+		waitForPermanentDeletion = v
+	}
+	tf.Set(d, "wait_for_permanent_deletion", waitForPermanentDeletion)
+
+	adoptExisting := false
+	if v := d.Get("adopt_existing").(bool); v {
+		adoptExisting = v
+	}
+	tf.Set(d, "adopt_existing", adoptExisting)
+
+	adoptedDestroyBehaviour := "delete"
+	if v := d.Get("adopted_destroy_behaviour").(string); v != "" {
+		adoptedDestroyBehaviour = v
+	}
+	tf.Set(d, "adopted_destroy_behaviour", adoptedDestroyBehaviour)
+
+	tf.Set(d, "adopted", d.Get("adopted").(bool))
+
+	provisioningWait := "0s"
+	if v := d.Get("provisioning_wait").(string); v != "" {
+		provisioningWait = v
+	}
+	tf.Set(d, "provisioning_wait", provisioningWait)
+
 	return nil
 }
 
+// groupMailSettingsFromConfig returns the configured mail settings for a unified group, or nil when none are configured
+func groupMailSettingsFromConfig(d *schema.ResourceData) *groupMailSettings {
+	var settings groupMailSettings
+	configured := false
+
+	if v, ok := d.GetOkExists("allow_external_senders"); ok { //nolint:staticcheck
+		settings.AllowExternalSenders = utils.Bool(v.(bool))
+		configured = true
+	}
+	if v, ok := d.GetOkExists("auto_subscribe_new_members"); ok { //nolint:staticcheck
+		settings.AutoSubscribeNewMembers = utils.Bool(v.(bool))
+		configured = true
+	}
+
+	if !configured {
+		return nil
+	}
+	return &settings
+}
+
+func groupResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*clients.Client).Groups.GroupsClient
+
+	if err := helpers.ValidateDirectoryObjectType(ctx, client.BaseClient, d.Id(), helpers.DirectoryObjectTypeGroup); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func groupResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).Groups.GroupsClient
 
-	_, status, err := client.Get(ctx, d.Id())
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("Group was not found"), "id", "Retrieving group with object ID %q", d.Id())
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "group",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	group, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	if d.Get("adopted").(bool) && d.Get("adopted_destroy_behaviour").(string) == "abandon" {
+		log.Printf("[DEBUG] Abandoning adopted group with object ID %q instead of deleting it", d.Id())
+		return nil
+	}
+
+	if d.Get("force_destroy_nested_references").(bool) {
+		if err := groupRemoveFromParents(ctx, client, d.Id()); err != nil {
+			return tf.ErrorDiagF(err, "Removing group with object ID %q from parent groups", d.Id())
 		}
-		return tf.ErrorDiagPathF(err, "id", "Retrieving group with object ID: %q", d.Id())
 	}
 
-	if _, err := client.Delete(ctx, d.Id()); err != nil {
-		return tf.ErrorDiagF(err, "Deleting group with object ID: %q", d.Id())
+	status, err = client.Delete(ctx, d.Id())
+	if err != nil && status != http.StatusNotFound {
+		err = groupDeleteParentsError(ctx, client, d.Id(), err)
+	}
+	if diags := deletion.CheckDeleted(ctx, status, err); diags.HasError() {
+		return diags
+	}
+
+	if d.Get("wait_for_permanent_deletion").(bool) {
+		// Only Microsoft 365 groups are soft-deleted, other groups are permanently deleted straight away
+		if !groupIsUnified(group) {
+			log.Printf("[DEBUG] Group with object ID %q is not a Microsoft 365 group, so has already been permanently deleted", d.Id())
+			return nil
+		}
+
+		var mailNickname string
+		if group.MailNickname != nil {
+			mailNickname = *group.MailNickname
+		}
+		if err := groupWaitForPermanentDeletion(ctx, client, d.Id(), mailNickname); err != nil {
+			return tf.ErrorDiagPathF(err, "wait_for_permanent_deletion", "Permanently deleting group with object ID %q", d.Id())
+		}
 	}
 
 	return nil
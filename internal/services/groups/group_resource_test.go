@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
@@ -17,6 +20,10 @@ import (
 
 type GroupResource struct{}
 
+// groupClassificationEnvVar specifies a classification to use when testing group classifications. Classifications can
+// only be set when the tenant defines a ClassificationList directory setting, so these tests are skipped unless it is set.
+const groupClassificationEnvVar = "ARM_TEST_GROUP_CLASSIFICATION"
+
 func TestAccGroup_basic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
@@ -27,6 +34,9 @@ func TestAccGroup_basic(t *testing.T) {
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("mail_nickname").IsUuid(),
+				check.That(data.ResourceName).Key("onpremises_security_identifier").IsEmpty(),
+				check.That(data.ResourceName).Key("security_identifier").MatchesRegex(regexp.MustCompile(`^S-1-12-1-`)),
 			),
 		},
 		data.ImportStep(),
@@ -48,6 +58,89 @@ func TestAccGroup_complete(t *testing.T) {
 	})
 }
 
+func TestAccGroup_mailNickname(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.mailNickname(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("mail_nickname").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("mail").Exists(),
+				check.That(data.ResourceName).Key("proxy_addresses.#").Exists(),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_visibility(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.visibility(data, "Private"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("visibility").HasValue("Private"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.visibility(data, "Public"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("visibility").HasValue("Public"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.visibility(data, "HiddenMembership"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("visibility").HasValue("HiddenMembership"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_behaviors(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.behaviors(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("behaviors.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_provisioningOptions(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.provisioningOptions(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("provisioning_options.#").HasValue("1"),
+				check.That(data.ResourceName).Key("provisioning_options.0").HasValue("Team"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccGroup_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
@@ -192,81 +285,499 @@ func TestAccGroup_membersUpdate(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep(),
+		data.ImportStep(),
+		{
+			Config: r.withServicePrincipalMember(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.noMembers(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_ownersUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withThreeOwners(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withOneOwner(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withServicePrincipalOwner(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_currentOwner(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.currentOwner(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("owners.#").HasValue("1"),
+				check.That(data.ResourceName).Key("owners.0").HasValue("current"),
+			),
+		},
+		// The placeholder is only retained when it is configured, so cannot be imported
+		data.ImportStep("owners"),
+		{
+			Config:      r.currentOwnerDuplicate(data),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("cannot both be specified"),
+		},
+	})
+}
+
+func TestAccGroup_groupOwner(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			// The owner group is created in the same apply, so its object ID is unknown at plan time
+			Config:      r.groupOwner(data),
+			ExpectError: regexp.MustCompile("groups cannot be owners of groups"),
+		},
+		{
+			Config:      r.groupOwner(data),
+			PlanOnly:    true,
+			ExpectError: regexp.MustCompile("groups cannot be owners of groups"),
+		},
+	})
+}
+
+func TestAccGroup_preventDuplicateNamesPass(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.preventDuplicateNamesPass(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep("prevent_duplicate_names"),
+	})
+}
+
+func TestAccGroup_preventDuplicateNamesFail(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		data.RequiresImportErrorStep(r.preventDuplicateNamesFail(data)),
+	})
+}
+
+func TestAccGroup_adoptExisting(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.adoptExisting(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_group.adopted").Key("adopted").HasValue("true"),
+				check.That("azuread_group.adopted").Key("object_id").MatchesOtherKey(check.That(data.ResourceName).Key("object_id")),
+				check.That("azuread_group.adopted").Key("description").HasValue("Adopted by Terraform"),
+			),
+		},
+	})
+}
+
+func TestAccGroup_adoptExistingNoMatch(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.adoptExistingNoMatch(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("adopted").HasValue("false"),
+			),
+		},
+		data.ImportStep("adopt_existing"),
+	})
+}
+
+func TestAccGroup_importWrongObjectType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.importWrongObjectType(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			ResourceName: data.ResourceName,
+			ImportState:  true,
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				other, ok := s.RootModule().Resources["azuread_user.other"]
+				if !ok {
+					return "", fmt.Errorf("azuread_user.other not found in state")
+				}
+				return other.Primary.ID, nil
+			},
+			ExpectError: regexp.MustCompile("is a User, not a Group"),
+		},
+	})
+}
+
+func TestAccGroup_unifiedMailSettings(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.unifiedMailSettings(data, true, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("allow_external_senders").HasValue("true"),
+				check.That(data.ResourceName).Key("auto_subscribe_new_members").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.unifiedMailSettings(data, false, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("allow_external_senders").HasValue("false"),
+				check.That(data.ResourceName).Key("auto_subscribe_new_members").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_classification(t *testing.T) {
+	classification := os.Getenv(groupClassificationEnvVar)
+	if classification == "" {
+		t.Skipf("skipping since %s is not set", groupClassificationEnvVar)
+	}
+
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.classification(data, classification),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("classification").HasValue(classification),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.unified(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("classification").HasValue(classification),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_classificationUpdate(t *testing.T) {
+	classification := os.Getenv(groupClassificationEnvVar)
+	if classification == "" {
+		t.Skipf("skipping since %s is not set", groupClassificationEnvVar)
+	}
+
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.unified(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("classification").IsEmpty(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.classification(data, classification),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("classification").HasValue(classification),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_classificationUndefined(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.classification(data, fmt.Sprintf("acctestUndefined-%d", data.RandomInteger)),
+			ExpectError: regexp.MustCompile("may not be defined for this tenant"),
+		},
+	})
+}
+
+func TestAccGroup_mailSettingsNotUnified(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.mailSettingsNotUnified(data, true),
+			ExpectError: regexp.MustCompile("`allow_external_senders` is only supported for unified groups"),
+		},
+		{
+			Config:      r.mailSettingsNotUnified(data, false),
+			ExpectError: regexp.MustCompile("`allow_external_senders` is only supported for unified groups"),
+		},
+	})
+}
+
+func TestAccGroup_exchangeOnlySettings(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.exchangeOnlySettings(data),
+			ExpectError: regexp.MustCompile("Not supported via Microsoft Graph, configure via Exchange Online"),
+		},
+	})
+}
+
+func TestAccGroup_mailAndSecurityDisabled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.mailAndSecurityDisabled(data),
+			ExpectError: regexp.MustCompile("at least one of `mail_enabled` or `security_enabled` must be true"),
+		},
+	})
+}
+
+func TestAccGroup_mailAndSecurityDisabledUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config:      r.mailAndSecurityDisabled(data),
+			ExpectError: regexp.MustCompile("at least one of `mail_enabled` or `security_enabled` must be true"),
+		},
+	})
+}
+
+func TestAccGroup_assignableToRole(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.assignableToRole(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("assignable_to_role").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccGroup_assignableToRoleMailOnly(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.assignableToRoleMailOnly(data),
+			ExpectError: regexp.MustCompile("`security_enabled` must be true when `assignable_to_role` is true"),
+		},
+	})
+}
+
+func TestAccGroup_provisioningWait(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.provisioningWait(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("provisioning_wait").HasValue("2m"),
+				check.That("azuread_group.parent").Key("members.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("provisioning_wait"),
+	})
+}
+
+func TestAccGroup_forceDestroyNestedReferences(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	// The groups are nested outside of Terraform, so that there are no dependencies to determine the destroy ordering
+	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.withServicePrincipalMember(data),
+			Config: r.forceDestroyNestedReferences(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("force_destroy_nested_references").HasValue("true"),
+				r.addMemberOutOfBand("azuread_group.middle", data.ResourceName),
+				r.addMemberOutOfBand("azuread_group.top", "azuread_group.middle"),
 			),
 		},
-		data.ImportStep(),
 		{
-			Config: r.noMembers(data),
+			Config: r.forceDestroyNestedReferences(data),
 			Check: resource.ComposeTestCheckFunc(
-				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_group.middle").Key("members.#").HasValue("1"),
+				check.That("azuread_group.top").Key("members.#").HasValue("1"),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
-func TestAccGroup_ownersUpdate(t *testing.T) {
+func TestAccGroup_waitForPermanentDeletion(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
 
+	// The group is destroyed and then recreated with the same mail nickname in consecutive applies
 	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.basic(data),
+			Config: r.waitForPermanentDeletion(data, true),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("wait_for_permanent_deletion").HasValue("true"),
 			),
 		},
-		data.ImportStep(),
 		{
-			Config: r.withThreeOwners(data),
-			Check: resource.ComposeTestCheckFunc(
-				check.That(data.ResourceName).ExistsInAzure(r),
-			),
+			Config: r.withoutGroup(),
 		},
-		data.ImportStep(),
 		{
-			Config: r.withOneOwner(data),
+			Config: r.waitForPermanentDeletion(data, true),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep(),
+		data.ImportStep("wait_for_permanent_deletion"),
+	})
+}
+
+func TestAccGroup_recreateWithoutPermanentDeletion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_group", "test")
+	r := GroupResource{}
+
+	// Without `wait_for_permanent_deletion`, the soft-deleted group continues to reserve its mail nickname
+	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.withServicePrincipalOwner(data),
+			Config: r.waitForPermanentDeletion(data, false),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep(),
+		{
+			Config: r.withoutGroup(),
+		},
+		{
+			Config:      r.waitForPermanentDeletion(data, false),
+			ExpectError: regexp.MustCompile("mailNickname"),
+		},
 	})
 }
 
-func TestAccGroup_preventDuplicateNamesPass(t *testing.T) {
+func TestAccGroup_themeAndPreferredLanguage(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
 
 	data.ResourceTest(t, r, []resource.TestStep{
 		{
-			Config: r.preventDuplicateNamesPass(data),
+			Config: r.themeAndPreferredLanguage(data, "Teal", "en"),
 			Check: resource.ComposeTestCheckFunc(
-				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestGroup-%d", data.RandomInteger)),
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("theme").HasValue("Teal"),
+				check.That(data.ResourceName).Key("preferred_language").HasValue("en"),
 			),
 		},
-		data.ImportStep("prevent_duplicate_names"),
+		data.ImportStep(),
+		{
+			Config: r.themeAndPreferredLanguage(data, "Purple", "fr"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("theme").HasValue("Purple"),
+				check.That(data.ResourceName).Key("preferred_language").HasValue("fr"),
+			),
+		},
+		data.ImportStep(),
 	})
 }
 
-func TestAccGroup_preventDuplicateNamesFail(t *testing.T) {
+func TestAccGroup_themeNotUnified(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_group", "test")
 	r := GroupResource{}
 
 	data.ResourceTest(t, r, []resource.TestStep{
-		data.RequiresImportErrorStep(r.preventDuplicateNamesFail(data)),
+		{
+			Config:      r.themeNotUnified(data),
+			ExpectError: regexp.MustCompile("`theme` is only supported for unified groups"),
+		},
 	})
 }
 
@@ -286,76 +797,45 @@ func (r GroupResource) Exists(ctx context.Context, clients *clients.Client, stat
 
 func (GroupResource) templateDiverseDirectoryObjects(data acceptance.TestData) string {
 	return fmt.Sprintf(`
-data "azuread_domains" "test" {
-  only_initial = true
-}
-
-resource "azuread_application" "test" {
-  display_name = "acctestGroup-%[1]d"
-}
-
-resource "azuread_service_principal" "test" {
-  application_id = azuread_application.test.application_id
-}
-
-resource "azuread_user" "test" {
-  user_principal_name = "acctestGroup.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestGroup-%[1]d"
-  password            = "%[2]s"
-}
+%[1]s
 
 resource "azuread_group" "member" {
-  display_name     = "acctestGroup-%[1]d-Member"
+  display_name     = "acctestGroup-%[2]d-Member"
   security_enabled = true
 }
-`, data.RandomInteger, data.RandomPassword)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
-func (GroupResource) templateThreeUsers(data acceptance.TestData) string {
+func (GroupResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
-data "azuread_domains" "test" {
-  only_initial = true
-}
-
-resource "azuread_user" "testA" {
-  user_principal_name = "acctestGroup.%[1]d.A@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestGroup-%[1]d-A"
-  password            = "%[2]s"
-}
-
-resource "azuread_user" "testB" {
-  user_principal_name = "acctestGroup.%[1]d.B@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestGroup-%[1]d-B"
-  mail_nickname       = "acctestGroup-%[1]d-B"
-  password            = "%[2]s"
-}
-
-resource "azuread_user" "testC" {
-  user_principal_name = "acctestGroup.%[1]d.C@${data.azuread_domains.test.domains.0.domain_name}"
-  display_name        = "acctestGroup-%[1]d-C"
-  password            = "%[2]s"
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
 }
-`, data.RandomInteger, data.RandomPassword)
+`, data.RandomInteger)
 }
 
-func (GroupResource) basic(data acceptance.TestData) string {
+func (GroupResource) unified(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[1]d"
+  types            = ["Unified"]
+  mail_enabled     = true
   security_enabled = true
 }
 `, data.RandomInteger)
 }
 
-func (GroupResource) unified(data acceptance.TestData) string {
+func (GroupResource) classification(data acceptance.TestData, classification string) string {
 	return fmt.Sprintf(`
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[1]d"
   types            = ["Unified"]
   mail_enabled     = true
   security_enabled = true
+  classification   = "%[2]s"
 }
-`, data.RandomInteger)
+`, data.RandomInteger, classification)
 }
 
 func (GroupResource) complete(data acceptance.TestData) string {
@@ -399,7 +879,7 @@ func (r GroupResource) withDiverseMembers(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  members          = [azuread_user.test.object_id, azuread_group.member.object_id, azuread_service_principal.test.object_id]
+  members          = [local.shared_user_ids[0], azuread_group.member.object_id, local.shared_service_principal_object_id]
 }
 `, r.templateDiverseDirectoryObjects(data), data.RandomInteger)
 }
@@ -411,7 +891,7 @@ func (r GroupResource) withDiverseOwners(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  owners           = [azuread_user.test.object_id, azuread_service_principal.test.object_id]
+  owners           = [local.shared_user_ids[0], local.shared_service_principal_object_id]
 }
 `, r.templateDiverseDirectoryObjects(data), data.RandomInteger)
 }
@@ -423,9 +903,9 @@ func (r GroupResource) withOneMember(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  members          = [azuread_user.testA.object_id]
+  members          = [local.shared_user_ids[0]]
 }
-`, r.templateThreeUsers(data), data.RandomInteger)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
 func (r GroupResource) withOneOwner(data acceptance.TestData) string {
@@ -435,9 +915,9 @@ func (r GroupResource) withOneOwner(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  owners           = [azuread_user.testA.object_id]
+  owners           = [local.shared_user_ids[0]]
 }
-`, r.templateThreeUsers(data), data.RandomInteger)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
 func (r GroupResource) withThreeMembers(data acceptance.TestData) string {
@@ -447,9 +927,9 @@ func (r GroupResource) withThreeMembers(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  members          = [azuread_user.testA.object_id, azuread_user.testB.object_id, azuread_user.testC.object_id]
+  members          = [local.shared_user_ids[0], local.shared_user_ids[1], local.shared_user_ids[2]]
 }
-`, r.templateThreeUsers(data), data.RandomInteger)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
 func (r GroupResource) withThreeOwners(data acceptance.TestData) string {
@@ -459,9 +939,9 @@ func (r GroupResource) withThreeOwners(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  owners           = [azuread_user.testA.object_id, azuread_user.testB.object_id, azuread_user.testC.object_id]
+  owners           = [local.shared_user_ids[0], local.shared_user_ids[1], local.shared_user_ids[2]]
 }
-`, r.templateThreeUsers(data), data.RandomInteger)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
 func (r GroupResource) withOwnersAndMembers(data acceptance.TestData) string {
@@ -471,10 +951,10 @@ func (r GroupResource) withOwnersAndMembers(data acceptance.TestData) string {
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[2]d"
   security_enabled = true
-  owners           = [azuread_user.testA.object_id]
-  members          = [azuread_user.testB.object_id, azuread_user.testC.object_id]
+  owners           = [local.shared_user_ids[0]]
+  members          = [local.shared_user_ids[1], local.shared_user_ids[2]]
 }
-`, r.templateThreeUsers(data), data.RandomInteger)
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
 func (r GroupResource) withManyOwnersAndMembers(data acceptance.TestData) string {
@@ -502,36 +982,61 @@ resource "azuread_group" "test" {
 
 func (GroupResource) withServicePrincipalMember(data acceptance.TestData) string {
 	return fmt.Sprintf(`
-resource "azuread_application" "test" {
-  display_name = "acctestGroup-%[1]d"
+%[1]s
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[2]d"
+  security_enabled = true
+  members          = [local.shared_service_principal_object_id]
 }
+`, data.SharedPrerequisites(), data.RandomInteger)
+}
+
+func (GroupResource) withServicePrincipalOwner(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
 
-resource "azuread_service_principal" "test" {
-  application_id = azuread_application.test.application_id
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[2]d"
+  security_enabled = true
+  owners           = [local.shared_service_principal_object_id]
+}
+`, data.SharedPrerequisites(), data.RandomInteger)
 }
 
+func (GroupResource) currentOwner(data acceptance.TestData) string {
+	return fmt.Sprintf(`
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[1]d"
   security_enabled = true
-  members          = [azuread_service_principal.test.object_id]
+  owners           = ["current"]
 }
 `, data.RandomInteger)
 }
 
-func (GroupResource) withServicePrincipalOwner(data acceptance.TestData) string {
+func (GroupResource) currentOwnerDuplicate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
-resource "azuread_application" "test" {
-  display_name = "acctestGroup-%[1]d"
+data "azuread_client_config" "current" {}
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+  owners           = ["current", data.azuread_client_config.current.object_id]
+}
+`, data.RandomInteger)
 }
 
-resource "azuread_service_principal" "test" {
-  application_id = azuread_application.test.application_id
+func (GroupResource) groupOwner(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "owner" {
+  display_name     = "acctestGroup-owner-%[1]d"
+  security_enabled = true
 }
 
 resource "azuread_group" "test" {
   display_name     = "acctestGroup-%[1]d"
   security_enabled = true
-  owners           = [azuread_service_principal.test.object_id]
+  owners           = [azuread_group.owner.object_id]
 }
 `, data.RandomInteger)
 }
@@ -557,3 +1062,260 @@ resource "azuread_group" "duplicate" {
 }
 `, r.basic(data))
 }
+
+func (r GroupResource) adoptExisting(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "adopted" {
+  display_name              = azuread_group.test.display_name
+  description               = "Adopted by Terraform"
+  security_enabled          = true
+  adopt_existing            = true
+  adopted_destroy_behaviour = "abandon"
+}
+`, r.basic(data))
+}
+
+func (GroupResource) adoptExistingNoMatch(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+  adopt_existing   = true
+}
+`, data.RandomInteger)
+}
+
+func (r GroupResource) importWrongObjectType(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "other" {
+  user_principal_name = "acctestGroup.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestGroup-%[2]d"
+  password            = "%[3]s"
+}
+`, r.basic(data), data.RandomInteger, data.RandomPassword)
+}
+
+func (GroupResource) mailNickname(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  mail_nickname    = "acctestGroup-%[1]d"
+  types            = ["Unified"]
+  mail_enabled     = true
+  security_enabled = true
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) visibility(data acceptance.TestData, visibility string) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  types            = ["Unified"]
+  mail_enabled     = true
+  security_enabled = true
+  visibility       = "%[2]s"
+}
+`, data.RandomInteger, visibility)
+}
+
+func (GroupResource) behaviors(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  types            = ["Unified"]
+  mail_enabled     = true
+  security_enabled = true
+  behaviors        = ["HideGroupInOutlook", "WelcomeEmailDisabled"]
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) provisioningOptions(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name         = "acctestGroup-%[1]d"
+  types                = ["Unified"]
+  mail_enabled         = true
+  security_enabled     = true
+  provisioning_options = ["Team"]
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) unifiedMailSettings(data acceptance.TestData, allowExternalSenders, autoSubscribeNewMembers bool) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name               = "acctestGroup-%[1]d"
+  types                      = ["Unified"]
+  mail_enabled               = true
+  security_enabled           = true
+  allow_external_senders     = %[2]t
+  auto_subscribe_new_members = %[3]t
+}
+`, data.RandomInteger, allowExternalSenders, autoSubscribeNewMembers)
+}
+
+func (GroupResource) mailSettingsNotUnified(data acceptance.TestData, allowExternalSenders bool) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name           = "acctestGroup-%[1]d"
+  security_enabled       = true
+  allow_external_senders = %[2]t
+}
+`, data.RandomInteger, allowExternalSenders)
+}
+
+func (GroupResource) exchangeOnlySettings(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name       = "acctestGroup-%[1]d"
+  types              = ["Unified"]
+  mail_enabled       = true
+  security_enabled   = true
+  moderation_enabled = true
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) mailAndSecurityDisabled(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  mail_enabled     = false
+  security_enabled = false
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) assignableToRole(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name       = "acctestGroup-%[1]d"
+  assignable_to_role = true
+  security_enabled   = true
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) assignableToRoleMailOnly(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name       = "acctestGroup-%[1]d"
+  assignable_to_role = true
+  mail_enabled       = true
+  types              = ["Unified"]
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) provisioningWait(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled  = true
+  provisioning_wait = "2m"
+}
+
+resource "azuread_group" "parent" {
+  display_name     = "acctestGroup-parent-%[1]d"
+  security_enabled = true
+  members          = [azuread_group.test.object_id]
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) forceDestroyNestedReferences(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name                    = "acctestGroup-bottom-%[1]d"
+  security_enabled                = true
+  force_destroy_nested_references = true
+}
+
+resource "azuread_group" "middle" {
+  display_name                    = "acctestGroup-middle-%[1]d"
+  security_enabled                = true
+  force_destroy_nested_references = true
+}
+
+resource "azuread_group" "top" {
+  display_name                    = "acctestGroup-top-%[1]d"
+  security_enabled                = true
+  force_destroy_nested_references = true
+}
+`, data.RandomInteger)
+}
+
+func (GroupResource) waitForPermanentDeletion(data acceptance.TestData, wait bool) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name                = "acctestGroup-%[1]d"
+  mail_nickname               = "acctestGroup-%[1]d"
+  types                       = ["Unified"]
+  mail_enabled                = true
+  security_enabled            = true
+  wait_for_permanent_deletion = %[2]t
+}
+`, data.RandomInteger, wait)
+}
+
+func (GroupResource) withoutGroup() string {
+	return `
+data "azuread_client_config" "test" {}
+`
+}
+
+// addMemberOutOfBand adds a member to a group without Terraform's knowledge
+func (GroupResource) addMemberOutOfBand(groupResourceName, memberResourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		group, ok := s.RootModule().Resources[groupResourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", groupResourceName)
+		}
+		member, ok := s.RootModule().Resources[memberResourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", memberResourceName)
+		}
+
+		client := acceptance.AzureADProvider.Meta().(*clients.Client).Groups.GroupsClient
+		properties := msgraph.Group{ID: utils.String(group.Primary.ID)}
+		properties.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, member.Primary.ID)
+		if _, err := client.AddMembers(context.Background(), &properties); err != nil {
+			return fmt.Errorf("adding %s as a member of %s: %v", memberResourceName, groupResourceName, err)
+		}
+
+		return nil
+	}
+}
+
+func (GroupResource) themeAndPreferredLanguage(data acceptance.TestData, theme, preferredLanguage string) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name       = "acctestGroup-%[1]d"
+  types              = ["Unified"]
+  mail_enabled       = true
+  security_enabled   = true
+  theme              = %[2]q
+  preferred_language = %[3]q
+}
+`, data.RandomInteger, theme, preferredLanguage)
+}
+
+func (GroupResource) themeNotUnified(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[1]d"
+  security_enabled = true
+  theme            = "Teal"
+}
+`, data.RandomInteger)
+}
@@ -2,26 +2,806 @@ package groups
 
 import (
 	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 )
 
+// groupMembersHash returns a hash of the provided member object IDs, which does not depend on their order or case
+func groupMembersHash(members *[]string) string {
+	ids := make([]string, 0)
+	if members != nil {
+		for _, id := range *members {
+			ids = append(ids, strings.ToLower(id))
+		}
+	}
+	sort.Strings(ids)
+
+	h := sha1.Sum([]byte(strings.Join(ids, "/")))
+	return hex.EncodeToString(h[:])
+}
+
 func groupFindByName(ctx context.Context, client *msgraph.GroupsClient, displayName string) (*[]msgraph.Group, error) {
-	filter := fmt.Sprintf("displayName eq '%s'", displayName)
+	groups, err := groupListByFilter(ctx, client, helpers.ODataEq("displayName", displayName))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]msgraph.Group, 0)
+	for _, group := range groups {
+		if group.DisplayName != nil && *group.DisplayName == displayName {
+			result = append(result, group)
+		}
+	}
+
+	return &result, nil
+}
+
+// groupListByFilter returns the groups matching filter. When a `$count` query finds matching groups, they are retrieved
+// with an advanced query, otherwise a regular list is used so that recently created groups are not missed.
+func groupListByFilter(ctx context.Context, client *msgraph.GroupsClient, filter string) ([]msgraph.Group, error) {
+	result := make([]msgraph.Group, 0)
+
+	found, err := common.AdvancedQueryListExisting(ctx, client.BaseClient, "/groups", common.AdvancedQuery{Filter: filter}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to count Groups with filter %q: %+v", filter, err)
+	}
+	if found {
+		return result, nil
+	}
+
 	groups, _, err := client.List(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to list Groups with filter %q: %+v", filter, err)
 	}
+	if groups != nil {
+		result = append(result, *groups...)
+	}
+
+	return result, nil
+}
+
+// groupDuplicateNameList returns a function which lists groups for a duplicate name check
+func groupDuplicateNameList(client *msgraph.GroupsClient) helpers.DuplicateNameListFunc {
+	return func(ctx context.Context, filter string) ([]helpers.DuplicateNameObject, error) {
+		groups, err := groupListByFilter(ctx, client, filter)
+		if err != nil {
+			return nil, err
+		}
+		result := make([]helpers.DuplicateNameObject, 0, len(groups))
+		for _, group := range groups {
+			result = append(result, helpers.DuplicateNameObject{ID: group.ID, Name: group.DisplayName})
+		}
+		return result, nil
+	}
+}
+
+// groupFindByOnPremisesProperty returns groups having the specified value for an on-premises property, such as
+// `onPremisesSamAccountName`. Filtering on some of these properties requires an advanced query, so one is attempted
+// first, falling back to a regular list for tenants which do not support advanced queries.
+func groupFindByOnPremisesProperty(ctx context.Context, client *msgraph.GroupsClient, property, value string, extraFilters ...string) (*[]msgraph.Group, string, error) {
+	filter := helpers.ODataAnd(append([]string{helpers.ODataEq(property, value)}, extraFilters...)...)
+
+	groups := make([]msgraph.Group, 0)
+	_, err := common.AdvancedQueryList(ctx, client.BaseClient, "/groups", common.AdvancedQuery{Filter: filter}, &groups)
+	if err == nil {
+		return &groups, filter, nil
+	}
+	if !common.IsAdvancedQueryUnsupported(err) {
+		return nil, filter, fmt.Errorf("unable to list Groups with filter %q: %+v", filter, err)
+	}
+
+	result, _, err := client.List(ctx, filter)
+	if err != nil {
+		return nil, filter, fmt.Errorf("unable to list Groups with filter %q: %+v", filter, err)
+	}
+
+	return result, filter, nil
+}
+
+// groupListPageSize is the largest page size supported when listing groups
+const groupListPageSize = 999
+
+// groupListAll returns the groups matching filter, or all groups when filter is empty, retrieving the largest pages
+// supported so that tenants with many thousands of groups can be listed with relatively few requests. Only the ID and
+// display name of each group are selected. When limit is greater than zero, no further pages are retrieved once more
+// than limit groups are found, and up to limit+1 groups are returned so that callers can tell when there are more.
+func groupListAll(ctx context.Context, client *msgraph.GroupsClient, filter string, limit int) ([]msgraph.Group, error) {
+	query := common.AdvancedQuery{
+		Filter: filter,
+		Select: []string{"id", "displayName"},
+		Top:    groupListPageSize,
+		Limit:  limit,
+	}
+	if limit > 0 && limit < groupListPageSize {
+		query.Top = limit + 1
+	}
+
+	groups := make([]msgraph.Group, 0)
+	_, err := common.AdvancedQueryList(ctx, client.BaseClient, "/groups", query, &groups)
+	if err == nil {
+		return groups, nil
+	}
+	if !common.IsAdvancedQueryUnsupported(err) {
+		return nil, fmt.Errorf("unable to list Groups with filter %q: %+v", filter, err)
+	}
+
+	// Fall back to a regular query, which is paged and limited in the same way
+	query.Regular = true
+	if _, err := common.AdvancedQueryList(ctx, client.BaseClient, "/groups", query, &groups); err != nil {
+		return nil, fmt.Errorf("unable to list Groups with filter %q: %+v", filter, err)
+	}
+
+	return groups, nil
+}
+
+// groupResourceBehaviorOptions are the behaviors which can be set when creating a Microsoft 365 group
+var groupResourceBehaviorOptions = []string{
+	"AllowOnlyMembersToPost",
+	"CalendarMemberReadOnly",
+	"ConnectorsDisabled",
+	"HideGroupInOutlook",
+	"SubscribeMembersToCalendarEventsDisabled",
+	"SubscribeNewGroupMembers",
+	"WelcomeEmailDisabled",
+}
+
+// groupResourceProvisioningOptions are the provisioning options which can be set when creating a Microsoft 365 group
+var groupResourceProvisioningOptions = []string{
+	"Team",
+}
+
+const (
+	// groupMembersAddBatchSize is the maximum number of member references which can be added to a group in one request
+	groupMembersAddBatchSize = 20
+
+	// groupOwnersLimit is the maximum number of owners a group can have
+	groupOwnersLimit = 100
+)
+
+const (
+	groupVisibilityHiddenMembership = "HiddenMembership"
+	groupVisibilityPrivate          = "Private"
+	groupVisibilityPublic           = "Public"
+)
+
+// groupThemes are the color themes supported for Microsoft 365 groups
+var groupThemes = []string{"Blue", "Green", "Orange", "Pink", "Purple", "Red", "Teal"}
+
+// groupValidateExchangeOnly returns a validation function which rejects any value for an attribute that cannot be
+// managed using Microsoft Graph, explaining how to configure it using Exchange Online instead. When alternative is
+// specified, it names an attribute supported by this resource which provides similar behaviour.
+func groupValidateExchangeOnly(parameter, alternative string) schema.SchemaValidateDiagFunc {
+	return func(_ interface{}, path cty.Path) diag.Diagnostics {
+		detail := fmt.Sprintf("This setting is not supported via Microsoft Graph, so it cannot be managed by this resource. Configure it via Exchange Online instead, using the `%s` parameter of the `Set-UnifiedGroup` cmdlet.", parameter)
+		if alternative != "" {
+			detail = fmt.Sprintf("%s Alternatively, the `%s` argument is supported for Microsoft 365 (unified) groups.", detail, alternative)
+		}
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Not supported via Microsoft Graph, configure via Exchange Online",
+			Detail:        detail,
+			AttributePath: path,
+		}}
+	}
+}
+
+// groupUnifiedOnlyAttributes are the attributes of a group which can only be set for Microsoft 365 (unified) groups
+var groupUnifiedOnlyAttributes = []string{"allow_external_senders", "auto_subscribe_new_members", "behaviors", "preferred_language", "provisioning_options", "theme"}
+
+// groupsMatchingForAdoption returns the groups which are suitable for adoption, i.e. those having the same
+// mail-enabled, security-enabled and role-assignable flags, and the same group types. Group types and role
+// assignability cannot be changed, so a group with differing values would immediately need to be replaced.
+func groupsMatchingForAdoption(groups []msgraph.Group, mailEnabled, securityEnabled, assignableToRole bool, groupTypes []msgraph.GroupType) []msgraph.Group {
+	hasGroupType := func(types []msgraph.GroupType, value msgraph.GroupType) bool {
+		for _, v := range types {
+			if strings.EqualFold(string(v), string(value)) {
+				return true
+			}
+		}
+		return false
+	}
 
 	result := make([]msgraph.Group, 0)
-	if groups != nil {
-		for _, group := range *groups {
-			if group.DisplayName != nil && *group.DisplayName == displayName {
-				result = append(result, group)
+	for _, group := range groups {
+		if group.MailEnabled == nil || *group.MailEnabled != mailEnabled {
+			continue
+		}
+		if group.SecurityEnabled == nil || *group.SecurityEnabled != securityEnabled {
+			continue
+		}
+		if (group.IsAssignableToRole != nil && *group.IsAssignableToRole) != assignableToRole {
+			continue
+		}
+		if len(group.GroupTypes) != len(groupTypes) {
+			continue
+		}
+		matchingTypes := true
+		for _, t := range groupTypes {
+			if !hasGroupType(group.GroupTypes, t) {
+				matchingTypes = false
+				break
 			}
 		}
+		if !matchingTypes {
+			continue
+		}
+		result = append(result, group)
 	}
 
-	return &result, nil
+	return result
+}
+
+// groupResourceSelectProperties maps the attributes of the azuread_group resource which are read from the group object to
+// their Graph properties, and determines which properties are selected when reading a group. Any new attribute which is
+// read from the group object must be added here, otherwise it will not be returned by the API.
+var groupResourceSelectProperties = map[string]string{
+	"assignable_to_role":             "isAssignableToRole",
+	"behaviors":                      "resourceBehaviorOptions",
+	"classification":                 "classification",
+	"created_by_app_id":              "createdByAppId",
+	"description":                    "description",
+	"display_name":                   "displayName",
+	"expiration_date_time":           "expirationDateTime",
+	"mail":                           "mail",
+	"mail_enabled":                   "mailEnabled",
+	"mail_nickname":                  "mailNickname",
+	"object_id":                      "id",
+	"onpremises_domain_name":         "onPremisesDomainName",
+	"onpremises_last_sync_date_time": "onPremisesLastSyncDateTime",
+	"onpremises_netbios_name":        "onPremisesNetBiosName",
+	"onpremises_sam_account_name":    "onPremisesSamAccountName",
+	"onpremises_security_identifier": "onPremisesSecurityIdentifier",
+	"onpremises_sync_enabled":        "onPremisesSyncEnabled",
+	"preferred_language":             "preferredLanguage",
+	"provisioning_options":           "resourceProvisioningOptions",
+	"proxy_addresses":                "proxyAddresses",
+	"renewed_date_time":              "renewedDateTime",
+	"security_enabled":               "securityEnabled",
+	"security_identifier":            "securityIdentifier",
+	"theme":                          "theme",
+	"types":                          "groupTypes",
+	"visibility":                     "visibility",
+}
+
+// groupResourceOptions holds the behaviors and provisioning options for Microsoft 365 groups, which can only be set when
+// creating a group. These are modelled separately since they are not supported by msgraph.Group.
+type groupResourceOptions struct {
+	ResourceBehaviorOptions     []string `json:"resourceBehaviorOptions,omitempty"`
+	ResourceProvisioningOptions []string `json:"resourceProvisioningOptions,omitempty"`
+}
+
+// groupReadOnlyProperties holds read-only properties of a group which are not supported by msgraph.Group
+type groupReadOnlyProperties struct {
+	CreatedByAppId *string `json:"createdByAppId,omitempty"`
+}
+
+// groupForResource is a group including the properties which are not supported by msgraph.Group
+type groupForResource struct {
+	msgraph.Group
+	groupResourceOptions
+	groupReadOnlyProperties
+}
+
+// groupGetReadOnlyProperties retrieves the read-only properties of a group which are not supported by msgraph.Group
+func groupGetReadOnlyProperties(ctx context.Context, client *msgraph.GroupsClient, id string) (*groupReadOnlyProperties, int, error) {
+	var properties groupReadOnlyProperties
+	status, err := common.GetSelected(ctx, client.BaseClient, fmt.Sprintf("/groups/%s", id), []string{"createdByAppId"}, &properties)
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.%v", err)
+	}
+
+	return &properties, status, nil
+}
+
+// groupFlattenDateTime formats an optional timestamp of a group as an RFC3339 string, which is empty when not set
+func groupFlattenDateTime(v *time.Time) string {
+	if v == nil {
+		return ""
+	}
+	return v.UTC().Format(time.RFC3339)
+}
+
+// groupClassificationError annotates a rejected request which set a classification, since the API does not explain
+// that classifications must be defined by the tenant before they can be used
+func groupClassificationError(err error, status int, classification string) error {
+	if err == nil || status != http.StatusBadRequest || classification == "" {
+		return err
+	}
+
+	return fmt.Errorf("%v\n\nThe classification %q may not be defined for this tenant. Classifications can only be set when the tenant defines a ClassificationList in its Group.Unified directory setting, and must be one of the values in that list.", err, classification)
+}
+
+// groupOnPremisesSyncedAttributes are the attributes of a group which are managed by the on-premises directory when the
+// group is synchronized using Azure AD Connect, and so cannot be changed in Azure AD
+var groupOnPremisesSyncedAttributes = []string{"description", "display_name", "mail_enabled", "members", "security_enabled"}
+
+// groupOnPremisesSyncChanges returns those attributes which are managed by the on-premises directory for a synchronized
+// group, and which hasChange reports as changed
+func groupOnPremisesSyncChanges(hasChange func(string) bool) []string {
+	changed := make([]string, 0)
+	for _, attr := range groupOnPremisesSyncedAttributes {
+		if hasChange(attr) {
+			changed = append(changed, attr)
+		}
+	}
+	return changed
+}
+
+// groupOnPremisesSyncMessage explains that the changed attributes cannot be updated, because the group is synchronized
+// from an on-premises directory
+func groupOnPremisesSyncMessage(changed []string) string {
+	attrs := make([]string, 0, len(changed))
+	for _, attr := range changed {
+		attrs = append(attrs, fmt.Sprintf("`%s`", attr))
+	}
+	return fmt.Sprintf("synchronized from an on-premises directory (`onpremises_sync_enabled` is true), so the following attributes are managed by on-premises Active Directory and cannot be changed in Azure AD: %s. Make these changes in the on-premises directory instead", strings.Join(attrs, ", "))
+}
+
+// groupOnPremisesSyncWriteError annotates a failed update to a group when the group turns out to be synchronized from an
+// on-premises directory, since the API rejects such updates with errors which do not mention synchronization. Other
+// errors, and errors for updates which changed no synchronized attributes or for a group which was not found, are
+// returned unchanged.
+func groupOnPremisesSyncWriteError(ctx context.Context, client *msgraph.GroupsClient, id string, status int, changed []string, err error) error {
+	if err == nil || status == http.StatusNotFound || len(changed) == 0 {
+		return err
+	}
+
+	group, _, getErr := client.Get(ctx, id)
+	if getErr != nil || group == nil || group.OnPremisesSyncEnabled == nil || !*group.OnPremisesSyncEnabled {
+		return err
+	}
+
+	return fmt.Errorf("%v\n\nThe group is %s.", err, groupOnPremisesSyncMessage(changed))
+}
+
+// groupCreate creates a group, including any behaviors and provisioning options, which cannot be set afterwards
+func groupCreate(ctx context.Context, client *msgraph.GroupsClient, group msgraph.Group, options groupResourceOptions) (*msgraph.Group, int, error) {
+	body, err := json.Marshal(groupForResource{Group: group, groupResourceOptions: options})
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	resp, status, _, err := client.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/groups",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var newGroup msgraph.Group
+	if err := json.Unmarshal(respBody, &newGroup); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &newGroup, status, nil
+}
+
+// groupGetForResource retrieves a group, selecting only the properties which are read by the azuread_group resource
+func groupGetForResource(ctx context.Context, client *msgraph.GroupsClient, id string) (*groupForResource, int, error) {
+	properties := make([]string, 0, len(groupResourceSelectProperties))
+	for _, property := range groupResourceSelectProperties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	var group groupForResource
+	status, err := common.GetSelected(ctx, client.BaseClient, fmt.Sprintf("/groups/%s", id), properties, &group)
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.%v", err)
+	}
+
+	return &group, status, nil
+}
+
+// groupUnifiedOnlyWriteError annotates a rejected update which set attributes that are only supported for Microsoft 365
+// groups, when the group turns out not to be a unified group, since the API rejects such updates with a generic bad
+// request error. Other errors are returned unchanged.
+func groupUnifiedOnlyWriteError(ctx context.Context, client *msgraph.GroupsClient, id string, status int, changed []string, err error) error {
+	if err == nil || status != http.StatusBadRequest || len(changed) == 0 {
+		return err
+	}
+
+	group, _, getErr := client.Get(ctx, id)
+	if getErr != nil || group == nil {
+		return err
+	}
+	for _, t := range group.GroupTypes {
+		if t == msgraph.GroupTypeUnified {
+			return err
+		}
+	}
+
+	attrs := make([]string, 0, len(changed))
+	for _, attr := range changed {
+		attrs = append(attrs, fmt.Sprintf("`%s`", attr))
+	}
+	return fmt.Errorf("%v\n\nThe group is not a Microsoft 365 (unified) group, so the following attributes cannot be set: %s. Only groups with `types` containing %q support these attributes.", err, strings.Join(attrs, ", "), msgraph.GroupTypeUnified)
+}
+
+// groupMailSettings holds the settings for Microsoft 365 groups which can only be retrieved by explicitly selecting them,
+// and which can only be updated in a request on their own. These are modelled separately from msgraph.Group, which
+// does not correctly type allowExternalSenders.
+type groupMailSettings struct {
+	AllowExternalSenders    *bool `json:"allowExternalSenders,omitempty"`
+	AutoSubscribeNewMembers *bool `json:"autoSubscribeNewMembers,omitempty"`
+
+	// IsSubscribedByMail is read-only and is never set when updating settings
+	IsSubscribedByMail *bool `json:"isSubscribedByMail,omitempty"`
+}
+
+func groupGetMailSettings(ctx context.Context, client *msgraph.GroupsClient, id string) (*groupMailSettings, int, error) {
+	resp, status, _, err := client.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/groups/%s", id),
+			Params:      url.Values{"$select": []string{"allowExternalSenders,autoSubscribeNewMembers,isSubscribedByMail"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var settings groupMailSettings
+	if err := json.Unmarshal(respBody, &settings); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	return &settings, status, nil
+}
+
+func groupUpdateMailSettings(ctx context.Context, client *msgraph.GroupsClient, id string, settings groupMailSettings) (int, error) {
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+
+	_, status, _, err := client.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/groups/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("GroupsClient.BaseClient.Patch(): %v", err)
+	}
+
+	return status, nil
+}
+
+// groupWaitForProvisioning waits until a newly created group can be referenced by other resources, by checking that
+// its members can be listed and that it is returned by a directoryObjects getByIds request. These are the endpoints
+// used when adding a group as a member or assigning roles to it, which can lag behind a successful Get. The wait is
+// bounded by both the specified duration and the deadline of the context.
+func groupWaitForProvisioning(ctx context.Context, client *msgraph.GroupsClient, id string, wait time.Duration) error {
+	timeout := wait
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+
+	_, err := (&resource.StateChangeConf{
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Available"},
+		Timeout:                   timeout,
+		MinTimeout:                1 * time.Second,
+		ContinuousTargetOccurence: 2,
+		Refresh: func() (interface{}, string, error) {
+			if _, status, err := client.ListMembers(ctx, id); err != nil {
+				if status == http.StatusNotFound {
+					log.Printf("[DEBUG] Provisioning probe for group with object ID %q: members not yet available", id)
+					return nil, "Waiting", nil
+				}
+				return nil, "Error", fmt.Errorf("listing members for group with object ID %q: %+v", id, err)
+			}
+
+			objects, err := helpers.DirectoryObjectsGetByIds(ctx, client.BaseClient, []string{id})
+			if err != nil {
+				return nil, "Error", fmt.Errorf("retrieving directory object for group with object ID %q: %+v", id, err)
+			}
+			if len(objects) == 0 {
+				log.Printf("[DEBUG] Provisioning probe for group with object ID %q: not yet returned by getByIds", id)
+				return nil, "Waiting", nil
+			}
+
+			log.Printf("[DEBUG] Provisioning probe for group with object ID %q: available", id)
+			return objects, "Available", nil
+		},
+	}).WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for group with object ID %q to become available: %+v", id, err)
+	}
+
+	return nil
+}
+
+// groupRemoveFromParents removes a group from every group of which it is a direct member. Removal is attempted for all
+// parent groups, and any failures are reported together.
+func groupRemoveFromParents(ctx context.Context, client *msgraph.GroupsClient, id string) error {
+	parents, _, err := helpers.MemberOfGroups(ctx, client.BaseClient, fmt.Sprintf("/groups/%s", id))
+	if err != nil {
+		return fmt.Errorf("listing parent groups: %v", err)
+	}
+
+	failures := make([]string, 0)
+	for _, parent := range parents {
+		log.Printf("[DEBUG] Removing group with object ID %q from parent group %s", id, groupParentName(parent))
+		tf.LockByName(groupResourceName, parent.ID)
+		_, err := client.RemoveMembers(ctx, parent.ID, &[]string{id})
+		tf.UnlockByName(groupResourceName, parent.ID)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", groupParentName(parent), err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("removing group from %d of %d parent groups:\n%s", len(failures), len(parents), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// groupDeleteParentsError annotates a failed deletion with the names of any groups which still have the group as a
+// member, since these references are a common cause of failure
+func groupDeleteParentsError(ctx context.Context, client *msgraph.GroupsClient, id string, err error) error {
+	parents, _, lookupErr := helpers.MemberOfGroups(ctx, client.BaseClient, fmt.Sprintf("/groups/%s", id))
+	if lookupErr != nil {
+		log.Printf("[DEBUG] Unable to list parent groups when explaining a failed deletion: %v", lookupErr)
+		return err
+	}
+	if len(parents) == 0 {
+		return err
+	}
+
+	names := make([]string, 0, len(parents))
+	for _, parent := range parents {
+		names = append(names, groupParentName(parent))
+	}
+	return fmt.Errorf("%v\n\nThe group is still a member of the following groups: %s. Remove it from these groups, or set `force_destroy_nested_references` to remove it automatically before deletion.", err, strings.Join(names, ", "))
+}
+
+func groupParentName(parent helpers.DirectoryObjectSummary) string {
+	return fmt.Sprintf("%q (%s)", parent.DisplayName, parent.ID)
+}
+
+// groupPermanentDeletionPollInterval is the interval between checks when waiting for a group to be permanently deleted
+var groupPermanentDeletionPollInterval = 2 * time.Second
+
+func groupIsUnified(group *msgraph.Group) bool {
+	if group == nil {
+		return false
+	}
+	for _, t := range group.GroupTypes {
+		if t == msgraph.GroupTypeUnified {
+			return true
+		}
+	}
+	return false
+}
+
+// groupWaitForPermanentDeletion permanently deletes a soft-deleted Microsoft 365 group, then waits until its mail
+// nickname is no longer reserved by either an active or a deleted group, so that a new group can be created with the
+// same mail nickname. Soft-deleted groups can take a short while to appear in the deleted items, so the permanent
+// deletion is retried until it succeeds. The wait is bounded by the deadline of the context.
+func groupWaitForPermanentDeletion(ctx context.Context, client *msgraph.GroupsClient, id, mailNickname string) error {
+	timeout := 5 * time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	purged := false
+	filter := helpers.ODataEq("mailNickname", mailNickname)
+
+	_, err := (&resource.StateChangeConf{
+		Pending:                   []string{"Waiting"},
+		Target:                    []string{"Released"},
+		Timeout:                   timeout,
+		MinTimeout:                groupPermanentDeletionPollInterval,
+		PollInterval:              groupPermanentDeletionPollInterval,
+		ContinuousTargetOccurence: 2,
+		Refresh: func() (interface{}, string, error) {
+			if !purged {
+				status, err := client.DeletePermanently(ctx, id)
+				if err != nil {
+					if status == http.StatusNotFound {
+						log.Printf("[DEBUG] Deleted group with object ID %q not yet found in deleted items", id)
+						return nil, "Waiting", nil
+					}
+					return nil, "Error", fmt.Errorf("permanently deleting group: %+v", err)
+				}
+				log.Printf("[DEBUG] Permanently deleted group with object ID %q", id)
+				purged = true
+			}
+
+			if mailNickname == "" {
+				return id, "Released", nil
+			}
+
+			active, _, err := client.List(ctx, filter)
+			if err != nil {
+				return nil, "Error", fmt.Errorf("listing groups for filter %q: %+v", filter, err)
+			}
+			if active != nil && len(*active) > 0 {
+				log.Printf("[DEBUG] Mail nickname %q is still reserved by an active group", mailNickname)
+				return nil, "Waiting", nil
+			}
+
+			deleted, _, err := client.ListDeleted(ctx, filter)
+			if err != nil {
+				return nil, "Error", fmt.Errorf("listing deleted groups for filter %q: %+v", filter, err)
+			}
+			if deleted != nil && len(*deleted) > 0 {
+				log.Printf("[DEBUG] Mail nickname %q is still reserved by a deleted group", mailNickname)
+				return nil, "Waiting", nil
+			}
+
+			return id, "Released", nil
+		},
+	}).WaitForStateContext(ctx)
+	if err != nil {
+		if !purged {
+			return fmt.Errorf("waiting for the deleted group to be permanently deleted: %+v", err)
+		}
+		return fmt.Errorf("waiting for mail nickname %q to be released: %+v", mailNickname, err)
+	}
+
+	return nil
+}
+
+// groupAddMembers adds the specified members to a group, split into requests of no more than groupMembersAddBatchSize
+// members, since Microsoft Graph rejects requests which add more member references than this
+func groupAddMembers(ctx context.Context, client *msgraph.GroupsClient, id string, memberIds []string) (int, error) {
+	var status int
+	for start := 0; start < len(memberIds); start += groupMembersAddBatchSize {
+		end := start + groupMembersAddBatchSize
+		if end > len(memberIds) {
+			end = len(memberIds)
+		}
+
+		group := msgraph.Group{ID: &id}
+		for _, m := range memberIds[start:end] {
+			group.AppendMember(client.BaseClient.Endpoint, client.BaseClient.ApiVersion, m)
+		}
+
+		var err error
+		if status, err = client.AddMembers(ctx, &group); err != nil {
+			return status, err
+		}
+	}
+	return status, nil
+}
+
+// groupValidateOwnersCount checks that no more owners are specified than a group can have, which the API would
+// otherwise only reject part-way through adding them
+func groupValidateOwnersCount(owners []string) error {
+	if len(owners) > groupOwnersLimit {
+		return fmt.Errorf("a group can have at most %d owners, but %d were specified", groupOwnersLimit, len(owners))
+	}
+	return nil
+}
+
+// groupValidateRoleAssignableMembers checks that the specified members of a role-assignable group are all users or
+// service principals, since such groups cannot have other groups (or any other object type) as members
+func groupValidateRoleAssignableMembers(members []helpers.DirectoryObjectSummary) error {
+	invalid := make([]string, 0)
+	for _, m := range members {
+		if !strings.EqualFold(m.Type, helpers.DirectoryObjectTypeUser) && !strings.EqualFold(m.Type, helpers.DirectoryObjectTypeServicePrincipal) {
+			invalid = append(invalid, fmt.Sprintf("%s %s (%q)", m.Type, m.ID, m.DisplayName))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("only users and service principals can be members of a group which is assignable to directory roles, but the following members are not: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// groupListMembersWithTypes returns the members of a group along with their object types, sorted by object ID for
+// stable ordering in state. When transitive is true, members of nested groups are also returned, which includes the
+// nested groups themselves.
+func groupListMembersWithTypes(ctx context.Context, client *msgraph.GroupsClient, id string, transitive bool) ([]helpers.DirectoryObjectSummary, int, error) {
+	relationship := "members"
+	if transitive {
+		relationship = "transitiveMembers"
+	}
+
+	resp, status, _, err := client.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/groups/%s/%s", id, relationship),
+			Params:      url.Values{"$select": []string{"id"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("GroupsClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+
+	var data struct {
+		Members []struct {
+			ID   *string `json:"id"`
+			Type string  `json:"@odata.type"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+
+	result := make([]helpers.DirectoryObjectSummary, 0, len(data.Members))
+	for _, m := range data.Members {
+		if m.ID == nil {
+			continue
+		}
+		result = append(result, helpers.DirectoryObjectSummary{
+			ID:   *m.ID,
+			Type: strings.TrimPrefix(m.Type, "#microsoft.graph."),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, status, nil
+}
+
+func groupFlattenMemberIds(members []helpers.DirectoryObjectSummary) []string {
+	result := make([]string, 0, len(members))
+	for _, m := range members {
+		result = append(result, m.ID)
+	}
+	return result
+}
+
+func groupFlattenMembersWithTypes(members []helpers.DirectoryObjectSummary) []interface{} {
+	result := make([]interface{}, 0, len(members))
+	for _, m := range members {
+		result = append(result, map[string]interface{}{
+			"object_id": m.ID,
+			"type":      m.Type,
+		})
+	}
+	return result
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
 )
@@ -37,7 +38,7 @@ func groupsDataSource() *schema.Resource {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"display_names", "object_ids"},
+				ExactlyOneOf: []string{"display_names", "object_ids", "return_all"},
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validate.UUID,
@@ -49,12 +50,37 @@ func groupsDataSource() *schema.Resource {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"display_names", "object_ids"},
+				ExactlyOneOf: []string{"display_names", "object_ids", "return_all"},
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validate.NoEmptyStrings,
 				},
 			},
+
+			"return_all": {
+				Description:  "Fetch all groups with no filter and return all that were found. The data source will still fail if no groups are found",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: []string{"display_names", "object_ids", "return_all"},
+			},
+
+			"filter": {
+				Description:      "An OData filter expression to narrow the groups returned when `return_all` is true, e.g. `startswith(displayName, 'eng')`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"return_all"},
+				ValidateDiagFunc: validate.ODataFilter,
+			},
+
+			"max_results": tf.DataSourceMaxResultsSchema("groups", "return_all"),
+
+			"ignore_missing": {
+				Description:   "Ignore missing groups and return groups that were found",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"return_all"},
+			},
 		},
 	}
 }
@@ -64,27 +90,55 @@ func groupsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	var groups []msgraph.Group
 	var expectedCount int
+	ignoreMissing := d.Get("ignore_missing").(bool)
+	returnAll := d.Get("return_all").(bool)
 
 	var displayNames []interface{}
 	if v, ok := d.GetOk("display_names"); ok {
 		displayNames = v.([]interface{})
 	}
 
-	if len(displayNames) > 0 {
+	var diags diag.Diagnostics
+	if returnAll {
+		filter := d.Get("filter").(string)
+		limit := tf.DataSourceListLimit(d)
+
+		result, err := groupListAll(ctx, client, filter, limit)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not retrieve groups")
+		}
+		if len(result) == 0 && filter == "" {
+			return tf.ErrorDiagPathF(nil, "return_all", "No groups found")
+		}
+		if len(result) > limit {
+			if diags = tf.DataSourceListExceeded(d, "groups", limit); diags.HasError() {
+				return diags
+			}
+			result = result[:limit]
+		}
+
+		groups = result
+	} else if len(displayNames) > 0 {
 		expectedCount = len(displayNames)
 		for _, v := range displayNames {
 			displayName := v.(string)
-			filter := fmt.Sprintf("displayName eq '%s'", displayName)
+			filter := helpers.ODataEq("displayName", displayName)
 			result, _, err := client.List(ctx, filter)
 			if err != nil {
-				return tf.ErrorDiagPathF(err, "display_names", "No group found with display name: %q", displayName)
+				return tf.ErrorDiagPathF(err, "display_names", "Finding group with display name: %q", displayName)
+			}
+			if result == nil {
+				return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
 			}
 
 			count := len(*result)
 			if count > 1 {
-				return tf.ErrorDiagPathF(err, "display_names", "More than one group found with display name: %q", displayName)
+				return tf.ErrorDiagPathF(nil, "display_names", "More than one group found with display name: %q", displayName)
 			} else if count == 0 {
-				return tf.ErrorDiagPathF(err, "display_names", "No group found with display name: %q", displayName)
+				if ignoreMissing {
+					continue
+				}
+				return tf.ErrorDiagPathF(nil, "display_names", "No group found with display name: %q", displayName)
 			}
 
 			groups = append(groups, (*result)[0])
@@ -96,16 +150,19 @@ func groupsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 			group, status, err := client.Get(ctx, objectId)
 			if err != nil {
 				if status == http.StatusNotFound {
-					return tf.ErrorDiagPathF(err, "object_id", "No group found with object ID: %q", objectId)
+					if ignoreMissing {
+						continue
+					}
+					return tf.ErrorDiagPathF(nil, "object_ids", "No group found with object ID: %q", objectId)
 				}
-				return tf.ErrorDiagPathF(err, "object_id", "Retrieving group with object ID: %q", objectId)
+				return tf.ErrorDiagPathF(err, "object_ids", "Retrieving group with object ID: %q", objectId)
 			}
 
 			groups = append(groups, *group)
 		}
 	}
 
-	if len(groups) != expectedCount {
+	if !returnAll && !ignoreMissing && len(groups) != expectedCount {
 		return tf.ErrorDiagF(fmt.Errorf("Expected: %d, Actual: %d", expectedCount, len(groups)), "Unexpected number of groups returned")
 	}
 
@@ -124,8 +181,8 @@ func groupsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	h := sha1.New()
-	if _, err := h.Write([]byte(strings.Join(newDisplayNames, "-"))); err != nil {
-		return tf.ErrorDiagF(err, "Unable to compute hash for names")
+	if _, err := h.Write([]byte(d.Get("filter").(string) + "#" + strings.Join(newObjectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for object IDs")
 	}
 
 	d.SetId("groups#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
@@ -133,5 +190,5 @@ func groupsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inte
 	tf.Set(d, "object_ids", newObjectIds)
 	tf.Set(d, "display_names", newDisplayNames)
 
-	return nil
+	return diags
 }
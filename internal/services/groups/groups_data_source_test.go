@@ -2,6 +2,7 @@ package groups_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -54,14 +55,55 @@ func TestAccGroupsDataSource_noNames(t *testing.T) {
 	})
 }
 
+func TestAccGroupsDataSource_ignoreMissing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_groups", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: GroupsDataSource{}.ignoreMissing(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_names.#").HasValue("2"),
+				check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccGroupsDataSource_missing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_groups", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config:      GroupsDataSource{}.missing(data),
+			ExpectError: regexp.MustCompile("No group found with display name"),
+		},
+	})
+}
+
+func TestAccGroupsDataSource_returnAll(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_groups", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: GroupsDataSource{}.returnAll(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("display_names.#").Exists(),
+				check.That(data.ResourceName).Key("object_ids.#").Exists(),
+			),
+		},
+	})
+}
+
 func (GroupsDataSource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 resource "azuread_group" "testA" {
-  name = "acctestGroupA-%[1]d"
+  display_name     = "acctestGroupA-%[1]d"
+  security_enabled = true
 }
 
 resource "azuread_group" "testB" {
-  name = "acctestGroupB-%[1]d"
+  display_name     = "acctestGroupB-%[1]d"
+  security_enabled = true
 }
 `, data.RandomInteger)
 }
@@ -71,7 +113,7 @@ func (GroupsDataSource) byDisplayNames(data acceptance.TestData) string {
 %[1]s
 
 data "azuread_groups" "test" {
-  display_names = [azuread_group.testA.name, azuread_group.testB.name]
+  display_names = [azuread_group.testA.display_name, azuread_group.testB.display_name]
 }
 `, GroupsDataSource{}.template(data))
 }
@@ -93,3 +135,41 @@ data "azuread_groups" "test" {
 }
 `
 }
+
+func (GroupsDataSource) ignoreMissing(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_groups" "test" {
+  ignore_missing = true
+
+  display_names = [
+    azuread_group.testA.display_name,
+    "acctestGroupNonExistent-%[2]d",
+    azuread_group.testB.display_name,
+  ]
+}
+`, GroupsDataSource{}.template(data), data.RandomInteger)
+}
+
+func (GroupsDataSource) missing(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_groups" "test" {
+  display_names = [azuread_group.testA.display_name, "acctestGroupNonExistent-%[2]d"]
+}
+`, GroupsDataSource{}.template(data), data.RandomInteger)
+}
+
+func (GroupsDataSource) returnAll(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_groups" "test" {
+  return_all = true
+
+  depends_on = [azuread_group.testA, azuread_group.testB]
+}
+`, GroupsDataSource{}.template(data))
+}
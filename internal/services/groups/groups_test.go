@@ -0,0 +1,1662 @@
+package groups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/manicminer/hamilton/auth"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	applicationsClient "github.com/hashicorp/terraform-provider-azuread/internal/services/applications/client"
+	groupsClient "github.com/hashicorp/terraform-provider-azuread/internal/services/groups/client"
+	serviceprincipalsClient "github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestGroupFindByName(t *testing.T) {
+	const groups = `{"value":[{"id":"11111111-1111-1111-1111-111111111111","displayName":"acctest"},{"id":"22222222-2222-2222-2222-222222222222","displayName":"ACCTEST"}]}`
+
+	cases := []struct {
+		name           string
+		countStatus    int
+		count          string
+		expectAdvanced bool
+		expectRegular  bool
+	}{
+		{
+			name:           "counted",
+			countStatus:    http.StatusOK,
+			count:          "2",
+			expectAdvanced: true,
+		},
+		{
+			// Counts are eventually consistent, so a recently created group may not yet be counted
+			name:          "not counted",
+			countStatus:   http.StatusOK,
+			count:         "0",
+			expectRegular: true,
+		},
+		{
+			name:          "advanced query unsupported",
+			countStatus:   http.StatusBadRequest,
+			expectRegular: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			advanced, regular := false, false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if expected := "displayName eq 'acctest'"; r.URL.Query().Get("$filter") != expected {
+					t.Errorf("expected filter %q, got %q", expected, r.URL.Query().Get("$filter"))
+				}
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/$count"):
+					w.WriteHeader(tc.countStatus)
+					if tc.countStatus == http.StatusBadRequest {
+						fmt.Fprint(w, `{"error":{"code":"Request_UnsupportedQuery","message":"Unsupported query."}}`)
+						return
+					}
+					fmt.Fprint(w, tc.count)
+				case r.Header.Get("ConsistencyLevel") == "eventual":
+					advanced = true
+					if expected := tc.count; r.URL.Query().Get("$top") != expected {
+						t.Errorf("expected $top to be the count %q, got %q", expected, r.URL.Query().Get("$top"))
+					}
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, groups)
+				default:
+					regular = true
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, groups)
+				}
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			result, err := groupFindByName(context.Background(), client, "acctest")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(*result) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(*result))
+			}
+			if advanced != tc.expectAdvanced {
+				t.Errorf("expected advanced query: %t, got: %t", tc.expectAdvanced, advanced)
+			}
+			if regular != tc.expectRegular {
+				t.Errorf("expected regular query: %t, got: %t", tc.expectRegular, regular)
+			}
+		})
+	}
+}
+
+func TestGroupFindByOnPremisesProperty(t *testing.T) {
+	cases := []struct {
+		name            string
+		advancedStatus  int
+		expectAdvanced  bool
+		expectFallback  bool
+		expectResults   int
+		expectErrorText string
+	}{
+		{
+			name:           "advanced query",
+			advancedStatus: http.StatusOK,
+			expectAdvanced: true,
+			expectResults:  1,
+		},
+		{
+			name:           "advanced query unsupported",
+			advancedStatus: http.StatusBadRequest,
+			expectAdvanced: true,
+			expectFallback: true,
+			expectResults:  1,
+		},
+		{
+			name:            "other error",
+			advancedStatus:  http.StatusForbidden,
+			expectAdvanced:  true,
+			expectErrorText: "unable to list Groups",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			advanced, fallback := false, false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if expected := "onPremisesSamAccountName eq 'o''brien' and securityEnabled eq true"; r.URL.Query().Get("$filter") != expected {
+					t.Errorf("expected filter %q, got %q", expected, r.URL.Query().Get("$filter"))
+				}
+				if r.Header.Get("ConsistencyLevel") == "eventual" {
+					advanced = true
+					switch tc.advancedStatus {
+					case http.StatusBadRequest:
+						w.WriteHeader(tc.advancedStatus)
+						fmt.Fprint(w, `{"error":{"code":"Request_UnsupportedQuery","message":"Unsupported query."}}`)
+						return
+					case http.StatusForbidden:
+						w.WriteHeader(tc.advancedStatus)
+						fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+						return
+					}
+				} else {
+					fallback = true
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{"value":[{"id":"11111111-1111-1111-1111-111111111111","onPremisesSamAccountName":"o'brien"}]}`)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			result, _, err := groupFindByOnPremisesProperty(context.Background(), client, "onPremisesSamAccountName", "o'brien", "securityEnabled eq true")
+			if tc.expectErrorText != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectErrorText) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectErrorText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if advanced != tc.expectAdvanced || fallback != tc.expectFallback {
+				t.Fatalf("expected advanced/fallback to be %t/%t, got %t/%t", tc.expectAdvanced, tc.expectFallback, advanced, fallback)
+			}
+			if len(*result) != tc.expectResults {
+				t.Fatalf("expected %d results, got %d", tc.expectResults, len(*result))
+			}
+		})
+	}
+}
+
+func TestGroupListAll(t *testing.T) {
+	const pages = 3
+
+	cases := []struct {
+		name                string
+		filter              string
+		limit               int
+		advancedUnsupported bool
+		expectedTop         int
+		expectedRequests    int
+		expectedGroups      int
+	}{
+		{
+			name:             "all groups",
+			expectedTop:      groupListPageSize,
+			expectedRequests: pages,
+			expectedGroups:   pages * 2,
+		},
+		{
+			name:             "filtered",
+			filter:           "startswith(displayName, 'group-')",
+			expectedTop:      groupListPageSize,
+			expectedRequests: pages,
+			expectedGroups:   pages * 2,
+		},
+		{
+			name:             "limited",
+			limit:            3,
+			expectedTop:      4,
+			expectedRequests: 2,
+			expectedGroups:   4,
+		},
+		{
+			name:                "limited regular query",
+			limit:               3,
+			advancedUnsupported: true,
+			expectedTop:         4,
+			expectedRequests:    2,
+			expectedGroups:      4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var server *httptest.Server
+			requests := 0
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				advanced := r.Header.Get("ConsistencyLevel") == "eventual"
+				if advanced != (r.URL.Query().Get("$count") == "true") {
+					t.Errorf("expected $count only for advanced queries")
+				}
+				if tc.advancedUnsupported && advanced {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":{"code":"Request_UnsupportedQuery","message":"Unsupported query."}}`)
+					return
+				}
+				if !tc.advancedUnsupported && !advanced {
+					t.Errorf("expected an advanced query")
+				}
+
+				requests++
+				if top := r.URL.Query().Get("$top"); top != strconv.Itoa(tc.expectedTop) {
+					t.Errorf("expected $top=%d, got %q", tc.expectedTop, top)
+				}
+				if filter := r.URL.Query().Get("$filter"); filter != tc.filter {
+					t.Errorf("expected $filter=%q, got %q", tc.filter, filter)
+				}
+
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				nextLink := ""
+				if page < pages-1 {
+					query := r.URL.Query()
+					query.Set("page", strconv.Itoa(page+1))
+					nextLink = fmt.Sprintf(`,"@odata.nextLink":%q`, server.URL+r.URL.Path+"?"+query.Encode())
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprintf(w, `{"value":[{"id":"%[1]d1111111-1111-1111-1111-111111111111","displayName":"group-%[1]d-a"},{"id":"%[1]d2222222-2222-2222-2222-222222222222","displayName":"group-%[1]d-b"}]%[2]s}`, page, nextLink)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			groups, err := groupListAll(context.Background(), client, tc.filter, tc.limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requests != tc.expectedRequests {
+				t.Fatalf("expected %d requests, got %d", tc.expectedRequests, requests)
+			}
+			if len(groups) != tc.expectedGroups {
+				t.Fatalf("expected %d groups, got %d", tc.expectedGroups, len(groups))
+			}
+		})
+	}
+}
+
+func TestGroupDataSourceFilter(t *testing.T) {
+	cases := []struct {
+		property        string
+		value           string
+		mailEnabled     *bool
+		securityEnabled *bool
+		expected        string
+	}{
+		{
+			property: "mailNickname",
+			value:    "engineering",
+			expected: "mailNickname eq 'engineering'",
+		},
+		{
+			property: "mailNickname",
+			value:    "o'brien's-team",
+			expected: "mailNickname eq 'o''brien''s-team'",
+		},
+		{
+			property:        "displayName",
+			value:           "Engineering",
+			mailEnabled:     utils.Bool(true),
+			securityEnabled: utils.Bool(false),
+			expected:        "displayName eq 'Engineering' and mailEnabled eq true and securityEnabled eq false",
+		},
+	}
+
+	for _, tc := range cases {
+		if actual := groupDataSourceFilter(tc.property, tc.value, tc.mailEnabled, tc.securityEnabled); actual != tc.expected {
+			t.Errorf("expected filter %q, got %q", tc.expected, actual)
+		}
+	}
+}
+
+func TestGroupsMatchingForAdoption(t *testing.T) {
+	newGroup := func(id string, mailEnabled, securityEnabled bool, groupTypes ...msgraph.GroupType) msgraph.Group {
+		return msgraph.Group{
+			ID:              utils.String(id),
+			DisplayName:     utils.String("acctest"),
+			GroupTypes:      groupTypes,
+			MailEnabled:     utils.Bool(mailEnabled),
+			SecurityEnabled: utils.Bool(securityEnabled),
+		}
+	}
+
+	roleAssignable := newGroup("role-assignable", false, true)
+	roleAssignable.IsAssignableToRole = utils.Bool(true)
+
+	groups := []msgraph.Group{
+		newGroup("security", false, true),
+		newGroup("unified", true, false, msgraph.GroupTypeUnified),
+		newGroup("unified-security", true, true, msgraph.GroupTypeUnified),
+		roleAssignable,
+		{ID: utils.String("nil-flags"), DisplayName: utils.String("acctest")},
+	}
+
+	cases := []struct {
+		name             string
+		mailEnabled      bool
+		securityEnabled  bool
+		assignableToRole bool
+		groupTypes       []msgraph.GroupType
+		expected         []string
+	}{
+		{
+			name:            "security group",
+			securityEnabled: true,
+			expected:        []string{"security"},
+		},
+		{
+			name:        "unified group",
+			mailEnabled: true,
+			groupTypes:  []msgraph.GroupType{msgraph.GroupTypeUnified},
+			expected:    []string{"unified"},
+		},
+		{
+			name:            "unified security group",
+			mailEnabled:     true,
+			securityEnabled: true,
+			groupTypes:      []msgraph.GroupType{msgraph.GroupTypeUnified},
+			expected:        []string{"unified-security"},
+		},
+		{
+			name:            "mismatched group types",
+			mailEnabled:     true,
+			securityEnabled: true,
+			expected:        []string{},
+		},
+		{
+			name:             "role-assignable security group",
+			securityEnabled:  true,
+			assignableToRole: true,
+			expected:         []string{"role-assignable"},
+		},
+		{
+			name:     "no flags",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := groupsMatchingForAdoption(groups, tc.mailEnabled, tc.securityEnabled, tc.assignableToRole, tc.groupTypes)
+			if len(result) != len(tc.expected) {
+				t.Fatalf("expected %d matching groups, got %d", len(tc.expected), len(result))
+			}
+			for i, g := range result {
+				if *g.ID != tc.expected[i] {
+					t.Fatalf("expected group %q at index %d, got %q", tc.expected[i], i, *g.ID)
+				}
+			}
+		})
+	}
+
+	duplicates := append(groups, newGroup("security-duplicate", false, true))
+	if result := groupsMatchingForAdoption(duplicates, false, true, false, nil); len(result) != 2 {
+		t.Fatalf("expected 2 matching groups, got %d", len(result))
+	}
+}
+
+func TestGroupResourceSelectProperties(t *testing.T) {
+	// Attributes which are not read from the group object, either because they are retrieved with separate requests or
+	// because they only exist in configuration
+	unselected := map[string]bool{
+		"accept_messages_only_from":       true,
+		"adopt_existing":                  true,
+		"adopted":                         true,
+		"adopted_destroy_behaviour":       true,
+		"allow_external_senders":          true,
+		"auto_subscribe_new_members":      true,
+		"force_destroy_nested_references": true,
+		"is_subscribed_by_mail":           true,
+		"members":                         true,
+		"members_hash":                    true,
+		"moderated_by":                    true,
+		"moderation_enabled":              true,
+		"owners":                          true,
+		"prevent_duplicate_names":         true,
+		"provisioning_wait":               true,
+		"reject_messages_from":            true,
+		"require_sender_authentication":   true,
+		"send_moderation_notifications":   true,
+		"wait_for_permanent_deletion":     true,
+	}
+
+	properties := make(map[string]bool)
+	for _, groupType := range []reflect.Type{reflect.TypeOf(msgraph.Group{}), reflect.TypeOf(groupResourceOptions{}), reflect.TypeOf(groupReadOnlyProperties{})} {
+		for i := 0; i < groupType.NumField(); i++ {
+			properties[strings.Split(groupType.Field(i).Tag.Get("json"), ",")[0]] = true
+		}
+	}
+
+	resourceSchema := groupResource().Schema
+	for attribute := range resourceSchema {
+		if _, ok := groupResourceSelectProperties[attribute]; !ok && !unselected[attribute] {
+			t.Errorf("attribute %q is not selected when reading groups, add it to groupResourceSelectProperties", attribute)
+		}
+	}
+	for attribute, property := range groupResourceSelectProperties {
+		if _, ok := resourceSchema[attribute]; !ok {
+			t.Errorf("attribute %q in groupResourceSelectProperties is not in the resource schema", attribute)
+		}
+		if !properties[property] {
+			t.Errorf("property %q for attribute %q is not a property of groupForResource", property, attribute)
+		}
+	}
+}
+
+func TestGroupMembersStableState(t *testing.T) {
+	const groupId = "00000000-0000-0000-0000-00000000abcd"
+	const memberCount, pageSize = 5000, 999
+
+	memberIds := make([]string, memberCount)
+	for i := range memberIds {
+		memberIds[i] = fmt.Sprintf("%08x-1111-1111-1111-111111111111", i)
+	}
+
+	// Each listing returns the members in a different order, split across pages
+	var server *httptest.Server
+	var shuffled []string
+	listings := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/beta/00000000-0000-0000-0000-000000000000/groups/%s/members", groupId) {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+			return
+		}
+
+		skip := r.URL.Query().Get("$skiptoken")
+		if skip == "" {
+			listings++
+			shuffled = append([]string{}, memberIds...)
+			rand.New(rand.NewSource(int64(listings))).Shuffle(len(shuffled), func(i, j int) {
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+		}
+		start, _ := strconv.Atoi(skip)
+		end := start + pageSize
+		if end > len(shuffled) {
+			end = len(shuffled)
+		}
+
+		page := map[string]interface{}{}
+		value := make([]map[string]string, 0, end-start)
+		for _, id := range shuffled[start:end] {
+			value = append(value, map[string]string{"@odata.type": "#microsoft.graph.user", "id": id})
+		}
+		page["value"] = value
+		if end < len(shuffled) {
+			page["@odata.nextLink"] = fmt.Sprintf("%s%s?$skiptoken=%d", server.URL, r.URL.Path, end)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Fatalf("encoding response: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	for name, resource := range map[string]*schema.Resource{"data source": groupDataSource(), "resource": groupResource()} {
+		t.Run(name, func(t *testing.T) {
+			var previousMembers *[]string
+			var previousState []byte
+
+			for i := 0; i < 2; i++ {
+				members, _, err := client.ListMembers(context.Background(), groupId)
+				if err != nil {
+					t.Fatalf("unexpected error listing members: %v", err)
+				}
+				if len(*members) != memberCount {
+					t.Fatalf("expected %d members, got %d", memberCount, len(*members))
+				}
+				if previousMembers != nil && reflect.DeepEqual(*previousMembers, *members) {
+					t.Fatalf("expected members to be returned in a different order for each listing")
+				}
+				previousMembers = members
+
+				d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+				d.SetId(groupId)
+				if diags := tf.Set(d, "members", tf.SortedStringSlice(members)); diags.HasError() {
+					t.Fatalf("unexpected error setting members: %v", diags)
+				}
+
+				state, err := json.Marshal(d.State().Attributes)
+				if err != nil {
+					t.Fatalf("marshalling state: %v", err)
+				}
+				if previousState != nil && !bytes.Equal(previousState, state) {
+					t.Fatalf("expected consecutive refreshes to produce identical state")
+				}
+				previousState = state
+			}
+		})
+	}
+}
+
+func TestGroupRemoveFromParents(t *testing.T) {
+	const (
+		groupId        = "11111111-1111-1111-1111-111111111111"
+		parentId       = "22222222-2222-2222-2222-222222222222"
+		failingId      = "33333333-3333-3333-3333-333333333333"
+		alreadyGoneId  = "44444444-4444-4444-4444-444444444444"
+		tenantIdPrefix = "/beta/00000000-0000-0000-0000-000000000000"
+	)
+
+	removed := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, tenantIdPrefix)
+		switch {
+		case path == fmt.Sprintf("/groups/%s/memberOf/microsoft.graph.group", groupId):
+			fmt.Fprintf(w, `{"value":[{"id":%q,"displayName":"parent"},{"id":%q,"displayName":"failing"},{"id":%q,"displayName":"already gone"}]}`, parentId, failingId, alreadyGoneId)
+		case r.Method == http.MethodGet && path == fmt.Sprintf("/groups/%s/members/%s/$ref", alreadyGoneId, groupId):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+		case r.Method == http.MethodGet && strings.HasSuffix(path, fmt.Sprintf("/members/%s/$ref", groupId)):
+			fmt.Fprintf(w, `{"id":%q}`, groupId)
+		case r.Method == http.MethodDelete && path == fmt.Sprintf("/groups/%s/members/%s/$ref", failingId, groupId):
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+		case r.Method == http.MethodDelete:
+			removed = append(removed, path)
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	err := groupRemoveFromParents(context.Background(), client, groupId)
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if !strings.Contains(err.Error(), "removing group from 1 of 3 parent groups") || !strings.Contains(err.Error(), fmt.Sprintf(`"failing" (%s)`, failingId)) {
+		t.Fatalf("expected error naming the failing parent group, got: %v", err)
+	}
+	if strings.Contains(err.Error(), parentId) || strings.Contains(err.Error(), alreadyGoneId) {
+		t.Fatalf("expected error to name only the failing parent group, got: %v", err)
+	}
+
+	expected := []string{fmt.Sprintf("/groups/%s/members/%s/$ref", parentId, groupId)}
+	if !reflect.DeepEqual(removed, expected) {
+		t.Fatalf("expected removals %v, got %v", expected, removed)
+	}
+
+	explained := groupDeleteParentsError(context.Background(), client, groupId, errors.New("GroupsClient.BaseClient.Delete(): unexpected status 400"))
+	if !strings.Contains(explained.Error(), "still a member of the following groups") || !strings.Contains(explained.Error(), fmt.Sprintf(`"parent" (%s)`, parentId)) {
+		t.Fatalf("expected error naming the parent groups, got: %v", explained)
+	}
+}
+
+func TestGroupOwnerCheckRemoval(t *testing.T) {
+	const ownerId = "11111111-1111-1111-1111-111111111111"
+
+	cases := []struct {
+		name      string
+		owners    *[]string
+		expectErr bool
+	}{
+		{
+			name:   "unknown owners",
+			owners: nil,
+		},
+		{
+			name:   "other owners remain",
+			owners: &[]string{ownerId, "22222222-2222-2222-2222-222222222222"},
+		},
+		{
+			name:   "owner already removed",
+			owners: &[]string{"22222222-2222-2222-2222-222222222222"},
+		},
+		{
+			name:      "last owner",
+			owners:    &[]string{ownerId},
+			expectErr: true,
+		},
+		{
+			name:      "last owner with different case",
+			owners:    &[]string{strings.ToUpper(ownerId)},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := groupOwnerCheckRemoval(tc.owners, ownerId)
+			if tc.expectErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupCreateResourceOptions(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  groupResourceOptions
+		expected map[string]interface{}
+	}{
+		{
+			name:     "no options",
+			expected: map[string]interface{}{},
+		},
+		{
+			name: "behaviors and provisioning options",
+			options: groupResourceOptions{
+				ResourceBehaviorOptions:     []string{"WelcomeEmailDisabled"},
+				ResourceProvisioningOptions: []string{"Team"},
+			},
+			expected: map[string]interface{}{
+				"resourceBehaviorOptions":     []interface{}{"WelcomeEmailDisabled"},
+				"resourceProvisioningOptions": []interface{}{"Team"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/beta/00000000-0000-0000-0000-000000000000/groups" {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decoding request body: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{"id":"11111111-1111-1111-1111-111111111111","displayName":"acctest"}`)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			group, _, err := groupCreate(context.Background(), client, msgraph.Group{DisplayName: utils.String("acctest")}, tc.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if group.ID == nil || *group.ID != "11111111-1111-1111-1111-111111111111" {
+				t.Fatalf("expected the created group to be returned, got: %+v", group)
+			}
+
+			if body["displayName"] != "acctest" {
+				t.Errorf("expected displayName to be sent, got body: %v", body)
+			}
+			for _, property := range []string{"resourceBehaviorOptions", "resourceProvisioningOptions"} {
+				if expected, ok := tc.expected[property]; ok {
+					if !reflect.DeepEqual(body[property], expected) {
+						t.Errorf("expected %s to be %v, got %v", property, expected, body[property])
+					}
+				} else if _, ok := body[property]; ok {
+					t.Errorf("expected %s to be omitted, got %v", property, body[property])
+				}
+			}
+		})
+	}
+}
+
+func TestGroupResourceCreateRollback(t *testing.T) {
+	const (
+		callerId = "00000000-0000-0000-0000-000000000001"
+		groupId  = "11111111-1111-1111-1111-111111111111"
+	)
+
+	cases := []struct {
+		name           string
+		failStep       string
+		rollback       bool
+		deleteStatus   int
+		expectDeleted  bool
+		expectRetained bool
+	}{
+		{name: "owners fail without rollback", failStep: "owners", expectRetained: true},
+		{name: "owners fail with rollback", failStep: "owners", rollback: true, deleteStatus: http.StatusNoContent, expectDeleted: true},
+		{name: "members fail without rollback", failStep: "members", expectRetained: true},
+		{name: "members fail with rollback", failStep: "members", rollback: true, deleteStatus: http.StatusNoContent, expectDeleted: true},
+		{name: "members fail with rollback already deleted", failStep: "members", rollback: true, deleteStatus: http.StatusNotFound, expectDeleted: true},
+		{name: "members fail with rollback failing", failStep: "members", rollback: true, deleteStatus: http.StatusForbidden, expectDeleted: true, expectRetained: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				step := ""
+				switch {
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/directoryObjects/getByIds"):
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, `{"value":[{"@odata.type":"#microsoft.graph.user","id":"33333333-3333-3333-3333-333333333333"}]}`)
+					return
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/groups"):
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusCreated)
+					fmt.Fprintf(w, `{"id":%q,"displayName":"acctest"}`, groupId)
+					return
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/groups/"+groupId+"/owners/$ref"):
+					step = "owners"
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/groups/"+groupId):
+					step = "members"
+				case r.Method == http.MethodDelete && strings.HasSuffix(r.URL.Path, "/groups/"+groupId):
+					deleted = true
+					w.WriteHeader(tc.deleteStatus)
+					return
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if step == tc.failStep {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			meta := &clients.Client{
+				Claims:                  auth.Claims{ObjectId: callerId},
+				RollbackOnPartialCreate: tc.rollback,
+				Applications:            &applicationsClient.Client{},
+				Groups:                  &groupsClient.Client{GroupsClient: client},
+				ServicePrincipals:       &serviceprincipalsClient.Client{},
+			}
+
+			d := schema.TestResourceDataRaw(t, groupResource().Schema, map[string]interface{}{
+				"display_name":     "acctest",
+				"security_enabled": true,
+				"owners":           []interface{}{"33333333-3333-3333-3333-333333333333"},
+				"members":          []interface{}{"44444444-4444-4444-4444-444444444444"},
+			})
+
+			diags := groupResourceCreate(context.Background(), d, meta)
+			if !diags.HasError() {
+				t.Fatalf("expected an error when the %s step fails", tc.failStep)
+			}
+			if deleted != tc.expectDeleted {
+				t.Fatalf("expected deleted to be %t, got %t", tc.expectDeleted, deleted)
+			}
+			if retained := d.Id() == groupId; retained != tc.expectRetained {
+				t.Fatalf("expected the group ID to be retained in state: %t, got ID %q", tc.expectRetained, d.Id())
+			}
+		})
+	}
+}
+
+func TestGroupWaitForPermanentDeletion(t *testing.T) {
+	const (
+		groupId        = "11111111-1111-1111-1111-111111111111"
+		mailNickname   = "o'brien-team"
+		tenantIdPrefix = "/beta/00000000-0000-0000-0000-000000000000"
+	)
+
+	interval := groupPermanentDeletionPollInterval
+	groupPermanentDeletionPollInterval = 10 * time.Millisecond
+	defer func() { groupPermanentDeletionPollInterval = interval }()
+
+	deleteAttempts, deletedListings := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, tenantIdPrefix)
+		switch {
+		case r.Method == http.MethodDelete && path == fmt.Sprintf("/directory/deletedItems/%s", groupId):
+			// The soft-deleted group is not found in the deleted items at first
+			deleteAttempts++
+			if deleteAttempts == 1 {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist."}}`)
+				return
+			}
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && path == "/groups":
+			if expected := "mailNickname eq 'o''brien-team'"; r.URL.Query().Get("$filter") != expected {
+				t.Errorf("expected filter %q, got %q", expected, r.URL.Query().Get("$filter"))
+			}
+			fmt.Fprint(w, `{"value":[]}`)
+		case r.Method == http.MethodGet && path == "/directory/deleteditems/microsoft.graph.group":
+			// The mail nickname remains reserved by the deleted group for a short while after permanent deletion
+			deletedListings++
+			if deletedListings == 1 {
+				fmt.Fprintf(w, `{"value":[{"id":%q,"mailNickname":%q}]}`, groupId, mailNickname)
+				return
+			}
+			fmt.Fprint(w, `{"value":[]}`)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := groupWaitForPermanentDeletion(ctx, client, groupId, mailNickname); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deleteAttempts != 2 {
+		t.Fatalf("expected permanent deletion to be attempted twice, got %d", deleteAttempts)
+	}
+	if deletedListings < 3 {
+		t.Fatalf("expected deleted groups to be listed until the mail nickname was released, got %d listings", deletedListings)
+	}
+}
+
+func TestGroupUnifiedOnlyWriteError(t *testing.T) {
+	const groupId = "11111111-1111-1111-1111-111111111111"
+	apiErr := errors.New("GroupsClient.BaseClient.Patch(): unexpected status 400 with OData error: Request_BadRequest: Invalid value specified for property 'theme' of resource 'Group'.")
+
+	cases := []struct {
+		name          string
+		unified       bool
+		status        int
+		changed       []string
+		expectMessage bool
+	}{
+		{
+			name:          "security group",
+			status:        http.StatusBadRequest,
+			changed:       []string{"theme"},
+			expectMessage: true,
+		},
+		{
+			name:    "unified group",
+			unified: true,
+			status:  http.StatusBadRequest,
+			changed: []string{"theme"},
+		},
+		{
+			name:    "no unified-only changes",
+			status:  http.StatusBadRequest,
+			changed: []string{},
+		},
+		{
+			name:    "other failure",
+			status:  http.StatusForbidden,
+			changed: []string{"theme"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tc.unified {
+					fmt.Fprintf(w, `{"id":%q,"groupTypes":["Unified"]}`, groupId)
+				} else {
+					fmt.Fprintf(w, `{"id":%q,"groupTypes":[]}`, groupId)
+				}
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			err := groupUnifiedOnlyWriteError(context.Background(), client, groupId, tc.status, tc.changed, apiErr)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if explained := strings.Contains(err.Error(), "not a Microsoft 365 (unified) group"); explained != tc.expectMessage {
+				t.Fatalf("expected explanation to be %t, got: %v", tc.expectMessage, err)
+			}
+			if tc.expectMessage && !strings.Contains(err.Error(), "`theme`") {
+				t.Fatalf("expected error to name the changed attributes, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupRelationshipResourceIds(t *testing.T) {
+	groupId, objectId := "00000000-0000-0000-0000-000000000000", "11111111-1111-1111-1111-111111111111"
+
+	for idType, relationship := range map[string]helpers.RelationshipResource{
+		"member": groupMemberRelationship(),
+		"owner":  groupOwnerRelationship(),
+	} {
+		expected := fmt.Sprintf("%s/%s/%s", groupId, idType, objectId)
+		if id := relationship.FormatId(groupId, objectId); id != expected {
+			t.Fatalf("%s: expected ID %q, got %q", relationship.Name, expected, id)
+		}
+
+		parentId, relatedId, err := relationship.ParseId(expected)
+		if err != nil {
+			t.Fatalf("%s: unexpected error parsing ID: %v", relationship.Name, err)
+		}
+		if parentId != groupId || relatedId != objectId {
+			t.Fatalf("%s: expected IDs %q and %q, got %q and %q", relationship.Name, groupId, objectId, parentId, relatedId)
+		}
+
+		if _, _, err := relationship.ParseId(fmt.Sprintf("%s/notARelationship/%s", groupId, objectId)); err == nil {
+			t.Fatalf("%s: expected an error parsing an ID of another type", relationship.Name)
+		}
+	}
+}
+
+func TestGroupGetForResourceReadOnlyProperties(t *testing.T) {
+	cases := []struct {
+		name                   string
+		response               string
+		expectedAppId          *string
+		expectedExpiration     string
+		expectedRenewed        string
+		expectedLastSync       string
+		expectedClassification *string
+	}{
+		{
+			name:     "absent",
+			response: `{"id":"11111111-1111-1111-1111-111111111111"}`,
+		},
+		{
+			name:     "null",
+			response: `{"id":"11111111-1111-1111-1111-111111111111","classification":null,"createdByAppId":null,"expirationDateTime":null,"onPremisesLastSyncDateTime":null,"renewedDateTime":null}`,
+		},
+		{
+			name:                   "populated",
+			response:               `{"id":"11111111-1111-1111-1111-111111111111","classification":"Confidential","createdByAppId":"22222222-2222-2222-2222-222222222222","expirationDateTime":"2022-06-01T10:00:00Z","onPremisesLastSyncDateTime":"2022-01-02T03:04:05+01:00","renewedDateTime":"2021-06-01T10:00:00Z"}`,
+			expectedAppId:          utils.String("22222222-2222-2222-2222-222222222222"),
+			expectedExpiration:     "2022-06-01T10:00:00Z",
+			expectedRenewed:        "2021-06-01T10:00:00Z",
+			expectedLastSync:       "2022-01-02T02:04:05Z",
+			expectedClassification: utils.String("Confidential"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				selected := make(map[string]bool)
+				for _, property := range strings.Split(r.URL.Query().Get("$select"), ",") {
+					selected[property] = true
+				}
+				for _, property := range []string{"classification", "createdByAppId", "expirationDateTime", "onPremisesLastSyncDateTime", "renewedDateTime"} {
+					if !selected[property] {
+						t.Errorf("expected %q to be selected, got: %v", property, selected)
+					}
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, tc.response)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			group, _, err := groupGetForResource(context.Background(), client, "11111111-1111-1111-1111-111111111111")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(group.CreatedByAppId, tc.expectedAppId) {
+				t.Errorf("expected createdByAppId %v, got %v", tc.expectedAppId, group.CreatedByAppId)
+			}
+			if !reflect.DeepEqual(group.Classification, tc.expectedClassification) {
+				t.Errorf("expected classification %v, got %v", tc.expectedClassification, group.Classification)
+			}
+			if v := groupFlattenDateTime(group.ExpirationDateTime); v != tc.expectedExpiration {
+				t.Errorf("expected expiration date time %q, got %q", tc.expectedExpiration, v)
+			}
+			if v := groupFlattenDateTime(group.RenewedDateTime); v != tc.expectedRenewed {
+				t.Errorf("expected renewed date time %q, got %q", tc.expectedRenewed, v)
+			}
+			if v := groupFlattenDateTime(group.OnPremisesLastSyncDateTime); v != tc.expectedLastSync {
+				t.Errorf("expected on-premises last sync date time %q, got %q", tc.expectedLastSync, v)
+			}
+		})
+	}
+}
+
+func TestGroupClassificationError(t *testing.T) {
+	err := errors.New("unexpected status 400")
+
+	if actual := groupClassificationError(nil, http.StatusBadRequest, "Confidential"); actual != nil {
+		t.Fatalf("expected nil error, got %v", actual)
+	}
+	if actual := groupClassificationError(err, http.StatusForbidden, "Confidential"); actual != err {
+		t.Fatalf("expected error to be unchanged for other status codes, got %v", actual)
+	}
+	if actual := groupClassificationError(err, http.StatusBadRequest, ""); actual != err {
+		t.Fatalf("expected error to be unchanged without a classification, got %v", actual)
+	}
+	if actual := groupClassificationError(err, http.StatusBadRequest, "Confidential"); actual == nil || !strings.Contains(actual.Error(), `"Confidential"`) || !strings.Contains(actual.Error(), "ClassificationList") {
+		t.Fatalf("expected error to explain classification requirements, got %v", actual)
+	}
+}
+
+func TestGroupOnPremisesSyncChanges(t *testing.T) {
+	cases := []struct {
+		name     string
+		changed  []string
+		expected []string
+	}{
+		{name: "rename", changed: []string{"display_name"}, expected: []string{"display_name"}},
+		{name: "member change", changed: []string{"members"}, expected: []string{"members"}},
+		{name: "owners only", changed: []string{"owners"}, expected: []string{}},
+		{name: "mixed", changed: []string{"owners", "members", "description"}, expected: []string{"description", "members"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hasChange := func(attr string) bool {
+				for _, v := range tc.changed {
+					if v == attr {
+						return true
+					}
+				}
+				return false
+			}
+			if result := groupOnPremisesSyncChanges(hasChange); !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected changed attributes %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGroupOnPremisesSyncWriteError(t *testing.T) {
+	const groupId = "11111111-1111-1111-1111-111111111111"
+
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id":%q,"onPremisesSyncEnabled":true}`, groupId)
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	writeErr := errors.New("update failed")
+
+	cases := []struct {
+		name            string
+		status          int
+		changed         []string
+		expectAnnotated bool
+	}{
+		{name: "synced attributes changed", status: http.StatusBadRequest, changed: []string{"display_name"}, expectAnnotated: true},
+		{name: "no synced attributes changed", status: http.StatusBadRequest},
+		{name: "group not found", status: http.StatusNotFound, changed: []string{"display_name"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			gets = 0
+			err := groupOnPremisesSyncWriteError(context.Background(), client, groupId, tc.status, tc.changed, writeErr)
+			if !tc.expectAnnotated {
+				if err != writeErr {
+					t.Fatalf("expected the error to be returned unchanged, got: %v", err)
+				}
+				if gets > 0 {
+					t.Fatalf("expected the group not to be retrieved, got %d requests", gets)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "synchronized from an on-premises directory") {
+				t.Fatalf("expected error explaining on-premises synchronization, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupResourceUpdateOnPremisesSynced(t *testing.T) {
+	const (
+		groupId  = "11111111-1111-1111-1111-111111111111"
+		memberId = "22222222-2222-2222-2222-222222222222"
+	)
+
+	cases := []struct {
+		name          string
+		synced        bool
+		config        map[string]interface{}
+		failMembers   bool
+		expectMessage string
+	}{
+		{
+			name:          "rename synced group",
+			synced:        true,
+			config:        map[string]interface{}{"display_name": "renamed", "security_enabled": true},
+			expectMessage: "cannot be changed in Azure AD: `display_name`, `security_enabled`",
+		},
+		{
+			name:          "change members of synced group",
+			synced:        true,
+			config:        map[string]interface{}{"display_name": "synced", "security_enabled": true, "members": []interface{}{memberId}},
+			failMembers:   true,
+			expectMessage: "cannot be changed in Azure AD: `display_name`, `members`, `security_enabled`",
+		},
+		{
+			name:   "rename cloud group",
+			config: map[string]interface{}{"display_name": "renamed", "security_enabled": true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/directoryObjects/getByIds"):
+					fmt.Fprintf(w, `{"value":[{"@odata.type":"#microsoft.graph.user","id":%q}]}`, memberId)
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/"+groupId):
+					if tc.synced {
+						fmt.Fprintf(w, `{"id":%q,"onPremisesSyncEnabled":true}`, groupId)
+					} else {
+						fmt.Fprintf(w, `{"id":%q}`, groupId)
+					}
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups/"+groupId+"/members"):
+					fmt.Fprint(w, `{"value":[]}`)
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/groups/"+groupId):
+					body := new(bytes.Buffer)
+					if _, err := body.ReadFrom(r.Body); err != nil {
+						t.Fatalf("reading request body: %v", err)
+					}
+					if strings.Contains(body.String(), "members@odata.bind") != tc.failMembers {
+						w.Header().Del("Content-Type")
+						w.WriteHeader(http.StatusNoContent)
+						return
+					}
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":{"code":"Request_BadRequest","message":"Unable to update the specified properties for on-premises mastered Directory Sync objects or objects currently undergoing migration."}}`)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			spClient := msgraph.NewServicePrincipalsClient("00000000-0000-0000-0000-000000000000")
+			spClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			spClient.BaseClient.DisableRetries = true
+
+			meta := &clients.Client{
+				Applications:      &applicationsClient.Client{},
+				Groups:            &groupsClient.Client{GroupsClient: client},
+				ServicePrincipals: &serviceprincipalsClient.Client{ServicePrincipalsClient: spClient},
+			}
+
+			d := schema.TestResourceDataRaw(t, groupResource().Schema, tc.config)
+			d.SetId(groupId)
+
+			diags := groupResourceUpdate(context.Background(), d, meta)
+			if !diags.HasError() {
+				t.Fatalf("expected an error")
+			}
+			detail := diags[0].Detail
+			if tc.expectMessage == "" {
+				if strings.Contains(detail, "onpremises_sync_enabled") {
+					t.Fatalf("expected error not to mention on-premises synchronization, got: %s", detail)
+				}
+				return
+			}
+			if !strings.Contains(detail, "synchronized from an on-premises directory") || !strings.Contains(detail, tc.expectMessage) {
+				t.Fatalf("expected error explaining on-premises synchronization with %q, got: %s", tc.expectMessage, detail)
+			}
+		})
+	}
+}
+
+func TestGroupAddMembersBatching(t *testing.T) {
+	const groupId = "00000000-0000-0000-0000-00000000abcd"
+
+	testCases := []struct {
+		memberCount      int
+		expectedRequests []int
+	}{
+		{memberCount: 1, expectedRequests: []int{1}},
+		{memberCount: groupMembersAddBatchSize, expectedRequests: []int{groupMembersAddBatchSize}},
+		{memberCount: groupMembersAddBatchSize + 1, expectedRequests: []int{groupMembersAddBatchSize, 1}},
+		{memberCount: 2 * groupMembersAddBatchSize, expectedRequests: []int{groupMembersAddBatchSize, groupMembersAddBatchSize}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(strconv.Itoa(tc.memberCount), func(t *testing.T) {
+			requests := make([]int, 0)
+			added := make([]string, 0)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != fmt.Sprintf("/beta/00000000-0000-0000-0000-000000000000/groups/%s", groupId) {
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				var body struct {
+					Members []string `json:"members@odata.bind"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decoding request body: %v", err)
+				}
+				if len(body.Members) > groupMembersAddBatchSize {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":{"code":"Request_BadRequest","message":"The request contains too many member references."}}`)
+					return
+				}
+				requests = append(requests, len(body.Members))
+				added = append(added, body.Members...)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+			client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			client.BaseClient.DisableRetries = true
+
+			memberIds := make([]string, tc.memberCount)
+			for i := range memberIds {
+				memberIds[i] = fmt.Sprintf("%08x-1111-1111-1111-111111111111", i)
+			}
+
+			if _, err := groupAddMembers(context.Background(), client, groupId, memberIds); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(requests, tc.expectedRequests) {
+				t.Fatalf("expected requests adding %v members, got %v", tc.expectedRequests, requests)
+			}
+			if len(added) != tc.memberCount {
+				t.Fatalf("expected %d members to be added, got %d", tc.memberCount, len(added))
+			}
+			for i, m := range added {
+				if !strings.HasSuffix(m, "/directoryObjects/"+memberIds[i]) {
+					t.Fatalf("expected member %d to reference %q, got %q", i, memberIds[i], m)
+				}
+			}
+		})
+	}
+}
+
+func TestGroupValidateOwnersCount(t *testing.T) {
+	owners := make([]string, groupOwnersLimit+1)
+	for i := range owners {
+		owners[i] = fmt.Sprintf("%08x-1111-1111-1111-111111111111", i)
+	}
+
+	if err := groupValidateOwnersCount(nil); err != nil {
+		t.Fatalf("expected no error without owners, got: %v", err)
+	}
+	if err := groupValidateOwnersCount(owners[:groupOwnersLimit]); err != nil {
+		t.Fatalf("expected no error with %d owners, got: %v", groupOwnersLimit, err)
+	}
+	if err := groupValidateOwnersCount(owners); err == nil || !strings.Contains(err.Error(), fmt.Sprintf("at most %d owners", groupOwnersLimit)) {
+		t.Fatalf("expected error stating the owner limit, got: %v", err)
+	}
+}
+
+func TestGroupValidateRoleAssignableMembers(t *testing.T) {
+	user := helpers.DirectoryObjectSummary{ID: "11111111-1111-1111-1111-111111111111", Type: helpers.DirectoryObjectTypeUser, DisplayName: "user"}
+	servicePrincipal := helpers.DirectoryObjectSummary{ID: "22222222-2222-2222-2222-222222222222", Type: helpers.DirectoryObjectTypeServicePrincipal, DisplayName: "service principal"}
+	group := helpers.DirectoryObjectSummary{ID: "33333333-3333-3333-3333-333333333333", Type: helpers.DirectoryObjectTypeGroup, DisplayName: "nested"}
+	device := helpers.DirectoryObjectSummary{ID: "44444444-4444-4444-4444-444444444444", Type: "device", DisplayName: "laptop"}
+
+	if err := groupValidateRoleAssignableMembers(nil); err != nil {
+		t.Fatalf("expected no error without members, got: %v", err)
+	}
+	if err := groupValidateRoleAssignableMembers([]helpers.DirectoryObjectSummary{user, servicePrincipal}); err != nil {
+		t.Fatalf("expected no error for users and service principals, got: %v", err)
+	}
+
+	err := groupValidateRoleAssignableMembers([]helpers.DirectoryObjectSummary{user, group, servicePrincipal, device})
+	if err == nil {
+		t.Fatalf("expected an error for a nested group and a device")
+	}
+	for _, v := range []string{group.ID, `"nested"`, device.ID, `"laptop"`} {
+		if !strings.Contains(err.Error(), v) {
+			t.Fatalf("expected error to contain %s, got: %v", v, err)
+		}
+	}
+	for _, v := range []string{user.ID, servicePrincipal.ID} {
+		if strings.Contains(err.Error(), v) {
+			t.Fatalf("expected error not to contain %s, got: %v", v, err)
+		}
+	}
+}
+
+func TestGroupListMembersWithTypes(t *testing.T) {
+	const groupId = "00000000-0000-0000-0000-00000000abcd"
+
+	pages := map[string][]string{
+		"members": {
+			`{"value":[{"@odata.type":"#microsoft.graph.user","id":"33333333-3333-3333-3333-333333333333"},{"@odata.type":"#microsoft.graph.group","id":"11111111-1111-1111-1111-111111111111"}]}`,
+		},
+		"transitiveMembers": {
+			`{"value":[{"@odata.type":"#microsoft.graph.user","id":"33333333-3333-3333-3333-333333333333"},{"@odata.type":"#microsoft.graph.group","id":"11111111-1111-1111-1111-111111111111"}],"@odata.nextLink":"%s%s?$skiptoken=1"}`,
+			`{"value":[{"@odata.type":"#microsoft.graph.servicePrincipal","id":"44444444-4444-4444-4444-444444444444"},{"@odata.type":"#microsoft.graph.device","id":"22222222-2222-2222-2222-222222222222"}]}`,
+		},
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		relationship := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/beta/00000000-0000-0000-0000-000000000000/groups/%s/", groupId))
+		responses, ok := pages[relationship]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		page := 0
+		if r.URL.Query().Get("$skiptoken") != "" {
+			page = 1
+		}
+		w.Header().Set("Content-Type", "application/json")
+		body := responses[page]
+		if strings.Contains(body, "@odata.nextLink") {
+			body = fmt.Sprintf(body, server.URL, r.URL.Path)
+		}
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	client := msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+
+	testCases := []struct {
+		transitive bool
+		expected   []helpers.DirectoryObjectSummary
+	}{
+		{
+			transitive: false,
+			expected: []helpers.DirectoryObjectSummary{
+				{ID: "11111111-1111-1111-1111-111111111111", Type: "group"},
+				{ID: "33333333-3333-3333-3333-333333333333", Type: "user"},
+			},
+		},
+		{
+			transitive: true,
+			expected: []helpers.DirectoryObjectSummary{
+				{ID: "11111111-1111-1111-1111-111111111111", Type: "group"},
+				{ID: "22222222-2222-2222-2222-222222222222", Type: "device"},
+				{ID: "33333333-3333-3333-3333-333333333333", Type: "user"},
+				{ID: "44444444-4444-4444-4444-444444444444", Type: "servicePrincipal"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("transitive=%t", tc.transitive), func(t *testing.T) {
+			members, _, err := groupListMembersWithTypes(context.Background(), client, groupId, tc.transitive)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(members, tc.expected) {
+				t.Fatalf("expected members %+v, got %+v", tc.expected, members)
+			}
+		})
+	}
+}
+
+func TestGroupResourceCustomizeDiffUnknownMembers(t *testing.T) {
+	const (
+		groupId = "11111111-1111-1111-1111-111111111111"
+		memberA = "22222222-2222-2222-2222-222222222222"
+		memberB = "33333333-3333-3333-3333-333333333333"
+		memberC = "44444444-4444-4444-4444-444444444444"
+
+		// The value used by the SDK to represent an unknown value in configuration
+		unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	)
+
+	cases := []struct {
+		name              string
+		existing          bool
+		members           []interface{}
+		expectMembersDiff bool
+		expectHashChange  bool
+	}{
+		{
+			name:             "unknown member added",
+			existing:         true,
+			members:          []interface{}{memberA, memberB, unknown},
+			expectHashChange: true,
+		},
+		{
+			name:             "only unknown members",
+			existing:         true,
+			members:          []interface{}{unknown},
+			expectHashChange: true,
+		},
+		{
+			name:              "known member added",
+			existing:          true,
+			members:           []interface{}{memberA, memberB, memberC},
+			expectMembersDiff: true,
+			expectHashChange:  true,
+		},
+		{
+			name:     "unchanged members",
+			existing: true,
+			members:  []interface{}{memberB, memberA},
+		},
+		{
+			name:              "unknown member for new group",
+			members:           []interface{}{memberA, unknown},
+			expectMembersDiff: true,
+			expectHashChange:  true,
+		},
+	}
+
+	r := groupResource()
+	meta := &clients.Client{
+		Groups: &groupsClient.Client{GroupsClient: msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.existing {
+				d := r.TestResourceData()
+				d.SetId(groupId)
+				tf.Set(d, "display_name", "acctest")
+				tf.Set(d, "security_enabled", true)
+				tf.Set(d, "behaviors", []string{})
+				tf.Set(d, "provisioning_options", []string{})
+				tf.Set(d, "members", []string{memberA, memberB})
+				tf.Set(d, "members_hash", groupMembersHash(&[]string{memberA, memberB}))
+				state = d.State()
+			}
+
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+				"display_name":     "acctest",
+				"security_enabled": true,
+				"members":          tc.members,
+			}), meta)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			attributes := make(map[string]*terraform.ResourceAttrDiff)
+			if diff != nil {
+				attributes = diff.Attributes
+			}
+
+			membersDiff := false
+			for k := range attributes {
+				if strings.HasPrefix(k, "members.") {
+					membersDiff = true
+				}
+			}
+			if membersDiff != tc.expectMembersDiff {
+				t.Fatalf("expected a diff for members: %t, got: %+v", tc.expectMembersDiff, attributes)
+			}
+
+			hash, hashChange := attributes["members_hash"]
+			if hashChange != tc.expectHashChange {
+				t.Fatalf("expected a diff for members_hash: %t, got: %+v", tc.expectHashChange, attributes)
+			}
+			if hashChange && !hash.NewComputed {
+				t.Fatalf("expected members_hash to be known after apply, got: %+v", hash)
+			}
+		})
+	}
+}
+
+func TestGroupMembersHash(t *testing.T) {
+	a := groupMembersHash(&[]string{"22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"})
+	b := groupMembersHash(&[]string{"33333333-3333-3333-3333-333333333333", "22222222-2222-2222-2222-222222222222"})
+	c := groupMembersHash(&[]string{"22222222-2222-2222-2222-222222222222"})
+
+	if a != b {
+		t.Fatalf("expected hash not to depend on order, got %q and %q", a, b)
+	}
+	if a == c {
+		t.Fatalf("expected hash to change when members change, got %q", a)
+	}
+	if groupMembersHash(nil) != groupMembersHash(&[]string{}) {
+		t.Fatal("expected nil and empty members to have the same hash")
+	}
+}
+
+func TestGroupResourceCustomizeDiffUnifiedOnly(t *testing.T) {
+	cases := []struct {
+		name        string
+		existing    map[string]interface{}
+		config      map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:        "explicit false for new group",
+			config:      map[string]interface{}{"display_name": "acctest", "security_enabled": true, "allow_external_senders": false},
+			expectError: true,
+		},
+		{
+			name:        "explicit true for new group",
+			config:      map[string]interface{}{"display_name": "acctest", "security_enabled": true, "auto_subscribe_new_members": true},
+			expectError: true,
+		},
+		{
+			name:   "null for new group",
+			config: map[string]interface{}{"display_name": "acctest", "security_enabled": true},
+		},
+		{
+			name:   "explicit false for unified group",
+			config: map[string]interface{}{"display_name": "acctest", "mail_enabled": true, "types": []interface{}{"Unified"}, "allow_external_senders": false},
+		},
+		{
+			name:     "null for existing group",
+			existing: map[string]interface{}{"display_name": "acctest", "security_enabled": true},
+			config:   map[string]interface{}{"display_name": "acctest", "security_enabled": true},
+		},
+		{
+			name:        "explicit false for existing group",
+			existing:    map[string]interface{}{"display_name": "acctest", "security_enabled": true},
+			config:      map[string]interface{}{"display_name": "acctest", "security_enabled": true, "allow_external_senders": false},
+			expectError: true,
+		},
+		{
+			name:     "null for unified group being replaced",
+			existing: map[string]interface{}{"display_name": "acctest", "mail_enabled": true, "types": []interface{}{"Unified"}, "allow_external_senders": true},
+			config:   map[string]interface{}{"display_name": "acctest", "security_enabled": true},
+		},
+	}
+
+	r := groupResource()
+	meta := &clients.Client{
+		Groups: &groupsClient.Client{GroupsClient: msgraph.NewGroupsClient("00000000-0000-0000-0000-000000000000")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var state *terraform.InstanceState
+			if tc.existing != nil {
+				d := r.TestResourceData()
+				d.SetId("11111111-1111-1111-1111-111111111111")
+				for k, v := range tc.existing {
+					tf.Set(d, k, v)
+				}
+				tf.Set(d, "behaviors", []string{})
+				tf.Set(d, "provisioning_options", []string{})
+				state = d.State()
+			}
+
+			_, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(tc.config), meta)
+			if tc.expectError {
+				if err == nil || !strings.Contains(err.Error(), "is only supported for unified groups") {
+					t.Fatalf("expected an error for a unified-only attribute, got: %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestGroupResourceExchangeOnlyAttributes(t *testing.T) {
+	r := groupResource()
+
+	for attr, value := range map[string]interface{}{
+		"accept_messages_only_from":     []interface{}{"22222222-2222-2222-2222-222222222222"},
+		"moderated_by":                  []interface{}{"22222222-2222-2222-2222-222222222222"},
+		"moderation_enabled":            false,
+		"reject_messages_from":          []interface{}{"22222222-2222-2222-2222-222222222222"},
+		"require_sender_authentication": true,
+		"send_moderation_notifications": "Always",
+	} {
+		diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"display_name": "acctest",
+			"mail_enabled": true,
+			"types":        []interface{}{"Unified"},
+			attr:           value,
+		}))
+		if !diags.HasError() {
+			t.Fatalf("%s: expected an error", attr)
+		}
+		if summary := diags[0].Summary; !strings.Contains(summary, "configure via Exchange Online") {
+			t.Fatalf("%s: expected an error explaining to use Exchange Online, got: %s", attr, summary)
+		}
+	}
+
+	if diags := r.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"display_name": "acctest",
+		"mail_enabled": true,
+		"types":        []interface{}{"Unified"},
+	})); diags.HasError() {
+		t.Fatalf("unexpected error: %+v", diags)
+	}
+}
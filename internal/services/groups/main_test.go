@@ -0,0 +1,12 @@
+package groups_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(acceptance.RunWithSharedPrerequisites(m))
+}
@@ -31,5 +31,6 @@ func (r Registration) SupportedResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
 		"azuread_group":        groupResource(),
 		"azuread_group_member": groupMemberResource(),
+		"azuread_group_owner":  groupOwnerResource(),
 	}
 }
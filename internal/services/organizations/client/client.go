@@ -0,0 +1,18 @@
+package client
+
+import (
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+type Client struct {
+	OrganizationClient *OrganizationClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	organizationClient := NewOrganizationClient(o.TenantID)
+	o.ConfigureClient(&organizationClient.BaseClient)
+
+	return &Client{
+		OrganizationClient: organizationClient,
+	}
+}
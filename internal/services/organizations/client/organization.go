@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// Organization describes an Organization object.
+type Organization struct {
+	ID                         *string           `json:"id,omitempty"`
+	CountryLetterCode          *string           `json:"countryLetterCode,omitempty"`
+	CreatedDateTime            *time.Time        `json:"createdDateTime,omitempty"`
+	DisplayName                *string           `json:"displayName,omitempty"`
+	TechnicalNotificationMails *[]string         `json:"technicalNotificationMails,omitempty"`
+	TenantType                 *string           `json:"tenantType,omitempty"`
+	VerifiedDomains            *[]VerifiedDomain `json:"verifiedDomains,omitempty"`
+}
+
+type VerifiedDomain struct {
+	Capabilities *string `json:"capabilities,omitempty"`
+	IsDefault    *bool   `json:"isDefault,omitempty"`
+	IsInitial    *bool   `json:"isInitial,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	Type         *string `json:"type,omitempty"`
+}
+
+// OrganizationClient performs operations on the Organization.
+type OrganizationClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewOrganizationClient returns a new OrganizationClient.
+func NewOrganizationClient(tenantId string) *OrganizationClient {
+	return &OrganizationClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Get retrieves the Organization for the current tenant.
+func (c *OrganizationClient) Get(ctx context.Context) (*Organization, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      "/organization",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("OrganizationClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Organizations []Organization `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	if len(data.Organizations) == 0 {
+		return nil, status, fmt.Errorf("no organization was returned for the current tenant")
+	}
+	return &data.Organizations[0], status, nil
+}
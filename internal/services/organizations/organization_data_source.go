@@ -0,0 +1,183 @@
+package organizations
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/organizations/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+)
+
+func organizationDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: organizationDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"object_id": {
+				Description: "The object ID of the organization, which is the same as the tenant ID",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"display_name": {
+				Description: "The display name of the organization",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"country_letter_code": {
+				Description: "The two-letter country or region abbreviation for the organization",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"created_date_time": {
+				Description: "The date and time when the organization was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"technical_notification_mails": {
+				Description: "A list of email addresses to which technical notifications for the organization are sent",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"tenant_type": {
+				Description: "The type of tenant, e.g. `AAD` or `AAD B2C`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"verified_domains": {
+				Description: "A list of domains that have been verified for the organization",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The domain name",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "The authentication type of the domain, e.g. `Managed` or `Federated`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"capabilities": {
+							Description: "A list of capabilities assigned to the domain, e.g. `Email` or `OfficeCommunicationsOnline`",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"is_default": {
+							Description: "Whether this is the default domain for the organization",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"is_initial": {
+							Description: "Whether this is the initial domain created by Azure Active Directory",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func organizationDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	org, err := meta.(*clients.Client).TenantOrganization(ctx)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not retrieve organization")
+	}
+	if org.ID == nil {
+		return tf.ErrorDiagF(nil, "Organization returned with nil ID")
+	}
+
+	d.SetId(*org.ID)
+
+	createdDateTime := ""
+	if org.CreatedDateTime != nil {
+		createdDateTime = org.CreatedDateTime.UTC().Format(time.RFC3339)
+	}
+
+	tf.Set(d, "object_id", org.ID)
+	tf.Set(d, "country_letter_code", org.CountryLetterCode)
+	tf.Set(d, "created_date_time", createdDateTime)
+	tf.Set(d, "display_name", org.DisplayName)
+	tf.Set(d, "technical_notification_mails", tf.FlattenStringSlicePtr(org.TechnicalNotificationMails))
+	tf.Set(d, "tenant_type", org.TenantType)
+	tf.Set(d, "verified_domains", flattenVerifiedDomains(org.VerifiedDomains))
+
+	return nil
+}
+
+func flattenVerifiedDomains(in *[]client.VerifiedDomain) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	result := make([]interface{}, 0, len(*in))
+	for _, v := range *in {
+		name := ""
+		if v.Name != nil {
+			name = *v.Name
+		}
+
+		domainType := ""
+		if v.Type != nil {
+			domainType = *v.Type
+		}
+
+		// Capabilities are returned as a single comma-separated string
+		capabilities := make([]interface{}, 0)
+		if v.Capabilities != nil {
+			for _, c := range strings.Split(*v.Capabilities, ",") {
+				if c = strings.TrimSpace(c); c != "" {
+					capabilities = append(capabilities, c)
+				}
+			}
+		}
+
+		isDefault := false
+		if v.IsDefault != nil {
+			isDefault = *v.IsDefault
+		}
+
+		isInitial := false
+		if v.IsInitial != nil {
+			isInitial = *v.IsInitial
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":         name,
+			"type":         domainType,
+			"capabilities": capabilities,
+			"is_default":   isDefault,
+			"is_initial":   isInitial,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,35 @@
+package organizations_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type OrganizationDataSource struct{}
+
+func TestAccOrganizationDataSource_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_organization", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: OrganizationDataSource{}.basic(),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_id").IsUuid(),
+				check.That(data.ResourceName).Key("display_name").Exists(),
+				check.That(data.ResourceName).Key("tenant_type").Exists(),
+				check.That(data.ResourceName).Key("verified_domains.#").Exists(),
+				check.That(data.ResourceName).Key("verified_domains.0.name").Exists(),
+				check.That(data.ResourceName).Key("verified_domains.0.is_default").Exists(),
+				check.That(data.ResourceName).Key("verified_domains.0.is_initial").Exists(),
+			),
+		},
+	})
+}
+
+func (OrganizationDataSource) basic() string {
+	return `data "azuread_organization" "test" {}`
+}
@@ -0,0 +1,157 @@
+package policies
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func claimsMappingPolicyResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: claimsMappingPolicyResourceCreate,
+		ReadContext:   claimsMappingPolicyResourceRead,
+		UpdateContext: claimsMappingPolicyResourceUpdate,
+		DeleteContext: claimsMappingPolicyResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"display_name": {
+				Description:      "The display name for this policy",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"definition": {
+				Description: "A string collection containing a JSON string that defines the rules and settings for this policy",
+				Type:        schema.TypeList,
+				Required:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsJSON,
+				},
+			},
+
+			"is_organization_default": {
+				Description: "Whether this policy should apply to all applications in the tenant which do not have a policy assigned",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func claimsMappingPolicyResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.ClaimsMappingPolicyClient
+	displayName := d.Get("display_name").(string)
+
+	policy, _, err := client.Create(ctx, expandClaimsMappingPolicy(d))
+	if err != nil {
+		return tf.ErrorDiagF(err, "Creating claims mapping policy %q", displayName)
+	}
+	if policy.ID == nil || *policy.ID == "" {
+		return tf.ErrorDiagF(errors.New("Object ID returned for claims mapping policy is nil/empty"), "Bad API response")
+	}
+
+	d.SetId(*policy.ID)
+
+	return claimsMappingPolicyResourceRead(ctx, d, meta)
+}
+
+func claimsMappingPolicyResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.ClaimsMappingPolicyClient
+
+	policy := expandClaimsMappingPolicy(d)
+	policy.ID = utils.String(d.Id())
+
+	if _, err := client.Update(ctx, policy); err != nil {
+		return tf.ErrorDiagF(err, "Updating claims mapping policy with object ID %q", d.Id())
+	}
+
+	return claimsMappingPolicyResourceRead(ctx, d, meta)
+}
+
+func claimsMappingPolicyResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.ClaimsMappingPolicyClient
+
+	policy, status, err := client.Get(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Claims mapping policy with object ID %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving claims mapping policy with object ID %q", d.Id())
+	}
+
+	tf.Set(d, "definition", tf.FlattenStringSlicePtr(policy.Definition))
+	tf.Set(d, "display_name", policy.DisplayName)
+
+	isOrganizationDefault := false
+	if policy.IsOrganizationDefault != nil {
+		isOrganizationDefault = *policy.IsOrganizationDefault
+	}
+	tf.Set(d, "is_organization_default", isOrganizationDefault)
+
+	return nil
+}
+
+func claimsMappingPolicyResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.ClaimsMappingPolicyClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "claims mapping policy",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
+}
+
+func expandClaimsMappingPolicy(d *schema.ResourceData) client.ClaimsMappingPolicy {
+	return client.ClaimsMappingPolicy{
+		Definition:            tf.ExpandStringSlicePtr(d.Get("definition").([]interface{})),
+		DisplayName:           utils.String(d.Get("display_name").(string)),
+		IsOrganizationDefault: utils.Bool(d.Get("is_organization_default").(bool)),
+	}
+}
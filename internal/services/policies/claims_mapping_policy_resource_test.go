@@ -0,0 +1,134 @@
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type ClaimsMappingPolicyResource struct{}
+
+func TestAccClaimsMappingPolicy_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_claims_mapping_policy", "test")
+	r := ClaimsMappingPolicyResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("definition.#").HasValue("1"),
+				check.That(data.ResourceName).Key("is_organization_default").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccClaimsMappingPolicy_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_claims_mapping_policy", "test")
+	r := ClaimsMappingPolicyResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.update(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctest-CMP-updated-%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r ClaimsMappingPolicyResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.ClaimsMappingPolicyClient
+	client.BaseClient.DisableRetries = true
+
+	_, status, err := client.Get(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Claims mapping policy with object ID %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve claims mapping policy with object ID %q: %+v", state.ID, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (ClaimsMappingPolicyResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_claims_mapping_policy" "test" {
+  display_name = "acctest-CMP-%[1]d"
+
+  definition = [
+    jsonencode({
+      ClaimsMappingPolicy = {
+        Version              = 1
+        IncludeBasicClaimSet = "true"
+        ClaimsSchema = [
+          {
+            Source       = "user"
+            ID           = "employeeid"
+            JwtClaimType = "employeeid"
+          },
+        ]
+      }
+    }),
+  ]
+}
+`, data.RandomInteger)
+}
+
+func (ClaimsMappingPolicyResource) update(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_claims_mapping_policy" "test" {
+  display_name = "acctest-CMP-updated-%[1]d"
+
+  definition = [
+    jsonencode({
+      ClaimsMappingPolicy = {
+        Version              = 1
+        IncludeBasicClaimSet = "false"
+        ClaimsSchema = [
+          {
+            Source        = "user"
+            ID            = "employeeid"
+            SamlClaimType = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/employeeid"
+            JwtClaimType  = "employeeid"
+          },
+          {
+            Source       = "user"
+            ID           = "department"
+            JwtClaimType = "department"
+          },
+        ]
+      }
+    }),
+  ]
+}
+`, data.RandomInteger)
+}
@@ -0,0 +1,23 @@
+package client
+
+import (
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+)
+
+type Client struct {
+	ClaimsMappingPolicyClient     *ClaimsMappingPolicyClient
+	CrossTenantAccessPolicyClient *CrossTenantAccessPolicyClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	claimsMappingPolicyClient := NewClaimsMappingPolicyClient(o.TenantID)
+	o.ConfigureClient(&claimsMappingPolicyClient.BaseClient)
+
+	crossTenantAccessPolicyClient := NewCrossTenantAccessPolicyClient(o.TenantID)
+	o.ConfigureClient(&crossTenantAccessPolicyClient.BaseClient)
+
+	return &Client{
+		ClaimsMappingPolicyClient:     claimsMappingPolicyClient,
+		CrossTenantAccessPolicyClient: crossTenantAccessPolicyClient,
+	}
+}
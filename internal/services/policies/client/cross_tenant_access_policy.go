@@ -0,0 +1,226 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+const (
+	CrossTenantAccessPolicyAccessTypeAllowed = "allowed"
+	CrossTenantAccessPolicyAccessTypeBlocked = "blocked"
+
+	CrossTenantAccessPolicyTargetTypeApplication = "application"
+	CrossTenantAccessPolicyTargetTypeGroup       = "group"
+	CrossTenantAccessPolicyTargetTypeUser        = "user"
+)
+
+// CrossTenantAccessPolicyConfigurationPartner describes the cross-tenant access settings for a specific partner tenant.
+// A nil setting indicates that the setting is inherited from the default configuration, so these are always sent.
+type CrossTenantAccessPolicyConfigurationPartner struct {
+	TenantId                 *string                              `json:"tenantId,omitempty"`
+	B2BCollaborationInbound  *CrossTenantAccessPolicyB2BSetting   `json:"b2bCollaborationInbound"`
+	B2BCollaborationOutbound *CrossTenantAccessPolicyB2BSetting   `json:"b2bCollaborationOutbound"`
+	InboundTrust             *CrossTenantAccessPolicyInboundTrust `json:"inboundTrust"`
+}
+
+// CrossTenantAccessPolicyConfigurationDefault describes the default cross-tenant access settings for the tenant, which
+// apply to partner tenants without their own configuration.
+type CrossTenantAccessPolicyConfigurationDefault struct {
+	B2BCollaborationInbound  *CrossTenantAccessPolicyB2BSetting   `json:"b2bCollaborationInbound,omitempty"`
+	B2BCollaborationOutbound *CrossTenantAccessPolicyB2BSetting   `json:"b2bCollaborationOutbound,omitempty"`
+	InboundTrust             *CrossTenantAccessPolicyInboundTrust `json:"inboundTrust,omitempty"`
+	IsServiceDefault         *bool                                `json:"isServiceDefault,omitempty"`
+}
+
+type CrossTenantAccessPolicyB2BSetting struct {
+	Applications   *CrossTenantAccessPolicyTargetConfiguration `json:"applications,omitempty"`
+	UsersAndGroups *CrossTenantAccessPolicyTargetConfiguration `json:"usersAndGroups,omitempty"`
+}
+
+type CrossTenantAccessPolicyTargetConfiguration struct {
+	AccessType *string                          `json:"accessType,omitempty"`
+	Targets    *[]CrossTenantAccessPolicyTarget `json:"targets,omitempty"`
+}
+
+type CrossTenantAccessPolicyTarget struct {
+	Target     *string `json:"target,omitempty"`
+	TargetType *string `json:"targetType,omitempty"`
+}
+
+type CrossTenantAccessPolicyInboundTrust struct {
+	IsCompliantDeviceAccepted           *bool `json:"isCompliantDeviceAccepted,omitempty"`
+	IsHybridAzureADJoinedDeviceAccepted *bool `json:"isHybridAzureADJoinedDeviceAccepted,omitempty"`
+	IsMfaAccepted                       *bool `json:"isMfaAccepted,omitempty"`
+}
+
+// CrossTenantAccessPolicyClient performs operations on the cross-tenant access policy.
+type CrossTenantAccessPolicyClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewCrossTenantAccessPolicyClient returns a new CrossTenantAccessPolicyClient.
+func NewCrossTenantAccessPolicyClient(tenantId string) *CrossTenantAccessPolicyClient {
+	return &CrossTenantAccessPolicyClient{
+		BaseClient: msgraph.NewClient(msgraph.VersionBeta, tenantId),
+	}
+}
+
+// CreatePartner creates the configuration for a partner tenant.
+func (c *CrossTenantAccessPolicyClient) CreatePartner(ctx context.Context, partner CrossTenantAccessPolicyConfigurationPartner) (*CrossTenantAccessPolicyConfigurationPartner, int, error) {
+	body, err := json.Marshal(partner)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/policies/crossTenantAccessPolicy/partners",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newPartner CrossTenantAccessPolicyConfigurationPartner
+	if err := json.Unmarshal(respBody, &newPartner); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newPartner, status, nil
+}
+
+// GetPartner retrieves the configuration for a partner tenant.
+func (c *CrossTenantAccessPolicyClient) GetPartner(ctx context.Context, tenantId string) (*CrossTenantAccessPolicyConfigurationPartner, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/policies/crossTenantAccessPolicy/partners/%s", tenantId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var partner CrossTenantAccessPolicyConfigurationPartner
+	if err := json.Unmarshal(respBody, &partner); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &partner, status, nil
+}
+
+// UpdatePartner amends the configuration for a partner tenant.
+func (c *CrossTenantAccessPolicyClient) UpdatePartner(ctx context.Context, partner CrossTenantAccessPolicyConfigurationPartner) (int, error) {
+	if partner.TenantId == nil {
+		return 0, fmt.Errorf("cannot update partner configuration with nil tenant ID")
+	}
+	tenantId := *partner.TenantId
+	partner.TenantId = nil
+	body, err := json.Marshal(partner)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/policies/crossTenantAccessPolicy/partners/%s", tenantId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
+
+// DeletePartner removes the configuration for a partner tenant.
+func (c *CrossTenantAccessPolicyClient) DeletePartner(ctx context.Context, tenantId string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/policies/crossTenantAccessPolicy/partners/%s", tenantId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
+
+// GetDefault retrieves the default configuration for the tenant.
+func (c *CrossTenantAccessPolicyClient) GetDefault(ctx context.Context) (*CrossTenantAccessPolicyConfigurationDefault, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      "/policies/crossTenantAccessPolicy/default",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var defaultConfig CrossTenantAccessPolicyConfigurationDefault
+	if err := json.Unmarshal(respBody, &defaultConfig); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &defaultConfig, status, nil
+}
+
+// UpdateDefault amends the default configuration for the tenant.
+func (c *CrossTenantAccessPolicyClient) UpdateDefault(ctx context.Context, defaultConfig CrossTenantAccessPolicyConfigurationDefault) (int, error) {
+	defaultConfig.IsServiceDefault = nil
+	body, err := json.Marshal(defaultConfig)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      "/policies/crossTenantAccessPolicy/default",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
+
+// ResetDefault restores the default configuration for the tenant to the system defaults.
+func (c *CrossTenantAccessPolicyClient) ResetDefault(ctx context.Context) (int, error) {
+	_, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      "/policies/crossTenantAccessPolicy/default/resetToSystemDefault",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("CrossTenantAccessPolicyClient.BaseClient.Post(): %v", err)
+	}
+	return status, nil
+}
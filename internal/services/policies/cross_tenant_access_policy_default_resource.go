@@ -0,0 +1,111 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+)
+
+// crossTenantAccessPolicyDefaultId is the resource ID for the default configuration, of which there is one per tenant
+const crossTenantAccessPolicyDefaultId = "default"
+
+func crossTenantAccessPolicyDefaultResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: crossTenantAccessPolicyDefaultResourceCreateUpdate,
+		ReadContext:   crossTenantAccessPolicyDefaultResourceRead,
+		UpdateContext: crossTenantAccessPolicyDefaultResourceCreateUpdate,
+		DeleteContext: crossTenantAccessPolicyDefaultResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if id != crossTenantAccessPolicyDefaultId {
+				return fmt.Errorf("specified ID (%q) is not valid, the ID of the default configuration is %q", id, crossTenantAccessPolicyDefaultId)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"b2b_collaboration_inbound": crossTenantAccessPolicyB2BSettingSchema("Default settings for users from other tenants collaborating in this tenant", true),
+
+			"b2b_collaboration_outbound": crossTenantAccessPolicyB2BSettingSchema("Default settings for users in this tenant collaborating in other tenants", true),
+
+			"inbound_trust": crossTenantAccessPolicyInboundTrustSchema(true),
+
+			"service_default": {
+				Description: "Whether the default configuration is the service default, i.e. it has not been customized",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// crossTenantAccessPolicyDefaultResourceCreateUpdate updates the default configuration. The default configuration always
+// exists, so it is adopted on creation and settings which are not configured retain their existing values.
+func crossTenantAccessPolicyDefaultResourceCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	properties := expandCrossTenantAccessPolicyDefault(d)
+	if properties.B2BCollaborationInbound != nil || properties.B2BCollaborationOutbound != nil || properties.InboundTrust != nil {
+		if status, err := client.UpdateDefault(ctx, properties); err != nil {
+			err = helpers.PermissionsError(err, status, helpers.PermissionsOperationCrossTenantAccessWrite, meta.(*clients.Client).Claims)
+			return tf.ErrorDiagF(err, "Updating default cross-tenant access configuration")
+		}
+	}
+
+	d.SetId(crossTenantAccessPolicyDefaultId)
+
+	return crossTenantAccessPolicyDefaultResourceRead(ctx, d, meta)
+}
+
+func crossTenantAccessPolicyDefaultResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	defaultConfig, _, err := client.GetDefault(ctx)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving default cross-tenant access configuration")
+	}
+
+	tf.Set(d, "b2b_collaboration_inbound", flattenCrossTenantAccessPolicyB2BSetting(defaultConfig.B2BCollaborationInbound))
+	tf.Set(d, "b2b_collaboration_outbound", flattenCrossTenantAccessPolicyB2BSetting(defaultConfig.B2BCollaborationOutbound))
+	tf.Set(d, "inbound_trust", flattenCrossTenantAccessPolicyInboundTrust(defaultConfig.InboundTrust))
+	tf.Set(d, "service_default", defaultConfig.IsServiceDefault != nil && *defaultConfig.IsServiceDefault)
+
+	return nil
+}
+
+// crossTenantAccessPolicyDefaultResourceDelete restores the system defaults, since the default configuration cannot be
+// removed
+func crossTenantAccessPolicyDefaultResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	if _, err := client.ResetDefault(ctx); err != nil {
+		return tf.ErrorDiagF(err, "Resetting default cross-tenant access configuration to system defaults")
+	}
+
+	return nil
+}
+
+// expandCrossTenantAccessPolicyDefault returns the settings for the default configuration. Settings which are not
+// configured are omitted on creation, and on update will hold their existing values since they are Computed.
+func expandCrossTenantAccessPolicyDefault(d *schema.ResourceData) client.CrossTenantAccessPolicyConfigurationDefault {
+	return client.CrossTenantAccessPolicyConfigurationDefault{
+		B2BCollaborationInbound:  expandCrossTenantAccessPolicyB2BSetting(d.Get("b2b_collaboration_inbound").([]interface{})),
+		B2BCollaborationOutbound: expandCrossTenantAccessPolicyB2BSetting(d.Get("b2b_collaboration_outbound").([]interface{})),
+		InboundTrust:             expandCrossTenantAccessPolicyInboundTrust(d.Get("inbound_trust").([]interface{})),
+	}
+}
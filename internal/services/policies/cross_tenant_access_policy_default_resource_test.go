@@ -0,0 +1,73 @@
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type CrossTenantAccessPolicyDefaultResource struct{}
+
+// The default configuration is a singleton, so all changes to it are made in a single test to avoid conflicts
+func TestAccCrossTenantAccessPolicyDefault_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_cross_tenant_access_policy_default", "test")
+	r := CrossTenantAccessPolicyDefaultResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.adopt(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("b2b_collaboration_inbound.#").HasValue("1"),
+				check.That(data.ResourceName).Key("b2b_collaboration_outbound.#").HasValue("1"),
+				check.That(data.ResourceName).Key("inbound_trust.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.inboundTrust(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("inbound_trust.0.mfa_accepted").HasValue("true"),
+				check.That(data.ResourceName).Key("service_default").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r CrossTenantAccessPolicyDefaultResource) Exists(ctx context.Context, clients *clients.Client, _ *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.CrossTenantAccessPolicyClient
+	client.BaseClient.DisableRetries = true
+
+	defaultConfig, _, err := client.GetDefault(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve default cross-tenant access configuration: %+v", err)
+	}
+
+	// The default configuration cannot be removed, so it is considered to exist only when it has been customized
+	return utils.Bool(defaultConfig.IsServiceDefault == nil || !*defaultConfig.IsServiceDefault), nil
+}
+
+func (CrossTenantAccessPolicyDefaultResource) adopt(_ acceptance.TestData) string {
+	return `
+resource "azuread_cross_tenant_access_policy_default" "test" {}
+`
+}
+
+func (CrossTenantAccessPolicyDefaultResource) inboundTrust(_ acceptance.TestData) string {
+	return `
+resource "azuread_cross_tenant_access_policy_default" "test" {
+  inbound_trust {
+    mfa_accepted = true
+  }
+}
+`
+}
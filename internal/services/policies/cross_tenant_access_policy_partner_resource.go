@@ -0,0 +1,146 @@
+package policies
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func crossTenantAccessPolicyPartnerResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: crossTenantAccessPolicyPartnerResourceCreate,
+		ReadContext:   crossTenantAccessPolicyPartnerResourceRead,
+		UpdateContext: crossTenantAccessPolicyPartnerResourceUpdate,
+		DeleteContext: crossTenantAccessPolicyPartnerResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			if _, err := uuid.ParseUUID(id); err != nil {
+				return fmt.Errorf("specified ID (%q) is not a valid tenant ID: %s", id, err)
+			}
+			return nil
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"tenant_id": {
+				Description:      "The tenant ID of the partner organization",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"b2b_collaboration_inbound": crossTenantAccessPolicyB2BSettingSchema("Settings for users from the partner tenant collaborating in this tenant. When not specified, the default configuration applies", false),
+
+			"b2b_collaboration_outbound": crossTenantAccessPolicyB2BSettingSchema("Settings for users in this tenant collaborating in the partner tenant. When not specified, the default configuration applies", false),
+
+			"inbound_trust": crossTenantAccessPolicyInboundTrustSchema(false),
+		},
+	}
+}
+
+func crossTenantAccessPolicyPartnerResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+	tenantId := d.Get("tenant_id").(string)
+
+	_, status, err := client.GetPartner(ctx, tenantId)
+	if err == nil {
+		return tf.ImportAsExistsDiag("azuread_cross_tenant_access_policy_partner", tenantId)
+	} else if status != http.StatusNotFound {
+		return tf.ErrorDiagPathF(err, "tenant_id", "Checking for existing partner configuration for tenant %q", tenantId)
+	}
+
+	partner := expandCrossTenantAccessPolicyPartner(d)
+	if _, status, err := client.CreatePartner(ctx, partner); err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationCrossTenantAccessWrite, meta.(*clients.Client).Claims)
+		return tf.ErrorDiagF(err, "Creating partner configuration for tenant %q", tenantId)
+	}
+
+	d.SetId(tenantId)
+
+	return crossTenantAccessPolicyPartnerResourceRead(ctx, d, meta)
+}
+
+func crossTenantAccessPolicyPartnerResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	if status, err := client.UpdatePartner(ctx, expandCrossTenantAccessPolicyPartner(d)); err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationCrossTenantAccessWrite, meta.(*clients.Client).Claims)
+		return tf.ErrorDiagF(err, "Updating partner configuration for tenant %q", d.Id())
+	}
+
+	return crossTenantAccessPolicyPartnerResourceRead(ctx, d, meta)
+}
+
+func crossTenantAccessPolicyPartnerResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	partner, status, err := client.GetPartner(ctx, d.Id())
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Partner configuration for tenant %q was not found - removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving partner configuration for tenant %q", d.Id())
+	}
+
+	tf.Set(d, "b2b_collaboration_inbound", flattenCrossTenantAccessPolicyB2BSetting(partner.B2BCollaborationInbound))
+	tf.Set(d, "b2b_collaboration_outbound", flattenCrossTenantAccessPolicyB2BSetting(partner.B2BCollaborationOutbound))
+	tf.Set(d, "inbound_trust", flattenCrossTenantAccessPolicyInboundTrust(partner.InboundTrust))
+	tf.Set(d, "tenant_id", d.Id())
+
+	return nil
+}
+
+func crossTenantAccessPolicyPartnerResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Policies.CrossTenantAccessPolicyClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "cross-tenant access partner configuration",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.GetPartner(ctx, d.Id())
+			return status, err
+		},
+	}
+
+	_, status, err := client.GetPartner(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.DeletePartner(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
+}
+
+func expandCrossTenantAccessPolicyPartner(d *schema.ResourceData) client.CrossTenantAccessPolicyConfigurationPartner {
+	return client.CrossTenantAccessPolicyConfigurationPartner{
+		TenantId:                 utils.String(d.Get("tenant_id").(string)),
+		B2BCollaborationInbound:  expandCrossTenantAccessPolicyB2BSetting(d.Get("b2b_collaboration_inbound").([]interface{})),
+		B2BCollaborationOutbound: expandCrossTenantAccessPolicyB2BSetting(d.Get("b2b_collaboration_outbound").([]interface{})),
+		InboundTrust:             expandCrossTenantAccessPolicyInboundTrust(d.Get("inbound_trust").([]interface{})),
+	}
+}
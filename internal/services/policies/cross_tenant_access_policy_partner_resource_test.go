@@ -0,0 +1,164 @@
+package policies_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type CrossTenantAccessPolicyPartnerResource struct{}
+
+// partnerTenantId is the tenant ID of a well-known organization, used as the partner tenant
+const partnerTenantId = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+
+func TestAccCrossTenantAccessPolicyPartner_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_cross_tenant_access_policy_partner", "test")
+	r := CrossTenantAccessPolicyPartnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tenant_id").HasValue(partnerTenantId),
+				check.That(data.ResourceName).Key("b2b_collaboration_inbound.#").HasValue("0"),
+				check.That(data.ResourceName).Key("inbound_trust.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCrossTenantAccessPolicyPartner_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_cross_tenant_access_policy_partner", "test")
+	r := CrossTenantAccessPolicyPartnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.complete(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("b2b_collaboration_inbound.0.users_and_groups.0.access_type").HasValue("allowed"),
+				check.That(data.ResourceName).Key("b2b_collaboration_outbound.0.applications.0.access_type").HasValue("blocked"),
+				check.That(data.ResourceName).Key("inbound_trust.0.mfa_accepted").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccCrossTenantAccessPolicyPartner_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_cross_tenant_access_policy_partner", "test")
+	r := CrossTenantAccessPolicyPartnerResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("b2b_collaboration_inbound.#").HasValue("0"),
+				check.That(data.ResourceName).Key("inbound_trust.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (r CrossTenantAccessPolicyPartnerResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Policies.CrossTenantAccessPolicyClient
+	client.BaseClient.DisableRetries = true
+
+	_, status, err := client.GetPartner(ctx, state.ID)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Partner configuration for tenant %q does not exist", state.ID)
+		}
+		return nil, fmt.Errorf("failed to retrieve partner configuration for tenant %q: %+v", state.ID, err)
+	}
+
+	return utils.Bool(true), nil
+}
+
+func (CrossTenantAccessPolicyPartnerResource) basic(_ acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_cross_tenant_access_policy_partner" "test" {
+  tenant_id = "%[1]s"
+}
+`, partnerTenantId)
+}
+
+func (CrossTenantAccessPolicyPartnerResource) complete(_ acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_cross_tenant_access_policy_partner" "test" {
+  tenant_id = "%[1]s"
+
+  b2b_collaboration_inbound {
+    applications {
+      access_type = "allowed"
+
+      target {
+        target      = "AllApplications"
+        target_type = "application"
+      }
+    }
+
+    users_and_groups {
+      access_type = "allowed"
+
+      target {
+        target      = "AllUsers"
+        target_type = "user"
+      }
+    }
+  }
+
+  b2b_collaboration_outbound {
+    applications {
+      access_type = "blocked"
+
+      target {
+        target      = "AllApplications"
+        target_type = "application"
+      }
+    }
+
+    users_and_groups {
+      access_type = "allowed"
+
+      target {
+        target      = "AllUsers"
+        target_type = "user"
+      }
+    }
+  }
+
+  inbound_trust {
+    mfa_accepted              = true
+    compliant_device_accepted = true
+  }
+}
+`, partnerTenantId)
+}
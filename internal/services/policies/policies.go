@@ -0,0 +1,214 @@
+package policies
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+// crossTenantAccessPolicyB2BSettingSchema returns the schema for a B2B collaboration setting. For the default
+// configuration, which always has a value, the setting is Computed so that it is adopted when not configured.
+func crossTenantAccessPolicyB2BSettingSchema(description string, computed bool) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    computed,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"applications": crossTenantAccessPolicyTargetConfigurationSchema("The applications to which this setting applies", "application IDs, or `AllApplications`"),
+
+				"users_and_groups": crossTenantAccessPolicyTargetConfigurationSchema("The users and groups to which this setting applies", "user or group object IDs, or `AllUsers`"),
+			},
+		},
+	}
+}
+
+func crossTenantAccessPolicyTargetConfigurationSchema(description, targetsDescription string) *schema.Schema {
+	return &schema.Schema{
+		Description: description,
+		Type:        schema.TypeList,
+		Required:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"access_type": {
+					Description: "Whether access is allowed or blocked for the targets. Possible values are `allowed` or `blocked`",
+					Type:        schema.TypeString,
+					Required:    true,
+					ValidateFunc: validation.StringInSlice([]string{
+						client.CrossTenantAccessPolicyAccessTypeAllowed,
+						client.CrossTenantAccessPolicyAccessTypeBlocked,
+					}, false),
+				},
+
+				"target": {
+					Description: "The targets to which access is allowed or blocked",
+					Type:        schema.TypeSet,
+					Required:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"target": {
+								Description:      "The target, one of " + targetsDescription,
+								Type:             schema.TypeString,
+								Required:         true,
+								ValidateDiagFunc: validate.NoEmptyStrings,
+							},
+
+							"target_type": {
+								Description: "The type of the target. Possible values are `application`, `group` or `user`",
+								Type:        schema.TypeString,
+								Required:    true,
+								ValidateFunc: validation.StringInSlice([]string{
+									client.CrossTenantAccessPolicyTargetTypeApplication,
+									client.CrossTenantAccessPolicyTargetTypeGroup,
+									client.CrossTenantAccessPolicyTargetTypeUser,
+								}, false),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func crossTenantAccessPolicyInboundTrustSchema(computed bool) *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether claims from the partner tenant's Azure AD are trusted when evaluating conditional access policies for inbound users",
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    computed,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"compliant_device_accepted": {
+					Description: "Whether compliant device claims from the partner tenant are trusted",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+
+				"hybrid_azure_ad_joined_device_accepted": {
+					Description: "Whether hybrid Azure AD joined device claims from the partner tenant are trusted",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+
+				"mfa_accepted": {
+					Description: "Whether multi-factor authentication claims from the partner tenant are trusted",
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+				},
+			},
+		},
+	}
+}
+
+func expandCrossTenantAccessPolicyB2BSetting(in []interface{}) *client.CrossTenantAccessPolicyB2BSetting {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	setting := in[0].(map[string]interface{})
+
+	return &client.CrossTenantAccessPolicyB2BSetting{
+		Applications:   expandCrossTenantAccessPolicyTargetConfiguration(setting["applications"].([]interface{})),
+		UsersAndGroups: expandCrossTenantAccessPolicyTargetConfiguration(setting["users_and_groups"].([]interface{})),
+	}
+}
+
+func expandCrossTenantAccessPolicyTargetConfiguration(in []interface{}) *client.CrossTenantAccessPolicyTargetConfiguration {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	targets := make([]client.CrossTenantAccessPolicyTarget, 0)
+	for _, raw := range config["target"].(*schema.Set).List() {
+		target := raw.(map[string]interface{})
+		targets = append(targets, client.CrossTenantAccessPolicyTarget{
+			Target:     utils.String(target["target"].(string)),
+			TargetType: utils.String(target["target_type"].(string)),
+		})
+	}
+
+	return &client.CrossTenantAccessPolicyTargetConfiguration{
+		AccessType: utils.String(config["access_type"].(string)),
+		Targets:    &targets,
+	}
+}
+
+func expandCrossTenantAccessPolicyInboundTrust(in []interface{}) *client.CrossTenantAccessPolicyInboundTrust {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	trust := in[0].(map[string]interface{})
+
+	return &client.CrossTenantAccessPolicyInboundTrust{
+		IsCompliantDeviceAccepted:           utils.Bool(trust["compliant_device_accepted"].(bool)),
+		IsHybridAzureADJoinedDeviceAccepted: utils.Bool(trust["hybrid_azure_ad_joined_device_accepted"].(bool)),
+		IsMfaAccepted:                       utils.Bool(trust["mfa_accepted"].(bool)),
+	}
+}
+
+func flattenCrossTenantAccessPolicyB2BSetting(in *client.CrossTenantAccessPolicyB2BSetting) []map[string]interface{} {
+	if in == nil || (in.Applications == nil && in.UsersAndGroups == nil) {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"applications":     flattenCrossTenantAccessPolicyTargetConfiguration(in.Applications),
+		"users_and_groups": flattenCrossTenantAccessPolicyTargetConfiguration(in.UsersAndGroups),
+	}}
+}
+
+func flattenCrossTenantAccessPolicyTargetConfiguration(in *client.CrossTenantAccessPolicyTargetConfiguration) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	accessType := ""
+	if in.AccessType != nil {
+		accessType = *in.AccessType
+	}
+
+	targets := make([]interface{}, 0)
+	if in.Targets != nil {
+		for _, t := range *in.Targets {
+			target, targetType := "", ""
+			if t.Target != nil {
+				target = *t.Target
+			}
+			if t.TargetType != nil {
+				targetType = *t.TargetType
+			}
+			targets = append(targets, map[string]interface{}{
+				"target":      target,
+				"target_type": targetType,
+			})
+		}
+	}
+
+	return []map[string]interface{}{{
+		"access_type": accessType,
+		"target":      targets,
+	}}
+}
+
+func flattenCrossTenantAccessPolicyInboundTrust(in *client.CrossTenantAccessPolicyInboundTrust) []map[string]interface{} {
+	if in == nil {
+		return []map[string]interface{}{}
+	}
+
+	return []map[string]interface{}{{
+		"compliant_device_accepted":              in.IsCompliantDeviceAccepted != nil && *in.IsCompliantDeviceAccepted,
+		"hybrid_azure_ad_joined_device_accepted": in.IsHybridAzureADJoinedDeviceAccepted != nil && *in.IsHybridAzureADJoinedDeviceAccepted,
+		"mfa_accepted":                           in.IsMfaAccepted != nil && *in.IsMfaAccepted,
+	}}
+}
@@ -0,0 +1,87 @@
+package policies
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/policies/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestCrossTenantAccessPolicyB2BSettingRoundTrip(t *testing.T) {
+	target := crossTenantAccessPolicyB2BSettingSchema("", false).Elem.(*schema.Resource).Schema["users_and_groups"].Elem.(*schema.Resource).Schema["target"]
+	targets := schema.NewSet(schema.HashResource(target.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{
+			"target":      "AllUsers",
+			"target_type": client.CrossTenantAccessPolicyTargetTypeUser,
+		},
+	})
+	applicationTargets := schema.NewSet(schema.HashResource(target.Elem.(*schema.Resource)), []interface{}{
+		map[string]interface{}{
+			"target":      "00000000-0000-0000-0000-000000000000",
+			"target_type": client.CrossTenantAccessPolicyTargetTypeApplication,
+		},
+	})
+
+	setting := expandCrossTenantAccessPolicyB2BSetting([]interface{}{map[string]interface{}{
+		"applications": []interface{}{map[string]interface{}{
+			"access_type": client.CrossTenantAccessPolicyAccessTypeBlocked,
+			"target":      applicationTargets,
+		}},
+		"users_and_groups": []interface{}{map[string]interface{}{
+			"access_type": client.CrossTenantAccessPolicyAccessTypeAllowed,
+			"target":      targets,
+		}},
+	}})
+	if setting == nil || setting.Applications == nil || setting.UsersAndGroups == nil {
+		t.Fatalf("expected applications and users_and_groups to be expanded, got %+v", setting)
+	}
+
+	result := flattenCrossTenantAccessPolicyB2BSetting(setting)
+	if len(result) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(result))
+	}
+
+	usersAndGroups := result[0]["users_and_groups"].([]map[string]interface{})
+	if len(usersAndGroups) != 1 || usersAndGroups[0]["access_type"] != client.CrossTenantAccessPolicyAccessTypeAllowed {
+		t.Fatalf("unexpected users_and_groups: %+v", usersAndGroups)
+	}
+	if targets := usersAndGroups[0]["target"].([]interface{}); len(targets) != 1 || targets[0].(map[string]interface{})["target"] != "AllUsers" {
+		t.Fatalf("unexpected users_and_groups targets: %+v", targets)
+	}
+
+	applications := result[0]["applications"].([]map[string]interface{})
+	if len(applications) != 1 || applications[0]["access_type"] != client.CrossTenantAccessPolicyAccessTypeBlocked {
+		t.Fatalf("unexpected applications: %+v", applications)
+	}
+
+	if result := flattenCrossTenantAccessPolicyB2BSetting(nil); len(result) != 0 {
+		t.Fatalf("expected no result for nil setting, got %d", len(result))
+	}
+}
+
+func TestCrossTenantAccessPolicyPartnerInheritsUnsetSettings(t *testing.T) {
+	partner := client.CrossTenantAccessPolicyConfigurationPartner{
+		TenantId: utils.String("00000000-0000-0000-0000-000000000000"),
+		InboundTrust: expandCrossTenantAccessPolicyInboundTrust([]interface{}{map[string]interface{}{
+			"compliant_device_accepted":              false,
+			"hybrid_azure_ad_joined_device_accepted": false,
+			"mfa_accepted":                           true,
+		}}),
+	}
+
+	body, err := json.Marshal(partner)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+
+	// Unset settings must be sent as null so that a removed setting reverts to inheriting the default configuration
+	for _, expected := range []string{`"b2bCollaborationInbound":null`, `"b2bCollaborationOutbound":null`, `"isMfaAccepted":true`} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected request body to contain %s, got %s", expected, body)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func appRoleAssignmentResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: appRoleAssignmentResourceCreate,
+		ReadContext:   appRoleAssignmentResourceRead,
+		DeleteContext: appRoleAssignmentResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.AppRoleAssignmentID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"app_role_id": {
+				Description:      "The ID of the app role to be assigned, or the default role ID `00000000-0000-0000-0000-000000000000`",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"principal_object_id": {
+				Description:      "The object ID of the user, group or service principal to be assigned this app role",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"resource_object_id": {
+				Description:      "The object ID of the service principal representing the resource",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"principal_display_name": {
+				Description: "The display name of the principal to which the app role is assigned",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"principal_type": {
+				Description: "The object type of the principal to which the app role is assigned",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"resource_display_name": {
+				Description: "The display name of the application representing the resource",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func appRoleAssignmentResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	appRoleId := d.Get("app_role_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+
+	tf.LockByName(servicePrincipalResourceName, resourceId)
+	defer tf.UnlockByName(servicePrincipalResourceName, resourceId)
+
+	resourceServicePrincipal, status, err := helpers.WaitForParentServicePrincipal(ctx, client, resourceId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, []string{resourceId})
+			return tf.ErrorDiagPathF(err, "resource_object_id", "Resource service principal with object ID %q was not found", resourceId)
+		}
+		return tf.ErrorDiagPathF(err, "resource_object_id", "Retrieving resource service principal with object ID %q", resourceId)
+	}
+
+	if err := servicePrincipalValidateAppRoleId(resourceServicePrincipal.AppRoles, appRoleId); err != nil {
+		return tf.ErrorDiagPathF(err, "app_role_id", "Invalid app role for resource service principal with object ID %q", resourceId)
+	}
+
+	assignments, _, err := client.ListAppRoleAssignments(ctx, resourceId)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing app role assignments for resource service principal with object ID %q", resourceId)
+	}
+	existing := servicePrincipalAppRoleAssignmentMatch(assignments, func(v msgraph.AppRoleAssignment) bool {
+		return v.PrincipalId != nil && strings.EqualFold(*v.PrincipalId, principalId) && v.AppRoleId != nil && strings.EqualFold(*v.AppRoleId, appRoleId)
+	})
+	if existing != nil && existing.Id != nil {
+		return tf.ImportAsExistsDiag("azuread_app_role_assignment", parse.NewAppRoleAssignmentID(resourceId, *existing.Id).String())
+	}
+
+	assignment, status, err := client.AssignAppRoleForResource(ctx, principalId, resourceId, appRoleId)
+	if err != nil {
+		err = meta.(*clients.Client).IdConfusion().PrincipalObjectIdsError(ctx, err, status, []string{principalId})
+		return tf.ErrorDiagF(err, "Assigning principal %q to app role %q for resource service principal with object ID %q", principalId, appRoleId, resourceId)
+	}
+	if assignment.Id == nil || *assignment.Id == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for app role assignment is nil/empty"), "Bad API response")
+	}
+
+	id := parse.NewAppRoleAssignmentID(resourceId, *assignment.Id)
+	d.SetId(id.String())
+
+	// Listing assignments immediately after creating one may not yet include it, so wait for it to be consistently
+	// listed in order that the subsequent read does not remove it from state
+	waitClient := *client
+	waitClient.BaseClient.DisableRetries = true
+	_, _, err = helpers.ParentWait{ParentType: "app role assignment", ObjectId: id.AssignmentId}.Wait(ctx, func(ctx context.Context) (interface{}, int, error) {
+		return appRoleAssignmentFind(ctx, &waitClient, id)
+	})
+	if err != nil {
+		return tf.ErrorDiagF(err, "Waiting for app role assignment %q for resource service principal with object ID %q", id.AssignmentId, resourceId)
+	}
+
+	return appRoleAssignmentResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	id, err := parse.AppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing app role assignment with ID %q", d.Id())
+	}
+
+	assignment, status, err := appRoleAssignmentFind(ctx, client, *id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] %v - removing from state", err)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Listing app role assignments for resource service principal with object ID %q", id.ResourceId)
+	}
+
+	tf.Set(d, "app_role_id", assignment.AppRoleId)
+	tf.Set(d, "principal_display_name", assignment.PrincipalDisplayName)
+	tf.Set(d, "principal_object_id", assignment.PrincipalId)
+	tf.Set(d, "principal_type", assignment.PrincipalType)
+	tf.Set(d, "resource_display_name", assignment.ResourceDisplayName)
+	tf.Set(d, "resource_object_id", id.ResourceId)
+
+	return nil
+}
+
+func appRoleAssignmentResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	id, err := parse.AppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing app role assignment with ID %q", d.Id())
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ResourceId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ResourceId)
+
+	if status, err := client.RemoveAppRoleAssignment(ctx, id.ResourceId, id.AssignmentId); err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] App role assignment %q was not found for resource service principal %q - assuming already deleted", id.AssignmentId, id.ResourceId)
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Removing app role assignment %q from resource service principal with object ID %q", id.AssignmentId, id.ResourceId)
+	}
+
+	return nil
+}
+
+// appRoleAssignmentFind returns the app role assignment identified by id, along with the HTTP status of the request.
+// A 404 status is returned when either the resource service principal or the assignment does not exist.
+func appRoleAssignmentFind(ctx context.Context, client *msgraph.ServicePrincipalsClient, id parse.AppRoleAssignmentId) (*msgraph.AppRoleAssignment, int, error) {
+	assignments, status, err := client.ListAppRoleAssignments(ctx, id.ResourceId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, status, fmt.Errorf("resource service principal with object ID %q was not found", id.ResourceId)
+		}
+		return nil, status, err
+	}
+
+	assignment := servicePrincipalAppRoleAssignmentMatch(assignments, func(v msgraph.AppRoleAssignment) bool {
+		return v.Id != nil && *v.Id == id.AssignmentId
+	})
+	if assignment == nil {
+		return nil, http.StatusNotFound, fmt.Errorf("app role assignment %q was not found for resource service principal with object ID %q", id.AssignmentId, id.ResourceId)
+	}
+
+	return assignment, status, nil
+}
@@ -0,0 +1,189 @@
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type AppRoleAssignmentResource struct{}
+
+func TestAccAppRoleAssignment_user(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignment", "test")
+	r := AppRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.user(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_display_name").HasValue(fmt.Sprintf("acctestUser-%d", data.RandomInteger)),
+				check.That(data.ResourceName).Key("principal_type").HasValue("User"),
+				check.That(data.ResourceName).Key("resource_display_name").HasValue(fmt.Sprintf("acctest-AppRoleAssignment-%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignment_groupDefaultAccess(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignment", "test")
+	r := AppRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupDefaultAccess(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("app_role_id").HasValue("00000000-0000-0000-0000-000000000000"),
+				check.That(data.ResourceName).Key("principal_type").HasValue("Group"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignment_servicePrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignment", "test")
+	r := AppRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.servicePrincipal(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("principal_type").HasValue("ServicePrincipal"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccAppRoleAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_app_role_assignment", "test")
+	r := AppRoleAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.user(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r AppRoleAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalsClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.AppRoleAssignmentID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing App Role Assignment ID: %v", err)
+	}
+
+	assignments, status, err := client.ListAppRoleAssignments(ctx, id.ResourceId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Service Principal with object ID %q does not exist", id.ResourceId)
+		}
+		return nil, fmt.Errorf("failed to list app role assignments for Service Principal %q: %+v", id.ResourceId, err)
+	}
+
+	if assignments != nil {
+		for _, v := range *assignments {
+			if v.Id != nil && *v.Id == id.AssignmentId {
+				return utils.Bool(true), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("App Role Assignment %q was not found for Service Principal %q", id.AssignmentId, id.ResourceId)
+}
+
+func (AppRoleAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctest-AppRoleAssignment-%[1]d"
+
+  app_role {
+    allowed_member_types = ["User", "Application"]
+    description          = "Admins can perform all task actions"
+    display_name         = "Admin"
+    enabled              = true
+    id                   = "%[2]s"
+    value                = "Admin.All"
+  }
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+}
+`, data.RandomInteger, data.RandomID)
+}
+
+func (r AppRoleAssignmentResource) user(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "azuread_app_role_assignment" "test" {
+  app_role_id         = "%[3]s"
+  principal_object_id = local.shared_user_ids[0]
+  resource_object_id  = azuread_service_principal.test.object_id
+}
+`, r.template(data), data.SharedPrerequisites(), data.RandomID)
+}
+
+func (r AppRoleAssignmentResource) groupDefaultAccess(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[2]d"
+  security_enabled = true
+}
+
+resource "azuread_app_role_assignment" "test" {
+  app_role_id         = "00000000-0000-0000-0000-000000000000"
+  principal_object_id = azuread_group.test.object_id
+  resource_object_id  = azuread_service_principal.test.object_id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r AppRoleAssignmentResource) servicePrincipal(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+%[2]s
+
+resource "azuread_app_role_assignment" "test" {
+  app_role_id         = "%[3]s"
+  principal_object_id = local.shared_service_principal_object_id
+  resource_object_id  = azuread_service_principal.test.object_id
+}
+`, r.template(data), data.SharedPrerequisites(), data.RandomID)
+}
+
+func (r AppRoleAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_app_role_assignment" "import" {
+  app_role_id         = azuread_app_role_assignment.test.app_role_id
+  principal_object_id = azuread_app_role_assignment.test.principal_object_id
+  resource_object_id  = azuread_app_role_assignment.test.resource_object_id
+}
+`, r.user(data))
+}
@@ -7,14 +7,34 @@ import (
 )
 
 type Client struct {
-	ServicePrincipalsClient *msgraph.ServicePrincipalsClient
+	ServicePrincipalsClient                                 *msgraph.ServicePrincipalsClient
+	ServicePrincipalClaimsMappingPolicyClient               *ServicePrincipalClaimsMappingPolicyClient
+	ServicePrincipalDelegatedPermissionClassificationClient *ServicePrincipalDelegatedPermissionClassificationClient
+	ServicePrincipalNotesClient                             *ServicePrincipalNotesClient
+	ServicePrincipalTokenSigningCertificateClient           *ServicePrincipalTokenSigningCertificateClient
 }
 
 func NewClient(o *common.ClientOptions) *Client {
 	msClient := msgraph.NewServicePrincipalsClient(o.TenantID)
 	o.ConfigureClient(&msClient.BaseClient)
 
+	claimsMappingPolicyClient := NewServicePrincipalClaimsMappingPolicyClient(o.TenantID)
+	o.ConfigureClient(&claimsMappingPolicyClient.BaseClient)
+
+	delegatedPermissionClassificationClient := NewServicePrincipalDelegatedPermissionClassificationClient(o.TenantID)
+	o.ConfigureClient(&delegatedPermissionClassificationClient.BaseClient)
+
+	notesClient := NewServicePrincipalNotesClient(o.TenantID)
+	o.ConfigureClient(&notesClient.BaseClient)
+
+	tokenSigningCertificateClient := NewServicePrincipalTokenSigningCertificateClient(o.TenantID)
+	o.ConfigureClient(&tokenSigningCertificateClient.BaseClient)
+
 	return &Client{
-		ServicePrincipalsClient: msClient,
+		ServicePrincipalsClient:                                 msClient,
+		ServicePrincipalClaimsMappingPolicyClient:               claimsMappingPolicyClient,
+		ServicePrincipalDelegatedPermissionClassificationClient: delegatedPermissionClassificationClient,
+		ServicePrincipalNotesClient:                             notesClient,
+		ServicePrincipalTokenSigningCertificateClient:           tokenSigningCertificateClient,
 	}
 }
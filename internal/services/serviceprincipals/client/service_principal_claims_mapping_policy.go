@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+// ServicePrincipalClaimsMappingPolicyClient manages the assignment of claims mapping policies to Service Principals.
+type ServicePrincipalClaimsMappingPolicyClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewServicePrincipalClaimsMappingPolicyClient returns a new ServicePrincipalClaimsMappingPolicyClient.
+func NewServicePrincipalClaimsMappingPolicyClient(tenantId string) *ServicePrincipalClaimsMappingPolicyClient {
+	return &ServicePrincipalClaimsMappingPolicyClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// List returns the object IDs of the claims mapping policies assigned to a Service Principal.
+func (c *ServicePrincipalClaimsMappingPolicyClient) List(ctx context.Context, id string) (*[]string, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/claimsMappingPolicies", id),
+			Params:      url.Values{"$select": []string{"id"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalClaimsMappingPolicyClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Policies []struct {
+			Id string `json:"id"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	ret := make([]string, len(data.Policies))
+	for i, v := range data.Policies {
+		ret[i] = v.Id
+	}
+	return &ret, status, nil
+}
+
+// Assign assigns a claims mapping policy to a Service Principal.
+func (c *ServicePrincipalClaimsMappingPolicyClient) Assign(ctx context.Context, id, policyId string) (int, error) {
+	// don't fail if the policy is already assigned
+	checkPolicyAlreadyAssigned := func(resp *http.Response, o *odata.OData) bool {
+		if resp.StatusCode == http.StatusBadRequest && o.Error != nil {
+			return o.Error.Match(odata.ErrorAddedObjectReferencesAlreadyExist)
+		}
+		return false
+	}
+
+	data := struct {
+		Policy string `json:"@odata.id"`
+	}{
+		Policy: fmt.Sprintf("%s/%s/policies/claimsMappingPolicies/%s", c.BaseClient.Endpoint, c.BaseClient.ApiVersion, policyId),
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		ValidStatusFunc:        checkPolicyAlreadyAssigned,
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/claimsMappingPolicies/$ref", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalClaimsMappingPolicyClient.BaseClient.Post(): %v", err)
+	}
+	return status, nil
+}
+
+// Remove removes the assignment of a claims mapping policy from a Service Principal.
+func (c *ServicePrincipalClaimsMappingPolicyClient) Remove(ctx context.Context, id, policyId string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/claimsMappingPolicies/%s/$ref", id, policyId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalClaimsMappingPolicyClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// DelegatedPermissionClassification describes the classification of a delegated permission published by a Service Principal.
+type DelegatedPermissionClassification struct {
+	ID             *string `json:"id,omitempty"`
+	Classification *string `json:"classification,omitempty"`
+	PermissionId   *string `json:"permissionId,omitempty"`
+	PermissionName *string `json:"permissionName,omitempty"`
+}
+
+// ServicePrincipalDelegatedPermissionClassificationClient manages the classification of delegated permissions published by Service Principals.
+type ServicePrincipalDelegatedPermissionClassificationClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewServicePrincipalDelegatedPermissionClassificationClient returns a new ServicePrincipalDelegatedPermissionClassificationClient.
+func NewServicePrincipalDelegatedPermissionClassificationClient(tenantId string) *ServicePrincipalDelegatedPermissionClassificationClient {
+	return &ServicePrincipalDelegatedPermissionClassificationClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// List returns the delegated permission classifications for a Service Principal.
+func (c *ServicePrincipalDelegatedPermissionClassificationClient) List(ctx context.Context, id string) (*[]DelegatedPermissionClassification, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/delegatedPermissionClassifications", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalDelegatedPermissionClassificationClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Classifications []DelegatedPermissionClassification `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.Classifications, status, nil
+}
+
+// Create classifies a delegated permission published by a Service Principal.
+func (c *ServicePrincipalDelegatedPermissionClassificationClient) Create(ctx context.Context, id string, classification DelegatedPermissionClassification) (*DelegatedPermissionClassification, int, error) {
+	body, err := json.Marshal(classification)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/delegatedPermissionClassifications", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalDelegatedPermissionClassificationClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newClassification DelegatedPermissionClassification
+	if err := json.Unmarshal(respBody, &newClassification); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newClassification, status, nil
+}
+
+// Delete removes a delegated permission classification from a Service Principal.
+func (c *ServicePrincipalDelegatedPermissionClassificationClient) Delete(ctx context.Context, id, classificationId string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s/delegatedPermissionClassifications/%s", id, classificationId),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalDelegatedPermissionClassificationClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
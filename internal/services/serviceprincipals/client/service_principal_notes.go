@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ServicePrincipalNotesClient manages the free text notes of Service Principals. These are not modelled by
+// msgraph.ServicePrincipal, so they are retrieved and updated separately here.
+type ServicePrincipalNotesClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewServicePrincipalNotesClient returns a new ServicePrincipalNotesClient.
+func NewServicePrincipalNotesClient(tenantId string) *ServicePrincipalNotesClient {
+	return &ServicePrincipalNotesClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Get retrieves the notes for a Service Principal.
+func (c *ServicePrincipalNotesClient) Get(ctx context.Context, id string) (*string, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s", id),
+			Params:      url.Values{"$select": []string{"notes"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("ServicePrincipalNotesClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Notes *string `json:"notes"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return data.Notes, status, nil
+}
+
+// Update sets the notes for a Service Principal. Specifying nil removes any existing notes.
+func (c *ServicePrincipalNotesClient) Update(ctx context.Context, id string, notes *string) (int, error) {
+	body, err := json.Marshal(struct {
+		Notes *string `json:"notes"`
+	}{notes})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/servicePrincipals/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("ServicePrincipalNotesClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
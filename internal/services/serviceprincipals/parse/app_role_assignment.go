@@ -0,0 +1,44 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// AppRoleAssignmentId identifies an assignment of a user, group or service principal to an app role published by a
+// resource service principal. Assignment IDs are opaque strings rather than UUIDs.
+type AppRoleAssignmentId struct {
+	ResourceId   string
+	AssignmentId string
+}
+
+func NewAppRoleAssignmentID(resourceId, assignmentId string) AppRoleAssignmentId {
+	return AppRoleAssignmentId{
+		ResourceId:   resourceId,
+		AssignmentId: assignmentId,
+	}
+}
+
+func (id AppRoleAssignmentId) String() string {
+	return fmt.Sprintf("%s/appRoleAssignment/%s", id.ResourceId, id.AssignmentId)
+}
+
+func AppRoleAssignmentID(idString string) (*AppRoleAssignmentId, error) {
+	parts := strings.Split(idString, "/")
+	if len(parts) != 3 || parts[1] != "appRoleAssignment" {
+		return nil, fmt.Errorf("App Role Assignment ID should be in the format {resourceObjectId}/appRoleAssignment/{assignmentId} - but got %q", idString)
+	}
+
+	id := NewAppRoleAssignmentID(parts[0], parts[2])
+
+	if _, err := uuid.ParseUUID(id.ResourceId); err != nil {
+		return nil, fmt.Errorf("Resource Object ID isn't a valid UUID (%q): %+v", id.ResourceId, err)
+	}
+	if id.AssignmentId == "" {
+		return nil, fmt.Errorf("Assignment ID in {resourceObjectId}/appRoleAssignment/{assignmentId} should not be empty")
+	}
+
+	return &id, nil
+}
@@ -0,0 +1,44 @@
+package parse
+
+import (
+	"testing"
+)
+
+func TestAppRoleAssignmentID(t *testing.T) {
+	const (
+		resourceId   = "22222222-2222-2222-2222-222222222222"
+		assignmentId = "6gS4tJ8bWk-9yJ-W3JtTsBfDoGq0uRZIpXDqwKSm8C8"
+	)
+
+	cases := []struct {
+		input string
+		valid bool
+	}{
+		{input: resourceId + "/appRoleAssignment/" + assignmentId, valid: true},
+		{input: resourceId + "/member/" + assignmentId},
+		{input: resourceId + "/appRoleAssignment/"},
+		{input: "foo/appRoleAssignment/" + assignmentId},
+		{input: resourceId + "/appRoleAssignment/" + assignmentId + "/appRoleAssignment/" + assignmentId},
+		{input: resourceId},
+		{input: ""},
+	}
+
+	for _, c := range cases {
+		id, err := AppRoleAssignmentID(c.input)
+		if !c.valid {
+			if err == nil {
+				t.Errorf("expected error parsing %q", c.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", c.input, err)
+		}
+		if id.ResourceId != resourceId || id.AssignmentId != assignmentId {
+			t.Fatalf("unexpected result parsing %q: %+v", c.input, id)
+		}
+		if id.String() != c.input {
+			t.Fatalf("expected %q, got %q", c.input, id.String())
+		}
+	}
+}
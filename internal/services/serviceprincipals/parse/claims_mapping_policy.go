@@ -0,0 +1,30 @@
+package parse
+
+import "fmt"
+
+type ClaimsMappingPolicyAssignmentId struct {
+	ObjectSubResourceId
+	ServicePrincipalId string
+	PolicyId           string
+}
+
+func NewClaimsMappingPolicyAssignmentID(servicePrincipalId, policyId string) ClaimsMappingPolicyAssignmentId {
+	return ClaimsMappingPolicyAssignmentId{
+		ObjectSubResourceId: NewObjectSubResourceID(servicePrincipalId, "claimsMappingPolicy", policyId),
+		ServicePrincipalId:  servicePrincipalId,
+		PolicyId:            policyId,
+	}
+}
+
+func ClaimsMappingPolicyAssignmentID(idString string) (*ClaimsMappingPolicyAssignmentId, error) {
+	id, err := ObjectSubResourceID(idString, "claimsMappingPolicy")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Claims Mapping Policy Assignment ID: %v", err)
+	}
+
+	return &ClaimsMappingPolicyAssignmentId{
+		ObjectSubResourceId: *id,
+		ServicePrincipalId:  id.objectId,
+		PolicyId:            id.subId,
+	}, nil
+}
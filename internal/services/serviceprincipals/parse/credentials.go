@@ -49,6 +49,19 @@ func PasswordID(idString string) (*CredentialId, error) {
 	}, nil
 }
 
+func TokenSigningCertificateID(idString string) (*CredentialId, error) {
+	id, err := ObjectSubResourceID(idString, "tokenSigningCertificate")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Token Signing Certificate ID: %v", err)
+	}
+
+	return &CredentialId{
+		ObjectId: id.objectId,
+		KeyType:  id.Type,
+		KeyId:    id.subId,
+	}, nil
+}
+
 func OldPasswordID(id string) (*CredentialId, error) {
 	parts := strings.Split(id, "/")
 	if len(parts) != 2 {
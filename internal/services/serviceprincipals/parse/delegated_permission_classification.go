@@ -0,0 +1,30 @@
+package parse
+
+import "fmt"
+
+type DelegatedPermissionClassificationId struct {
+	ObjectSubResourceId
+	ServicePrincipalId string
+	PermissionId       string
+}
+
+func NewDelegatedPermissionClassificationID(servicePrincipalId, permissionId string) DelegatedPermissionClassificationId {
+	return DelegatedPermissionClassificationId{
+		ObjectSubResourceId: NewObjectSubResourceID(servicePrincipalId, "delegatedPermissionClassification", permissionId),
+		ServicePrincipalId:  servicePrincipalId,
+		PermissionId:        permissionId,
+	}
+}
+
+func DelegatedPermissionClassificationID(idString string) (*DelegatedPermissionClassificationId, error) {
+	id, err := ObjectSubResourceID(idString, "delegatedPermissionClassification")
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse Delegated Permission Classification ID: %v", err)
+	}
+
+	return &DelegatedPermissionClassificationId{
+		ObjectSubResourceId: *id,
+		ServicePrincipalId:  id.objectId,
+		PermissionId:        id.subId,
+	}, nil
+}
@@ -21,16 +21,21 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"azuread_client_config":     clientConfigDataSource(),
-		"azuread_service_principal": servicePrincipalData(),
+		"azuread_client_config":      clientConfigDataSource(),
+		"azuread_service_principal":  servicePrincipalData(),
+		"azuread_service_principals": servicePrincipalsDataSource(),
 	}
 }
 
 // SupportedResources returns the supported Resources supported by this Service
 func (r Registration) SupportedResources() map[string]*schema.Resource {
 	return map[string]*schema.Resource{
-		"azuread_service_principal":             servicePrincipalResource(),
-		"azuread_service_principal_certificate": servicePrincipalCertificateResource(),
-		"azuread_service_principal_password":    servicePrincipalPasswordResource(),
+		"azuread_app_role_assignment":                                   appRoleAssignmentResource(),
+		"azuread_service_principal":                                     servicePrincipalResource(),
+		"azuread_service_principal_claims_mapping_policy_assignment":    servicePrincipalClaimsMappingPolicyAssignmentResource(),
+		"azuread_service_principal_certificate":                         servicePrincipalCertificateResource(),
+		"azuread_service_principal_delegated_permission_classification": servicePrincipalDelegatedPermissionClassificationResource(),
+		"azuread_service_principal_password":                            servicePrincipalPasswordResource(),
+		"azuread_service_principal_token_signing_certificate":           servicePrincipalTokenSigningCertificateResource(),
 	}
 }
@@ -3,9 +3,7 @@ package serviceprincipals
 import (
 	"context"
 	"errors"
-	"fmt"
 	"log"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -94,7 +92,7 @@ func servicePrincipalCertificateResource() *schema.Resource {
 				Optional:         true,
 				ForceNew:         true,
 				ConflictsWith:    []string{"end_date"},
-				ValidateDiagFunc: validate.NoEmptyStrings,
+				ValidateDiagFunc: validate.Duration,
 			},
 
 			"type": {
@@ -140,12 +138,9 @@ func servicePrincipalCertificateResourceCreate(ctx context.Context, d *schema.Re
 	tf.LockByName(servicePrincipalResourceName, id.ObjectId)
 	defer tf.UnlockByName(servicePrincipalResourceName, id.ObjectId)
 
-	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(nil, "service_principal_id", "Service principal with object ID %q was not found", id.ObjectId)
-		}
-		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving service principal with object ID %q", id.ObjectId)
+	app, status, err := helpers.WaitForParentServicePrincipal(ctx, client, id.ObjectId)
+	if diags := servicePrincipalCredentialParent("azuread_service_principal_certificate", id.ObjectId).CheckCreate(status, err); diags.HasError() {
+		return diags
 	}
 
 	newCredentials := make([]msgraph.KeyCredential, 0)
@@ -182,13 +177,8 @@ func servicePrincipalCertificateResourceRead(ctx context.Context, d *schema.Reso
 	}
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			log.Printf("[DEBUG] Service Principal with ID %q for %s credential %q was not found - removing from state!", id.ObjectId, id.KeyType, id.KeyId)
-			d.SetId("")
-			return nil
-		}
-		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving service principal with object ID %q", id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_certificate", id.ObjectId).CheckRead(d, status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	var credential *msgraph.KeyCredential
@@ -238,11 +228,8 @@ func servicePrincipalCertificateResourceDelete(ctx context.Context, d *schema.Re
 	defer tf.UnlockByName(servicePrincipalResourceName, id.ObjectId)
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("Service Principal was not found"), "service_principal_id", "Retrieving service principal with object ID %q", id.ObjectId)
-		}
-		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving service principal with object ID %q", id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_certificate", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	newCredentials := make([]msgraph.KeyCredential, 0)
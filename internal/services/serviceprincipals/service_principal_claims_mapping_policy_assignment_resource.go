@@ -0,0 +1,63 @@
+package serviceprincipals
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+)
+
+func servicePrincipalClaimsMappingPolicyAssignmentResource() *schema.Resource {
+	return servicePrincipalClaimsMappingPolicyAssignmentRelationship().Resource()
+}
+
+func servicePrincipalClaimsMappingPolicyAssignmentRelationship() helpers.RelationshipResource {
+	return helpers.RelationshipResource{
+		Name:               "azuread_service_principal_claims_mapping_policy_assignment",
+		ParentType:         "service principal",
+		ParentAttribute:    "service_principal_id",
+		ParentDescription:  "The object ID of the service principal to which the policy should be assigned",
+		RelatedType:        "claims mapping policy",
+		RelatedAttribute:   "claims_mapping_policy_id",
+		RelatedDescription: "The object ID of the claims mapping policy to assign",
+		LockName:           servicePrincipalResourceName,
+		MissingIsRemoved:   true,
+
+		StrictDelete: func(meta interface{}) bool {
+			return meta.(*clients.Client).StrictDelete
+		},
+
+		FormatId: func(servicePrincipalId, policyId string) string {
+			return parse.NewClaimsMappingPolicyAssignmentID(servicePrincipalId, policyId).String()
+		},
+
+		ParseId: func(idString string) (string, string, error) {
+			id, err := parse.ClaimsMappingPolicyAssignmentID(idString)
+			if err != nil {
+				return "", "", err
+			}
+			return id.ServicePrincipalId, id.PolicyId, nil
+		},
+
+		GetParent: func(ctx context.Context, meta interface{}, servicePrincipalId string) (int, error) {
+			_, status, err := helpers.WaitForParentServicePrincipal(ctx, meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient, servicePrincipalId)
+			return status, err
+		},
+
+		List: func(ctx context.Context, meta interface{}, servicePrincipalId string) (*[]string, int, error) {
+			return meta.(*clients.Client).ServicePrincipals.ServicePrincipalClaimsMappingPolicyClient.List(ctx, servicePrincipalId)
+		},
+
+		Add: func(ctx context.Context, meta interface{}, servicePrincipalId, policyId string) error {
+			_, err := meta.(*clients.Client).ServicePrincipals.ServicePrincipalClaimsMappingPolicyClient.Assign(ctx, servicePrincipalId, policyId)
+			return helpers.GraphError(err)
+		},
+
+		Remove: func(ctx context.Context, meta interface{}, servicePrincipalId, policyId string) (int, error) {
+			return meta.(*clients.Client).ServicePrincipals.ServicePrincipalClaimsMappingPolicyClient.Remove(ctx, servicePrincipalId, policyId)
+		},
+	}
+}
@@ -0,0 +1,132 @@
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type ServicePrincipalClaimsMappingPolicyAssignmentResource struct{}
+
+func TestAccServicePrincipalClaimsMappingPolicyAssignment_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_claims_mapping_policy_assignment", "test")
+	r := ServicePrincipalClaimsMappingPolicyAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalClaimsMappingPolicyAssignment_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_claims_mapping_policy_assignment", "test")
+	r := ServicePrincipalClaimsMappingPolicyAssignmentResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r ServicePrincipalClaimsMappingPolicyAssignmentResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalClaimsMappingPolicyClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.ClaimsMappingPolicyAssignmentID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Claims Mapping Policy Assignment ID: %v", err)
+	}
+
+	policies, status, err := client.List(ctx, id.ServicePrincipalId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Service Principal with object ID %q does not exist", id.ServicePrincipalId)
+		}
+		return nil, fmt.Errorf("failed to list claims mapping policies for Service Principal %q: %+v", id.ServicePrincipalId, err)
+	}
+
+	if policies != nil {
+		for _, v := range *policies {
+			if strings.EqualFold(v, id.PolicyId) {
+				return utils.Bool(true), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Claims Mapping Policy %q was not assigned to Service Principal %q", id.PolicyId, id.ServicePrincipalId)
+}
+
+func (ServicePrincipalClaimsMappingPolicyAssignmentResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+}
+
+resource "azuread_claims_mapping_policy" "test" {
+  display_name = "acctest-CMP-%[1]d"
+
+  definition = [
+    jsonencode({
+      ClaimsMappingPolicy = {
+        Version              = 1
+        IncludeBasicClaimSet = "true"
+        ClaimsSchema = [
+          {
+            Source       = "user"
+            ID           = "employeeid"
+            JwtClaimType = "employeeid"
+          },
+        ]
+      }
+    }),
+  ]
+}
+`, data.RandomInteger)
+}
+
+func (r ServicePrincipalClaimsMappingPolicyAssignmentResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_claims_mapping_policy_assignment" "test" {
+  service_principal_id     = azuread_service_principal.test.id
+  claims_mapping_policy_id = azuread_claims_mapping_policy.test.id
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalClaimsMappingPolicyAssignmentResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_claims_mapping_policy_assignment" "import" {
+  service_principal_id     = azuread_service_principal_claims_mapping_policy_assignment.test.service_principal_id
+  claims_mapping_policy_id = azuread_service_principal_claims_mapping_policy_assignment.test.claims_mapping_policy_id
+}
+`, r.basic(data))
+}
@@ -31,7 +31,8 @@ func servicePrincipalData() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"application_id", "client_id", "display_name"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
@@ -40,7 +41,8 @@ func servicePrincipalData() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"application_id", "client_id", "object_id"},
 				ValidateDiagFunc: validate.NoEmptyStrings,
 			},
 
@@ -49,10 +51,65 @@ func servicePrincipalData() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ExactlyOneOf:     []string{"application_id", "display_name", "object_id"},
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"display_name", "object_id"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
+			"client_id": {
+				Description:      "The client ID (application ID) of the application associated with this service principal",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				AtLeastOneOf:     []string{"application_id", "client_id", "display_name", "object_id"},
+				ConflictsWith:    []string{"display_name", "object_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"include_member_of": {
+				Description: "Whether to retrieve the object IDs of groups that the service principal is a member of, either directly or transitively, into the `member_of` attribute",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"fail_if_not_found": tf.DataSourceFailIfNotFoundSchema("service principal"),
+
+			"found": tf.DataSourceFoundSchema("service principal"),
+
+			"member_of": {
+				Description: "A list of object IDs of groups that the service principal is a member of, either directly or transitively. Only populated when `include_member_of` is `true`",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"app_owner_organization_id": {
+				Description: "The tenant ID where the associated application is registered",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"application_template_id": {
+				Description: "The ID of the application template from which the associated application was created, if it was created from the gallery",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"homepage_url": {
+				Description: "Home page or landing page of the associated application",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"sign_in_audience": {
+				Description: "The Microsoft account types that are supported for the associated application",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"app_roles": schemaAppRolesComputed(),
 
 			"oauth2_permission_scopes": schemaOauth2PermissionScopesComputed(),
@@ -70,7 +127,7 @@ func servicePrincipalDataSourceRead(ctx context.Context, d *schema.ResourceData,
 		sp, status, err := client.Get(ctx, objectId)
 		if err != nil {
 			if status == http.StatusNotFound {
-				return tf.ErrorDiagPathF(nil, "object_id", "Service principal with object ID %q was not found", objectId)
+				return tf.DataSourceNotFound(d, "object_id", objectId, tf.ErrorDiagPathF(nil, "object_id", "Service principal with object ID %q was not found", objectId))
 			}
 
 			return tf.ErrorDiagPathF(err, "object_id", "Retrieving service principal with object ID %q", objectId)
@@ -79,7 +136,7 @@ func servicePrincipalDataSourceRead(ctx context.Context, d *schema.ResourceData,
 		servicePrincipal = sp
 	} else if _, ok := d.GetOk("display_name"); ok {
 		displayName := d.Get("display_name").(string)
-		filter := fmt.Sprintf("displayName eq '%s'", displayName)
+		filter := helpers.ODataEq("displayName", displayName)
 
 		result, _, err := client.List(ctx, filter)
 		if err != nil {
@@ -101,33 +158,22 @@ func servicePrincipalDataSourceRead(ctx context.Context, d *schema.ResourceData,
 		}
 
 		if servicePrincipal == nil {
-			return tf.ErrorDiagF(nil, "No service principal found matching display name: %q", displayName)
+			return tf.DataSourceNotFound(d, "display_name", displayName, tf.ErrorDiagF(nil, "No service principal found matching display name: %q", displayName))
 		}
 	} else {
-		applicationId := d.Get("application_id").(string)
-		filter := fmt.Sprintf("appId eq '%s'", applicationId)
-
-		result, _, err := client.List(ctx, filter)
+		applicationId, applicationIdKey, err := tf.GetAliasedString(d, "application_id", "client_id")
 		if err != nil {
-			return tf.ErrorDiagF(err, "Listing service principals for filter %q", filter)
+			return tf.ErrorDiagPathF(err, "client_id", "Conflicting arguments")
 		}
-		if result == nil {
-			return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
-		}
-
-		for _, sp := range *result {
-			if sp.AppId == nil {
-				continue
-			}
 
-			if *sp.AppId == applicationId {
-				servicePrincipal = &sp
-				break
-			}
+		// Service principals for Microsoft-published APIs are looked up using the provider's cache
+		servicePrincipal, err = meta.(*clients.Client).ServicePrincipalByAppId(ctx, applicationId)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Retrieving service principal for application ID %q", applicationId)
 		}
 
 		if servicePrincipal == nil {
-			return tf.ErrorDiagF(nil, "No service principal found for application ID: %q", applicationId)
+			return tf.DataSourceNotFound(d, applicationIdKey, applicationId, tf.ErrorDiagF(nil, "No service principal found for application ID: %q", applicationId))
 		}
 	}
 
@@ -137,11 +183,27 @@ func servicePrincipalDataSourceRead(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(*servicePrincipal.ID)
 
+	tf.Set(d, "app_owner_organization_id", servicePrincipal.AppOwnerOrganizationId)
 	tf.Set(d, "app_roles", helpers.ApplicationFlattenAppRoles(servicePrincipal.AppRoles))
 	tf.Set(d, "application_id", servicePrincipal.AppId)
+	tf.Set(d, "application_template_id", servicePrincipal.ApplicationTemplateId)
+	tf.Set(d, "client_id", servicePrincipal.AppId)
 	tf.Set(d, "display_name", servicePrincipal.DisplayName)
+	tf.Set(d, "homepage_url", servicePrincipal.Homepage)
+	tf.Set(d, "found", true)
 	tf.Set(d, "oauth2_permission_scopes", helpers.ApplicationFlattenOAuth2PermissionScopes(servicePrincipal.PublishedPermissionScopes))
 	tf.Set(d, "object_id", servicePrincipal.ID)
+	tf.Set(d, "sign_in_audience", string(servicePrincipal.SignInAudience))
+
+	var diags diag.Diagnostics
+	memberOf := make([]string, 0)
+	if d.Get("include_member_of").(bool) {
+		memberOf, diags = helpers.FlattenTransitiveMemberOf(ctx, client.BaseClient, fmt.Sprintf("/servicePrincipals/%s", *servicePrincipal.ID))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	tf.Set(d, "member_of", memberOf)
 
-	return nil
+	return diags
 }
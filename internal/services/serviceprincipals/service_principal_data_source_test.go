@@ -20,7 +20,9 @@ func TestAccServicePrincipalDataSource_byApplicationId(t *testing.T) {
 		{
 			Config: r.byApplicationId(data),
 			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("app_owner_organization_id").Exists(),
 				check.That(data.ResourceName).Key("application_id").Exists(),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", data.ResourceName, "application_id"),
 				check.That(data.ResourceName).Key("object_id").Exists(),
 				check.That(data.ResourceName).Key("display_name").Exists(),
 				check.That(data.ResourceName).Key("app_roles.#").HasValue("2"),
@@ -30,6 +32,23 @@ func TestAccServicePrincipalDataSource_byApplicationId(t *testing.T) {
 	})
 }
 
+func TestAccServicePrincipalDataSource_byClientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principal", "test")
+	r := ServicePrincipalDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.byClientId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("client_id").IsUuid(),
+				check.That(data.ResourceName).Key("object_id").Exists(),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", data.ResourceName, "application_id"),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", "azuread_service_principal.test", "client_id"),
+			),
+		},
+	})
+}
+
 func TestAccServicePrincipalDataSource_byDisplayName(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_service_principal", "test")
 	r := ServicePrincipalDataSource{}
@@ -66,6 +85,21 @@ func TestAccServicePrincipalDataSource_byObjectId(t *testing.T) {
 	})
 }
 
+func TestAccServicePrincipalDataSource_memberOf(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principal", "test")
+	r := ServicePrincipalDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: r.memberOf(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("object_id").Exists(),
+				check.That(data.ResourceName).Key("member_of.#").HasValue("2"),
+			),
+		},
+	})
+}
+
 func (ServicePrincipalDataSource) byApplicationId(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -76,6 +110,16 @@ data "azuread_service_principal" "test" {
 `, ServicePrincipalResource{}.complete(data))
 }
 
+func (ServicePrincipalDataSource) byClientId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principal" "test" {
+  client_id = azuread_service_principal.test.client_id
+}
+`, ServicePrincipalResource{}.complete(data))
+}
+
 func (ServicePrincipalDataSource) byDisplayName(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -95,3 +139,28 @@ data "azuread_service_principal" "test" {
 }
 `, ServicePrincipalResource{}.complete(data))
 }
+
+func (ServicePrincipalDataSource) memberOf(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "direct" {
+  display_name     = "acctestServicePrincipal-direct-%[2]d"
+  security_enabled = true
+  members          = [azuread_service_principal.test.object_id]
+}
+
+resource "azuread_group" "transitive" {
+  display_name     = "acctestServicePrincipal-transitive-%[2]d"
+  security_enabled = true
+  members          = [azuread_group.direct.object_id]
+}
+
+data "azuread_service_principal" "test" {
+  object_id         = azuread_service_principal.test.object_id
+  include_member_of = true
+
+  depends_on = [azuread_group.transitive]
+}
+`, ServicePrincipalResource{}.complete(data), data.RandomInteger)
+}
@@ -0,0 +1,223 @@
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+// servicePrincipalDelegatedPermissionClassifications are the classifications which can be assigned to delegated
+// permissions. Microsoft Graph also defines "medium" and "high", which are not yet supported.
+var servicePrincipalDelegatedPermissionClassifications = []string{"low"}
+
+func servicePrincipalDelegatedPermissionClassificationResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: servicePrincipalDelegatedPermissionClassificationResourceCreate,
+		ReadContext:   servicePrincipalDelegatedPermissionClassificationResourceRead,
+		DeleteContext: servicePrincipalDelegatedPermissionClassificationResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.DelegatedPermissionClassificationID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"service_principal_object_id": {
+				Description:      "The object ID of the service principal publishing the delegated permission",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"permission_id": {
+				Description:      "The ID of the delegated permission to classify",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"permission_id", "permission_name"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"permission_name": {
+				Description:      "The name of the delegated permission to classify",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"permission_id", "permission_name"},
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"classification": {
+				Description:  "The classification of the delegated permission",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(servicePrincipalDelegatedPermissionClassifications, false),
+			},
+		},
+	}
+}
+
+func servicePrincipalDelegatedPermissionClassificationResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	servicePrincipalsClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	classificationClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalDelegatedPermissionClassificationClient
+	servicePrincipalId := d.Get("service_principal_object_id").(string)
+
+	tf.LockByName(servicePrincipalResourceName, servicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, servicePrincipalId)
+
+	servicePrincipal, status, err := helpers.WaitForParentServicePrincipal(ctx, servicePrincipalsClient, servicePrincipalId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return tf.ErrorDiagPathF(nil, "service_principal_object_id", "Service principal with object ID %q was not found", servicePrincipalId)
+		}
+		return tf.ErrorDiagPathF(err, "service_principal_object_id", "Retrieving service principal with object ID %q", servicePrincipalId)
+	}
+
+	permissionId := d.Get("permission_id").(string)
+	permissionName := d.Get("permission_name").(string)
+	scope, err := servicePrincipalFindPermissionScope(servicePrincipal.PublishedPermissionScopes, permissionId, permissionName)
+	if err != nil {
+		if permissionId != "" {
+			return tf.ErrorDiagPathF(err, "permission_id", "Resolving delegated permission for service principal with object ID %q", servicePrincipalId)
+		}
+		return tf.ErrorDiagPathF(err, "permission_name", "Resolving delegated permission for service principal with object ID %q", servicePrincipalId)
+	}
+
+	id := parse.NewDelegatedPermissionClassificationID(servicePrincipalId, *scope.ID)
+
+	existing, err := servicePrincipalDelegatedPermissionClassificationFind(ctx, classificationClient, id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing delegated permission classifications for service principal with object ID %q", id.ServicePrincipalId)
+	}
+	if existing != nil {
+		return tf.ImportAsExistsDiag("azuread_service_principal_delegated_permission_classification", id.String())
+	}
+
+	properties := client.DelegatedPermissionClassification{
+		Classification: utils.String(d.Get("classification").(string)),
+		PermissionId:   scope.ID,
+		PermissionName: scope.Value,
+	}
+
+	classification, _, err := classificationClient.Create(ctx, id.ServicePrincipalId, properties)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Classifying delegated permission %q for service principal with object ID %q", *scope.Value, id.ServicePrincipalId)
+	}
+	if classification.ID == nil || *classification.ID == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for delegated permission classification is nil/empty"), "Bad API response")
+	}
+
+	d.SetId(id.String())
+
+	return servicePrincipalDelegatedPermissionClassificationResourceRead(ctx, d, meta)
+}
+
+func servicePrincipalDelegatedPermissionClassificationResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	classificationClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalDelegatedPermissionClassificationClient
+
+	id, err := parse.DelegatedPermissionClassificationID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing delegated permission classification with ID %q", d.Id())
+	}
+
+	classifications, status, err := classificationClient.List(ctx, id.ServicePrincipalId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Service principal with object ID %q was not found - removing delegated permission classification %q from state", id.ServicePrincipalId, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Listing delegated permission classifications for service principal with object ID %q", id.ServicePrincipalId)
+	}
+
+	classification := servicePrincipalDelegatedPermissionClassificationMatch(classifications, id.PermissionId)
+	if classification == nil {
+		log.Printf("[DEBUG] Delegated permission %q is not classified for service principal %q - removing from state", id.PermissionId, id.ServicePrincipalId)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "classification", classification.Classification)
+	tf.Set(d, "permission_id", id.PermissionId)
+	tf.Set(d, "permission_name", classification.PermissionName)
+	tf.Set(d, "service_principal_object_id", id.ServicePrincipalId)
+
+	return nil
+}
+
+func servicePrincipalDelegatedPermissionClassificationResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	classificationClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalDelegatedPermissionClassificationClient
+
+	id, err := parse.DelegatedPermissionClassificationID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing delegated permission classification with ID %q", d.Id())
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ServicePrincipalId)
+
+	classification, err := servicePrincipalDelegatedPermissionClassificationFind(ctx, classificationClient, *id)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Listing delegated permission classifications for service principal with object ID %q", id.ServicePrincipalId)
+	}
+	if classification == nil || classification.ID == nil {
+		log.Printf("[DEBUG] Delegated permission %q is not classified for service principal %q - assuming already deleted", id.PermissionId, id.ServicePrincipalId)
+		return nil
+	}
+
+	if status, err := classificationClient.Delete(ctx, id.ServicePrincipalId, *classification.ID); err != nil && status != http.StatusNotFound {
+		return tf.ErrorDiagF(err, "Removing classification of delegated permission %q from service principal with object ID %q", id.PermissionId, id.ServicePrincipalId)
+	}
+
+	return nil
+}
+
+// servicePrincipalDelegatedPermissionClassificationFind returns the classification of the delegated permission
+// identified by id, or nil when the permission is not classified or the service principal does not exist.
+func servicePrincipalDelegatedPermissionClassificationFind(ctx context.Context, classificationClient *client.ServicePrincipalDelegatedPermissionClassificationClient, id parse.DelegatedPermissionClassificationId) (*client.DelegatedPermissionClassification, error) {
+	classifications, status, err := classificationClient.List(ctx, id.ServicePrincipalId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return servicePrincipalDelegatedPermissionClassificationMatch(classifications, id.PermissionId), nil
+}
+
+func servicePrincipalDelegatedPermissionClassificationMatch(classifications *[]client.DelegatedPermissionClassification, permissionId string) *client.DelegatedPermissionClassification {
+	if classifications == nil {
+		return nil
+	}
+	for _, v := range *classifications {
+		if v.PermissionId != nil && strings.EqualFold(*v.PermissionId, permissionId) {
+			return &v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package serviceprincipals_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type ServicePrincipalDelegatedPermissionClassificationResource struct{}
+
+func TestAccServicePrincipalDelegatedPermissionClassification_byName(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_delegated_permission_classification", "test")
+	r := ServicePrincipalDelegatedPermissionClassificationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.byName(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permission_id").IsUuid(),
+				check.That(data.ResourceName).Key("permission_name").HasValue("User.ReadBasic.All"),
+				check.That(data.ResourceName).Key("classification").HasValue("low"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalDelegatedPermissionClassification_byId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_delegated_permission_classification", "test")
+	r := ServicePrincipalDelegatedPermissionClassificationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.byId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("permission_id").HasValue("b340eb25-3456-403f-be2f-af7a0d370277"),
+				check.That(data.ResourceName).Key("permission_name").HasValue("User.ReadBasic.All"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipalDelegatedPermissionClassification_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal_delegated_permission_classification", "test")
+	r := ServicePrincipalDelegatedPermissionClassificationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.byName(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport(data)),
+	})
+}
+
+func (r ServicePrincipalDelegatedPermissionClassificationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.ServicePrincipals.ServicePrincipalDelegatedPermissionClassificationClient
+	client.BaseClient.DisableRetries = true
+
+	id, err := parse.DelegatedPermissionClassificationID(state.ID)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Delegated Permission Classification ID: %v", err)
+	}
+
+	classifications, status, err := client.List(ctx, id.ServicePrincipalId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Service Principal with object ID %q does not exist", id.ServicePrincipalId)
+		}
+		return nil, fmt.Errorf("failed to list delegated permission classifications for Service Principal %q: %+v", id.ServicePrincipalId, err)
+	}
+
+	if classifications != nil {
+		for _, v := range *classifications {
+			if v.PermissionId != nil && strings.EqualFold(*v.PermissionId, id.PermissionId) {
+				return utils.Bool(true), nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("Delegated Permission %q was not classified for Service Principal %q", id.PermissionId, id.ServicePrincipalId)
+}
+
+func (ServicePrincipalDelegatedPermissionClassificationResource) template(data acceptance.TestData) string {
+	return `
+data "azuread_service_principal" "msgraph" {
+  application_id = "00000003-0000-0000-c000-000000000000"
+}
+`
+}
+
+func (r ServicePrincipalDelegatedPermissionClassificationResource) byName(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_delegated_permission_classification" "test" {
+  service_principal_object_id = data.azuread_service_principal.msgraph.object_id
+  permission_name             = "User.ReadBasic.All"
+  classification              = "low"
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalDelegatedPermissionClassificationResource) byId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_delegated_permission_classification" "test" {
+  service_principal_object_id = data.azuread_service_principal.msgraph.object_id
+  permission_id               = "b340eb25-3456-403f-be2f-af7a0d370277"
+  classification              = "low"
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalDelegatedPermissionClassificationResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_service_principal_delegated_permission_classification" "import" {
+  service_principal_object_id = azuread_service_principal_delegated_permission_classification.test.service_principal_object_id
+  permission_name             = azuread_service_principal_delegated_permission_classification.test.permission_name
+  classification              = azuread_service_principal_delegated_permission_classification.test.classification
+}
+`, r.byName(data))
+}
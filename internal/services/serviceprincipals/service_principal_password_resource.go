@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -104,12 +103,9 @@ func servicePrincipalPasswordResourceCreate(ctx context.Context, d *schema.Resou
 	tf.LockByName(servicePrincipalResourceName, objectId)
 	defer tf.UnlockByName(servicePrincipalResourceName, objectId)
 
-	sp, status, err := client.Get(ctx, objectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(nil, "service_principal_id", "Service principal with object ID %q was not found", objectId)
-		}
-		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving service principal with object ID %q", objectId)
+	sp, status, err := helpers.WaitForParentServicePrincipal(ctx, client, objectId)
+	if diags := servicePrincipalCredentialParent("azuread_service_principal_password", objectId).CheckCreate(status, err); diags.HasError() {
+		return diags
 	}
 	if sp == nil || sp.ID == nil {
 		return tf.ErrorDiagF(errors.New("nil service principal or service principal with nil ID was returned"), "API error retrieving service principal with object ID %q", objectId)
@@ -145,13 +141,8 @@ func servicePrincipalPasswordResourceRead(ctx context.Context, d *schema.Resourc
 	}
 
 	app, status, err := client.Get(ctx, id.ObjectId)
-	if err != nil {
-		if status == http.StatusNotFound {
-			log.Printf("[DEBUG] Service Principal with ID %q for %s credential %q was not found - removing from state!", id.ObjectId, id.KeyType, id.KeyId)
-			d.SetId("")
-			return nil
-		}
-		return tf.ErrorDiagPathF(err, "service_principal_id", "Retrieving service principal with object ID %q", id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_password", id.ObjectId).CheckRead(d, status, err); gone || diags.HasError() {
+		return diags
 	}
 
 	var credential *msgraph.PasswordCredential
@@ -200,6 +191,11 @@ func servicePrincipalPasswordResourceDelete(ctx context.Context, d *schema.Resou
 	tf.LockByName(servicePrincipalResourceName, id.ObjectId)
 	defer tf.UnlockByName(servicePrincipalResourceName, id.ObjectId)
 
+	_, status, err := client.Get(ctx, id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_password", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
+	}
+
 	if _, err := client.RemovePassword(ctx, id.ObjectId, id.KeyId); err != nil {
 		return tf.ErrorDiagF(err, "Removing password credential %q from service principal with object ID %q", id.KeyId, id.ObjectId)
 	}
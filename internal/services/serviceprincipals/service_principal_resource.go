@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
@@ -29,6 +30,8 @@ func servicePrincipalResource() *schema.Resource {
 		UpdateContext: servicePrincipalResourceUpdate,
 		DeleteContext: servicePrincipalResourceDelete,
 
+		CustomizeDiff: tf.AliasedStringCustomizeDiff("application_id", "client_id"),
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Minute),
 			Read:   schema.DefaultTimeout(5 * time.Minute),
@@ -36,19 +39,31 @@ func servicePrincipalResource() *schema.Resource {
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
-		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+		Importer: tf.ValidateResourceIDPriorToImportThen(func(id string) error {
 			if _, err := uuid.ParseUUID(id); err != nil {
 				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
 			}
 			return nil
-		}),
+		}, servicePrincipalResourceImport),
 
 		Schema: map[string]*schema.Schema{
 			"application_id": {
 				Description:      "The application ID (client ID) of the application for which to create a service principal",
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"application_id", "client_id"},
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"client_id": {
+				Description:      "The client ID (application ID) of the application for which to create a service principal",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
 				ForceNew:         true,
+				ExactlyOneOf:     []string{"application_id", "client_id"},
 				ValidateDiagFunc: validate.UUID,
 			},
 
@@ -58,6 +73,32 @@ func servicePrincipalResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"login_url": {
+				Description:      "The URL where the service provider redirects the user to Azure AD to authenticate. Azure AD uses the URL to launch the application from Microsoft 365 or the Azure AD My Apps",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.IsHTTPOrHTTPSURL,
+			},
+
+			"notes": {
+				Description: "Free text field to capture information about the service principal, typically used for operational purposes",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"preferred_single_sign_on_mode": {
+				Description: "The single sign-on mode configured for this application. Azure AD uses the preferred single sign-on mode to launch the application from Microsoft 365 or the Azure AD My Apps",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"",
+					"notSupported",
+					"oidc",
+					"password",
+					"saml",
+				}, false),
+			},
+
 			"display_name": {
 				Description: "The display name of the application associated with this service principal",
 				Type:        schema.TypeString,
@@ -70,15 +111,77 @@ func servicePrincipalResource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"app_owner_organization_id": {
+				Description: "The tenant ID where the associated application is registered",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"application_template_id": {
+				Description: "The ID of the application template from which the associated application was created, if it was created from the gallery",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"homepage_url": {
+				Description: "Home page or landing page of the associated application",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"sign_in_audience": {
+				Description: "The Microsoft account types that are supported for the associated application",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"app_roles": schemaAppRolesComputed(),
 
 			"oauth2_permission_scopes": schemaOauth2PermissionScopesComputed(),
 
+			"feature_tags": {
+				Description:   "Block of features to configure for this service principal using tags",
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"tags"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"custom_single_sign_on": {
+							Description: "Whether this service principal represents a custom SAML application",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+
+						"enterprise": {
+							Description: "Whether this service principal represents an Enterprise Application",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+
+						"gallery": {
+							Description: "Whether this service principal represents a gallery application",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+
+						"hide": {
+							Description: "Whether this app is invisible to users in My Apps and Office 365 Launcher",
+							Type:        schema.TypeBool,
+							Optional:    true,
+						},
+					},
+				},
+			},
+
 			"tags": {
-				Description: "A set of tags to apply to the service principal",
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Set:         schema.HashString,
+				Description:   "A set of tags to apply to the service principal",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"feature_tags"},
+				Set:           schema.HashString,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -89,16 +192,31 @@ func servicePrincipalResource() *schema.Resource {
 
 func servicePrincipalResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	notesClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalNotesClient
+
+	applicationId, _, err := tf.GetAliasedString(d, "application_id", "client_id")
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "client_id", "Conflicting arguments")
+	}
 
 	properties := msgraph.ServicePrincipal{
 		AccountEnabled:            utils.Bool(true),
-		AppId:                     utils.String(d.Get("application_id").(string)),
+		AppId:                     utils.String(applicationId),
 		AppRoleAssignmentRequired: utils.Bool(d.Get("app_role_assignment_required").(bool)),
-		Tags:                      tf.ExpandStringSlicePtr(d.Get("tags").(*schema.Set).List()),
+		Tags:                      servicePrincipalExpandTags(d),
+	}
+
+	if v, ok := d.GetOk("login_url"); ok {
+		properties.LoginUrl = utils.String(v.(string))
+	}
+	if v, ok := d.GetOk("preferred_single_sign_on_mode"); ok {
+		properties.PreferredSingleSignOnMode = utils.String(v.(string))
 	}
 
-	servicePrincipal, _, err := client.Create(ctx, properties)
+	servicePrincipal, status, err := client.Create(ctx, properties)
 	if err != nil {
+		err = meta.(*clients.Client).IdConfusion().ClientIdError(ctx, err, status, *properties.AppId)
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationServicePrincipalCreate, meta.(*clients.Client).Claims)
 		return tf.ErrorDiagF(err, "Could not create service principal")
 	}
 	if servicePrincipal.ID == nil || *servicePrincipal.ID == "" {
@@ -106,27 +224,51 @@ func servicePrincipalResourceCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	d.SetId(*servicePrincipal.ID)
 
+	// Notes are not modelled by msgraph.ServicePrincipal, so they are set once the service principal has been created
+	if v, ok := d.GetOk("notes"); ok {
+		if _, err := notesClient.Update(ctx, d.Id(), utils.String(v.(string))); err != nil {
+			return tf.ErrorDiagPathF(err, "notes", "Could not set notes for service principal with object ID: %q", d.Id())
+		}
+	}
+
 	return servicePrincipalResourceRead(ctx, d, meta)
 }
 
 func servicePrincipalResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	notesClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalNotesClient
 
 	properties := msgraph.ServicePrincipal{
 		ID:                        utils.String(d.Id()),
 		AppRoleAssignmentRequired: utils.Bool(d.Get("app_role_assignment_required").(bool)),
-		Tags:                      tf.ExpandStringSlicePtr(d.Get("tags").(*schema.Set).List()),
+		LoginUrl:                  utils.String(d.Get("login_url").(string)),
+		Tags:                      servicePrincipalExpandTags(d),
+	}
+
+	if d.HasChange("preferred_single_sign_on_mode") {
+		properties.PreferredSingleSignOnMode = utils.String(d.Get("preferred_single_sign_on_mode").(string))
 	}
 
 	if _, err := client.Update(ctx, properties); err != nil {
 		return tf.ErrorDiagF(err, "Updating service principal with object ID: %q", d.Id())
 	}
 
+	if d.HasChange("notes") {
+		var notes *string
+		if v := d.Get("notes").(string); v != "" {
+			notes = utils.String(v)
+		}
+		if _, err := notesClient.Update(ctx, d.Id(), notes); err != nil {
+			return tf.ErrorDiagPathF(err, "notes", "Updating notes for service principal with object ID: %q", d.Id())
+		}
+	}
+
 	return servicePrincipalResourceRead(ctx, d, meta)
 }
 
 func servicePrincipalResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	notesClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalNotesClient
 	objectId := d.Id()
 
 	servicePrincipal, status, err := client.Get(ctx, objectId)
@@ -140,33 +282,72 @@ func servicePrincipalResourceRead(ctx context.Context, d *schema.ResourceData, m
 		return tf.ErrorDiagF(err, "retrieving service principal with object ID: %q", d.Id())
 	}
 
+	notes, _, err := notesClient.Get(ctx, objectId)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "notes", "Could not retrieve notes for service principal with object ID: %q", d.Id())
+	}
+
 	tf.Set(d, "app_role_assignment_required", servicePrincipal.AppRoleAssignmentRequired)
+	tf.Set(d, "app_owner_organization_id", servicePrincipal.AppOwnerOrganizationId)
 	tf.Set(d, "app_roles", helpers.ApplicationFlattenAppRoles(servicePrincipal.AppRoles))
 	tf.Set(d, "application_id", servicePrincipal.AppId)
+	tf.Set(d, "application_template_id", servicePrincipal.ApplicationTemplateId)
+	tf.Set(d, "client_id", servicePrincipal.AppId)
 	tf.Set(d, "display_name", servicePrincipal.DisplayName)
+	tf.Set(d, "homepage_url", servicePrincipal.Homepage)
+	tf.Set(d, "feature_tags", servicePrincipalFlattenFeatureTags(servicePrincipal.Tags))
+	tf.Set(d, "login_url", servicePrincipal.LoginUrl)
+	tf.Set(d, "notes", notes)
 	tf.Set(d, "oauth2_permission_scopes", helpers.ApplicationFlattenOAuth2PermissionScopes(servicePrincipal.PublishedPermissionScopes))
 	tf.Set(d, "object_id", servicePrincipal.ID)
+	tf.Set(d, "preferred_single_sign_on_mode", servicePrincipal.PreferredSingleSignOnMode)
+	tf.Set(d, "sign_in_audience", string(servicePrincipal.SignInAudience))
 	tf.Set(d, "tags", servicePrincipal.Tags)
 
 	return nil
 }
 
-func servicePrincipalResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+// servicePrincipalExpandTags returns the tags to apply to the service principal. When features are configured using the
+// `feature_tags` block, which conflicts with `tags`, the existing tags are retained apart from those denoting features.
+func servicePrincipalExpandTags(d *schema.ResourceData) *[]string {
+	if v, ok := d.GetOk("feature_tags"); ok && d.HasChange("feature_tags") {
+		existingTags := *tf.ExpandStringSlicePtr(d.Get("tags").(*schema.Set).List())
+		tags := servicePrincipalExpandFeatureTags(v.([]interface{}), existingTags)
+		return &tags
+	}
+	return tf.ExpandStringSlicePtr(d.Get("tags").(*schema.Set).List())
+}
+
+func servicePrincipalResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
 
-	_, status, err := client.Get(ctx, d.Id())
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("Service Principal was not found"), "id", "Retrieving service principal with object ID %q", d.Id())
-		}
+	if err := helpers.ValidateDirectoryObjectType(ctx, client.BaseClient, d.Id(), helpers.DirectoryObjectTypeServicePrincipal); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
 
-		return tf.ErrorDiagPathF(err, "id", "Retrieving service principal with object ID %q", d.Id())
+func servicePrincipalResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "service principal",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
 	}
 
-	status, err = client.Delete(ctx, d.Id())
-	if err != nil {
-		return tf.ErrorDiagPathF(err, "id", "Deleting service principal with object ID %q, got status %d", d.Id(), status)
+	_, status, err := client.Get(ctx, d.Id())
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
 	}
 
-	return nil
+	status, err = client.Delete(ctx, d.Id())
+	return deletion.CheckDeleted(ctx, status, err)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -43,12 +44,50 @@ func TestAccServicePrincipal_complete(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 				check.That(data.ResourceName).Key("app_roles.#").HasValue("2"),
 				check.That(data.ResourceName).Key("oauth2_permission_scopes.#").HasValue("2"),
+				check.That(data.ResourceName).Key("login_url").HasValue("https://example.com/login"),
+				check.That(data.ResourceName).Key("notes").HasValue("Owned by the identity team"),
+				check.That(data.ResourceName).Key("preferred_single_sign_on_mode").HasValue("saml"),
 			),
 		},
 		data.ImportStep(),
 	})
 }
 
+func TestAccServicePrincipal_clientId(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.clientId(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("client_id").IsUuid(),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", data.ResourceName, "application_id"),
+				resource.TestCheckResourceAttrPair(data.ResourceName, "client_id", "azuread_application.test", "client_id"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// Switching between the argument names should not cause any changes
+			Config:   r.basic(data),
+			PlanOnly: true,
+		},
+	})
+}
+
+func TestAccServicePrincipal_conflictingIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.conflictingIds(data),
+			ExpectError: regexp.MustCompile("only one of `application_id,client_id` can be specified"),
+		},
+	})
+}
+
 func TestAccServicePrincipal_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
 	r := ServicePrincipalResource{}
@@ -78,6 +117,97 @@ func TestAccServicePrincipal_update(t *testing.T) {
 	})
 }
 
+func TestAccServicePrincipal_featureTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.featureTags(data, true, true, false, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("2"),
+				check.That(data.ResourceName).Key("feature_tags.0.custom_single_sign_on").HasValue("true"),
+				check.That(data.ResourceName).Key("feature_tags.0.enterprise").HasValue("true"),
+				check.That(data.ResourceName).Key("feature_tags.0.gallery").HasValue("false"),
+				check.That(data.ResourceName).Key("feature_tags.0.hide").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.featureTags(data, false, true, true, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("3"),
+				check.That(data.ResourceName).Key("feature_tags.0.custom_single_sign_on").HasValue("false"),
+				check.That(data.ResourceName).Key("feature_tags.0.enterprise").HasValue("true"),
+				check.That(data.ResourceName).Key("feature_tags.0.gallery").HasValue("true"),
+				check.That(data.ResourceName).Key("feature_tags.0.hide").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.featureTagsFromTags(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("2"),
+				check.That(data.ResourceName).Key("feature_tags.0.enterprise").HasValue("false"),
+				check.That(data.ResourceName).Key("feature_tags.0.hide").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.featureTags(data, false, true, false, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.#").HasValue("2"),
+				check.That(data.ResourceName).Key("feature_tags.0.enterprise").HasValue("true"),
+				check.That(data.ResourceName).Key("feature_tags.0.hide").HasValue("false"),
+				resource.TestCheckTypeSetElemAttr(data.ResourceName, "tags.*", "CustomTag"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccServicePrincipal_featureTagsConflict(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.featureTagsConflict(data),
+			ExpectError: regexp.MustCompile("conflicts with"),
+		},
+	})
+}
+
+func TestAccServicePrincipal_importWrongObjectType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_service_principal", "test")
+	r := ServicePrincipalResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.importWrongObjectType(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			ResourceName: data.ResourceName,
+			ImportState:  true,
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				other, ok := s.RootModule().Resources["azuread_application.test"]
+				if !ok {
+					return "", fmt.Errorf("azuread_application.test not found in state")
+				}
+				return other.Primary.ID, nil
+			},
+			ExpectError: regexp.MustCompile("is an Application, not a ServicePrincipal"),
+		},
+	})
+}
+
 func (r ServicePrincipalResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.ServicePrincipals.ServicePrincipalsClient
 	client.BaseClient.DisableRetries = true
@@ -104,6 +234,27 @@ resource "azuread_service_principal" "test" {
 `, data.RandomInteger)
 }
 
+func (ServicePrincipalResource) clientId(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  client_id = azuread_application.test.client_id
+}
+`, data.RandomInteger)
+}
+
+func (ServicePrincipalResource) conflictingIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_service_principal" "test" {
+  application_id = "%[1]s"
+  client_id      = "%[2]s"
+}
+`, data.UUID(), data.UUID())
+}
+
 func (ServicePrincipalResource) complete(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 resource "azuread_application" "test" {
@@ -151,10 +302,68 @@ resource "azuread_application" "test" {
 }
 
 resource "azuread_service_principal" "test" {
-  application_id               = azuread_application.test.application_id
-  app_role_assignment_required = true
+  application_id                = azuread_application.test.application_id
+  app_role_assignment_required  = true
+  login_url                     = "https://example.com/login"
+  notes                         = "Owned by the identity team"
+  preferred_single_sign_on_mode = "saml"
 
   tags = ["test", "multiple", "CapitalS"]
 }
 `, data.RandomInteger, data.UUID(), data.UUID(), data.UUID(), data.UUID())
 }
+
+func (ServicePrincipalResource) featureTags(data acceptance.TestData, customSingleSignOn, enterprise, gallery, hide bool) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+
+  feature_tags {
+    custom_single_sign_on = %[2]t
+    enterprise            = %[3]t
+    gallery               = %[4]t
+    hide                  = %[5]t
+  }
+}
+`, data.RandomInteger, customSingleSignOn, enterprise, gallery, hide)
+}
+
+func (ServicePrincipalResource) featureTagsFromTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+
+  tags = ["HideApp", "CustomTag"]
+}
+`, data.RandomInteger)
+}
+
+func (ServicePrincipalResource) featureTagsConflict(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "test" {
+  display_name = "acctestServicePrincipal-%[1]d"
+}
+
+resource "azuread_service_principal" "test" {
+  application_id = azuread_application.test.application_id
+
+  tags = ["HideApp"]
+
+  feature_tags {
+    hide = true
+  }
+}
+`, data.RandomInteger)
+}
+
+func (r ServicePrincipalResource) importWrongObjectType(data acceptance.TestData) string {
+	return r.basic(data)
+}
@@ -0,0 +1,228 @@
+package serviceprincipals
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func servicePrincipalTokenSigningCertificateResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: servicePrincipalTokenSigningCertificateResourceCreate,
+		ReadContext:   servicePrincipalTokenSigningCertificateResourceRead,
+		DeleteContext: servicePrincipalTokenSigningCertificateResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+			_, err := parse.TokenSigningCertificateID(id)
+			return err
+		}),
+
+		Schema: map[string]*schema.Schema{
+			"service_principal_id": {
+				Description:      "The object ID of the service principal for which this certificate should be created",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
+			"display_name": {
+				Description:  "A friendly name for the certificate, which must start with `CN=`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^CN=.+`), "must start with `CN=`"),
+			},
+
+			"end_date": {
+				Description:  "The end date until which the certificate is valid, formatted as an RFC3339 date string (e.g. `2018-01-01T01:02:03Z`). Defaults to 3 years from the creation date",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"key_id": {
+				Description: "A UUID used to uniquely identify the certificate",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"start_date": {
+				Description: "The start date from which the certificate is valid, formatted as an RFC3339 date string",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"thumbprint": {
+				Description: "A SHA-1 generated thumbprint of the token signing certificate, which can be used to set the preferred signing certificate for a SAML application",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func servicePrincipalTokenSigningCertificateResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	certificateClient := meta.(*clients.Client).ServicePrincipals.ServicePrincipalTokenSigningCertificateClient
+	objectId := d.Get("service_principal_id").(string)
+
+	var endDate *time.Time
+	if v, ok := d.GetOk("end_date"); ok {
+		expiry, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "end_date", "Unable to parse the provided end date %q", v)
+		}
+		endDate = &expiry
+	}
+
+	tf.LockByName(servicePrincipalResourceName, objectId)
+	defer tf.UnlockByName(servicePrincipalResourceName, objectId)
+
+	_, status, err := helpers.WaitForParentServicePrincipal(ctx, client, objectId)
+	if diags := servicePrincipalCredentialParent("azuread_service_principal_token_signing_certificate", objectId).CheckCreate(status, err); diags.HasError() {
+		return diags
+	}
+
+	certificate, _, err := certificateClient.Add(ctx, objectId, d.Get("display_name").(string), endDate)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Adding token signing certificate for service principal with object ID %q", objectId)
+	}
+	if certificate.KeyId == nil || *certificate.KeyId == "" {
+		return tf.ErrorDiagF(errors.New("keyId returned for token signing certificate is nil/empty"), "Bad API response")
+	}
+
+	id := parse.NewCredentialID(objectId, "tokenSigningCertificate", *certificate.KeyId)
+	d.SetId(id.String())
+
+	if certificate.Thumbprint != nil {
+		tf.Set(d, "thumbprint", *certificate.Thumbprint)
+	}
+
+	return servicePrincipalTokenSigningCertificateResourceRead(ctx, d, meta)
+}
+
+func servicePrincipalTokenSigningCertificateResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	id, err := parse.TokenSigningCertificateID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing token signing certificate with ID %q", d.Id())
+	}
+
+	servicePrincipal, status, err := client.Get(ctx, id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_token_signing_certificate", id.ObjectId).CheckRead(d, status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	var credential *msgraph.KeyCredential
+	matched, _ := servicePrincipalTokenSigningKeyCredentials(servicePrincipal.KeyCredentials, id.KeyId)
+	for i, cred := range matched {
+		if cred.KeyId != nil && *cred.KeyId == id.KeyId {
+			credential = &matched[i]
+			break
+		}
+	}
+
+	if credential == nil {
+		log.Printf("[DEBUG] Token signing certificate %q for service principal with object ID %q was not found - removing from state!", id.KeyId, id.ObjectId)
+		d.SetId("")
+		return nil
+	}
+
+	tf.Set(d, "service_principal_id", id.ObjectId)
+	tf.Set(d, "display_name", credential.DisplayName)
+	tf.Set(d, "key_id", id.KeyId)
+
+	if thumbprint := servicePrincipalTokenSigningThumbprint(credential.CustomKeyIdentifier); thumbprint != "" {
+		tf.Set(d, "thumbprint", thumbprint)
+	}
+
+	startDate := ""
+	if v := credential.StartDateTime; v != nil {
+		startDate = v.Format(time.RFC3339)
+	}
+	tf.Set(d, "start_date", startDate)
+
+	endDate := ""
+	if v := credential.EndDateTime; v != nil {
+		endDate = v.Format(time.RFC3339)
+	}
+	tf.Set(d, "end_date", endDate)
+
+	return nil
+}
+
+func servicePrincipalTokenSigningCertificateResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+
+	id, err := parse.TokenSigningCertificateID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing token signing certificate with ID %q", d.Id())
+	}
+
+	tf.LockByName(servicePrincipalResourceName, id.ObjectId)
+	defer tf.UnlockByName(servicePrincipalResourceName, id.ObjectId)
+
+	servicePrincipal, status, err := client.Get(ctx, id.ObjectId)
+	if gone, diags := servicePrincipalCredentialParent("azuread_service_principal_token_signing_certificate", id.ObjectId).CheckDelete(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	// Remove both the signing and verify key credentials created for the certificate
+	matched, remaining := servicePrincipalTokenSigningKeyCredentials(servicePrincipal.KeyCredentials, id.KeyId)
+	if len(matched) == 0 {
+		log.Printf("[DEBUG] Token signing certificate %q for service principal with object ID %q was not found", id.KeyId, id.ObjectId)
+		return nil
+	}
+	if remaining == nil {
+		remaining = make([]msgraph.KeyCredential, 0)
+	}
+
+	properties := msgraph.ServicePrincipal{
+		ID:             &id.ObjectId,
+		KeyCredentials: &remaining,
+	}
+	if _, err := client.Update(ctx, properties); err != nil {
+		return tf.ErrorDiagF(err, "Removing token signing certificate %q from service principal with object ID %q", id.KeyId, id.ObjectId)
+	}
+
+	// The private key of the certificate is stored in a password credential with the same custom key identifier
+	customKeyIdentifier := matched[0].CustomKeyIdentifier
+	if customKeyIdentifier == nil || servicePrincipal.PasswordCredentials == nil {
+		return nil
+	}
+	for _, cred := range *servicePrincipal.PasswordCredentials {
+		if cred.KeyId == nil || cred.CustomKeyIdentifier == nil || *cred.CustomKeyIdentifier != *customKeyIdentifier {
+			continue
+		}
+		if status, err := client.RemovePassword(ctx, id.ObjectId, *cred.KeyId); err != nil && status != http.StatusNotFound {
+			return tf.ErrorDiagF(err, "Removing private key password credential %q for token signing certificate %q from service principal with object ID %q", *cred.KeyId, id.KeyId, id.ObjectId)
+		}
+	}
+
+	return nil
+}
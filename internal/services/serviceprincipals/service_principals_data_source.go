@@ -0,0 +1,322 @@
+package serviceprincipals
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func servicePrincipalsDataSource() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: servicePrincipalsDataSourceRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"application_ids": {
+				Description:  "The application IDs (client IDs) of the applications associated with the service principals",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"application_ids", "display_names", "object_ids", "return_all"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.UUID,
+				},
+			},
+
+			"display_names": {
+				Description:  "The display names of the applications associated with the service principals",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"application_ids", "display_names", "object_ids", "return_all"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.NoEmptyStrings,
+				},
+			},
+
+			"object_ids": {
+				Description:  "The object IDs of the service principals",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"application_ids", "display_names", "object_ids", "return_all"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.UUID,
+				},
+			},
+
+			"return_all": {
+				Description:  "Fetch all service principals with no filter and return all that were found. The data source will still fail if no service principals are found",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: []string{"application_ids", "display_names", "object_ids", "return_all"},
+			},
+
+			"ignore_missing": {
+				Description:   "Ignore missing service principals and return the service principals that were found. The data source will still fail if no service principals are found",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"return_all"},
+			},
+
+			"only_third_party": {
+				Description: "Only return service principals for applications registered in a tenant other than the current tenant",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"service_principals": {
+				Description: "A list of service principals",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_enabled": {
+							Description: "Whether or not the service principal account is enabled",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"app_owner_organization_id": {
+							Description: "The tenant ID where the associated application is registered",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"app_role_assignment_required": {
+							Description: "Whether this service principal requires an app role assignment to a user or group before Azure AD will issue a user or access token to the application",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+
+						"application_id": {
+							Description: "The application ID (client ID) of the application associated with this service principal",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"application_template_id": {
+							Description: "The ID of the application template from which the associated application was created, if it was created from the gallery",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"client_id": {
+							Description: "The client ID (application ID) of the application associated with this service principal",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"display_name": {
+							Description: "The display name of the application associated with this service principal",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"homepage_url": {
+							Description: "Home page or landing page of the associated application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"object_id": {
+							Description: "The object ID of the service principal",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"service_principal_names": {
+							Description: "A list of identifier URI(s), copied over from the associated application",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"sign_in_audience": {
+							Description: "The Microsoft account types that are supported for the associated application",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"type": {
+							Description: "Identifies whether the service principal represents an application or a managed identity",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func servicePrincipalsDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).ServicePrincipals.ServicePrincipalsClient
+	tenantId := meta.(*clients.Client).TenantID
+
+	var servicePrincipals []msgraph.ServicePrincipal
+	var expectedCount int
+	ignoreMissing := d.Get("ignore_missing").(bool)
+	returnAll := d.Get("return_all").(bool)
+
+	if returnAll {
+		result, _, err := client.List(ctx, "")
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not retrieve service principals")
+		}
+		if result == nil {
+			return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
+		}
+		if len(*result) == 0 {
+			return tf.ErrorDiagPathF(nil, "return_all", "No service principals found")
+		}
+
+		servicePrincipals = append(servicePrincipals, *result...)
+	} else if applicationIds, ok := d.Get("application_ids").([]interface{}); ok && len(applicationIds) > 0 {
+		expectedCount = len(applicationIds)
+		for _, v := range applicationIds {
+			filter := helpers.ODataEq("appId", v)
+			result, _, err := client.List(ctx, filter)
+			if err != nil {
+				return tf.ErrorDiagF(err, "Finding service principal for application ID: %q", v)
+			}
+			if result == nil {
+				return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
+			}
+
+			count := len(*result)
+			if count > 1 {
+				return tf.ErrorDiagPathF(nil, "application_ids", "More than one service principal found for application ID: %q", v)
+			} else if count == 0 {
+				if ignoreMissing {
+					continue
+				}
+				return tf.ErrorDiagPathF(nil, "application_ids", "Service principal not found for application ID: %q", v)
+			}
+
+			servicePrincipals = append(servicePrincipals, (*result)[0])
+		}
+	} else if displayNames, ok := d.Get("display_names").([]interface{}); ok && len(displayNames) > 0 {
+		expectedCount = len(displayNames)
+		for _, v := range displayNames {
+			filter := helpers.ODataEq("displayName", v.(string))
+			result, _, err := client.List(ctx, filter)
+			if err != nil {
+				return tf.ErrorDiagF(err, "Finding service principal with display name: %q", v)
+			}
+			if result == nil {
+				return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
+			}
+
+			count := len(*result)
+			if count > 1 {
+				return tf.ErrorDiagPathF(nil, "display_names", "More than one service principal found with display name: %q", v)
+			} else if count == 0 {
+				if ignoreMissing {
+					continue
+				}
+				return tf.ErrorDiagPathF(nil, "display_names", "Service principal not found with display name: %q", v)
+			}
+
+			servicePrincipals = append(servicePrincipals, (*result)[0])
+		}
+	} else if objectIds, ok := d.Get("object_ids").([]interface{}); ok && len(objectIds) > 0 {
+		expectedCount = len(objectIds)
+		for _, v := range objectIds {
+			sp, status, err := client.Get(ctx, v.(string))
+			if err != nil {
+				if status == http.StatusNotFound {
+					if ignoreMissing {
+						continue
+					}
+					return tf.ErrorDiagPathF(nil, "object_ids", "Service principal not found with object ID: %q", v)
+				}
+				return tf.ErrorDiagF(err, "Retrieving service principal with object ID: %q", v)
+			}
+			if sp == nil {
+				return tf.ErrorDiagPathF(nil, "object_ids", "Service principal not found with object ID: %q", v)
+			}
+
+			servicePrincipals = append(servicePrincipals, *sp)
+		}
+	}
+
+	if !returnAll && !ignoreMissing && len(servicePrincipals) != expectedCount {
+		return tf.ErrorDiagF(fmt.Errorf("Expected: %d, Actual: %d", expectedCount, len(servicePrincipals)), "Unexpected number of service principals returned")
+	}
+
+	onlyThirdParty := d.Get("only_third_party").(bool)
+
+	applicationIds := make([]string, 0)
+	displayNames := make([]string, 0)
+	objectIds := make([]string, 0)
+	servicePrincipalList := make([]map[string]interface{}, 0)
+	for _, s := range servicePrincipals {
+		if s.ID == nil || s.DisplayName == nil {
+			return tf.ErrorDiagF(errors.New("API returned service principal with nil object ID or displayName"), "Bad API Response")
+		}
+
+		if onlyThirdParty && !servicePrincipalIsThirdParty(s, tenantId) {
+			continue
+		}
+
+		objectIds = append(objectIds, *s.ID)
+		displayNames = append(displayNames, *s.DisplayName)
+		if s.AppId != nil {
+			applicationIds = append(applicationIds, *s.AppId)
+		}
+
+		sp := make(map[string]interface{})
+		sp["account_enabled"] = s.AccountEnabled
+		sp["app_owner_organization_id"] = s.AppOwnerOrganizationId
+		sp["app_role_assignment_required"] = s.AppRoleAssignmentRequired
+		sp["application_id"] = s.AppId
+		sp["client_id"] = s.AppId
+		sp["application_template_id"] = s.ApplicationTemplateId
+		sp["display_name"] = s.DisplayName
+		sp["homepage_url"] = s.Homepage
+		sp["object_id"] = s.ID
+		sp["service_principal_names"] = tf.FlattenStringSlicePtr(s.ServicePrincipalNames)
+		sp["sign_in_audience"] = string(s.SignInAudience)
+		sp["type"] = s.ServicePrincipalType
+		servicePrincipalList = append(servicePrincipalList, sp)
+	}
+
+	// Generate a unique ID based on result
+	h := sha1.New()
+	if _, err := h.Write([]byte(strings.Join(objectIds, "/"))); err != nil {
+		return tf.ErrorDiagF(err, "Unable to compute hash for object IDs")
+	}
+
+	d.SetId("servicePrincipals#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "application_ids", applicationIds)
+	tf.Set(d, "display_names", displayNames)
+	tf.Set(d, "object_ids", objectIds)
+	tf.Set(d, "service_principals", servicePrincipalList)
+
+	return nil
+}
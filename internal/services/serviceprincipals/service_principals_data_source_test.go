@@ -0,0 +1,192 @@
+package serviceprincipals_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+)
+
+type ServicePrincipalsDataSource struct{}
+
+func TestAccServicePrincipalsDataSource_byApplicationIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.byApplicationIds(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("application_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("display_names.#").HasValue("2"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principals.#").HasValue("2"),
+		),
+	}})
+}
+
+func TestAccServicePrincipalsDataSource_byDisplayNames(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.byDisplayNames(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("application_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("display_names.#").HasValue("2"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principals.#").HasValue("2"),
+		),
+	}})
+}
+
+func TestAccServicePrincipalsDataSource_byObjectIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.byObjectIds(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("application_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("display_names.#").HasValue("2"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principals.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principals.0.app_owner_organization_id").Exists(),
+		),
+	}})
+}
+
+func TestAccServicePrincipalsDataSource_ignoreMissing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.ignoreMissing(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("service_principals.#").HasValue("2"),
+		),
+	}})
+}
+
+func TestAccServicePrincipalsDataSource_onlyThirdParty(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.onlyThirdParty(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("application_ids.#").HasValue("1"),
+			check.That(data.ResourceName).Key("application_ids.0").HasValue("00000003-0000-0000-c000-000000000000"),
+			check.That(data.ResourceName).Key("service_principals.#").HasValue("1"),
+			check.That(data.ResourceName).Key("service_principals.0.app_owner_organization_id").Exists(),
+		),
+	}})
+}
+
+func TestAccServicePrincipalsDataSource_returnAll(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_service_principals", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: ServicePrincipalsDataSource{}.returnAll(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("object_ids.#").Exists(),
+			check.That(data.ResourceName).Key("service_principals.#").Exists(),
+		),
+	}})
+}
+
+func (ServicePrincipalsDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azuread_application" "testA" {
+  display_name = "acctestServicePrincipals-A-%[1]d"
+}
+
+resource "azuread_service_principal" "testA" {
+  application_id = azuread_application.testA.application_id
+}
+
+resource "azuread_application" "testB" {
+  display_name = "acctestServicePrincipals-B-%[1]d"
+}
+
+resource "azuread_service_principal" "testB" {
+  application_id = azuread_application.testB.application_id
+}
+`, data.RandomInteger)
+}
+
+func (r ServicePrincipalsDataSource) byApplicationIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principals" "test" {
+  application_ids = [
+    azuread_service_principal.testA.application_id,
+    azuread_service_principal.testB.application_id,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalsDataSource) byDisplayNames(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principals" "test" {
+  display_names = [
+    azuread_service_principal.testA.display_name,
+    azuread_service_principal.testB.display_name,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalsDataSource) byObjectIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principals" "test" {
+  object_ids = [
+    azuread_service_principal.testA.object_id,
+    azuread_service_principal.testB.object_id,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalsDataSource) ignoreMissing(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principals" "test" {
+  ignore_missing = true
+
+  object_ids = [
+    azuread_service_principal.testA.object_id,
+    "e0000000-0000-0000-0000-000000000000",
+    azuread_service_principal.testB.object_id,
+  ]
+}
+`, r.template(data))
+}
+
+func (r ServicePrincipalsDataSource) onlyThirdParty(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_service_principals" "test" {
+  only_third_party = true
+
+  application_ids = [
+    azuread_service_principal.testA.application_id,
+    "00000003-0000-0000-c000-000000000000",
+  ]
+}
+`, r.template(data))
+}
+
+func (ServicePrincipalsDataSource) returnAll(_ acceptance.TestData) string {
+	return `
+data "azuread_service_principals" "test" {
+  return_all = true
+}
+`
+}
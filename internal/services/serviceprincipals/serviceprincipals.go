@@ -0,0 +1,212 @@
+package serviceprincipals
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+)
+
+func servicePrincipalCredentialParent(resourceType, objectId string) helpers.CredentialParent {
+	return helpers.CredentialParent{
+		ResourceType: resourceType,
+		ParentType:   "service principal",
+		Attr:         "service_principal_id",
+		ObjectId:     objectId,
+	}
+}
+
+// servicePrincipalFeatureTags maps the attributes of the `feature_tags` block to the tags which control the behaviour
+// of enterprise applications in the Azure Portal
+var servicePrincipalFeatureTags = map[string]string{
+	"custom_single_sign_on": "WindowsAzureActiveDirectoryCustomSingleSignOnApplication",
+	"enterprise":            "WindowsAzureActiveDirectoryIntegratedApp",
+	"gallery":               "WindowsAzureActiveDirectoryGalleryApplicationPrimaryV1",
+	"hide":                  "HideApp",
+}
+
+// servicePrincipalExpandFeatureTags returns the tags for the configured features. Existing tags which do not denote
+// features are retained, so that tags set outside of Terraform are preserved.
+func servicePrincipalExpandFeatureTags(in []interface{}, existingTags []string) []string {
+	featureTagValues := make(map[string]bool)
+	for _, tag := range servicePrincipalFeatureTags {
+		featureTagValues[tag] = true
+	}
+
+	result := make([]string, 0)
+	for _, tag := range existingTags {
+		if !featureTagValues[tag] {
+			result = append(result, tag)
+		}
+	}
+
+	if len(in) > 0 && in[0] != nil {
+		features := in[0].(map[string]interface{})
+		for attr, tag := range servicePrincipalFeatureTags {
+			if v, ok := features[attr].(bool); ok && v {
+				result = append(result, tag)
+			}
+		}
+	}
+
+	sort.Strings(result)
+	return result
+}
+
+// servicePrincipalFlattenFeatureTags returns the features denoted by the tags of a service principal
+func servicePrincipalFlattenFeatureTags(tags *[]string) []map[string]interface{} {
+	features := make(map[string]interface{})
+	for attr, tag := range servicePrincipalFeatureTags {
+		features[attr] = false
+		if tags != nil {
+			for _, t := range *tags {
+				if t == tag {
+					features[attr] = true
+					break
+				}
+			}
+		}
+	}
+	return []map[string]interface{}{features}
+}
+
+// servicePrincipalIsThirdParty returns whether the application for a service principal is registered in a tenant other
+// than the specified tenant. Service principals without a known owner organization, such as some first-party Microsoft
+// applications, are not considered to be third-party.
+func servicePrincipalIsThirdParty(servicePrincipal msgraph.ServicePrincipal, tenantId string) bool {
+	if servicePrincipal.AppOwnerOrganizationId == nil || *servicePrincipal.AppOwnerOrganizationId == "" {
+		return false
+	}
+	return !strings.EqualFold(*servicePrincipal.AppOwnerOrganizationId, tenantId)
+}
+
+// servicePrincipalTokenSigningKeyCredentials returns the key credentials belonging to the same token signing
+// certificate as the key credential with the specified key ID, along with any remaining key credentials. Creating a
+// token signing certificate adds a key credential for signing and another for verification, which share the same
+// custom key identifier. The matched credentials are nil when no key credential has the specified key ID.
+func servicePrincipalTokenSigningKeyCredentials(credentials *[]msgraph.KeyCredential, keyId string) (matched, remaining []msgraph.KeyCredential) {
+	if credentials == nil {
+		return nil, nil
+	}
+
+	var customKeyIdentifier *string
+	for _, cred := range *credentials {
+		if cred.KeyId != nil && strings.EqualFold(*cred.KeyId, keyId) {
+			customKeyIdentifier = cred.CustomKeyIdentifier
+			break
+		}
+	}
+
+	for _, cred := range *credentials {
+		switch {
+		case cred.KeyId != nil && strings.EqualFold(*cred.KeyId, keyId):
+			matched = append(matched, cred)
+		case customKeyIdentifier != nil && cred.CustomKeyIdentifier != nil && *cred.CustomKeyIdentifier == *customKeyIdentifier:
+			matched = append(matched, cred)
+		default:
+			remaining = append(remaining, cred)
+		}
+	}
+
+	return
+}
+
+// servicePrincipalTokenSigningThumbprint returns the thumbprint of a token signing certificate, which is the hex
+// encoded value of the base64 encoded custom key identifier of its key credentials
+func servicePrincipalTokenSigningThumbprint(customKeyIdentifier *string) string {
+	if customKeyIdentifier == nil {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(*customKeyIdentifier)
+	if err != nil {
+		return ""
+	}
+	return strings.ToUpper(hex.EncodeToString(decoded))
+}
+
+// servicePrincipalFindPermissionScope returns the delegated permission published by a service principal which matches
+// the specified permission ID, or the specified permission name when no ID is given. An error listing the available
+// permissions is returned when no published permission matches.
+func servicePrincipalFindPermissionScope(scopes *[]msgraph.PermissionScope, permissionId, permissionName string) (*msgraph.PermissionScope, error) {
+	available := make([]string, 0)
+	if scopes != nil {
+		for _, scope := range *scopes {
+			if scope.ID == nil || scope.Value == nil {
+				continue
+			}
+			if permissionId != "" && strings.EqualFold(*scope.ID, permissionId) {
+				return &scope, nil
+			}
+			if permissionId == "" && *scope.Value == permissionName {
+				return &scope, nil
+			}
+			if permissionId != "" {
+				available = append(available, *scope.ID)
+			} else {
+				available = append(available, *scope.Value)
+			}
+		}
+	}
+	sort.Strings(available)
+
+	if permissionId != "" {
+		return nil, fmt.Errorf("no delegated permission with ID %q is published by this service principal, available permission IDs: %s", permissionId, strings.Join(available, ", "))
+	}
+	return nil, fmt.Errorf("no delegated permission with name %q is published by this service principal, available permission names: %s", permissionName, strings.Join(available, ", "))
+}
+
+// servicePrincipalDefaultAccessAppRoleId is the ID of the default access app role, which can be assigned for resource
+// service principals that do not publish any app roles
+const servicePrincipalDefaultAccessAppRoleId = "00000000-0000-0000-0000-000000000000"
+
+// servicePrincipalValidateAppRoleId returns an error listing the available app roles when the specified app role is not
+// an enabled app role published by a service principal. The default access app role is always valid.
+func servicePrincipalValidateAppRoleId(roles *[]msgraph.AppRole, appRoleId string) error {
+	if appRoleId == servicePrincipalDefaultAccessAppRoleId {
+		return nil
+	}
+
+	available := make([]string, 0)
+	if roles != nil {
+		for _, role := range *roles {
+			if role.ID == nil {
+				continue
+			}
+			enabled := role.IsEnabled == nil || *role.IsEnabled
+			if strings.EqualFold(*role.ID, appRoleId) {
+				if !enabled {
+					return fmt.Errorf("the app role with ID %q published by this service principal is disabled", appRoleId)
+				}
+				return nil
+			}
+			if enabled {
+				available = append(available, *role.ID)
+			}
+		}
+	}
+	sort.Strings(available)
+
+	if len(available) == 0 {
+		return fmt.Errorf("no app role with ID %q is published by this service principal, which publishes no enabled app roles. Specify %q to assign default access", appRoleId, servicePrincipalDefaultAccessAppRoleId)
+	}
+	return fmt.Errorf("no app role with ID %q is published by this service principal, available app role IDs: %s", appRoleId, strings.Join(available, ", "))
+}
+
+// servicePrincipalAppRoleAssignmentMatch returns the first app role assignment for which match returns true, or nil
+// when there is none
+func servicePrincipalAppRoleAssignmentMatch(assignments *[]msgraph.AppRoleAssignment, match func(msgraph.AppRoleAssignment) bool) *msgraph.AppRoleAssignment {
+	if assignments == nil {
+		return nil
+	}
+	for _, v := range *assignments {
+		if match(v) {
+			return &v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package serviceprincipals
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/serviceprincipals/parse"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestServicePrincipalFeatureTagsRoundTrip(t *testing.T) {
+	attrs := []string{"custom_single_sign_on", "enterprise", "gallery", "hide"}
+	existingTags := []string{"CustomTag", "WindowsAzureActiveDirectoryIntegratedApp", "HideApp", "notes:some value"}
+
+	// Every combination of the four features
+	for i := 0; i < 1<<len(attrs); i++ {
+		features := make(map[string]interface{})
+		expectedTags := []string{"CustomTag", "notes:some value"}
+		for j, attr := range attrs {
+			enabled := i&(1<<j) != 0
+			features[attr] = enabled
+			if enabled {
+				expectedTags = append(expectedTags, servicePrincipalFeatureTags[attr])
+			}
+		}
+		sort.Strings(expectedTags)
+
+		tags := servicePrincipalExpandFeatureTags([]interface{}{features}, existingTags)
+		if !reflect.DeepEqual(tags, expectedTags) {
+			t.Fatalf("features %v: expected tags %v, got %v", features, expectedTags, tags)
+		}
+
+		flattened := servicePrincipalFlattenFeatureTags(&tags)
+		if len(flattened) != 1 || !reflect.DeepEqual(flattened[0], features) {
+			t.Fatalf("tags %v: expected features %v, got %v", tags, features, flattened)
+		}
+	}
+}
+
+func TestServicePrincipalFlattenFeatureTags(t *testing.T) {
+	expected := map[string]interface{}{
+		"custom_single_sign_on": false,
+		"enterprise":            false,
+		"gallery":               false,
+		"hide":                  false,
+	}
+
+	for _, tags := range []*[]string{nil, {}, {"CustomTag", "hideapp"}} {
+		flattened := servicePrincipalFlattenFeatureTags(tags)
+		if len(flattened) != 1 || !reflect.DeepEqual(flattened[0], expected) {
+			t.Fatalf("tags %v: expected features %v, got %v", tags, expected, flattened)
+		}
+	}
+}
+
+func TestServicePrincipalExpandFeatureTagsEmptyBlock(t *testing.T) {
+	existingTags := []string{"HideApp", "CustomTag"}
+	expected := []string{"CustomTag"}
+
+	for _, in := range [][]interface{}{nil, {nil}} {
+		if tags := servicePrincipalExpandFeatureTags(in, existingTags); !reflect.DeepEqual(tags, expected) {
+			t.Fatalf("expected tags %v, got %v", expected, tags)
+		}
+	}
+}
+
+func TestServicePrincipalIsThirdParty(t *testing.T) {
+	tenantId := "11111111-1111-1111-1111-111111111111"
+
+	testCases := []struct {
+		ownerOrganizationId *string
+		expected            bool
+	}{
+		{nil, false},
+		{utils.String(""), false},
+		{utils.String(tenantId), false},
+		{utils.String(strings.ToUpper(tenantId)), false},
+		{utils.String("f8cdef31-a31e-4b4a-93e4-5f571e91255a"), true},
+	}
+
+	for _, tc := range testCases {
+		sp := msgraph.ServicePrincipal{AppOwnerOrganizationId: tc.ownerOrganizationId}
+		if actual := servicePrincipalIsThirdParty(sp, tenantId); actual != tc.expected {
+			t.Errorf("appOwnerOrganizationId %v: expected %t, got %t", tc.ownerOrganizationId, tc.expected, actual)
+		}
+	}
+}
+
+func TestServicePrincipalTokenSigningKeyCredentials(t *testing.T) {
+	signingKeyId := "11111111-1111-1111-1111-111111111111"
+	verifyKeyId := "22222222-2222-2222-2222-222222222222"
+	otherKeyId := "33333333-3333-3333-3333-333333333333"
+
+	credentials := []msgraph.KeyCredential{
+		{KeyId: utils.String(signingKeyId), CustomKeyIdentifier: utils.String("q83vEjRWeJA="), Usage: msgraph.KeyCredentialUsageSign},
+		{KeyId: utils.String(verifyKeyId), CustomKeyIdentifier: utils.String("q83vEjRWeJA="), Usage: msgraph.KeyCredentialUsageVerify},
+		{KeyId: utils.String(otherKeyId), CustomKeyIdentifier: utils.String("AAECAwQFBgc="), Usage: msgraph.KeyCredentialUsageVerify},
+	}
+
+	matched, remaining := servicePrincipalTokenSigningKeyCredentials(&credentials, strings.ToUpper(verifyKeyId))
+	if len(matched) != 2 || *matched[0].KeyId != signingKeyId || *matched[1].KeyId != verifyKeyId {
+		t.Fatalf("expected signing and verify credentials to be matched, got %v", matched)
+	}
+	if len(remaining) != 1 || *remaining[0].KeyId != otherKeyId {
+		t.Fatalf("expected unrelated credential to remain, got %v", remaining)
+	}
+
+	matched, remaining = servicePrincipalTokenSigningKeyCredentials(&credentials, "44444444-4444-4444-4444-444444444444")
+	if len(matched) != 0 || len(remaining) != 3 {
+		t.Fatalf("expected no credentials to be matched, got %v", matched)
+	}
+
+	if matched, remaining = servicePrincipalTokenSigningKeyCredentials(nil, signingKeyId); matched != nil || remaining != nil {
+		t.Fatalf("expected nil results for nil credentials, got %v and %v", matched, remaining)
+	}
+}
+
+func TestServicePrincipalTokenSigningThumbprint(t *testing.T) {
+	testCases := []struct {
+		customKeyIdentifier *string
+		expected            string
+	}{
+		{nil, ""},
+		{utils.String(""), ""},
+		{utils.String("not base64!"), ""},
+		{utils.String("q83vEjRWeJA="), "ABCDEF1234567890"},
+	}
+
+	for _, tc := range testCases {
+		if actual := servicePrincipalTokenSigningThumbprint(tc.customKeyIdentifier); actual != tc.expected {
+			t.Errorf("customKeyIdentifier %v: expected %q, got %q", tc.customKeyIdentifier, tc.expected, actual)
+		}
+	}
+}
+
+func TestServicePrincipalFindPermissionScope(t *testing.T) {
+	scopes := []msgraph.PermissionScope{
+		{ID: utils.String("e1fe6dd8-ba31-4d61-89e7-88639da4683d"), Value: utils.String("User.Read")},
+		{ID: utils.String("14dad69e-099b-42c9-810b-d002981feec1"), Value: utils.String("profile")},
+	}
+
+	scope, err := servicePrincipalFindPermissionScope(&scopes, "", "profile")
+	if err != nil || *scope.ID != "14dad69e-099b-42c9-810b-d002981feec1" {
+		t.Fatalf("expected to resolve permission by name, got %v, %v", scope, err)
+	}
+
+	scope, err = servicePrincipalFindPermissionScope(&scopes, "E1FE6DD8-BA31-4D61-89E7-88639DA4683D", "")
+	if err != nil || *scope.Value != "User.Read" {
+		t.Fatalf("expected to resolve permission by ID, got %v, %v", scope, err)
+	}
+
+	if _, err = servicePrincipalFindPermissionScope(&scopes, "", "user.read"); err == nil {
+		t.Fatalf("expected permission names to be matched case-sensitively")
+	}
+
+	_, err = servicePrincipalFindPermissionScope(&scopes, "", "Mail.Read")
+	if err == nil || !strings.Contains(err.Error(), `"Mail.Read"`) || !strings.Contains(err.Error(), "User.Read, profile") {
+		t.Fatalf("expected error listing the available permission names, got %v", err)
+	}
+
+	if _, err = servicePrincipalFindPermissionScope(nil, "00000000-0000-0000-0000-000000000000", ""); err == nil {
+		t.Fatalf("expected an error when no permissions are published")
+	}
+}
+
+func TestServicePrincipalValidateAppRoleId(t *testing.T) {
+	roles := []msgraph.AppRole{
+		{ID: utils.String("22222222-2222-2222-2222-222222222222"), IsEnabled: utils.Bool(true), Value: utils.String("Admin")},
+		{ID: utils.String("11111111-1111-1111-1111-111111111111"), IsEnabled: utils.Bool(true), Value: utils.String("User")},
+		{ID: utils.String("33333333-3333-3333-3333-333333333333"), IsEnabled: utils.Bool(false), Value: utils.String("Legacy")},
+	}
+
+	if err := servicePrincipalValidateAppRoleId(&roles, "22222222-2222-2222-2222-222222222222"); err != nil {
+		t.Fatalf("unexpected error for published app role: %v", err)
+	}
+	if err := servicePrincipalValidateAppRoleId(nil, servicePrincipalDefaultAccessAppRoleId); err != nil {
+		t.Fatalf("expected the default access app role to be valid, got %v", err)
+	}
+
+	err := servicePrincipalValidateAppRoleId(&roles, "33333333-3333-3333-3333-333333333333")
+	if err == nil || !strings.Contains(err.Error(), "disabled") {
+		t.Fatalf("expected an error for a disabled app role, got %v", err)
+	}
+
+	err = servicePrincipalValidateAppRoleId(&roles, "44444444-4444-4444-4444-444444444444")
+	if err == nil || !strings.Contains(err.Error(), "11111111-1111-1111-1111-111111111111, 22222222-2222-2222-2222-222222222222") {
+		t.Fatalf("expected an error listing the enabled app roles, got %v", err)
+	}
+
+	err = servicePrincipalValidateAppRoleId(nil, "44444444-4444-4444-4444-444444444444")
+	if err == nil || !strings.Contains(err.Error(), servicePrincipalDefaultAccessAppRoleId) {
+		t.Fatalf("expected an error suggesting default access, got %v", err)
+	}
+}
+
+func TestAppRoleAssignmentFind(t *testing.T) {
+	const resourceId = "00000000-0000-0000-0000-000000000001"
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case !strings.Contains(r.URL.Path, resourceId):
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource does not exist"}}`)
+		case r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"@odata.nextLink":%q,"value":[{"id":"first-assignment","appRoleId":"11111111-1111-1111-1111-111111111111","principalId":"22222222-2222-2222-2222-222222222222"}]}`, server.URL+r.URL.Path+"?page=2")
+		default:
+			fmt.Fprint(w, `{"value":[{"id":"second-assignment","appRoleId":"00000000-0000-0000-0000-000000000000","principalId":"33333333-3333-3333-3333-333333333333","principalType":"Group"}]}`)
+		}
+	}))
+	defer server.Close()
+
+	client := msgraph.NewServicePrincipalsClient("00000000-0000-0000-0000-000000000000")
+	client.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	client.BaseClient.DisableRetries = true
+	ctx := context.Background()
+
+	assignment, _, err := appRoleAssignmentFind(ctx, client, parse.NewAppRoleAssignmentID(resourceId, "second-assignment"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *assignment.PrincipalId != "33333333-3333-3333-3333-333333333333" || *assignment.PrincipalType != "Group" {
+		t.Fatalf("unexpected assignment: %+v", assignment)
+	}
+
+	if _, status, err := appRoleAssignmentFind(ctx, client, parse.NewAppRoleAssignmentID(resourceId, "SECOND-ASSIGNMENT")); err == nil || status != http.StatusNotFound {
+		t.Fatalf("expected assignment IDs to be matched case-sensitively, got status %d: %v", status, err)
+	}
+
+	if _, status, err := appRoleAssignmentFind(ctx, client, parse.NewAppRoleAssignmentID("00000000-0000-0000-0000-000000000002", "first-assignment")); err == nil || status != http.StatusNotFound {
+		t.Fatalf("expected a not found status when the resource service principal does not exist, got status %d: %v", status, err)
+	}
+}
+
+func TestServicePrincipalClaimsMappingPolicyAssignmentIds(t *testing.T) {
+	relationship := servicePrincipalClaimsMappingPolicyAssignmentRelationship()
+	servicePrincipalId, policyId := "00000000-0000-0000-0000-000000000000", "11111111-1111-1111-1111-111111111111"
+
+	expected := fmt.Sprintf("%s/claimsMappingPolicy/%s", servicePrincipalId, policyId)
+	if id := relationship.FormatId(servicePrincipalId, policyId); id != expected {
+		t.Fatalf("expected ID %q, got %q", expected, id)
+	}
+
+	parentId, relatedId, err := relationship.ParseId(expected)
+	if err != nil {
+		t.Fatalf("unexpected error parsing ID: %v", err)
+	}
+	if parentId != servicePrincipalId || relatedId != policyId {
+		t.Fatalf("expected IDs %q and %q, got %q and %q", servicePrincipalId, policyId, parentId, relatedId)
+	}
+
+	if _, _, err := relationship.ParseId(fmt.Sprintf("%s/owner/%s", servicePrincipalId, policyId)); err == nil {
+		t.Fatal("expected an error parsing an ID of another type")
+	}
+}
@@ -7,14 +7,46 @@ import (
 )
 
 type Client struct {
-	UsersClient *msgraph.UsersClient
+	InvitationsClient    *msgraph.InvitationsClient
+	UsersClient          *msgraph.UsersClient
+	UserIdentitiesClient *UserIdentitiesClient
+	UserManagerClient    *UserManagerClient
+	UserPhotoClient      *UserPhotoClient
+
+	// UserCreateBatcher is only configured when batched user creation is enabled in the provider
+	UserCreateBatcher *UserCreateBatcher
 }
 
 func NewClient(o *common.ClientOptions) *Client {
+	invitationsClient := msgraph.NewInvitationsClient(o.TenantID)
+	o.ConfigureClient(&invitationsClient.BaseClient)
+
 	msClient := msgraph.NewUsersClient(o.TenantID)
 	o.ConfigureClient(&msClient.BaseClient)
 
+	userIdentitiesClient := NewUserIdentitiesClient(o.TenantID)
+	o.ConfigureClient(&userIdentitiesClient.BaseClient)
+
+	userManagerClient := NewUserManagerClient(o.TenantID)
+	o.ConfigureClient(&userManagerClient.BaseClient)
+
+	userPhotoClient := NewUserPhotoClient(o.TenantID)
+	o.ConfigureClient(&userPhotoClient.BaseClient)
+
 	return &Client{
-		UsersClient: msClient,
+		InvitationsClient:    invitationsClient,
+		UsersClient:          msClient,
+		UserIdentitiesClient: userIdentitiesClient,
+		UserManagerClient:    userManagerClient,
+		UserPhotoClient:      userPhotoClient,
 	}
 }
+
+// EnableCreateBatching configures a UserCreateBatcher, using the same configuration as the UsersClient
+func (c *Client) EnableCreateBatching() {
+	batcher := NewUserCreateBatcher(c.UsersClient.BaseClient.TenantId)
+	batcher.BaseClient.Authorizer = c.UsersClient.BaseClient.Authorizer
+	batcher.BaseClient.Endpoint = c.UsersClient.BaseClient.Endpoint
+	batcher.BaseClient.UserAgent = c.UsersClient.BaseClient.UserAgent
+	c.UserCreateBatcher = batcher
+}
@@ -0,0 +1,376 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/manicminer/hamilton/msgraph"
+	"github.com/manicminer/hamilton/odata"
+)
+
+const (
+	// userBatchMaxSize is the maximum number of requests permitted in a single JSON batch request
+	userBatchMaxSize = 20
+
+	// userBatchWindow is the maximum amount of time to wait for further users to be queued before sending a batch
+	userBatchWindow = 2 * time.Second
+
+	// userBatchAttempts is the maximum number of times a user is sent in a batch when it is throttled or the API
+	// returns a server error for it
+	userBatchAttempts = 10
+
+	// userBatchRetryInitialDelay is the delay before a user is first resubmitted, which is doubled for each subsequent
+	// attempt unless the API specifies a delay with a Retry-After header
+	userBatchRetryInitialDelay = 1 * time.Second
+
+	userBatchRetryDelayCap = 64 * time.Second
+)
+
+// userBatchRetryStatuses are the per-item response statuses for which a user is resubmitted in a subsequent batch, which
+// matches the rate limiting behaviour of the Microsoft Graph client
+var userBatchRetryStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// UserCreateBatcher coalesces concurrent user creation requests into Microsoft Graph JSON batch requests. It is safe
+// for concurrent use, which is the case when Terraform creates many resources in parallel.
+type UserCreateBatcher struct {
+	BaseClient msgraph.Client
+
+	// MaxSize is the maximum number of users to create in a single batch request
+	MaxSize int
+
+	// Window is the maximum amount of time to wait for further users to be queued before sending a batch
+	Window time.Duration
+
+	// RetryInitialDelay is the delay before resubmitting a throttled user when the API does not specify one
+	RetryInitialDelay time.Duration
+
+	mu      sync.Mutex
+	pending []*userCreateRequest
+	timer   *time.Timer
+}
+
+type userCreateRequest struct {
+	user   msgraph.User
+	result chan userCreateResult
+
+	// The following are guarded by the batcher lock
+	attempts  int
+	retrying  bool
+	withdrawn bool
+}
+
+type userCreateResult struct {
+	user   *msgraph.User
+	status int
+	err    error
+}
+
+type userBatchRequest struct {
+	Id      string            `json:"id"`
+	Method  string            `json:"method"`
+	Url     string            `json:"url"`
+	Headers map[string]string `json:"headers"`
+	Body    msgraph.User      `json:"body"`
+}
+
+type userBatchResponse struct {
+	Id      string            `json:"id"`
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// NewUserCreateBatcher returns a new UserCreateBatcher.
+func NewUserCreateBatcher(tenantId string) *UserCreateBatcher {
+	return &UserCreateBatcher{
+		BaseClient:        msgraph.NewClient(msgraph.VersionBeta, tenantId),
+		MaxSize:           userBatchMaxSize,
+		Window:            userBatchWindow,
+		RetryInitialDelay: userBatchRetryInitialDelay,
+	}
+}
+
+// Create queues a user to be created in the next batch, and blocks until the batch has been sent and a result is
+// available for this user. Failure to create a user does not affect other users in the same batch.
+//
+// When ctx finishes before the user has been sent, it is withdrawn from the queue and never created. Once the user has
+// been sent it may already have been created, so Create waits for the result regardless, allowing the caller to record
+// the new user rather than leaving it unmanaged.
+func (b *UserCreateBatcher) Create(ctx context.Context, user msgraph.User) (*msgraph.User, int, error) {
+	req := &userCreateRequest{
+		user:   user,
+		result: make(chan userCreateResult, 1),
+	}
+
+	b.mu.Lock()
+	b.enqueue(req)
+	b.mu.Unlock()
+
+	select {
+	case result := <-req.result:
+		return result.user, result.status, result.err
+	case <-ctx.Done():
+	}
+
+	if b.withdraw(req) {
+		return nil, 0, fmt.Errorf("waiting for batched user creation: %v", ctx.Err())
+	}
+
+	log.Printf("[DEBUG] Waiting for the result of a batched user creation which was already sent, after the context finished: %v", ctx.Err())
+	result := <-req.result
+	return result.user, result.status, result.err
+}
+
+// enqueue adds a request to the queue, sending a batch when the queue is full, or otherwise scheduling one to be sent
+// once the window has elapsed. The caller must hold the lock.
+func (b *UserCreateBatcher) enqueue(req *userCreateRequest) {
+	b.pending = append(b.pending, req)
+	if len(b.pending) >= b.maxSize() {
+		go b.send(b.takeBatch())
+	} else if b.timer == nil {
+		b.timer = time.AfterFunc(b.Window, func() {
+			b.mu.Lock()
+			batch := b.takeBatch()
+			b.mu.Unlock()
+			b.send(batch)
+		})
+	}
+}
+
+// withdraw removes a request which has not yet been sent, or which is waiting to be resubmitted, returning whether it
+// was withdrawn. Requests which have been sent cannot be withdrawn.
+func (b *UserCreateBatcher) withdraw(req *userCreateRequest) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if req.retrying {
+		req.withdrawn = true
+		return true
+	}
+	for i, pending := range b.pending {
+		if pending == req {
+			b.pending = append(b.pending[:i], b.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// resubmit queues requests to be sent again in a subsequent batch once delay has elapsed, unless they are withdrawn in
+// the meantime
+func (b *UserCreateBatcher) resubmit(reqs []*userCreateRequest, delay time.Duration) {
+	b.mu.Lock()
+	for _, req := range reqs {
+		req.retrying = true
+	}
+	b.mu.Unlock()
+
+	time.AfterFunc(delay, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for _, req := range reqs {
+			req.retrying = false
+			if !req.withdrawn {
+				b.enqueue(req)
+			}
+		}
+	})
+}
+
+// retryDelay returns the delay before resubmitting a request for the specified attempt, which is overridden by any
+// delay requested by the API in a Retry-After header
+func (b *UserCreateBatcher) retryDelay(attempt int, headers map[string]string) time.Duration {
+	for k, v := range headers {
+		if strings.EqualFold(k, "Retry-After") {
+			if seconds, err := strconv.Atoi(strings.TrimSpace(v)); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	delay := b.RetryInitialDelay
+	if delay <= 0 {
+		delay = userBatchRetryInitialDelay
+	}
+	for i := 1; i < attempt && delay < userBatchRetryDelayCap; i++ {
+		delay *= 2
+	}
+	if delay > userBatchRetryDelayCap {
+		delay = userBatchRetryDelayCap
+	}
+	return delay
+}
+
+func (b *UserCreateBatcher) maxSize() int {
+	if b.MaxSize <= 0 || b.MaxSize > userBatchMaxSize {
+		return userBatchMaxSize
+	}
+	return b.MaxSize
+}
+
+// takeBatch removes up to maxSize pending requests from the queue. The caller must hold the lock.
+func (b *UserCreateBatcher) takeBatch() []*userCreateRequest {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+
+	n := len(b.pending)
+	if n > b.maxSize() {
+		n = b.maxSize()
+	}
+	batch := b.pending[:n]
+	b.pending = b.pending[n:]
+
+	// Schedule another batch for any remaining requests
+	if len(b.pending) > 0 {
+		b.timer = time.AfterFunc(b.Window, func() {
+			b.mu.Lock()
+			next := b.takeBatch()
+			b.mu.Unlock()
+			b.send(next)
+		})
+	}
+
+	return batch
+}
+
+// send performs a batch request for the provided user creation requests. A background context is used, since the
+// batch is shared by many resources and should not be cancelled because one of them is.
+func (b *UserCreateBatcher) send(batch []*userCreateRequest) {
+	if len(batch) == 0 {
+		return
+	}
+
+	fail := func(status int, err error) {
+		for _, req := range batch {
+			req.result <- userCreateResult{status: status, err: err}
+		}
+	}
+
+	b.mu.Lock()
+	for _, req := range batch {
+		req.attempts++
+	}
+	b.mu.Unlock()
+
+	requests := make([]userBatchRequest, 0, len(batch))
+	for i, req := range batch {
+		requests = append(requests, userBatchRequest{
+			Id:      strconv.Itoa(i),
+			Method:  http.MethodPost,
+			Url:     "/users",
+			Headers: map[string]string{"Content-Type": "application/json"},
+			Body:    req.user,
+		})
+	}
+
+	body, err := json.Marshal(struct {
+		Requests []userBatchRequest `json:"requests"`
+	}{requests})
+	if err != nil {
+		fail(0, fmt.Errorf("json.Marshal(): %v", err))
+		return
+	}
+
+	// Request bodies contain passwords, so only log the number of users in the batch
+	log.Printf("[DEBUG] Sending batch request to create %d users", len(batch))
+
+	resp, status, _, err := b.BaseClient.Post(context.Background(), msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      "/$batch",
+			HasTenantId: false,
+		},
+	})
+	if err != nil {
+		fail(status, fmt.Errorf("UserCreateBatcher.BaseClient.Post(): %v", err))
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		fail(status, fmt.Errorf("io.ReadAll(): %v", err))
+		return
+	}
+
+	var data struct {
+		Responses []userBatchResponse `json:"responses"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		fail(status, fmt.Errorf("json.Unmarshal(): %v", err))
+		return
+	}
+
+	responses := make(map[string]userBatchResponse, len(data.Responses))
+	for _, r := range data.Responses {
+		responses[r.Id] = r
+	}
+
+	retries := make([]*userCreateRequest, 0)
+	var retryDelay time.Duration
+	for i, req := range batch {
+		r, ok := responses[strconv.Itoa(i)]
+		if !ok {
+			req.result <- userCreateResult{err: fmt.Errorf("no response was returned for this user in the batch response")}
+			continue
+		}
+
+		// Throttled users and those for which the API returned a server error are resubmitted in a subsequent batch,
+		// waiting for the longest delay requested for any of them
+		if containsInt(userBatchRetryStatuses, r.Status) && req.attempts < userBatchAttempts {
+			if delay := b.retryDelay(req.attempts, r.Headers); delay > retryDelay {
+				retryDelay = delay
+			}
+			retries = append(retries, req)
+			continue
+		}
+
+		if r.Status != http.StatusCreated {
+			errText := fmt.Sprintf("response: %s", r.Body)
+			var o odata.OData
+			if err := json.Unmarshal(r.Body, &o); err == nil && o.Error != nil {
+				errText = fmt.Sprintf("OData error: %s", o.Error)
+			}
+			req.result <- userCreateResult{status: r.Status, err: fmt.Errorf("unexpected status %d with %s", r.Status, errText)}
+			continue
+		}
+
+		var newUser msgraph.User
+		if err := json.Unmarshal(r.Body, &newUser); err != nil {
+			req.result <- userCreateResult{status: r.Status, err: fmt.Errorf("json.Unmarshal(): %v", err)}
+			continue
+		}
+		req.result <- userCreateResult{user: &newUser, status: r.Status}
+	}
+
+	if len(retries) > 0 {
+		log.Printf("[DEBUG] Resubmitting %d throttled or failed users in %s", len(retries), retryDelay)
+		b.resubmit(retries, retryDelay)
+	}
+}
+
+func containsInt(list []int, v int) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
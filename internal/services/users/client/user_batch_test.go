@@ -0,0 +1,270 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func TestUserCreateBatcher(t *testing.T) {
+	var mu sync.Mutex
+	batchSizes := make([]int, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/$batch") {
+			t.Errorf("unexpected request path %q", r.URL.Path)
+		}
+
+		var req struct {
+			Requests []userBatchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding batch request: %v", err)
+		}
+
+		mu.Lock()
+		batchSizes = append(batchSizes, len(req.Requests))
+		mu.Unlock()
+
+		responses := make([]map[string]interface{}, 0)
+		for _, item := range req.Requests {
+			upn := *item.Body.UserPrincipalName
+			if strings.HasPrefix(upn, "fail") {
+				responses = append(responses, map[string]interface{}{
+					"id":     item.Id,
+					"status": http.StatusBadRequest,
+					"body":   map[string]interface{}{"error": map[string]interface{}{"code": "Request_BadRequest", "message": "Invalid user"}},
+				})
+				continue
+			}
+			responses = append(responses, map[string]interface{}{
+				"id":     item.Id,
+				"status": http.StatusCreated,
+				"body":   map[string]interface{}{"id": "id-" + upn, "userPrincipalName": upn},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer server.Close()
+
+	batcher := NewUserCreateBatcher("00000000-0000-0000-0000-000000000000")
+	batcher.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	batcher.Window = 100 * time.Millisecond
+
+	const total = 25
+	var wg sync.WaitGroup
+	errs := make([]error, total)
+	users := make([]*msgraph.User, total)
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			upn := fmt.Sprintf("user%d@example.com", i)
+			if i%10 == 0 {
+				upn = fmt.Sprintf("fail%d@example.com", i)
+			}
+			users[i], _, errs[i] = batcher.Create(context.Background(), msgraph.User{UserPrincipalName: utils.String(upn)})
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < total; i++ {
+		if i%10 == 0 {
+			if errs[i] == nil {
+				t.Fatalf("expected an error for user %d", i)
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Fatalf("unexpected error for user %d: %v", i, errs[i])
+		}
+		if expected := fmt.Sprintf("id-user%d@example.com", i); users[i] == nil || users[i].ID == nil || *users[i].ID != expected {
+			t.Fatalf("expected user %d to have ID %q, got %v", i, expected, users[i])
+		}
+	}
+
+	sum := 0
+	for _, size := range batchSizes {
+		if size > userBatchMaxSize {
+			t.Fatalf("batch of size %d exceeds the maximum of %d", size, userBatchMaxSize)
+		}
+		sum += size
+	}
+	if sum != total {
+		t.Fatalf("expected %d users to be sent, got %d", total, sum)
+	}
+	if len(batchSizes) >= total {
+		t.Fatalf("expected users to be coalesced into fewer than %d batches, got %d", total, len(batchSizes))
+	}
+}
+
+func TestUserCreateBatcherRetriesThrottledUsers(t *testing.T) {
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []userBatchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding batch request: %v", err)
+		}
+
+		responses := make([]map[string]interface{}, 0)
+		for _, item := range req.Requests {
+			upn := *item.Body.UserPrincipalName
+
+			mu.Lock()
+			attempts[upn]++
+			attempt := attempts[upn]
+			mu.Unlock()
+
+			switch {
+			case strings.HasPrefix(upn, "throttled") && attempt == 1:
+				responses = append(responses, map[string]interface{}{
+					"id":      item.Id,
+					"status":  http.StatusTooManyRequests,
+					"headers": map[string]string{"Retry-After": "0"},
+					"body":    map[string]interface{}{"error": map[string]interface{}{"code": "TooManyRequests", "message": "Too many requests"}},
+				})
+			case strings.HasPrefix(upn, "unavailable"):
+				responses = append(responses, map[string]interface{}{
+					"id":     item.Id,
+					"status": http.StatusServiceUnavailable,
+					"body":   map[string]interface{}{"error": map[string]interface{}{"code": "ServiceUnavailable", "message": "Service unavailable"}},
+				})
+			default:
+				responses = append(responses, map[string]interface{}{
+					"id":     item.Id,
+					"status": http.StatusCreated,
+					"body":   map[string]interface{}{"id": "id-" + upn, "userPrincipalName": upn},
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer server.Close()
+
+	batcher := NewUserCreateBatcher("00000000-0000-0000-0000-000000000000")
+	batcher.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	batcher.Window = 10 * time.Millisecond
+	batcher.RetryInitialDelay = time.Millisecond
+
+	user, _, err := batcher.Create(context.Background(), msgraph.User{UserPrincipalName: utils.String("throttled@example.com")})
+	if err != nil {
+		t.Fatalf("unexpected error for throttled user: %v", err)
+	}
+	if user == nil || user.ID == nil || *user.ID != "id-throttled@example.com" {
+		t.Fatalf("unexpected user returned for throttled user: %v", user)
+	}
+	if attempts["throttled@example.com"] != 2 {
+		t.Fatalf("expected throttled user to be sent twice, got %d", attempts["throttled@example.com"])
+	}
+
+	_, status, err := batcher.Create(context.Background(), msgraph.User{UserPrincipalName: utils.String("unavailable@example.com")})
+	if err == nil {
+		t.Fatalf("expected an error for unavailable user")
+	}
+	if status != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d for unavailable user, got %d", http.StatusServiceUnavailable, status)
+	}
+	if attempts["unavailable@example.com"] != userBatchAttempts {
+		t.Fatalf("expected unavailable user to be sent %d times, got %d", userBatchAttempts, attempts["unavailable@example.com"])
+	}
+}
+
+func TestUserCreateBatcherCancellation(t *testing.T) {
+	received := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	sent := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Requests []userBatchRequest `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding batch request: %v", err)
+		}
+
+		mu.Lock()
+		sent += len(req.Requests)
+		mu.Unlock()
+
+		received <- struct{}{}
+		<-release
+
+		responses := make([]map[string]interface{}, 0)
+		for _, item := range req.Requests {
+			upn := *item.Body.UserPrincipalName
+			responses = append(responses, map[string]interface{}{
+				"id":     item.Id,
+				"status": http.StatusCreated,
+				"body":   map[string]interface{}{"id": "id-" + upn, "userPrincipalName": upn},
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"responses": responses})
+	}))
+	defer server.Close()
+
+	batcher := NewUserCreateBatcher("00000000-0000-0000-0000-000000000000")
+	batcher.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+
+	// A user which has not been sent is withdrawn, and never created
+	batcher.Window = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := batcher.Create(ctx, msgraph.User{UserPrincipalName: utils.String("pending@example.com")}); err == nil {
+		t.Fatalf("expected an error for cancelled pending user")
+	}
+	batcher.mu.Lock()
+	if len(batcher.pending) != 0 {
+		t.Fatalf("expected cancelled user to be removed from the queue, %d users remain", len(batcher.pending))
+	}
+	batcher.timer.Stop()
+	batcher.timer = nil
+	batcher.mu.Unlock()
+
+	// A user which has been sent may already exist, so its result is returned after cancellation
+	batcher.Window = time.Millisecond
+	ctx, cancel = context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var user *msgraph.User
+	var err error
+	go func() {
+		defer close(done)
+		user, _, err = batcher.Create(ctx, msgraph.User{UserPrincipalName: utils.String("inflight@example.com")})
+	}()
+
+	<-received
+	cancel()
+	close(release)
+	<-done
+
+	if err != nil {
+		t.Fatalf("unexpected error for cancelled in-flight user: %v", err)
+	}
+	if user == nil || user.ID == nil || *user.ID != "id-inflight@example.com" {
+		t.Fatalf("unexpected user returned for cancelled in-flight user: %v", user)
+	}
+	if sent != 1 {
+		t.Fatalf("expected only the in-flight user to be sent, got %d", sent)
+	}
+}
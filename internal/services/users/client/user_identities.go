@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ObjectIdentity describes a sign-in identity for a user
+type ObjectIdentity struct {
+	Issuer           *string `json:"issuer,omitempty"`
+	IssuerAssignedId *string `json:"issuerAssignedId,omitempty"`
+	SignInType       *string `json:"signInType,omitempty"`
+}
+
+// UserWithIdentities is a User which additionally includes its sign-in identities, which are not modelled by msgraph.User
+type UserWithIdentities struct {
+	msgraph.User
+	Identities *[]ObjectIdentity `json:"identities,omitempty"`
+}
+
+// UserIdentitiesClient performs operations on Users which involve their sign-in identities.
+type UserIdentitiesClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewUserIdentitiesClient returns a new UserIdentitiesClient.
+func NewUserIdentitiesClient(tenantId string) *UserIdentitiesClient {
+	return &UserIdentitiesClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Create creates a new User including the specified identities.
+func (c *UserIdentitiesClient) Create(ctx context.Context, user UserWithIdentities) (*msgraph.User, int, error) {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return nil, 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	resp, status, _, err := c.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusCreated},
+		Uri: msgraph.Uri{
+			Entity:      "/users",
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UserIdentitiesClient.BaseClient.Post(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var newUser msgraph.User
+	if err := json.Unmarshal(respBody, &newUser); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &newUser, status, nil
+}
+
+// Get retrieves the identities for a User.
+func (c *UserIdentitiesClient) Get(ctx context.Context, id string) (*[]ObjectIdentity, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s", id),
+			Params:      url.Values{"$select": []string{"identities"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UserIdentitiesClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var data struct {
+		Identities []ObjectIdentity `json:"identities"`
+	}
+	if err := json.Unmarshal(respBody, &data); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &data.Identities, status, nil
+}
+
+// Update replaces the identities for a User. The full collection of identities must be specified.
+func (c *UserIdentitiesClient) Update(ctx context.Context, id string, identities []ObjectIdentity) (int, error) {
+	body, err := json.Marshal(struct {
+		Identities []ObjectIdentity `json:"identities"`
+	}{identities})
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:             body,
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UserIdentitiesClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
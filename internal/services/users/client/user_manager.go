@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// UserManagerClient manages the manager relationship of Users.
+type UserManagerClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewUserManagerClient returns a new UserManagerClient.
+func NewUserManagerClient(tenantId string) *UserManagerClient {
+	return &UserManagerClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// Get returns the object ID of the manager of a User. A 404 status is returned when the user has no manager.
+func (c *UserManagerClient) Get(ctx context.Context, id string) (*string, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/manager", id),
+			Params:      url.Values{"$select": []string{"id"}},
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UserManagerClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var manager struct {
+		Id *string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &manager); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return manager.Id, status, nil
+}
+
+// Assign sets the manager of a User, replacing any existing manager.
+func (c *UserManagerClient) Assign(ctx context.Context, id, managerId string) (int, error) {
+	data := struct {
+		Manager string `json:"@odata.id"`
+	}{
+		Manager: fmt.Sprintf("%s/%s/directoryObjects/%s", c.BaseClient.Endpoint, c.BaseClient.ApiVersion, managerId),
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := c.BaseClient.Put(ctx, msgraph.PutHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/manager/$ref", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UserManagerClient.BaseClient.Put(): %v", err)
+	}
+	return status, nil
+}
+
+// Remove clears the manager of a User. A 404 status is returned when the user has no manager.
+func (c *UserManagerClient) Remove(ctx context.Context, id string) (int, error) {
+	_, status, _, err := c.BaseClient.Delete(ctx, msgraph.DeleteHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/manager/$ref", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UserManagerClient.BaseClient.Delete(): %v", err)
+	}
+	return status, nil
+}
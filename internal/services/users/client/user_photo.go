@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/manicminer/hamilton/msgraph"
+)
+
+// ProfilePhoto describes the metadata of a user's profile photo
+type ProfilePhoto struct {
+	ID     *string `json:"id,omitempty"`
+	Height *int    `json:"height,omitempty"`
+	Width  *int    `json:"width,omitempty"`
+}
+
+// UserPhotoContent holds the binary content of a user's profile photo
+type UserPhotoContent struct {
+	Content     []byte
+	ContentType string
+
+	// ExceedsMaxSize indicates that the photo was larger than the requested maximum size, in which case Content is nil
+	ExceedsMaxSize bool
+}
+
+// UserPhotoClient performs operations on the profile photos of Users.
+type UserPhotoClient struct {
+	BaseClient msgraph.Client
+}
+
+// NewUserPhotoClient returns a new UserPhotoClient.
+func NewUserPhotoClient(tenantId string) *UserPhotoClient {
+	return &UserPhotoClient{
+		BaseClient: msgraph.NewClient(msgraph.Version10, tenantId),
+	}
+}
+
+// GetMetadata retrieves the metadata for the profile photo of a User. A 404 status is returned when the user has no photo.
+func (c *UserPhotoClient) GetMetadata(ctx context.Context, id string) (*ProfilePhoto, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/photo", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UserPhotoClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	var photo ProfilePhoto
+	if err := json.Unmarshal(respBody, &photo); err != nil {
+		return nil, status, fmt.Errorf("json.Unmarshal(): %v", err)
+	}
+	return &photo, status, nil
+}
+
+// GetContent retrieves the binary content of the profile photo of a User. Photos larger than maxBytes are not returned,
+// instead ExceedsMaxSize is set in the result. A 404 status is returned when the user has no photo.
+func (c *UserPhotoClient) GetContent(ctx context.Context, id string, maxBytes int64) (*UserPhotoContent, int, error) {
+	resp, status, _, err := c.BaseClient.Get(ctx, msgraph.GetHttpRequestInput{
+		ValidStatusCodes: []int{http.StatusOK},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/photo/$value", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return nil, status, fmt.Errorf("UserPhotoClient.BaseClient.Get(): %v", err)
+	}
+	defer resp.Body.Close()
+
+	result := UserPhotoContent{
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if resp.ContentLength > maxBytes {
+		result.ExceedsMaxSize = true
+		return &result, status, nil
+	}
+
+	// The Content-Length header may be absent, so read at most one byte more than permitted to detect oversized photos
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, status, fmt.Errorf("io.ReadAll(): %v", err)
+	}
+	if int64(len(content)) > maxBytes {
+		result.ExceedsMaxSize = true
+		return &result, status, nil
+	}
+
+	result.Content = content
+	return &result, status, nil
+}
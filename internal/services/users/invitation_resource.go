@@ -0,0 +1,238 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
+)
+
+func invitationResource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: invitationResourceCreate,
+		ReadContext:   invitationResourceRead,
+		DeleteContext: invitationResourceDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_email_address": {
+				Description:      "The email address of the user being invited",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.StringIsEmailAddress,
+			},
+
+			"redirect_url": {
+				Description:      "The URL that the user should be redirected to once the invitation is redeemed",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.IsHTTPOrHTTPSURL,
+			},
+
+			"user_display_name": {
+				Description:      "The display name of the user being invited",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validate.NoEmptyStrings,
+			},
+
+			"user_type": {
+				Description:  "The user type of the user being invited",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "Guest",
+				ValidateFunc: validation.StringInSlice([]string{"Guest", "Member"}, false),
+			},
+
+			"message": {
+				Description: "Customize the message sent to the invited user",
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"additional_recipients": {
+							Description: "Email addresses of additional recipients the invitation message should be sent to",
+							Type:        schema.TypeList,
+							Optional:    true,
+							ForceNew:    true,
+							MaxItems:    1,
+							Elem: &schema.Schema{
+								Type:             schema.TypeString,
+								ValidateDiagFunc: validate.StringIsEmailAddress,
+							},
+						},
+
+						"body": {
+							Description:      "Customized message body you want to send if you don't want to send the default message",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							ConflictsWith:    []string{"message.0.language"},
+							ValidateDiagFunc: validate.NoEmptyStrings,
+						},
+
+						"language": {
+							Description:      "The language you want to send the default message in",
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							ConflictsWith:    []string{"message.0.body"},
+							ValidateDiagFunc: validate.NoEmptyStrings,
+						},
+					},
+				},
+			},
+
+			"redeem_url": {
+				Description: "The URL the user can use to redeem their invitation",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"user_id": {
+				Description: "Object ID of the invited user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func invitationResourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Users.InvitationsClient
+
+	properties := msgraph.Invitation{
+		InvitedUserEmailAddress: utils.String(d.Get("user_email_address").(string)),
+		InviteRedirectURL:       utils.String(d.Get("redirect_url").(string)),
+		InvitedUserType:         utils.String(d.Get("user_type").(string)),
+	}
+
+	if v, ok := d.GetOk("user_display_name"); ok {
+		properties.InvitedUserDisplayName = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("message"); ok {
+		properties.SendInvitationMessage = utils.Bool(true)
+		properties.InvitedUserMessageInfo = expandInvitedUserMessageInfo(v.([]interface{}))
+	}
+
+	invitation, status, err := client.Create(ctx, properties)
+	if err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationUserInvite, meta.(*clients.Client).Claims)
+		return tf.ErrorDiagF(err, "Creating invitation for %q", *properties.InvitedUserEmailAddress)
+	}
+
+	if invitation.ID == nil || *invitation.ID == "" {
+		return tf.ErrorDiagF(errors.New("ID returned for invitation is nil/empty"), "Bad API response")
+	}
+	if invitation.InvitedUser == nil || invitation.InvitedUser.ID == nil || *invitation.InvitedUser.ID == "" {
+		return tf.ErrorDiagF(errors.New("Object ID returned for invited user is nil/empty"), "Bad API response")
+	}
+
+	d.SetId(*invitation.ID)
+	tf.Set(d, "user_id", invitation.InvitedUser.ID)
+	tf.Set(d, "redeem_url", invitation.InviteRedeemURL)
+
+	return invitationResourceRead(ctx, d, meta)
+}
+
+func invitationResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Users.UsersClient
+
+	// Invitations cannot be retrieved from the API once created, so the invited user is read instead
+	userId := d.Get("user_id").(string)
+
+	user, status, err := client.Get(ctx, userId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] Invited user with object ID %q was not found - removing invitation %q from state", userId, d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "Retrieving invited user with object ID %q", userId)
+	}
+
+	tf.Set(d, "user_id", user.ID)
+
+	return nil
+}
+
+func invitationResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client).Users.UsersClient
+	userId := d.Get("user_id").(string)
+
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "invited user",
+		ObjectId:   userId,
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, userId)
+			return status, err
+		},
+	}
+
+	_, status, err := client.Get(ctx, userId)
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
+
+	status, err = client.Delete(ctx, userId)
+	return deletion.CheckDeleted(ctx, status, err)
+}
+
+func expandInvitedUserMessageInfo(in []interface{}) *msgraph.InvitedUserMessageInfo {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+	config := in[0].(map[string]interface{})
+
+	result := msgraph.InvitedUserMessageInfo{}
+
+	if v, ok := config["additional_recipients"].([]interface{}); ok && len(v) > 0 {
+		recipients := make([]msgraph.Recipient, 0, len(v))
+		for _, address := range v {
+			recipients = append(recipients, msgraph.Recipient{
+				EmailAddress: &msgraph.EmailAddress{
+					Address: utils.String(address.(string)),
+				},
+			})
+		}
+		result.CCRecipients = &recipients
+	}
+
+	if v, ok := config["body"].(string); ok && v != "" {
+		result.CustomizedMessageBody = utils.String(v)
+	}
+
+	if v, ok := config["language"].(string); ok && v != "" {
+		result.MessageLanguage = utils.String(v)
+	}
+
+	return &result
+}
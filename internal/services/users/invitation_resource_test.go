@@ -0,0 +1,184 @@
+package users_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azuread/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+type InvitationResource struct{}
+
+func TestAccInvitation_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_invitation", "test")
+	r := InvitationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.basic(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user_id").IsUuid(),
+				check.That(data.ResourceName).Key("redeem_url").Exists(),
+				check.That(data.ResourceName).Key("user_type").HasValue("Guest"),
+			),
+		},
+	})
+}
+
+func TestAccInvitation_member(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_invitation", "test")
+	r := InvitationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.member(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user_id").IsUuid(),
+				check.That(data.ResourceName).Key("user_type").HasValue("Member"),
+			),
+		},
+	})
+}
+
+func TestAccInvitation_messageBody(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_invitation", "test")
+	r := InvitationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.messageBody(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user_id").IsUuid(),
+			),
+		},
+	})
+}
+
+func TestAccInvitation_messageLanguage(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_invitation", "test")
+	r := InvitationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.messageLanguage(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("user_id").IsUuid(),
+			),
+		},
+	})
+}
+
+func TestAccInvitation_groupMember(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_invitation", "test")
+	r := InvitationResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.groupMember(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_group_member.test").Key("member_object_id").IsUuid(),
+			),
+		},
+	})
+}
+
+func (r InvitationResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
+	client := clients.Users.UsersClient
+	client.BaseClient.DisableRetries = true
+
+	userId := state.Attributes["user_id"]
+
+	user, status, err := client.Get(ctx, userId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return nil, fmt.Errorf("Invited user with object ID %q does not exist", userId)
+		}
+		return nil, fmt.Errorf("failed to retrieve invited user with object ID %q: %+v", userId, err)
+	}
+	return utils.Bool(user.ID != nil && *user.ID == userId), nil
+}
+
+func (InvitationResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_invitation" "test" {
+  user_email_address = "acctest-invited-%[1]d@test.com"
+  redirect_url       = "https://portal.azure.com"
+}
+`, data.RandomInteger)
+}
+
+func (InvitationResource) member(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_invitation" "test" {
+  user_email_address = "acctest-invited-%[1]d@test.com"
+  user_display_name  = "acctest-invited-%[1]d"
+  redirect_url       = "https://portal.azure.com"
+  user_type          = "Member"
+}
+`, data.RandomInteger)
+}
+
+func (InvitationResource) messageBody(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_invitation" "test" {
+  user_email_address = "acctest-invited-%[1]d@test.com"
+  user_display_name  = "acctest-invited-%[1]d"
+  redirect_url       = "https://portal.azure.com"
+
+  message {
+    additional_recipients = ["acctest-additional-%[1]d@test.com"]
+    body                  = "Hello there! You are invited to join my Azure tenant."
+  }
+}
+`, data.RandomInteger)
+}
+
+func (InvitationResource) messageLanguage(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_invitation" "test" {
+  user_email_address = "acctest-invited-%[1]d@test.com"
+  redirect_url       = "https://portal.azure.com"
+
+  message {
+    language = "fr-CA"
+  }
+}
+`, data.RandomInteger)
+}
+
+func (r InvitationResource) groupMember(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "test" {
+  display_name     = "acctestGroup-%[2]d"
+  security_enabled = true
+}
+
+resource "azuread_group_member" "test" {
+  group_object_id  = azuread_group.test.object_id
+  member_object_id = azuread_invitation.test.user_id
+}
+`, r.basic(data), data.RandomInteger)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
 )
@@ -56,12 +57,54 @@ func userDataSource() *schema.Resource {
 				ValidateDiagFunc: validate.NoEmptyStrings,
 			},
 
+			"fail_if_not_found": tf.DataSourceFailIfNotFoundSchema("user"),
+
+			"found": tf.DataSourceFoundSchema("user"),
+
+			"include_member_of": {
+				Description: "Whether to retrieve the object IDs of groups that the user is a member of, either directly or transitively, into the `member_of` attribute",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"include_photo": {
+				Description: "Whether to retrieve the user's profile photo into the `photo` attribute",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"member_of": {
+				Description: "A list of object IDs of groups that the user is a member of, either directly or transitively. Only populated when `include_member_of` is `true`",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"account_enabled": {
 				Description: "Whether or not the account is enabled",
 				Type:        schema.TypeBool,
 				Computed:    true,
 			},
 
+			"age_group": {
+				Description: "The age group of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"business_phones": {
+				Description: "The telephone numbers for the user",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
 			"city": {
 				Description: "The city in which the user is located",
 				Type:        schema.TypeString,
@@ -74,6 +117,18 @@ func userDataSource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"consent_provided_for_minor": {
+				Description: "Whether consent has been obtained for minors",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"cost_center": {
+				Description: "The cost center associated with the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"country": {
 				Description: "The country/region in which the user is located, e.g. `US` or `UK`",
 				Type:        schema.TypeString,
@@ -92,12 +147,63 @@ func userDataSource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"division": {
+				Description: "The name of the division in which the user works",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"employee_id": {
+				Description: "The employee identifier assigned to the user by the organisation",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"employee_type": {
+				Description: "Captures enterprise worker type, e.g. `Employee`, `Contractor`, `Consultant` or `Vendor`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"fax_number": {
+				Description: "The fax number of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"given_name": {
 				Description: "The given name (first name) of the user",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 
+			"identities": {
+				Description: "A list of identities with which the user can sign in, including the identity for the user principal name",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer": {
+							Description: "The issuer of the identity",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"issuer_assigned_id": {
+							Description: "The unique identifier assigned to the user by the issuer",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
+						"sign_in_type": {
+							Description: "The type of sign-in identity, e.g. `emailAddress`, `federated`, `userName` or `userPrincipalName`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+
 			"job_title": {
 				Description: "The user’s job title",
 				Type:        schema.TypeString,
@@ -134,18 +240,54 @@ func userDataSource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"onpremises_security_identifier": {
+				Description: "The on-premise security identifier (SID) of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"onpremises_user_principal_name": {
 				Description: "The on-premise user principal name of the user",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 
+			"photo": {
+				Description: "The user's profile photo, base64-encoded. Only populated when `include_photo` is `true`, and empty when the user has no photo or the photo is too large",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"photo_content_type": {
+				Description: "The content type of the user's profile photo, e.g. `image/jpeg`. Only populated when `include_photo` is `true`",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
+			"photo_height": {
+				Description: "The height of the user's profile photo in pixels. Only populated when `include_photo` is `true`",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
+			"photo_width": {
+				Description: "The width of the user's profile photo in pixels. Only populated when `include_photo` is `true`",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+
 			"postal_code": {
 				Description: "The postal code for the user's postal address. The postal code is specific to the user's country/region. In the United States of America, this attribute contains the ZIP code",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
 
+			"security_identifier": {
+				Description: "The security identifier (SID) of the user, which can be used to grant access to resources such as Azure SQL databases",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"state": {
 				Description: "The state or province in the user's address",
 				Type:        schema.TypeString,
@@ -185,7 +327,7 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 	var user msgraph.User
 
 	if upn, ok := d.Get("user_principal_name").(string); ok && upn != "" {
-		filter := fmt.Sprintf("userPrincipalName eq '%s'", upn)
+		filter := helpers.ODataEq("userPrincipalName", upn)
 		users, _, err := client.List(ctx, filter)
 		if err != nil {
 			return tf.ErrorDiagF(err, "Finding user with UPN: %q", upn)
@@ -197,14 +339,14 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 		if count > 1 {
 			return tf.ErrorDiagPathF(nil, "user_principal_name", "More than one user found with UPN: %q", upn)
 		} else if count == 0 {
-			return tf.ErrorDiagPathF(err, "user_principal_name", "User with UPN %q was not found", upn)
+			return tf.DataSourceNotFound(d, "user_principal_name", upn, tf.ErrorDiagPathF(err, "user_principal_name", "User with UPN %q was not found", upn))
 		}
 		user = (*users)[0]
 	} else if objectId, ok := d.Get("object_id").(string); ok && objectId != "" {
 		u, status, err := client.Get(ctx, objectId)
 		if err != nil {
 			if status == http.StatusNotFound {
-				return tf.ErrorDiagPathF(nil, "object_id", "User not found with object ID: %q", objectId)
+				return tf.DataSourceNotFound(d, "object_id", objectId, tf.ErrorDiagPathF(nil, "object_id", "User not found with object ID: %q", objectId))
 			}
 			return tf.ErrorDiagF(err, "Retrieving user with object ID: %q", objectId)
 		}
@@ -213,7 +355,7 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 		}
 		user = *u
 	} else if mailNickname, ok := d.Get("mail_nickname").(string); ok && mailNickname != "" {
-		filter := fmt.Sprintf("mailNickname eq '%s'", mailNickname)
+		filter := helpers.ODataEq("mailNickname", mailNickname)
 		users, _, err := client.List(ctx, filter)
 		if err != nil {
 			return tf.ErrorDiagF(err, "Finding user with email alias: %q", mailNickname)
@@ -225,7 +367,7 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 		if count > 1 {
 			return tf.ErrorDiagPathF(nil, "mail_nickname", "More than one user found with email alias: %q", upn)
 		} else if count == 0 {
-			return tf.ErrorDiagPathF(err, "mail_nickname", "User not found with email alias: %q", upn)
+			return tf.DataSourceNotFound(d, "mail_nickname", mailNickname, tf.ErrorDiagPathF(err, "mail_nickname", "User not found with email alias: %q", upn))
 		}
 		user = (*users)[0]
 	} else {
@@ -238,12 +380,28 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(*user.ID)
 
+	// Some profile properties are not returned unless selected, and are not modelled by msgraph.User
+	profile, _, err := userGetForResource(ctx, client, *user.ID)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Retrieving profile for user with object ID: %q", *user.ID)
+	}
+	costCenter, division := flattenUserEmployeeOrgData(profile.EmployeeOrgData)
+
 	tf.Set(d, "account_enabled", user.AccountEnabled)
+	tf.Set(d, "age_group", profile.AgeGroup)
+	tf.Set(d, "business_phones", tf.FlattenStringSlicePtr(profile.BusinessPhones))
 	tf.Set(d, "city", user.City)
 	tf.Set(d, "company_name", user.CompanyName)
+	tf.Set(d, "consent_provided_for_minor", profile.ConsentProvidedForMinor)
+	tf.Set(d, "cost_center", costCenter)
 	tf.Set(d, "country", user.Country)
 	tf.Set(d, "department", user.Department)
 	tf.Set(d, "display_name", user.DisplayName)
+	tf.Set(d, "division", division)
+	tf.Set(d, "employee_id", profile.EmployeeId)
+	tf.Set(d, "employee_type", profile.EmployeeType)
+	tf.Set(d, "fax_number", profile.FaxNumber)
+	tf.Set(d, "found", true)
 	tf.Set(d, "given_name", user.GivenName)
 	tf.Set(d, "job_title", user.JobTitle)
 	tf.Set(d, "mail", user.Mail)
@@ -253,8 +411,10 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 	tf.Set(d, "office_location", user.OfficeLocation)
 	tf.Set(d, "onpremises_immutable_id", user.OnPremisesImmutableId)
 	tf.Set(d, "onpremises_sam_account_name", user.OnPremisesSamAccountName)
+	tf.Set(d, "onpremises_security_identifier", profile.OnPremisesSecurityIdentifier)
 	tf.Set(d, "onpremises_user_principal_name", user.OnPremisesUserPrincipalName)
 	tf.Set(d, "postal_code", user.PostalCode)
+	tf.Set(d, "security_identifier", profile.SecurityIdentifier)
 	tf.Set(d, "state", user.State)
 	tf.Set(d, "street_address", user.StreetAddress)
 	tf.Set(d, "surname", user.Surname)
@@ -262,5 +422,35 @@ func userDataSourceRead(ctx context.Context, d *schema.ResourceData, meta interf
 	tf.Set(d, "user_principal_name", user.UserPrincipalName)
 	tf.Set(d, "user_type", user.UserType)
 
-	return nil
+	identities, _, err := meta.(*clients.Client).Users.UserIdentitiesClient.Get(ctx, *user.ID)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "identities", "Could not retrieve identities for user with object ID: %q", *user.ID)
+	}
+	tf.Set(d, "identities", flattenUserIdentities(identities, true))
+
+	var diags diag.Diagnostics
+	memberOf := make([]string, 0)
+	if d.Get("include_member_of").(bool) {
+		memberOf, diags = helpers.FlattenTransitiveMemberOf(ctx, client.BaseClient, fmt.Sprintf("/users/%s", *user.ID))
+		if diags.HasError() {
+			return diags
+		}
+	}
+	tf.Set(d, "member_of", memberOf)
+
+	photo := userPhoto{}
+	if d.Get("include_photo").(bool) {
+		var photoDiags diag.Diagnostics
+		photo, photoDiags = userGetPhoto(ctx, meta.(*clients.Client).Users.UserPhotoClient, *user.ID)
+		diags = append(diags, photoDiags...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	tf.Set(d, "photo", photo.Content)
+	tf.Set(d, "photo_content_type", photo.ContentType)
+	tf.Set(d, "photo_height", photo.Height)
+	tf.Set(d, "photo_width", photo.Width)
+
+	return diags
 }
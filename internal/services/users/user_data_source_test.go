@@ -32,6 +32,20 @@ func TestAccUserDataSource_byUserPrincipalNameNonexistent(t *testing.T) {
 	}})
 }
 
+func TestAccUserDataSource_byUserPrincipalNameNonexistentOptional(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UserDataSource{}.byUserPrincipalNameNonexistentOptional(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("found").HasValue("false"),
+			check.That(data.ResourceName).Key("display_name").IsEmpty(),
+			check.That(data.ResourceName).Key("object_id").IsEmpty(),
+			check.That("azuread_group.test").Key("members.#").HasValue("0"),
+		),
+	}})
+}
+
 func TestAccUserDataSource_byObjectId(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
 	r := UserDataSource{}
@@ -51,6 +65,18 @@ func TestAccUserDataSource_byObjectIdNonexistent(t *testing.T) {
 	}})
 }
 
+func TestAccUserDataSource_byObjectIdNonexistentOptional(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UserDataSource{}.byObjectIdNonexistentOptional(),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("found").HasValue("false"),
+			check.That(data.ResourceName).Key("user_principal_name").IsEmpty(),
+		),
+	}})
+}
+
 func TestAccUserDataSource_byMailNickname(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
 	r := UserDataSource{}
@@ -70,14 +96,51 @@ func TestAccUserDataSource_byMailNicknameNonexistent(t *testing.T) {
 	}})
 }
 
+func TestAccUserDataSource_memberOf(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
+	r := UserDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: r.memberOf(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("object_id").Exists(),
+			check.That(data.ResourceName).Key("member_of.#").HasValue("2"),
+		),
+	}})
+}
+
+func TestAccUserDataSource_photoNotSet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_user", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UserDataSource{}.photo(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("object_id").IsUuid(),
+			check.That(data.ResourceName).Key("photo").IsEmpty(),
+			check.That(data.ResourceName).Key("photo_content_type").IsEmpty(),
+			check.That(data.ResourceName).Key("photo_height").HasValue("0"),
+			check.That(data.ResourceName).Key("photo_width").HasValue("0"),
+		),
+	}})
+}
+
 func (UserDataSource) testCheckFunc(data acceptance.TestData) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		check.That(data.ResourceName).Key("account_enabled").Exists(),
+		check.That(data.ResourceName).Key("age_group").HasValue("NotAdult"),
+		check.That(data.ResourceName).Key("business_phones.0").HasValue("(555) 555-5556"),
 		check.That(data.ResourceName).Key("city").HasValue(fmt.Sprintf("acctestUser-%d-City", data.RandomInteger)),
 		check.That(data.ResourceName).Key("company_name").HasValue(fmt.Sprintf("acctestUser-%d-Company", data.RandomInteger)),
+		check.That(data.ResourceName).Key("consent_provided_for_minor").HasValue("Granted"),
+		check.That(data.ResourceName).Key("cost_center").HasValue(fmt.Sprintf("acctestUser-%d-CostCenter", data.RandomInteger)),
 		check.That(data.ResourceName).Key("country").HasValue(fmt.Sprintf("acctestUser-%d-Country", data.RandomInteger)),
 		check.That(data.ResourceName).Key("department").HasValue(fmt.Sprintf("acctestUser-%d-Dept", data.RandomInteger)),
 		check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestUser-%d-DisplayName", data.RandomInteger)),
+		check.That(data.ResourceName).Key("division").HasValue(fmt.Sprintf("acctestUser-%d-Division", data.RandomInteger)),
+		check.That(data.ResourceName).Key("employee_id").HasValue(fmt.Sprintf("E-%s", data.RandomString)),
+		check.That(data.ResourceName).Key("employee_type").HasValue("Contractor"),
+		check.That(data.ResourceName).Key("fax_number").HasValue("(555) 555-5560"),
+		check.That(data.ResourceName).Key("found").HasValue("true"),
 		check.That(data.ResourceName).Key("given_name").HasValue(fmt.Sprintf("acctestUser-%d-GivenName", data.RandomInteger)),
 		check.That(data.ResourceName).Key("job_title").HasValue(fmt.Sprintf("acctestUser-%d-Job", data.RandomInteger)),
 		//check.That(data.ResourceName).Key("mail").Exists(), // TODO only set for O365 domains
@@ -86,7 +149,9 @@ func (UserDataSource) testCheckFunc(data acceptance.TestData) resource.TestCheck
 		check.That(data.ResourceName).Key("object_id").IsUuid(),
 		check.That(data.ResourceName).Key("office_location").HasValue(fmt.Sprintf("acctestUser-%d-OfficeLocation", data.RandomInteger)),
 		check.That(data.ResourceName).Key("onpremises_immutable_id").Exists(),
+		check.That(data.ResourceName).Key("onpremises_security_identifier").IsEmpty(),
 		check.That(data.ResourceName).Key("postal_code").HasValue("111111"),
+		check.That(data.ResourceName).Key("security_identifier").MatchesRegex(regexp.MustCompile(`^S-1-12-1-`)),
 		check.That(data.ResourceName).Key("state").HasValue(fmt.Sprintf("acctestUser-%d-State", data.RandomInteger)),
 		check.That(data.ResourceName).Key("street_address").HasValue(fmt.Sprintf("acctestUser-%d-Street", data.RandomInteger)),
 		check.That(data.ResourceName).Key("surname").HasValue(fmt.Sprintf("acctestUser-%d-Surname", data.RandomInteger)),
@@ -118,6 +183,25 @@ data "azuread_user" "test" {
 `, data.RandomInteger)
 }
 
+func (UserDataSource) byUserPrincipalNameNonexistentOptional(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+data "azuread_user" "test" {
+  user_principal_name = "not-a-real-user-%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  fail_if_not_found   = false
+}
+
+resource "azuread_group" "test" {
+  display_name     = "acctestUser-optional-%[1]d"
+  security_enabled = true
+  members          = data.azuread_user.test.found ? [data.azuread_user.test.object_id] : []
+}
+`, data.RandomInteger)
+}
+
 func (UserDataSource) byObjectId(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -136,6 +220,15 @@ data "azuread_user" "test" {
 `
 }
 
+func (UserDataSource) byObjectIdNonexistentOptional() string {
+	return `
+data "azuread_user" "test" {
+  object_id         = "00000000-0000-0000-0000-000000000000"
+  fail_if_not_found = false
+}
+`
+}
+
 func (UserDataSource) byMailNickname(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %[1]s
@@ -157,3 +250,39 @@ data "azuread_user" "test" {
 }
 `, data.RandomInteger)
 }
+
+func (UserDataSource) memberOf(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "direct" {
+  display_name     = "acctestUser-direct-%[2]d"
+  security_enabled = true
+  members          = [azuread_user.test.object_id]
+}
+
+resource "azuread_group" "transitive" {
+  display_name     = "acctestUser-transitive-%[2]d"
+  security_enabled = true
+  members          = [azuread_group.direct.object_id]
+}
+
+data "azuread_user" "test" {
+  object_id         = azuread_user.test.object_id
+  include_member_of = true
+
+  depends_on = [azuread_group.transitive]
+}
+`, UserResource{}.basic(data), data.RandomInteger)
+}
+
+func (UserDataSource) photo(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_user" "test" {
+  object_id     = azuread_user.test.object_id
+  include_photo = true
+}
+`, UserResource{}.basic(data))
+}
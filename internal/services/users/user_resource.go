@@ -16,6 +16,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
@@ -37,12 +38,12 @@ func userResource() *schema.Resource {
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
-		Importer: tf.ValidateResourceIDPriorToImport(func(id string) error {
+		Importer: tf.ValidateResourceIDPriorToImportThen(func(id string) error {
 			if _, err := uuid.ParseUUID(id); err != nil {
 				return fmt.Errorf("specified ID (%q) is not valid: %s", id, err)
 			}
 			return nil
-		}),
+		}, userResourceImport),
 
 		Schema: map[string]*schema.Schema{
 			"user_principal_name": {
@@ -67,6 +68,23 @@ func userResource() *schema.Resource {
 				Default:     true,
 			},
 
+			"age_group": {
+				Description:  "The age group of the user. Supported values are `Adult`, `NotAdult` and `Minor`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(userAgeGroups, false),
+			},
+
+			"business_phones": {
+				Description: "The telephone numbers for the user. Only one number can be set for this property. Read-only for users synchronized with Azure AD Connect",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.NoEmptyStrings,
+				},
+			},
+
 			"city": {
 				Description: "The city in which the user is located",
 				Type:        schema.TypeString,
@@ -79,6 +97,19 @@ func userResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"consent_provided_for_minor": {
+				Description:  "Whether consent has been obtained for minors. Supported values are `Granted`, `Denied` and `NotRequired`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(userConsentProvidedForMinorValues, false),
+			},
+
+			"cost_center": {
+				Description: "The cost center associated with the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
 			"country": {
 				Description: "The country/region in which the user is located, e.g. `US` or `UK`",
 				Type:        schema.TypeString,
@@ -91,6 +122,31 @@ func userResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"division": {
+				Description: "The name of the division in which the user works",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"employee_id": {
+				Description:  "The employee identifier assigned to the user by the organisation",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(0, 16),
+			},
+
+			"employee_type": {
+				Description: "Captures enterprise worker type, e.g. `Employee`, `Contractor`, `Consultant` or `Vendor`",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
+			"fax_number": {
+				Description: "The fax number of the user",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+
 			"force_password_change": {
 				Description: "Whether the user is forced to change the password during the next sign-in. Only takes effect when also changing the password",
 				Type:        schema.TypeBool,
@@ -104,6 +160,41 @@ func userResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"identities": {
+				Description: "One or more `identities` blocks describing the identities with which the user can sign in. The identity for the user principal name is managed automatically and is not included",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"issuer": {
+							Description:      "The issuer of the identity, e.g. `contoso.onmicrosoft.com` for local accounts, or the domain of a federated identity provider",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.NoEmptyStrings,
+						},
+
+						"issuer_assigned_id": {
+							Description:      "The unique identifier assigned to the user by the issuer, e.g. an email address for `emailAddress` sign-in",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validate.NoEmptyStrings,
+						},
+
+						"sign_in_type": {
+							Description: "The type of sign-in identity. Possible values are `emailAddress`, `federated` or `userName`",
+							Type:        schema.TypeString,
+							Required:    true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"emailAddress",
+								"federated",
+								"userName",
+							}, false),
+						},
+					},
+				},
+			},
+
 			"job_title": {
 				Description: "The user’s job title",
 				Type:        schema.TypeString,
@@ -123,6 +214,13 @@ func userResource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"manager_id": {
+				Description:      "The object ID of the user's manager",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validate.UUID,
+			},
+
 			"mobile_phone": {
 				Description: "The primary cellular telephone number for the user",
 				Type:        schema.TypeString,
@@ -148,6 +246,12 @@ func userResource() *schema.Resource {
 				Computed:    true,
 			},
 
+			"onpremises_security_identifier": {
+				Description: "The on-premise security identifier (SID) of the user",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"onpremises_user_principal_name": {
 				Description: "The on-premise user principal name of the user",
 				Type:        schema.TypeString,
@@ -169,6 +273,12 @@ func userResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"security_identifier": {
+				Description: "The security identifier (SID) of the user, which can be used to grant access to resources such as Azure SQL databases",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+
 			"street_address": {
 				Description: "The street address of the user's place of business",
 				Type:        schema.TypeString,
@@ -181,6 +291,27 @@ func userResource() *schema.Resource {
 				Optional:    true,
 			},
 
+			"prevent_duplicate_names": {
+				Description: "If `true`, will return an error if an existing user is found with the same display name or mail nickname",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"block_sign_in_on_destroy": {
+				Description: "Whether to disable the user account and revoke its sign-in sessions when the resource is destroyed, instead of deleting the user",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
+			"skip_upn_domain_validation": {
+				Description: "Whether to skip validation of the domain part of the user principal name against the tenant's verified domains at plan time. This is useful when the domain is being added and verified in the same apply",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+
 			"surname": {
 				Description: "The user's surname (family name or last name)",
 				Type:        schema.TypeString,
@@ -212,6 +343,36 @@ func userResourceCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m
 	if diff.Id() == "" && diff.Get("password").(string) == "" {
 		return fmt.Errorf("`password` is required when creating a new user")
 	}
+
+	if (diff.Id() == "" || diff.HasChange("user_principal_name")) && diff.NewValueKnown("user_principal_name") && !diff.Get("skip_upn_domain_validation").(bool) {
+		upn := diff.Get("user_principal_name").(string)
+
+		// Warnings cannot be reported at plan time, so they are reported when the user is created or updated
+		if _, err := userValidateUpnDomain(ctx, meta.(*clients.Client), upn); err != nil {
+			return err
+		}
+	}
+
+	// An unconfigured mail nickname defaults to part of the UPN, in which case it can only be checked at apply time
+	if diff.Get("prevent_duplicate_names").(bool) {
+		displayName, mailNickname := "", ""
+		if (diff.Id() == "" || diff.HasChange("display_name")) && diff.NewValueKnown("display_name") {
+			displayName = diff.Get("display_name").(string)
+		}
+		if (diff.Id() == "" || diff.HasChange("mail_nickname")) && diff.NewValueKnown("mail_nickname") {
+			mailNickname = diff.Get("mail_nickname").(string)
+		}
+
+		client := meta.(*clients.Client).Users.UsersClient
+		attr, value, existingId, err := userFindDuplicate(ctx, client, displayName, mailNickname, diff.Id())
+		if err != nil {
+			return fmt.Errorf("could not check for existing user(s) with the same %s: %+v", attr, err)
+		}
+		if existingId != "" {
+			return tf.ImportAsDuplicateError("azuread_user", existingId, value)
+		}
+	}
+
 	return nil
 }
 
@@ -226,6 +387,29 @@ func userResourceCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		mailNickName = strings.Split(upn, "@")[0]
 	}
 
+	// Domain validation at plan time is skipped when the verified domains cannot be retrieved, which is reported here
+	var warnings diag.Diagnostics
+	if !d.Get("skip_upn_domain_validation").(bool) {
+		warning, err := userValidateUpnDomain(ctx, meta.(*clients.Client), upn)
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "user_principal_name", "Validating user principal name")
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+
+	// Perform this check at apply time to catch any duplicate names created during the same apply
+	if d.Get("prevent_duplicate_names").(bool) {
+		attr, value, existingId, err := userFindDuplicate(ctx, client, d.Get("display_name").(string), mailNickName, "")
+		if err != nil {
+			return tf.ErrorDiagPathF(err, attr, "Could not check for existing user(s)")
+		}
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_user", existingId, value)
+		}
+	}
+
 	properties := msgraph.User{
 		AccountEnabled:    utils.Bool(d.Get("account_enabled").(bool)),
 		City:              utils.NullableString(d.Get("city").(string)),
@@ -255,8 +439,36 @@ func userResourceCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		properties.OnPremisesImmutableId = utils.String(v.(string))
 	}
 
-	user, _, err := client.Create(ctx, properties)
+	if v, ok := d.GetOk("business_phones"); ok {
+		properties.BusinessPhones = tf.ExpandStringSlicePtr(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("employee_id"); ok {
+		properties.EmployeeId = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("employee_type"); ok {
+		properties.EmployeeType = utils.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("fax_number"); ok {
+		properties.FaxNumber = utils.String(v.(string))
+	}
+
+	var user *msgraph.User
+	var status int
+	var err error
+	if v, ok := d.GetOk("identities"); ok {
+		// Identities must be specified at creation time for local accounts, so these users are created individually
+		identities := expandUserIdentities(v.(*schema.Set).List())
+		user, status, err = meta.(*clients.Client).Users.UserIdentitiesClient.Create(ctx, userWithIdentities(properties, identities))
+	} else if batcher := meta.(*clients.Client).Users.UserCreateBatcher; batcher != nil {
+		user, status, err = batcher.Create(ctx, properties)
+	} else {
+		user, status, err = client.Create(ctx, properties)
+	}
 	if err != nil {
+		err = helpers.PermissionsError(err, status, helpers.PermissionsOperationUserCreate, meta.(*clients.Client).Claims)
 		return tf.ErrorDiagF(err, "Creating user %q", upn)
 	}
 
@@ -266,12 +478,58 @@ func userResourceCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	d.SetId(*user.ID)
 
-	return userResourceRead(ctx, d, meta)
+	// Profile properties not modelled by msgraph.User are set once the user exists, since users can be created in
+	// several ways which each only support msgraph.User
+	ageGroup, consentProvidedForMinor := d.Get("age_group").(string), d.Get("consent_provided_for_minor").(string)
+	costCenter, division := d.Get("cost_center").(string), d.Get("division").(string)
+	if ageGroup != "" || consentProvidedForMinor != "" || costCenter != "" || division != "" {
+		profile := userWithProfile{
+			User:                    msgraph.User{ID: user.ID},
+			AgeGroup:                utils.NullableString(ageGroup),
+			ConsentProvidedForMinor: utils.NullableString(consentProvidedForMinor),
+			EmployeeOrgData:         expandUserEmployeeOrgData(costCenter, division),
+		}
+		if _, err := userUpdate(ctx, client, profile); err != nil {
+			return tf.ErrorDiagF(err, "Could not set profile properties for user with ID: %q", d.Id())
+		}
+	}
+
+	// The manager is a relationship rather than a property, so can only be assigned once the user exists
+	if v, ok := d.GetOk("manager_id"); ok {
+		if diags := userUpdateManager(ctx, meta.(*clients.Client).Users.UserManagerClient, d.Id(), v.(string)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(warnings, userResourceRead(ctx, d, meta)...)
 }
 
 func userResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).Users.UsersClient
 
+	// Domain validation at plan time is skipped when the verified domains cannot be retrieved, which is reported here
+	var warnings diag.Diagnostics
+	if d.HasChange("user_principal_name") && !d.Get("skip_upn_domain_validation").(bool) {
+		warning, err := userValidateUpnDomain(ctx, meta.(*clients.Client), d.Get("user_principal_name").(string))
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "user_principal_name", "Validating user principal name")
+		}
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	}
+
+	// Perform this check at apply time to catch any duplicate names created during the same apply
+	if d.Get("prevent_duplicate_names").(bool) {
+		attr, value, existingId, err := userFindDuplicate(ctx, client, d.Get("display_name").(string), d.Get("mail_nickname").(string), d.Id())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, attr, "Could not check for existing user(s)")
+		}
+		if existingId != "" {
+			return tf.ImportAsDuplicateDiag("azuread_user", existingId, value)
+		}
+	}
+
 	properties := msgraph.User{
 		ID:             utils.String(d.Id()),
 		AccountEnabled: utils.Bool(d.Get("account_enabled").(bool)),
@@ -303,11 +561,45 @@ func userResourceUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		properties.OnPremisesImmutableId = utils.String(d.Get("onpremises_immutable_id").(string))
 	}
 
-	if _, err := client.Update(ctx, properties); err != nil {
+	if d.HasChange("business_phones") {
+		properties.BusinessPhones = tf.ExpandStringSlicePtr(d.Get("business_phones").([]interface{}))
+	}
+
+	user := userWithProfile{
+		User:                    properties,
+		AgeGroup:                utils.NullableString(d.Get("age_group").(string)),
+		ConsentProvidedForMinor: utils.NullableString(d.Get("consent_provided_for_minor").(string)),
+		EmployeeId:              utils.NullableString(d.Get("employee_id").(string)),
+		EmployeeOrgData:         expandUserEmployeeOrgData(d.Get("cost_center").(string), d.Get("division").(string)),
+		EmployeeType:            utils.NullableString(d.Get("employee_type").(string)),
+		FaxNumber:               utils.NullableString(d.Get("fax_number").(string)),
+	}
+
+	if _, err := userUpdate(ctx, client, user); err != nil {
 		return tf.ErrorDiagF(err, "Could not update user with ID: %q", d.Id())
 	}
 
-	return userResourceRead(ctx, d, meta)
+	if d.HasChange("identities") {
+		identitiesClient := meta.(*clients.Client).Users.UserIdentitiesClient
+
+		// The identities collection is replaced in its entirety, so retain the identity for the user principal name
+		existing, _, err := identitiesClient.Get(ctx, d.Id())
+		if err != nil {
+			return tf.ErrorDiagPathF(err, "identities", "Could not retrieve identities for user with ID: %q", d.Id())
+		}
+		identities := userIdentitiesRetainingUserPrincipalName(existing, expandUserIdentities(d.Get("identities").(*schema.Set).List()))
+		if _, err := identitiesClient.Update(ctx, d.Id(), identities); err != nil {
+			return tf.ErrorDiagPathF(err, "identities", "Could not update identities for user with ID: %q", d.Id())
+		}
+	}
+
+	if d.HasChange("manager_id") {
+		if diags := userUpdateManager(ctx, meta.(*clients.Client).Users.UserManagerClient, d.Id(), d.Get("manager_id").(string)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(warnings, userResourceRead(ctx, d, meta)...)
 }
 
 func userResourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -315,7 +607,7 @@ func userResourceRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	objectId := d.Id()
 
-	user, status, err := client.Get(ctx, objectId)
+	user, status, err := userGetForResource(ctx, client, objectId)
 	if err != nil {
 		if status == http.StatusNotFound {
 			log.Printf("[DEBUG] User with Object ID %q was not found - removing from state!", objectId)
@@ -325,12 +617,22 @@ func userResourceRead(ctx context.Context, d *schema.ResourceData, meta interfac
 		return tf.ErrorDiagF(err, "Retrieving user with object ID: %q", objectId)
 	}
 
+	costCenter, division := flattenUserEmployeeOrgData(user.EmployeeOrgData)
+
 	tf.Set(d, "account_enabled", user.AccountEnabled)
+	tf.Set(d, "age_group", user.AgeGroup)
+	tf.Set(d, "business_phones", tf.FlattenStringSlicePtr(user.BusinessPhones))
 	tf.Set(d, "city", user.City)
 	tf.Set(d, "company_name", user.CompanyName)
+	tf.Set(d, "consent_provided_for_minor", user.ConsentProvidedForMinor)
+	tf.Set(d, "cost_center", costCenter)
 	tf.Set(d, "country", user.Country)
 	tf.Set(d, "department", user.Department)
 	tf.Set(d, "display_name", user.DisplayName)
+	tf.Set(d, "division", division)
+	tf.Set(d, "employee_id", user.EmployeeId)
+	tf.Set(d, "employee_type", user.EmployeeType)
+	tf.Set(d, "fax_number", user.FaxNumber)
 	tf.Set(d, "given_name", user.GivenName)
 	tf.Set(d, "job_title", user.JobTitle)
 	tf.Set(d, "mail", user.Mail)
@@ -340,8 +642,10 @@ func userResourceRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	tf.Set(d, "office_location", user.OfficeLocation)
 	tf.Set(d, "onpremises_immutable_id", user.OnPremisesImmutableId)
 	tf.Set(d, "onpremises_sam_account_name", user.OnPremisesSamAccountName)
+	tf.Set(d, "onpremises_security_identifier", user.OnPremisesSecurityIdentifier)
 	tf.Set(d, "onpremises_user_principal_name", user.OnPremisesUserPrincipalName)
 	tf.Set(d, "postal_code", user.PostalCode)
+	tf.Set(d, "security_identifier", user.SecurityIdentifier)
 	tf.Set(d, "state", user.State)
 	tf.Set(d, "street_address", user.StreetAddress)
 	tf.Set(d, "surname", user.Surname)
@@ -349,24 +653,86 @@ func userResourceRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	tf.Set(d, "user_principal_name", user.UserPrincipalName)
 	tf.Set(d, "user_type", user.UserType)
 
+	identities, _, err := meta.(*clients.Client).Users.UserIdentitiesClient.Get(ctx, objectId)
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "identities", "Could not retrieve identities for user with object ID: %q", objectId)
+	}
+	tf.Set(d, "identities", flattenUserIdentities(identities, false))
+
+	managerId, status, err := meta.(*clients.Client).Users.UserManagerClient.Get(ctx, objectId)
+	if err != nil && status != http.StatusNotFound {
+		return tf.ErrorDiagPathF(err, "manager_id", "Could not retrieve manager for user with object ID: %q", objectId)
+	}
+	tf.Set(d, "manager_id", managerId)
+
+	skipUpnDomainValidation := false
+	if v := d.Get("skip_upn_domain_validation").(bool); v {
+		skipUpnDomainValidation = v
+	}
+	tf.Set(d, "skip_upn_domain_validation", skipUpnDomainValidation)
+	tf.Set(d, "prevent_duplicate_names", d.Get("prevent_duplicate_names").(bool))
+	tf.Set(d, "block_sign_in_on_destroy", d.Get("block_sign_in_on_destroy").(bool))
+
 	return nil
 }
 
+func userResourceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*clients.Client).Users.UsersClient
+
+	if err := helpers.ValidateDirectoryObjectType(ctx, client.BaseClient, d.Id(), helpers.DirectoryObjectTypeUser); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func userResourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client).Users.UsersClient
 
+	deletion := helpers.ObjectDeletion{
+		ObjectType: "user",
+		ObjectId:   d.Id(),
+		Strict:     meta.(*clients.Client).StrictDelete,
+		Get: func(ctx context.Context) (int, error) {
+			client := *client
+			client.BaseClient.DisableRetries = true
+			_, status, err := client.Get(ctx, d.Id())
+			return status, err
+		},
+	}
+
 	_, status, err := client.Get(ctx, d.Id())
-	if err != nil {
-		if status == http.StatusNotFound {
-			return tf.ErrorDiagPathF(fmt.Errorf("User was not found"), "id", "Retrieving user with object ID %q", d.Id())
-		}
+	if gone, diags := deletion.CheckExists(status, err); gone || diags.HasError() {
+		return diags
+	}
 
-		return tf.ErrorDiagPathF(err, "id", "Retrieving user with object ID %q", d.Id())
+	if d.Get("block_sign_in_on_destroy").(bool) {
+		return userBlockSignIn(ctx, client, d.Id())
 	}
 
 	status, err = client.Delete(ctx, d.Id())
-	if err != nil {
-		return tf.ErrorDiagPathF(err, "id", "Deleting user with object ID %q, got status %d", d.Id(), status)
+	return deletion.CheckDeleted(ctx, status, err)
+}
+
+// userBlockSignIn disables a user account and revokes its sign-in sessions, in place of deleting the user. The account
+// is disabled first, so failing to revoke sessions afterwards is only reported as a warning.
+func userBlockSignIn(ctx context.Context, client *msgraph.UsersClient, id string) diag.Diagnostics {
+	user := msgraph.User{
+		ID:             utils.String(id),
+		AccountEnabled: utils.Bool(false),
+	}
+	if _, err := client.Update(ctx, user); err != nil {
+		return tf.ErrorDiagF(err, "Disabling user with object ID %q", id)
+	}
+
+	log.Printf("[DEBUG] Disabled user with object ID %q instead of deleting it, since `block_sign_in_on_destroy` is set", id)
+
+	if _, err := userRevokeSignInSessions(ctx, client, id); err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not revoke sign-in sessions for user with object ID %q", id),
+			Detail:   fmt.Sprintf("The user was disabled and removed from state, but existing sign-in sessions may remain valid until they expire: %v", err),
+		}}
 	}
 
 	return nil
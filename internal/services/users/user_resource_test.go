@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -26,6 +28,8 @@ func TestAccUser_basic(t *testing.T) {
 			Config: r.basic(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("onpremises_security_identifier").IsEmpty(),
+				check.That(data.ResourceName).Key("security_identifier").MatchesRegex(regexp.MustCompile(`^S-1-12-1-`)),
 			),
 		},
 		data.ImportStep("force_password_change", "password"),
@@ -41,6 +45,14 @@ func TestAccUser_complete(t *testing.T) {
 			Config: r.complete(data),
 			Check: resource.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("age_group").HasValue("NotAdult"),
+				check.That(data.ResourceName).Key("business_phones.#").HasValue("1"),
+				check.That(data.ResourceName).Key("consent_provided_for_minor").HasValue("Granted"),
+				check.That(data.ResourceName).Key("cost_center").HasValue(fmt.Sprintf("acctestUser-%d-CostCenter", data.RandomInteger)),
+				check.That(data.ResourceName).Key("division").HasValue(fmt.Sprintf("acctestUser-%d-Division", data.RandomInteger)),
+				check.That(data.ResourceName).Key("employee_id").HasValue(fmt.Sprintf("E-%s", data.RandomString)),
+				check.That(data.ResourceName).Key("employee_type").HasValue("Contractor"),
+				check.That(data.ResourceName).Key("fax_number").HasValue("(555) 555-5560"),
 			),
 		},
 		data.ImportStep("force_password_change", "password"),
@@ -97,6 +109,199 @@ func TestAccUser_threeUsersABC(t *testing.T) {
 	})
 }
 
+func TestAccUser_unverifiedDomain(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config:      r.unverifiedDomain(data),
+			ExpectError: regexp.MustCompile("is not a verified domain in this tenant"),
+		},
+	})
+}
+
+// TestAccUser_batchedCreation creates many users with and without batching, logging the time taken for each so that
+// the wall-clock improvement from batching can be compared.
+func TestAccUser_batchedCreation(t *testing.T) {
+	for _, batched := range []bool{false, true} {
+		batched := batched
+		t.Run(fmt.Sprintf("batched=%t", batched), func(t *testing.T) {
+			data := acceptance.BuildTestData(t, "azuread_user", "test")
+			r := UserResource{}
+
+			var start time.Time
+			data.ResourceTest(t, r, []resource.TestStep{
+				{
+					PreConfig: func() {
+						start = time.Now()
+					},
+					Config: r.manyUsers(data, batched),
+					Check: resource.ComposeTestCheckFunc(
+						check.That(data.ResourceName+".0").ExistsInAzure(r),
+						check.That(data.ResourceName+".39").ExistsInAzure(r),
+						func(_ *terraform.State) error {
+							t.Logf("Created 40 users with batch_user_creation = %t in %s", batched, time.Since(start))
+							return nil
+						},
+					),
+				},
+			})
+		})
+	}
+}
+
+func TestAccUser_importWrongObjectType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.importWrongObjectType(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			ResourceName: data.ResourceName,
+			ImportState:  true,
+			ImportStateIdFunc: func(s *terraform.State) (string, error) {
+				other, ok := s.RootModule().Resources["azuread_group.other"]
+				if !ok {
+					return "", fmt.Errorf("azuread_group.other not found in state")
+				}
+				return other.Primary.ID, nil
+			},
+			ExpectError: regexp.MustCompile("is a Group, not a User"),
+		},
+	})
+}
+
+func TestAccUser_identities(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.identities(data, "acctestUser.%[1]d@example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identities.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("force_password_change", "password"),
+		{
+			Config: r.identities(data, "acctestUser.%[1]d.updated@example.com"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("identities.#").HasValue("1"),
+			),
+		},
+		data.ImportStep("force_password_change", "password"),
+	})
+}
+
+func TestAccUser_preventDuplicateNamesPass(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.preventDuplicateNamesPass(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("display_name").HasValue(fmt.Sprintf("acctestUser-%d", data.RandomInteger)),
+			),
+		},
+		data.ImportStep("force_password_change", "password", "prevent_duplicate_names"),
+	})
+}
+
+func TestAccUser_preventDuplicateNamesFail(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		data.RequiresImportErrorStep(r.preventDuplicateNamesFail(data)),
+	})
+}
+
+func TestAccUser_preventDuplicateMailNicknameFail(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		data.RequiresImportErrorStep(r.preventDuplicateMailNicknameFail(data)),
+	})
+}
+
+func TestAccUser_blockSignInOnDestroy(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+	checker := &userBlockSignInChecker{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.blockSignInOnDestroy(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("account_enabled").HasValue("true"),
+				checker.captureObjectId(data.ResourceName),
+			),
+		},
+		data.ImportStep("block_sign_in_on_destroy", "force_password_change", "password"),
+		{
+			// Removing the resource should disable the user rather than deleting it
+			Config: r.domains(),
+			Check:  checker.checkDisabled,
+		},
+	})
+}
+
+func TestAccUser_manager(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.manager(data, true),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manager_id").MatchesOtherKey(check.That("azuread_user.manager").Key("object_id")),
+			),
+		},
+		data.ImportStep("force_password_change", "password"),
+		{
+			// Removing the manager from configuration should clear it in Azure AD
+			Config: r.manager(data, false),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("manager_id").IsEmpty(),
+			),
+		},
+		data.ImportStep("force_password_change", "password"),
+	})
+}
+
+func TestAccUser_managerChain(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azuread_user", "test")
+	r := UserResource{}
+
+	// All three users are created in a single apply, so each manager assignment races replication of the manager
+	data.ResourceTest(t, r, []resource.TestStep{
+		{
+			Config: r.managerChain(data),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azuread_user.director").Key("manager_id").IsEmpty(),
+				check.That("azuread_user.manager").Key("manager_id").MatchesOtherKey(check.That("azuread_user.director").Key("object_id")),
+				check.That(data.ResourceName).Key("manager_id").MatchesOtherKey(check.That("azuread_user.manager").Key("object_id")),
+			),
+		},
+		data.ImportStep("force_password_change", "password"),
+	})
+}
+
 func (r UserResource) Exists(ctx context.Context, clients *clients.Client, state *terraform.InstanceState) (*bool, error) {
 	client := clients.Users.UsersClient
 	client.BaseClient.DisableRetries = true
@@ -111,6 +316,16 @@ func (r UserResource) Exists(ctx context.Context, clients *clients.Client, state
 	return utils.Bool(user.ID != nil && *user.ID == state.ID), nil
 }
 
+func (UserResource) domains() string {
+	return `
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+`
+}
+
 func (UserResource) basic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azuread" {}
@@ -157,10 +372,19 @@ resource "azuread_user" "test" {
   country         = "acctestUser-%[1]d-Country"
   postal_code     = "111111"
   mobile_phone    = "(555) 555-5555"
+  fax_number      = "(555) 555-5560"
+  business_phones = ["(555) 555-5556"]
+
+  age_group                  = "NotAdult"
+  consent_provided_for_minor = "Granted"
+  cost_center                = "acctestUser-%[1]d-CostCenter"
+  division                   = "acctestUser-%[1]d-Division"
+  employee_id                = "E-%[3]s"
+  employee_type              = "Contractor"
 
   onpremises_immutable_id = "%[1]d"
 }
-`, data.RandomInteger, data.RandomPassword)
+`, data.RandomInteger, data.RandomPassword, data.RandomString)
 }
 
 func (UserResource) threeUsersABC(data acceptance.TestData) string {
@@ -191,3 +415,215 @@ resource "azuread_user" "testC" {
 }
 `, data.RandomInteger, data.RandomPassword)
 }
+
+func (UserResource) unverifiedDomain(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@acctest-%[1]d.invalid"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (UserResource) manyUsers(data acceptance.TestData, batched bool) string {
+	return fmt.Sprintf(`
+provider "azuread" {
+  batch_user_creation = %[3]t
+}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  count = 40
+
+  user_principal_name = "acctestUser.%[1]d.${count.index}@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-${count.index}"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword, batched)
+}
+
+func (r UserResource) importWrongObjectType(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_group" "other" {
+  display_name     = "acctestUser-%[2]d"
+  security_enabled = true
+}
+`, r.basic(data), data.RandomInteger)
+}
+
+func (r UserResource) manager(data acceptance.TestData, withManager bool) string {
+	managerId := "null"
+	if withManager {
+		managerId = "azuread_user.manager.object_id"
+	}
+
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "manager" {
+  user_principal_name = "acctestManager.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[2]d"
+  password            = "%[3]s"
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[2]d"
+  password            = "%[3]s"
+  manager_id          = %[4]s
+}
+`, r.domains(), data.RandomInteger, data.RandomPassword, managerId)
+}
+
+func (r UserResource) managerChain(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "director" {
+  user_principal_name = "acctestDirector.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestDirector-%[2]d"
+  password            = "%[3]s"
+}
+
+resource "azuread_user" "manager" {
+  user_principal_name = "acctestManager.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestManager-%[2]d"
+  password            = "%[3]s"
+  manager_id          = azuread_user.director.object_id
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[2]d"
+  password            = "%[3]s"
+  manager_id          = azuread_user.manager.object_id
+}
+`, r.domains(), data.RandomInteger, data.RandomPassword)
+}
+
+func (UserResource) identities(data acceptance.TestData, emailAddressFormat string) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d"
+  password            = "%[2]s"
+
+  identities {
+    sign_in_type       = "emailAddress"
+    issuer             = data.azuread_domains.test.domains.0.domain_name
+    issuer_assigned_id = "%[3]s"
+  }
+}
+`, data.RandomInteger, data.RandomPassword, fmt.Sprintf(emailAddressFormat, data.RandomInteger))
+}
+
+func (UserResource) preventDuplicateNamesPass(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "test" {
+  user_principal_name     = "acctestUser.%[1]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name            = "acctestUser-%[1]d"
+  password                = "%[2]s"
+  prevent_duplicate_names = true
+}
+`, data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserResource) preventDuplicateNamesFail(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "duplicate" {
+  user_principal_name     = "acctestUser.%[2]d.dup@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name            = azuread_user.test.display_name
+  password                = "%[3]s"
+  prevent_duplicate_names = true
+}
+`, r.basic(data), data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserResource) preventDuplicateMailNicknameFail(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "duplicate" {
+  user_principal_name     = "acctestUser.%[2]d.dup@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name            = "acctestUser-%[2]d-dup"
+  mail_nickname           = azuread_user.test.mail_nickname
+  password                = "%[3]s"
+  prevent_duplicate_names = true
+}
+`, r.basic(data), data.RandomInteger, data.RandomPassword)
+}
+
+func (r UserResource) blockSignInOnDestroy(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azuread_user" "test" {
+  user_principal_name      = "acctestUser.%[2]d@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name             = "acctestUser-%[2]d"
+  password                 = "%[3]s"
+  block_sign_in_on_destroy = true
+}
+`, r.domains(), data.RandomInteger, data.RandomPassword)
+}
+
+// userBlockSignInChecker verifies that a user still exists and is disabled after being destroyed with
+// block_sign_in_on_destroy set, and then deletes the user since it is no longer managed by Terraform
+type userBlockSignInChecker struct {
+	objectId string
+}
+
+func (c *userBlockSignInChecker) captureObjectId(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+		c.objectId = rs.Primary.ID
+		return nil
+	}
+}
+
+func (c *userBlockSignInChecker) checkDisabled(_ *terraform.State) error {
+	client := acceptance.AzureADProvider.Meta().(*clients.Client).Users.UsersClient
+	ctx := context.Background()
+
+	user, status, err := client.Get(ctx, c.objectId)
+	if err != nil {
+		if status == http.StatusNotFound {
+			return fmt.Errorf("user with object ID %q was deleted, expected it to be disabled", c.objectId)
+		}
+		return fmt.Errorf("retrieving user with object ID %q: %+v", c.objectId, err)
+	}
+
+	if _, err := client.Delete(ctx, c.objectId); err != nil {
+		return fmt.Errorf("cleaning up user with object ID %q: %+v", c.objectId, err)
+	}
+
+	if user.AccountEnabled == nil || *user.AccountEnabled {
+		return fmt.Errorf("expected user with object ID %q to be disabled", c.objectId)
+	}
+
+	return nil
+}
@@ -0,0 +1,501 @@
+package users
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/common"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+// userIdentitySignInTypeUserPrincipalName is the sign-in type of the identity which is automatically maintained by
+// Azure AD for the user principal name of a user
+const userIdentitySignInTypeUserPrincipalName = "userPrincipalName"
+
+// userManagerAssignTimeout bounds the retries when assigning a manager which is not yet available, for contexts
+// without a deadline
+const userManagerAssignTimeout = 5 * time.Minute
+
+// userResourceSelectProperties maps the attributes of the azuread_user resource which are read from the user object to
+// their Graph properties, and determines which properties are selected when reading a user. Any new attribute which is
+// read from the user object must be added here, otherwise it will not be returned by the API.
+var userResourceSelectProperties = map[string]string{
+	"account_enabled":                "accountEnabled",
+	"age_group":                      "ageGroup",
+	"business_phones":                "businessPhones",
+	"city":                           "city",
+	"company_name":                   "companyName",
+	"consent_provided_for_minor":     "consentProvidedForMinor",
+	"cost_center":                    "employeeOrgData",
+	"country":                        "country",
+	"department":                     "department",
+	"display_name":                   "displayName",
+	"division":                       "employeeOrgData",
+	"employee_id":                    "employeeId",
+	"employee_type":                  "employeeType",
+	"fax_number":                     "faxNumber",
+	"given_name":                     "givenName",
+	"job_title":                      "jobTitle",
+	"mail":                           "mail",
+	"mail_nickname":                  "mailNickname",
+	"mobile_phone":                   "mobilePhone",
+	"object_id":                      "id",
+	"office_location":                "officeLocation",
+	"onpremises_immutable_id":        "onPremisesImmutableId",
+	"onpremises_sam_account_name":    "onPremisesSamAccountName",
+	"onpremises_security_identifier": "onPremisesSecurityIdentifier",
+	"onpremises_user_principal_name": "onPremisesUserPrincipalName",
+	"postal_code":                    "postalCode",
+	"security_identifier":            "securityIdentifier",
+	"state":                          "state",
+	"street_address":                 "streetAddress",
+	"surname":                        "surname",
+	"usage_location":                 "usageLocation",
+	"user_principal_name":            "userPrincipalName",
+	"user_type":                      "userType",
+}
+
+// userAgeGroups are the possible values for the age group of a user
+var userAgeGroups = []string{"Adult", "Minor", "NotAdult"}
+
+// userConsentProvidedForMinorValues are the possible values for whether consent has been obtained for a minor
+var userConsentProvidedForMinorValues = []string{"Denied", "Granted", "NotRequired"}
+
+// userEmployeeOrgData describes the organization that a user works for
+type userEmployeeOrgData struct {
+	CostCenter *msgraph.StringNullWhenEmpty `json:"costCenter,omitempty"`
+	Division   *msgraph.StringNullWhenEmpty `json:"division,omitempty"`
+}
+
+// userWithProfile is a User which additionally includes the profile properties not modelled by msgraph.User. The
+// employeeId, employeeType and faxNumber properties are redeclared here so that they can be cleared, since fields at a
+// shallower depth take precedence over those of the embedded msgraph.User when marshaling.
+type userWithProfile struct {
+	msgraph.User
+	AgeGroup                *msgraph.StringNullWhenEmpty `json:"ageGroup,omitempty"`
+	ConsentProvidedForMinor *msgraph.StringNullWhenEmpty `json:"consentProvidedForMinor,omitempty"`
+	EmployeeId              *msgraph.StringNullWhenEmpty `json:"employeeId,omitempty"`
+	EmployeeOrgData         *userEmployeeOrgData         `json:"employeeOrgData,omitempty"`
+	EmployeeType            *msgraph.StringNullWhenEmpty `json:"employeeType,omitempty"`
+	FaxNumber               *msgraph.StringNullWhenEmpty `json:"faxNumber,omitempty"`
+
+	// SecurityIdentifier is read-only, and is never set when updating a user
+	SecurityIdentifier *string `json:"securityIdentifier,omitempty"`
+}
+
+// userGetForResource retrieves a user, selecting only the properties which are read by the azuread_user resource
+func userGetForResource(ctx context.Context, client *msgraph.UsersClient, id string) (*userWithProfile, int, error) {
+	selected := make(map[string]bool, len(userResourceSelectProperties))
+	properties := make([]string, 0, len(userResourceSelectProperties))
+	for _, property := range userResourceSelectProperties {
+		if !selected[property] {
+			selected[property] = true
+			properties = append(properties, property)
+		}
+	}
+	sort.Strings(properties)
+
+	var user userWithProfile
+	status, err := common.GetSelected(ctx, client.BaseClient, fmt.Sprintf("/users/%s", id), properties, &user)
+	if err != nil {
+		return nil, status, fmt.Errorf("UsersClient.%v", err)
+	}
+
+	return &user, status, nil
+}
+
+// userRevokeSignInSessions invalidates the refresh tokens and session cookies issued to a user, so that they must sign in
+// again. This is not supported by msgraph.UsersClient.
+func userRevokeSignInSessions(ctx context.Context, client *msgraph.UsersClient, id string) (int, error) {
+	_, status, _, err := client.BaseClient.Post(ctx, msgraph.PostHttpRequestInput{
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusOK, http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s/revokeSignInSessions", id),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		// A successful response has a boolean `value`, which the client fails to decode as an OData collection before
+		// the status is checked, so this particular error indicates success
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Value == "bool" {
+			return http.StatusOK, nil
+		}
+		return status, fmt.Errorf("UsersClient.BaseClient.Post(): %v", err)
+	}
+	return status, nil
+}
+
+// userUpdate updates a user, including any profile properties not modelled by msgraph.User. This is not supported by
+// msgraph.UsersClient.
+func userUpdate(ctx context.Context, client *msgraph.UsersClient, user userWithProfile) (int, error) {
+	body, err := json.Marshal(user)
+	if err != nil {
+		return 0, fmt.Errorf("json.Marshal(): %v", err)
+	}
+	_, status, _, err := client.BaseClient.Patch(ctx, msgraph.PatchHttpRequestInput{
+		Body:                   body,
+		ConsistencyFailureFunc: msgraph.RetryOn404ConsistencyFailureFunc,
+		ValidStatusCodes:       []int{http.StatusNoContent},
+		Uri: msgraph.Uri{
+			Entity:      fmt.Sprintf("/users/%s", *user.ID),
+			HasTenantId: true,
+		},
+	})
+	if err != nil {
+		return status, fmt.Errorf("UsersClient.BaseClient.Patch(): %v", err)
+	}
+	return status, nil
+}
+
+// userPhotoMaxBytes is the maximum size of profile photo retrieved by the azuread_user data source. Photos are stored
+// base64-encoded in state, so larger photos are skipped.
+const userPhotoMaxBytes = 1 << 20
+
+// userPhoto holds the profile photo of a user, with the content base64-encoded
+type userPhoto struct {
+	Content     string
+	ContentType string
+	Height      int
+	Width       int
+}
+
+// userGetPhoto retrieves the profile photo for a user. An empty result is returned when the user has no photo, and a
+// warning is returned when the photo is larger than userPhotoMaxBytes, in which case the content is omitted.
+func userGetPhoto(ctx context.Context, photoClient *client.UserPhotoClient, id string) (userPhoto, diag.Diagnostics) {
+	var result userPhoto
+
+	metadata, status, err := photoClient.GetMetadata(ctx, id)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] No profile photo found for user with object ID %q", id)
+			return result, nil
+		}
+		return result, tf.ErrorDiagPathF(err, "photo", "Retrieving profile photo metadata for user with object ID %q", id)
+	}
+	if metadata.Height != nil {
+		result.Height = *metadata.Height
+	}
+	if metadata.Width != nil {
+		result.Width = *metadata.Width
+	}
+
+	content, status, err := photoClient.GetContent(ctx, id, userPhotoMaxBytes)
+	if err != nil {
+		if status == http.StatusNotFound {
+			log.Printf("[DEBUG] No profile photo content found for user with object ID %q", id)
+			return userPhoto{}, nil
+		}
+		return result, tf.ErrorDiagPathF(err, "photo", "Retrieving profile photo for user with object ID %q", id)
+	}
+	result.ContentType = content.ContentType
+
+	if content.ExceedsMaxSize {
+		return result, diag.Diagnostics{diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Profile photo too large",
+			Detail:        fmt.Sprintf("The profile photo for user with object ID %q is larger than %d bytes and has not been retrieved", id, userPhotoMaxBytes),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "photo"}},
+		}}
+	}
+	result.Content = base64.StdEncoding.EncodeToString(content.Content)
+
+	return result, nil
+}
+
+// userValidateUpnDomain checks that the domain part of the provided user principal name matches one of the verified
+// domains in the tenant. When the verified domains cannot be retrieved, validation is skipped and a warning is returned
+// instead of an error.
+func userValidateUpnDomain(ctx context.Context, client *clients.Client, upn string) (*diag.Diagnostic, error) {
+	i := strings.LastIndex(upn, "@")
+	if i < 0 {
+		// Format is validated separately
+		return nil, nil
+	}
+	upnDomain := upn[i+1:]
+
+	domains, err := client.VerifiedDomains(ctx)
+	if err != nil {
+		log.Printf("[WARN] Could not retrieve verified domains, skipping domain validation for user principal name %q: %v", upn, err)
+		return &diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       "Could not validate the domain of the user principal name",
+			Detail:        fmt.Sprintf("The verified domains in the tenant could not be retrieved, so the domain %q in `user_principal_name` was not validated: %v", upnDomain, err),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "user_principal_name"}},
+		}, nil
+	}
+
+	for _, domain := range domains {
+		if strings.EqualFold(upnDomain, domain) {
+			return nil, nil
+		}
+	}
+
+	sorted := make([]string, len(domains))
+	copy(sorted, domains)
+	sort.Strings(sorted)
+
+	return nil, fmt.Errorf("the domain %q in `user_principal_name` (%q) is not a verified domain in this tenant. Valid domains are: %s. Set `skip_upn_domain_validation = true` if the domain is being verified in the same apply", upnDomain, upn, strings.Join(sorted, ", "))
+}
+
+// userFindDuplicate returns the ID of an existing user, other than the user with currentId, having the same display
+// name or mail nickname, along with the attribute and value which matched. An empty mailNickname is not checked. An
+// empty ID is returned when there is no duplicate.
+func userFindDuplicate(ctx context.Context, client *msgraph.UsersClient, displayName, mailNickname, currentId string) (attr, value, existingId string, err error) {
+	checks := []struct {
+		attr, property, value string
+	}{
+		{attr: "display_name", property: "displayName", value: displayName},
+		{attr: "mail_nickname", property: "mailNickname", value: mailNickname},
+	}
+
+	for _, c := range checks {
+		if c.value == "" {
+			continue
+		}
+		existingId, err = helpers.DuplicateNameFind(ctx, userDuplicateNameList(client, c.property), c.property, c.value, currentId)
+		if err != nil || existingId != "" {
+			return c.attr, c.value, existingId, err
+		}
+	}
+
+	return "", "", "", nil
+}
+
+// userDuplicateNameList returns a function which lists users for a duplicate name check on the specified property,
+// which must be either `displayName` or `mailNickname`
+func userDuplicateNameList(client *msgraph.UsersClient, property string) helpers.DuplicateNameListFunc {
+	return func(ctx context.Context, filter string) ([]helpers.DuplicateNameObject, error) {
+		users, _, err := client.List(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list Users with filter %q: %+v", filter, err)
+		}
+		result := make([]helpers.DuplicateNameObject, 0)
+		if users != nil {
+			for _, user := range *users {
+				name := user.DisplayName
+				if property == "mailNickname" {
+					name = user.MailNickname
+				}
+				result = append(result, helpers.DuplicateNameObject{ID: user.ID, Name: name})
+			}
+		}
+		return result, nil
+	}
+}
+
+func userWithIdentities(user msgraph.User, identities []client.ObjectIdentity) client.UserWithIdentities {
+	return client.UserWithIdentities{
+		User:       user,
+		Identities: &identities,
+	}
+}
+
+// userIdentitiesRetainingUserPrincipalName returns the desired identities for a user along with any existing identity
+// for the user principal name, which must be retained when updating the identities collection.
+func userIdentitiesRetainingUserPrincipalName(existing *[]client.ObjectIdentity, desired []client.ObjectIdentity) []client.ObjectIdentity {
+	result := make([]client.ObjectIdentity, 0, len(desired)+1)
+	if existing != nil {
+		for _, identity := range *existing {
+			if identity.SignInType != nil && strings.EqualFold(*identity.SignInType, userIdentitySignInTypeUserPrincipalName) {
+				result = append(result, identity)
+			}
+		}
+	}
+	return append(result, desired...)
+}
+
+func expandUserIdentities(in []interface{}) []client.ObjectIdentity {
+	result := make([]client.ObjectIdentity, 0, len(in))
+	for _, raw := range in {
+		identity := raw.(map[string]interface{})
+		result = append(result, client.ObjectIdentity{
+			Issuer:           utils.String(identity["issuer"].(string)),
+			IssuerAssignedId: utils.String(identity["issuer_assigned_id"].(string)),
+			SignInType:       utils.String(identity["sign_in_type"].(string)),
+		})
+	}
+	return result
+}
+
+// flattenUserIdentities flattens the identities for a user. Unless includeUserPrincipalName is true, the identity which
+// is automatically maintained by Azure AD for the user principal name is omitted, since it cannot be managed directly.
+func flattenUserIdentities(in *[]client.ObjectIdentity, includeUserPrincipalName bool) []interface{} {
+	result := make([]interface{}, 0)
+	if in == nil {
+		return result
+	}
+
+	for _, identity := range *in {
+		signInType := ""
+		if identity.SignInType != nil {
+			signInType = *identity.SignInType
+		}
+		if !includeUserPrincipalName && strings.EqualFold(signInType, userIdentitySignInTypeUserPrincipalName) {
+			continue
+		}
+
+		issuer := ""
+		if identity.Issuer != nil {
+			issuer = *identity.Issuer
+		}
+
+		issuerAssignedId := ""
+		if identity.IssuerAssignedId != nil {
+			issuerAssignedId = *identity.IssuerAssignedId
+		}
+
+		result = append(result, map[string]interface{}{
+			"issuer":             issuer,
+			"issuer_assigned_id": issuerAssignedId,
+			"sign_in_type":       signInType,
+		})
+	}
+
+	return result
+}
+
+// userListPageSize is the largest page size supported when listing users
+const userListPageSize = 999
+
+// usersDataSourceSelectProperties are the properties of each user which are returned by the azuread_users data source
+var usersDataSourceSelectProperties = []string{
+	"accountEnabled",
+	"displayName",
+	"employeeId",
+	"id",
+	"mail",
+	"mailNickname",
+	"onPremisesImmutableId",
+	"onPremisesSamAccountName",
+	"onPremisesUserPrincipalName",
+	"usageLocation",
+	"userPrincipalName",
+}
+
+// userListAll returns the users matching filter, or all users when filter is empty, retrieving the largest pages
+// supported so that large tenants can be listed with relatively few requests. Only the properties used by the
+// azuread_users data source are selected. When limit is greater than zero, no further pages are retrieved once more
+// than limit users are found, and up to limit+1 users are returned so that callers can tell when there are more.
+func userListAll(ctx context.Context, client *msgraph.UsersClient, filter string, limit int) ([]msgraph.User, error) {
+	query := common.AdvancedQuery{
+		Filter: filter,
+		Select: usersDataSourceSelectProperties,
+		Top:    userListPageSize,
+		Limit:  limit,
+	}
+	if limit > 0 && limit < userListPageSize {
+		query.Top = limit + 1
+	}
+
+	users := make([]msgraph.User, 0)
+	_, err := common.AdvancedQueryList(ctx, client.BaseClient, "/users", query, &users)
+	if err == nil {
+		return users, nil
+	}
+	if !common.IsAdvancedQueryUnsupported(err) {
+		return nil, fmt.Errorf("unable to list Users with filter %q: %+v", filter, err)
+	}
+
+	// Fall back to a regular query, which is paged and limited in the same way
+	query.Regular = true
+	if _, err := common.AdvancedQueryList(ctx, client.BaseClient, "/users", query, &users); err != nil {
+		return nil, fmt.Errorf("unable to list Users with filter %q: %+v", filter, err)
+	}
+
+	return users, nil
+}
+
+// userUpdateManager assigns the manager of a user, or removes any existing manager when managerId is empty, so that
+// removing `manager_id` from the configuration also clears the manager in Azure AD
+func userUpdateManager(ctx context.Context, managerClient *client.UserManagerClient, id, managerId string) diag.Diagnostics {
+	if managerId == "" {
+		if status, err := managerClient.Remove(ctx, id); err != nil && status != http.StatusNotFound {
+			return tf.ErrorDiagPathF(err, "manager_id", "Could not remove manager for user with object ID: %q", id)
+		}
+		return nil
+	}
+
+	if err := userAssignManager(ctx, managerClient, id, managerId); err != nil {
+		return tf.ErrorDiagPathF(err, "manager_id", "Could not assign manager for user with object ID: %q", id)
+	}
+	return nil
+}
+
+// userAssignManager assigns the manager of a user, retrying with backoff for as long as the manager is not found. When
+// users and their managers are created in the same apply, a newly created manager can take some time to replicate,
+// during which the manager reference is rejected with a 404. Retries are bounded by the deadline of the context, or by
+// userManagerAssignTimeout when the context has no deadline.
+func userAssignManager(ctx context.Context, managerClient *client.UserManagerClient, id, managerId string) error {
+	timeout := userManagerAssignTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+
+	var lastErr error
+	_, err := (&resource.StateChangeConf{
+		Pending: []string{"Waiting"},
+		Target:  []string{"Assigned"},
+		Timeout: timeout,
+		Refresh: func() (interface{}, string, error) {
+			status, err := managerClient.Assign(ctx, id, managerId)
+			if err != nil {
+				if status == http.StatusNotFound {
+					log.Printf("[DEBUG] Manager with object ID %q not yet found when assigning to user with object ID %q - retrying", managerId, id)
+					lastErr = err
+					return status, "Waiting", nil
+				}
+				lastErr = nil
+				return nil, "Error", helpers.GraphError(err)
+			}
+			return status, "Assigned", nil
+		},
+	}).WaitForStateContext(ctx)
+	if err != nil {
+		// lastErr is only retained when giving up whilst the manager is still not found
+		if lastErr != nil {
+			return fmt.Errorf("waiting for manager with object ID %q to become available: %v", managerId, helpers.GraphError(lastErr))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func expandUserEmployeeOrgData(costCenter, division string) *userEmployeeOrgData {
+	return &userEmployeeOrgData{
+		CostCenter: utils.NullableString(costCenter),
+		Division:   utils.NullableString(division),
+	}
+}
+
+func flattenUserEmployeeOrgData(in *userEmployeeOrgData) (costCenter, division string) {
+	if in == nil {
+		return
+	}
+	if in.CostCenter != nil {
+		costCenter = string(*in.CostCenter)
+	}
+	if in.Division != nil {
+		division = string(*in.Division)
+	}
+	return
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/manicminer/hamilton/msgraph"
 
 	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers"
 	"github.com/hashicorp/terraform-provider-azuread/internal/tf"
 	"github.com/hashicorp/terraform-provider-azuread/internal/validate"
 )
@@ -32,12 +33,24 @@ func usersData() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"employee_ids": {
+				Description:  "The employee identifiers assigned to the users by the organisation",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"employee_ids", "mail_nicknames", "object_ids", "return_all", "user_principal_names"},
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validate.NoEmptyStrings,
+				},
+			},
+
 			"mail_nicknames": {
 				Description:  "The email aliases of the users",
 				Type:         schema.TypeList,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"object_ids", "user_principal_names", "mail_nicknames"},
+				ExactlyOneOf: []string{"employee_ids", "mail_nicknames", "object_ids", "return_all", "user_principal_names"},
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validate.NoEmptyStrings,
@@ -49,7 +62,7 @@ func usersData() *schema.Resource {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"object_ids", "user_principal_names", "mail_nicknames"},
+				ExactlyOneOf: []string{"employee_ids", "mail_nicknames", "object_ids", "return_all", "user_principal_names"},
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validate.UUID,
@@ -61,18 +74,36 @@ func usersData() *schema.Resource {
 				Type:         schema.TypeList,
 				Optional:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"object_ids", "user_principal_names", "mail_nicknames"},
+				ExactlyOneOf: []string{"employee_ids", "mail_nicknames", "object_ids", "return_all", "user_principal_names"},
 				Elem: &schema.Schema{
 					Type:             schema.TypeString,
 					ValidateDiagFunc: validate.NoEmptyStrings,
 				},
 			},
 
+			"return_all": {
+				Description:  "Fetch all users with no filter and return all that were found. The data source will still fail if no users are found",
+				Type:         schema.TypeBool,
+				Optional:     true,
+				ExactlyOneOf: []string{"employee_ids", "mail_nicknames", "object_ids", "return_all", "user_principal_names"},
+			},
+
+			"filter": {
+				Description:      "An OData filter expression to narrow the users returned when `return_all` is true, e.g. `startswith(userPrincipalName, 'j')`",
+				Type:             schema.TypeString,
+				Optional:         true,
+				RequiredWith:     []string{"return_all"},
+				ValidateDiagFunc: validate.ODataFilter,
+			},
+
+			"max_results": tf.DataSourceMaxResultsSchema("users", "return_all"),
+
 			"ignore_missing": {
-				Description: "Ignore missing users and return users that were found. The data source will still fail if no users are found",
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
+				Description:   "Ignore missing users and return users that were found. The data source will still fail if no users are found",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"return_all"},
 			},
 
 			"users": {
@@ -93,6 +124,12 @@ func usersData() *schema.Resource {
 							Computed:    true,
 						},
 
+						"employee_id": {
+							Description: "The employee identifier assigned to the user by the organisation",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+
 						"mail": {
 							Description: "The primary email address of the user",
 							Type:        schema.TypeString,
@@ -153,11 +190,32 @@ func usersDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 	var users []msgraph.User
 	var expectedCount int
 	ignoreMissing := d.Get("ignore_missing").(bool)
+	returnAll := d.Get("return_all").(bool)
+
+	var diags diag.Diagnostics
+	if returnAll {
+		filter := d.Get("filter").(string)
+		limit := tf.DataSourceListLimit(d)
+
+		result, err := userListAll(ctx, client, filter, limit)
+		if err != nil {
+			return tf.ErrorDiagF(err, "Could not retrieve users")
+		}
+		if len(result) == 0 && filter == "" {
+			return tf.ErrorDiagPathF(nil, "return_all", "No users found")
+		}
+		if len(result) > limit {
+			if diags = tf.DataSourceListExceeded(d, "users", limit); diags.HasError() {
+				return diags
+			}
+			result = result[:limit]
+		}
 
-	if upns, ok := d.Get("user_principal_names").([]interface{}); ok && len(upns) > 0 {
+		users = result
+	} else if upns, ok := d.Get("user_principal_names").([]interface{}); ok && len(upns) > 0 {
 		expectedCount = len(upns)
 		for _, v := range upns {
-			filter := fmt.Sprintf("userPrincipalName eq '%s'", v)
+			filter := helpers.ODataEq("userPrincipalName", v)
 			result, _, err := client.List(ctx, filter)
 			if err != nil {
 				return tf.ErrorDiagF(err, "Finding user with UPN: %q", v)
@@ -198,7 +256,7 @@ func usersDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		} else if mailNicknames, ok := d.Get("mail_nicknames").([]interface{}); ok && len(mailNicknames) > 0 {
 			expectedCount = len(mailNicknames)
 			for _, v := range mailNicknames {
-				filter := fmt.Sprintf("mailNickname eq '%s'", v)
+				filter := helpers.ODataEq("mailNickname", v)
 				result, _, err := client.List(ctx, filter)
 				if err != nil {
 					return tf.ErrorDiagF(err, "Finding user with email alias: %q", v)
@@ -218,16 +276,40 @@ func usersDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 				}
 				users = append(users, (*result)[0])
 			}
+		} else if employeeIds, ok := d.Get("employee_ids").([]interface{}); ok && len(employeeIds) > 0 {
+			expectedCount = len(employeeIds)
+			for _, v := range employeeIds {
+				filter := helpers.ODataEq("employeeId", v)
+				result, _, err := client.List(ctx, filter)
+				if err != nil {
+					return tf.ErrorDiagF(err, "Finding user with employee ID: %q", v)
+				}
+				if result == nil {
+					return tf.ErrorDiagF(errors.New("API returned nil result"), "Bad API Response")
+				}
+
+				count := len(*result)
+				if count > 1 {
+					return tf.ErrorDiagPathF(nil, "employee_ids", "More than one user found with employee ID: %q", v)
+				} else if count == 0 {
+					if ignoreMissing {
+						continue
+					}
+					return tf.ErrorDiagPathF(err, "employee_ids", "User not found with employee ID: %q", v)
+				}
+				users = append(users, (*result)[0])
+			}
 		}
 	}
 
-	if !ignoreMissing && len(users) != expectedCount {
+	if !returnAll && !ignoreMissing && len(users) != expectedCount {
 		return tf.ErrorDiagF(fmt.Errorf("Expected: %d, Actual: %d", expectedCount, len(users)), "Unexpected number of users returned")
 	}
 
 	upns := make([]string, 0)
 	objectIds := make([]string, 0)
 	mailNicknames := make([]string, 0)
+	employeeIds := make([]string, 0)
 	userList := make([]map[string]interface{}, 0)
 	for _, u := range users {
 		if u.ID == nil || u.UserPrincipalName == nil {
@@ -239,10 +321,14 @@ func usersDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 		if u.MailNickname != nil {
 			mailNicknames = append(mailNicknames, *u.MailNickname)
 		}
+		if u.EmployeeId != nil {
+			employeeIds = append(employeeIds, *u.EmployeeId)
+		}
 
 		user := make(map[string]interface{})
 		user["account_enabled"] = u.AccountEnabled
 		user["display_name"] = u.DisplayName
+		user["employee_id"] = u.EmployeeId
 		user["mail"] = u.Mail
 		user["mail_nickname"] = u.MailNickname
 		user["object_id"] = u.ID
@@ -256,15 +342,16 @@ func usersDataSourceRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 	// Generate a unique ID based on result
 	h := sha1.New()
-	if _, err := h.Write([]byte(strings.Join(upns, "/"))); err != nil {
+	if _, err := h.Write([]byte(d.Get("filter").(string) + "#" + strings.Join(upns, "/"))); err != nil {
 		return tf.ErrorDiagF(err, "Unable to compute hash for UPNs")
 	}
 
 	d.SetId("users#" + base64.URLEncoding.EncodeToString(h.Sum(nil)))
+	tf.Set(d, "employee_ids", employeeIds)
 	tf.Set(d, "mail_nicknames", mailNicknames)
 	tf.Set(d, "object_ids", objectIds)
 	tf.Set(d, "user_principal_names", upns)
 	tf.Set(d, "users", userList)
 
-	return nil
+	return diags
 }
@@ -2,6 +2,7 @@ package users_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -92,6 +93,55 @@ func TestAccUsersDataSource_byMailNicknamesIgnoreMissing(t *testing.T) {
 	}})
 }
 
+func TestAccUsersDataSource_byEmployeeIds(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_users", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UsersDataSource{}.byEmployeeIds(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("user_principal_names.#").HasValue("2"),
+			check.That(data.ResourceName).Key("object_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("employee_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("users.#").HasValue("2"),
+			check.That(data.ResourceName).Key("users.0.employee_id").HasValue(fmt.Sprintf("A-%s", data.RandomString)),
+		),
+	}})
+}
+
+func TestAccUsersDataSource_byEmployeeIdsIgnoreMissing(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_users", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UsersDataSource{}.byEmployeeIdsIgnoreMissing(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("employee_ids.#").HasValue("2"),
+			check.That(data.ResourceName).Key("users.#").HasValue("2"),
+		),
+	}})
+}
+
+func TestAccUsersDataSource_returnAll(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_users", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config: UsersDataSource{}.returnAll(data),
+		Check: resource.ComposeTestCheckFunc(
+			check.That(data.ResourceName).Key("object_ids.#").Exists(),
+			check.That(data.ResourceName).Key("user_principal_names.#").Exists(),
+			check.That(data.ResourceName).Key("users.#").Exists(),
+		),
+	}})
+}
+
+func TestAccUsersDataSource_returnAllWithNames(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azuread_users", "test")
+
+	data.DataSourceTest(t, []resource.TestStep{{
+		Config:      UsersDataSource{}.returnAllWithNames(data),
+		ExpectError: regexp.MustCompile("only one of `employee_ids,mail_nicknames,object_ids,return_all,user_principal_names`"),
+	}})
+}
+
 func TestAccUsersDataSource_noNames(t *testing.T) {
 	data := acceptance.BuildTestData(t, "data.azuread_users", "test")
 
@@ -185,6 +235,73 @@ data "azuread_users" "test" {
 `, UserResource{}.threeUsersABC(data), data.RandomInteger)
 }
 
+func (UsersDataSource) employeeUsers(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azuread" {}
+
+data "azuread_domains" "test" {
+  only_initial = true
+}
+
+resource "azuread_user" "testA" {
+  user_principal_name = "acctestUser.%[1]d.A@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-A"
+  employee_id         = "A-%[3]s"
+  password            = "%[2]s"
+}
+
+resource "azuread_user" "testB" {
+  user_principal_name = "acctestUser.%[1]d.B@${data.azuread_domains.test.domains.0.domain_name}"
+  display_name        = "acctestUser-%[1]d-B"
+  employee_id         = "B-%[3]s"
+  password            = "%[2]s"
+}
+`, data.RandomInteger, data.RandomPassword, data.RandomString)
+}
+
+func (r UsersDataSource) byEmployeeIds(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_users" "test" {
+  employee_ids = [azuread_user.testA.employee_id, azuread_user.testB.employee_id]
+}
+`, r.employeeUsers(data))
+}
+
+func (r UsersDataSource) byEmployeeIdsIgnoreMissing(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+data "azuread_users" "test" {
+  ignore_missing = true
+
+  employee_ids = [
+    azuread_user.testA.employee_id,
+    "C-%[2]s",
+    azuread_user.testB.employee_id,
+  ]
+}
+`, r.employeeUsers(data), data.RandomString)
+}
+
+func (UsersDataSource) returnAll(_ acceptance.TestData) string {
+	return `
+data "azuread_users" "test" {
+  return_all = true
+}
+`
+}
+
+func (UsersDataSource) returnAllWithNames(_ acceptance.TestData) string {
+	return `
+data "azuread_users" "test" {
+  return_all           = true
+  user_principal_names = ["someone@example.com"]
+}
+`
+}
+
 func (UsersDataSource) noNames() string {
 	return `
 data "azuread_users" "test" {
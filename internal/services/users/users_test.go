@@ -0,0 +1,602 @@
+package users
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/manicminer/hamilton/environments"
+	"github.com/manicminer/hamilton/msgraph"
+
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	domains "github.com/hashicorp/terraform-provider-azuread/internal/services/domains/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/users/client"
+	"github.com/hashicorp/terraform-provider-azuread/internal/utils"
+)
+
+func testUserIdentities() *[]client.ObjectIdentity {
+	return &[]client.ObjectIdentity{
+		{
+			Issuer:           utils.String("contoso.onmicrosoft.com"),
+			IssuerAssignedId: utils.String("jdoe@contoso.onmicrosoft.com"),
+			SignInType:       utils.String("userPrincipalName"),
+		},
+		{
+			Issuer:           utils.String("contoso.onmicrosoft.com"),
+			IssuerAssignedId: utils.String("jdoe@example.com"),
+			SignInType:       utils.String("emailAddress"),
+		},
+	}
+}
+
+func TestFlattenUserIdentities(t *testing.T) {
+	withoutUpn := flattenUserIdentities(testUserIdentities(), false)
+	if len(withoutUpn) != 1 {
+		t.Fatalf("expected 1 identity when excluding the user principal name, got %d", len(withoutUpn))
+	}
+	if v := withoutUpn[0].(map[string]interface{})["sign_in_type"]; v != "emailAddress" {
+		t.Fatalf("expected remaining identity to have sign_in_type %q, got %q", "emailAddress", v)
+	}
+
+	withUpn := flattenUserIdentities(testUserIdentities(), true)
+	if len(withUpn) != 2 {
+		t.Fatalf("expected 2 identities when including the user principal name, got %d", len(withUpn))
+	}
+
+	if result := flattenUserIdentities(nil, false); len(result) != 0 {
+		t.Fatalf("expected no identities for nil input, got %d", len(result))
+	}
+}
+
+func TestUserIdentitiesRetainingUserPrincipalName(t *testing.T) {
+	desired := []client.ObjectIdentity{
+		{
+			Issuer:           utils.String("contoso.onmicrosoft.com"),
+			IssuerAssignedId: utils.String("john.doe@example.com"),
+			SignInType:       utils.String("emailAddress"),
+		},
+	}
+
+	result := userIdentitiesRetainingUserPrincipalName(testUserIdentities(), desired)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 identities, got %d", len(result))
+	}
+	if *result[0].SignInType != "userPrincipalName" {
+		t.Fatalf("expected the user principal name identity to be retained, got %q", *result[0].SignInType)
+	}
+	if *result[1].IssuerAssignedId != "john.doe@example.com" {
+		t.Fatalf("expected the desired identity to be included, got %q", *result[1].IssuerAssignedId)
+	}
+}
+
+func TestUserResourceSelectProperties(t *testing.T) {
+	// Attributes which are not read from the user object, either because they are retrieved with separate requests or
+	// because they only exist in configuration
+	unselected := map[string]bool{
+		"block_sign_in_on_destroy":   true,
+		"force_password_change":      true,
+		"identities":                 true,
+		"manager_id":                 true,
+		"password":                   true,
+		"prevent_duplicate_names":    true,
+		"skip_upn_domain_validation": true,
+	}
+
+	properties := make(map[string]bool)
+	for _, userType := range []reflect.Type{reflect.TypeOf(msgraph.User{}), reflect.TypeOf(userWithProfile{})} {
+		for i := 0; i < userType.NumField(); i++ {
+			properties[strings.Split(userType.Field(i).Tag.Get("json"), ",")[0]] = true
+		}
+	}
+
+	resourceSchema := userResource().Schema
+	for attribute := range resourceSchema {
+		if _, ok := userResourceSelectProperties[attribute]; !ok && !unselected[attribute] {
+			t.Errorf("attribute %q is not selected when reading users, add it to userResourceSelectProperties", attribute)
+		}
+	}
+	for attribute, property := range userResourceSelectProperties {
+		if _, ok := resourceSchema[attribute]; !ok {
+			t.Errorf("attribute %q in userResourceSelectProperties is not in the resource schema", attribute)
+		}
+		if !properties[property] {
+			t.Errorf("property %q for attribute %q is not a property of msgraph.User or userWithProfile", property, attribute)
+		}
+	}
+}
+
+func TestUserWithProfileJSON(t *testing.T) {
+	user := userWithProfile{
+		User: msgraph.User{
+			ID:         utils.String("11111111-1111-1111-1111-111111111111"),
+			EmployeeId: utils.String("ignored"),
+		},
+		AgeGroup:        utils.NullableString("Adult"),
+		EmployeeId:      utils.NullableString(""),
+		EmployeeOrgData: expandUserEmployeeOrgData("CC-1234", ""),
+	}
+
+	body, err := json.Marshal(user)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, ok := payload["employeeId"]; !ok || v != nil {
+		t.Fatalf("expected employeeId to be cleared with null, got %v", payload)
+	}
+	if payload["ageGroup"] != "Adult" {
+		t.Fatalf("expected ageGroup to be set, got %v", payload)
+	}
+	if _, ok := payload["securityIdentifier"]; ok {
+		t.Fatalf("expected read-only securityIdentifier to be omitted, got %v", payload)
+	}
+	expectedOrgData := map[string]interface{}{"costCenter": "CC-1234", "division": nil}
+	if !reflect.DeepEqual(payload["employeeOrgData"], expectedOrgData) {
+		t.Fatalf("expected employeeOrgData %v, got %v", expectedOrgData, payload["employeeOrgData"])
+	}
+
+	var read userWithProfile
+	if err := json.Unmarshal([]byte(`{"id":"11111111-1111-1111-1111-111111111111","employeeId":"E123","businessPhones":["+1 555 0100"],"employeeOrgData":{"division":"Engineering","costCenter":null},"onPremisesSecurityIdentifier":null,"securityIdentifier":"S-1-12-1-1111111111-1111111111-1111111111-1111111111"}`), &read); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if read.EmployeeId == nil || *read.EmployeeId != "E123" {
+		t.Fatalf("expected employeeId to be read, got %v", read.EmployeeId)
+	}
+	if read.SecurityIdentifier == nil || *read.SecurityIdentifier != "S-1-12-1-1111111111-1111111111-1111111111-1111111111" {
+		t.Fatalf("expected securityIdentifier to be read, got %v", read.SecurityIdentifier)
+	}
+	if read.OnPremisesSecurityIdentifier != nil {
+		t.Fatalf("expected no onPremisesSecurityIdentifier for a cloud-only user, got %q", *read.OnPremisesSecurityIdentifier)
+	}
+	if read.BusinessPhones == nil || len(*read.BusinessPhones) != 1 {
+		t.Fatalf("expected businessPhones to be read, got %v", read.BusinessPhones)
+	}
+	if costCenter, division := flattenUserEmployeeOrgData(read.EmployeeOrgData); costCenter != "" || division != "Engineering" {
+		t.Fatalf("expected cost center %q and division %q, got %q and %q", "", "Engineering", costCenter, division)
+	}
+	if costCenter, division := flattenUserEmployeeOrgData(nil); costCenter != "" || division != "" {
+		t.Fatalf("expected empty values for nil employeeOrgData, got %q and %q", costCenter, division)
+	}
+}
+
+func TestUserValidateUpnDomain(t *testing.T) {
+	cases := []struct {
+		name            string
+		upn             string
+		listFails       bool
+		expectedError   bool
+		expectedWarning bool
+	}{
+		{
+			name: "verified",
+			upn:  "jdoe@Contoso.onmicrosoft.com",
+		},
+		{
+			name:          "unverified",
+			upn:           "jdoe@contoso.com",
+			expectedError: true,
+		},
+		{
+			name:          "unknown",
+			upn:           "jdoe@contoso.om",
+			expectedError: true,
+		},
+		{
+			name:            "domains unavailable",
+			upn:             "jdoe@contoso.om",
+			listFails:       true,
+			expectedWarning: true,
+		},
+		{
+			name:      "no domain",
+			upn:       "jdoe",
+			listFails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if tc.listFails {
+					w.WriteHeader(http.StatusForbidden)
+					fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+					return
+				}
+				fmt.Fprint(w, `{"value":[{"id":"contoso.onmicrosoft.com","isVerified":true},{"id":"contoso.com","isVerified":false}]}`)
+			}))
+			defer server.Close()
+
+			domainsClient := msgraph.NewDomainsClient("00000000-0000-0000-0000-000000000000")
+			domainsClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			domainsClient.BaseClient.DisableRetries = true
+			meta := &clients.Client{
+				Domains: &domains.Client{DomainsClient: domainsClient},
+			}
+
+			warning, err := userValidateUpnDomain(context.Background(), meta, tc.upn)
+			if tc.expectedError != (err != nil) {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedError, err)
+			}
+			if tc.expectedWarning != (warning != nil) {
+				t.Fatalf("expected warning: %t, got: %+v", tc.expectedWarning, warning)
+			}
+			if warning != nil && warning.Severity != diag.Warning {
+				t.Fatalf("expected a warning, got severity %v", warning.Severity)
+			}
+		})
+	}
+}
+
+func TestUserGetPhoto(t *testing.T) {
+	ctx := context.Background()
+	small := []byte{0xff, 0xd8, 0xff, 0xe0}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var content []byte
+		switch {
+		case strings.Contains(r.URL.Path, "/users/with-photo/"):
+			content = small
+		case strings.Contains(r.URL.Path, "/users/large-photo/"):
+			content = make([]byte, userPhotoMaxBytes+1)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"ImageNotFound","message":"The photo wasn't found."}}`)
+			return
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/photo/$value") {
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write(content)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id":"96X96","height":96,"width":96}`)
+	}))
+	defer server.Close()
+
+	photoClient := client.NewUserPhotoClient("00000000-0000-0000-0000-000000000000")
+	photoClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	photoClient.BaseClient.DisableRetries = true
+
+	t.Run("with photo", func(t *testing.T) {
+		photo, diags := userGetPhoto(ctx, photoClient, "with-photo")
+		if len(diags) > 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		expected := userPhoto{Content: base64.StdEncoding.EncodeToString(small), ContentType: "image/jpeg", Height: 96, Width: 96}
+		if photo != expected {
+			t.Fatalf("expected %+v, got %+v", expected, photo)
+		}
+	})
+
+	t.Run("without photo", func(t *testing.T) {
+		photo, diags := userGetPhoto(ctx, photoClient, "without-photo")
+		if len(diags) > 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if photo != (userPhoto{}) {
+			t.Fatalf("expected empty photo, got %+v", photo)
+		}
+	})
+
+	t.Run("large photo", func(t *testing.T) {
+		photo, diags := userGetPhoto(ctx, photoClient, "large-photo")
+		if len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Fatalf("expected a single warning, got: %v", diags)
+		}
+		expected := userPhoto{ContentType: "image/jpeg", Height: 96, Width: 96}
+		if photo != expected {
+			t.Fatalf("expected %+v, got %+v", expected, photo)
+		}
+	})
+}
+
+func TestUserListAll(t *testing.T) {
+	var server *httptest.Server
+	requests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if expected := strings.Join(usersDataSourceSelectProperties, ","); r.URL.Query().Get("$select") != expected {
+			t.Errorf("expected $select=%q, got %q", expected, r.URL.Query().Get("$select"))
+		}
+		if filter := r.URL.Query().Get("$filter"); filter != "accountEnabled eq true" {
+			t.Errorf("unexpected filter: %q", filter)
+		}
+
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(requests))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"@odata.nextLink":%q,"value":[{"id":"%[2]d1111111-1111-1111-1111-111111111111","userPrincipalName":"a%[2]d@example.com"},{"id":"%[2]d2222222-2222-2222-2222-222222222222","userPrincipalName":"b%[2]d@example.com"}]}`, server.URL+r.URL.Path+"?"+query.Encode(), requests)
+	}))
+	defer server.Close()
+
+	usersClient := msgraph.NewUsersClient("00000000-0000-0000-0000-000000000000")
+	usersClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	usersClient.BaseClient.DisableRetries = true
+
+	users, err := userListAll(context.Background(), usersClient, "accountEnabled eq true", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(users) != 6 {
+		t.Fatalf("expected one more than the limit of 5 users, got %d", len(users))
+	}
+	if requests != 3 {
+		t.Fatalf("expected paging to stop after 3 requests, got %d", requests)
+	}
+}
+
+func TestUserUpdateManager(t *testing.T) {
+	const (
+		tenantPrefix = "/v1.0/00000000-0000-0000-0000-000000000000"
+		managerId    = "22222222-2222-2222-2222-222222222222"
+	)
+	ctx := context.Background()
+
+	requests := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, tenantPrefix)
+		requests = append(requests, r.Method+" "+path)
+
+		switch {
+		case r.Method == http.MethodPut && path == "/users/with-manager/manager/$ref":
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decoding request body: %v", err)
+			}
+			if !strings.HasSuffix(body["@odata.id"], "/v1.0/directoryObjects/"+managerId) {
+				t.Errorf("unexpected manager reference: %q", body["@odata.id"])
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && path == "/users/with-manager/manager/$ref":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && path == "/users/with-manager/manager":
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"id":%q}`, managerId)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource 'manager' does not exist or one of its queried reference-property objects are not present."}}`)
+		}
+	}))
+	defer server.Close()
+
+	managerClient := client.NewUserManagerClient("00000000-0000-0000-0000-000000000000")
+	managerClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+	managerClient.BaseClient.DisableRetries = true
+
+	if diags := userUpdateManager(ctx, managerClient, "with-manager", managerId); diags.HasError() {
+		t.Fatalf("unexpected error assigning manager: %v", diags)
+	}
+	if diags := userUpdateManager(ctx, managerClient, "with-manager", ""); diags.HasError() {
+		t.Fatalf("unexpected error removing manager: %v", diags)
+	}
+	if diags := userUpdateManager(ctx, managerClient, "without-manager", ""); diags.HasError() {
+		t.Fatalf("expected removing a missing manager to succeed, got: %v", diags)
+	}
+
+	expected := []string{
+		"PUT /users/with-manager/manager/$ref",
+		"DELETE /users/with-manager/manager/$ref",
+		"DELETE /users/without-manager/manager/$ref",
+	}
+	if !reflect.DeepEqual(requests, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+
+	id, _, err := managerClient.Get(ctx, "with-manager")
+	if err != nil || id == nil || *id != managerId {
+		t.Fatalf("expected manager %q, got %v (err: %v)", managerId, id, err)
+	}
+	if _, status, err := managerClient.Get(ctx, "without-manager"); err == nil || status != http.StatusNotFound {
+		t.Fatalf("expected a 404 for a user without a manager, got status %d (err: %v)", status, err)
+	}
+}
+
+func TestUserAssignManagerRetries(t *testing.T) {
+	const managerId = "22222222-2222-2222-2222-222222222222"
+
+	cases := []struct {
+		name        string
+		notFound    int
+		timeout     time.Duration
+		expectError bool
+	}{
+		{
+			name:     "manager replicated after retries",
+			notFound: 2,
+			timeout:  time.Minute,
+		},
+		{
+			name:        "manager never found",
+			notFound:    -1,
+			timeout:     time.Second,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				if tc.notFound < 0 || attempts <= tc.notFound {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusNotFound)
+					fmt.Fprint(w, `{"error":{"code":"Request_ResourceNotFound","message":"Resource '22222222-2222-2222-2222-222222222222' does not exist or one of its queried reference-property objects are not present."}}`)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			managerClient := client.NewUserManagerClient("00000000-0000-0000-0000-000000000000")
+			managerClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			managerClient.BaseClient.DisableRetries = true
+
+			ctx, cancel := context.WithTimeout(context.Background(), tc.timeout)
+			defer cancel()
+
+			err := userAssignManager(ctx, managerClient, "11111111-1111-1111-1111-111111111111", managerId)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error to be %t, got: %v", tc.expectError, err)
+			}
+			if tc.expectError {
+				if !strings.Contains(err.Error(), "does not exist") {
+					t.Fatalf("expected the last API error to be reported, got: %v", err)
+				}
+			} else if attempts != tc.notFound+1 {
+				t.Fatalf("expected %d attempts, got %d", tc.notFound+1, attempts)
+			}
+		})
+	}
+}
+
+func TestUserBlockSignIn(t *testing.T) {
+	const userId = "11111111-1111-1111-1111-111111111111"
+
+	cases := []struct {
+		name          string
+		disableStatus int
+		revokeStatus  int
+		expectRevoked bool
+		expectError   bool
+		expectWarning bool
+	}{
+		{
+			name:          "disabled and revoked",
+			disableStatus: http.StatusNoContent,
+			revokeStatus:  http.StatusOK,
+			expectRevoked: true,
+		},
+		{
+			name:          "revoke fails",
+			disableStatus: http.StatusNoContent,
+			revokeStatus:  http.StatusForbidden,
+			expectRevoked: true,
+			expectWarning: true,
+		},
+		{
+			name:          "disable fails",
+			disableStatus: http.StatusForbidden,
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var disabled, revoked bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := http.StatusNotFound
+				switch {
+				case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/users/"+userId):
+					var body map[string]interface{}
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decoding request body: %v", err)
+					}
+					if body["accountEnabled"] != false {
+						t.Errorf("expected accountEnabled to be false, got body: %v", body)
+					}
+					disabled = true
+					status = tc.disableStatus
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users/"+userId+"/revokeSignInSessions"):
+					revoked = true
+					status = tc.revokeStatus
+				case r.Method == http.MethodDelete:
+					t.Errorf("unexpected request to delete user: %s", r.URL.Path)
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				}
+				switch status {
+				case http.StatusOK:
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(status)
+					fmt.Fprint(w, `{"value":true}`)
+				case http.StatusForbidden:
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(status)
+					fmt.Fprint(w, `{"error":{"code":"Authorization_RequestDenied","message":"Insufficient privileges to complete the operation."}}`)
+				default:
+					w.WriteHeader(status)
+				}
+			}))
+			defer server.Close()
+
+			usersClient := msgraph.NewUsersClient("00000000-0000-0000-0000-000000000000")
+			usersClient.BaseClient.Endpoint = environments.ApiEndpoint(server.URL)
+			usersClient.BaseClient.DisableRetries = true
+
+			diags := userBlockSignIn(context.Background(), usersClient, userId)
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got diagnostics: %v", tc.expectError, diags)
+			}
+			if hasWarning := len(diags) > 0 && diags[0].Severity == diag.Warning; hasWarning != tc.expectWarning {
+				t.Fatalf("expected warning: %t, got diagnostics: %v", tc.expectWarning, diags)
+			}
+			if !disabled {
+				t.Fatalf("expected the user to be disabled")
+			}
+			if revoked != tc.expectRevoked {
+				t.Fatalf("expected revoked to be %t, got %t", tc.expectRevoked, revoked)
+			}
+		})
+	}
+}
+
+func TestExpandInvitedUserMessageInfo(t *testing.T) {
+	if result := expandInvitedUserMessageInfo(nil); result != nil {
+		t.Fatalf("expected nil message info for nil input, got %+v", result)
+	}
+	if result := expandInvitedUserMessageInfo([]interface{}{nil}); result != nil {
+		t.Fatalf("expected nil message info for empty block, got %+v", result)
+	}
+
+	result := expandInvitedUserMessageInfo([]interface{}{
+		map[string]interface{}{
+			"additional_recipients": []interface{}{"jdoe@example.com"},
+			"body":                  "Welcome aboard",
+			"language":              "",
+		},
+	})
+	expected := &msgraph.InvitedUserMessageInfo{
+		CCRecipients: &[]msgraph.Recipient{
+			{
+				EmailAddress: &msgraph.EmailAddress{
+					Address: utils.String("jdoe@example.com"),
+				},
+			},
+		},
+		CustomizedMessageBody: utils.String("Welcome aboard"),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("unexpected message info, expected %+v, got %+v", expected, result)
+	}
+
+	result = expandInvitedUserMessageInfo([]interface{}{
+		map[string]interface{}{
+			"additional_recipients": []interface{}{},
+			"body":                  "",
+			"language":              "fr-CA",
+		},
+	})
+	expected = &msgraph.InvitedUserMessageInfo{
+		MessageLanguage: utils.String("fr-CA"),
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("unexpected message info, expected %+v, got %+v", expected, result)
+	}
+}
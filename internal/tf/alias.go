@@ -0,0 +1,51 @@
+package tf
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetAliasedString returns the value of a string argument which can be specified using either its name or an alias,
+// along with the name of the argument from which the value was taken. An error is returned when both are specified
+// with different values.
+func GetAliasedString(d *schema.ResourceData, key, alias string) (string, string, error) {
+	value := d.Get(key).(string)
+	aliasValue := d.Get(alias).(string)
+
+	switch {
+	case value != "" && aliasValue != "" && value != aliasValue:
+		return "", "", fmt.Errorf("`%s` (%q) and `%s` (%q) must have the same value when both are specified", key, value, alias, aliasValue)
+	case value == "" && aliasValue != "":
+		return aliasValue, alias, nil
+	}
+
+	return value, key, nil
+}
+
+// AliasedStringCustomizeDiff returns a CustomizeDiffFunc which keeps a string argument and its alias in agreement.
+// Both must be Optional and Computed in the schema, and should be declared with ExactlyOneOf, since the configured values
+// cannot be inspected here: an unchanged argument cannot be distinguished from a value previously computed from the
+// other one. When only one of them changes, the other is therefore planned to follow it.
+func AliasedStringCustomizeDiff(key, alias string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		keyChanged, aliasChanged := diff.HasChange(key), diff.HasChange(alias)
+
+		switch {
+		case keyChanged && !aliasChanged:
+			return aliasedStringFollow(diff, alias, diff.Get(key).(string))
+		case aliasChanged && !keyChanged:
+			return aliasedStringFollow(diff, key, diff.Get(alias).(string))
+		}
+
+		return nil
+	}
+}
+
+func aliasedStringFollow(diff *schema.ResourceDiff, key, value string) error {
+	if value == "" {
+		return diff.SetNewComputed(key)
+	}
+	return diff.SetNew(key, value)
+}
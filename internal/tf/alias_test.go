@@ -0,0 +1,158 @@
+package tf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func aliasTestSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"application_id": {Type: schema.TypeString, Optional: true, Computed: true, ForceNew: true, ExactlyOneOf: []string{"application_id", "client_id"}},
+		"client_id":      {Type: schema.TypeString, Optional: true, Computed: true, ForceNew: true, ExactlyOneOf: []string{"application_id", "client_id"}},
+	}
+}
+
+func TestGetAliasedString(t *testing.T) {
+	cases := []struct {
+		name          string
+		config        map[string]interface{}
+		expectedValue string
+		expectedKey   string
+		expectError   bool
+	}{
+		{name: "neither", config: map[string]interface{}{}, expectedValue: "", expectedKey: "application_id"},
+		{name: "key", config: map[string]interface{}{"application_id": "foo"}, expectedValue: "foo", expectedKey: "application_id"},
+		{name: "alias", config: map[string]interface{}{"client_id": "foo"}, expectedValue: "foo", expectedKey: "client_id"},
+		{name: "both matching", config: map[string]interface{}{"application_id": "foo", "client_id": "foo"}, expectedValue: "foo", expectedKey: "application_id"},
+		{name: "both differing", config: map[string]interface{}{"application_id": "foo", "client_id": "bar"}, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, aliasTestSchema(), c.config)
+			value, key, err := GetAliasedString(d, "application_id", "client_id")
+			if c.expectError {
+				if err == nil {
+					t.Fatalf("expected an error, got value %q", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != c.expectedValue || key != c.expectedKey {
+				t.Fatalf("expected %q from %q, got %q from %q", c.expectedValue, c.expectedKey, value, key)
+			}
+		})
+	}
+}
+
+func TestAliasedStringCustomizeDiff(t *testing.T) {
+	r := &schema.Resource{
+		Schema:        aliasTestSchema(),
+		CustomizeDiff: AliasedStringCustomizeDiff("application_id", "client_id"),
+	}
+
+	existing := &terraform.InstanceState{
+		ID: "11111111-1111-1111-1111-111111111111",
+		Attributes: map[string]string{
+			"id":             "11111111-1111-1111-1111-111111111111",
+			"application_id": "foo",
+			"client_id":      "foo",
+		},
+	}
+
+	cases := []struct {
+		name         string
+		state        *terraform.InstanceState
+		config       map[string]interface{}
+		expected     map[string]string
+		expectNoDiff bool
+	}{
+		{
+			name:     "create with key",
+			config:   map[string]interface{}{"application_id": "foo"},
+			expected: map[string]string{"application_id": "foo", "client_id": "foo"},
+		},
+		{
+			name:     "create with alias",
+			config:   map[string]interface{}{"client_id": "foo"},
+			expected: map[string]string{"application_id": "foo", "client_id": "foo"},
+		},
+		{
+			name:         "unchanged switching to alias",
+			state:        existing,
+			config:       map[string]interface{}{"client_id": "foo"},
+			expectNoDiff: true,
+		},
+		{
+			name:     "key changed",
+			state:    existing,
+			config:   map[string]interface{}{"application_id": "bar"},
+			expected: map[string]string{"application_id": "bar", "client_id": "bar"},
+		},
+		{
+			name:     "alias changed",
+			state:    existing,
+			config:   map[string]interface{}{"client_id": "bar"},
+			expected: map[string]string{"application_id": "bar", "client_id": "bar"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if diags := r.Validate(terraform.NewResourceConfigRaw(c.config)); diags.HasError() {
+				t.Fatalf("unexpected validation error: %+v", diags)
+			}
+			diff, err := r.Diff(context.Background(), c.state, terraform.NewResourceConfigRaw(c.config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if c.expectNoDiff {
+				if diff != nil && len(diff.Attributes) > 0 {
+					t.Fatalf("expected no diff, got: %#v", diff.Attributes)
+				}
+				return
+			}
+			if diff == nil {
+				t.Fatalf("expected a diff, got nil")
+			}
+			for k, v := range c.expected {
+				attr, ok := diff.Attributes[k]
+				if !ok {
+					t.Fatalf("expected a diff for %q", k)
+				}
+				if attr.New != v || attr.NewComputed {
+					t.Fatalf("expected %q to be planned as %q, got %q (computed: %t)", k, v, attr.New, attr.NewComputed)
+				}
+			}
+		})
+	}
+}
+
+func TestAliasedStringValidate(t *testing.T) {
+	r := &schema.Resource{Schema: aliasTestSchema()}
+
+	cases := []struct {
+		name        string
+		config      map[string]interface{}
+		expectError bool
+	}{
+		{name: "key", config: map[string]interface{}{"application_id": "foo"}},
+		{name: "alias", config: map[string]interface{}{"client_id": "foo"}},
+		{name: "neither", config: map[string]interface{}{}, expectError: true},
+		{name: "both matching", config: map[string]interface{}{"application_id": "foo", "client_id": "foo"}, expectError: true},
+		{name: "both differing", config: map[string]interface{}{"application_id": "foo", "client_id": "bar"}, expectError: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if diags := r.Validate(terraform.NewResourceConfigRaw(c.config)); diags.HasError() != c.expectError {
+				t.Fatalf("expected validation error: %t, got: %+v", c.expectError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tf
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataSourceListSafetyCap is the maximum number of objects returned by data sources which list objects, when
+// `max_results` is not specified. Listing a very large tenant in full can exhaust memory or time out, so finding more
+// objects than this is an error, asking for the listing to be narrowed with `filter` or bounded with `max_results`.
+const DataSourceListSafetyCap = 100000
+
+// DataSourceFailIfNotFoundSchema returns the schema for the `fail_if_not_found` argument of data sources which support
+// optional lookups. Use together with DataSourceFoundSchema and DataSourceNotFound.
+func DataSourceFailIfNotFoundSchema(objectType string) *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether to fail when no " + objectType + " is found. When `false`, the `found` attribute indicates whether the " + objectType + " exists",
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+	}
+}
+
+// DataSourceFoundSchema returns the schema for the `found` attribute of data sources which support optional lookups
+func DataSourceFoundSchema(objectType string) *schema.Schema {
+	return &schema.Schema{
+		Description: "Whether the " + objectType + " was found. Always `true` unless `fail_if_not_found` is `false`",
+		Type:        schema.TypeBool,
+		Computed:    true,
+	}
+}
+
+// DataSourceNotFound should be called when a data source lookup matches no objects, where attr and value are the
+// argument used for the lookup and its value. When `fail_if_not_found` is true, the provided diagnostics are returned.
+// Otherwise, the data source is given an ID derived from the lookup and `found` is set to false, leaving all other
+// computed attributes unset.
+func DataSourceNotFound(d *schema.ResourceData, attr, value string, diags diag.Diagnostics) diag.Diagnostics {
+	if d.Get("fail_if_not_found").(bool) {
+		return diags
+	}
+
+	log.Printf("[DEBUG] No object found with %s %q, and `fail_if_not_found` is false", attr, value)
+	d.SetId(fmt.Sprintf("%s/%s", attr, value))
+	return Set(d, "found", false)
+}
+
+// DataSourceMaxResultsSchema returns the schema for the `max_results` argument of data sources which list objects. Use
+// together with DataSourceListLimit and DataSourceListExceeded.
+func DataSourceMaxResultsSchema(objectTypePlural string, requiredWith ...string) *schema.Schema {
+	return &schema.Schema{
+		Description:  fmt.Sprintf("The maximum number of %[1]s to return. When more %[1]s are found, the results are truncated and a warning is emitted. When not specified, an error is returned if more than %[2]d %[1]s are found", objectTypePlural, DataSourceListSafetyCap),
+		Type:         schema.TypeInt,
+		Optional:     true,
+		RequiredWith: requiredWith,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+}
+
+// DataSourceListLimit returns the maximum number of objects a data source should list, which is the value of
+// `max_results` when specified, or DataSourceListSafetyCap otherwise
+func DataSourceListLimit(d *schema.ResourceData) int {
+	if v, ok := d.GetOk("max_results"); ok && v.(int) > 0 {
+		return v.(int)
+	}
+	return DataSourceListSafetyCap
+}
+
+// DataSourceListExceeded should be called when a data source found more objects than the limit returned by
+// DataSourceListLimit. When `max_results` is specified, a warning is returned and the caller should return the first
+// `max_results` objects. Otherwise, an error is returned explaining how to narrow or bound the listing.
+func DataSourceListExceeded(d *schema.ResourceData, objectTypePlural string, limit int) diag.Diagnostics {
+	if _, ok := d.GetOk("max_results"); ok {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity:      diag.Warning,
+			Summary:       fmt.Sprintf("Results truncated to %d %s", limit, objectTypePlural),
+			Detail:        fmt.Sprintf("More than %[1]d %[2]s were found, so only the first %[1]d have been returned. Specify a `filter`, or increase `max_results`, to return all the matching %[2]s", limit, objectTypePlural),
+			AttributePath: cty.Path{cty.GetAttrStep{Name: "max_results"}},
+		}}
+	}
+
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Result too large: more than %d %s found", limit, objectTypePlural),
+		Detail:   fmt.Sprintf("Listing more than %d %s is not supported without an explicit limit. Use `filter` to narrow the results, or `max_results` to bound them", limit, objectTypePlural),
+	}}
+}
@@ -0,0 +1,72 @@
+package tf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceNotFound(t *testing.T) {
+	dataSourceSchema := map[string]*schema.Schema{
+		"display_name":      {Type: schema.TypeString, Optional: true},
+		"fail_if_not_found": DataSourceFailIfNotFoundSchema("widget"),
+		"found":             DataSourceFoundSchema("widget"),
+	}
+	notFound := ErrorDiagF(nil, "Widget not found")
+
+	t.Run("fail", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{"display_name": "foo"})
+		diags := DataSourceNotFound(d, "display_name", "foo", notFound)
+		if !diags.HasError() || diags[0].Summary != "Widget not found" {
+			t.Fatalf("expected not found error, got: %v", diags)
+		}
+		if d.Id() != "" {
+			t.Fatalf("expected empty ID, got %q", d.Id())
+		}
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{"display_name": "foo", "fail_if_not_found": false})
+		if diags := DataSourceNotFound(d, "display_name", "foo", notFound); diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if expected := "display_name/foo"; d.Id() != expected {
+			t.Fatalf("expected ID %q, got %q", expected, d.Id())
+		}
+		if found, ok := d.GetOk("found"); ok || found.(bool) {
+			t.Fatalf("expected found to be false")
+		}
+	})
+}
+
+func TestDataSourceListExceeded(t *testing.T) {
+	dataSourceSchema := map[string]*schema.Schema{
+		"max_results": DataSourceMaxResultsSchema("widgets"),
+	}
+
+	t.Run("safety cap", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{})
+		limit := DataSourceListLimit(d)
+		if limit != DataSourceListSafetyCap {
+			t.Fatalf("expected limit to be the safety cap of %d, got %d", DataSourceListSafetyCap, limit)
+		}
+		diags := DataSourceListExceeded(d, "widgets", limit)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "Result too large") {
+			t.Fatalf("expected a result too large error, got: %v", diags)
+		}
+	})
+
+	t.Run("max results", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourceSchema, map[string]interface{}{"max_results": 10})
+		limit := DataSourceListLimit(d)
+		if limit != 10 {
+			t.Fatalf("expected limit to be max_results of 10, got %d", limit)
+		}
+		diags := DataSourceListExceeded(d, "widgets", limit)
+		if len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Fatalf("expected a truncation warning, got: %v", diags)
+		}
+	})
+}
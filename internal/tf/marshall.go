@@ -1,5 +1,7 @@
 package tf
 
+import "sort"
+
 func ExpandStringSlice(input []interface{}) []string {
 	result := make([]string, 0)
 	for _, item := range input {
@@ -28,3 +30,15 @@ func FlattenStringSlicePtr(input *[]string) []interface{} {
 	}
 	return result
 }
+
+// SortedStringSlice returns a sorted copy of the input, or an empty slice when the input is nil. Collections such as
+// group members are not returned by the API in a stable order, so they should be sorted before being written to list
+// attributes in order to avoid spurious differences between refreshes. Sets are unordered, so need not be sorted.
+func SortedStringSlice(input *[]string) []string {
+	result := make([]string, 0)
+	if input != nil {
+		result = append(result, *input...)
+	}
+	sort.Strings(result)
+	return result
+}
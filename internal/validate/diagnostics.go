@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// invalidValueDiagnostic returns an error diagnostic which names the offending value and where it was found, so that a
+// bad element can be identified in a large list or set. The attribute path is retained so that Terraform can highlight
+// the element in configuration.
+func invalidValueDiagnostic(path cty.Path, value string, summary string, detail string) diag.Diagnostic {
+	if value != "" {
+		summary = fmt.Sprintf("%s, got %q", summary, value)
+	}
+
+	if location := pathString(path); location != "" {
+		if detail == "" {
+			detail = fmt.Sprintf("Invalid value %q for `%s`", value, location)
+		} else {
+			detail = fmt.Sprintf("Invalid value %q for `%s`: %s", value, location, detail)
+		}
+	}
+
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       summary,
+		Detail:        detail,
+		AttributePath: path,
+	}
+}
+
+// pathString returns a readable representation of a cty.Path, with list and set elements shown by their index, for
+// example `web.0.redirect_uris.2`
+func pathString(path cty.Path) string {
+	parts := make([]string, 0, len(path))
+
+	for _, step := range path {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			parts = append(parts, s.Name)
+		case cty.IndexStep:
+			if s.Key.IsNull() || !s.Key.IsKnown() {
+				continue
+			}
+			switch s.Key.Type() {
+			case cty.Number:
+				parts = append(parts, s.Key.AsBigFloat().Text('f', -1))
+			case cty.String:
+				parts = append(parts, s.Key.AsString())
+			}
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,98 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestPathString(t *testing.T) {
+	cases := []struct {
+		Path     cty.Path
+		Expected string
+	}{
+		{
+			Path:     cty.Path{},
+			Expected: "",
+		},
+		{
+			Path:     cty.GetAttrPath("display_name"),
+			Expected: "display_name",
+		},
+		{
+			Path:     cty.GetAttrPath("members").IndexInt(3),
+			Expected: "members.3",
+		},
+		{
+			Path:     cty.GetAttrPath("web").IndexInt(0).GetAttr("redirect_uris").IndexInt(2),
+			Expected: "web.0.redirect_uris.2",
+		},
+		{
+			Path:     cty.GetAttrPath("tags").IndexString("foo"),
+			Expected: "tags.foo",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Expected, func(t *testing.T) {
+			if result := pathString(tc.Path); result != tc.Expected {
+				t.Fatalf("Expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestInvalidValueDiagnostics(t *testing.T) {
+	path := cty.GetAttrPath("web").IndexInt(0).GetAttr("redirect_uris").IndexInt(2)
+
+	cases := []struct {
+		TestName string
+		Func     schema.SchemaValidateDiagFunc
+	}{
+		{
+			TestName: "Duration",
+			Func:     Duration,
+		},
+		{
+			TestName: "EmailAddress",
+			Func:     StringIsEmailAddress,
+		},
+		{
+			TestName: "URI",
+			Func:     IsHTTPOrHTTPSURL,
+		},
+		{
+			TestName: "UUID",
+			Func:     UUID,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := tc.Func("10 days", path)
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+			if !strings.Contains(diags[0].Summary, `"10 days"`) {
+				t.Fatalf("Expected summary to contain the invalid value, got %q", diags[0].Summary)
+			}
+			if !strings.Contains(diags[0].Detail, "`web.0.redirect_uris.2`") {
+				t.Fatalf("Expected detail to contain the attribute path, got %q", diags[0].Detail)
+			}
+		})
+	}
+}
+
+func TestInvalidValueDiagnosticAttributePath(t *testing.T) {
+	path := cty.GetAttrPath("members").IndexInt(4)
+
+	diags := UUID("not-a-uuid", path)
+	if len(diags) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+	}
+	if !diags[0].AttributePath.Equals(path) {
+		t.Fatalf("Expected attribute path %#v, got %#v", path, diags[0].AttributePath)
+	}
+}
@@ -0,0 +1,33 @@
+package validate
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Duration validates that the given string is a non-negative duration, e.g. `30s` or `5m`
+func Duration(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must be a valid duration, e.g. `30s` or `5m`", err.Error()))
+		return
+	}
+
+	if d < 0 {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must not be a negative duration", ""))
+	}
+
+	return
+}
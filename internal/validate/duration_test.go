@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestDuration(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    "0s",
+			TestName: "Zero",
+			ErrCount: 0,
+		},
+		{
+			Value:    "30s",
+			TestName: "Seconds",
+			ErrCount: 0,
+		},
+		{
+			Value:    "1m30s",
+			TestName: "MinutesAndSeconds",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    "30",
+			TestName: "NoUnit",
+			ErrCount: 1,
+		},
+		{
+			Value:    "-5s",
+			TestName: "Negative",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := Duration(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected Duration to have %d not %d errors for %q", tc.ErrCount, len(diags), tc.Value)
+			}
+		})
+	}
+}
@@ -24,11 +24,7 @@ func NoEmptyStrings(i interface{}, path cty.Path) (ret diag.Diagnostics) {
 	}
 
 	if strings.TrimSpace(v) == "" {
-		ret = append(ret, diag.Diagnostic{
-			Severity:      diag.Error,
-			Summary:       "Value must not be empty",
-			AttributePath: path,
-		})
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must not be empty", ""))
 	}
 
 	return
@@ -47,20 +43,92 @@ func StringIsEmailAddress(i interface{}, path cty.Path) (ret diag.Diagnostics) {
 	}
 
 	if strings.TrimSpace(v) == "" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must not be empty", ""))
+		return
+	}
+
+	regExIsEmailAddress := regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
+	if !regExIsEmailAddress.MatchString(v) {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must be a valid email address", ""))
+	}
+
+	return
+}
+
+// MailNickname validates that the given string is a valid mail alias for a Microsoft 365 group or user. Mail aliases
+// must be no longer than 64 characters, may only contain ASCII characters, and cannot contain spaces or any of the
+// characters @ ( ) \ [ ] " ; : < > ,
+func MailNickname(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
 		ret = append(ret, diag.Diagnostic{
 			Severity:      diag.Error,
-			Summary:       "Value must not be empty",
+			Summary:       "Expected a string value",
 			AttributePath: path,
 		})
+		return
 	}
 
-	regExIsEmailAddress := regexp.MustCompile("^[a-zA-Z0-9.!#$%&'*+/=?^_`{|}~-]+@[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(?:\\.[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$")
-	if !regExIsEmailAddress.MatchString(v) {
+	if strings.TrimSpace(v) == "" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must not be empty", ""))
+		return
+	}
+
+	if len(v) > 64 {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must be no longer than 64 characters", ""))
+	}
+
+	for _, c := range v {
+		if c > 127 {
+			ret = append(ret, invalidValueDiagnostic(path, v, "Value must only contain ASCII characters", ""))
+			break
+		}
+	}
+
+	if strings.ContainsAny(v, " @()\\[]\";:<>,") {
+		ret = append(ret, invalidValueDiagnostic(path, v, `Value must not contain spaces or any of the characters @ ( ) \ [ ] " ; : < > ,`, ""))
+	}
+
+	return
+}
+
+// ODataFilter validates that the given string is plausibly an OData filter expression which can be passed through to
+// the API. String literals must be terminated, with any single quotes inside them escaped by doubling, so that a value
+// cannot end a literal early and alter the expression. Control characters are not permitted, and neither are ampersands
+// outside of string literals, since these would attempt to add other query parameters.
+func ODataFilter(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
 		ret = append(ret, diag.Diagnostic{
 			Severity:      diag.Error,
-			Summary:       "Value must be a valid email address",
+			Summary:       "Expected a string value",
 			AttributePath: path,
 		})
+		return
+	}
+
+	if strings.TrimSpace(v) == "" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must not be empty", ""))
+		return
+	}
+
+	inLiteral := false
+	for _, c := range v {
+		switch {
+		case c < 0x20 || c == 0x7f:
+			ret = append(ret, invalidValueDiagnostic(path, v, "Value must not contain control characters", ""))
+			return
+		case c == '\'':
+			// An escaped quote inside a literal is seen as the literal ending and immediately starting again
+			inLiteral = !inLiteral
+		case c == '&' && !inLiteral:
+			ret = append(ret, invalidValueDiagnostic(path, v, "Value must not contain `&` outside of a string literal", "The value should be a filter expression only, without any other query parameters"))
+			return
+		}
+	}
+
+	if inLiteral {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value contains an unterminated string literal", "Single quotes inside string literals must be escaped by doubling them, e.g. `displayName eq 'O''Brien'`"))
 	}
 
 	return
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -133,3 +134,121 @@ func TestStringIsEmailAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestMailNickname(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    "engineering-team",
+			TestName: "Valid",
+			ErrCount: 0,
+		},
+		{
+			Value:    "j.doe_99",
+			TestName: "ValidPunctuation",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    "engineering team",
+			TestName: "Space",
+			ErrCount: 1,
+		},
+		{
+			Value:    "team@hashicorp.com",
+			TestName: "AtChar",
+			ErrCount: 1,
+		},
+		{
+			Value:    "team(one)",
+			TestName: "Parentheses",
+			ErrCount: 1,
+		},
+		{
+			Value:    "équipe",
+			TestName: "NonASCII",
+			ErrCount: 1,
+		},
+		{
+			Value:    strings.Repeat("a", 64),
+			TestName: "MaxLength",
+			ErrCount: 0,
+		},
+		{
+			Value:    strings.Repeat("a", 65),
+			TestName: "TooLong",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := MailNickname(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected MailNickname to have %d not %d errors for %q", tc.ErrCount, len(diags), tc.TestName)
+			}
+		})
+	}
+}
+
+func TestODataFilter(t *testing.T) {
+	cases := []struct {
+		Value    string
+		TestName string
+		ErrCount int
+	}{
+		{
+			Value:    "startswith(displayName, 'eng')",
+			TestName: "Valid",
+			ErrCount: 0,
+		},
+		{
+			Value:    "displayName eq 'O''Brien & Co'",
+			TestName: "ValidEscapedQuoteAndAmpersand",
+			ErrCount: 0,
+		},
+		{
+			Value:    "",
+			TestName: "Empty",
+			ErrCount: 1,
+		},
+		{
+			Value:    "displayName eq 'O'Brien'",
+			TestName: "UnescapedQuote",
+			ErrCount: 1,
+		},
+		{
+			Value:    "displayName eq 'eng",
+			TestName: "UnterminatedLiteral",
+			ErrCount: 1,
+		},
+		{
+			Value:    "accountEnabled eq true&$top=999",
+			TestName: "QueryParameter",
+			ErrCount: 1,
+		},
+		{
+			Value:    "accountEnabled eq true\nor true",
+			TestName: "ControlCharacter",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.TestName, func(t *testing.T) {
+			diags := ODataFilter(tc.Value, cty.Path{})
+
+			if len(diags) != tc.ErrCount {
+				t.Fatalf("Expected ODataFilter to have %d not %d errors for %q", tc.ErrCount, len(diags), tc.TestName)
+			}
+		})
+	}
+}
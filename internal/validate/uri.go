@@ -22,6 +22,74 @@ func IsAppURI(i interface{}, path cty.Path) diag.Diagnostics {
 	return IsURI([]string{"http", "https", "api", "ms-appx"}, true)(i, path)
 }
 
+// IsHTTPSOrLocalhostURL validates that the given string is an HTTPS URL, or an HTTP URL for localhost, as required for
+// the redirect URIs of a single-page application
+func IsHTTPSOrLocalhostURL(i interface{}, path cty.Path) diag.Diagnostics {
+	if diags := IsHTTPOrHTTPSURL(i, path); diags.HasError() {
+		return diags
+	}
+
+	var ret diag.Diagnostics
+	if u, _ := url.Parse(i.(string)); u.Scheme == "http" && u.Hostname() != "localhost" {
+		ret = append(ret, invalidValueDiagnostic(path, i.(string), "Expected URL to have a schema of https, or http for localhost", ""))
+	}
+	return ret
+}
+
+// IsPublicClientRedirectURI validates that the given string is a URI suitable for a public client redirect URI. Native
+// and mobile applications commonly use custom schemes such as `msal{clientId}://auth`, so any scheme is permitted except
+// for `http`, which is only supported for localhost
+func IsPublicClientRedirectURI(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	v, ok := i.(string)
+	if !ok {
+		ret = append(ret, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Expected a string value",
+			AttributePath: path,
+		})
+		return
+	}
+
+	if v == "" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "URI must not be empty", ""))
+		return
+	}
+
+	u, err := url.Parse(v)
+	if err != nil {
+		ret = append(ret, invalidValueDiagnostic(path, v, "URI is in an invalid format", err.Error()))
+		return
+	}
+
+	if u.Scheme == "" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "URI has no scheme", ""))
+		return
+	}
+
+	if u.Scheme == "http" && u.Hostname() != "localhost" {
+		ret = append(ret, invalidValueDiagnostic(path, v, "Expected URI to have a schema other than http, unless it is for localhost", ""))
+	}
+
+	return
+}
+
+// logoutURLMaxLength is the maximum length of a front-channel logout URL for an application
+const logoutURLMaxLength = 255
+
+// IsLogoutURL validates that the given string is an HTTPS URL no longer than 255 characters, as required for the
+// front-channel logout URL of an application
+func IsLogoutURL(i interface{}, path cty.Path) diag.Diagnostics {
+	if diags := IsHTTPSURL(i, path); diags.HasError() {
+		return diags
+	}
+
+	var ret diag.Diagnostics
+	if v := i.(string); len(v) > logoutURLMaxLength {
+		ret = append(ret, invalidValueDiagnostic(path, v, fmt.Sprintf("URL must not be longer than %d characters", logoutURLMaxLength), fmt.Sprintf("The URL is %d characters long", len(v))))
+	}
+	return ret
+}
+
 func IsURI(validURLSchemes []string, URNAllowed bool) schema.SchemaValidateDiagFunc {
 	return func(i interface{}, path cty.Path) (ret diag.Diagnostics) {
 		v, ok := i.(string)
@@ -35,11 +103,7 @@ func IsURI(validURLSchemes []string, URNAllowed bool) schema.SchemaValidateDiagF
 		}
 
 		if v == "" {
-			ret = append(ret, diag.Diagnostic{
-				Severity:      diag.Error,
-				Summary:       "URL must not be empty",
-				AttributePath: path,
-			})
+			ret = append(ret, invalidValueDiagnostic(path, v, "URL must not be empty", ""))
 			return
 		}
 
@@ -52,21 +116,12 @@ func IsURI(validURLSchemes []string, URNAllowed bool) schema.SchemaValidateDiagF
 
 		u, err := url.Parse(v)
 		if err != nil {
-			ret = append(ret, diag.Diagnostic{
-				Severity:      diag.Error,
-				Summary:       "URL is in an invalid format",
-				Detail:        err.Error(),
-				AttributePath: path,
-			})
+			ret = append(ret, invalidValueDiagnostic(path, v, "URL is in an invalid format", err.Error()))
 			return
 		}
 
 		if u.Host == "" {
-			ret = append(ret, diag.Diagnostic{
-				Severity:      diag.Error,
-				Summary:       "URL has no host",
-				AttributePath: path,
-			})
+			ret = append(ret, invalidValueDiagnostic(path, v, "URL has no host", ""))
 			return
 		}
 
@@ -76,11 +131,7 @@ func IsURI(validURLSchemes []string, URNAllowed bool) schema.SchemaValidateDiagF
 			}
 		}
 
-		ret = append(ret, diag.Diagnostic{
-			Severity:      diag.Error,
-			Summary:       fmt.Sprintf("Expected URL to have a schema of: %s", strings.Join(validURLSchemes, ", ")),
-			AttributePath: path,
-		})
+		ret = append(ret, invalidValueDiagnostic(path, v, fmt.Sprintf("Expected URL to have a schema of: %s", strings.Join(validURLSchemes, ", ")), ""))
 		return
 	}
 }
@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -147,3 +148,129 @@ func TestIsAppURI(t *testing.T) {
 		})
 	}
 }
+
+func TestIsHTTPSOrLocalhostURL(t *testing.T) {
+	cases := []struct {
+		Url    string
+		Errors int
+	}{
+		{
+			Url:    "",
+			Errors: 1,
+		},
+		{
+			Url:    "ftp://www.example.com",
+			Errors: 1,
+		},
+		{
+			Url:    "http://www.example.com",
+			Errors: 1,
+		},
+		{
+			Url:    "http://localhost.example.com",
+			Errors: 1,
+		},
+		{
+			Url:    "http://localhost:3000/callback",
+			Errors: 0,
+		},
+		{
+			Url:    "https://www.example.com",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Url, func(t *testing.T) {
+			diags := IsHTTPSOrLocalhostURL(tc.Url, cty.Path{})
+
+			if len(diags) != tc.Errors {
+				t.Fatalf("Expected IsHTTPSOrLocalhostURL to have %d not %d errors for %q", tc.Errors, len(diags), tc.Url)
+			}
+		})
+	}
+}
+
+func TestIsPublicClientRedirectURI(t *testing.T) {
+	cases := []struct {
+		Url    string
+		Errors int
+	}{
+		{
+			Url:    "",
+			Errors: 1,
+		},
+		{
+			Url:    "this is not a url",
+			Errors: 1,
+		},
+		{
+			Url:    "http://www.example.com",
+			Errors: 1,
+		},
+		{
+			Url:    "http://localhost:8080",
+			Errors: 0,
+		},
+		{
+			Url:    "https://login.microsoftonline.com/common/oauth2/nativeclient",
+			Errors: 0,
+		},
+		{
+			Url:    "msal00000000-0000-0000-0000-000000000000://auth",
+			Errors: 0,
+		},
+		{
+			Url:    "ms-appx-web://microsoft.aad.brokerplugin/00000000-0000-0000-0000-000000000000",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Url, func(t *testing.T) {
+			diags := IsPublicClientRedirectURI(tc.Url, cty.Path{})
+
+			if len(diags) != tc.Errors {
+				t.Fatalf("Expected IsPublicClientRedirectURI to have %d not %d errors for %q", tc.Errors, len(diags), tc.Url)
+			}
+		})
+	}
+}
+
+func TestIsLogoutURL(t *testing.T) {
+	cases := []struct {
+		Url    string
+		Errors int
+	}{
+		{
+			Url:    "",
+			Errors: 1,
+		},
+		{
+			Url:    "http://www.example.com/logout",
+			Errors: 1,
+		},
+		{
+			Url:    "https://www.example.com/logout",
+			Errors: 0,
+		},
+		{
+			Url:    "https://www.example.com/" + strings.Repeat("a", 231),
+			Errors: 0,
+		},
+		{
+			Url:    "https://www.example.com/" + strings.Repeat("a", 232),
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Url, func(t *testing.T) {
+			diags := IsLogoutURL(tc.Url, cty.Path{})
+
+			if len(diags) != tc.Errors {
+				t.Fatalf("Expected IsLogoutURL to have %d not %d errors for %q", tc.Errors, len(diags), tc.Url)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-uuid"
@@ -22,11 +23,24 @@ func UUID(i interface{}, path cty.Path) (ret diag.Diagnostics) {
 	}
 
 	if _, err := uuid.ParseUUID(v); err != nil {
-		ret = append(ret, diag.Diagnostic{
-			Severity:      diag.Error,
-			Summary:       "Value must be a valid UUID",
-			AttributePath: path,
-		})
+		ret = append(ret, invalidValueDiagnostic(path, v, "Value must be a valid UUID", ""))
+	}
+
+	return
+}
+
+// UUIDOrCurrent is like UUID, but additionally accepts the value `current`, which resources resolve to the object ID of
+// the authenticated principal
+func UUIDOrCurrent(i interface{}, path cty.Path) (ret diag.Diagnostics) {
+	if v, ok := i.(string); ok && strings.EqualFold(v, "current") {
+		return
+	}
+
+	if diags := UUID(i, path); diags.HasError() {
+		if v, ok := i.(string); ok {
+			return diag.Diagnostics{invalidValueDiagnostic(path, v, `Value must be a valid UUID or "current"`, "")}
+		}
+		return diags
 	}
 
 	return
@@ -39,3 +39,37 @@ func TestUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestUUIDOrCurrent(t *testing.T) {
+	cases := []struct {
+		Input  string
+		Errors int
+	}{
+		{
+			Input:  "",
+			Errors: 1,
+		},
+		{
+			Input:  "currently",
+			Errors: 1,
+		},
+		{
+			Input:  "current",
+			Errors: 0,
+		},
+		{
+			Input:  "00000000-0000-0000-0000-000000000000",
+			Errors: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Input, func(t *testing.T) {
+			diags := UUIDOrCurrent(tc.Input, cty.Path{})
+
+			if len(diags) != tc.Errors {
+				t.Fatalf("Expected UUIDOrCurrent to have %d not %d errors for %q", tc.Errors, len(diags), tc.Input)
+			}
+		})
+	}
+}